@@ -0,0 +1,31 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cms
+
+//
+// swagger:operation GET /metrics Metrics GetMetrics
+// ---
+// description: |
+//   GetMetrics returns the operational metrics of the service in the Prometheus text exposition format. The API
+//   does not require authentication so that it can be scraped by Prometheus. Along with the Go runtime and process
+//   metrics, the following metrics are reported
+//     - isecl_http_request_duration_seconds: histogram of the request latency, labelled by method and route
+//     - isecl_http_responses_total: number of responses, labelled by method, route and status code
+//
+//   The routes are reported as path templates, the resource IDs are not part of the metrics.
+//
+// produces:
+//   - text/plain
+// responses:
+//   '200':
+//     description: Successfully retrieved the metrics.
+//     content: text/plain
+//
+// x-sample-call-endpoint: https://cms.com:8445/cms/v1/metrics
+// x-sample-call-output: |
+//   # HELP isecl_http_responses_total Number of HTTP responses by route and status code.
+//   # TYPE isecl_http_responses_total counter
+//   isecl_http_responses_total{code="200",method="GET",route="/cms/v1/version"} 3
@@ -0,0 +1,366 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+
+// AttestationSchedule request/response payload
+// swagger:parameters AttestationSchedule
+type AttestationSchedule struct {
+	// in:body
+	Body hvs.AttestationSchedule
+}
+
+// AttestationScheduleCollection response payload
+// swagger:parameters AttestationScheduleCollection
+type AttestationScheduleCollection struct {
+	// in:body
+	Body hvs.AttestationScheduleCollection
+}
+
+// ScheduledAttestationCollection response payload
+// swagger:parameters ScheduledAttestationCollection
+type ScheduledAttestationCollection struct {
+	// in:body
+	Body hvs.ScheduledAttestationCollection
+}
+
+// ---
+
+// swagger:operation POST /attestation-schedules Attestation-Schedules Create-AttestationSchedule
+// ---
+//
+// description: |
+//   Sets how often the hosts linked to a flavorgroup are re-attested. By default the report refresher (HRRS) attests
+//   a host again when its report expires. The hosts linked to a flavorgroup with a schedule are also attested once
+//   the interval of the schedule has elapsed since their latest report, so that an interval longer than the validity
+//   of the reports never leaves a host with an expired report. A host linked to several scheduled flavorgroups
+//   follows the shortest interval.
+//
+//   The report refresher checks the schedules every refresh period, or every shortest interval of the schedules when
+//   it is shorter. A flavorgroup has at most one schedule. Deleting the flavorgroup deletes its schedule.
+//
+//    | Attribute      | Description |
+//    |----------------|-------------|
+//    | flavorgroup_id | ID of the flavorgroup. |
+//    | interval       | Interval between two attestations of a host, at least one minute, e.g. "5m" or "24h". |
+//
+// x-permissions: attestation_schedules:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/AttestationSchedule"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '201':
+//     description: Successfully created the attestation schedule.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/AttestationSchedule"
+//   '400':
+//     description: Invalid request body provided, the flavorgroup does not exist or already has a schedule
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/attestation-schedules
+// x-sample-call-input: |
+//    {
+//        "flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//        "interval": "5m"
+//    }
+// x-sample-call-output: |
+//    {
+//        "id": "3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c",
+//        "flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//        "interval": "5m",
+//        "created": "2022-03-01T10:00:00.000000Z"
+//    }
+
+// ---
+
+// swagger:operation GET /attestation-schedules Attestation-Schedules Search-AttestationSchedules
+// ---
+//
+// description: |
+//   Searches the attestation schedules.
+//
+//   Returns - The serialized AttestationScheduleCollection Go struct object that was retrieved.
+// x-permissions: attestation_schedules:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: flavorgroupId
+//   description: Only the schedule of this flavorgroup is returned.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the attestation schedules.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/AttestationScheduleCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/attestation-schedules?flavorgroupId=ee37c360-7eae-4250-a677-6ee12adce8e2
+// x-sample-call-output: |
+//    {
+//        "attestation_schedules": [
+//            {
+//                "id": "3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c",
+//                "flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//                "interval": "5m",
+//                "created": "2022-03-01T10:00:00.000000Z"
+//            }
+//        ]
+//    }
+
+// ---
+
+// swagger:operation GET /attestation-schedules/{schedule_id} Attestation-Schedules Retrieve-AttestationSchedule
+// ---
+//
+// description: |
+//   Retrieves an attestation schedule.
+//
+//   Returns - The serialized AttestationSchedule Go struct object that was retrieved.
+// x-permissions: attestation_schedules:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: schedule_id
+//   description: Unique ID of the attestation schedule.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the attestation schedule.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/AttestationSchedule"
+//   '404':
+//     description: Attestation schedule with given ID does not exist
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/attestation-schedules/3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c
+// x-sample-call-output: |
+//    {
+//        "id": "3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c",
+//        "flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//        "interval": "5m",
+//        "created": "2022-03-01T10:00:00.000000Z"
+//    }
+
+// ---
+
+// swagger:operation PUT /attestation-schedules/{schedule_id} Attestation-Schedules Update-AttestationSchedule
+// ---
+//
+// description: |
+//   Updates the interval of an attestation schedule. The flavorgroup of a schedule cannot be changed.
+//
+//   Returns - The serialized AttestationSchedule Go struct object that was updated.
+// x-permissions: attestation_schedules:store
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: schedule_id
+//   description: Unique ID of the attestation schedule.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/AttestationSchedule"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully updated the attestation schedule.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/AttestationSchedule"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: Attestation schedule with given ID does not exist
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/attestation-schedules/3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c
+// x-sample-call-input: |
+//    {
+//        "interval": "24h"
+//    }
+// x-sample-call-output: |
+//    {
+//        "id": "3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c",
+//        "flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//        "interval": "24h",
+//        "created": "2022-03-01T10:00:00.000000Z"
+//    }
+
+// ---
+
+// swagger:operation DELETE /attestation-schedules/{schedule_id} Attestation-Schedules Delete-AttestationSchedule
+// ---
+//
+// description: |
+//   Deletes an attestation schedule. The hosts of the flavorgroup are then attested again when their reports expire.
+// x-permissions: attestation_schedules:delete
+// security:
+//  - bearerAuth: []
+// parameters:
+// - name: schedule_id
+//   description: Unique ID of the attestation schedule.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// responses:
+//   '204':
+//     description: Successfully deleted the attestation schedule.
+//   '404':
+//     description: Attestation schedule with given ID does not exist
+//   '500':
+//     description: Internal server error
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/attestation-schedules/3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c
+
+// ---
+
+// swagger:operation GET /attestation-schedules/{schedule_id}/hosts Attestation-Schedules Search-ScheduledAttestations
+// ---
+//
+// description: |
+//   Retrieves the next attestation of each host linked to the flavorgroup of the schedule, earliest first. A host
+//   linked to several scheduled flavorgroups follows the schedule with the shortest interval, which is returned in
+//   schedule_id. The next run is the end of the interval, or the refresh of the report before it expires when that is
+//   earlier. A next run in the past means that the host is attested on the next refresh cycle.
+//
+//   Returns - The serialized ScheduledAttestationCollection Go struct object that was retrieved.
+// x-permissions: attestation_schedules:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: schedule_id
+//   description: Unique ID of the attestation schedule.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the scheduled attestations.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/ScheduledAttestationCollection"
+//   '404':
+//     description: Attestation schedule with given ID does not exist
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/attestation-schedules/3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c/hosts
+// x-sample-call-output: |
+//    {
+//        "scheduled_attestations": [
+//            {
+//                "host_id": "fb2b8d4c-6ef5-4c1e-a1a5-9c0c1d2e3f4a",
+//                "schedule_id": "3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c",
+//                "interval": "5m",
+//                "last_attestation": "2022-03-01T10:02:00.000000Z",
+//                "next_run": "2022-03-01T10:07:00.000000Z"
+//            }
+//        ]
+//    }
@@ -0,0 +1,149 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+
+// AuditLogEntryCollection response payload
+// swagger:parameters AuditLogEntryCollection
+type AuditLogEntryCollection struct {
+	// in:body
+	Body hvs.AuditLogEntryCollection
+}
+
+// ---
+
+// swagger:operation GET /audit-logs AuditLogs Search-AuditLogs
+// ---
+//
+// description: |
+//   Audit log entries record every create, update and delete of audited HVS entities such as reports and host status.
+//   Each entry contains the columns of the entity at the time of the change and marks the columns that were updated.
+//   Entries are searched across all the rotated audit log partitions.
+//
+//   Returns - The serialized AuditLogEntryCollection Go struct object that was retrieved.
+// x-permissions: audit_logs:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: entityId
+//   description: ID of the audited entity.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: entityType
+//   description: Type of the audited entity, for example report or host_status.
+//   in: query
+//   type: string
+//   required: false
+// - name: action
+//   description: Action that was audited.
+//   in: query
+//   type: string
+//   required: false
+//   enum: [create, update, delete]
+// - name: fromDate
+//   description: |
+//     Only entries created on or after this date are returned. The date can be in one of the formats (YYYY-MM-DD)|(YYYY-MM-DD hh:mm:ss)|(YYYY-MM-DDThh:mm:ss.000Z)|(YYYY-MM-DDThh:mm:ss.000000Z)
+//   in: query
+//   type: string
+//   required: false
+// - name: toDate
+//   description: |
+//     Only entries created before this date are returned. The date can be in one of the formats (YYYY-MM-DD)|(YYYY-MM-DD hh:mm:ss)|(YYYY-MM-DDThh:mm:ss.000Z)|(YYYY-MM-DDThh:mm:ss.000000Z)
+//   in: query
+//   type: string
+//   required: false
+// - name: limit
+//   description: Limit of the number of items in a page.
+//   in: query
+//   type: integer
+//   required: false
+//   default: 1000
+// - name: afterId
+//   description: Next row id after which db must be queried
+//   in: query
+//   type: integer
+//   required: false
+// - name: orderBy
+//   description: Orders the audit log entry collection in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//   in: query
+//   type: string
+//   enum:
+//      - asc
+//      - desc
+//   required: false
+// - name: sortBy
+//   description: Field the collection is sorted by, ties are ordered by creation. Accepted values are "created", "entity_type", "action".
+//   in: query
+//   type: string
+//   enum:
+//      - created
+//      - entity_type
+//      - action
+//   required: false
+// - name: cursor
+//   description: Opaque cursor returned in the next link of a sorted page, it carries the sort field, the order and the position of the next page. It cannot be combined with afterId, sortBy or orderBy.
+//   in: query
+//   type: string
+//   required: false
+// - name: includeTotal
+//   description: Returns the total number of records matching the search criteria when true, the records are not counted by default.
+//   in: query
+//   type: boolean
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the audit log entries.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/AuditLogEntryCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/audit-logs?entityType=report&action=create&limit=1
+// x-sample-call-output: |
+//    {
+//        "audit_logs": [
+//            {
+//                "id": "1bb9c8b6-2a47-4b7a-9a3f-0b3e3a9c1f01",
+//                "entity_id": "15701f03-7b1d-461c-8e1d-8f1d8a3b9c20",
+//                "entity_type": "report",
+//                "created": "2022-03-01T10:00:00.000000Z",
+//                "action": "create",
+//                "data": {
+//                    "columns": [
+//                        {
+//                            "name": "id",
+//                            "value": "15701f03-7b1d-461c-8e1d-8f1d8a3b9c20",
+//                            "is_updated": false
+//                        },
+//                        {
+//                            "name": "host_id",
+//                            "value": "b8e1e8a6-9e4e-4c6f-8b87-4d4c2b3a1f10",
+//                            "is_updated": false
+//                        }
+//                    ]
+//                }
+//            }
+//        ],
+//        "next": "limit=1&afterId=1"
+//    }
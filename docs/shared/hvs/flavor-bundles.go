@@ -0,0 +1,225 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+
+// SignedFlavorBundle request/response payload
+// swagger:parameters SignedFlavorBundle
+type SignedFlavorBundle struct {
+	// in:body
+	Body hvs.SignedFlavorBundle
+}
+
+// FlavorBundleImportReport response payload
+// swagger:parameters FlavorBundleImportReport
+type FlavorBundleImportReport struct {
+	// in:body
+	Body hvs.FlavorBundleImportReport
+}
+
+// ---
+
+// swagger:operation GET /flavors/export Flavors Export-Flavors
+// ---
+//
+// description: |
+//   Exports the flavors matching the filter criteria as a signed flavor bundle. Along with the signed flavors the
+//   bundle contains the flavor groups the flavors belong to, with their flavor match policies and policy rules, and
+//   the flavor templates linked to these flavor groups. The bundle is signed with the flavor signing key of the HVS
+//   and carries the flavor signing certificate chain. HOST_UNIQUE and ASSET_TAG flavors are never exported.
+//
+//   At least one of the filter criteria must be provided.
+//
+// x-permissions: flavors:export
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   description: Flavor ID, can be repeated
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: flavorgroupId
+//   description: Flavor group ID
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: flavorParts
+//   description: Flavor part, can be repeated
+//   in: query
+//   type: string
+//   required: false
+//   enum: [PLATFORM, OS, SOFTWARE, IMA]
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully exported the flavors.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/SignedFlavorBundle"
+//   '400':
+//     description: Invalid filter criteria provided
+//   '404':
+//     description: No flavors found to export
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/export?flavorgroupId=ee37c360-7eae-4250-a677-6ee12adce8e2
+// x-sample-call-output: |
+//    {
+//        "bundle": {
+//            "created": "2022-06-01T10:22:14.417526Z",
+//            "signed_flavors": [
+//                {
+//                    "flavor": {
+//                        "meta": {
+//                            "id": "890a1ee2-4d0a-4bdb-b4c2-9d1e1b1f0e7c",
+//                            "description": {
+//                                "flavor_part": "PLATFORM",
+//                                "label": "INTEL_IntelCorporation_SE5C620.86B.00.01.0014.070920180847_TXT_TPM_06-16-2020",
+//                                "tpm_version": "2.0"
+//                            }
+//                        },
+//                        "pcrs": []
+//                    },
+//                    "signature": "EyuFK0QbFRFdhrlH8M1WV..."
+//                }
+//            ],
+//            "flavorgroups": [
+//                {
+//                    "id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//                    "name": "automatic",
+//                    "flavorIds": ["890a1ee2-4d0a-4bdb-b4c2-9d1e1b1f0e7c"],
+//                    "flavorTemplateIds": ["426912bd-39b0-4daa-ad21-0c6933230b50"],
+//                    "flavor_match_policies": [
+//                        {
+//                            "flavor_part": "PLATFORM",
+//                            "match_policy": {
+//                                "match_type": "ANY_OF",
+//                                "required": "REQUIRED"
+//                            }
+//                        }
+//                    ]
+//                }
+//            ],
+//            "flavor_templates": [
+//                {
+//                    "id": "426912bd-39b0-4daa-ad21-0c6933230b50",
+//                    "label": "default-uefi",
+//                    "condition": ["//host_info/os_name//*[text()='RedHatEnterprise']"],
+//                    "flavor_parts": {}
+//                }
+//            ]
+//        },
+//        "signature": "Vd6t2fUOh8cX6kP3lR0c...",
+//        "signing_certificate": "-----BEGIN CERTIFICATE-----\nMIIEoDCCAwigAwIBAgIBBDANBgkqhkiG9w0BAQ0FADBQMQswCQYDVQQGEwJVUzEL\n...\n-----END CERTIFICATE-----\n"
+//    }
+
+// ---
+
+// swagger:operation POST /flavors/import Flavors Import-Flavors
+// ---
+//
+// description: |
+//   Imports a signed flavor bundle exported by another HVS. The bundle signing certificate must be issued by the
+//   flavor signing CA of this HVS, which is the issuer in the chain of its configured flavor signing certificate, and
+//   must have the digital signature and content commitment key usages. The bundle and each of its flavors must be
+//   signed with it. The whole import is stored in a single transaction. The flavors are signed again with the flavor signing key of this HVS before they are stored. Flavor groups are
+//   matched by name, flavor templates and flavors by ID. Items identical to the existing data are reported as
+//   existing and left untouched.
+//
+//   When an item of the bundle conflicts with the existing data (a flavor or flavor template with the same ID and a
+//   different content, a flavor with the same label, a flavor group with the same name and different match policies
+//   or policy rules, or a deleted flavor template) nothing is imported and the conflicts are reported with status
+//   409. With dryRun set to true the import report is returned without storing anything.
+//
+//   The hosts of the flavor groups that received new flavors are queued for flavor verification.
+//
+// x-permissions: flavors:import
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/SignedFlavorBundle"
+// - name: dryRun
+//   description: Only report what would be imported
+//   in: query
+//   type: boolean
+//   required: false
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully imported the flavor bundle.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorBundleImportReport"
+//   '400':
+//     description: Invalid request body provided or flavor bundle verification failed
+//   '409':
+//     description: The flavor bundle conflicts with existing data, nothing was imported
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorBundleImportReport"
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/import
+// x-sample-call-input: |
+//    {
+//        "bundle": {
+//            "created": "2022-06-01T10:22:14.417526Z",
+//            "signed_flavors": [ ... ],
+//            "flavorgroups": [ ... ],
+//            "flavor_templates": [ ... ]
+//        },
+//        "signature": "Vd6t2fUOh8cX6kP3lR0c...",
+//        "signing_certificate": "-----BEGIN CERTIFICATE-----\n...\n-----END CERTIFICATE-----\n"
+//    }
+// x-sample-call-output: |
+//    {
+//        "dry_run": false,
+//        "imported_flavors": ["890a1ee2-4d0a-4bdb-b4c2-9d1e1b1f0e7c"],
+//        "existing_flavorgroups": ["automatic"],
+//        "existing_flavor_templates": ["426912bd-39b0-4daa-ad21-0c6933230b50"]
+//    }
@@ -0,0 +1,127 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+
+// FlavorImpactAnalysis response payload
+// swagger:parameters FlavorImpactAnalysis
+type FlavorImpactAnalysis struct {
+	// in:body
+	Body hvs.FlavorImpactAnalysis
+}
+
+// ---
+
+// swagger:operation POST /rpc/flavor-impact-analysis Flavors Analyze-Flavor-Impact
+// ---
+//
+// description: |
+//   Evaluates candidate flavors against the latest host manifests of the hosts linked to a flavor group, without
+//   storing the flavors or any report. For every host the response contains the result of each candidate flavor,
+//   the current trust status and the trust status predicted as per the flavor match policy of the flavor group.
+//   Hosts that are not in CONNECTED state are reported as not evaluated.
+//
+//   The serialized FlavorCreateRequest Go struct object represents the content of the request body. Flavors must be
+//   provided as flavor content, connection_string is not supported. At most one flavor group name can be given,
+//   if none is provided the automatic flavor group is used. partial_flavor_types restricts the candidate flavors
+//   to the given flavor types.
+//
+// x-permissions: flavors:analyze
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorCreateRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully analyzed the impact of the candidate flavors.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorImpactAnalysis"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/rpc/flavor-impact-analysis
+// x-sample-call-input: |
+//    {
+//        "flavor_collection": {
+//            "flavors": [
+//                {
+//                    "flavor": {
+//                        "meta": {
+//                            "description": {
+//                                "flavor_part": "SOFTWARE",
+//                                "label": "ISecL_Default_Application_Flavor_v2.0"
+//                            }
+//                        },
+//                        "software": {
+//                            "measurements": {},
+//                            "cumulative_hash": "0b5d2a8b3d4f7a9c1e6f2d3b4a5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c"
+//                        }
+//                    }
+//                }
+//            ]
+//        },
+//        "flavorgroup_names": ["automatic"]
+//    }
+// x-sample-call-output: |
+//    {
+//        "flavorgroup_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//        "flavorgroup_name": "automatic",
+//        "host_count": 1,
+//        "trust_changed_count": 1,
+//        "not_evaluated_count": 0,
+//        "hosts": [
+//            {
+//                "host_id": "e57e5ea0-d465-461e-882d-1600090caa0d",
+//                "host_name": "host-1",
+//                "current_trusted": true,
+//                "predicted_trusted": false,
+//                "trust_changed": true,
+//                "flavor_results": [
+//                    {
+//                        "flavor_id": "890a1ee2-4d0a-4bdb-b4c2-9d1e1b1f0e7c",
+//                        "label": "ISecL_Default_Application_Flavor_v2.0",
+//                        "flavor_part": "SOFTWARE",
+//                        "trusted": false,
+//                        "faults": [
+//                            {
+//                                "fault_name": "com.intel.mtwilson.core.verifier.policy.fault.XmlMeasurementValueMismatch",
+//                                "description": "Host XML measurement log final hash with value '...' does not match the expected value '...'"
+//                            }
+//                        ]
+//                    }
+//                ]
+//            }
+//        ]
+//    }
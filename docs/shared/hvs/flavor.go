@@ -31,6 +31,20 @@ type SignedFlavorCollection struct {
 	Body hvs.SignedFlavorCollection
 }
 
+// Flavor update API request payload
+// swagger:parameters FlavorUpdateRequest
+type FlavorUpdateRequest struct {
+	// in:body
+	Body hvs.Flavors
+}
+
+// Flavor revisions API response payload
+// swagger:parameters FlavorRevisionCollection
+type FlavorRevisionCollection struct {
+	// in:body
+	Body hvs.FlavorRevisionCollection
+}
+
 // ---
 //
 // swagger:operation GET /flavors Flavors Search-Flavors
@@ -90,6 +104,33 @@ type SignedFlavorCollection struct {
 //   in: query
 //   type: integer
 //   required: false
+// - name: orderBy
+//   description: Orders the flavor collection in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//   in: query
+//   type: string
+//   enum:
+//      - asc
+//      - desc
+//   required: false
+// - name: sortBy
+//   description: Field the collection is sorted by, ties are ordered by creation. Accepted values are "created_at", "label", "flavor_part".
+//   in: query
+//   type: string
+//   enum:
+//      - created_at
+//      - label
+//      - flavor_part
+//   required: false
+// - name: cursor
+//   description: Opaque cursor returned in the next link of a sorted page, it carries the sort field, the order and the position of the next page. It cannot be combined with afterId, sortBy or orderBy.
+//   in: query
+//   type: string
+//   required: false
+// - name: includeTotal
+//   description: Returns the total number of records matching the search criteria when true, the records are not counted by default.
+//   in: query
+//   type: boolean
+//   required: false
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -575,3 +616,199 @@ type SignedFlavorCollection struct {
 // x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/f66ac31d-124d-418e-8200-2abf414a9adf
 
 // ---
+
+// swagger:operation PUT /flavors/{flavor_id} Flavors Update-Flavor
+// ---
+//
+// description: |
+//   Updates a flavor by creating a new signed revision of it. The flavor keeps its ID and flavorgroup
+//   associations, the earlier revision is retained and can be retrieved using the revisions API.
+//   The new revision is considered the latest flavor for the LATEST match policy, hosts associated with the
+//   flavor are added to the flavor verification queue.
+//   The flavor part of the flavor cannot be changed.
+//
+//   Returns - The serialized Signed Flavor Go struct object that was updated.
+// x-permissions: flavors:store
+// security:
+//  - bearerAuth: []
+// consumes:
+// - application/json
+// produces:
+// - application/json
+// parameters:
+// - name: flavor_id
+//   description: Unique UUID of the flavor.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/Flavors"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully updated the flavor.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/SignedFlavor"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: No flavor with the provided flavor ID found.
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/f66ac31d-124d-418e-8200-2abf414a9adf
+// x-sample-call-input: |
+//  {
+//    "flavor": {
+//        "meta": {
+//            "schema": {
+//                "uri": "lib:wml:measurements:1.0"
+//            },
+//            "id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//            "description": {
+//                "flavor_part": "SOFTWARE",
+//                "label": "ISL_Applications124",
+//                "digest_algorithm": "SHA384"
+//            }
+//        },
+//        "software": {
+//            "measurements": {
+//                "opt-trustagent-bin": {
+//                    "type": "directoryMeasurementType",
+//                    "value": "3519466d871c395ce1f5b073a4a3847b6b8f0b3e495337daa0474f967aeecd48f699df29a4d106288f3b0d1705ecef75",
+//                    "Path": "/opt/trustagent/bin",
+//                    "Include": ".*"
+//                }
+//            },
+//            "cumulative_hash": "a5d7b1e3f0c94b2e8d6a1c7f3e9b5d2a4c8e6f0b1d3a5c7e9f2b4d6a8c0e1f3a5b7c9d2e4f6a8b0c1d3e5f7a9b2c4d6e8"
+//        }
+//    }
+//  }
+// x-sample-call-output: |
+//  {
+//    "flavor": {
+//        "meta": {
+//            "schema": {
+//                "uri": "lib:wml:measurements:1.0"
+//            },
+//            "id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//            "description": {
+//                "flavor_part": "SOFTWARE",
+//                "label": "ISL_Applications124",
+//                "digest_algorithm": "SHA384"
+//            }
+//        },
+//        "software": {
+//            "measurements": {
+//                "opt-trustagent-bin": {
+//                    "type": "directoryMeasurementType",
+//                    "value": "3519466d871c395ce1f5b073a4a3847b6b8f0b3e495337daa0474f967aeecd48f699df29a4d106288f3b0d1705ecef75",
+//                    "Path": "/opt/trustagent/bin",
+//                    "Include": ".*"
+//                }
+//            },
+//            "cumulative_hash": "a5d7b1e3f0c94b2e8d6a1c7f3e9b5d2a4c8e6f0b1d3a5c7e9f2b4d6a8c0e1f3a5b7c9d2e4f6a8b0c1d3e5f7a9b2c4d6e8"
+//        }
+//    },
+//    "signature": "W9c1Ttp1Uq6ahbkQnvBjtlP8cnVq+Nkb3tDoRrHNSDb2z9PwhqWTh1bXYMSgYavlJHuDvqOlAuB6HYyOjmG+0k3Kwq3HZkP3uPMjDQdzqsqDBhY6mK4OtXbT6uZsLVJpnC8e+P1bKzlOyV0kYMgC8KZ3TCp7xS0X9TGkL0yGtjH5Y7h3QUF6zAQMjvB3R3h5v8jFJ9hN5f0N9P2K2s0k1dYzV0qj7r5v9C6iB6pQfF8yT0cB5g1LwM4nH9tX2rC3xJ6yZ0bV7kQ1dF8mS2aW4eR9uT3oP5iL7jH1gK6fD0cA4sN2xM8vB3nC7zE9"
+//  }
+
+// ---
+
+// swagger:operation GET /flavors/{flavor_id}/revisions Flavors Search-FlavorRevisions
+// ---
+//
+// description: |
+//   Retrieves all the revisions of a flavor, latest revision first. A flavor that was never updated has a single revision.
+//
+//   Returns - The serialized FlavorRevisionCollection Go struct object that was retrieved.
+// x-permissions: flavors:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: flavor_id
+//   description: Unique UUID of the flavor.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the flavor revisions.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorRevisionCollection"
+//   '404':
+//     description: No flavor with the provided flavor ID found.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavors/f66ac31d-124d-418e-8200-2abf414a9adf/revisions
+// x-sample-call-output: |
+//  {
+//    "revisions": [
+//        {
+//            "revision": 2,
+//            "created": "2022-03-02T09:12:41.126711Z",
+//            "flavor": {
+//                "meta": {
+//                    "id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//                    "description": {
+//                        "flavor_part": "SOFTWARE",
+//                        "label": "ISL_Applications124",
+//                        "digest_algorithm": "SHA384"
+//                    }
+//                }
+//            },
+//            "signature": "W9c1Ttp1Uq6ahbkQnvBjtlP8cnVq+Nkb3tDoRrHNSDb2z9PwhqWTh1bXYMSgYavlJHuDvqOlAuB6HYyOjmG..."
+//        },
+//        {
+//            "revision": 1,
+//            "created": "2022-02-21T11:40:03.532402Z",
+//            "flavor": {
+//                "meta": {
+//                    "id": "f66ac31d-124d-418e-8200-2abf414a9adf",
+//                    "description": {
+//                        "flavor_part": "SOFTWARE",
+//                        "label": "ISL_Applications123",
+//                        "digest_algorithm": "SHA384"
+//                    }
+//                }
+//            },
+//            "signature": "aas8/Nv7yYuwx2ZIOMrXFpNf333tBJgr87Dpo7Z5jjUR36Estlb8pYaTGN4Dz9JtbXZy2uIBLr1wjhkHV..."
+//        }
+//    ]
+//  }
@@ -20,6 +20,13 @@ type FlavorgroupCollection struct {
 	Body hvs.FlavorgroupCollection
 }
 
+// PolicyRuleCollection request and response payload for updating the policy rules of a FlavorGroup
+// swagger:parameters PolicyRuleCollection
+type PolicyRuleCollection struct {
+	// in:body
+	Body hvs.PolicyRuleCollection
+}
+
 // FlavorgroupFlavorLinkCriteria request payload for creating FlavorGroup-Flavor links
 // swagger:parameters FlavorgroupFlavorLinkCriteria
 type FlavorgroupFlavorLinkCriteria struct {
@@ -118,6 +125,31 @@ type FlavorgroupFlavorLinkCollection struct {
 //   in: query
 //   type: integer
 //   required: false
+// - name: orderBy
+//   description: Orders the flavorgroup collection in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//   in: query
+//   type: string
+//   enum:
+//      - asc
+//      - desc
+//   required: false
+// - name: sortBy
+//   description: Field the collection is sorted by, ties are ordered by creation. Accepted values are "name".
+//   in: query
+//   type: string
+//   enum:
+//      - name
+//   required: false
+// - name: cursor
+//   description: Opaque cursor returned in the next link of a sorted page, it carries the sort field, the order and the position of the next page. It cannot be combined with afterId, sortBy or orderBy.
+//   in: query
+//   type: string
+//   required: false
+// - name: includeTotal
+//   description: Returns the total number of records matching the search criteria when true, the records are not counted by default.
+//   in: query
+//   type: boolean
+//   required: false
 // - name: nameEqualTo
 //   description: Flavor group name.
 //   in: query
@@ -210,6 +242,7 @@ type FlavorgroupFlavorLinkCollection struct {
 //    | name                           | Name of the flavorgroup to be created. |
 //    | flavor_match_policy_collection | Collection of flavor match policies. Each flavor match policy contains two <br> parts: <br><b>flavor_part</b>:The type or classification of the flavor.<br> <b>match_policy</b>:The policy which defines how the host is verified against the <br> flavors in the flavor group for the specified flavor part. |
 //    | flavorTemplateIds              | (Optional) Flavor template ids that the created flavorgroup will be associated with. If not provided, created flavorgroup will be associated with all the templates associated with the automatic flavor group. |
+//    | policy_rules                   | (Optional) Policy rules evaluated on the host manifest of the hosts linked to the flavorgroup. See PUT /flavorgroups/{flavorgroup_id}/policy-rules. |
 //
 // x-permissions: flavorgroups:create
 // security:
@@ -506,6 +539,124 @@ type FlavorgroupFlavorLinkCollection struct {
 
 // ---
 
+// swagger:operation PUT /flavorgroups/{flavorgroup_id}/policy-rules Flavorgroups Update-PolicyRules
+// ---
+//
+// description: |
+//   Replaces the policy rules of a flavor group. The policy rules are evaluated on the host manifest of the hosts
+//   linked to the flavor group on every verification, in addition to the rules of the flavors. Each policy rule is
+//   reported in the trust report as a rule.PolicyExpressionMatches result under its marker, with a
+//   fault.PolicyExpressionMismatch fault for each condition that is not satisfied, and in the SAML report as the
+//   POLICY_<name> attribute. The hosts linked to the flavor group are queued for verification.
+//
+//   The serialized PolicyRuleCollection Go struct object represents the content of the request body.
+//
+//    | Attribute   | Description |
+//    |-------------|-------------|
+//    | name        | Name of the policy rule, unique in the flavor group. Letters, digits, '_', '.' and '-' only. |
+//    | description | (Optional) Description of the policy rule. |
+//    | marker      | (Optional) Flavor part the result is reported under, PLATFORM by default. |
+//    | expression  | Expression evaluated on the host manifest. An expression is either <b>all_of</b> or <b>any_of</b> a list of expressions, <b>not</b> an expression, or a condition with a <b>path</b>, an <b>operator</b> and a <b>value</b>. |
+//
+//   The path is a dot separated list of the JSON field names of the host manifest, numeric segments index arrays,
+//   for example host_info.hardware_features.TXT.enabled. The supported operators are equals, not_equals, exists,
+//   contains, in, matches (regular expression), gt, gte, lt and lte. Values are compared as numbers or booleans when
+//   both can be parsed as such, gt, gte, lt and lte compare other strings as versions.
+//
+// x-permissions: flavorgroups:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: flavorgroup_id
+//   required: true
+//   in: path
+//   type: string
+//   format: uuid
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/PolicyRuleCollection"
+// - name: Content-Type
+//   required: true
+//   in: header
+//   type: string
+// - name: Accept
+//   required: true
+//   in: header
+//   type: string
+// responses:
+//   '200':
+//     description: Successfully updated the policy rules of the flavorgroup.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/PolicyRuleCollection"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: FlavorGroup ID in request path does not exist
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavorgroups/8d7964db-4e4d-49a0-b441-1beabbcebf78/policy-rules
+// x-sample-call-input: |
+//    {
+//        "policy_rules": [
+//            {
+//                "name": "txt-bios",
+//                "description": "TXT enabled and BIOS version at least SE5C620.86B.02.01.0010",
+//                "marker": "PLATFORM",
+//                "expression": {
+//                    "all_of": [
+//                        {
+//                            "path": "host_info.hardware_features.TXT.enabled",
+//                            "operator": "equals",
+//                            "value": true
+//                        },
+//                        {
+//                            "path": "host_info.bios_version",
+//                            "operator": "gte",
+//                            "value": "SE5C620.86B.02.01.0010"
+//                        }
+//                    ]
+//                }
+//            }
+//        ]
+//    }
+// x-sample-call-output: |
+//    {
+//        "policy_rules": [
+//            {
+//                "name": "txt-bios",
+//                "description": "TXT enabled and BIOS version at least SE5C620.86B.02.01.0010",
+//                "marker": "PLATFORM",
+//                "expression": {
+//                    "all_of": [
+//                        {
+//                            "path": "host_info.hardware_features.TXT.enabled",
+//                            "operator": "equals",
+//                            "value": true
+//                        },
+//                        {
+//                            "path": "host_info.bios_version",
+//                            "operator": "gte",
+//                            "value": "SE5C620.86B.02.01.0010"
+//                        }
+//                    ]
+//                }
+//            }
+//        ]
+//    }
+
+// ---
+
 // swagger:operation GET /flavorgroups/{flavorgroup_id}/flavors/{flavor_id}  Flavorgroups  Retrieve-Flavorlink
 // ---
 //
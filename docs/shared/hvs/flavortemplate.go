@@ -20,6 +20,20 @@ type FlavorTemplateReq struct {
 	Body hvs.FlavorTemplateReq
 }
 
+// FlavorTemplateValidationRequest request payload
+// swagger:parameters FlavorTemplateValidationRequest
+type FlavorTemplateValidationRequest struct {
+	// in: body
+	Body hvs.FlavorTemplateValidationRequest
+}
+
+// FlavorTemplateValidation response payload
+// swagger:parameters FlavorTemplateValidation
+type FlavorTemplateValidation struct {
+	// in: body
+	Body hvs.FlavorTemplateValidation
+}
+
 // FlavorTemplateFlavorgroup response payload
 // swagger:parameters FlavorTemplateFlavorgroup
 type FlavorTemplateFlavorgroup struct {
@@ -321,11 +335,140 @@ type FlavorTemplateFlavorgroupCollection struct {
 
 // ---
 
+// swagger:operation POST /flavor-templates/validate Flavortemplates Validate-FlavorTemplate
+// ---
+// description: |
+//   Validates a flavor template without storing it. All the problems of the template are reported instead of the
+//   first one only:
+//   - Errors: the template does not adhere to the schema, a condition is not a valid jsonquery statement, a PCR index
+//     is not in the range 0 to 23, a PCR bank is unknown or a PCR has more than one rule in a flavor part.
+//   - Warnings: the template is accepted but is likely not what the author intended, e.g. a rule that verifies
+//     neither the PCR value nor the event log, or events of a PCR verified by several flavor parts.
+//
+//   When a host manifest or the ID of a registered host is provided, the conditions of the template are evaluated on
+//   the host manifest (the latest host manifest collected from the host when the host ID is provided). When all the
+//   conditions match and the template has no errors, the preview contains the flavors the template would generate
+//   for the host. The flavors are neither signed nor stored.
+//
+//    | Attribute                      | Description|
+//    |--------------------------------|------------|
+//    | flavor_template                | The flavor template to validate. |
+//    | host_manifest                  | (Optional) Host manifest used to preview the template. |
+//    | host_id                        | (Optional) ID of a registered host used to preview the template. Cannot be provided with host_manifest. |
+//
+// x-permissions: flavor-template:validate
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/FlavorTemplateValidationRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   required: true
+//   in: header
+//   type: string
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   required: true
+//   in: header
+//   type: string
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully validated the flavor template.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/FlavorTemplateValidation"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: No host manifest found for the host
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/flavor-templates/validate
+// x-sample-call-input: |
+//    {
+//        "flavor_template": {
+//            "label": "custom-uefi",
+//            "condition": [
+//                "//host_info/os_name//*[text()='RedHatEnterprise']",
+//                "//host_info/hardware_features/TPM/meta/tpm_version//*[text()='2.0']"
+//            ],
+//            "flavor_parts": {
+//                "OS": {
+//                    "pcr_rules": [
+//                        {
+//                            "pcr": {
+//                                "index": 7,
+//                                "bank": ["SHA256", "SHA256"]
+//                            },
+//                            "eventlog_includes": [
+//                                "shim",
+//                                "vmlinuz"
+//                            ]
+//                        },
+//                        {
+//                            "pcr": {
+//                                "index": 24,
+//                                "bank": ["SHA256"]
+//                            },
+//                            "pcr_matches": true
+//                        }
+//                    ]
+//                }
+//            }
+//        },
+//        "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2"
+//    }
+// x-sample-call-output: |
+//    {
+//        "valid": false,
+//        "errors": [
+//            "OS: PCR index 24 is not in the range 0 to 23"
+//        ],
+//        "warnings": [
+//            "OS: PCR 7 lists bank SHA256 more than once"
+//        ],
+//        "preview": {
+//            "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//            "hardware_uuid": "0009e54e-642f-e511-906e-0012795d96dd",
+//            "conditions": [
+//                {
+//                    "condition": "//host_info/os_name//*[text()='RedHatEnterprise']",
+//                    "matched": true
+//                },
+//                {
+//                    "condition": "//host_info/hardware_features/TPM/meta/tpm_version//*[text()='2.0']",
+//                    "matched": true
+//                }
+//            ],
+//            "matched": true
+//        }
+//    }
+
+// ---
+
 // swagger:operation GET /flavor-templates Flavortemplates Search-FlavorTemplates
 // ---
 //
 // description: |
 //   Retrieves all the flavor templates available in the database.
+//   The response is an array of flavor templates, the total number of templates matching the query is returned in the
+//   X-Total-Count header when includeTotal is true and the next page, if any, in the Link header.
 //
 // x-permissions: flavor-template:retrieve
 // security:
@@ -360,6 +503,35 @@ type FlavorTemplateFlavorgroupCollection struct {
 //   in: query
 //   type: string
 //   required: false
+// - name: limit
+//   description: Limit of the number of items in a page.
+//   in: query
+//   type: integer
+//   required: false
+//   default: 1000
+// - name: afterId
+//   description: Next row id after which db must be queried
+//   in: query
+//   type: integer
+//   required: false
+// - name: orderBy
+//   description: Orders the flavor template collection in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//   in: query
+//   type: string
+//   enum:
+//      - asc
+//      - desc
+//   required: false
+// - name: cursor
+//   description: Opaque cursor returned in the next link of a sorted page, it carries the sort field, the order and the position of the next page. It cannot be combined with afterId, sortBy or orderBy.
+//   in: query
+//   type: string
+//   required: false
+// - name: includeTotal
+//   description: Returns the total number of templates matching the search criteria in the X-Total-Count header when true, the templates are not counted by default.
+//   in: query
+//   type: boolean
+//   required: false
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -374,6 +546,13 @@ type FlavorTemplateFlavorgroupCollection struct {
 //       application/json
 //     schema:
 //       $ref: "#/definitions/FlavorTemplate"
+//     headers:
+//       X-Total-Count:
+//         type: integer
+//         description: Number of flavor templates matching the query, returned when includeTotal is true
+//       Link:
+//         type: string
+//         description: Query of the next page with rel="next"
 //   '400':
 //     description: Invalid or Bad request
 //   '401':
@@ -0,0 +1,118 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+
+// HostBundle request payload
+// swagger:parameters HostBundle
+type HostBundle struct {
+	// in:body
+	Body hvs.HostBundle
+}
+
+// HostBundleUploadResponse response payload
+// swagger:response HostBundleUploadResponse
+type HostBundleUploadResponse struct {
+	// in:body
+	Body hvs.HostBundleUploadResponse
+}
+
+// ---
+
+// swagger:operation POST /host-bundles Host-Bundles Create-HostBundle
+// ---
+//
+// description: |
+//   Uploads the host bundle of a host that cannot accept connections from the HVS, such as an air-gapped host.
+//
+//   The host collects its host info and a TPM quote from the Trust Agent and pushes them in a host bundle. The quote
+//   must be requested with the nonce computed from the host info and the creation time of the bundle: the base64
+//   encoded SHA256 digest of the compacted host info document followed by the creation time in RFC3339 format. The
+//   AIK signature of the quote therefore covers the whole bundle. Since the HVS cannot challenge the host, the
+//   freshness of the bundle is given by its creation time only: bundles older than the host-bundle-max-age setting of
+//   the HVS (24h by default) or created in the future are rejected, and so are bundles that are not newer than the
+//   stored bundle of the host.
+//
+//   The HVS verifies the quote of the bundle and stores it as /opt/hvs/host-bundles/<hardware_uuid>.json, replacing
+//   the previous bundle of the host. Bundles can also be copied to this directory directly. The host is then
+//   registered with the returned connection string, in the form bundle:file:///opt/hvs/host-bundles/<hardware_uuid>.json,
+//   and every attestation of the host uses the last bundle it pushed. The AIK certificate of the quote is verified
+//   against the Privacy CA during the attestation, as for the hosts the HVS connects to. Once the stored bundle is
+//   older than the max age its quote is no longer used and the host is reported as untrusted until it pushes a new
+//   bundle.
+//
+//    | Attribute               | Description |
+//    |-------------------------|-------------|
+//    | created                 | Creation time of the bundle. |
+//    | host_info               | Host info document returned by the Trust Agent. |
+//    | nonce                   | Base64 encoded nonce of the TPM quote. |
+//    | tpm_quote_response      | TPM quote response xml document returned by the Trust Agent. |
+//    | binding_key_certificate | (Optional) PEM encoded binding key certificate of the host. |
+//
+//   Asset tags and software manifests cannot be deployed to hosts registered with a bundle connection string.
+//
+// x-permissions: host_bundles:create
+// security:
+//  - bearerAuth: []
+// consumes:
+//  - application/json
+// produces:
+//  - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//     "$ref": "#/definitions/HostBundle"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '201':
+//     description: Successfully verified and stored the host bundle.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/HostBundleUploadResponse"
+//   '400':
+//     description: Invalid request body, the TPM quote of the host bundle could not be verified or the host bundle is expired.
+//   '409':
+//     description: The host bundle is not newer than the stored host bundle of the host.
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/host-bundles
+// x-sample-call-input: |
+//    {
+//        "created": "2022-03-01T10:15:00Z",
+//        "host_info": {
+//            "os_name": "RedHatEnterprise",
+//            "hardware_uuid": "00ecd3ab-9af4-e711-906e-001560a04062",
+//            ...
+//        },
+//        "nonce": "3P2kYIwNxMXNkxd2ZJHnfVt6ZQNb0mjJw5Bg9EO1uGs=",
+//        "tpm_quote_response": "<tpm_quote_response>...</tpm_quote_response>"
+//    }
+// x-sample-call-output: |
+//    {
+//        "hardware_uuid": "00ecd3ab-9af4-e711-906e-001560a04062",
+//        "created": "2022-03-01T10:15:00Z",
+//        "connection_string": "bundle:file:///opt/hvs/host-bundles/00ecd3ab-9af4-e711-906e-001560a04062.json"
+//    }
@@ -0,0 +1,238 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+
+// HostQuarantine response payload
+// swagger:parameters HostQuarantine
+type HostQuarantine struct {
+	// in:body
+	Body hvs.HostQuarantine
+}
+
+// HostQuarantineCollection response payload
+// swagger:parameters HostQuarantineCollection
+type HostQuarantineCollection struct {
+	// in:body
+	Body hvs.HostQuarantineCollection
+}
+
+// HostQuarantineRelease request payload
+// swagger:parameters HostQuarantineRelease
+type HostQuarantineRelease struct {
+	// in:body
+	Body hvs.HostQuarantineRelease
+}
+
+// ---
+
+// swagger:operation GET /host-quarantines Host-Quarantines Search-HostQuarantines
+// ---
+//
+// description: |
+//   Searches the remediation state of the hosts with untrusted reports, most recently updated first.
+//
+//   The remediation is enabled by setting remediation.untrusted-reports-threshold in the HVS configuration. HVS counts
+//   the consecutive untrusted reports of every host and a trusted report resets the count. When the count reaches the
+//   threshold the host is quarantined:
+//     - The tag certificates of the host are deleted when remediation.delete-tag-certificates is set. The IDs of the
+//       deleted certificates are returned in deleted_tag_certificates. The certificates are deleted, not revoked: an
+//       asset tag already deployed to the host is not removed.
+//     - The binding key certificate of the host is not affected. The quarantine is not part of the SAML reports of
+//       the host, KBS transfers keys to the host again once its reports are trusted.
+//     - The actions of remediation.actions are invoked. "webhook" delivers a host.quarantined event to the
+//       notification subscriptions of the event type. "script" runs remediation.script with the HVS_EVENT_TYPE,
+//       HVS_HOST_ID, HVS_HOST_NAME, HVS_HARDWARE_UUID and HVS_REPORT_ID environment variables, it is killed after
+//       remediation.script-timeout. "nats" sends a quarantine notice to the Trust Agent of a host connected through
+//       NATS.
+//
+//   A quarantined host stays quarantined, whatever its later reports, until it is released. Every change of the
+//   remediation state is recorded in the audit log with the entity type host_quarantine.
+//
+//   Returns - The serialized HostQuarantineCollection Go struct object that was retrieved.
+// x-permissions: host_quarantines:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: quarantined
+//   description: Only the quarantined hosts are returned when true, only the hosts that are not quarantined when false.
+//   in: query
+//   type: boolean
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the host quarantines.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/HostQuarantineCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/host-quarantines?quarantined=true
+// x-sample-call-output: |
+//    {
+//        "host_quarantines": [
+//            {
+//                "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//                "quarantined": true,
+//                "untrusted_reports": 3,
+//                "report_id": "0a6b0a1a-d1ee-4c46-8a83-5b1c3e1c9e2d",
+//                "quarantined_at": "2022-03-01T10:15:00.000000Z",
+//                "deleted_tag_certificates": [
+//                    "fda6105d-a340-42da-bc35-0555e7a5e360"
+//                ],
+//                "updated": "2022-03-01T10:15:00.000000Z"
+//            }
+//        ]
+//    }
+
+// ---
+
+// swagger:operation GET /host-quarantines/{host_id} Host-Quarantines Retrieve-HostQuarantine
+// ---
+//
+// description: |
+//   Retrieves the remediation state of a host. A host without record has had no untrusted report since the
+//   remediation was enabled.
+//
+//   Returns - The serialized HostQuarantine Go struct object that was retrieved.
+// x-permissions: host_quarantines:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: host_id
+//   description: Unique ID of the host.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the host quarantine.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/HostQuarantine"
+//   '404':
+//     description: Host with given ID has no untrusted reports or does not exist
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/host-quarantines/ee37c360-7eae-4250-a677-6ee12adce8e2
+// x-sample-call-output: |
+//    {
+//        "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//        "quarantined": true,
+//        "untrusted_reports": 3,
+//        "report_id": "0a6b0a1a-d1ee-4c46-8a83-5b1c3e1c9e2d",
+//        "quarantined_at": "2022-03-01T10:15:00.000000Z",
+//        "updated": "2022-03-01T10:15:00.000000Z"
+//    }
+
+// ---
+
+// swagger:operation POST /host-quarantines/{host_id}/release Host-Quarantines Release-Host
+// ---
+//
+// description: |
+//   Releases a quarantined host. The consecutive untrusted reports of the host are counted again from zero and the
+//   actions of remediation.actions are invoked with the host.released event. The deleted tag certificates are not
+//   restored, new tag certificates have to be created and deployed for the host.
+//
+//   The request body with the release comment is optional.
+//
+//   Returns - The serialized HostQuarantine Go struct object of the released host.
+// x-permissions: host_quarantines:release
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: host_id
+//   description: Unique ID of the host.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: request body
+//   required: false
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/HostQuarantineRelease"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: false
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully released the host.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/HostQuarantine"
+//   '400':
+//     description: Invalid request body provided or the host is not quarantined
+//   '404':
+//     description: Host with given ID has no untrusted reports or does not exist
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/host-quarantines/ee37c360-7eae-4250-a677-6ee12adce8e2/release
+// x-sample-call-input: |
+//    {
+//        "comment": "BIOS reflashed"
+//    }
+// x-sample-call-output: |
+//    {
+//        "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//        "quarantined": false,
+//        "untrusted_reports": 0,
+//        "report_id": "0a6b0a1a-d1ee-4c46-8a83-5b1c3e1c9e2d",
+//        "quarantined_at": "2022-03-01T10:15:00.000000Z",
+//        "released_at": "2022-03-01T11:00:00.000000Z",
+//        "release_comment": "BIOS reflashed",
+//        "updated": "2022-03-01T11:00:00.000000Z"
+//    }
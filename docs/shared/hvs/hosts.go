@@ -27,6 +27,20 @@ type HostCreateRequest struct {
 	Body hvs.HostCreateRequest
 }
 
+// HostBulkCreateRequest request payload
+// swagger:parameters HostBulkCreateRequest
+type HostBulkCreateRequest struct {
+	// in:body
+	Body hvs.HostBulkCreateRequest
+}
+
+// HostBulkCreateResponse response payload
+// swagger:parameters HostBulkCreateResponse
+type HostBulkCreateResponse struct {
+	// in:body
+	Body hvs.HostBulkCreateResponse
+}
+
 // HostFlavorgroup response payload
 // swagger:parameters HostFlavorgroup
 type HostFlavorgroup struct {
@@ -141,6 +155,122 @@ type HostFlavorgroupCreateRequest struct {
 
 // ---
 
+// swagger:operation POST /hosts/bulk Hosts BulkCreateHosts
+// ---
+//
+// description: |
+//   <b>Creates hosts in bulk.</b>
+//   <pre>
+//   Registers up to 1000 hosts in a single request. Each host is validated like a host created with POST /hosts, a
+//   host that cannot be created is reported with an error in the result of its row and does not fail the other hosts.
+//   The hosts are created in batches of 50 hosts, all the hosts of a batch are created in a single transaction. When
+//   a batch fails, its hosts are created one at a time so that only the failing hosts are reported.</br>
+//   The hosts are not connected during the registration, unless verify-quote-for-host-registration is set: the TPM
+//   quotes of the hosts are then verified by up to 10 concurrent connections before the hosts are created. Once
+//   created, the hosts are added to the flavor verification queue in backend, which retrieves the hardware UUID of the
+//   hosts and links the hosts created without flavor group to the default flavor groups.</br>
+//   </pre>
+//
+//   The hosts are provided as a serialized HostBulkCreateRequest Go struct object, or as a CSV file with a header row
+//   naming the columns. The host_name and connection_string columns are required, the flavor group names of a host
+//   are separated by semicolons.
+//
+//    | Attribute         | Description |
+//    |-------------------|-------------|
+//    | hosts             | List of HostCreateRequest, see POST /hosts. |
+//
+//   The response has the result of each host, in the order of the request.
+//
+//    | Attribute         | Description |
+//    |-------------------|-------------|
+//    | created           | Number of hosts created. |
+//    | failed            | Number of hosts that could not be created. |
+//    | results           | Result of each host, with the position of the host in the request (row), the created host or the error. |
+//
+// x-permissions: hosts:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// - text/csv
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/HostBulkCreateRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+//     - text/csv
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully processed the hosts, the result of each host is in the response.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/HostBulkCreateResponse"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type or Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/hosts/bulk
+// x-sample-call-input: |
+//    {
+//        "hosts": [
+//            {
+//                "host_name": "Purley host1",
+//                "connection_string": "intel:https://trustagent1.server.com:1443",
+//                "description": "RHEL TPM2.0 Purley"
+//            },
+//            {
+//                "host_name": "Purley host2",
+//                "connection_string": "intel:https://trustagent2.server.com:1443",
+//                "flavorgroup_names": ["unknown"]
+//            }
+//        ]
+//    }
+// x-sample-call-output: |
+//    {
+//        "created": 1,
+//        "failed": 1,
+//        "results": [
+//            {
+//                "row": 1,
+//                "host_name": "Purley host1",
+//                "host": {
+//                    "id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//                    "host_name": "Purley host1",
+//                    "description": "RHEL TPM2.0 Purley",
+//                    "connection_string": "intel:https://trustagent1.server.com:1443"
+//                }
+//            },
+//            {
+//                "row": 2,
+//                "host_name": "Purley host2",
+//                "error": "Flavor group record not found: unknown"
+//            }
+//        ]
+//    }
+
+// ---
+
 // swagger:operation GET /hosts/{host_id} Hosts RetrieveHost
 // ---
 //
@@ -390,7 +520,7 @@ type HostFlavorgroupCreateRequest struct {
 //   type: boolean
 //   required: false
 // - name: orderBy
-//   description: Orders the host collection in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//   description: Orders the host collection in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc". The hosts are sorted by name when sortBy is not given. orderBy can be combined with limit and afterId, the next page is then linked with a cursor; such requests were rejected with 400 in previous releases.
 //   in: query
 //   type: string
 //   enum:
@@ -408,6 +538,23 @@ type HostFlavorgroupCreateRequest struct {
 //   in: query
 //   type: integer
 //   required: false
+// - name: sortBy
+//   description: Field the collection is sorted by, ties are ordered by creation. Accepted values are "name".
+//   in: query
+//   type: string
+//   enum:
+//      - name
+//   required: false
+// - name: cursor
+//   description: Opaque cursor returned in the next link of a sorted page, it carries the sort field, the order and the position of the next page. It cannot be combined with afterId, sortBy or orderBy.
+//   in: query
+//   type: string
+//   required: false
+// - name: includeTotal
+//   description: Returns the total number of records matching the search criteria when true, the records are not counted by default.
+//   in: query
+//   type: boolean
+//   required: false
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -0,0 +1,37 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+//
+// swagger:operation GET /metrics Metrics GetMetrics
+// ---
+// description: |
+//   GetMetrics returns the operational metrics of the service in the Prometheus text exposition format. The API
+//   does not require authentication so that it can be scraped by Prometheus. Along with the Go runtime and process
+//   metrics, the following metrics are reported
+//     - isecl_http_request_duration_seconds: histogram of the request latency, labelled by method and route
+//     - isecl_http_responses_total: number of responses, labelled by method, route and status code
+//     - isecl_hvs_host_fetcher_queue_depth: number of hosts waiting for their data to be fetched
+//     - isecl_hvs_host_fetcher_workers: number of host data fetcher workers
+//     - isecl_hvs_host_fetcher_busy_workers: number of workers currently fetching host data
+//     - isecl_hvs_queue_backlog: number of flavor verification queue entries, labelled by state
+//     - isecl_hvs_hrrs_refreshes_total: number of report refresh cycles, labelled by result
+//     - isecl_hvs_hrrs_refreshed_hosts_total: number of hosts queued for verification because their report expired
+//
+//   The routes are reported as path templates, the resource IDs are not part of the metrics.
+//
+// produces:
+//   - text/plain
+// responses:
+//   '200':
+//     description: Successfully retrieved the metrics.
+//     content: text/plain
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/metrics
+// x-sample-call-output: |
+//   # HELP isecl_http_responses_total Number of HTTP responses by route and status code.
+//   # TYPE isecl_http_responses_total counter
+//   isecl_http_responses_total{code="200",method="GET",route="/hvs/v2/version"} 3
@@ -0,0 +1,318 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+
+// NotificationSubscription request/response payload
+// swagger:parameters NotificationSubscription
+type NotificationSubscription struct {
+	// in:body
+	Body hvs.NotificationSubscription
+}
+
+// NotificationSubscriptionCollection response payload
+// swagger:parameters NotificationSubscriptionCollection
+type NotificationSubscriptionCollection struct {
+	// in:body
+	Body hvs.NotificationSubscriptionCollection
+}
+
+// NotificationDeadLetterCollection response payload
+// swagger:parameters NotificationDeadLetterCollection
+type NotificationDeadLetterCollection struct {
+	// in:body
+	Body hvs.NotificationDeadLetterCollection
+}
+
+// NotificationEvent payload delivered to the subscribers
+// swagger:parameters NotificationEvent
+type NotificationEvent struct {
+	// in:body
+	Body hvs.NotificationEvent
+}
+
+// ---
+
+// swagger:operation POST /notification-subscriptions Notification-Subscriptions Create-NotificationSubscription
+// ---
+//
+// description: |
+//   Registers an HTTPS webhook that is notified of host events, so that consumers do not have to poll the reports.
+//   The supported event types are
+//     - host.trust_changed: a new report of the host has an overall trust different from the previous report
+//     - host.state_changed: the host state transitions, for example from CONNECTED to CONNECTION_FAILURE
+//     - host.quarantined: the remediation quarantined the host after consecutive untrusted reports
+//     - host.released: a quarantined host was released
+//
+//   Each event is sent as a POST request with the serialized NotificationEvent Go struct object as the body and the headers
+//     - X-HVS-Event: the event type
+//     - X-HVS-Delivery: the unique ID of the event
+//     - X-HVS-Timestamp: the time of the request in seconds since the epoch
+//     - X-HVS-Signature: "sha256=" followed by the hex encoded HMAC-SHA256 of "<X-HVS-Timestamp>.<body>" computed with the subscription secret
+//
+//   A delivery that fails, or is not answered with a 2xx status, is retried with an exponential backoff. When all the retries
+//   fail the event is moved to the dead letters of the subscription.
+//
+//   If the secret is not provided, a secret is generated. The secret is returned only in the response of this API.
+//
+//    | Attribute   | Description |
+//    |-------------|-------------|
+//    | url         | HTTPS URL of the subscriber. |
+//    | event_types | Event types to subscribe to. |
+//    | secret      | (Optional) Secret, 16 to 256 characters, used to sign the event payloads. |
+//
+// x-permissions: notification_subscriptions:create
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//    "$ref": "#/definitions/NotificationSubscription"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '201':
+//     description: Successfully created the notification subscription.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/NotificationSubscription"
+//   '400':
+//     description: Invalid request body provided
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-subscriptions
+// x-sample-call-input: |
+//    {
+//        "url": "https://ihub.com:5443/events",
+//        "event_types": ["host.trust_changed", "host.state_changed"]
+//    }
+// x-sample-call-output: |
+//    {
+//        "id": "5a2b1c3d-7e8f-4a9b-8c1d-2e3f4a5b6c7d",
+//        "url": "https://ihub.com:5443/events",
+//        "event_types": ["host.trust_changed", "host.state_changed"],
+//        "secret": "4c7d0a3e9b1f2a6c8e5d7b9a1c3e5f7092b4d6f8a0c2e4f6a8b0d2f4a6c8e0b2",
+//        "created": "2022-03-01T10:00:00.000000Z"
+//    }
+
+// ---
+
+// swagger:operation GET /notification-subscriptions Notification-Subscriptions Search-NotificationSubscriptions
+// ---
+//
+// description: |
+//   Searches the notification subscriptions. The subscription secrets are not returned.
+//
+//   Returns - The serialized NotificationSubscriptionCollection Go struct object that was retrieved.
+// x-permissions: notification_subscriptions:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: eventType
+//   description: Only subscriptions to this event type are returned.
+//   in: query
+//   type: string
+//   required: false
+//   enum: [host.trust_changed, host.state_changed, host.quarantined, host.released]
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully searched the notification subscriptions.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/NotificationSubscriptionCollection"
+//   '400':
+//     description: Invalid search criteria provided
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-subscriptions?eventType=host.trust_changed
+// x-sample-call-output: |
+//    {
+//        "notification_subscriptions": [
+//            {
+//                "id": "5a2b1c3d-7e8f-4a9b-8c1d-2e3f4a5b6c7d",
+//                "url": "https://ihub.com:5443/events",
+//                "event_types": ["host.trust_changed", "host.state_changed"],
+//                "created": "2022-03-01T10:00:00.000000Z"
+//            }
+//        ]
+//    }
+
+// ---
+
+// swagger:operation GET /notification-subscriptions/{subscription_id} Notification-Subscriptions Retrieve-NotificationSubscription
+// ---
+//
+// description: |
+//   Retrieves a notification subscription. The subscription secret is not returned.
+//
+//   Returns - The serialized NotificationSubscription Go struct object that was retrieved.
+// x-permissions: notification_subscriptions:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: subscription_id
+//   description: Unique ID of the notification subscription.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the notification subscription.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/NotificationSubscription"
+//   '404':
+//     description: Notification subscription with given ID does not exist
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-subscriptions/5a2b1c3d-7e8f-4a9b-8c1d-2e3f4a5b6c7d
+// x-sample-call-output: |
+//    {
+//        "id": "5a2b1c3d-7e8f-4a9b-8c1d-2e3f4a5b6c7d",
+//        "url": "https://ihub.com:5443/events",
+//        "event_types": ["host.trust_changed", "host.state_changed"],
+//        "created": "2022-03-01T10:00:00.000000Z"
+//    }
+
+// ---
+
+// swagger:operation DELETE /notification-subscriptions/{subscription_id} Notification-Subscriptions Delete-NotificationSubscription
+// ---
+//
+// description: |
+//   Deletes a notification subscription along with its dead letters.
+// x-permissions: notification_subscriptions:delete
+// security:
+//  - bearerAuth: []
+// parameters:
+// - name: subscription_id
+//   description: Unique ID of the notification subscription.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// responses:
+//   '204':
+//     description: Successfully deleted the notification subscription.
+//   '404':
+//     description: Notification subscription with given ID does not exist
+//   '500':
+//     description: Internal server error
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-subscriptions/5a2b1c3d-7e8f-4a9b-8c1d-2e3f4a5b6c7d
+
+// ---
+
+// swagger:operation GET /notification-subscriptions/{subscription_id}/dead-letters Notification-Subscriptions Search-NotificationDeadLetters
+// ---
+//
+// description: |
+//   Retrieves the events that could not be delivered to the subscriber after all the retries, most recent first.
+//
+//   Returns - The serialized NotificationDeadLetterCollection Go struct object that was retrieved.
+// x-permissions: notification_subscriptions:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: subscription_id
+//   description: Unique ID of the notification subscription.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the dead letters of the notification subscription.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/NotificationDeadLetterCollection"
+//   '404':
+//     description: Notification subscription with given ID does not exist
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/notification-subscriptions/5a2b1c3d-7e8f-4a9b-8c1d-2e3f4a5b6c7d/dead-letters
+// x-sample-call-output: |
+//    {
+//        "dead_letters": [
+//            {
+//                "id": "7c4d3e5f-9a0b-4c2d-8e3f-4a5b6c7d8e9f",
+//                "subscription_id": "5a2b1c3d-7e8f-4a9b-8c1d-2e3f4a5b6c7d",
+//                "event": {
+//                    "id": "6b3c2d4e-8f9a-4b1c-9d2e-3f4a5b6c7d8e",
+//                    "event_type": "host.state_changed",
+//                    "host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//                    "created": "2022-03-01T10:00:00.000000Z",
+//                    "previous_host_state": "CONNECTED",
+//                    "host_state": "CONNECTION_FAILURE"
+//                },
+//                "attempts": 6,
+//                "last_error": "subscriber responded with status 503",
+//                "created": "2022-03-01T10:16:00.000000Z"
+//            }
+//        ]
+//    }
@@ -0,0 +1,90 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+
+// ReportRetentionStatus response payload
+// swagger:parameters ReportRetentionStatus
+type ReportRetentionStatus struct {
+	// in:body
+	Body hvs.ReportRetentionStatus
+}
+
+// ---
+
+// swagger:operation GET /report-retention Report-Retention Retrieve-ReportRetention
+// ---
+//
+// description: |
+//   Retrieves the retention policy of the report history and the statistics of the last purge.
+//
+//   The reports replaced by a newer report of the host are kept in the report history. When the retention is enabled,
+//   the reports of the history older than the retention days are periodically purged in batches, either deleted or moved
+//   to the report archive. The latest reports of every host, as well as its last trusted and last untrusted reports,
+//   are never purged. The current report of a host is never purged. Once a host is deleted, all of its reports older
+//   than the retention days are purged. The audit log entries of the reports are not affected by the purge.
+//
+//   The retention is configured with the report-retention settings of the HVS configuration and is disabled when
+//   retention-days is zero.
+//
+//    | Attribute            | Description |
+//    |----------------------|-------------|
+//    | enabled              | True when the report history is periodically purged. |
+//    | run_period           | Interval between two purges. |
+//    | retention_days       | Number of days the reports are kept in the report history. |
+//    | keep_latest_per_host | Number of latest reports of a host, including the current one, that are never purged. |
+//    | batch_size           | Maximum number of reports purged in a single transaction. |
+//    | archive              | True when the purged reports are moved to the report archive instead of being deleted. |
+//    | running              | True while a purge is in progress. |
+//    | last_run             | Statistics of the last purge. |
+//    | next_run             | Scheduled time of the next purge. |
+//
+//   Returns - The serialized ReportRetentionStatus Go struct object that was retrieved.
+// x-permissions: report_retention:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the report retention status.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/ReportRetentionStatus"
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/report-retention
+// x-sample-call-output: |
+//    {
+//        "enabled": true,
+//        "run_period": "24h0m0s",
+//        "retention_days": 90,
+//        "keep_latest_per_host": 10,
+//        "batch_size": 500,
+//        "archive": false,
+//        "running": false,
+//        "last_run": {
+//            "started_at": "2022-03-01T02:00:00.000000Z",
+//            "completed_at": "2022-03-01T02:00:04.512000Z",
+//            "created_before": "2021-12-01T02:00:00.000000Z",
+//            "batches": 3,
+//            "purged": 1204
+//        },
+//        "next_run": "2022-03-02T02:00:04.512000Z"
+//    }
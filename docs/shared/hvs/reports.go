@@ -21,6 +21,20 @@ type ReportCollection struct {
 	Body hvs.ReportCollection
 }
 
+// ReportDiff response payload
+// swagger:parameters ReportDiff
+type ReportDiff struct {
+	// in:body
+	Body hvs.ReportDiff
+}
+
+// ReportSummary response payload
+// swagger:parameters ReportSummary
+type ReportSummary struct {
+	// in:body
+	Body hvs.ReportSummary
+}
+
 // Report request payload
 // swagger:parameters ReportCreateRequest
 type ReportCreateRequest struct {
@@ -69,6 +83,31 @@ type ReportCreateRequest struct {
 //   in: query
 //   type: integer
 //   required: false
+// - name: orderBy
+//   description: Orders the report collection in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//   in: query
+//   type: string
+//   enum:
+//      - asc
+//      - desc
+//   required: false
+// - name: sortBy
+//   description: Field the collection is sorted by, ties are ordered by creation. Accepted values are "created".
+//   in: query
+//   type: string
+//   enum:
+//      - created
+//   required: false
+// - name: cursor
+//   description: Opaque cursor returned in the next link of a sorted page, it carries the sort field, the order and the position of the next page. It cannot be combined with afterId, sortBy or orderBy.
+//   in: query
+//   type: string
+//   required: false
+// - name: includeTotal
+//   description: Returns the total number of records matching the search criteria when true, the records are not counted by default.
+//   in: query
+//   type: boolean
+//   required: false
 // - name: hostId
 //   description: host Id of the host. If this parameter is specified, it will return report only for active host with specified host id.
 //   in: query
@@ -341,11 +380,64 @@ type ReportCreateRequest struct {
 // description: |
 //   Retrieves a report.
 //   Returns - The serialized Report Go struct object that was retrieved.
+//
+//   When the format query parameter is set to "summary", the ReportSummary of the report is returned instead: the
+//   rule results are grouped by flavor part, the failing rules are explained in plain sentences, e.g.
+//   "PCR 17 SHA256 mismatch: expected ..., actual ...", the flavors the host was verified against are listed and
+//   the event log entries of the failures refer to the PCR event logs of the host manifest.
+//
+//   When the Accept header is text/markdown, the summary is rendered as a markdown document. The event log entries
+//   of the failures link to an appendix listing the event logs of their PCRs.
+//
+//   Sample summary returned by GET /reports/8a545a4f-d282-4d91-8ec5-bcbe439dcfbc?format=summary:
+//
+//     {
+//       "report_id": "8a545a4f-d282-4d91-8ec5-bcbe439dcfbc",
+//       "host_id": "94824cb6-d6c8-4faf-83b0-125996ceebe2",
+//       "host_name": "host-1",
+//       "hardware_uuid": "0009e54e-642f-e511-906e-0012795d96dd",
+//       "created": "2018-07-23T16:39:52-07:00",
+//       "expiration": "2018-07-23T17:39:52-07:00",
+//       "trusted": false,
+//       "flavor_parts": [
+//         {
+//           "flavor_part": "PLATFORM",
+//           "trusted": false,
+//           "flavors": [
+//             {
+//               "flavor_id": "a774ddad-fca1-4670-86b2-605c88a16dab",
+//               "matched": false
+//             }
+//           ],
+//           "failures": [
+//             {
+//               "rule": "PCR event log equals the flavor, excluding the tagged events",
+//               "flavor_id": "a774ddad-fca1-4670-86b2-605c88a16dab",
+//               "reasons": [
+//                 "PCR 17 SHA256 event log contains 1 events that are not in the flavor"
+//               ],
+//               "event_log_entries": [
+//                 {
+//                   "pcr_index": "pcr_17",
+//                   "pcr_bank": "SHA256",
+//                   "event_index": 12,
+//                   "type_name": "EV_EVENT_TAG",
+//                   "tags": ["LCP_DETAILS_HASH"],
+//                   "measurement": "9069ca78e7450a285173431b3e52c5c25299e473",
+//                   "status": "unexpected"
+//                 }
+//               ]
+//             }
+//           ]
+//         }
+//       ]
+//     }
 // x-permissions: reports:retrieve
 // security:
 //  - bearerAuth: []
 // produces:
 // - application/json
+// - text/markdown
 // parameters:
 // - name: report_id
 //   description: Unique ID of the Report.
@@ -353,6 +445,13 @@ type ReportCreateRequest struct {
 //   required: true
 //   type: string
 //   format: uuid
+// - name: format
+//   description: Set to summary to retrieve the ReportSummary of the report.
+//   in: query
+//   type: string
+//   required: false
+//   enum:
+//     - summary
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -360,6 +459,7 @@ type ReportCreateRequest struct {
 //   required: true
 //   enum:
 //     - application/json
+//     - text/markdown
 // responses:
 //   '200':
 //     description: Successfully retrieved the Report.
@@ -367,6 +467,8 @@ type ReportCreateRequest struct {
 //       application/json
 //     schema:
 //       $ref: "#/definitions/Report"
+//   '400':
+//     description: Invalid format query parameter provided.
 //   '404':
 //     description: No relevant report record found.
 //   '415':
@@ -431,3 +533,101 @@ type ReportCreateRequest struct {
 //       "expiration": "2018-07-23T17:39:52-0700"
 //     }
 //   }
+
+// ---
+
+// swagger:operation GET /reports/{report_id}/diff Reports Diff-Report
+// ---
+//
+// description: |
+//   Compares a report with another report. The report to compare against can be the current report or any report
+//   created earlier for a host. When the against query parameter is not provided or is set to "previous", the report
+//   is compared with the report created for the same host before it.
+//   Returns - The rules only evaluated in the report (added_rules), the rules only evaluated in the report compared
+//   against (removed_rules) and, for the rules evaluated in both, the changes of the trust status, faults and PCR or
+//   event log mismatches (changed_rules).
+// x-permissions: reports:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: report_id
+//   description: Unique ID of the Report.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: against
+//   description: Unique ID of the Report to compare against, or "previous" for the previous report of the host.
+//   in: query
+//   type: string
+//   required: false
+//   default: previous
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully compared the Reports.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/ReportDiff"
+//   '400':
+//     description: Invalid query parameter or report compared against itself.
+//   '404':
+//     description: The report or the report to compare against does not exist.
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error.
+// x-sample-call-endpoint: https://hvs.com:8443/hvs/v2/reports/8a545a4f-d282-4d91-8ec5-bcbe439dcfbc/diff?against=previous
+// x-sample-call-output: |
+//   {
+//     "report_id": "8a545a4f-d282-4d91-8ec5-bcbe439dcfbc",
+//     "host_id": "94824cb6-d6c8-4faf-83b0-125996ceebe2",
+//     "trusted": false,
+//     "against_report_id": "4d7c0b4b-4f5d-4a0e-9a8f-cd2f9e6b6b41",
+//     "against_host_id": "94824cb6-d6c8-4faf-83b0-125996ceebe2",
+//     "against_trusted": true,
+//     "changed_rules": [
+//       {
+//         "rule": {
+//           "rule_name": "rule.PcrEventLogEqualsExcluding",
+//           "markers": [
+//             "PLATFORM"
+//           ]
+//         },
+//         "flavor_id": "1108e0f4-96ee-4839-9bf7-a5a25457797f",
+//         "trusted": false,
+//         "against_trusted": true,
+//         "added_faults": [
+//           {
+//             "fault_name": "fault.PcrEventLogMissingExpectedEntries",
+//             "description": "Module manifest for PCR 17 of SHA256 value missing 1 expected entries",
+//             "pcr_index": 17,
+//             "pcr_bank": "SHA256"
+//           }
+//         ],
+//         "changed_mismatch_fields": [
+//           {
+//             "name": "PcrEventLogMissingFields",
+//             "pcr_index": 17,
+//             "pcr_bank": "SHA256",
+//             "added_missing_entries": [
+//               {
+//                 "type_id": "0x40c",
+//                 "type_name": "EV_SINIT",
+//                 "measurement": "a1b2c3d4"
+//               }
+//             ]
+//           }
+//         ]
+//       }
+//     ]
+//   }
@@ -104,6 +104,45 @@ type TagCertificateCollection struct {
 //   type: string
 //   format: uuid
 //   required: false
+// - name: limit
+//   description: Limit of the number of items in a page.
+//   in: query
+//   type: integer
+//   required: false
+//   default: 1000
+// - name: afterId
+//   description: Next row id after which db must be queried
+//   in: query
+//   type: integer
+//   required: false
+// - name: orderBy
+//   description: Orders the tag certificate collection in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//   in: query
+//   type: string
+//   enum:
+//      - asc
+//      - desc
+//   required: false
+// - name: sortBy
+//   description: Field the collection is sorted by, ties are ordered by creation. The certificates are sorted by subject by default. Accepted values are "subject", "issuer", "notbefore", "notafter".
+//   in: query
+//   type: string
+//   enum:
+//      - subject
+//      - issuer
+//      - notbefore
+//      - notafter
+//   required: false
+// - name: cursor
+//   description: Opaque cursor returned in the next link of a sorted page, it carries the sort field, the order and the position of the next page. It cannot be combined with afterId, sortBy or orderBy.
+//   in: query
+//   type: string
+//   required: false
+// - name: includeTotal
+//   description: Returns the total number of records matching the search criteria when true, the records are not counted by default.
+//   in: query
+//   type: boolean
+//   required: false
 // - name: Accept
 //   description: Accept header
 //   in: header
@@ -57,6 +57,32 @@ type TpmEndorsementCollection struct {
 //     in: query
 //     type: integer
 //     required: false
+//   - name: orderBy
+//     description: Orders the TPM endorsement collection in ascending/descending order. Accepted values are "asc"/"desc" the default being "asc".
+//     in: query
+//     type: string
+//     enum:
+//        - asc
+//        - desc
+//     required: false
+//   - name: sortBy
+//     description: Field the collection is sorted by, ties are ordered by creation. Accepted values are "issuer", "hardware_uuid".
+//     in: query
+//     type: string
+//     enum:
+//        - issuer
+//        - hardware_uuid
+//     required: false
+//   - name: cursor
+//     description: Opaque cursor returned in the next link of a sorted page, it carries the sort field, the order and the position of the next page. It cannot be combined with afterId, sortBy or orderBy.
+//     in: query
+//     type: string
+//     required: false
+//   - name: includeTotal
+//     description: Returns the total number of records matching the search criteria when true, the records are not counted by default.
+//     in: query
+//     type: boolean
+//     required: false
 //   - name: issuerEqualTo
 //     description: Issuer name.
 //     in: query
@@ -15,6 +15,27 @@ type KeyTransferPolicy struct {
 	Body kbs.KeyTransferPolicy
 }
 
+// KeyTransferPolicyEvaluationRequest request payload
+// swagger:parameters KeyTransferPolicyEvaluationRequest
+type KeyTransferPolicyEvaluationRequest struct {
+	// in:body
+	Body kbs.KeyTransferPolicyEvaluationRequest
+}
+
+// KeyTransferPolicyEvaluation response payload
+// swagger:parameters KeyTransferPolicyEvaluation
+type KeyTransferPolicyEvaluation struct {
+	// in:body
+	Body kbs.KeyTransferPolicyEvaluation
+}
+
+// KeyTransferPolicyUsage response payload
+// swagger:parameters KeyTransferPolicyUsage
+type KeyTransferPolicyUsage struct {
+	// in:body
+	Body kbs.KeyTransferPolicyUsage
+}
+
 // KeyTransferPolicyCollection response payload
 // swagger:parameters KeyTransferPolicyCollection
 type KeyTransferPolicyCollection struct {
@@ -207,6 +228,8 @@ type KeyTransferPolicyCollection struct {
 //
 // description: |
 //   Update a key transfer policy. Transfer-Policy with only one attestation-type i.e; SGX or TDX can be Updated at a time.
+//   The attestation type, attributes, policy ids and certificate issuers of the policy are replaced with the ones in the request,
+//   the keys associated with the policy are transferred as per the updated policy.
 //
 //   The serialized KeyTransferPolicy Go struct object represents the content of the request body.
 //
@@ -271,6 +294,8 @@ type KeyTransferPolicyCollection struct {
 //       $ref: "#/definitions/KeyTransferPolicy"
 //   '400':
 //     description: Invalid request body provided
+//   '404':
+//     description: KeyTransferPolicy record not found
 //   '415':
 //     description: Invalid Accept Header in Request
 //   '500':
@@ -461,11 +486,137 @@ type KeyTransferPolicyCollection struct {
 
 // ---
 
+// swagger:operation POST /key-transfer-policies/{id}/evaluate KeyTransferPolicies EvaluateKeyTransferPolicy
+// ---
+//
+// description: |
+//   Evaluates sample attestation claims against a key transfer policy without releasing any key. The claims are checked
+//   the same way as in a key transfer, SGX/TDX claims and the certificate issuer are checked against the policy attributes
+//   and a SAML report is checked the same way as in a key transfer with SAML report.
+//   Returns - The per attribute evaluation with the overall decision.
+//
+//    | Attribute                                    | Description |
+//    |----------------------------------------------|-------------|
+//    | attestation_type                             | Attestation type of the client i.e; "SGX" or "TDX". Defaults to the type of the provided claims. |
+//    | cert_issuer                                  | Common name of the issuer of the client certificate. |
+//    | sgx                                          | SGX enclave claims i.e; mrsigner, isvprodid, mrenclave, isvsvn and tcb_level. |
+//    | tdx                                          | TDX measurements i.e; mrsignerseam, mrseam, seamsvn, mrtd, rtmr0-rtmr3 and tcb_level. |
+//    | saml                                         | SAML report of the host issued by HVS. |
+//    | key_id                                       | Key associated with the policy, the usage policy of the key is checked against the asset tags in the SAML report. |
+//
+//   Each evaluated attribute has one of the below results, the decision is "allowed" only when all the attributes are matched.
+//
+//    | Result                                       | Description |
+//    |----------------------------------------------|-------------|
+//    | matched                                      | The claim satisfies the policy. |
+//    | mismatched                                   | The claim does not satisfy the policy. |
+//    | missing                                      | The claim required by the policy was not provided. |
+//
+// x-permissions: keys-transfer-policies:evaluate
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// consumes:
+// - application/json
+// parameters:
+// - name: id
+//   description: Unique ID of the key transfer policy.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: request body
+//   required: true
+//   in: body
+//   schema:
+//     "$ref": "#/definitions/KeyTransferPolicyEvaluationRequest"
+// - name: Content-Type
+//   description: Content-Type header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully evaluated the key transfer policy.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/KeyTransferPolicyEvaluation"
+//   '400':
+//     description: Invalid request body provided
+//   '404':
+//     description: KeyTransferPolicy record not found
+//   '415':
+//     description: Invalid Content-Type/Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://kbs.com:9443/kbs/v1/key-transfer-policies/ee37c360-7eae-4250-a677-6ee12adce8e2/evaluate
+// x-sample-call-input: |
+//    {
+//      "sgx": {
+//        "mrsigner": "cd171c56941c6ce49690b455f691d9c8a04c2e43e0a4d30f752fa5285c7ee57f",
+//        "isvprodid": 1,
+//        "isvsvn": 0
+//      }
+//    }
+// x-sample-call-output: |
+//    {
+//      "policy_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//      "decision": "denied",
+//      "attributes": [
+//        {
+//          "name": "attestation_type",
+//          "result": "matched",
+//          "expected": "SGX",
+//          "actual": "SGX"
+//        },
+//        {
+//          "name": "mrsigner",
+//          "result": "matched",
+//          "expected": "cd171c56941c6ce49690b455f691d9c8a04c2e43e0a4d30f752fa5285c7ee57f",
+//          "actual": "cd171c56941c6ce49690b455f691d9c8a04c2e43e0a4d30f752fa5285c7ee57f"
+//        },
+//        {
+//          "name": "isvprodid",
+//          "result": "matched",
+//          "expected": "1",
+//          "actual": "1"
+//        },
+//        {
+//          "name": "mrenclave",
+//          "result": "missing",
+//          "expected": "01c60b9617b2f96e53cb75ef01e0dccea3afc7b7992697eabb8f714b2ccd1953"
+//        },
+//        {
+//          "name": "isvsvn",
+//          "result": "matched",
+//          "expected": "0",
+//          "actual": "0"
+//        }
+//      ]
+//    }
+
+// ---
+
 // swagger:operation DELETE /key-transfer-policies/{id} KeyTransferPolicies DeleteKeyTransferPolicy
 // ---
 //
 // description: |
-//   Deletes a key transfer policy.
+//   Deletes a key transfer policy. A key transfer policy associated with keys is not deleted unless force is set.
+//   The keys of a forcibly deleted policy keep the id of the deleted policy as transfer_policy_id and their transfer
+//   is denied, a key cannot be associated with another policy: the key must be deleted and registered again with
+//   another policy. The default key transfer policy of the configuration cannot be deleted, even if force is set.
 // x-permissions: keys-transfer-policies:delete
 // security:
 //  - bearerAuth: []
@@ -476,9 +627,16 @@ type KeyTransferPolicyCollection struct {
 //   required: true
 //   type: string
 //   format: uuid
+// - name: force
+//   description: Delete the key transfer policy even if it is associated with keys.
+//   in: query
+//   type: boolean
+//   required: false
 // responses:
 //   '204':
 //     description: Successfully deleted the key transfer policy.
+//   '400':
+//     description: Key transfer policy is associated with keys, is the default key transfer policy or invalid query parameter provided
 //   '404':
 //     description: KeyTransferPolicy record not found
 //   '500':
@@ -487,6 +645,57 @@ type KeyTransferPolicyCollection struct {
 
 // ---
 
+// swagger:operation GET /key-transfer-policies/{id}/usage KeyTransferPolicies RetrieveKeyTransferPolicyUsage
+// ---
+//
+// description: |
+//   Retrieves the keys associated with a key transfer policy.
+//   Returns - The serialized KeyTransferPolicyUsage Go struct object with the ids of the associated keys.
+// x-permissions: keys-transfer-policies:retrieve
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   description: Unique ID of the key transfer policy.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the usage of the key transfer policy.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/KeyTransferPolicyUsage"
+//   '404':
+//     description: KeyTransferPolicy record not found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://kbs.com:9443/kbs/v1/key-transfer-policies/75d34bf4-80fb-4ca5-8602-a8d82e56b30d/usage
+// x-sample-call-output: |
+//    {
+//      "policy_id": "75d34bf4-80fb-4ca5-8602-a8d82e56b30d",
+//      "key_ids": [
+//        "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//        "8a5b3c42-0e0c-4a5f-9d5d-0d3c3a8c7e6b"
+//      ]
+//    }
+
+// ---
+
 // swagger:operation GET /key-transfer-policies KeyTransferPolicies SearchKeyTransferPolicies
 // ---
 //
@@ -0,0 +1,139 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package kbs
+
+import "github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+
+type KeyTransferRecords []kbs.KeyTransferRecord
+
+// KeyTransferRecordCollection response payload
+// swagger:parameters KeyTransferRecordCollection
+type KeyTransferRecordCollection struct {
+	// in:body
+	Body KeyTransferRecords
+}
+
+// ---
+
+// swagger:operation GET /key-transfers KeyTransfers SearchKeyTransfers
+// ---
+//
+// description: |
+//   Searches for the audit records of the key transfer attempts. A record is written for every request to transfer
+//   a key with an envelope key, with a SAML report or with the SKC library, whether the key is transferred or not.
+//   The denied transfers of an unverified requester are aggregated per remote address: the first denied transfer of
+//   a minute is recorded, the following denied transfers of that minute are recorded as a single record with the
+//   number of attempts. The records are kept according to the key-transfer-audit retention policy of the configuration.
+//   Returns - The collection of serialized KeyTransferRecord Go struct objects, the most recent record first.
+//
+//    | Attribute          | Description |
+//    |--------------------|-------------|
+//    | key_id             | Unique identifier of the requested key. |
+//    | key_version        | Requested version of a rotated key, not present when the current version is requested. |
+//    | transfer_type      | envelope, saml or skc. |
+//    | requester          | Subject of the bearer token (envelope), host name of the SAML report (saml) or common name of the client certificate (skc). |
+//    | requester_verified | False when the requester is not authenticated, i.e. the host name of a SAML report whose signature or trust is not verified. |
+//    | remote_address     | Network address of the client. |
+//    | attestation_type   | TPM for a SAML report, STM label of the SKC session e.g. SGX. |
+//    | evidence_hash      | Hex encoded SHA-384 digest of the SAML report or of the SGX quote of the SKC session. |
+//    | transfer_policy_id | Unique identifier of the key transfer policy of the key. |
+//    | decision           | allowed or denied. |
+//    | fault_reason       | Reason the key transfer is denied. |
+//    | attempts           | Number of denied transfers of an unverified requester aggregated in the record, the record is the last of these transfers. |
+//
+// x-permissions: key_transfers:search
+// security:
+//  - bearerAuth: []
+// produces:
+//  - application/json
+// parameters:
+// - name: keyId
+//   description: Unique identifier of the key.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: transferType
+//   description: Type of key transfer.
+//   in: query
+//   type: string
+//   required: false
+//   enum: [envelope, saml, skc]
+// - name: requester
+//   description: Identity of the requester.
+//   in: query
+//   type: string
+//   required: false
+// - name: transferPolicyId
+//   description: Unique identifier of the key transfer policy.
+//   in: query
+//   type: string
+//   format: uuid
+//   required: false
+// - name: decision
+//   description: Decision of the key transfer.
+//   in: query
+//   type: string
+//   required: false
+//   enum: [allowed, denied]
+// - name: fromDate
+//   description: |
+//     Returns the records created on or after the date.
+//     Date formats supported: (YYYY-MM-DD)|(YYYY-MM-DD hh:mm:ss)|(YYYY-MM-DDThh:mm:ss.000Z)|(YYYY-MM-DDThh:mm:ss.000000Z)
+//   in: query
+//   type: string
+//   required: false
+// - name: toDate
+//   description: |
+//     Returns the records created on or before the date.
+//     Date formats supported: (YYYY-MM-DD)|(YYYY-MM-DD hh:mm:ss)|(YYYY-MM-DDThh:mm:ss.000Z)|(YYYY-MM-DDThh:mm:ss.000000Z)
+//   in: query
+//   type: string
+//   required: false
+// - name: limit
+//   description: Maximum number of records returned.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   required: false
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully retrieved the key transfer records.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/KeyTransferRecords"
+//   '400':
+//     description: Invalid values for request params
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://kbs.com:9443/kbs/v1/key-transfers?keyId=ee37c360-7eae-4250-a677-6ee12adce8e2&decision=denied
+// x-sample-call-output: |
+//  [
+//    {
+//      "id": "4b7bc0d4-1b2c-4a57-9a3d-8f6f3ae4d8b1",
+//      "key_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//      "transfer_type": "saml",
+//      "requester": "ima-host-69",
+//      "requester_verified": false,
+//      "remote_address": "10.1.2.3:52114",
+//      "attestation_type": "TPM",
+//      "evidence_hash": "5b1c1bd2a1a1d5c0b0f0b5a1c6d2c1b8e0f7f0e9b2a0d2c4f2f0e1d7c8b9a0f1e2d3c4b5a69788776655443322110f",
+//      "transfer_policy_id": "ee37c360-7eae-4250-a677-6ee12adce8e2",
+//      "decision": "denied",
+//      "fault_reason": "Client not trusted by HVS",
+//      "created_at": "2022-06-20T06:30:35.085644391Z"
+//    }
+//  ]
@@ -121,7 +121,8 @@ type KeyTransferResponse struct {
 //        },
 //        "transfer_policy_id": "3ce27bbd-3c5f-4b15-8c0a-44310f0f83d9",
 //        "transfer_link": "https://kbs.com:9443/kbs/v1/keys/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/transfer",
-//        "created_at": "2020-09-23T11:16:26.738467277Z"
+//        "created_at": "2020-09-23T11:16:26.738467277Z",
+//        "version": 1
 //    }
 
 // ---
@@ -175,7 +176,8 @@ type KeyTransferResponse struct {
 //        },
 //        "transfer_policy_id": "3ce27bbd-3c5f-4b15-8c0a-44310f0f83d9",
 //        "transfer_link": "https://kbs.com:9443/kbs/v1/keys/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/transfer",
-//        "created_at": "2020-09-23T11:16:26.738467277Z"
+//        "created_at": "2020-09-23T11:16:26.738467277Z",
+//        "version": 1
 //    }
 
 // ---
@@ -184,7 +186,7 @@ type KeyTransferResponse struct {
 // ---
 //
 // description: |
-//   Transfers a key.
+//   Transfers a key. The current version of a rotated key is transferred unless a version is requested.
 //   Returns - The serialized KeyTransferResponse Go struct object that was retrieved.
 // x-permissions: keys:transfer
 // security:
@@ -200,6 +202,12 @@ type KeyTransferResponse struct {
 //   required: true
 //   type: string
 //   format: uuid
+// - name: version
+//   description: Version of a rotated key, the current version is transferred when not provided.
+//   in: query
+//   type: integer
+//   minimum: 1
+//   required: false
 // - name: Content-Type
 //   description: Content-Type header
 //   in: header
@@ -221,8 +229,10 @@ type KeyTransferResponse struct {
 //       application/json
 //     schema:
 //       $ref: "#/definitions/KeyTransferResponse"
+//   '400':
+//     description: Invalid key version or public key provided
 //   '404':
-//     description: Key record not found
+//     description: Key record or key version not found
 //   '415':
 //     description: Invalid Content-Type/Accept Header in Request
 //   '500':
@@ -249,6 +259,88 @@ type KeyTransferResponse struct {
 
 // ---
 
+// swagger:operation POST /keys/{id}/rotate Keys RotateKey
+// ---
+//
+// description: |
+//   Rotates a key. A new version of the key is created with the configured key manager, with the algorithm and
+//   length or curve of the key. The key keeps its ID, transfer policy and transfer link, the transfers return the
+//   new version from then on. The previous versions stay available to decrypt the existing content, they are
+//   transferred with the version query parameter of POST /keys/{id} and POST /keys/{id}/transfer, and are deleted
+//   along with the key.
+//
+//   The versions of the key are listed in the versions field of the response with the time they were created at
+//   and rotated at, the current version has no rotated_at.
+//
+//   Registered keys cannot be rotated, the new version would not be the key material of the owner of the key. A
+//   registered key is replaced by registering the new key material as a new key. The keys registered before the
+//   key versions are not marked as registered and are rotated.
+//   Returns - The serialized KeyResponse Go struct object of the rotated key.
+// x-permissions: keys:rotate
+// security:
+//  - bearerAuth: []
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   description: Unique ID of the key.
+//   in: path
+//   required: true
+//   type: string
+//   format: uuid
+// - name: Accept
+//   description: Accept header
+//   in: header
+//   type: string
+//   required: true
+//   enum:
+//     - application/json
+// responses:
+//   '200':
+//     description: Successfully rotated the key.
+//     content:
+//       application/json
+//     schema:
+//       $ref: "#/definitions/KeyResponse"
+//   '400':
+//     description: Registered key cannot be rotated
+//   '404':
+//     description: Key record not found
+//   '415':
+//     description: Invalid Accept Header in Request
+//   '500':
+//     description: Internal server error
+//
+// x-sample-call-endpoint: https://kbs.com:9443/kbs/v1/keys/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/rotate
+// x-sample-call-output: |
+//    {
+//        "key_information": {
+//            "id": "fc0cc779-22b6-4741-b0d9-e2e69635ad1e",
+//            "algorithm": "AES",
+//            "key_length": 256,
+//            "kmip_key_id": "2"
+//        },
+//        "transfer_policy_id": "3ce27bbd-3c5f-4b15-8c0a-44310f0f83d9",
+//        "transfer_link": "https://kbs.com:9443/kbs/v1/keys/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/transfer",
+//        "created_at": "2020-09-23T11:16:26.738467277Z",
+//        "version": 2,
+//        "versions": [
+//            {
+//                "version": 1,
+//                "kmip_key_id": "1",
+//                "created_at": "2020-09-23T11:16:26.738467277Z",
+//                "rotated_at": "2021-09-23T08:02:11.104578903Z"
+//            },
+//            {
+//                "version": 2,
+//                "kmip_key_id": "2",
+//                "created_at": "2021-09-23T08:02:11.104578903Z"
+//            }
+//        ]
+//    }
+
+// ---
+
 // swagger:operation DELETE /keys/{id} Keys DeleteKey
 // ---
 //
@@ -343,6 +435,7 @@ type KeyTransferResponse struct {
 //            },
 //            "transfer_policy_id": "3ce27bbd-3c5f-4b15-8c0a-44310f0f83d9",
 //            "transfer_link": "https://kbs.com:9443/kbs/v1/keys/fc0cc779-22b6-4741-b0d9-e2e69635ad1e/transfer",
-//            "created_at": "2020-09-23T11:16:26.738467277Z"
+//            "created_at": "2020-09-23T11:16:26.738467277Z",
+//            "version": 1
 //        }
 //    ]
@@ -138,7 +138,8 @@ type TagWriteRequestInfo struct {
 //                                  is the extended nonce used in the TPM quote (20 bytes), and the ip-address is the 4-byte encoding of
 //                                  the IP address.
 //           - pcrs            - List of PCRs for which the quote is needed.
-//           - pcrBanks    - TPM PCR bank to read.
+//           - pcrBanks    - TPM PCR bank to read. Supported banks are SHA1, SHA256, SHA384, SHA512 and SM3_256,
+//                           all of the active banks are read when the list is empty.
 //   schema:
 //     "$ref": "#/definitions/TpmQuoteRequest"
 // responses:
@@ -14,6 +14,7 @@ import (
 	"github.com/intel-secl/intel-secl/v5/pkg/authservice/constants"
 	"github.com/intel-secl/intel-secl/v5/pkg/authservice/postgres"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
 	cmw "github.com/intel-secl/intel-secl/v5/pkg/lib/common/middleware"
 )
 
@@ -35,6 +36,7 @@ func InitRoutes(cfg *config.Configuration, dataStore *postgres.PostgresDatabase,
 
 	// ISECL-8715 - Prevent potential open redirects to external URLs
 	router.SkipClean(true)
+	router.Use(commMetrics.RequestMetrics())
 	defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg, dataStore, tokenFactory)
 	return router
 }
@@ -47,6 +49,7 @@ func defineSubRoutes(router *mux.Router, service string, cfg *config.Configurati
 	serviceApi := "/" + service + "/" + constants.ApiVersion
 	subRouter := router.PathPrefix(serviceApi).Subrouter()
 	subRouter = SetVersionRoutes(subRouter)
+	subRouter = commMetrics.SetMetricsRoutes(subRouter)
 	subRouter = SetJwtCertificateRoutes(subRouter)
 	subRouter = SetJwtTokenRoutes(subRouter, dataStore, tokenFactory)
 	subRouter = SetUsersNoAuthRoutes(subRouter, dataStore)
@@ -30,6 +30,11 @@ type TAClient interface {
 	GetBaseURL() *url.URL
 }
 
+// QuarantineNotifier is implemented by the TA clients that can notify the Trust Agent when its host is quarantined
+type QuarantineNotifier interface {
+	SendQuarantineNotice(notice taModel.QuarantineNotice) error
+}
+
 func NewTAClient(aasApiUrl string, taApiUrl *url.URL, serviceUserName, serviceUserPassword string,
 	trustedCaCerts []x509.Certificate, imaMeasureEnabled bool) (TAClient, error) {
 
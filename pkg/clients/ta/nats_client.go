@@ -226,3 +226,18 @@ func (client *natsTAClient) GetMeasurementFromManifest(manifest taModel.Manifest
 func (client *natsTAClient) GetBaseURL() *url.URL {
 	return nil
 }
+
+// SendQuarantineNotice publishes the notice to the Trust Agent, no reply is expected
+func (client *natsTAClient) SendQuarantineNotice(notice taModel.QuarantineNotice) error {
+	conn, err := client.newNatsConnection()
+	if err != nil {
+		return errors.Wrap(err, "client/nats_client:SendQuarantineNotice() Error establishing connection to nats server")
+	}
+	defer conn.Close()
+
+	err = conn.Publish(taModel.CreateSubject(client.natsHostID, taModel.NatsQuarantineNotice), &notice)
+	if err != nil {
+		return errors.Wrap(err, "client/nats_client:SendQuarantineNotice() Error publishing quarantine notice")
+	}
+	return errors.Wrap(conn.Flush(), "client/nats_client:SendQuarantineNotice() Error flushing quarantine notice")
+}
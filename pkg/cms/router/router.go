@@ -12,6 +12,7 @@ import (
 	"github.com/intel-secl/intel-secl/v5/pkg/cms/constants"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/middleware"
 	cos "github.com/intel-secl/intel-secl/v5/pkg/lib/common/os"
 	"github.com/pkg/errors"
@@ -36,6 +37,7 @@ func InitRoutes(cfg *config.Configuration) *mux.Router {
 	router := mux.NewRouter()
 
 	router.SkipClean(true)
+	router.Use(commMetrics.RequestMetrics())
 	defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg)
 	return router
 }
@@ -47,6 +49,7 @@ func defineSubRoutes(router *mux.Router, service string, cfg *config.Configurati
 	serviceApi := "/" + service + constants.ApiVersion
 	subRouter := router.PathPrefix(serviceApi).Subrouter()
 	subRouter = SetVersionRoutes(subRouter)
+	subRouter = commMetrics.SetMetricsRoutes(subRouter)
 	subRouter = SetCACertificatesRoutes(subRouter)
 
 	subRouter = router.PathPrefix(serviceApi).Subrouter()
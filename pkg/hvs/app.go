@@ -93,6 +93,8 @@ func (a *App) Run(args []string) error {
 			return errInvalidCmd
 		}
 		return a.configDBRotation()
+	case "verify-offline":
+		return a.verifyOffline(args[2:])
 	case "uninstall":
 		// the only allowed flag is --purge
 		purge := false
@@ -10,6 +10,9 @@ import (
 
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/notification"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/remediation"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/reportretention"
 	commConfig "github.com/intel-secl/intel-secl/v5/pkg/lib/common/config"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -53,12 +56,31 @@ const (
 	AuditLogNumRotated  = "audit-log.number-rotated"
 	AuditLogBufferSize  = "audit-log.buffer-size"
 
+	NotificationBufferSize     = "notification.buffer-size"
+	NotificationMaxRetries     = "notification.max-retries"
+	NotificationRetryInterval  = "notification.retry-interval"
+	NotificationRequestTimeout = "notification.request-timeout"
+
+	ReportRetentionRunPeriod         = "report-retention.run-period"
+	ReportRetentionDays              = "report-retention.retention-days"
+	ReportRetentionKeepLatestPerHost = "report-retention.keep-latest-per-host"
+	ReportRetentionBatchSize         = "report-retention.batch-size"
+	ReportRetentionArchive           = "report-retention.archive"
+
+	RemediationUntrustedReportsThreshold = "remediation.untrusted-reports-threshold"
+	RemediationDeleteTagCertificates     = "remediation.delete-tag-certificates"
+	RemediationActions                   = "remediation.actions"
+	RemediationScript                    = "remediation.script"
+	RemediationScriptTimeout             = "remediation.script-timeout"
+
 	AikCertValidity   = "aik-certificate-validity-years"
 	DataEncryptionKey = "data-encryption-key"
 	NatsServers       = "nats.servers"
 
 	RequireEKCertForHostProvision  = "require-ek-cert-for-host-provision"
 	VerifyQuoteForHostRegistration = "verify-quote-for-host-registration"
+
+	HostBundleMaxAge = "host-bundle-max-age"
 )
 
 type Configuration struct {
@@ -84,6 +106,8 @@ type Configuration struct {
 	RequireEKCertForHostProvision  bool `yaml:"require-ek-cert-for-host-provision" mapstructure:"require-ek-cert-for-host-provision"`
 	VerifyQuoteForHostRegistration bool `yaml:"verify-quote-for-host-registration" mapstructure:"verify-quote-for-host-registration"`
 
+	HostBundleMaxAge time.Duration `yaml:"host-bundle-max-age" mapstructure:"host-bundle-max-age"`
+
 	Server                   commConfig.ServerConfig `yaml:"server"`
 	Log                      commConfig.LogConfig    `yaml:"log"`
 	DB                       commConfig.DBConfig     `yaml:"db"`
@@ -92,6 +116,10 @@ type Configuration struct {
 	VCSS                     VCSSConfig              `yaml:"vcss"`
 	NATS                     NatsConfig              `yaml:"nats"`
 	EnableEkCertRevokeChecks bool                    `yaml:"enable-ekcert-revoke-check" mapstructure:"enable-ekcert-revoke-check"`
+
+	Notification    notification.NotificationConfig       `yaml:"notification"`
+	ReportRetention reportretention.ReportRetentionConfig `yaml:"report-retention" mapstructure:"report-retention"`
+	Remediation     remediation.RemediationConfig         `yaml:"remediation"`
 }
 
 type FVSConfig struct {
@@ -75,6 +75,9 @@ const (
 	TagCACertFile = TrustedCaCertsDir + "tag-ca-cert.pem"
 	TagCAKeyFile  = TrustedKeysDir + "tag-ca.key"
 
+	// host bundles pushed by hosts that cannot accept connections from the HVS
+	HostBundlesDir = HomeDir + "host-bundles/"
+
 	// default locations for tls certificate and key
 	DefaultTLSKeyFile  = ConfigDir + "tls.key"
 	DefaultTLSCertFile = ConfigDir + "tls-cert.pem"
@@ -173,9 +176,48 @@ const (
 	DefaultChannelBufferSize = 5000
 )
 
+// notification constants
+const (
+	DefaultNotificationBufferSize     = 1000
+	DefaultNotificationMaxRetries     = 5
+	DefaultNotificationRetryInterval  = time.Duration(30) * time.Second
+	DefaultNotificationRequestTimeout = time.Duration(10) * time.Second
+)
+
+// report retention constants, the report history is kept indefinitely unless the retention days are set
+const (
+	DefaultReportRetentionRunPeriod         = time.Duration(24) * time.Hour
+	DefaultReportRetentionDays              = 0
+	DefaultReportRetentionKeepLatestPerHost = 10
+	DefaultReportRetentionBatchSize         = 500
+	DefaultReportRetentionArchive           = false
+)
+
+// host bundle constants, a host is untrusted once its last host bundle is older than the max age
+const (
+	DefaultHostBundleMaxAge = time.Duration(24) * time.Hour
+	HostBundleMaxClockSkew  = time.Duration(5) * time.Minute
+)
+
+// remediation constants, hosts are never quarantined unless the untrusted reports threshold is set
+const (
+	DefaultRemediationUntrustedReportsThreshold = 0
+	DefaultRemediationDeleteTagCertificates     = false
+	DefaultRemediationScriptTimeout             = time.Duration(30) * time.Second
+)
+
+// attestation schedule constants
+const (
+	MinAttestationScheduleInterval = time.Minute
+)
+
 // Search APIs filter constants
 const (
 	MaxNumDaysSearchLimit = 365
+
+	// headers carrying the paging information of the Search APIs responding with a plain array
+	HTTPHeaderTotalCount = "X-Total-Count"
+	HTTPHeaderLink       = "Link"
 )
 
 //Schema location constants
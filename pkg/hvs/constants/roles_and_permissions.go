@@ -29,17 +29,25 @@ const (
 	HostDelete   = "hosts:delete"
 	HostSearch   = "hosts:search"
 
+	HostBundleCreate = "host_bundles:create"
+
 	//FlavorTemplate Permissions.
 	FlavorTemplateCreate   = "flavor-template:create"
 	FlavorTemplateRetrieve = "flavor-template:retrieve"
 	FlavorTemplateSearch   = "flavor-template:search"
 	FlavorTemplateDelete   = "flavor-template:delete"
+	FlavorTemplateValidate = "flavor-template:validate"
 
 	FlavorCreate   = "flavors:create"
 	FlavorRetrieve = "flavors:retrieve"
 	FlavorSearch   = "flavors:search"
+	FlavorUpdate   = "flavors:store"
 	FlavorDelete   = "flavors:delete"
 
+	FlavorImpactAnalysis = "flavors:analyze"
+	FlavorExport         = "flavors:export"
+	FlavorImport         = "flavors:import"
+
 	TagFlavorCreate        = "tag_flavors:create"
 	HostUniqueFlavorCreate = "host_unique_flavors:create"
 
@@ -61,6 +69,25 @@ const (
 	ReportRetrieve = "reports:retrieve"
 	ReportSearch   = "reports:search"
 
+	ReportRetentionRetrieve = "report_retention:retrieve"
+
+	AuditLogSearch = "audit_logs:search"
+
+	NotificationSubscriptionCreate   = "notification_subscriptions:create"
+	NotificationSubscriptionRetrieve = "notification_subscriptions:retrieve"
+	NotificationSubscriptionSearch   = "notification_subscriptions:search"
+	NotificationSubscriptionDelete   = "notification_subscriptions:delete"
+
+	AttestationScheduleCreate   = "attestation_schedules:create"
+	AttestationScheduleRetrieve = "attestation_schedules:retrieve"
+	AttestationScheduleSearch   = "attestation_schedules:search"
+	AttestationScheduleUpdate   = "attestation_schedules:store"
+	AttestationScheduleDelete   = "attestation_schedules:delete"
+
+	HostQuarantineRetrieve = "host_quarantines:retrieve"
+	HostQuarantineSearch   = "host_quarantines:search"
+	HostQuarantineRelease  = "host_quarantines:release"
+
 	// AssetTagAPI
 	TagCertificateCreate = "tag_certificates:create"
 	TagCertificateDelete = "tag_certificates:delete"
@@ -22,6 +22,8 @@ const (
 	RuleXmlMeasurementLogIntegrity  = RulePrefix + "XmlMeasurementLogIntegrity"
 	RuleImaMeasurementLogIntegrity  = RulePrefix + "ImaMeasurementLogIntegrity"
 	RuleImaEventLogEquals           = RulePrefix + "ImaEventLogEquals"
+	RulePolicyExpressionMatches     = RulePrefix + "PolicyExpressionMatches"
+	RulePcrEventLogReplay           = RulePrefix + "PcrEventLogReplay"
 )
 
 // Verifier Faults
@@ -47,6 +49,9 @@ const (
 	FaultPcrValueMismatch                           = FaultPrefix + "PcrValueMismatch"
 	FaultPcrValueMismatchSHA1                       = FaultPcrValueMismatch + "SHA1"
 	FaultPcrValueMismatchSHA256                     = FaultPcrValueMismatch + "SHA256"
+	FaultPcrValueMismatchSHA384                     = FaultPcrValueMismatch + "SHA384"
+	FaultPcrValueMismatchSHA512                     = FaultPcrValueMismatch + "SHA512"
+	FaultPcrValueMismatchSM3_256                    = FaultPcrValueMismatch + "SM3_256"
 	FaultPcrValueMissing                            = FaultPrefix + "PcrValueMissing"
 	FaultTagCertificateExpired                      = FaultPrefix + "TagCertificateExpired"
 	FaultTagCertificateMissing                      = FaultPrefix + "TagCertificateMissing"
@@ -59,14 +64,18 @@ const (
 	FaultXmlMeasurementLogValueMismatchEntries384   = FaultPrefix + "XmlMeasurementLogValueMismatchEntriesSha384"
 	FaultXmlMeasurementsDigestValueMismatch         = FaultPrefix + "XmlMeasurementsDigestValueMismatch"
 	FaultXmlMeasurementValueMismatch                = FaultPrefix + "XmlMeasurementValueMismatch"
+	FaultPolicyExpressionMismatch                   = FaultPrefix + "PolicyExpressionMismatch"
+	FaultPolicyExpressionInvalid                    = FaultPrefix + "PolicyExpressionInvalid"
 	PcrEventLogUnexpectedFields                     = "PcrEventLogUnexpectedFields"
 	PcrEventLogMissingFields                        = "PcrEventLogMissingFields"
+	PcrEventLogDivergentEvent                       = "PcrEventLogDivergentEvent"
 )
 
 //Builder names
 const (
-	IntelBuilder  = "Intel Host Trust Policy"
-	VmwareBuilder = "VMware Host Trust Policy"
+	IntelBuilder     = "Intel Host Trust Policy"
+	VmwareBuilder    = "VMware Host Trust Policy"
+	MicrosoftBuilder = "Microsoft Host Trust Policy"
 )
 
 //Rule names
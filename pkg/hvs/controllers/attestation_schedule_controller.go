@@ -0,0 +1,269 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type AttestationScheduleController struct {
+	ASStore   domain.AttestationScheduleStore
+	FGStore   domain.FlavorGroupStore
+	Scheduler domain.AttestationScheduler
+}
+
+func NewAttestationScheduleController(as domain.AttestationScheduleStore, fgs domain.FlavorGroupStore, scheduler domain.AttestationScheduler) *AttestationScheduleController {
+	return &AttestationScheduleController{
+		ASStore:   as,
+		FGStore:   fgs,
+		Scheduler: scheduler,
+	}
+}
+
+var attestationScheduleSearchParams = map[string]bool{"flavorgroupId": true}
+
+// Create sets the attestation interval of the hosts linked to a flavorgroup, a flavorgroup has at most one schedule
+func (controller AttestationScheduleController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/attestation_schedule_controller:Create() Entering")
+	defer defaultLog.Trace("controllers/attestation_schedule_controller:Create() Leaving")
+
+	reqSchedule, status, err := decodeAttestationSchedule(r)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if reqSchedule.ID != uuid.Nil || !reqSchedule.CreatedAt.IsZero() {
+		secLog.Errorf("controllers/attestation_schedule_controller:Create() %s : id and created must not be provided", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "id and created must not be provided"}
+	}
+
+	if reqSchedule.FlavorgroupId == uuid.Nil {
+		secLog.Errorf("controllers/attestation_schedule_controller:Create() %s : flavorgroup_id is not provided", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "flavorgroup_id must be provided"}
+	}
+
+	_, err = controller.FGStore.Retrieve(reqSchedule.FlavorgroupId)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("flavorgroupId", reqSchedule.FlavorgroupId).Errorf(
+				"controllers/attestation_schedule_controller:Create() %s : Flavorgroup does not exist", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroup with given ID does not exist"}
+		}
+		defaultLog.WithError(err).Error("controllers/attestation_schedule_controller:Create() Failed to retrieve flavorgroup")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create attestation schedule"}
+	}
+
+	existingSchedules, err := controller.ASStore.Search(&models.AttestationScheduleFilterCriteria{FlavorgroupId: reqSchedule.FlavorgroupId})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/attestation_schedule_controller:Create() Attestation schedule search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create attestation schedule"}
+	}
+	if len(existingSchedules) > 0 {
+		secLog.Errorf("controllers/attestation_schedule_controller:Create() %s : Flavorgroup already has an attestation schedule", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Attestation schedule for the flavorgroup already exists with ID " + existingSchedules[0].ID.String()}
+	}
+
+	schedule, err := controller.ASStore.Create(&hvs.AttestationSchedule{
+		FlavorgroupId: reqSchedule.FlavorgroupId,
+		Interval:      reqSchedule.Interval,
+	})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/attestation_schedule_controller:Create() Attestation schedule create failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create attestation schedule"}
+	}
+
+	secLog.WithField("flavorgroupId", schedule.FlavorgroupId).Infof("%s: Attestation schedule created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return schedule, http.StatusCreated, nil
+}
+
+func (controller AttestationScheduleController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/attestation_schedule_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/attestation_schedule_controller:Retrieve() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	schedule, status, err := controller.retrieveSchedule(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.Infof("%s: Attestation schedule retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return schedule, http.StatusOK, nil
+}
+
+func (controller AttestationScheduleController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/attestation_schedule_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/attestation_schedule_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), attestationScheduleSearchParams); err != nil {
+		secLog.Errorf("controllers/attestation_schedule_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	criteria := &models.AttestationScheduleFilterCriteria{}
+	if flavorgroupId := strings.TrimSpace(r.URL.Query().Get("flavorgroupId")); flavorgroupId != "" {
+		fgId, err := uuid.Parse(flavorgroupId)
+		if err != nil {
+			secLog.Errorf("controllers/attestation_schedule_controller:Search() %s : Invalid flavorgroup ID", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid search criteria provided"}
+		}
+		criteria.FlavorgroupId = fgId
+	}
+
+	schedules, err := controller.ASStore.Search(criteria)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/attestation_schedule_controller:Search() Attestation schedule search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search attestation schedules"}
+	}
+
+	secLog.Infof("%s: Return attestation schedule query result to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.AttestationScheduleCollection{AttestationSchedules: schedules}, http.StatusOK, nil
+}
+
+// Update changes the interval of the schedule, the flavorgroup of a schedule cannot be changed
+func (controller AttestationScheduleController) Update(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/attestation_schedule_controller:Update() Entering")
+	defer defaultLog.Trace("controllers/attestation_schedule_controller:Update() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	reqSchedule, status, err := decodeAttestationSchedule(r)
+	if err != nil {
+		return nil, status, err
+	}
+
+	schedule, status, err := controller.retrieveSchedule(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if (reqSchedule.ID != uuid.Nil && reqSchedule.ID != id) ||
+		(reqSchedule.FlavorgroupId != uuid.Nil && reqSchedule.FlavorgroupId != schedule.FlavorgroupId) {
+		secLog.Errorf("controllers/attestation_schedule_controller:Update() %s : id and flavorgroup_id cannot be changed", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "id and flavorgroup_id of an attestation schedule cannot be changed"}
+	}
+
+	schedule.Interval = reqSchedule.Interval
+	updatedSchedule, err := controller.ASStore.Update(schedule)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/attestation_schedule_controller:Update() Attestation schedule update failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update attestation schedule"}
+	}
+
+	secLog.WithField("flavorgroupId", updatedSchedule.FlavorgroupId).Infof("%s: Attestation schedule updated by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return updatedSchedule, http.StatusOK, nil
+}
+
+// Delete removes the schedule, the hosts of the flavorgroup are then refreshed from the expiration of their reports
+func (controller AttestationScheduleController) Delete(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/attestation_schedule_controller:Delete() Entering")
+	defer defaultLog.Trace("controllers/attestation_schedule_controller:Delete() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	schedule, status, err := controller.retrieveSchedule(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if err := controller.ASStore.Delete(id); err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/attestation_schedule_controller:Delete() Failed to delete attestation schedule")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete attestation schedule"}
+	}
+
+	secLog.WithField("flavorgroupId", schedule.FlavorgroupId).Infof("%s: Attestation schedule deleted by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return nil, http.StatusNoContent, nil
+}
+
+// SearchScheduledAttestations returns the next attestation of each host linked to the flavorgroup of the schedule
+func (controller AttestationScheduleController) SearchScheduledAttestations(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/attestation_schedule_controller:SearchScheduledAttestations() Entering")
+	defer defaultLog.Trace("controllers/attestation_schedule_controller:SearchScheduledAttestations() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	schedule, status, err := controller.retrieveSchedule(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	scheduledAttestations, err := controller.Scheduler.GetScheduledAttestations(schedule)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/attestation_schedule_controller:SearchScheduledAttestations() Failed to get scheduled attestations")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search scheduled attestations"}
+	}
+
+	secLog.Infof("%s: Return scheduled attestations to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.ScheduledAttestationCollection{ScheduledAttestations: scheduledAttestations}, http.StatusOK, nil
+}
+
+func (controller AttestationScheduleController) retrieveSchedule(id uuid.UUID) (*hvs.AttestationSchedule, int, error) {
+	schedule, err := controller.ASStore.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/attestation_schedule_controller:retrieveSchedule() Attestation schedule with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Attestation schedule with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/attestation_schedule_controller:retrieveSchedule() Failed to retrieve attestation schedule")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve attestation schedule"}
+	}
+	return schedule, http.StatusOK, nil
+}
+
+// decodeAttestationSchedule decodes the schedule in the request body and validates its interval
+func decodeAttestationSchedule(r *http.Request) (*hvs.AttestationSchedule, int, error) {
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/attestation_schedule_controller:decodeAttestationSchedule() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var reqSchedule hvs.AttestationSchedule
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqSchedule); err != nil {
+		secLog.WithError(err).Errorf("controllers/attestation_schedule_controller:decodeAttestationSchedule() %s : Failed to decode"+
+			" request body as AttestationSchedule", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateAttestationScheduleInterval(reqSchedule.Interval); err != nil {
+		secLog.WithError(err).Errorf("controllers/attestation_schedule_controller:decodeAttestationSchedule() %s : Invalid"+
+			" attestation schedule interval", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	return &reqSchedule, http.StatusOK, nil
+}
+
+func validateAttestationScheduleInterval(interval string) error {
+	if interval == "" {
+		return errors.New("interval must be provided")
+	}
+	duration, err := time.ParseDuration(interval)
+	if err != nil {
+		return errors.New("Invalid interval, use a duration such as 5m or 24h")
+	}
+	if duration < constants.MinAttestationScheduleInterval {
+		return errors.Errorf("interval must be at least %s", constants.MinAttestationScheduleInterval)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v5/pkg/hvs/router"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/hrrs"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AttestationScheduleController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var attestationScheduleStore *mocks.MockAttestationScheduleStore
+	var flavorgroupStore *mocks.MockFlavorgroupStore
+	var attestationScheduleController *controllers.AttestationScheduleController
+
+	const scheduleId = "3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c"
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		attestationScheduleStore = mocks.NewMockAttestationScheduleStore()
+		flavorgroupStore = mocks.NewFakeFlavorgroupStore()
+		reportStore := mocks.NewEmptyMockReportStore()
+		scheduler, err := hrrs.NewHostReportRefresher(hrrs.HRRSConfig{RefreshPeriod: hrrs.DefaultRefreshPeriod}, reportStore,
+			attestationScheduleStore, flavorgroupStore, nil)
+		Expect(err).NotTo(HaveOccurred())
+		attestationScheduleController = controllers.NewAttestationScheduleController(attestationScheduleStore, flavorgroupStore, scheduler)
+	})
+
+	send := func(method, path, body string) {
+		req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+	}
+
+	// Specs for HTTP Post to "/attestation-schedules"
+	Describe("Create attestation schedule", func() {
+		BeforeEach(func() {
+			router.Handle("/attestation-schedules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(attestationScheduleController.Create))).Methods(http.MethodPost)
+		})
+
+		Context("When a valid schedule is provided", func() {
+			It("Should create the schedule", func() {
+				send(http.MethodPost, "/attestation-schedules", `{"flavorgroup_id":"ee37c360-7eae-4250-a677-6ee12adce8e3","interval":"24h"}`)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var schedule hvs.AttestationSchedule
+				Expect(json.Unmarshal(w.Body.Bytes(), &schedule)).To(Succeed())
+				Expect(schedule.ID).NotTo(Equal(uuid.Nil))
+				Expect(schedule.Interval).To(Equal("24h"))
+			})
+		})
+		Context("When the flavorgroup already has a schedule", func() {
+			It("Should return bad request", func() {
+				send(http.MethodPost, "/attestation-schedules", `{"flavorgroup_id":"ee37c360-7eae-4250-a677-6ee12adce8e2","interval":"1h"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the flavorgroup does not exist", func() {
+			It("Should return bad request", func() {
+				send(http.MethodPost, "/attestation-schedules", `{"flavorgroup_id":"73755fda-c910-46be-821f-e8ddeab189e9","interval":"1h"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the interval is shorter than a minute", func() {
+			It("Should return bad request", func() {
+				send(http.MethodPost, "/attestation-schedules", `{"flavorgroup_id":"ee37c360-7eae-4250-a677-6ee12adce8e3","interval":"30s"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/attestation-schedules"
+	Describe("Search attestation schedules", func() {
+		BeforeEach(func() {
+			router.Handle("/attestation-schedules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(attestationScheduleController.Search))).Methods(http.MethodGet)
+		})
+
+		Context("When filtered by flavorgroup", func() {
+			It("Should return the schedule of the flavorgroup", func() {
+				send(http.MethodGet, "/attestation-schedules?flavorgroupId=ee37c360-7eae-4250-a677-6ee12adce8e2", "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var schedules hvs.AttestationScheduleCollection
+				Expect(json.Unmarshal(w.Body.Bytes(), &schedules)).To(Succeed())
+				Expect(schedules.AttestationSchedules).To(HaveLen(1))
+			})
+		})
+		Context("When an invalid flavorgroup ID is provided", func() {
+			It("Should return bad request", func() {
+				send(http.MethodGet, "/attestation-schedules?flavorgroupId=automatic", "")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Put to "/attestation-schedules/{id}"
+	Describe("Update attestation schedule", func() {
+		BeforeEach(func() {
+			router.Handle("/attestation-schedules/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(attestationScheduleController.Update))).Methods(http.MethodPut)
+		})
+
+		Context("When a new interval is provided", func() {
+			It("Should update the interval", func() {
+				send(http.MethodPut, "/attestation-schedules/"+scheduleId, `{"interval":"15m"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				schedule, _ := attestationScheduleStore.Retrieve(uuid.MustParse(scheduleId))
+				Expect(schedule.Interval).To(Equal("15m"))
+			})
+		})
+		Context("When the flavorgroup is changed", func() {
+			It("Should return bad request", func() {
+				send(http.MethodPut, "/attestation-schedules/"+scheduleId, `{"flavorgroup_id":"ee37c360-7eae-4250-a677-6ee12adce8e3","interval":"15m"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the schedule does not exist", func() {
+			It("Should return not found", func() {
+				send(http.MethodPut, "/attestation-schedules/73755fda-c910-46be-821f-e8ddeab189e9", `{"interval":"15m"}`)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Delete to "/attestation-schedules/{id}"
+	Describe("Delete attestation schedule", func() {
+		BeforeEach(func() {
+			router.Handle("/attestation-schedules/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(attestationScheduleController.Delete))).Methods(http.MethodDelete)
+		})
+
+		Context("When the schedule exists", func() {
+			It("Should delete the schedule", func() {
+				send(http.MethodDelete, "/attestation-schedules/"+scheduleId, "")
+				Expect(w.Code).To(Equal(http.StatusNoContent))
+
+				schedules, _ := attestationScheduleStore.Search(&models.AttestationScheduleFilterCriteria{})
+				Expect(schedules).To(BeEmpty())
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/attestation-schedules/{id}/hosts"
+	Describe("Search scheduled attestations", func() {
+		BeforeEach(func() {
+			router.Handle("/attestation-schedules/{id}/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(attestationScheduleController.SearchScheduledAttestations))).Methods(http.MethodGet)
+		})
+
+		Context("When a host is linked to the flavorgroup of the schedule", func() {
+			It("Should return the next run of the host", func() {
+				hostId := uuid.New()
+				flavorgroupStore.HostFlavorgroupStore = []*hvs.HostFlavorgroup{
+					{HostId: hostId, FlavorgroupId: uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")},
+				}
+
+				send(http.MethodGet, "/attestation-schedules/"+scheduleId+"/hosts", "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var scheduledAttestations hvs.ScheduledAttestationCollection
+				Expect(json.Unmarshal(w.Body.Bytes(), &scheduledAttestations)).To(Succeed())
+				Expect(scheduledAttestations.ScheduledAttestations).To(HaveLen(1))
+				Expect(scheduledAttestations.ScheduledAttestations[0].HostId).To(Equal(hostId))
+				Expect(scheduledAttestations.ScheduledAttestations[0].Interval).To(Equal("5m"))
+				// the host without report is attested on the next refresh cycle
+				Expect(scheduledAttestations.ScheduledAttestations[0].LastAttestation).To(BeNil())
+				Expect(scheduledAttestations.ScheduledAttestations[0].NextRun).To(BeTemporally("<=", time.Now()))
+			})
+		})
+		Context("When the schedule does not exist", func() {
+			It("Should return not found", func() {
+				send(http.MethodGet, "/attestation-schedules/73755fda-c910-46be-821f-e8ddeab189e9/hosts", "")
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,165 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type AuditLogController struct {
+	Store domain.AuditLogEntryStore
+}
+
+func NewAuditLogController(store domain.AuditLogEntryStore) *AuditLogController {
+	return &AuditLogController{Store: store}
+}
+
+var auditLogSearchParams = map[string]bool{"entityId": true, "entityType": true, "action": true, "fromDate": true,
+	"toDate": true, "limit": true, "afterId": true, "cursor": true, "includeTotal": true, "sortBy": true, "orderBy": true}
+
+var auditLogActions = map[string]bool{"create": true, "update": true, "delete": true}
+
+func (controller AuditLogController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/audit_log_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/audit_log_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), auditLogSearchParams); err != nil {
+		secLog.Errorf("controllers/audit_log_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	filter, err := getAuditLogFilterCriteria(r.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/audit_log_controller:Search() %s Invalid filter criteria", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid filter criteria"}
+	}
+
+	entries, err := controller.Store.Search(filter)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/audit_log_controller:Search() Audit log search operation failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Audit log search operation failed"}
+	}
+
+	total := 0
+	if filter.IncludeTotal {
+		total, err = controller.Store.Count(filter)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/audit_log_controller:Search() Audit log count operation failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Audit log search operation failed"}
+		}
+	}
+
+	var next, prev string
+	if len(entries) > 0 {
+		lastRowId := entries[len(entries)-1].RowId
+		next, prev = GetPageLinks(filter.PageCriteria, lastRowId, len(entries))
+	}
+
+	auditLogCollection := hvs.AuditLogEntryCollection{
+		AuditLogEntries: []hvs.AuditLogEntry{}, Total: total, Next: next, Previous: prev,
+	}
+	for _, entry := range entries {
+		auditLogCollection.AuditLogEntries = append(auditLogCollection.AuditLogEntries, convertToAuditLogEntry(entry))
+	}
+
+	secLog.Infof("%s: Audit logs searched by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return auditLogCollection, http.StatusOK, nil
+}
+
+// getAuditLogFilterCriteria checks for set filter params in the Search request and returns a valid AuditLogEntryFilterCriteria
+func getAuditLogFilterCriteria(params url.Values) (*models.AuditLogEntryFilterCriteria, error) {
+	defaultLog.Trace("controllers/audit_log_controller:getAuditLogFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/audit_log_controller:getAuditLogFilterCriteria() Leaving")
+
+	criteria := models.AuditLogEntryFilterCriteria{}
+
+	entityId := strings.TrimSpace(params.Get("entityId"))
+	if entityId != "" {
+		id, err := uuid.Parse(entityId)
+		if err != nil {
+			return nil, errors.New("Invalid UUID format of the entityId specified")
+		}
+		criteria.EntityID = id
+	}
+
+	entityType := strings.TrimSpace(params.Get("entityType"))
+	if entityType != "" {
+		if err := validation.ValidateIdentifier(entityType); err != nil {
+			return nil, errors.Wrap(err, "Valid contents for entityType must be specified")
+		}
+		criteria.EntityType = entityType
+	}
+
+	action := strings.ToLower(strings.TrimSpace(params.Get("action")))
+	if action != "" {
+		if !auditLogActions[action] {
+			return nil, errors.New("action must be one of create, update or delete")
+		}
+		criteria.Action = action
+	}
+
+	fromDate := strings.TrimSpace(params.Get("fromDate"))
+	if fromDate != "" {
+		pTime, err := utils.ParseDateQueryParam(fromDate)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid fromDate specified")
+		}
+		criteria.FromDate = pTime
+	}
+
+	toDate := strings.TrimSpace(params.Get("toDate"))
+	if toDate != "" {
+		pTime, err := utils.ParseDateQueryParam(toDate)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid toDate specified")
+		}
+		criteria.ToDate = pTime
+	}
+
+	if !criteria.FromDate.IsZero() && !criteria.ToDate.IsZero() && criteria.ToDate.Before(criteria.FromDate) {
+		return nil, errors.New("toDate must not be before fromDate")
+	}
+
+	page, err := PopulatePageCriteria(params, models.AuditLogEntrySortFields)
+	if err != nil {
+		return nil, err
+	}
+	criteria.PageCriteria = *page
+
+	return &criteria, nil
+}
+
+func convertToAuditLogEntry(entry models.AuditLogEntry) hvs.AuditLogEntry {
+	columns := make([]hvs.AuditLogColumn, 0, len(entry.Data.Columns))
+	for _, c := range entry.Data.Columns {
+		columns = append(columns, hvs.AuditLogColumn{
+			Name:      c.Name,
+			Value:     c.Value,
+			IsUpdated: c.IsUpdated,
+		})
+	}
+	return hvs.AuditLogEntry{
+		RowId:      entry.RowId,
+		ID:         entry.ID,
+		EntityID:   entry.EntityID,
+		EntityType: entry.EntityType,
+		CreatedAt:  entry.CreatedAt,
+		Action:     entry.Action,
+		Data:       hvs.AuditLogData{Columns: columns},
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	hvsRoutes "github.com/intel-secl/intel-secl/v5/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditLogController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var auditLogController *controllers.AuditLogController
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		auditLogController = controllers.NewAuditLogController(mocks.NewMockAuditLogEntryStore())
+		router.Handle("/audit-logs", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(auditLogController.Search))).Methods(http.MethodGet)
+	})
+
+	// Specs for HTTP Get to "/audit-logs"
+	Describe("Search audit log entries", func() {
+		search := func(query string) *hvs.AuditLogEntryCollection {
+			req, err := http.NewRequest(http.MethodGet, "/audit-logs"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				return nil
+			}
+			var collection hvs.AuditLogEntryCollection
+			err = json.Unmarshal(w.Body.Bytes(), &collection)
+			Expect(err).NotTo(HaveOccurred())
+			return &collection
+		}
+
+		Context("When no filter criteria is provided", func() {
+			It("Should return all audit log entries", func() {
+				collection := search("")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(collection.AuditLogEntries).To(HaveLen(3))
+			})
+		})
+		Context("When filtered by entity type and action", func() {
+			It("Should return the matching audit log entries", func() {
+				collection := search("?entityType=report&action=delete")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(collection.AuditLogEntries).To(HaveLen(1))
+				Expect(collection.AuditLogEntries[0].Action).To(Equal("delete"))
+			})
+		})
+		Context("When filtered by entity ID and time range", func() {
+			It("Should return the matching audit log entries", func() {
+				collection := search("?entityId=15701f03-7b1d-461c-8e1d-8f1d8a3b9c20&fromDate=2022-03-01&toDate=2022-03-02")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(collection.AuditLogEntries).To(HaveLen(1))
+				Expect(collection.AuditLogEntries[0].Action).To(Equal("create"))
+			})
+		})
+		Context("When limit and afterId are provided", func() {
+			It("Should return a page of audit log entries with next and prev links", func() {
+				collection := search("?limit=1&afterId=1")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(collection.AuditLogEntries).To(HaveLen(1))
+				Expect(collection.AuditLogEntries[0].EntityType).To(Equal("host_status"))
+				Expect(collection.Next).To(Equal("limit=1&afterId=2"))
+				Expect(collection.Previous).To(Equal("limit=1&afterId=0"))
+			})
+		})
+		Context("When an invalid action is provided", func() {
+			It("Should return bad request", func() {
+				search("?action=drop")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When an invalid entity ID is provided", func() {
+			It("Should return bad request", func() {
+				search("?entityId=abc")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When an unknown query parameter is provided", func() {
+			It("Should return bad request", func() {
+				search("?hostName=host1")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
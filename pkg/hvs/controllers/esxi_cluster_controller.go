@@ -38,7 +38,8 @@ func NewESXiClusterController(ec domain.ESXiClusterStore, hc HostController) *ES
 	}
 }
 
-var esxiClusterSearchParams = map[string]bool{"id": true, "clusterName": true, "afterId": true, "limit": true}
+var esxiClusterSearchParams = map[string]bool{"id": true, "clusterName": true, "afterId": true, "limit": true,
+	"cursor": true, "includeTotal": true, "sortBy": true, "orderBy": true}
 
 func (controller ESXiClusterController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/esxi_cluster_controller:Create() Entering")
@@ -157,6 +158,15 @@ func (controller ESXiClusterController) Search(w http.ResponseWriter, r *http.Re
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search ESXi cluster"}
 	}
 
+	total := 0
+	if filter.IncludeTotal {
+		total, err = controller.ECStore.Count(filter)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/esxi_cluster_controller:Search() ESXi cluster count failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search ESXi cluster"}
+		}
+	}
+
 	for index, cluster := range esxiClusters {
 		esxiClusters[index].HostNames, err = controller.ECStore.SearchHosts(cluster.Id)
 		if err != nil {
@@ -170,10 +180,10 @@ func (controller ESXiClusterController) Search(w http.ResponseWriter, r *http.Re
 	var next, prev string
 	if len(esxiClusters) > 0 {
 		lastRowId := esxiClusters[len(esxiClusters)-1].RowId
-		next, prev = GetNextAndPrevValues(filter.Limit, filter.AfterId, lastRowId, len(esxiClusters))
+		next, prev = GetPageLinks(filter.PageCriteria, lastRowId, len(esxiClusters))
 	}
 
-	esxiClusterCollection := hvs.ESXiClusterCollection{ESXiCluster: esxiClusters, Next: next, Previous: prev}
+	esxiClusterCollection := hvs.ESXiClusterCollection{ESXiCluster: esxiClusters, Total: total, Next: next, Previous: prev}
 
 	secLog.Infof("%s: Return ESXi cluster query result to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
 	return esxiClusterCollection, http.StatusOK, nil
@@ -310,12 +320,11 @@ func getECCriteria(params url.Values) (*models.ESXiClusterFilterCriteria, error)
 		ecfc.ClusterName = params.Get("clusterName")
 	}
 
-	limit, afterid, err := validation.ValidatePaginationValues(params.Get("limit"), params.Get("afterId"))
+	page, err := PopulatePageCriteria(params, models.ESXiClusterSortFields)
 	if err != nil {
 		return nil, err
 	}
-	ecfc.Limit = limit
-	ecfc.AfterId = afterid
+	ecfc.PageCriteria = *page
 
 	return &ecfc, nil
 }
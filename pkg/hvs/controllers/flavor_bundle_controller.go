@@ -0,0 +1,552 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	dm "github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
+	fu "github.com/intel-secl/intel-secl/v5/pkg/lib/flavor/util"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const (
+	flavorBundleConflictFlavor         = "flavor"
+	flavorBundleConflictFlavorgroup    = "flavorgroup"
+	flavorBundleConflictFlavorTemplate = "flavor_template"
+)
+
+var flavorExportParams = map[string]bool{"id": true, "flavorgroupId": true, "flavorParts": true}
+var flavorImportParams = map[string]bool{"dryRun": true}
+
+// Export creates a signed bundle of the flavors matching the filter along with the flavorgroups they belong to and
+// the flavor templates linked to these flavorgroups. Host unique and asset tag flavors are bound to a single host
+// and are never exported.
+func (fcon *FlavorController) Export(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:Export() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:Export() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), flavorExportParams); err != nil {
+		secLog.Errorf("controllers/flavor_bundle_controller:Export() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	ids := r.URL.Query()["id"]
+	flavorgroupId := r.URL.Query().Get("flavorgroupId")
+	flavorParts := r.URL.Query()["flavorParts"]
+	if len(ids) == 0 && flavorgroupId == "" && len(flavorParts) == 0 {
+		secLog.Errorf("controllers/flavor_bundle_controller:Export() %s : No filter provided", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "One of id, flavorgroupId or flavorParts must be provided"}
+	}
+
+	filterCriteria, err := validateFlavorFilterCriteria("", "", flavorgroupId, ids, flavorParts, "", "")
+	if err != nil {
+		secLog.Errorf("controllers/flavor_bundle_controller:Export() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	// the bundle holds all the matching flavors
+	filterCriteria.Limit = 0
+
+	signedFlavors, err := fcon.FStore.Search(&dm.FlavorVerificationFC{
+		FlavorFC: *filterCriteria,
+	})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Export() Flavor search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Flavors"}
+	}
+
+	bundle := hvs.FlavorBundle{
+		Created:       time.Now().UTC(),
+		SignedFlavors: []hvs.SignedFlavor{},
+	}
+	exportedFlavors := make(map[uuid.UUID]bool)
+	for _, signedFlavor := range signedFlavors {
+		flavorPart, _ := signedFlavor.Flavor.Meta.Description[hvs.FlavorPartDescription].(string)
+		if hvs.FlavorPartsNotFilteredForLatestFlavor[hvs.FlavorPartName(flavorPart)] {
+			defaultLog.Debugf("controllers/flavor_bundle_controller:Export() Skipping %s flavor %s", flavorPart, signedFlavor.Flavor.Meta.ID)
+			continue
+		}
+		if exportedFlavors[signedFlavor.Flavor.Meta.ID] {
+			continue
+		}
+		exportedFlavors[signedFlavor.Flavor.Meta.ID] = true
+		bundle.SignedFlavors = append(bundle.SignedFlavors, hvs.SignedFlavor{
+			Flavor:    signedFlavor.Flavor,
+			Signature: signedFlavor.Signature,
+		})
+	}
+	if len(bundle.SignedFlavors) == 0 {
+		secLog.Info("controllers/flavor_bundle_controller:Export() No flavors found to export")
+		return nil, http.StatusNotFound, &commErr.ResourceError{Message: "No flavors found to export"}
+	}
+
+	if err = fcon.addFlavorgroupsToBundle(&bundle, exportedFlavors, filterCriteria.FlavorgroupID); err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Export() Failed to add flavorgroups to flavor bundle")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve flavorgroups of the Flavors"}
+	}
+
+	flavorSignKey, signingCerts, err := (*fcon.CertStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+	if err != nil || flavorSignKey == nil || len(signingCerts) == 0 {
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Export() Flavor signing key or certificate not found")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to sign flavor bundle"}
+	}
+	var signingCertChain bytes.Buffer
+	for _, cert := range signingCerts {
+		if err = pem.Encode(&signingCertChain, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Export() Failed to encode flavor signing certificate")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to sign flavor bundle"}
+		}
+	}
+
+	signedBundle, err := hvs.NewSignedFlavorBundle(&bundle, flavorSignKey.(*rsa.PrivateKey), signingCertChain.String())
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Export() Failed to sign flavor bundle")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to sign flavor bundle"}
+	}
+
+	secLog.Infof("%s: Flavor bundle with %d flavors exported to: %s", commLogMsg.AuthorizedAccess, len(bundle.SignedFlavors), r.RemoteAddr)
+	return signedBundle, http.StatusOK, nil
+}
+
+// addFlavorgroupsToBundle adds the flavorgroups linked to the exported flavors, the flavor IDs of each flavorgroup
+// are restricted to the exported flavors
+func (fcon *FlavorController) addFlavorgroupsToBundle(bundle *hvs.FlavorBundle, exportedFlavors map[uuid.UUID]bool, flavorgroupId uuid.UUID) error {
+	defaultLog.Trace("controllers/flavor_bundle_controller:addFlavorgroupsToBundle() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:addFlavorgroupsToBundle() Leaving")
+
+	fgCriteria := dm.FlavorGroupFilterCriteria{}
+	if flavorgroupId != uuid.Nil {
+		fgCriteria.Ids = []uuid.UUID{flavorgroupId}
+	}
+	flavorgroups, err := fcon.FGStore.Search(&fgCriteria)
+	if err != nil {
+		return errors.Wrap(err, "Failed to search flavorgroups")
+	}
+
+	exportedTemplates := make(map[uuid.UUID]bool)
+	for _, fg := range flavorgroups {
+		fgFlavorIds, err := fcon.FGStore.SearchFlavors(fg.ID)
+		if err != nil && !strings.Contains(err.Error(), commErr.RowsNotFound) {
+			return errors.Wrapf(err, "Failed to search flavors of flavorgroup %s", fg.ID)
+		}
+		var flavorIds []uuid.UUID
+		for _, fId := range fgFlavorIds {
+			if exportedFlavors[fId] {
+				flavorIds = append(flavorIds, fId)
+			}
+		}
+		if len(flavorIds) == 0 {
+			continue
+		}
+
+		templateIds, err := fcon.FGStore.SearchFlavorTemplatesByFlavorGroup(fg.ID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to search flavor templates of flavorgroup %s", fg.ID)
+		}
+		for _, templateId := range templateIds {
+			if exportedTemplates[templateId] {
+				continue
+			}
+			template, err := fcon.FTStore.Retrieve(templateId, false)
+			if err != nil {
+				// deleted templates are not applied to the flavorgroup anymore
+				defaultLog.WithError(err).Debugf("controllers/flavor_bundle_controller:addFlavorgroupsToBundle() Skipping flavor template %s", templateId)
+				continue
+			}
+			exportedTemplates[templateId] = true
+			bundle.FlavorTemplates = append(bundle.FlavorTemplates, *template)
+		}
+
+		var fgTemplateIds []uuid.UUID
+		for _, templateId := range templateIds {
+			if exportedTemplates[templateId] {
+				fgTemplateIds = append(fgTemplateIds, templateId)
+			}
+		}
+		bundle.FlavorGroups = append(bundle.FlavorGroups, hvs.FlavorGroup{
+			ID:                fg.ID,
+			Name:              fg.Name,
+			FlavorIds:         flavorIds,
+			FlavorTemplateIds: fgTemplateIds,
+			MatchPolicies:     fg.MatchPolicies,
+			PolicyRules:       fg.PolicyRules,
+		})
+	}
+	return nil
+}
+
+// Import verifies a signed flavor bundle exported by an HVS trusted by the configured flavor signing CA and stores
+// its content. The flavors are signed again with the flavor signing key of this HVS. Nothing is imported when an item
+// of the bundle conflicts with the existing data, the conflicts are reported instead. With dryRun the import report
+// is returned without storing anything.
+func (fcon *FlavorController) Import(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:Import() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:Import() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		secLog.Error("controllers/flavor_bundle_controller:Import() Invalid Content-Type")
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+	if err := utils.ValidateQueryParams(r.URL.Query(), flavorImportParams); err != nil {
+		secLog.Errorf("controllers/flavor_bundle_controller:Import() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	dryRun := false
+	if dryRunParam := r.URL.Query().Get("dryRun"); dryRunParam != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(dryRunParam); err != nil {
+			secLog.Errorf("controllers/flavor_bundle_controller:Import() %s : Invalid dryRun value", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "dryRun must be true or false"}
+		}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_bundle_controller:Import() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var signedBundle hvs.SignedFlavorBundle
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&signedBundle); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_bundle_controller:Import() %s : Failed to decode request body as signed flavor bundle", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := fcon.verifyFlavorBundle(&signedBundle); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_bundle_controller:Import() %s : Flavor bundle verification failed", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	bundle := &signedBundle.Bundle
+	report, existingFlavorgroups, err := fcon.planFlavorBundleImport(bundle)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Import() Failed to compare flavor bundle with existing data")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to import flavor bundle"}
+	}
+	report.DryRun = dryRun
+	if dryRun {
+		return report, http.StatusOK, nil
+	}
+	if len(report.Conflicts) > 0 {
+		secLog.Infof("controllers/flavor_bundle_controller:Import() Flavor bundle has %d conflicts, nothing imported", len(report.Conflicts))
+		return report, http.StatusConflict, nil
+	}
+
+	if err = fcon.importFlavorBundle(bundle, report, existingFlavorgroups); err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:Import() Failed to import flavor bundle")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to import flavor bundle"}
+	}
+
+	secLog.Infof("%s: Flavor bundle with %d flavors imported by: %s", commLogMsg.PrivilegeModified, len(report.ImportedFlavors), r.RemoteAddr)
+	return report, http.StatusOK, nil
+}
+
+// verifyFlavorBundle checks that the bundle signing certificate is a signing certificate issued by the CA of the flavor
+// signing certificate of this HVS and that the bundle and the flavors are signed by it
+func (fcon *FlavorController) verifyFlavorBundle(signedBundle *hvs.SignedFlavorBundle) error {
+	defaultLog.Trace("controllers/flavor_bundle_controller:verifyFlavorBundle() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:verifyFlavorBundle() Leaving")
+
+	if signedBundle.SigningCertificate == "" {
+		return errors.New("The flavor bundle signing certificate is not provided")
+	}
+	signingCerts, err := crypt.GetX509CertsFromPem([]byte(signedBundle.SigningCertificate))
+	if err != nil || len(signingCerts) == 0 {
+		return errors.New("Invalid flavor bundle signing certificate")
+	}
+	if err = verifyFlavorBundleSigningCertificate(&signingCerts[0], fcon.CertStore); err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_bundle_controller:verifyFlavorBundle() Flavor bundle signing certificate verification failed")
+		return errors.New("The flavor bundle signing certificate is not trusted")
+	}
+
+	publicKey, ok := signingCerts[0].PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("The flavor bundle signing certificate must have an RSA public key")
+	}
+	if err = signedBundle.Verify(publicKey); err != nil {
+		return errors.New("Invalid flavor bundle signature")
+	}
+
+	if len(signedBundle.Bundle.SignedFlavors) == 0 {
+		return errors.New("The flavor bundle does not contain any flavor")
+	}
+	for i := range signedBundle.Bundle.SignedFlavors {
+		signedFlavor := &signedBundle.Bundle.SignedFlavors[i]
+		if err = validateFlavorMetaContent(&signedFlavor.Flavor.Meta); err != nil {
+			return errors.Wrapf(err, "Invalid flavor %s", signedFlavor.Flavor.Meta.ID)
+		}
+		if signedFlavor.Flavor.Meta.ID == uuid.Nil {
+			return errors.New("Flavor ID must be provided for each flavor of the bundle")
+		}
+		flavorPart := hvs.FlavorPartName(signedFlavor.Flavor.Meta.Description[hvs.FlavorPartDescription].(string))
+		if hvs.FlavorPartsNotFilteredForLatestFlavor[flavorPart] {
+			return errors.Errorf("%s flavors cannot be imported", flavorPart)
+		}
+		if err = signedFlavor.Verify(publicKey); err != nil {
+			return errors.Errorf("Invalid signature of flavor %s", signedFlavor.Flavor.Meta.ID)
+		}
+	}
+	for _, fg := range signedBundle.Bundle.FlavorGroups {
+		if fg.Name == "" {
+			return errors.New("Flavorgroup name must be provided for each flavorgroup of the bundle")
+		}
+	}
+	for _, template := range signedBundle.Bundle.FlavorTemplates {
+		if template.ID == uuid.Nil {
+			return errors.New("Flavor template ID must be provided for each flavor template of the bundle")
+		}
+	}
+	return nil
+}
+
+// verifyFlavorBundleSigningCertificate checks that the certificate is issued by the flavor signing CA, which is the
+// issuer of the flavor signing certificate of this HVS, and that it has the key usages of a signing certificate. The
+// CMS issues TLS certificates from another CA so they cannot be used to sign flavor bundles.
+func verifyFlavorBundleSigningCertificate(signingCert *x509.Certificate, certStore *crypt.CertificatesStore) error {
+	_, flavorSigningCerts, err := (*certStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+	if err != nil || len(flavorSigningCerts) < 2 {
+		return errors.New("The flavor signing certificate chain does not contain the flavor signing CA")
+	}
+	flavorSigningCAs := flavorSigningCerts[1:]
+	_, rootCAs, _ := (*certStore).GetKeyAndCertificates(dm.CaCertTypesRootCa.String())
+
+	if signingCert.IsCA || signingCert.KeyUsage&x509.KeyUsageDigitalSignature == 0 ||
+		signingCert.KeyUsage&x509.KeyUsageContentCommitment == 0 {
+		return errors.New("The certificate does not have the key usages of a signing certificate")
+	}
+
+	// the signing certificates of the CMS have no extended key usage
+	chains, err := signingCert.Verify(x509.VerifyOptions{
+		Roots:         crypt.GetCertPool(rootCAs),
+		Intermediates: crypt.GetCertPool(flavorSigningCAs),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return errors.Wrap(err, "The certificate chain could not be verified")
+	}
+	for _, chain := range chains {
+		if len(chain) < 2 {
+			continue
+		}
+		for i := range flavorSigningCAs {
+			if chain[1].Equal(&flavorSigningCAs[i]) {
+				return nil
+			}
+		}
+	}
+	return errors.New("The certificate is not issued by the flavor signing CA")
+}
+
+// planFlavorBundleImport compares the content of the bundle with the existing flavors, flavorgroups and flavor
+// templates. The existing flavorgroups are returned by name.
+func (fcon *FlavorController) planFlavorBundleImport(bundle *hvs.FlavorBundle) (*hvs.FlavorBundleImportReport, map[string]hvs.FlavorGroup, error) {
+	defaultLog.Trace("controllers/flavor_bundle_controller:planFlavorBundleImport() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:planFlavorBundleImport() Leaving")
+
+	report := &hvs.FlavorBundleImportReport{}
+
+	for _, template := range bundle.FlavorTemplates {
+		existing, err := fcon.FTStore.Retrieve(template.ID, true)
+		if err != nil {
+			if _, ok := err.(*commErr.StatusNotFoundError); !ok {
+				return nil, nil, errors.Wrapf(err, "Failed to retrieve flavor template %s", template.ID)
+			}
+			report.CreatedFlavorTemplates = append(report.CreatedFlavorTemplates, template.ID)
+			continue
+		}
+		if _, err = fcon.FTStore.Retrieve(template.ID, false); err != nil {
+			report.Conflicts = append(report.Conflicts, hvs.FlavorBundleConflict{Type: flavorBundleConflictFlavorTemplate,
+				ID: template.ID, Name: template.Label, Reason: "Flavor template with the same ID was deleted"})
+		} else if !equalJson(existing, &template) {
+			report.Conflicts = append(report.Conflicts, hvs.FlavorBundleConflict{Type: flavorBundleConflictFlavorTemplate,
+				ID: template.ID, Name: template.Label, Reason: "Flavor template with the same ID has a different content"})
+		} else {
+			report.ExistingFlavorTemplates = append(report.ExistingFlavorTemplates, template.ID)
+		}
+	}
+
+	existingFlavorgroups := make(map[string]hvs.FlavorGroup)
+	for _, fg := range bundle.FlavorGroups {
+		flavorgroups, err := fcon.FGStore.Search(&dm.FlavorGroupFilterCriteria{NameEqualTo: fg.Name})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Failed to search flavorgroup %s", fg.Name)
+		}
+		if len(flavorgroups) == 0 || flavorgroups[0].ID == uuid.Nil {
+			report.CreatedFlavorGroups = append(report.CreatedFlavorGroups, fg.Name)
+			continue
+		}
+		existing := flavorgroups[0]
+		if !equalFlavorgroupPolicies(&existing, &fg) {
+			report.Conflicts = append(report.Conflicts, hvs.FlavorBundleConflict{Type: flavorBundleConflictFlavorgroup,
+				ID: existing.ID, Name: fg.Name, Reason: "Flavorgroup with the same name has different match policies or policy rules"})
+			continue
+		}
+		existingFlavorgroups[fg.Name] = existing
+		report.ExistingFlavorGroups = append(report.ExistingFlavorGroups, fg.Name)
+	}
+
+	for _, signedFlavor := range bundle.SignedFlavors {
+		flavorId := signedFlavor.Flavor.Meta.ID
+		label, _ := signedFlavor.Flavor.Meta.Description[hvs.Label].(string)
+		existing, err := fcon.FStore.Retrieve(flavorId)
+		if err == nil {
+			if equalJson(&existing.Flavor, &signedFlavor.Flavor) {
+				report.ExistingFlavors = append(report.ExistingFlavors, flavorId)
+			} else {
+				report.Conflicts = append(report.Conflicts, hvs.FlavorBundleConflict{Type: flavorBundleConflictFlavor,
+					ID: flavorId, Name: label, Reason: "Flavor with the same ID has a different content"})
+			}
+			continue
+		}
+		if !strings.Contains(err.Error(), commErr.RowsNotFound) {
+			return nil, nil, errors.Wrapf(err, "Failed to retrieve flavor %s", flavorId)
+		}
+
+		sameLabel, err := fcon.FStore.Search(&dm.FlavorVerificationFC{
+			FlavorFC: dm.FlavorFilterCriteria{Key: hvs.Label, Value: label},
+		})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Failed to search flavors with label %s", label)
+		}
+		if len(sameLabel) > 0 {
+			report.Conflicts = append(report.Conflicts, hvs.FlavorBundleConflict{Type: flavorBundleConflictFlavor,
+				ID: flavorId, Name: label, Reason: "Flavor with the same label already exists with ID " + sameLabel[0].Flavor.Meta.ID.String()})
+			continue
+		}
+		report.ImportedFlavors = append(report.ImportedFlavors, flavorId)
+	}
+	return report, existingFlavorgroups, nil
+}
+
+// importFlavorBundle stores the new items of the bundle and links the flavors to their flavorgroups in a single
+// transaction, the hosts of the flavorgroups are then queued for flavor verification
+func (fcon *FlavorController) importFlavorBundle(bundle *hvs.FlavorBundle, report *hvs.FlavorBundleImportReport, flavorgroups map[string]hvs.FlavorGroup) error {
+	defaultLog.Trace("controllers/flavor_bundle_controller:importFlavorBundle() Entering")
+	defer defaultLog.Trace("controllers/flavor_bundle_controller:importFlavorBundle() Leaving")
+
+	createdTemplates := make(map[uuid.UUID]bool)
+	for _, templateId := range report.CreatedFlavorTemplates {
+		createdTemplates[templateId] = true
+	}
+	importedFlavors := make(map[uuid.UUID]bool)
+	for _, flavorId := range report.ImportedFlavors {
+		importedFlavors[flavorId] = true
+	}
+	flavorSignKey, _, _ := (*fcon.CertStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+
+	var flavorgroupsForQueue []hvs.FlavorGroup
+	err := fcon.FBStore.Import(func(ftStore domain.FlavorTemplateStore, fgStore domain.FlavorGroupStore, fStore domain.FlavorStore) error {
+		for i := range bundle.FlavorTemplates {
+			if !createdTemplates[bundle.FlavorTemplates[i].ID] {
+				continue
+			}
+			template := bundle.FlavorTemplates[i]
+			if _, err := ftStore.Create(&template); err != nil {
+				return errors.Wrapf(err, "Failed to create flavor template %s", template.ID)
+			}
+		}
+
+		for _, fg := range bundle.FlavorGroups {
+			if _, ok := flavorgroups[fg.Name]; ok {
+				continue
+			}
+			created, err := fgStore.Create(&hvs.FlavorGroup{
+				Name:              fg.Name,
+				FlavorTemplateIds: fg.FlavorTemplateIds,
+				MatchPolicies:     fg.MatchPolicies,
+				PolicyRules:       fg.PolicyRules,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "Failed to create flavorgroup %s", fg.Name)
+			}
+			flavorgroups[fg.Name] = *created
+		}
+
+		for i := range bundle.SignedFlavors {
+			flavor := bundle.SignedFlavors[i].Flavor
+			if !importedFlavors[flavor.Meta.ID] {
+				continue
+			}
+			signedFlavor, err := fu.PlatformFlavorUtil{}.GetSignedFlavor(&flavor, flavorSignKey.(*rsa.PrivateKey))
+			if err != nil {
+				return errors.Wrapf(err, "Failed to sign flavor %s", flavor.Meta.ID)
+			}
+			if _, err = fStore.Create(signedFlavor); err != nil {
+				return errors.Wrapf(err, "Failed to create flavor %s", flavor.Meta.ID)
+			}
+		}
+
+		for _, bundleFg := range bundle.FlavorGroups {
+			fg := flavorgroups[bundleFg.Name]
+			var flavorIds []uuid.UUID
+			for _, flavorId := range bundleFg.FlavorIds {
+				if _, err := fgStore.RetrieveFlavor(fg.ID, flavorId); err == nil {
+					continue
+				}
+				flavorIds = append(flavorIds, flavorId)
+			}
+			if len(flavorIds) == 0 {
+				continue
+			}
+			if _, err := fgStore.AddFlavors(fg.ID, flavorIds); err != nil {
+				return errors.Wrapf(err, "Failed to link flavors to flavorgroup %s", fg.Name)
+			}
+			flavorgroupsForQueue = append(flavorgroupsForQueue, fg)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(flavorgroupsForQueue) > 0 {
+		go fcon.addFlavorgroupHostsToFlavorVerifyQueue(flavorgroupsForQueue, nil, false)
+	}
+	return nil
+}
+
+func equalFlavorgroupPolicies(existing, imported *hvs.FlavorGroup) bool {
+	if len(existing.MatchPolicies) != 0 || len(imported.MatchPolicies) != 0 {
+		if !equalJson(existing.MatchPolicies, imported.MatchPolicies) {
+			return false
+		}
+	}
+	if len(existing.PolicyRules) != 0 || len(imported.PolicyRules) != 0 {
+		if !equalJson(existing.PolicyRules, imported.PolicyRules) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalJson(a, b interface{}) bool {
+	aJson, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bJson, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aJson, bJson)
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v5/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlavorBundleController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var sourceController *controllers.FlavorController
+	var destController *controllers.FlavorController
+	var destFlavorStore *mocks.MockFlavorStore
+	var destFlavorGroupStore *mocks.MockFlavorgroupStore
+	var sourceCerts []x509.Certificate
+	var destKey *rsa.PrivateKey
+
+	flavorId := uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3")
+	automaticFgId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+	// the CMS issues the flavor signing certificates from its signing CA and the TLS certificates from its TLS CA
+	var rootCa, signingCa, tlsCa *x509.Certificate
+	var rootCaKey, signingCaKey, tlsCaKey *rsa.PrivateKey
+
+	newCertificate := func(template, issuer *x509.Certificate, issuerKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		template.SerialNumber = big.NewInt(time.Now().UnixNano())
+		template.NotBefore = time.Now().Add(-time.Minute)
+		template.NotAfter = time.Now().Add(time.Hour)
+		if issuer == nil {
+			issuer, issuerKey = template, key
+		}
+		certDer, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+		Expect(err).NotTo(HaveOccurred())
+		cert, err := x509.ParseCertificate(certDer)
+		Expect(err).NotTo(HaveOccurred())
+		return cert, key
+	}
+
+	newCa := func(commonName string, issuer *x509.Certificate, issuerKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+		return newCertificate(&x509.Certificate{
+			Subject:               pkix.Name{CommonName: commonName},
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}, issuer, issuerKey)
+	}
+
+	newSigningCertStore := func(issuer *x509.Certificate, issuerKey *rsa.PrivateKey, keyUsage x509.KeyUsage, extKeyUsage ...x509.ExtKeyUsage) (*crypt.CertificatesStore, *rsa.PrivateKey, []x509.Certificate) {
+		cert, key := newCertificate(&x509.Certificate{
+			Subject:     pkix.Name{CommonName: "HVS Flavor Signing Certificate"},
+			KeyUsage:    keyUsage,
+			ExtKeyUsage: extKeyUsage,
+		}, issuer, issuerKey)
+		certs := []x509.Certificate{*cert, *issuer}
+		return &crypt.CertificatesStore{
+			models.CertTypesFlavorSigning.String(): &crypt.CertificateStore{Key: key, Certificates: certs},
+			models.CaCertTypesRootCa.String():      &crypt.CertificateStore{Certificates: []x509.Certificate{*rootCa}},
+		}, key, certs
+	}
+	signingKeyUsage := x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+
+		if rootCa == nil {
+			rootCa, rootCaKey = newCa("CMS Root CA", nil, nil)
+			signingCa, signingCaKey = newCa("CMS Signing CA", rootCa, rootCaKey)
+			tlsCa, tlsCaKey = newCa("CMS TLS CA", rootCa, rootCaKey)
+		}
+
+		var sourceCertStore *crypt.CertificatesStore
+		var sourceKey *rsa.PrivateKey
+		sourceCertStore, sourceKey, sourceCerts = newSigningCertStore(signingCa, signingCaKey, signingKeyUsage)
+
+		// the flavors of the source HVS are signed with its flavor signing key
+		mockFlavor, err := mocks.NewMockFlavorStore().Retrieve(flavorId)
+		Expect(err).NotTo(HaveOccurred())
+		signedFlavor, err := hvs.NewSignedFlavor(&mockFlavor.Flavor, sourceKey)
+		Expect(err).NotTo(HaveOccurred())
+		sourceFlavorStore := &mocks.MockFlavorStore{}
+		_, err = sourceFlavorStore.Create(signedFlavor)
+		Expect(err).NotTo(HaveOccurred())
+		sourceFlavorGroupStore := mocks.NewFakeFlavorgroupStore()
+		_, err = sourceFlavorGroupStore.AddFlavors(automaticFgId, []uuid.UUID{flavorId})
+		Expect(err).NotTo(HaveOccurred())
+
+		sourceController = &controllers.FlavorController{
+			FStore:    sourceFlavorStore,
+			FGStore:   sourceFlavorGroupStore,
+			HStore:    mocks.NewMockHostStore(),
+			CertStore: sourceCertStore,
+			FTStore:   mocks.NewFakeFlavorTemplateStore(),
+		}
+
+		// the destination HVS has a flavor signing certificate issued by the same CA
+		var destCertStore *crypt.CertificatesStore
+		destCertStore, destKey, _ = newSigningCertStore(signingCa, signingCaKey, signingKeyUsage)
+		destFlavorStore = &mocks.MockFlavorStore{}
+		destFlavorGroupStore = mocks.NewFakeFlavorgroupStore()
+		destFlavorTemplateStore := mocks.NewFakeFlavorTemplateStore()
+		destController = &controllers.FlavorController{
+			FStore:    destFlavorStore,
+			FGStore:   destFlavorGroupStore,
+			HStore:    mocks.NewMockHostStore(),
+			CertStore: destCertStore,
+			FTStore:   destFlavorTemplateStore,
+			FBStore:   mocks.NewMockFlavorBundleStore(destFlavorTemplateStore, destFlavorGroupStore, destFlavorStore),
+		}
+	})
+
+	exportFlavors := func(query string) *hvs.SignedFlavorBundle {
+		router.Handle("/flavors/export", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(sourceController.Export))).Methods(http.MethodGet)
+		req, err := http.NewRequest(http.MethodGet, "/flavors/export"+query, nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			return nil
+		}
+		var signedBundle hvs.SignedFlavorBundle
+		Expect(json.Unmarshal(w.Body.Bytes(), &signedBundle)).To(Succeed())
+		return &signedBundle
+	}
+
+	importFlavors := func(signedBundle *hvs.SignedFlavorBundle, query string) *hvs.FlavorBundleImportReport {
+		router.Handle("/flavors/import", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(destController.Import))).Methods(http.MethodPost)
+		body, err := json.Marshal(signedBundle)
+		Expect(err).NotTo(HaveOccurred())
+		req, err := http.NewRequest(http.MethodPost, "/flavors/import"+query, bytes.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK && w.Code != http.StatusConflict {
+			return nil
+		}
+		var report hvs.FlavorBundleImportReport
+		Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(Succeed())
+		return &report
+	}
+
+	Describe("Export flavors", func() {
+		Context("Export flavors by ID", func() {
+			It("Should return a signed bundle with the flavorgroups of the flavors", func() {
+				signedBundle := exportFlavors("?id=" + flavorId.String())
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(signedBundle.Bundle.SignedFlavors).To(HaveLen(1))
+				Expect(signedBundle.Bundle.FlavorGroups).To(HaveLen(1))
+				Expect(signedBundle.Bundle.FlavorGroups[0].Name).To(Equal("automatic"))
+				Expect(signedBundle.Bundle.FlavorGroups[0].FlavorIds).To(Equal([]uuid.UUID{flavorId}))
+				Expect(signedBundle.Bundle.FlavorGroups[0].MatchPolicies).To(HaveLen(3))
+				Expect(signedBundle.Verify(sourceCerts[0].PublicKey.(*rsa.PublicKey))).To(Succeed())
+			})
+		})
+		Context("Export flavors without filter", func() {
+			It("Should return bad request", func() {
+				exportFlavors("")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Export flavors with an unknown ID", func() {
+			It("Should return not found", func() {
+				exportFlavors("?id=" + uuid.New().String())
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("Import flavors", func() {
+		Context("Import a bundle signed by a trusted flavor signing certificate", func() {
+			It("Should store the flavors signed with the destination key", func() {
+				report := importFlavors(exportFlavors("?id="+flavorId.String()), "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(report.ImportedFlavors).To(Equal([]uuid.UUID{flavorId}))
+				Expect(report.ExistingFlavorGroups).To(Equal([]string{"automatic"}))
+				Expect(report.Conflicts).To(BeEmpty())
+
+				imported, err := destFlavorStore.Retrieve(flavorId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(imported.Verify(&destKey.PublicKey)).To(Succeed())
+				_, err = destFlavorGroupStore.RetrieveFlavor(automaticFgId, flavorId)
+				Expect(err).NotTo(HaveOccurred())
+
+				// importing the same bundle again does not change anything
+				report = importFlavors(exportFlavors("?id="+flavorId.String()), "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(report.ImportedFlavors).To(BeEmpty())
+				Expect(report.ExistingFlavors).To(Equal([]uuid.UUID{flavorId}))
+			})
+		})
+		Context("Import a bundle with dryRun", func() {
+			It("Should report the import without storing the flavors", func() {
+				report := importFlavors(exportFlavors("?id="+flavorId.String()), "?dryRun=true")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(report.DryRun).To(BeTrue())
+				Expect(report.ImportedFlavors).To(Equal([]uuid.UUID{flavorId}))
+				_, err := destFlavorStore.Retrieve(flavorId)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+		Context("Import a tampered bundle", func() {
+			It("Should return bad request", func() {
+				signedBundle := exportFlavors("?id=" + flavorId.String())
+				signedBundle.Bundle.FlavorGroups[0].MatchPolicies = nil
+				importFlavors(signedBundle, "")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Import a bundle signed by an untrusted certificate", func() {
+			It("Should return bad request", func() {
+				otherRootCa, otherRootCaKey := newCa("Other Root CA", nil, nil)
+				sourceController.CertStore, _, _ = newSigningCertStore(otherRootCa, otherRootCaKey, signingKeyUsage)
+				importFlavors(exportFlavors("?id="+flavorId.String()), "")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(ContainSubstring("The flavor bundle signing certificate is not trusted"))
+			})
+		})
+		Context("Import a bundle signed by a TLS certificate issued by the CMS", func() {
+			It("Should return bad request", func() {
+				sourceController.CertStore, _, _ = newSigningCertStore(tlsCa, tlsCaKey, signingKeyUsage,
+					x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)
+				importFlavors(exportFlavors("?id="+flavorId.String()), "")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(ContainSubstring("The flavor bundle signing certificate is not trusted"))
+			})
+		})
+		Context("Import a bundle signed by a certificate without the signing key usages", func() {
+			It("Should return bad request", func() {
+				sourceController.CertStore, _, _ = newSigningCertStore(signingCa, signingCaKey, x509.KeyUsageKeyEncipherment)
+				importFlavors(exportFlavors("?id="+flavorId.String()), "")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(ContainSubstring("The flavor bundle signing certificate is not trusted"))
+			})
+		})
+		Context("Import a bundle conflicting with an existing flavorgroup", func() {
+			It("Should report the conflict and import nothing", func() {
+				fg, err := destFlavorGroupStore.Retrieve(automaticFgId)
+				Expect(err).NotTo(HaveOccurred())
+				fg.MatchPolicies = fg.MatchPolicies[:1]
+
+				report := importFlavors(exportFlavors("?id="+flavorId.String()), "")
+				Expect(w.Code).To(Equal(http.StatusConflict))
+				Expect(report.Conflicts).To(HaveLen(1))
+				Expect(report.Conflicts[0].Type).To(Equal("flavorgroup"))
+				Expect(report.Conflicts[0].Name).To(Equal("automatic"))
+				_, err = destFlavorStore.Retrieve(flavorId)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -48,10 +48,12 @@ type FlavorController struct {
 	CertStore *crypt.CertificatesStore
 	HostCon   HostController
 	FTStore   domain.FlavorTemplateStore
+	FBStore   domain.FlavorBundleStore
 	IsExsi    bool
 }
 
-var flavorSearchParams = map[string]bool{"id": true, "key": true, "value": true, "flavorgroupId": true, "flavorParts": true, "limit": true, "afterId": true}
+var flavorSearchParams = map[string]bool{"id": true, "key": true, "value": true, "flavorgroupId": true, "flavorParts": true, "limit": true, "afterId": true,
+	"cursor": true, "includeTotal": true, "sortBy": true, "orderBy": true}
 
 func NewFlavorController(fs domain.FlavorStore, fgs domain.FlavorGroupStore, hs domain.HostStore, tcs domain.TagCertificateStore, htm domain.HostTrustManager, certStore *crypt.CertificatesStore, hcConfig domain.HostControllerConfig, fts domain.FlavorTemplateStore) *FlavorController {
 	// certStore should have an entry for Flavor Signing CA
@@ -764,6 +766,12 @@ func (fcon *FlavorController) Search(w http.ResponseWriter, r *http.Request) (in
 		secLog.Errorf("controllers/flavor_controller:Search()  %s", err.Error())
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
 	}
+	page, err := PopulatePageCriteria(r.URL.Query(), dm.FlavorSortFields)
+	if err != nil {
+		secLog.Errorf("controllers/flavor_controller:Search()  %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	filterCriteria.PageCriteria = *page
 
 	signedFlavors, err := fcon.FStore.Search(&dm.FlavorVerificationFC{
 		FlavorFC: *filterCriteria,
@@ -773,14 +781,25 @@ func (fcon *FlavorController) Search(w http.ResponseWriter, r *http.Request) (in
 		return nil, http.StatusInternalServerError, errors.Errorf("Unable to search Flavors")
 	}
 
+	total := 0
+	if filterCriteria.IncludeTotal {
+		total, err = fcon.FStore.Count(&dm.FlavorVerificationFC{
+			FlavorFC: *filterCriteria,
+		})
+		if err != nil {
+			secLog.WithError(err).Error("controllers/flavor_controller:Search() Flavor count failed")
+			return nil, http.StatusInternalServerError, errors.Errorf("Unable to search Flavors")
+		}
+	}
+
 	var next, prev string
 	if len(signedFlavors) > 0 {
 		lastRowId := signedFlavors[len(signedFlavors)-1].RowId
-		next, prev = GetNextAndPrevValues(filterCriteria.Limit, filterCriteria.AfterId, lastRowId, len(signedFlavors))
+		next, prev = GetPageLinks(filterCriteria.PageCriteria, lastRowId, len(signedFlavors))
 	}
 
 	signedFlavoursCollection := hvs.SignedFlavorCollection{
-		SignedFlavors: signedFlavors, Next: next, Previous: prev}
+		SignedFlavors: signedFlavors, Total: total, Next: next, Previous: prev}
 	secLog.Infof("%s: Return flavor query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
 	return signedFlavoursCollection, http.StatusOK, nil
 }
@@ -892,6 +911,126 @@ func (fcon *FlavorController) Retrieve(w http.ResponseWriter, r *http.Request) (
 
 }
 
+func (fcon *FlavorController) Update(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:Update() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:Update() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		secLog.Error("controllers/flavor_controller:Update() Invalid Content-Type")
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavor_controller:Update() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var updateReq hvs.Flavors
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&updateReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Update() %s :  Failed to decode request body as Flavor", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateFlavorMetaContent(&updateReq.Flavor.Meta); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_controller:Update() %s Invalid flavor meta content", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	if updateReq.Flavor.Meta.ID != uuid.Nil && updateReq.Flavor.Meta.ID != id {
+		secLog.Errorf("controllers/flavor_controller:Update() %s Flavor ID in request body does not match", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor ID in request body does not match the ID in the URL"}
+	}
+
+	existingFlavor, err := fcon.FStore.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Info(
+				"controllers/flavor_controller:Update() Flavor with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Flavor with given ID does not exist"}
+		}
+		secLog.WithError(err).WithField("id", id).Info(
+			"controllers/flavor_controller:Update() failed to retrieve Flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavor with the given ID"}
+	}
+
+	// a revision must not change the flavor part, the flavorgroup associations depend on it
+	if existingFlavor.Flavor.Meta.Description[hvs.FlavorPartDescription] != updateReq.Flavor.Meta.Description[hvs.FlavorPartDescription] {
+		secLog.Errorf("controllers/flavor_controller:Update() %s Flavor part cannot be changed", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor part of an existing flavor cannot be changed"}
+	}
+
+	updateReq.Flavor.Meta.ID = id
+	flavorSignKey, _, _ := (*fcon.CertStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+	signedFlavor, err := fu.PlatformFlavorUtil{}.GetSignedFlavor(&updateReq.Flavor, flavorSignKey.(*rsa.PrivateKey))
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Update() Error getting signed flavor from flavor library")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to sign Flavor"}
+	}
+
+	updatedFlavor, err := fcon.FStore.Update(signedFlavor)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/flavor_controller:Update() Failed to update Flavor")
+		if strings.Contains(err.Error(), consts.DuplicateKeyCheck) {
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavor with same label already exists"}
+		}
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update Flavor"}
+	}
+
+	// trust cache entries refer to the previous revision
+	if err = fcon.HStore.RemoveTrustCacheFlavors(uuid.Nil, []uuid.UUID{id}); err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/flavor_controller:Update() Failed to purge Flavor from trust cache")
+	}
+
+	hostIdsForQueue, err := getHostsAssociatedWithFlavor(fcon.HStore, fcon.FGStore, updatedFlavor)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_controller:Update() Failed to retrieve hosts " +
+			"associated with flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve hosts " +
+			"associated with flavor for trust re-verification"}
+	}
+
+	defaultLog.Debugf("Found %v hosts to be added to flavor-verify queue", len(hostIdsForQueue))
+	if len(hostIdsForQueue) >= 1 {
+		err := fcon.HTManager.VerifyHostsAsync(hostIdsForQueue, false, false)
+		if err != nil {
+			defaultLog.Error("controllers/flavor_controller:Update() Host to Flavor Verify Queue addition failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to re-verify hosts " +
+				"associated with updated Flavor"}
+		}
+	}
+
+	secLog.WithField("id", id).Infof("%s: Flavor updated by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return updatedFlavor, http.StatusOK, nil
+}
+
+func (fcon *FlavorController) SearchRevisions(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_controller:SearchRevisions() Entering")
+	defer defaultLog.Trace("controllers/flavor_controller:SearchRevisions() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	if _, err := fcon.FStore.Retrieve(id); err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", id).Info(
+				"controllers/flavor_controller:SearchRevisions() Flavor with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Flavor with given ID does not exist"}
+		}
+		secLog.WithError(err).WithField("id", id).Info(
+			"controllers/flavor_controller:SearchRevisions() failed to retrieve Flavor")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavor with the given ID"}
+	}
+
+	revisions, err := fcon.FStore.SearchRevisions(id)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/flavor_controller:SearchRevisions() Failed to retrieve Flavor revisions")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavor revisions"}
+	}
+
+	secLog.Infof("%s: Return flavor revisions query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.FlavorRevisionCollection{FlavorRevisions: revisions}, http.StatusOK, nil
+}
+
 func validateFlavorFilterCriteria(key, value, flavorgroupId string, ids, flavorParts []string, limitString string, afterIdString string) (*dm.FlavorFilterCriteria, error) {
 	defaultLog.Trace("controllers/flavor_controller:validateFlavorFilterCriteria() Entering")
 	defer defaultLog.Trace("controllers/flavor_controller:validateFlavorFilterCriteria() Leaving")
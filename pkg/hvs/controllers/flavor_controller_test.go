@@ -5,6 +5,7 @@
 package controllers_test
 
 import (
+	"bytes"
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
@@ -14,6 +15,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
@@ -267,6 +269,128 @@ var _ = Describe("FlavorController", func() {
 		})
 	})
 
+	// Specs for HTTP Put to "/flavors/{flavorId}"
+	Describe("Update Flavor by ID", func() {
+		updateFlavor := func(id string, body []byte, contentType string) {
+			router.Handle("/flavors/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.Update))).Methods(http.MethodPut)
+			req, err := http.NewRequest(http.MethodPut, "/flavors/"+id, bytes.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", contentType)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+		existingFlavor := func() hvs.Flavors {
+			sf, err := flavorStore.Retrieve(uuid.MustParse("c36b5412-8c02-4e08-8a74-8bfa40425cf3"))
+			Expect(err).NotTo(HaveOccurred())
+			// copy the flavor so that the stored description is not modified
+			flavorJson, err := json.Marshal(sf.Flavor)
+			Expect(err).NotTo(HaveOccurred())
+			var updateReq hvs.Flavors
+			err = json.Unmarshal(flavorJson, &updateReq.Flavor)
+			Expect(err).NotTo(HaveOccurred())
+			return updateReq
+		}
+
+		Context("Update Flavor with valid content", func() {
+			It("Should create a new signed revision and retain the previous one", func() {
+				updateReq := existingFlavor()
+				updateReq.Flavor.Meta.Description[hvs.Label] = "INTEL_IntelCorporation_SE5C620_TXT_TPM_revised"
+				body, _ := json.Marshal(updateReq)
+				updateFlavor("c36b5412-8c02-4e08-8a74-8bfa40425cf3", body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var sf hvs.SignedFlavor
+				err := json.Unmarshal(w.Body.Bytes(), &sf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sf.Signature).NotTo(BeEmpty())
+				Expect(sf.Flavor.Meta.ID.String()).To(Equal("c36b5412-8c02-4e08-8a74-8bfa40425cf3"))
+
+				router.Handle("/flavors/{id}/revisions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.SearchRevisions))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3/revisions", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var revisions hvs.FlavorRevisionCollection
+				err = json.Unmarshal(w.Body.Bytes(), &revisions)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revisions.FlavorRevisions).To(HaveLen(2))
+				Expect(revisions.FlavorRevisions[0].Revision).To(Equal(2))
+				Expect(revisions.FlavorRevisions[0].Flavor.Meta.Description[hvs.Label]).To(Equal("INTEL_IntelCorporation_SE5C620_TXT_TPM_revised"))
+				Expect(revisions.FlavorRevisions[1].Revision).To(Equal(1))
+			})
+		})
+		Context("Update Flavor with a different flavor part", func() {
+			It("Should return bad request", func() {
+				updateReq := existingFlavor()
+				updateReq.Flavor.Meta.Description[hvs.FlavorPartDescription] = hvs.FlavorPartOs.String()
+				body, _ := json.Marshal(updateReq)
+				updateFlavor("c36b5412-8c02-4e08-8a74-8bfa40425cf3", body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Update Flavor with mismatched ID in request body", func() {
+			It("Should return bad request", func() {
+				updateReq := existingFlavor()
+				updateReq.Flavor.Meta.ID = uuid.MustParse("73755fda-c910-46be-821f-e8ddeab189e9")
+				body, _ := json.Marshal(updateReq)
+				updateFlavor("c36b5412-8c02-4e08-8a74-8bfa40425cf3", body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Update Flavor by non-existent ID", func() {
+			It("Should return not found", func() {
+				updateReq := existingFlavor()
+				updateReq.Flavor.Meta.ID = uuid.Nil
+				body, _ := json.Marshal(updateReq)
+				updateFlavor("73755fda-c910-46be-821f-e8ddeab189e9", body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("Update Flavor with invalid Content-Type", func() {
+			It("Should return unsupported media type", func() {
+				body, _ := json.Marshal(existingFlavor())
+				updateFlavor("c36b5412-8c02-4e08-8a74-8bfa40425cf3", body, consts.HTTPMediaTypeXml)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/flavors/{flavorId}/revisions"
+	Describe("Search Flavor revisions", func() {
+		Context("Search revisions of a Flavor that was never updated", func() {
+			It("Should return only the current revision", func() {
+				router.Handle("/flavors/{id}/revisions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.SearchRevisions))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/flavors/c36b5412-8c02-4e08-8a74-8bfa40425cf3/revisions", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var revisions hvs.FlavorRevisionCollection
+				err = json.Unmarshal(w.Body.Bytes(), &revisions)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revisions.FlavorRevisions).To(HaveLen(1))
+				Expect(revisions.FlavorRevisions[0].Revision).To(Equal(1))
+			})
+		})
+		Context("Search revisions of a non-existent Flavor", func() {
+			It("Should return not found", func() {
+				router.Handle("/flavors/{id}/revisions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorController.SearchRevisions))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/flavors/73755fda-c910-46be-821f-e8ddeab189e9/revisions", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
 	// Specs for HTTP Post to "/flavor"
 	Describe("Create a new flavor", func() {
 		Context("Provide a invalid Create request with XSS Attack Strings", func() {
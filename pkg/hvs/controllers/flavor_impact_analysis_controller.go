@@ -0,0 +1,306 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	dm "github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
+	fu "github.com/intel-secl/intel-secl/v5/pkg/lib/flavor/util"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/verifier"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// FlavorImpactAnalysisController evaluates candidate flavors against the cached host manifests of the hosts
+// linked to a flavorgroup, without storing the flavors or the resulting reports
+type FlavorImpactAnalysisController struct {
+	FGStore        domain.FlavorGroupStore
+	HStore         domain.HostStore
+	HSStore        domain.HostStatusStore
+	RStore         domain.ReportStore
+	CertStore      *crypt.CertificatesStore
+	FlavorVerifier verifier.Verifier
+}
+
+func NewFlavorImpactAnalysisController(fgs domain.FlavorGroupStore, hs domain.HostStore, hss domain.HostStatusStore,
+	rs domain.ReportStore, certStore *crypt.CertificatesStore, fv verifier.Verifier) *FlavorImpactAnalysisController {
+	return &FlavorImpactAnalysisController{
+		FGStore:        fgs,
+		HStore:         hs,
+		HSStore:        hss,
+		RStore:         rs,
+		CertStore:      certStore,
+		FlavorVerifier: fv,
+	}
+}
+
+func (controller *FlavorImpactAnalysisController) Analyze(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavor_impact_analysis_controller:Analyze() Entering")
+	defer defaultLog.Trace("controllers/flavor_impact_analysis_controller:Analyze() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Errorf("controllers/flavor_impact_analysis_controller:Analyze() %s : The request body is not provided", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var analysisReq dm.FlavorCreateRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&analysisReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_impact_analysis_controller:Analyze() %s : Failed to decode request body as Flavor", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateFlavorImpactAnalysisRequest(analysisReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_impact_analysis_controller:Analyze() %s : Invalid flavor impact analysis request", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	flavorgroupName := dm.FlavorGroupsAutomatic.String()
+	if len(analysisReq.FlavorgroupNames) == 1 {
+		flavorgroupName = analysisReq.FlavorgroupNames[0]
+	}
+	flavorgroups, err := controller.FGStore.Search(&dm.FlavorGroupFilterCriteria{NameEqualTo: flavorgroupName})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_impact_analysis_controller:Analyze() Failed to retrieve Flavorgroup")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Flavorgroup"}
+	}
+	if len(flavorgroups) == 0 {
+		secLog.Errorf("controllers/flavor_impact_analysis_controller:Analyze() %s : Flavorgroup %s does not exist", commLogMsg.InvalidInputBadParam, flavorgroupName)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Flavorgroup with given name does not exist"}
+	}
+	flavorgroup := flavorgroups[0]
+
+	candidates, err := controller.getCandidateFlavors(analysisReq)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavor_impact_analysis_controller:Analyze() %s : Invalid flavor content", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	if len(candidates) == 0 {
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "No flavor of the requested flavor parts found in the request"}
+	}
+
+	hostIds, err := controller.FGStore.SearchHostsByFlavorGroup(flavorgroup.ID)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavor_impact_analysis_controller:Analyze() Failed to retrieve hosts linked to Flavorgroup")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve hosts linked to Flavorgroup"}
+	}
+
+	analysis := hvs.FlavorImpactAnalysis{
+		FlavorgroupId:   flavorgroup.ID,
+		FlavorgroupName: flavorgroup.Name,
+		HostCount:       len(hostIds),
+		Hosts:           []hvs.HostFlavorImpact{},
+	}
+	for _, hostId := range hostIds {
+		impact := controller.analyzeHost(hostId, &flavorgroup, candidates)
+		if impact.Error != "" {
+			analysis.NotEvaluatedCount++
+		} else if impact.TrustChanged {
+			analysis.TrustChangedCount++
+		}
+		analysis.Hosts = append(analysis.Hosts, impact)
+	}
+
+	secLog.Infof("%s: Flavor impact analysis requested by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return analysis, http.StatusOK, nil
+}
+
+// analyzeHost verifies the candidate flavors against the latest host manifest of the host and predicts the trust
+// status of the host as per the match policies of the flavorgroup
+func (controller *FlavorImpactAnalysisController) analyzeHost(hostId uuid.UUID, flavorgroup *hvs.FlavorGroup, candidates []hvs.SignedFlavor) hvs.HostFlavorImpact {
+	defaultLog.Trace("controllers/flavor_impact_analysis_controller:analyzeHost() Entering")
+	defer defaultLog.Trace("controllers/flavor_impact_analysis_controller:analyzeHost() Leaving")
+
+	impact := hvs.HostFlavorImpact{HostId: hostId}
+	host, err := controller.HStore.Retrieve(hostId, nil)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("controllers/flavor_impact_analysis_controller:analyzeHost() Failed to retrieve host %s", hostId)
+		impact.Error = "Failed to retrieve host"
+		return impact
+	}
+	impact.HostName = host.HostName
+
+	hostStatuses, err := controller.HSStore.Search(&dm.HostStatusFilterCriteria{
+		HostId:        hostId,
+		LatestPerHost: true,
+		Limit:         1,
+	})
+	if err != nil || len(hostStatuses) == 0 || hostStatuses[0].HostStatusInformation.HostState != hvs.HostStateConnected {
+		impact.Error = "No host manifest available for the host, host is not in CONNECTED state"
+		return impact
+	}
+	hostManifest := hostStatuses[0].HostManifest
+
+	var currentReport *hvs.TrustReport
+	reports, err := controller.RStore.Search(&dm.ReportFilterCriteria{
+		HostID:        hostId,
+		LatestPerHost: true,
+		Limit:         1,
+	})
+	if err != nil {
+		defaultLog.WithError(err).Warnf("controllers/flavor_impact_analysis_controller:analyzeHost() Failed to retrieve report for host %s", hostId)
+	} else if len(reports) > 0 {
+		currentReport = &reports[0].TrustReport
+		currentTrusted := currentReport.IsTrusted()
+		impact.CurrentTrusted = &currentTrusted
+	}
+
+	// results of the candidate flavors, in the order given, for each flavor part
+	flavorPartResults := make(map[hvs.FlavorPartName][]bool)
+	for i := range candidates {
+		trustReport, err := controller.FlavorVerifier.Verify(&hostManifest, &candidates[i], false)
+		if err != nil {
+			defaultLog.WithError(err).Errorf("controllers/flavor_impact_analysis_controller:analyzeHost() Failed to verify flavor against host %s", hostId)
+			impact.Error = "Failed to verify flavor against the host manifest"
+			impact.FlavorResults = nil
+			return impact
+		}
+
+		var flavorPart hvs.FlavorPartName
+		_ = (&flavorPart).Parse(candidates[i].Flavor.Meta.Description[hvs.FlavorPartDescription].(string))
+		result := hvs.FlavorImpactResult{
+			FlavorId:   candidates[i].Flavor.Meta.ID,
+			Label:      candidates[i].Flavor.Meta.Description[hvs.Label].(string),
+			FlavorPart: flavorPart,
+			Trusted:    trustReport.IsTrusted(),
+		}
+		for _, ruleResult := range trustReport.Results {
+			result.Faults = append(result.Faults, ruleResult.Faults...)
+		}
+		impact.FlavorResults = append(impact.FlavorResults, result)
+		flavorPartResults[flavorPart] = append(flavorPartResults[flavorPart], result.Trusted)
+	}
+
+	predictedTrusted := predictHostTrust(flavorgroup, currentReport, flavorPartResults)
+	impact.PredictedTrusted = &predictedTrusted
+	// hosts without a report are considered untrusted
+	impact.TrustChanged = (impact.CurrentTrusted != nil && *impact.CurrentTrusted) != predictedTrusted
+	return impact
+}
+
+// predictHostTrust combines the results of the candidate flavors with the results of the current report of the host.
+// Flavor parts with a LATEST match policy take the result of the last candidate flavor, ANY_OF flavor parts are trusted
+// if any of the existing or candidate flavors match and ALL_OF flavor parts require all of them to match. Flavor parts
+// that are not part of the candidate flavors keep their current results.
+func predictHostTrust(flavorgroup *hvs.FlavorGroup, currentReport *hvs.TrustReport, flavorPartResults map[hvs.FlavorPartName][]bool) bool {
+	defaultLog.Trace("controllers/flavor_impact_analysis_controller:predictHostTrust() Entering")
+	defer defaultLog.Trace("controllers/flavor_impact_analysis_controller:predictHostTrust() Leaving")
+
+	matchPolicies, _, _ := flavorgroup.GetMatchPolicyMaps()
+	predictedTrusted := true
+	for flavorPart, results := range flavorPartResults {
+		existing := currentReport != nil && len(currentReport.GetResultsForMarker(flavorPart.String())) > 0
+		existingTrusted := existing && currentReport.IsTrustedForMarker(flavorPart.String())
+
+		var partTrusted bool
+		switch matchPolicies[flavorPart].MatchType {
+		case hvs.MatchTypeLatest:
+			partTrusted = results[len(results)-1]
+		case hvs.MatchTypeAllOf:
+			partTrusted = !existing || existingTrusted
+			for _, trusted := range results {
+				partTrusted = partTrusted && trusted
+			}
+		default:
+			partTrusted = existingTrusted
+			for _, trusted := range results {
+				partTrusted = partTrusted || trusted
+			}
+		}
+		predictedTrusted = predictedTrusted && partTrusted
+	}
+
+	if currentReport != nil {
+		for _, ruleResult := range currentReport.Results {
+			evaluated := false
+			for _, marker := range ruleResult.Rule.Markers {
+				if _, ok := flavorPartResults[marker]; ok {
+					evaluated = true
+					break
+				}
+			}
+			if !evaluated && !ruleResult.IsTrusted() {
+				predictedTrusted = false
+			}
+		}
+	}
+	return predictedTrusted
+}
+
+// getCandidateFlavors signs the unsigned flavors from the request and returns them along with the signed flavors
+// restricted to the requested flavor parts
+func (controller *FlavorImpactAnalysisController) getCandidateFlavors(analysisReq dm.FlavorCreateRequest) ([]hvs.SignedFlavor, error) {
+	defaultLog.Trace("controllers/flavor_impact_analysis_controller:getCandidateFlavors() Entering")
+	defer defaultLog.Trace("controllers/flavor_impact_analysis_controller:getCandidateFlavors() Leaving")
+
+	var signedFlavors []hvs.SignedFlavor
+	if len(analysisReq.FlavorCollection.Flavors) > 0 {
+		flavorSignKey, _, err := (*controller.CertStore).GetKeyAndCertificates(dm.CertTypesFlavorSigning.String())
+		if err != nil || flavorSignKey == nil {
+			return nil, errors.New("Flavor signing key not found")
+		}
+		for _, flavor := range analysisReq.FlavorCollection.Flavors {
+			if err := validateFlavorMetaContent(&flavor.Flavor.Meta); err != nil {
+				return nil, errors.Wrap(err, "Invalid flavor content")
+			}
+			signedFlavor, err := fu.PlatformFlavorUtil{}.GetSignedFlavor(&flavor.Flavor, flavorSignKey.(*rsa.PrivateKey))
+			if err != nil {
+				return nil, errors.Wrap(err, "Error signing flavor")
+			}
+			signedFlavors = append(signedFlavors, *signedFlavor)
+		}
+	}
+	for _, signedFlavor := range analysisReq.SignedFlavorCollection.SignedFlavors {
+		if err := validateFlavorMetaContent(&signedFlavor.Flavor.Meta); err != nil {
+			return nil, errors.Wrap(err, "Invalid flavor content")
+		}
+		signedFlavors = append(signedFlavors, signedFlavor)
+	}
+
+	if len(analysisReq.FlavorParts) == 0 {
+		return signedFlavors, nil
+	}
+	var candidates []hvs.SignedFlavor
+	for _, signedFlavor := range signedFlavors {
+		for _, flavorPart := range analysisReq.FlavorParts {
+			if signedFlavor.Flavor.Meta.Description[hvs.FlavorPartDescription] == flavorPart.String() {
+				candidates = append(candidates, signedFlavor)
+				break
+			}
+		}
+	}
+	return candidates, nil
+}
+
+func validateFlavorImpactAnalysisRequest(analysisReq dm.FlavorCreateRequest) error {
+	defaultLog.Trace("controllers/flavor_impact_analysis_controller:validateFlavorImpactAnalysisRequest() Entering")
+	defer defaultLog.Trace("controllers/flavor_impact_analysis_controller:validateFlavorImpactAnalysisRequest() Leaving")
+
+	if analysisReq.ConnectionString != "" {
+		return errors.New("Flavor content must be given, connection string is not supported for impact analysis")
+	}
+	if len(analysisReq.FlavorgroupNames) > 1 {
+		return errors.New("Only one flavorgroup name can be given for impact analysis")
+	}
+	if err := validateFlavorCreateRequest(analysisReq); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	hvsRoutes "github.com/intel-secl/intel-secl/v5/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/verifier"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// mockFlavorVerifier trusts every flavor unless its label contains "untrusted"
+type mockFlavorVerifier struct{}
+
+func (v *mockFlavorVerifier) Verify(hostManifest *hvs.HostManifest, signedFlavor *hvs.SignedFlavor, skipFlavorSignatureVerification bool) (*hvs.TrustReport, error) {
+	var flavorPart hvs.FlavorPartName
+	_ = (&flavorPart).Parse(signedFlavor.Flavor.Meta.Description[hvs.FlavorPartDescription].(string))
+	ruleResult := hvs.RuleResult{
+		Rule:    hvs.RuleInfo{Name: "MockRule", Markers: []hvs.FlavorPartName{flavorPart}},
+		Trusted: true,
+	}
+	if strings.Contains(signedFlavor.Flavor.Meta.Description[hvs.Label].(string), "untrusted") {
+		ruleResult.Trusted = false
+		ruleResult.Faults = []hvs.Fault{{Name: "MockFault", Description: "Flavor does not match the host manifest"}}
+	}
+	return &hvs.TrustReport{HostManifest: *hostManifest, Results: []hvs.RuleResult{ruleResult}}, nil
+}
+
+func (v *mockFlavorVerifier) GetVerifierCerts() verifier.VerifierCertificates {
+	return verifier.VerifierCertificates{}
+}
+
+var _ = Describe("FlavorImpactAnalysisController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var flavorImpactAnalysisController *controllers.FlavorImpactAnalysisController
+
+	connectedHostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	disconnectedHostId := uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d")
+	automaticFlavorgroupId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		flavorGroupStore := mocks.NewFakeFlavorgroupStore()
+		flavorGroupStore.HostFlavorgroupStore = append(flavorGroupStore.HostFlavorgroupStore,
+			&hvs.HostFlavorgroup{HostId: connectedHostId, FlavorgroupId: automaticFlavorgroupId},
+			&hvs.HostFlavorgroup{HostId: disconnectedHostId, FlavorgroupId: automaticFlavorgroupId})
+		flavorImpactAnalysisController = controllers.NewFlavorImpactAnalysisController(flavorGroupStore,
+			mocks.NewMockHostStore(), mocks.NewMockHostStatusStore(), mocks.NewMockReportStore(),
+			mocks.NewFakeCertificatesStore(), &mockFlavorVerifier{})
+		router.Handle("/rpc/flavor-impact-analysis", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorImpactAnalysisController.Analyze))).Methods(http.MethodPost)
+	})
+
+	// Specs for HTTP Post to "/rpc/flavor-impact-analysis"
+	Describe("Analyze the impact of candidate flavors", func() {
+		analyze := func(body string, contentType string) *hvs.FlavorImpactAnalysis {
+			req, err := http.NewRequest(http.MethodPost, "/rpc/flavor-impact-analysis", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", contentType)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				return nil
+			}
+			var analysis hvs.FlavorImpactAnalysis
+			err = json.Unmarshal(w.Body.Bytes(), &analysis)
+			Expect(err).NotTo(HaveOccurred())
+			return &analysis
+		}
+		hostImpact := func(analysis *hvs.FlavorImpactAnalysis, hostId uuid.UUID) *hvs.HostFlavorImpact {
+			for i := range analysis.Hosts {
+				if analysis.Hosts[i].HostId == hostId {
+					return &analysis.Hosts[i]
+				}
+			}
+			return nil
+		}
+		flavorJson := func(flavorPart, label string) string {
+			return `{"flavor_collection":{"flavors":[{"flavor":{"meta":{"description":{"flavor_part":"` + flavorPart +
+				`","label":"` + label + `"}}}}]},"flavorgroup_names":["automatic"]}`
+		}
+
+		Context("When a candidate flavor matches the host manifest", func() {
+			It("Should predict an unchanged trust status for the connected host", func() {
+				analysis := analyze(flavorJson("PLATFORM", "candidate_platform"), consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(analysis.FlavorgroupName).To(Equal("automatic"))
+				Expect(analysis.HostCount).To(Equal(2))
+				Expect(analysis.NotEvaluatedCount).To(Equal(1))
+				Expect(analysis.TrustChangedCount).To(Equal(0))
+
+				impact := hostImpact(analysis, connectedHostId)
+				Expect(impact).NotTo(BeNil())
+				Expect(impact.Error).To(BeEmpty())
+				Expect(impact.FlavorResults).To(HaveLen(1))
+				Expect(impact.FlavorResults[0].Trusted).To(BeTrue())
+				Expect(*impact.PredictedTrusted).To(BeTrue())
+				Expect(impact.TrustChanged).To(BeFalse())
+
+				impact = hostImpact(analysis, disconnectedHostId)
+				Expect(impact).NotTo(BeNil())
+				Expect(impact.Error).NotTo(BeEmpty())
+				Expect(impact.PredictedTrusted).To(BeNil())
+			})
+		})
+		Context("When a candidate flavor of an ALL_OF flavor part does not match the host manifest", func() {
+			It("Should predict the connected host to become untrusted", func() {
+				analysis := analyze(flavorJson("SOFTWARE", "untrusted_software"), consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(analysis.TrustChangedCount).To(Equal(1))
+
+				impact := hostImpact(analysis, connectedHostId)
+				Expect(impact).NotTo(BeNil())
+				Expect(impact.FlavorResults[0].Trusted).To(BeFalse())
+				Expect(impact.FlavorResults[0].Faults).To(HaveLen(1))
+				Expect(*impact.PredictedTrusted).To(BeFalse())
+				Expect(impact.TrustChanged).To(BeTrue())
+			})
+		})
+		Context("When a connection string is provided", func() {
+			It("Should return bad request", func() {
+				analyze(`{"connection_string":"intel:https://ta.ip.com:1443"}`, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the flavorgroup does not exist", func() {
+			It("Should return bad request", func() {
+				analyze(strings.Replace(flavorJson("PLATFORM", "candidate_platform"), "automatic", "unknown", 1), consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When an invalid Content-Type is provided", func() {
+			It("Should return unsupported media type", func() {
+				analyze(flavorJson("PLATFORM", "candidate_platform"), consts.HTTPMediaTypeJwt)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+})
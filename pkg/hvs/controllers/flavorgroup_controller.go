@@ -15,6 +15,7 @@ import (
 	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/verifier/rules"
 	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"net/http"
@@ -30,7 +31,8 @@ type FlavorgroupController struct {
 	HTManager           domain.HostTrustManager
 }
 
-var flavorGroupSearchParams = map[string]bool{"id": true, "nameEqualTo": true, "nameContains": true, "includeFlavorContent": true, "limit": true, "afterId": true}
+var flavorGroupSearchParams = map[string]bool{"id": true, "nameEqualTo": true, "nameContains": true, "includeFlavorContent": true, "limit": true, "afterId": true,
+	"cursor": true, "includeTotal": true, "sortBy": true, "orderBy": true}
 
 func (controller FlavorgroupController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/flavorgroup_controller:Create() Entering")
@@ -108,6 +110,12 @@ func (controller FlavorgroupController) Search(w http.ResponseWriter, r *http.Re
 		secLog.WithError(err).Errorf("controllers/flavorgroup_controller:Search()  %s", commLogMsg.InvalidInputBadParam)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid filter criteria"}
 	}
+	page, err := PopulatePageCriteria(r.URL.Query(), models.FlavorGroupSortFields)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/flavorgroup_controller:Search()  %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid filter criteria"}
+	}
+	filter.PageCriteria = *page
 
 	flavorgroups, err := controller.FlavorGroupStore.Search(filter)
 	if err != nil {
@@ -115,12 +123,22 @@ func (controller FlavorgroupController) Search(w http.ResponseWriter, r *http.Re
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Flavorgroups"}
 	}
 
+	total := 0
+	if filter.IncludeTotal {
+		total, err = controller.FlavorGroupStore.Count(filter)
+		if err != nil {
+			secLog.WithError(err).Error("controllers/flavorgroup_controller:Search() Flavorgroup count failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Flavorgroups"}
+		}
+	}
+
 	flavorgroupCollection, err := controller.getAssociatedFlavorAndTemplates(flavorgroups, includeFlavorContent, *filter)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/flavorgroup_controller:Search() Error getting flavor(s) " +
 			"associated with flavor group")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search Flavorgroups"}
 	}
+	flavorgroupCollection.Total = total
 
 	secLog.Infof("%s: Return flavorgroup query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
 	return flavorgroupCollection, http.StatusOK, nil
@@ -196,6 +214,65 @@ func (controller FlavorgroupController) Retrieve(w http.ResponseWriter, r *http.
 	return flavorGroup, http.StatusOK, nil
 }
 
+// UpdatePolicyRules replaces the policy rules of the flavorgroup and queues the hosts linked to the flavorgroup
+// for verification so that their reports include the new rules
+func (controller FlavorgroupController) UpdatePolicyRules(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavorgroup_controller:UpdatePolicyRules() Entering")
+	defer defaultLog.Trace("controllers/flavorgroup_controller:UpdatePolicyRules() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/flavorgroup_controller:UpdatePolicyRules() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	fgID := uuid.MustParse(mux.Vars(r)["fgID"])
+
+	var reqPolicyRules hvs.PolicyRuleCollection
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqPolicyRules); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavorgroup_controller:UpdatePolicyRules() %s :  Failed to decode request body as PolicyRuleCollection", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validatePolicyRules(reqPolicyRules.PolicyRules); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavorgroup_controller:UpdatePolicyRules() %s", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid policy rules: " + err.Error()}
+	}
+
+	if err := controller.FlavorGroupStore.UpdatePolicyRules(fgID, reqPolicyRules.PolicyRules); err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			secLog.WithError(err).WithField("id", fgID).Error(
+				"controllers/flavorgroup_controller:UpdatePolicyRules() FlavorGroup with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "FlavorGroup with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", fgID).Errorf("controllers/flavorgroup_controller:UpdatePolicyRules() %s : Failed to update policy rules", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update policy rules of FlavorGroup"}
+	}
+
+	linkedHosts, err := controller.FlavorGroupStore.SearchHostsByFlavorGroup(fgID)
+	if err != nil {
+		defaultLog.WithError(err).WithField("flavorGroup", fgID).Errorf("controllers/flavorgroup_controller:UpdatePolicyRules() %s : Failed to fetch hosts linked to FlavorGroup", commLogMsg.AppRuntimeErr)
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update policy rules of FlavorGroup"}
+	}
+
+	if len(linkedHosts) > 0 {
+		// fetch the host data so that a new report is saved even if the cached flavors are still trusted
+		err = controller.HTManager.VerifyHostsAsync(linkedHosts, true, false)
+		if err != nil {
+			defaultLog.WithError(err).WithField("linkedHosts", linkedHosts).Error("controllers/flavorgroup_controller:UpdatePolicyRules() Addition of Hosts to Flavor Verify Queue failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to update policy rules of FlavorGroup"}
+		}
+	}
+
+	secLog.WithField("flavorGroup", fgID).Infof("%s: FlavorGroup policy rules updated by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return reqPolicyRules, http.StatusOK, nil
+}
+
 func (controller FlavorgroupController) ValidateFlavorGroup(flavorGroup hvs.FlavorGroup) error {
 	defaultLog.Trace("controllers/flavorgroup_controller:ValidateFlavorGroup() Entering")
 	defer defaultLog.Trace("controllers/flavorgroup_controller:ValidateFlavorGroup() Leaving")
@@ -230,6 +307,22 @@ func (controller FlavorgroupController) ValidateFlavorGroup(flavorGroup hvs.Flav
 	if len(flavorGroup.MatchPolicies) == 0 {
 		return errors.New("Flavor Type Match Policy Collection must be specified")
 	}
+	return validatePolicyRules(flavorGroup.PolicyRules)
+}
+
+// validatePolicyRules checks the expression of each policy rule, the names identify the rules in the trust reports
+// so they must be unique within the flavorgroup
+func validatePolicyRules(policyRules []hvs.PolicyRule) error {
+	names := make(map[string]bool)
+	for _, policyRule := range policyRules {
+		if err := rules.ValidatePolicyRule(policyRule); err != nil {
+			return err
+		}
+		if names[policyRule.Name] {
+			return errors.Errorf("Policy rule name '%s' must be unique", policyRule.Name)
+		}
+		names[policyRule.Name] = true
+	}
 	return nil
 }
 
@@ -545,7 +638,7 @@ func (controller FlavorgroupController) getAssociatedFlavorAndTemplates(flavorgr
 	var next, prev string
 	if len(flavorgroupList) > 0 {
 		lastRowId := flavorgroupList[len(flavorgroupList)-1].RowId
-		next, prev = GetNextAndPrevValues(filter.Limit, filter.AfterId, lastRowId, len(flavorgroupList))
+		next, prev = GetPageLinks(filter.PageCriteria, lastRowId, len(flavorgroupList))
 	}
 
 	flavorgroupCollection := &hvs.FlavorgroupCollection{
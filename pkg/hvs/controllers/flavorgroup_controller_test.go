@@ -958,4 +958,118 @@ var _ = Describe("FlavorgroupController", func() {
 			})
 		})
 	})
+
+	// Specs for HTTP Put to "/flavorgroups/{fgID}/policy-rules"
+	Describe("Update the policy rules of a FlavorGroup", func() {
+		Context("Provide valid policy rules", func() {
+			It("Should update the policy rules and get HTTP Status: 200", func() {
+				router.Handle("/flavorgroups/{fgID:"+validation.UUIDReg+"}/policy-rules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.UpdatePolicyRules))).Methods(http.MethodPut)
+				policyRulesJson := `{
+								"policy_rules": [
+									{
+										"name": "txt-bios",
+										"description": "TXT enabled and BIOS version at least 02.01.0010",
+										"expression": {
+											"all_of": [
+												{"path": "host_info.hardware_features.TXT.enabled", "operator": "equals", "value": true},
+												{"path": "host_info.bios_version", "operator": "gte", "value": "SE5C620.86B.02.01.0010"}
+											]
+										}
+									}
+								]
+							}`
+
+				req, err := http.NewRequest(
+					http.MethodPut,
+					"/flavorgroups/ee37c360-7eae-4250-a677-6ee12adce8e2/policy-rules",
+					strings.NewReader(policyRulesJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				fg, err := flavorgroupStore.Retrieve(uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fg.PolicyRules).To(HaveLen(1))
+				Expect(fg.PolicyRules[0].Expression.AllOf).To(HaveLen(2))
+			})
+		})
+
+		Context("Provide a policy rule with an unsupported operator", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/flavorgroups/{fgID:"+validation.UUIDReg+"}/policy-rules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.UpdatePolicyRules))).Methods(http.MethodPut)
+				policyRulesJson := `{"policy_rules": [{"name": "os", "expression": {"path": "host_info.os_name", "operator": "startswith", "value": "Red"}}]}`
+
+				req, err := http.NewRequest(
+					http.MethodPut,
+					"/flavorgroups/ee37c360-7eae-4250-a677-6ee12adce8e2/policy-rules",
+					strings.NewReader(policyRulesJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide policy rules for a non-existent FlavorGroup", func() {
+			It("Should get HTTP Status: 404", func() {
+				router.Handle("/flavorgroups/{fgID:"+validation.UUIDReg+"}/policy-rules", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.UpdatePolicyRules))).Methods(http.MethodPut)
+				policyRulesJson := `{"policy_rules": []}`
+
+				req, err := http.NewRequest(
+					http.MethodPut,
+					"/flavorgroups/73755fda-c910-46be-821f-e8ddeab189e9/policy-rules",
+					strings.NewReader(policyRulesJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("Create a Flavorgroup with policy rules of the same name", func() {
+			It("Should get HTTP Status: 400", func() {
+				router.Handle("/flavorgroups", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorgroupController.Create))).Methods(http.MethodPost)
+				flavorgroupJson := `{
+								"name": "hvs_flavorgroup_policy",
+								"flavor_match_policy_collection": {
+									"flavor_match_policies": [
+										{
+											"flavor_part": "PLATFORM",
+											"match_policy": {
+												"match_type": "ANY_OF",
+												"required": "REQUIRED"
+											}
+										}
+									]
+								},
+								"policy_rules": [
+									{"name": "txt", "expression": {"path": "host_info.hardware_features.TXT.enabled", "operator": "equals", "value": true}},
+									{"name": "txt", "expression": {"path": "host_info.hardware_features.TXT", "operator": "exists"}}
+								]
+							}`
+
+				req, err := http.NewRequest(
+					http.MethodPost,
+					"/flavorgroups",
+					strings.NewReader(flavorgroupJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
 })
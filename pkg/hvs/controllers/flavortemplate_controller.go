@@ -11,6 +11,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/antchfx/jsonquery"
@@ -23,7 +24,9 @@ import (
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
 	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/slice"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/flavor"
 	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
 	"github.com/pkg/errors"
 	"github.com/xeipuuv/gojsonschema"
@@ -32,6 +35,7 @@ import (
 type FlavorTemplateController struct {
 	FTStore                 domain.FlavorTemplateStore
 	FGStore                 domain.FlavorGroupStore
+	HSStore                 domain.HostStatusStore
 	CommonDefinitionsSchema string
 	FlavorTemplateSchema    string
 	DefinitionsSchemaJSON   string
@@ -43,16 +47,18 @@ type ErrorMessage struct {
 
 // NewFlavorTemplateController This method is used to initialize the flavorTemplateController
 func NewFlavorTemplateController(flavorTemplateStore domain.FlavorTemplateStore, flavorGroupStore domain.FlavorGroupStore,
-	commonDefinitionsSchema, flavorTemplateSchema string) *FlavorTemplateController {
+	hostStatusStore domain.HostStatusStore, commonDefinitionsSchema, flavorTemplateSchema string) *FlavorTemplateController {
 	return &FlavorTemplateController{
 		FTStore:                 flavorTemplateStore,
 		FGStore:                 flavorGroupStore,
+		HSStore:                 hostStatusStore,
 		CommonDefinitionsSchema: commonDefinitionsSchema,
 		FlavorTemplateSchema:    flavorTemplateSchema,
 	}
 }
 
-var flavorTemplateSearchParams = map[string]bool{"id": true, "label": true, "conditionContains": true, "flavorPartContains": true, "includeDeleted": true}
+var flavorTemplateSearchParams = map[string]bool{"id": true, "label": true, "conditionContains": true, "flavorPartContains": true, "includeDeleted": true,
+	"limit": true, "afterId": true, "cursor": true, "includeTotal": true, "orderBy": true}
 
 // Create This method is used to create the flavor template and store it in the database
 func (ftc *FlavorTemplateController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -191,6 +197,22 @@ func (ftc *FlavorTemplateController) Search(w http.ResponseWriter, r *http.Reque
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error retrieving all flavor templates"}
 	}
 
+	// the response body is a plain array of templates, the total and the next page are returned as headers
+	if criteria.IncludeTotal {
+		total, err := ftc.FTStore.Count(criteria)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/flavortemplate_controller:Search() Error counting flavor templates")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error retrieving all flavor templates"}
+		}
+		w.Header().Set(consts.HTTPHeaderTotalCount, strconv.Itoa(total))
+	}
+	if len(flavorTemplates) > 0 {
+		lastRowId := flavorTemplates[len(flavorTemplates)-1].RowId
+		if next, _ := GetPageLinks(criteria.PageCriteria, lastRowId, len(flavorTemplates)); next != "" {
+			w.Header().Set(consts.HTTPHeaderLink, fmt.Sprintf("<?%s>; rel=\"next\"", next))
+		}
+	}
+
 	return flavorTemplates, http.StatusOK, nil
 }
 
@@ -448,40 +470,87 @@ func (ftc *FlavorTemplateController) SearchFlavorgroups(w http.ResponseWriter, r
 	return flavorTemplateFlavorgroupCollection, http.StatusOK, nil
 }
 
-// validateFlavorTemplateCreateRequest This method is used to validate the flavor template
-func (ftc *FlavorTemplateController) ValidateFlavorTemplateCreateRequest(FlvrTemp hvs.FlavorTemplate, template string) (string, error) {
-	defaultLog.Trace("controllers/flavortemplate_controller:ValidateFlavorTemplateCreateRequest() Entering")
-	defer defaultLog.Trace("controllers/flavortemplate_controller:ValidateFlavorTemplateCreateRequest() Leaving")
-	// Check whether the template is adhering to the schema
-	schemaLoader := gojsonschema.NewSchemaLoader()
+// Validate checks a flavor template without storing it. When a host manifest or the ID of a registered host is
+// provided, the conditions of the template are evaluated on the host manifest and the flavors the template would
+// generate for the host are returned.
+func (ftc *FlavorTemplateController) Validate(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/flavortemplate_controller:Validate() Entering")
+	defer defaultLog.Trace("controllers/flavortemplate_controller:Validate() Leaving")
 
-	var err error
-	if ftc.DefinitionsSchemaJSON == "" {
-		ftc.DefinitionsSchemaJSON, err = readJSON(ftc.CommonDefinitionsSchema)
-		if err != nil {
-			return "Unable to read the common definitions schema", errors.Wrap(err, "controllers/flavortemplate_controller:ValidateFlavorTemplateCreateRequest() Unable to read the file"+consts.CommonDefinitionsSchema)
-		}
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		defaultLog.Error("controllers/flavortemplate_controller:Validate() Invalid Content-Type")
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
 	}
 
-	if validationErr := validation.ValidateStrings([]string{FlvrTemp.Label}); validationErr != nil {
-		return "Flavor template label is not in valid format", errors.Wrap(validationErr, "controllers/flavortemplate_controller:ValidateFlavorTemplateCreateRequest() Flavor template label is not in valid format")
+	if r.ContentLength == 0 {
+		secLog.Errorf("controllers/flavortemplate_controller:Validate() %s : The request body is not provided", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
 	}
 
-	definitionsSchema := gojsonschema.NewStringLoader(ftc.DefinitionsSchemaJSON)
+	var validationReq hvs.FlavorTemplateValidationRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&validationReq); err != nil {
+		secLog.WithError(err).Errorf("controllers/flavortemplate_controller:Validate() %s : Unable to decode request body", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode request body"}
+	}
 
-	if ftc.TemplateSchemaJSON == "" {
-		ftc.TemplateSchemaJSON, err = readJSON(ftc.FlavorTemplateSchema)
+	if validationReq.HostManifest != nil && validationReq.HostId != nil {
+		secLog.Errorf("controllers/flavortemplate_controller:Validate() %s : Both host manifest and host ID provided", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Only one of host_manifest and host_id can be provided"}
+	}
+
+	validationResult, err := ftc.validateFlavorTemplate(&validationReq.FlavorTemplate)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/flavortemplate_controller:Validate() Unable to validate the flavor template")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to validate the flavor template"}
+	}
+
+	hostManifest := validationReq.HostManifest
+	if validationReq.HostId != nil {
+		hostStatuses, err := ftc.HSStore.Search(&models.HostStatusFilterCriteria{
+			HostId:        *validationReq.HostId,
+			LatestPerHost: true,
+			Limit:         1,
+		})
 		if err != nil {
-			return "Unable to read the template schema", errors.Wrap(err, "controllers/flavortemplate_controller:ValidateFlavorTemplateCreateRequest() Unable to read the file"+consts.FlavorTemplateSchema)
+			defaultLog.WithError(err).Error("controllers/flavortemplate_controller:Validate() Failed to retrieve the host status")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve the host manifest"}
+		}
+		if len(hostStatuses) == 0 {
+			secLog.WithField("id", *validationReq.HostId).Info("controllers/flavortemplate_controller:Validate() No host manifest found for the host")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "No host manifest found for the host with given ID"}
 		}
+		hostManifest = &hostStatuses[0].HostManifest
 	}
 
-	flvrTemplateSchema := gojsonschema.NewStringLoader(ftc.TemplateSchemaJSON)
-	schemaLoader.AddSchemas(definitionsSchema)
+	if hostManifest != nil {
+		validationResult.Preview, err = previewFlavorTemplate(validationReq.FlavorTemplate, hostManifest, validationResult.Valid)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/flavortemplate_controller:Validate() Unable to apply the flavor template to the host manifest")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to apply the flavor template to the host manifest"}
+		}
+		if validationReq.HostId != nil {
+			validationResult.Preview.HostId = *validationReq.HostId
+		}
+	}
 
-	schema, err := schemaLoader.Compile(flvrTemplateSchema)
+	secLog.Infof("%s: Flavor template validated by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return validationResult, http.StatusOK, nil
+}
+
+// validateFlavorTemplateCreateRequest This method is used to validate the flavor template
+func (ftc *FlavorTemplateController) ValidateFlavorTemplateCreateRequest(FlvrTemp hvs.FlavorTemplate, template string) (string, error) {
+	defaultLog.Trace("controllers/flavortemplate_controller:ValidateFlavorTemplateCreateRequest() Entering")
+	defer defaultLog.Trace("controllers/flavortemplate_controller:ValidateFlavorTemplateCreateRequest() Leaving")
+	if validationErr := validation.ValidateStrings([]string{FlvrTemp.Label}); validationErr != nil {
+		return "Flavor template label is not in valid format", errors.Wrap(validationErr, "controllers/flavortemplate_controller:ValidateFlavorTemplateCreateRequest() Flavor template label is not in valid format")
+	}
+
+	// Check whether the template is adhering to the schema
+	schema, errMsg, err := ftc.getFlavorTemplateSchema()
 	if err != nil {
-		return "Unable to compile the template", errors.Wrap(err, "controllers/flavortemplate_controller:ValidateFlavorTemplateCreateRequest() Unable to compile the schemas")
+		return errMsg, errors.Wrap(err, "controllers/flavortemplate_controller:ValidateFlavorTemplateCreateRequest() Unable to get the template schema")
 	}
 
 	documentLoader := gojsonschema.NewStringLoader(template)
@@ -543,6 +612,273 @@ func (ftc *FlavorTemplateController) ValidateFlavorTemplateCreateRequest(FlvrTem
 	return "", nil
 }
 
+// validateFlavorTemplate reports all the errors and warnings of the template instead of stopping at the first error
+// as ValidateFlavorTemplateCreateRequest does
+func (ftc *FlavorTemplateController) validateFlavorTemplate(flavorTemplate *hvs.FlavorTemplate) (*hvs.FlavorTemplateValidation, error) {
+	defaultLog.Trace("controllers/flavortemplate_controller:validateFlavorTemplate() Entering")
+	defer defaultLog.Trace("controllers/flavortemplate_controller:validateFlavorTemplate() Leaving")
+
+	var result hvs.FlavorTemplateValidation
+
+	flavorTemplateBytes, err := json.Marshal(flavorTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to marshal FlavorTemplate")
+	}
+
+	schema, _, err := ftc.getFlavorTemplateSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	schemaResult, err := schema.Validate(gojsonschema.NewBytesLoader(flavorTemplateBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to validate the template")
+	}
+	for _, desc := range schemaResult.Errors() {
+		result.Errors = append(result.Errors, desc.String())
+	}
+
+	if err := validation.ValidateStrings([]string{flavorTemplate.Label}); err != nil {
+		result.Errors = append(result.Errors, "Flavor template label is not in valid format")
+	}
+
+	if len(flavorTemplate.Condition) == 0 {
+		result.Errors = append(result.Errors, "The template has no conditions")
+	}
+
+	tempDoc, err := jsonquery.Parse(strings.NewReader("{}"))
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to parse json query")
+	}
+	for _, condition := range flavorTemplate.Condition {
+		if _, err := jsonquery.Query(tempDoc, condition); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Invalid syntax in condition %q: %s", condition, err.Error()))
+		}
+	}
+
+	flavorParts := getTemplateFlavorParts(flavorTemplate)
+	if len(flavorParts) == 0 {
+		result.Errors = append(result.Errors, "The template does not define any flavor part")
+	}
+
+	for _, partName := range hvs.GetFlavorTypes() {
+		flavorPart, ok := flavorParts[partName]
+		if !ok {
+			continue
+		}
+		pcrIndexes := make(map[int]bool)
+		for _, pcrRule := range flavorPart.PcrRules {
+			ruleErrors, ruleWarnings := validatePcrRule(partName, pcrRule)
+			result.Errors = append(result.Errors, ruleErrors...)
+			result.Warnings = append(result.Warnings, ruleWarnings...)
+
+			if pcrIndexes[pcrRule.Pcr.Index] {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: PCR %d has more than one rule", partName, pcrRule.Pcr.Index))
+			}
+			pcrIndexes[pcrRule.Pcr.Index] = true
+		}
+	}
+	result.Warnings = append(result.Warnings, getConflictingPcrRules(flavorParts)...)
+
+	result.Valid = len(result.Errors) == 0
+	return &result, nil
+}
+
+// getTemplateFlavorParts returns the flavor parts defined in the template
+func getTemplateFlavorParts(flavorTemplate *hvs.FlavorTemplate) map[hvs.FlavorPartName]*hvs.FlavorPart {
+	flavorParts := make(map[hvs.FlavorPartName]*hvs.FlavorPart)
+	if flavorTemplate.FlavorParts == nil {
+		return flavorParts
+	}
+	for partName, flavorPart := range map[hvs.FlavorPartName]*hvs.FlavorPart{
+		hvs.FlavorPartPlatform:   flavorTemplate.FlavorParts.Platform,
+		hvs.FlavorPartOs:         flavorTemplate.FlavorParts.OS,
+		hvs.FlavorPartHostUnique: flavorTemplate.FlavorParts.HostUnique,
+		hvs.FlavorPartIma:        flavorTemplate.FlavorParts.Ima,
+	} {
+		if flavorPart != nil {
+			flavorParts[partName] = flavorPart
+		}
+	}
+	return flavorParts
+}
+
+// validatePcrRule checks the PCR index and banks of a rule and whether the rule verifies anything
+func validatePcrRule(partName hvs.FlavorPartName, pcrRule hvs.PcrRules) ([]string, []string) {
+	var ruleErrors, ruleWarnings []string
+
+	if pcrRule.Pcr.Index < int(hvs.PCR0) || pcrRule.Pcr.Index > int(hvs.PCR23) {
+		ruleErrors = append(ruleErrors, fmt.Sprintf("%s: PCR index %d is not in the range 0 to 23", partName, pcrRule.Pcr.Index))
+	}
+
+	if len(pcrRule.Pcr.Bank) == 0 {
+		ruleErrors = append(ruleErrors, fmt.Sprintf("%s: PCR %d has no bank", partName, pcrRule.Pcr.Index))
+	}
+	banks := make(map[string]bool)
+	for _, bank := range pcrRule.Pcr.Bank {
+		if _, err := hvs.GetSHAAlgorithm(bank); err != nil {
+			ruleErrors = append(ruleErrors, fmt.Sprintf("%s: PCR %d has an unknown bank %s", partName, pcrRule.Pcr.Index, bank))
+		}
+		if banks[bank] {
+			ruleWarnings = append(ruleWarnings, fmt.Sprintf("%s: PCR %d lists bank %s more than once", partName, pcrRule.Pcr.Index, bank))
+		}
+		banks[bank] = true
+	}
+
+	if (pcrRule.PcrMatches == nil || !*pcrRule.PcrMatches) && pcrRule.EventlogEquals == nil && len(pcrRule.EventlogIncludes) == 0 {
+		ruleWarnings = append(ruleWarnings, fmt.Sprintf("%s: PCR %d rule does not verify the PCR value or the event log", partName, pcrRule.Pcr.Index))
+	}
+
+	tags := make(map[string]bool)
+	for _, tag := range pcrRule.EventlogIncludes {
+		if tags[tag] {
+			ruleWarnings = append(ruleWarnings, fmt.Sprintf("%s: PCR %d includes the events tagged %s more than once", partName, pcrRule.Pcr.Index, tag))
+		}
+		tags[tag] = true
+	}
+	return ruleErrors, ruleWarnings
+}
+
+// getConflictingPcrRules looks for rules of different flavor parts that verify the same events of a PCR. An event
+// included by a flavor part must be excluded from the eventlog_equals rule of the other parts and included only once,
+// otherwise a change of the event makes several flavor parts untrusted.
+func getConflictingPcrRules(flavorParts map[hvs.FlavorPartName]*hvs.FlavorPart) []string {
+	var warnings []string
+	partNames := hvs.GetFlavorTypes()
+
+	for i, partName := range partNames {
+		flavorPart, ok := flavorParts[partName]
+		if !ok {
+			continue
+		}
+		for _, pcrRule := range flavorPart.PcrRules {
+			for _, otherPartName := range partNames[i+1:] {
+				otherPart, ok := flavorParts[otherPartName]
+				if !ok {
+					continue
+				}
+				for _, otherRule := range otherPart.PcrRules {
+					if otherRule.Pcr.Index != pcrRule.Pcr.Index {
+						continue
+					}
+					if pcrRule.PcrMatches != nil && *pcrRule.PcrMatches && otherRule.PcrMatches != nil && *otherRule.PcrMatches {
+						warnings = append(warnings, fmt.Sprintf("%s and %s: both verify the value of PCR %d", partName, otherPartName, pcrRule.Pcr.Index))
+					}
+					for _, tag := range pcrRule.EventlogIncludes {
+						if slice.Contains(otherRule.EventlogIncludes, tag) {
+							warnings = append(warnings, fmt.Sprintf("%s and %s: both include the events tagged %s of PCR %d", partName, otherPartName, tag, pcrRule.Pcr.Index))
+						} else if otherRule.EventlogEquals != nil && !slice.Contains(otherRule.EventlogEquals.ExcludingTags, tag) {
+							warnings = append(warnings, fmt.Sprintf("%s: the events tagged %s of PCR %d are not excluded from the eventlog_equals rule of %s", otherPartName, tag, pcrRule.Pcr.Index, partName))
+						}
+					}
+					for _, tag := range otherRule.EventlogIncludes {
+						if pcrRule.EventlogEquals != nil && !slice.Contains(pcrRule.EventlogEquals.ExcludingTags, tag) {
+							warnings = append(warnings, fmt.Sprintf("%s: the events tagged %s of PCR %d are not excluded from the eventlog_equals rule of %s", partName, tag, pcrRule.Pcr.Index, otherPartName))
+						}
+					}
+				}
+			}
+		}
+	}
+	return warnings
+}
+
+// previewFlavorTemplate evaluates the conditions of the template on the host manifest in the same way as the flavor
+// controller and, when they all match, generates the flavors of the template with the flavor library
+func previewFlavorTemplate(flavorTemplate hvs.FlavorTemplate, hostManifest *hvs.HostManifest, generateFlavors bool) (*hvs.FlavorTemplatePreview, error) {
+	defaultLog.Trace("controllers/flavortemplate_controller:previewFlavorTemplate() Entering")
+	defer defaultLog.Trace("controllers/flavortemplate_controller:previewFlavorTemplate() Leaving")
+
+	preview := hvs.FlavorTemplatePreview{
+		HardwareUUID: hostManifest.HostInfo.HardwareUUID,
+		Conditions:   []hvs.FlavorTemplateConditionResult{},
+		Matched:      len(flavorTemplate.Condition) > 0,
+	}
+
+	hostManifestBytes, err := json.Marshal(hostManifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling host manifest")
+	}
+	hostManifestJSON, err := jsonquery.Parse(strings.NewReader(string(hostManifestBytes)))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in parsing the host manifest")
+	}
+
+	for _, condition := range flavorTemplate.Condition {
+		conditionResult := hvs.FlavorTemplateConditionResult{Condition: condition}
+		expectedData, err := jsonquery.Query(hostManifestJSON, condition)
+		if err != nil {
+			conditionResult.Error = err.Error()
+		} else {
+			conditionResult.Matched = expectedData != nil
+		}
+		if !conditionResult.Matched {
+			preview.Matched = false
+		}
+		preview.Conditions = append(preview.Conditions, conditionResult)
+	}
+
+	if !preview.Matched || !generateFlavors {
+		return &preview, nil
+	}
+
+	platformFlavorProvider, err := flavor.NewPlatformFlavorProvider(hostManifest, nil, []hvs.FlavorTemplate{flavorTemplate})
+	if err != nil {
+		preview.Errors = append(preview.Errors, err.Error())
+		return &preview, nil
+	}
+	platformFlavor, err := platformFlavorProvider.GetPlatformFlavor()
+	if err != nil {
+		preview.Errors = append(preview.Errors, err.Error())
+		return &preview, nil
+	}
+
+	flavorParts := getTemplateFlavorParts(&flavorTemplate)
+	for _, partName := range hvs.GetFlavorTypes() {
+		if _, ok := flavorParts[partName]; !ok {
+			continue
+		}
+		flavors, err := (*platformFlavor).GetFlavorPartRaw(partName)
+		if err != nil {
+			preview.Errors = append(preview.Errors, fmt.Sprintf("Unable to generate the %s flavor: %s", partName, err.Error()))
+			continue
+		}
+		preview.Flavors = append(preview.Flavors, flavors...)
+	}
+	return &preview, nil
+}
+
+// getFlavorTemplateSchema compiles the flavor template schema, the schema files are read on first use
+func (ftc *FlavorTemplateController) getFlavorTemplateSchema() (*gojsonschema.Schema, string, error) {
+	schemaLoader := gojsonschema.NewSchemaLoader()
+
+	var err error
+	if ftc.DefinitionsSchemaJSON == "" {
+		ftc.DefinitionsSchemaJSON, err = readJSON(ftc.CommonDefinitionsSchema)
+		if err != nil {
+			return nil, "Unable to read the common definitions schema", errors.Wrap(err, "controllers/flavortemplate_controller:getFlavorTemplateSchema() Unable to read the file"+consts.CommonDefinitionsSchema)
+		}
+	}
+
+	definitionsSchema := gojsonschema.NewStringLoader(ftc.DefinitionsSchemaJSON)
+
+	if ftc.TemplateSchemaJSON == "" {
+		ftc.TemplateSchemaJSON, err = readJSON(ftc.FlavorTemplateSchema)
+		if err != nil {
+			return nil, "Unable to read the template schema", errors.Wrap(err, "controllers/flavortemplate_controller:getFlavorTemplateSchema() Unable to read the file"+consts.FlavorTemplateSchema)
+		}
+	}
+
+	flvrTemplateSchema := gojsonschema.NewStringLoader(ftc.TemplateSchemaJSON)
+	schemaLoader.AddSchemas(definitionsSchema)
+
+	schema, err := schemaLoader.Compile(flvrTemplateSchema)
+	if err != nil {
+		return nil, "Unable to compile the template", errors.Wrap(err, "controllers/flavortemplate_controller:getFlavorTemplateSchema() Unable to compile the schemas")
+	}
+	return schema, "", nil
+}
+
 // readJSON This method is used to read the json file
 func readJSON(jsonFilePath string) (string, error) {
 	defaultLog.Trace("controllers/flavortemplate_controller:readJSON() Entering")
@@ -599,5 +935,12 @@ func populateFlavorTemplateFilterCriteria(params url.Values) (*models.FlavorTemp
 		criteria.FlavorPartContains = strings.ToUpper(flavorPart)
 	}
 
+	// the label is part of the template content, the templates can only be listed in the order of creation
+	page, err := PopulatePageCriteria(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	criteria.PageCriteria = *page
+
 	return &criteria, nil
 }
@@ -7,9 +7,13 @@ package controllers_test
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"strconv"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	hvsConsts "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
 	hvsRoutes "github.com/intel-secl/intel-secl/v5/pkg/hvs/router"
@@ -27,13 +31,15 @@ var _ = Describe("FlavorTemplateController", func() {
 	var w *httptest.ResponseRecorder
 	var flavorTemplateStore *mocks.MockFlavorTemplateStore
 	var flavorGroupStore *mocks.MockFlavorgroupStore
+	var hostStatusStore *mocks.MockHostStatusStore
 	var flavorTemplateController *controllers.FlavorTemplateController
 	BeforeEach(func() {
 		router = mux.NewRouter()
 		flavorTemplateStore = mocks.NewFakeFlavorTemplateStore()
 		flavorGroupStore = mocks.NewFakeFlavorgroupStore()
+		hostStatusStore = mocks.NewMockHostStatusStore()
 
-		flavorTemplateController = controllers.NewFlavorTemplateController(flavorTemplateStore, flavorGroupStore,
+		flavorTemplateController = controllers.NewFlavorTemplateController(flavorTemplateStore, flavorGroupStore, hostStatusStore,
 			"../../../build/linux/hvs/schema/common.schema.json", "../../../build/linux/hvs/schema/flavor-template.json")
 	})
 
@@ -779,6 +785,24 @@ var _ = Describe("FlavorTemplateController", func() {
 				var ft *[]hvs.FlavorTemplate
 				err = json.Unmarshal(w.Body.Bytes(), &ft)
 				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Header().Get(hvsConsts.HTTPHeaderTotalCount)).To(BeEmpty())
+			})
+		})
+
+		Context("When includeTotal parameter is added in search API", func() {
+			It("The total count of Flavor template records is returned in a header", func() {
+				router.Handle("/flavor-templates", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorTemplateController.Search))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/flavor-templates?includeTotal=true", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var ft *[]hvs.FlavorTemplate
+				err = json.Unmarshal(w.Body.Bytes(), &ft)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Header().Get(hvsConsts.HTTPHeaderTotalCount)).To(Equal(strconv.Itoa(len(*ft))))
 			})
 		})
 
@@ -1141,4 +1165,123 @@ var _ = Describe("FlavorTemplateController", func() {
 
 	})
 
+	// Specs for HTTP Post to "/flavor-templates/validate"
+	Describe("Validate a FlavorTemplate", func() {
+		var validationReq hvs.FlavorTemplateValidationRequest
+		BeforeEach(func() {
+			router.Handle("/flavor-templates/validate", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(flavorTemplateController.Validate))).Methods(http.MethodPost)
+
+			templateBytes, err := ioutil.ReadFile("../../../build/linux/hvs/templates/default-linux-rhel-tpm20-tboot.json")
+			Expect(err).NotTo(HaveOccurred())
+			validationReq = hvs.FlavorTemplateValidationRequest{}
+			Expect(json.Unmarshal(templateBytes, &validationReq.FlavorTemplate)).To(Succeed())
+		})
+
+		validate := func(contentType string) *hvs.FlavorTemplateValidation {
+			validationReqBytes, err := json.Marshal(validationReq)
+			Expect(err).NotTo(HaveOccurred())
+			req, err := http.NewRequest(http.MethodPost, "/flavor-templates/validate", strings.NewReader(string(validationReqBytes)))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", contentType)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				return nil
+			}
+			var validationResult hvs.FlavorTemplateValidation
+			Expect(json.Unmarshal(w.Body.Bytes(), &validationResult)).To(Succeed())
+			return &validationResult
+		}
+
+		Context("Provide a valid FlavorTemplate and a matching host manifest", func() {
+			It("Should return the flavors generated for the host and HTTP Status: 200", func() {
+				manifestBytes, err := ioutil.ReadFile("../../lib/flavor/test/resources/RHELHostManifest.json")
+				Expect(err).NotTo(HaveOccurred())
+				validationReq.HostManifest = &hvs.HostManifest{}
+				Expect(json.Unmarshal(manifestBytes, validationReq.HostManifest)).To(Succeed())
+
+				validationResult := validate(consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(validationResult.Valid).To(BeTrue())
+				Expect(validationResult.Errors).To(BeEmpty())
+				Expect(validationResult.Warnings).To(BeEmpty())
+				Expect(validationResult.Preview.HardwareUUID).To(Equal("0009e54e-642f-e511-906e-0012795d96dd"))
+				Expect(validationResult.Preview.Conditions).To(HaveLen(3))
+				Expect(validationResult.Preview.Matched).To(BeTrue())
+				Expect(validationResult.Preview.Errors).To(BeEmpty())
+
+				var flavorParts []string
+				for _, flavor := range validationResult.Preview.Flavors {
+					flavorParts = append(flavorParts, flavor.Meta.Description[hvs.FlavorPartDescription].(string))
+				}
+				Expect(flavorParts).To(Equal([]string{hvs.FlavorPartPlatform.String(), hvs.FlavorPartOs.String(), hvs.FlavorPartHostUnique.String()}))
+			})
+		})
+
+		Context("Provide a FlavorTemplate with invalid conditions and PCR rules", func() {
+			It("Should return the errors and warnings of the template and HTTP Status: 200", func() {
+				validationReq.FlavorTemplate.Condition = append(validationReq.FlavorTemplate.Condition, "//host_info/os_name[")
+				pcrRules := validationReq.FlavorTemplate.FlavorParts.OS.PcrRules
+				pcrRules[0].EventlogIncludes = []string{"vmlinuz", "initrd"}
+				pcrRules = append(pcrRules, hvs.PcrRules{Pcr: hvs.PCR{Index: 17, Bank: []string{"SHA256", "SHA256", "MD5"}}})
+				pcrRules = append(pcrRules, hvs.PcrRules{Pcr: hvs.PCR{Index: 24, Bank: []string{"SHA256"}}, EventlogIncludes: []string{"shim"}})
+				validationReq.FlavorTemplate.FlavorParts.OS.PcrRules = pcrRules
+
+				validationResult := validate(consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(validationResult.Valid).To(BeFalse())
+				Expect(validationResult.Preview).To(BeNil())
+				Expect(validationResult.Errors).To(ContainElement(ContainSubstring("Invalid syntax in condition")))
+				Expect(validationResult.Errors).To(ContainElement("OS: PCR 17 has more than one rule"))
+				Expect(validationResult.Errors).To(ContainElement("OS: PCR 17 has an unknown bank MD5"))
+				Expect(validationResult.Errors).To(ContainElement("OS: PCR index 24 is not in the range 0 to 23"))
+				Expect(validationResult.Warnings).To(ContainElement("OS: PCR 17 lists bank SHA256 more than once"))
+				Expect(validationResult.Warnings).To(ContainElement("OS: PCR 17 rule does not verify the PCR value or the event log"))
+				Expect(validationResult.Warnings).To(ContainElement("OS and HOST_UNIQUE: both include the events tagged initrd of PCR 17"))
+			})
+		})
+
+		Context("Provide a FlavorTemplate and the ID of a registered host", func() {
+			It("Should evaluate the conditions on the host manifest of the host and return HTTP Status: 200", func() {
+				hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+				validationReq.HostId = &hostId
+
+				validationResult := validate(consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(validationResult.Valid).To(BeTrue())
+				Expect(validationResult.Preview.HostId).To(Equal(hostId))
+				Expect(validationResult.Preview.Conditions).To(HaveLen(3))
+			})
+		})
+
+		Context("Provide the ID of a host without host manifest", func() {
+			It("Should return HTTP Status: 404", func() {
+				hostId := uuid.MustParse("13885605-a0ee-41f2-b6fc-fd82edc487ad")
+				validationReq.HostId = &hostId
+
+				validate(consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("Provide both a host manifest and a host ID", func() {
+			It("Should return HTTP Status: 400", func() {
+				hostId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+				validationReq.HostId = &hostId
+				validationReq.HostManifest = &hvs.HostManifest{}
+
+				validate(consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide an invalid Content-Type", func() {
+			It("Should return HTTP Status: 415", func() {
+				validate(consts.HTTPMediaTypeXml)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+
 })
@@ -0,0 +1,154 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	hvsConstants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
+	hostConnector "github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type HostBundleController struct {
+	BundleDir string
+	MaxAge    time.Duration
+	// serializes the replacement of bundles so that an older bundle never overwrites a newer one
+	storeLock *sync.Mutex
+}
+
+func NewHostBundleController(bundleDir string, maxAge time.Duration) *HostBundleController {
+	if maxAge <= 0 {
+		maxAge = hvsConstants.DefaultHostBundleMaxAge
+	}
+	return &HostBundleController{BundleDir: bundleDir, MaxAge: maxAge, storeLock: &sync.Mutex{}}
+}
+
+// errStaleHostBundle is returned when the host bundle is not newer than the stored bundle of the host
+var errStaleHostBundle = errors.New("host bundle is not newer than the stored host bundle")
+
+// Create verifies a host bundle pushed by a host and stores it in the bundle directory, the host can then be
+// registered with the connection string of the bundle and is attested from the last bundle it pushed
+func (controller HostBundleController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_bundle_controller:Create() Entering")
+	defer defaultLog.Trace("controllers/host_bundle_controller:Create() Leaving")
+
+	if r.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/host_bundle_controller:Create() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var hostBundle hvs.HostBundle
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&hostBundle); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_bundle_controller:Create() %s :  Failed to decode request body as host bundle", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	hostManifest, err := hostConnector.VerifyHostBundle(&hostBundle)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/host_bundle_controller:Create() %s : Host bundle verification failed", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host bundle verification failed"}
+	}
+
+	if err = hostConnector.CheckHostBundleAge(&hostBundle, controller.MaxAge); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_bundle_controller:Create() %s : Host bundle is expired", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host bundle is older than " + controller.MaxAge.String() + " or created in the future"}
+	}
+
+	// the hardware uuid is used as the file name of the bundle
+	hardwareUUID, err := uuid.Parse(hostManifest.HostInfo.HardwareUUID)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/host_bundle_controller:Create() %s : Invalid hardware UUID in host bundle", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid hardware UUID in host bundle"}
+	}
+
+	bundlePath, err := controller.storeHostBundle(hardwareUUID, &hostBundle)
+	if err == errStaleHostBundle {
+		secLog.Errorf("controllers/host_bundle_controller:Create() %s : Host bundle of %s is not newer than the stored bundle", commLogMsg.InvalidInputBadParam, hardwareUUID)
+		return nil, http.StatusConflict, &commErr.ResourceError{Message: "Host bundle is not newer than the stored host bundle"}
+	}
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_bundle_controller:Create() Error storing host bundle")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error storing host bundle"}
+	}
+	secLog.Infof("controllers/host_bundle_controller:Create() Host bundle of %s stored by: %s", hardwareUUID, r.RemoteAddr)
+
+	return hvs.HostBundleUploadResponse{
+		HardwareUUID:     hardwareUUID.String(),
+		Created:          hostBundle.Created,
+		ConnectionString: "bundle:file://" + bundlePath,
+	}, http.StatusCreated, nil
+}
+
+// storeHostBundle replaces the bundle of the host, the bundle is written to a temporary file first so that a
+// concurrent attestation never reads a partial bundle. A bundle that is not newer than the stored bundle is rejected
+// so that an old bundle cannot be replayed
+func (controller HostBundleController) storeHostBundle(hardwareUUID uuid.UUID, hostBundle *hvs.HostBundle) (string, error) {
+	controller.storeLock.Lock()
+	defer controller.storeLock.Unlock()
+
+	err := os.MkdirAll(controller.BundleDir, 0700)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not create directory %s", controller.BundleDir)
+	}
+
+	bundlePath := filepath.Join(controller.BundleDir, hardwareUUID.String()+".json")
+	storedBundleBytes, err := ioutil.ReadFile(bundlePath)
+	if err == nil {
+		var storedBundle hvs.HostBundle
+		err = json.Unmarshal(storedBundleBytes, &storedBundle)
+		if err != nil {
+			return "", errors.Wrapf(err, "could not unmarshal stored host bundle %s", bundlePath)
+		}
+		if !hostBundle.Created.After(storedBundle.Created) {
+			return "", errStaleHostBundle
+		}
+	} else if !os.IsNotExist(err) {
+		return "", errors.Wrapf(err, "could not read stored host bundle %s", bundlePath)
+	}
+
+	bundleBytes, err := json.Marshal(hostBundle)
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal host bundle")
+	}
+
+	tmpFile, err := ioutil.TempFile(controller.BundleDir, hardwareUUID.String()+".*.tmp")
+	if err != nil {
+		return "", errors.Wrap(err, "could not create temporary host bundle file")
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(bundleBytes)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "could not write host bundle")
+	}
+
+	err = os.Rename(tmpFile.Name(), bundlePath)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not rename host bundle to %s", bundlePath)
+	}
+	return bundlePath, nil
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	hvsRoutes "github.com/intel-secl/intel-secl/v5/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	hostConnector "github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector"
+	hcConstants "github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/constants"
+	hcMocks "github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HostBundleController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var bundleDir string
+	var hostBundle *hvs.HostBundle
+	var hostInfo []byte
+
+	BeforeEach(func() {
+		var err error
+		bundleDir, err = ioutil.TempDir("", "host-bundles")
+		Expect(err).NotTo(HaveOccurred())
+
+		hostInfo, err = ioutil.ReadFile("../../lib/host-connector/test/sample_platform_info.json")
+		Expect(err).NotTo(HaveOccurred())
+		hostBundle, err = hcMocks.NewMockHostBundle(hostInfo)
+		Expect(err).NotTo(HaveOccurred())
+
+		router = mux.NewRouter()
+		hostBundleController := controllers.NewHostBundleController(bundleDir, time.Hour)
+		router.Handle("/host-bundles", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostBundleController.Create))).Methods(http.MethodPost)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(bundleDir)
+	})
+
+	upload := func(body []byte, contentType string) {
+		req, err := http.NewRequest(http.MethodPost, "/host-bundles", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		req.Header.Set("Content-Type", contentType)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+	}
+
+	// Specs for HTTP Post to "/host-bundles"
+	Describe("Upload a host bundle", func() {
+		Context("When the TPM quote of the bundle is valid", func() {
+			It("Should store the bundle and return its connection string", func() {
+				body, err := json.Marshal(hostBundle)
+				Expect(err).NotTo(HaveOccurred())
+				upload(body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var response hvs.HostBundleUploadResponse
+				Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+				Expect(response.HardwareUUID).To(Equal("0009e54e-642f-e511-906e-0012795d96dd"))
+				bundlePath := filepath.Join(bundleDir, response.HardwareUUID+".json")
+				Expect(response.ConnectionString).To(Equal("bundle:file://" + bundlePath))
+
+				// the stored bundle can be read by the bundle connector
+				factory := hostConnector.NewBundleConnectorFactory(bundleDir, time.Hour)
+				connector, err := factory.GetHostConnector(vendorBundleConnector("file://"+bundlePath), "", nil, false)
+				Expect(err).NotTo(HaveOccurred())
+				hostManifest, err := connector.GetHostManifest(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostManifest.HostInfo.HardwareUUID).To(Equal(response.HardwareUUID))
+			})
+		})
+		Context("When the bundle is not newer than the stored bundle", func() {
+			It("Should return conflict and keep the stored bundle", func() {
+				body, err := json.Marshal(hostBundle)
+				Expect(err).NotTo(HaveOccurred())
+				upload(body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				// the same bundle is replayed
+				upload(body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusConflict))
+
+				olderBundle, err := hcMocks.NewMockHostBundleCreatedAt(hostInfo, hostBundle.Created.Add(-time.Minute))
+				Expect(err).NotTo(HaveOccurred())
+				body, err = json.Marshal(olderBundle)
+				Expect(err).NotTo(HaveOccurred())
+				upload(body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusConflict))
+
+				storedBundleBytes, err := ioutil.ReadFile(filepath.Join(bundleDir, "0009e54e-642f-e511-906e-0012795d96dd.json"))
+				Expect(err).NotTo(HaveOccurred())
+				var storedBundle hvs.HostBundle
+				Expect(json.Unmarshal(storedBundleBytes, &storedBundle)).To(Succeed())
+				Expect(storedBundle.Created.Equal(hostBundle.Created)).To(BeTrue())
+			})
+		})
+		Context("When the bundle is older than the max age", func() {
+			It("Should return bad request", func() {
+				staleBundle, err := hcMocks.NewMockHostBundleCreatedAt(hostInfo, time.Now().Add(-2*time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+				body, err := json.Marshal(staleBundle)
+				Expect(err).NotTo(HaveOccurred())
+				upload(body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+				files, err := ioutil.ReadDir(bundleDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(BeEmpty())
+			})
+		})
+		Context("When the bundle is created in the future", func() {
+			It("Should return bad request", func() {
+				futureBundle, err := hcMocks.NewMockHostBundleCreatedAt(hostInfo, time.Now().Add(time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+				body, err := json.Marshal(futureBundle)
+				Expect(err).NotTo(HaveOccurred())
+				upload(body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the host info of the bundle is modified", func() {
+			It("Should return bad request", func() {
+				hostBundle.HostInfo = []byte(`{"hardware_uuid":"00ecd3ab-9af4-e711-906e-001560a04063"}`)
+				hostBundle.Nonce = hvs.GetHostBundleNonce(hostBundle.HostInfo, hostBundle.Created)
+				body, err := json.Marshal(hostBundle)
+				Expect(err).NotTo(HaveOccurred())
+				upload(body, consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+				files, err := ioutil.ReadDir(bundleDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(BeEmpty())
+			})
+		})
+		Context("When the request body contains unknown fields", func() {
+			It("Should return bad request", func() {
+				upload([]byte(`{"host":"bundle"}`), consts.HTTPMediaTypeJson)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the Content-Type is not json", func() {
+			It("Should return unsupported media type", func() {
+				upload([]byte(`{}`), consts.HTTPMediaTypeJwt)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+})
+
+func vendorBundleConnector(url string) types.VendorConnector {
+	return types.VendorConnector{
+		Vendor: hcConstants.VendorBundle,
+		Url:    url,
+	}
+}
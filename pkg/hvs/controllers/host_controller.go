@@ -5,12 +5,15 @@
 package controllers
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -34,12 +37,13 @@ type HostController struct {
 	FStore    domain.FlavorStore
 	FGStore   domain.FlavorGroupStore
 	HCStore   domain.HostCredentialStore
+	HRStore   domain.HostRegistrationStore
 	HTManager domain.HostTrustManager
 	HCConfig  domain.HostControllerConfig
 }
 
 func NewHostController(hs domain.HostStore, hss domain.HostStatusStore, fs domain.FlavorStore,
-	fgs domain.FlavorGroupStore, hcs domain.HostCredentialStore,
+	fgs domain.FlavorGroupStore, hcs domain.HostCredentialStore, hrs domain.HostRegistrationStore,
 	htm domain.HostTrustManager, hcc domain.HostControllerConfig) *HostController {
 	return &HostController{
 		HStore:    hs,
@@ -47,16 +51,35 @@ func NewHostController(hs domain.HostStore, hss domain.HostStatusStore, fs domai
 		FStore:    fs,
 		FGStore:   fgs,
 		HCStore:   hcs,
+		HRStore:   hrs,
 		HTManager: htm,
 		HCConfig:  hcc,
 	}
 }
 
 var hostSearchParams = map[string]bool{"id": true, "nameEqualTo": true, "nameContains": true, "hostHardwareId": true,
-	"key": true, "value": true, "trusted": true, "getTrustStatus": true, "getHostStatus": true, "orderBy": true, "limit": true, "afterId": true}
+	"key": true, "value": true, "trusted": true, "getTrustStatus": true, "getHostStatus": true, "orderBy": true, "limit": true, "afterId": true,
+	"cursor": true, "includeTotal": true, "sortBy": true}
 
 var hostRetrieveParams = map[string]bool{"getReport": true, "getHostStatus": true}
 
+const (
+	// maxBulkCreateHosts is the number of hosts that can be registered in a bulk create request
+	maxBulkCreateHosts = 1000
+	// bulkCreateHostsBatchSize is the number of hosts created in a transaction by a bulk create request
+	bulkCreateHostsBatchSize = 50
+	// bulkCreateQuoteWorkers is the number of hosts of a bulk create request whose quote is verified concurrently
+	bulkCreateQuoteWorkers = 10
+
+	hostCsvColumnHostName         = "host_name"
+	hostCsvColumnDescription      = "description"
+	hostCsvColumnConnectionString = "connection_string"
+	hostCsvColumnFlavorgroupNames = "flavorgroup_names"
+)
+
+var hostCsvColumns = map[string]bool{hostCsvColumnHostName: true, hostCsvColumnDescription: true,
+	hostCsvColumnConnectionString: true, hostCsvColumnFlavorgroupNames: true}
+
 func (hc *HostController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/host_controller:Create() Entering")
 	defer defaultLog.Trace("controllers/host_controller:Create() Leaving")
@@ -81,44 +104,381 @@ func (hc *HostController) Create(w http.ResponseWriter, r *http.Request) (interf
 	}
 
 	if hc.HCConfig.VerifyQuoteForHostRegistration {
+		if err := hc.verifyHostQuote(reqHost.ConnectionString); err != nil {
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: err.Error()}
+		}
+	}
+	createdHost, status, err := hc.CreateHost(reqHost)
+	if err != nil {
+		return nil, status, err
+	}
 
-		connectionString, _, err := GenerateConnectionString(reqHost.ConnectionString, hc.HCConfig.Username,
-			hc.HCConfig.Password,
-			hc.HCStore)
+	secLog.WithField("host", createdHost).Infof("%s: Host created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return createdHost, status, nil
+}
+
+// BulkCreate registers the hosts of a HostBulkCreateRequest or of a CSV file. The hosts are only connected to verify
+// their quote, their details are retrieved by the flavor verification triggered after they are created. A host that
+// cannot be registered is reported in the result of its row and does not fail the other hosts.
+func (hc *HostController) BulkCreate(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_controller:BulkCreate() Entering")
+	defer defaultLog.Trace("controllers/host_controller:BulkCreate() Leaving")
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType != consts.HTTPMediaTypeJson && contentType != consts.HTTPMediaTypeCsv {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/host_controller:BulkCreate() The request body was not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
+	}
+
+	var reqHosts []hvs.HostCreateRequest
+	if contentType == consts.HTTPMediaTypeCsv {
+		var err error
+		reqHosts, err = parseHostCreateRequestsCsv(r.Body)
 		if err != nil {
-			defaultLog.WithError(err).Error("controllers/host_controller:Create() Could not generate formatted connection string")
-			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error generating connection string"}
+			secLog.WithError(err).Errorf("controllers/host_controller:BulkCreate() %s :  Failed to parse request body as CSV", commLogMsg.InvalidInputBadEncoding)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to parse CSV request body: " + err.Error()}
 		}
+	} else {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
 
-		hostConnector, err := hc.HCConfig.HostConnectorProvider.NewHostConnector(connectionString)
-		if err != nil {
-			defaultLog.WithError(err).Error("controllers/host_controller:Create() Failed " +
-				"to initialize HostConnector to the host")
-			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Target Host connection failed"}
+		var reqBulkHosts hvs.HostBulkCreateRequest
+		if err := dec.Decode(&reqBulkHosts); err != nil {
+			secLog.WithError(err).Errorf("controllers/host_controller:BulkCreate() %s :  Failed to decode request body as HostBulkCreateRequest", commLogMsg.InvalidInputBadEncoding)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
 		}
+		reqHosts = reqBulkHosts.HostCreateRequests
+	}
+
+	if len(reqHosts) == 0 {
+		secLog.Errorf("controllers/host_controller:BulkCreate() %s : No hosts provided", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "At least one host must be specified"}
+	}
+	if len(reqHosts) > maxBulkCreateHosts {
+		secLog.Errorf("controllers/host_controller:BulkCreate() %s : Too many hosts provided", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: fmt.Sprintf("At most %d hosts can be registered in a request", maxBulkCreateHosts)}
+	}
 
-		nonce, err := hcUtil.GenerateNonce(20)
+	response := hc.createHosts(reqHosts)
+	secLog.Infof("%s: %d hosts created by: %s", commLogMsg.PrivilegeModified, response.Created, r.RemoteAddr)
+	return response, http.StatusOK, nil
+}
+
+// createHosts validates the hosts and creates the valid ones in batches, the hosts of a batch are created in a single
+// transaction and queued for flavor verification together. When a batch fails, its hosts are created one by one so
+// that a failing host does not fail the other hosts of the batch
+func (hc *HostController) createHosts(reqHosts []hvs.HostCreateRequest) *hvs.HostBulkCreateResponse {
+	defaultLog.Trace("controllers/host_controller:createHosts() Entering")
+	defer defaultLog.Trace("controllers/host_controller:createHosts() Leaving")
+
+	response := &hvs.HostBulkCreateResponse{Results: make([]hvs.HostBulkCreateResult, len(reqHosts))}
+	var batch []*models.HostRegistration
+	var batchRows []int
+
+	createBatch := func() {
+		if len(batch) == 0 {
+			return
+		}
+		defer func() {
+			batch = nil
+			batchRows = nil
+		}()
+		var hostIds []uuid.UUID
+		if err := hc.HRStore.CreateBatch(batch); err == nil {
+			for i, registration := range batch {
+				response.Results[batchRows[i]].Host = registration.Host
+				hostIds = append(hostIds, registration.Host.Id)
+			}
+		} else {
+			// the batch is rolled back, the hosts are created one by one so that only the failing rows are reported
+			defaultLog.WithError(err).Warn("controllers/host_controller:createHosts() Host batch create failed, creating the hosts one by one")
+			for i, registration := range batch {
+				if err := hc.HRStore.CreateBatch([]*models.HostRegistration{registration}); err != nil {
+					defaultLog.WithError(err).Errorf("controllers/host_controller:createHosts() Host %s create failed", registration.Host.HostName)
+					response.Results[batchRows[i]].Error = "Failed to create Host"
+					continue
+				}
+				response.Results[batchRows[i]].Host = registration.Host
+				hostIds = append(hostIds, registration.Host.Id)
+			}
+		}
+		if len(hostIds) == 0 {
+			return
+		}
+		// Since we are adding new hosts, the forceUpdate flag should be set to true so that we connect to the
+		// hosts and get the host manifest and the host details that were not retrieved during the registration
+		if err := hc.HTManager.VerifyHostsAsync(hostIds, true, false); err != nil {
+			defaultLog.WithError(err).Error("controllers/host_controller:createHosts() Host to Flavor Verify Queue addition failed")
+		}
+	}
+
+	existingHostNames, err := hc.existingHostNames(reqHosts)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:createHosts() Host search failed")
+		for i, reqHost := range reqHosts {
+			response.Results[i] = hvs.HostBulkCreateResult{Row: i + 1, HostName: reqHost.HostName, Error: "Failed to create Host"}
+		}
+		response.Failed = len(reqHosts)
+		return response
+	}
+
+	hostNames := make(map[string]bool, len(reqHosts))
+	registrations := make([]*models.HostRegistration, len(reqHosts))
+	connectionStrings := make([]string, len(reqHosts))
+	for i, reqHost := range reqHosts {
+		response.Results[i] = hvs.HostBulkCreateResult{Row: i + 1, HostName: reqHost.HostName}
+		if reqHost.HostName != "" && hostNames[reqHost.HostName] {
+			response.Results[i].Error = "Host with this name is already specified in the request"
+			continue
+		}
+		hostNames[reqHost.HostName] = true
+
+		registration, connectionString, err := hc.newHostRegistration(reqHost, existingHostNames)
 		if err != nil {
-			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error generating nonce for TPM quote request"}
+			response.Results[i].Error = err.Error()
+			continue
 		}
+		registrations[i] = registration
+		connectionStrings[i] = connectionString
+	}
+
+	// the quotes must be verified before the hosts are created, they are verified concurrently by a bounded number
+	// of workers rather than one host after another
+	if hc.HCConfig.VerifyQuoteForHostRegistration {
+		rows := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < bulkCreateQuoteWorkers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range rows {
+					if err := hc.verifyConnectionStringQuote(connectionStrings[i]); err != nil {
+						response.Results[i].Error = err.Error()
+					}
+				}
+			}()
+		}
+		for i := range registrations {
+			if registrations[i] != nil {
+				rows <- i
+			}
+		}
+		close(rows)
+		wg.Wait()
+	}
+
+	for i, registration := range registrations {
+		if registration == nil || response.Results[i].Error != "" {
+			continue
+		}
+		batch = append(batch, registration)
+		batchRows = append(batchRows, i)
+		if len(batch) == bulkCreateHostsBatchSize {
+			createBatch()
+		}
+	}
+	createBatch()
 
-		verificationNonceInBytes, tpmQuoteInBytes, aikCertificate, _, _, err := hostConnector.GetTPMQuoteResponse(nonce, nil)
+	for _, result := range response.Results {
+		if result.Error == "" {
+			response.Created++
+		} else {
+			response.Failed++
+		}
+	}
+	return response
+}
+
+// existingHostNames returns the names of the hosts of a bulk create request that are already registered, the hosts
+// are searched in a single query
+func (hc *HostController) existingHostNames(reqHosts []hvs.HostCreateRequest) (map[string]bool, error) {
+	defaultLog.Trace("controllers/host_controller:existingHostNames() Entering")
+	defer defaultLog.Trace("controllers/host_controller:existingHostNames() Leaving")
+
+	var names []string
+	for _, reqHost := range reqHosts {
+		if reqHost.HostName != "" {
+			names = append(names, reqHost.HostName)
+		}
+	}
+	existingHostNames := make(map[string]bool)
+	if len(names) == 0 {
+		return existingHostNames, nil
+	}
+	existingHosts, err := hc.HStore.Search(&models.HostFilterCriteria{NameList: names}, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, existingHost := range existingHosts {
+		existingHostNames[existingHost.HostName] = true
+	}
+	return existingHostNames, nil
+}
+
+// newHostRegistration validates a host of a bulk create request against the names of the registered hosts, it
+// returns the registration along with the connection string with the credentials of the host. The error message can
+// be returned to the client
+func (hc *HostController) newHostRegistration(reqHost hvs.HostCreateRequest, existingHostNames map[string]bool) (*models.HostRegistration, string, error) {
+	defaultLog.Trace("controllers/host_controller:newHostRegistration() Entering")
+	defer defaultLog.Trace("controllers/host_controller:newHostRegistration() Leaving")
+
+	if reqHost.HostName == "" || reqHost.ConnectionString == "" {
+		return nil, "", errors.New("Host connection string and host name must be specified")
+	}
+
+	if err := validateHostCreateCriteria(reqHost); err != nil {
+		secLog.WithError(err).Errorf("controllers/host_controller:newHostRegistration() %s Invalid host data", commLogMsg.InvalidInputBadParam)
+		return nil, "", errors.New("Invalid host data")
+	}
+
+	if existingHostNames[reqHost.HostName] {
+		secLog.WithField("Name", reqHost.HostName).Warningf("%s: Trying to create duplicate Host", commLogMsg.InvalidInputBadParam)
+		return nil, "", errors.New("Host with this name already exist")
+	}
+
+	connectionString, credential, err := GenerateConnectionString(reqHost.ConnectionString,
+		hc.HCConfig.Username,
+		hc.HCConfig.Password,
+		hc.HCStore)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:newHostRegistration() Could not generate formatted connection string")
+		return nil, "", err
+	}
+
+	// without flavorgroups the default flavorgroups are linked when the host details are retrieved
+	var flavorgroupIds []uuid.UUID
+	if len(reqHost.FlavorgroupNames) > 0 {
+		flavorGroups, err := GetFlavorGroups(hc.FGStore, reqHost.FlavorgroupNames, nil)
 		if err != nil {
-			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Error getting TPM Quote Response"}
+			defaultLog.WithError(err).Error("controllers/host_controller:newHostRegistration() Host FlavorGroup not found")
+			return nil, "", err
 		}
+		for _, flavorGroup := range flavorGroups {
+			flavorgroupIds = append(flavorgroupIds, flavorGroup.ID)
+		}
+	}
+
+	return &models.HostRegistration{
+		Host: &hvs.Host{
+			HostName:         reqHost.HostName,
+			Description:      reqHost.Description,
+			ConnectionString: utils.GetConnectionStringWithoutCredentials(connectionString),
+			FlavorgroupNames: reqHost.FlavorgroupNames,
+		},
+		Credential: &models.HostCredential{
+			HostName:   reqHost.HostName,
+			Credential: credential,
+		},
+		FlavorgroupIds: flavorgroupIds,
+	}, connectionString, nil
+}
 
-		_, _, err = hcUtil.VerifyQuoteAndGetPCRDetails(verificationNonceInBytes, tpmQuoteInBytes, aikCertificate)
+// parseHostCreateRequestsCsv reads the hosts from a CSV file with a header row naming the columns, the host_name and
+// connection_string columns are required, the flavorgroup names are separated by semicolons
+func parseHostCreateRequestsCsv(body io.Reader) ([]hvs.HostCreateRequest, error) {
+	defaultLog.Trace("controllers/host_controller:parseHostCreateRequestsCsv() Entering")
+	defer defaultLog.Trace("controllers/host_controller:parseHostCreateRequestsCsv() Leaving")
+
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read header row")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if !hostCsvColumns[column] {
+			return nil, errors.Errorf("unknown column %q", column)
+		}
+		columns[column] = i
+	}
+	if _, ok := columns[hostCsvColumnHostName]; !ok {
+		return nil, errors.Errorf("column %q is required", hostCsvColumnHostName)
+	}
+	if _, ok := columns[hostCsvColumnConnectionString]; !ok {
+		return nil, errors.Errorf("column %q is required", hostCsvColumnConnectionString)
+	}
+
+	value := func(record []string, column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var reqHosts []hvs.HostCreateRequest
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "TPM Quote verification failed"}
+			return nil, err
 		}
+		reqHost := hvs.HostCreateRequest{
+			HostName:         value(record, hostCsvColumnHostName),
+			Description:      value(record, hostCsvColumnDescription),
+			ConnectionString: value(record, hostCsvColumnConnectionString),
+		}
+		if flavorgroupNames := value(record, hostCsvColumnFlavorgroupNames); flavorgroupNames != "" {
+			for _, flavorgroupName := range strings.Split(flavorgroupNames, ";") {
+				reqHost.FlavorgroupNames = append(reqHost.FlavorgroupNames, strings.TrimSpace(flavorgroupName))
+			}
+		}
+		reqHosts = append(reqHosts, reqHost)
 	}
-	createdHost, status, err := hc.CreateHost(reqHost)
+	return reqHosts, nil
+}
+
+// verifyHostQuote connects to the host and verifies the TPM quote of the host, the error message can be returned to
+// the client
+func (hc *HostController) verifyHostQuote(cs string) error {
+	defaultLog.Trace("controllers/host_controller:verifyHostQuote() Entering")
+	defer defaultLog.Trace("controllers/host_controller:verifyHostQuote() Leaving")
+
+	connectionString, _, err := GenerateConnectionString(cs, hc.HCConfig.Username,
+		hc.HCConfig.Password,
+		hc.HCStore)
 	if err != nil {
-		return nil, status, err
+		defaultLog.WithError(err).Error("controllers/host_controller:verifyHostQuote() Could not generate formatted connection string")
+		return errors.New("Error generating connection string")
 	}
+	return hc.verifyConnectionStringQuote(connectionString)
+}
 
-	secLog.WithField("host", createdHost).Infof("%s: Host created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
-	return createdHost, status, nil
+// verifyConnectionStringQuote connects to the host of a connection string with credentials and verifies the TPM
+// quote of the host, the error message can be returned to the client
+func (hc *HostController) verifyConnectionStringQuote(connectionString string) error {
+	defaultLog.Trace("controllers/host_controller:verifyConnectionStringQuote() Entering")
+	defer defaultLog.Trace("controllers/host_controller:verifyConnectionStringQuote() Leaving")
+
+	hostConnector, err := hc.HCConfig.HostConnectorProvider.NewHostConnector(connectionString)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_controller:verifyConnectionStringQuote() Failed " +
+			"to initialize HostConnector to the host")
+		return errors.New("Target Host connection failed")
+	}
+
+	nonce, err := hcUtil.GenerateNonce(20)
+	if err != nil {
+		return errors.New("Error generating nonce for TPM quote request")
+	}
+
+	verificationNonceInBytes, tpmQuoteInBytes, aikCertificate, _, _, err := hostConnector.GetTPMQuoteResponse(nonce, nil)
+	if err != nil {
+		return errors.New("Error getting TPM Quote Response")
+	}
+
+	_, _, err = hcUtil.VerifyQuoteAndGetPCRDetails(verificationNonceInBytes, tpmQuoteInBytes, aikCertificate)
+	if err != nil {
+		return errors.New("TPM Quote verification failed")
+	}
+	return nil
 }
 
 func (hc *HostController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -266,14 +626,23 @@ func (hc *HostController) Search(w http.ResponseWriter, r *http.Request) (interf
 		return nil, http.StatusInternalServerError, errors.Errorf("Failed to search Hosts")
 	}
 
+	total := 0
+	if hostFilterCriteria.IncludeTotal {
+		total, err = hc.HStore.Count(hostFilterCriteria)
+		if err != nil {
+			defaultLog.WithError(err).Error("controllers/host_controller:Search() Host count failed")
+			return nil, http.StatusInternalServerError, errors.Errorf("Failed to search Hosts")
+		}
+	}
+
 	var next, prev string
-	if hostFilterCriteria.OrderBy == "" && len(hosts) > 0 {
+	if len(hosts) > 0 {
 		lastRowId := hosts[len(hosts)-1].RowId
-		next, prev = GetNextAndPrevValues(hostFilterCriteria.Limit, hostFilterCriteria.AfterId, lastRowId, len(hosts))
+		next, prev = GetPageLinks(hostFilterCriteria.PageCriteria, lastRowId, len(hosts))
 	}
 
 	hostCollection := hvs.HostCollection{
-		Hosts: hosts, Next: next, Previous: prev,
+		Hosts: hosts, Total: total, Next: next, Previous: prev,
 	}
 
 	secLog.Infof("%s: Hosts searched by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
@@ -765,24 +1134,15 @@ func populateHostFilterCriteria(params url.Values) (*models.HostFilterCriteria,
 		criteria.Trusted = &trustStatus
 	}
 
-	if params.Get("orderBy") != "" && (params.Get("limit") != "" || params.Get("afterId") != "") {
-		return nil, errors.New("Both Orderby and Limit/Afterid cannot be set together")
-	}
-
-	if params.Get("orderBy") != "" {
-		orderType, err := models.GetOrderType(params.Get("orderBy"))
-		if err != nil {
-			return nil, errors.New("Invalid orderBy query param value, must be asc/desc")
-		}
-		criteria.OrderBy = orderType
-	}
-
-	limit, afterId, err := validation.ValidatePaginationValues(params.Get("limit"), params.Get("afterId"))
+	page, err := PopulatePageCriteria(params, models.HostSortFields)
 	if err != nil {
 		return nil, err
 	}
-	criteria.Limit = limit
-	criteria.AfterId = afterId
+	// hosts ordered with orderBy are sorted by name
+	if page.OrderBy != "" && page.SortBy == "" {
+		page.SortBy = "name"
+	}
+	criteria.PageCriteria = *page
 
 	return &criteria, nil
 }
@@ -985,16 +1345,3 @@ func (hc *HostController) retrieveFlavorgroup(hId, fgId uuid.UUID) (interface{},
 	}
 	return hostFlavorgroup, http.StatusOK, nil
 }
-
-func GetNextAndPrevValues(limit int, afterId int, rowId int, lenOfResponse int) (string, string) {
-	var next, prev string
-	if lenOfResponse == limit {
-		next = "limit=" + strconv.Itoa(limit) + "&afterId=" + strconv.Itoa(rowId)
-	}
-	if (afterId - limit) > 0 {
-		prev = "limit=" + strconv.Itoa(limit) + "&afterId=" + strconv.Itoa(afterId-limit)
-	} else if afterId > 0 {
-		prev = "limit=" + strconv.Itoa(limit) + "&afterId=" + strconv.Itoa(0)
-	}
-	return next, prev
-}
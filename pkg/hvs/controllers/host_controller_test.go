@@ -22,12 +22,21 @@ import (
 	hvsRoutes "github.com/intel-secl/intel-secl/v5/pkg/hvs/router"
 	smocks "github.com/intel-secl/intel-secl/v5/pkg/hvs/services/hosttrust/mocks"
 	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	hostConnector "github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector"
 	mocks2 "github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/mocks"
 	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 )
 
+// unreachableHostConnectorProvider provides the connectors of hosts that cannot be connected
+type unreachableHostConnectorProvider struct{}
+
+func (unreachableHostConnectorProvider) NewHostConnector(string) (hostConnector.HostConnector, error) {
+	return nil, errors.New("connection refused")
+}
+
 var _ = Describe("HostController", func() {
 	var router *mux.Router
 	var w *httptest.ResponseRecorder
@@ -65,6 +74,7 @@ var _ = Describe("HostController", func() {
 			FStore:    flavorStore,
 			FGStore:   flavorGroupStore,
 			HCStore:   hostCredentialStore,
+			HRStore:   mocks.NewMockHostRegistrationStore(hostStore, hostCredentialStore),
 			HTManager: hostTrustManager,
 			HCConfig:  hostControllerConfig,
 		}
@@ -94,6 +104,33 @@ var _ = Describe("HostController", func() {
 				Expect(w.Code).To(Equal(http.StatusCreated))
 			})
 		})
+		Context("Provide a valid Create request for a Microsoft host", func() {
+			It("Should create a new Host with the hardware UUID of the Windows host", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods(http.MethodPost)
+				hostJson := `{
+								"host_name": "windows-host",
+								"connection_string": "microsoft:https://windows.ta.ip.com:1443",
+								"description": "Microsoft Host"
+							}`
+
+				req, err := http.NewRequest(
+					http.MethodPost,
+					"/hosts",
+					strings.NewReader(hostJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var host hvs.Host
+				Expect(json.Unmarshal(w.Body.Bytes(), &host)).To(Succeed())
+				Expect(host.HardwareUuid).NotTo(BeNil())
+				Expect(host.HardwareUuid.String()).To(Equal("4c4c4544-0043-4210-8053-b4c04f395032"))
+			})
+		})
 		Context("Provide a Create request that contains duplicate hostname", func() {
 			It("Should fail to create new Host", func() {
 				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Create))).Methods(http.MethodPost)
@@ -283,6 +320,230 @@ var _ = Describe("HostController", func() {
 
 	})
 
+	// Specs for HTTP Post to "/hosts/bulk"
+	Describe("Create Hosts in bulk", func() {
+		Context("Provide a valid bulk Create request with an invalid host", func() {
+			It("Should create the valid Hosts and report the invalid one", func() {
+				router.Handle("/hosts/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.BulkCreate))).Methods(http.MethodPost)
+				hostsJson := `{
+								"hosts": [
+									{
+										"host_name": "localhost3",
+										"connection_string": "intel:https://another.ta.ip.com:1443",
+										"flavorgroup_names": ["automatic"]
+									},
+									{
+										"host_name": "localhost1",
+										"connection_string": "intel:https://ta.ip.com:1443"
+									},
+									{
+										"host_name": "localhost4",
+										"connection_string": "intel:https://t a.ip.com:1443"
+									},
+									{
+										"host_name": "localhost5",
+										"connection_string": "intel:https://yet.another.ta.ip.com:1443",
+										"flavorgroup_names": ["unknown"]
+									},
+									{
+										"host_name": "localhost3",
+										"connection_string": "intel:https://another.ta.ip.com:1443"
+									}
+								]
+							}`
+
+				req, err := http.NewRequest(
+					http.MethodPost,
+					"/hosts/bulk",
+					strings.NewReader(hostsJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var response hvs.HostBulkCreateResponse
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Created).To(Equal(1))
+				Expect(response.Failed).To(Equal(4))
+				Expect(response.Results[0].Host).NotTo(BeNil())
+				Expect(response.Results[1].Error).To(Equal("Host with this name already exist"))
+				Expect(response.Results[2].Error).To(Equal("Invalid host data"))
+				Expect(response.Results[3].Error).To(ContainSubstring("Flavor group record not found"))
+				Expect(response.Results[4].Error).To(Equal("Host with this name is already specified in the request"))
+
+				hosts, err := hostStore.Search(&models.HostFilterCriteria{NameEqualTo: "localhost3"}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(hosts)).To(Equal(1))
+				credential, err := hostCredentialStore.FindByHostName("localhost3")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(credential.HostId).To(Equal(hosts[0].Id))
+			})
+		})
+
+		Context("Provide a valid bulk Create request with a host failing its batch", func() {
+			It("Should create the other Hosts of the batch and report the failing one", func() {
+				hostRegistrationStore := mocks.NewMockHostRegistrationStore(hostStore, hostCredentialStore)
+				hostRegistrationStore.FailingHostNames = map[string]bool{"localhost4": true}
+				hostController.HRStore = hostRegistrationStore
+				router.Handle("/hosts/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.BulkCreate))).Methods(http.MethodPost)
+				hostsCsv := "host_name,connection_string\n" +
+					"localhost3,intel:https://another.ta.ip.com:1443\n" +
+					"localhost4,intel:https://yet.another.ta.ip.com:1443\n" +
+					"localhost5,intel:https://one.more.ta.ip.com:1443\n"
+
+				req, err := http.NewRequest(
+					http.MethodPost,
+					"/hosts/bulk",
+					strings.NewReader(hostsCsv),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeCsv)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var response hvs.HostBulkCreateResponse
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Created).To(Equal(2))
+				Expect(response.Failed).To(Equal(1))
+				Expect(response.Results[0].Host).NotTo(BeNil())
+				Expect(response.Results[1].Host).To(BeNil())
+				Expect(response.Results[1].Error).To(Equal("Failed to create Host"))
+				Expect(response.Results[2].Host).NotTo(BeNil())
+
+				hosts, err := hostStore.Search(&models.HostFilterCriteria{NameEqualTo: "localhost4"}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hosts).To(BeEmpty())
+			})
+		})
+
+		Context("Provide a valid bulk Create request with hosts failing the quote verification", func() {
+			It("Should report the Hosts and not create them", func() {
+				hostController.HCConfig.VerifyQuoteForHostRegistration = true
+				hostController.HCConfig.HostConnectorProvider = unreachableHostConnectorProvider{}
+				router.Handle("/hosts/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.BulkCreate))).Methods(http.MethodPost)
+				hostsCsv := "host_name,connection_string\n" +
+					"localhost1,intel:https://ta.ip.com:1443\n" +
+					"localhost3,intel:https://another.ta.ip.com:1443\n" +
+					"localhost4,intel:https://yet.another.ta.ip.com:1443\n"
+
+				req, err := http.NewRequest(
+					http.MethodPost,
+					"/hosts/bulk",
+					strings.NewReader(hostsCsv),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeCsv)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var response hvs.HostBulkCreateResponse
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Created).To(Equal(0))
+				Expect(response.Failed).To(Equal(3))
+				Expect(response.Results[0].Error).To(Equal("Host with this name already exist"))
+				Expect(response.Results[1].Error).To(Equal("Target Host connection failed"))
+				Expect(response.Results[2].Error).To(Equal("Target Host connection failed"))
+
+				hosts, err := hostStore.Search(&models.HostFilterCriteria{NameList: []string{"localhost3", "localhost4"}}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hosts).To(BeEmpty())
+			})
+		})
+
+		Context("Provide a valid CSV bulk Create request", func() {
+			It("Should create the Hosts", func() {
+				router.Handle("/hosts/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.BulkCreate))).Methods(http.MethodPost)
+				hostsCsv := "host_name,connection_string,description,flavorgroup_names\n" +
+					"localhost3,intel:https://another.ta.ip.com:1443,Another Intel Host,automatic;platform_software\n" +
+					"localhost4,intel:https://yet.another.ta.ip.com:1443,,\n"
+
+				req, err := http.NewRequest(
+					http.MethodPost,
+					"/hosts/bulk",
+					strings.NewReader(hostsCsv),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeCsv)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var response hvs.HostBulkCreateResponse
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Created).To(Equal(2))
+				Expect(response.Results[0].Host.FlavorgroupNames).To(Equal([]string{"automatic", "platform_software"}))
+				Expect(response.Results[1].Host.Description).To(BeEmpty())
+			})
+		})
+
+		Context("Provide a CSV bulk Create request without connection string column", func() {
+			It("Should return 400", func() {
+				router.Handle("/hosts/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.BulkCreate))).Methods(http.MethodPost)
+				hostsCsv := "host_name,description\nlocalhost3,Another Intel Host\n"
+
+				req, err := http.NewRequest(
+					http.MethodPost,
+					"/hosts/bulk",
+					strings.NewReader(hostsCsv),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeCsv)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide a bulk Create request without hosts", func() {
+			It("Should return 400", func() {
+				router.Handle("/hosts/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.BulkCreate))).Methods(http.MethodPost)
+
+				req, err := http.NewRequest(
+					http.MethodPost,
+					"/hosts/bulk",
+					strings.NewReader(`{"hosts": []}`),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Provide a invalid Content-Type in bulk Create request", func() {
+			It("Should return 415", func() {
+				router.Handle("/hosts/bulk", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.BulkCreate))).Methods(http.MethodPost)
+
+				req, err := http.NewRequest(
+					http.MethodPost,
+					"/hosts/bulk",
+					strings.NewReader(`{"hosts": []}`),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeXml)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+
 	// Specs for HTTP Get to "/hosts/{hId}"
 	Describe("Retrieve an existing Host", func() {
 		Context("Retrieve Host by ID", func() {
@@ -598,6 +859,90 @@ var _ = Describe("HostController", func() {
 				Expect(w.Code).To(Equal(http.StatusBadRequest))
 			})
 		})
+		Context("Get the Hosts sorted by name with limit", func() {
+			It("Should get a page of Hosts with the total and a cursor to the next page", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/hosts?limit=1&sortBy=name&orderBy=desc&includeTotal=true", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostCollection hvs.HostCollection
+				err = json.Unmarshal(w.Body.Bytes(), &hostCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(hostCollection.Hosts)).To(Equal(1))
+				Expect(hostCollection.Total).To(Equal(2))
+				Expect(hostCollection.Next).To(HavePrefix("limit=1&cursor="))
+				Expect(hostCollection.Previous).To(BeEmpty())
+
+				// the cursor carries the sort order to the next page
+				req, err = http.NewRequest(http.MethodGet, "/hosts?"+hostCollection.Next, nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+		Context("Get the Hosts ordered with orderBy and limit", func() {
+			It("Should get a page of Hosts sorted by name instead of returning bad request", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/hosts?orderBy=desc&limit=1", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hostCollection hvs.HostCollection
+				err = json.Unmarshal(w.Body.Bytes(), &hostCollection)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(hostCollection.Hosts)).To(Equal(1))
+				Expect(hostCollection.Total).To(BeZero())
+				Expect(hostCollection.Next).To(HavePrefix("limit=1&cursor="))
+
+				cursor := models.PageCriteria{}
+				Expect(cursor.ParseCursor(strings.TrimPrefix(hostCollection.Next, "limit=1&cursor="))).To(Succeed())
+				Expect(cursor.SortBy).To(Equal("name"))
+				Expect(cursor.OrderBy).To(Equal(models.OrderType(models.Descending)))
+			})
+		})
+		Context("Get all the Hosts when sortBy is invalid", func() {
+			It("Should return bad request", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/hosts?sortBy=connection_string", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Get all the Hosts when cursor is invalid", func() {
+			It("Should return bad request", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/hosts?cursor=invalid-cursor", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Get all the Hosts when both cursor and afterId are given", func() {
+			It("Should return bad request", func() {
+				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods(http.MethodGet)
+				cursor := models.PageCriteria{SortBy: "name"}.Cursor(1)
+				req, err := http.NewRequest(http.MethodGet, "/hosts?afterId=1&cursor="+cursor, nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 		Context("Get all the Hosts with key value params", func() {
 			It("Should get list of all the filtered Hosts", func() {
 				router.Handle("/hosts", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostController.Search))).Methods(http.MethodGet)
@@ -1142,6 +1487,7 @@ func TestNewHostController(t *testing.T) {
 		fs  domain.FlavorStore
 		fgs domain.FlavorGroupStore
 		hcs domain.HostCredentialStore
+		hrs domain.HostRegistrationStore
 		htm domain.HostTrustManager
 		hcc domain.HostControllerConfig
 	}
@@ -1158,6 +1504,7 @@ func TestNewHostController(t *testing.T) {
 				fs:  nil,
 				fgs: nil,
 				hcs: nil,
+				hrs: nil,
 				htm: nil,
 				hcc: domain.HostControllerConfig{},
 			},
@@ -1166,7 +1513,7 @@ func TestNewHostController(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := controllers.NewHostController(tt.args.hs, tt.args.hss, tt.args.fs, tt.args.fgs, tt.args.hcs, tt.args.htm, tt.args.hcc); !reflect.DeepEqual(got, tt.want) {
+			if got := controllers.NewHostController(tt.args.hs, tt.args.hss, tt.args.fs, tt.args.fgs, tt.args.hcs, tt.args.hrs, tt.args.htm, tt.args.hcc); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewHostController() = %v, want %v", got, tt.want)
 			}
 		})
@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/validation"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+)
+
+type HostQuarantineController struct {
+	HQStore            domain.HostQuarantineStore
+	RemediationManager domain.RemediationManager
+}
+
+func NewHostQuarantineController(hqs domain.HostQuarantineStore, rm domain.RemediationManager) *HostQuarantineController {
+	return &HostQuarantineController{
+		HQStore:            hqs,
+		RemediationManager: rm,
+	}
+}
+
+var hostQuarantineSearchParams = map[string]bool{"quarantined": true}
+
+// Search returns the remediation state of the hosts with untrusted reports, optionally filtered by quarantine
+func (controller HostQuarantineController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_quarantine_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/host_quarantine_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), hostQuarantineSearchParams); err != nil {
+		secLog.Errorf("controllers/host_quarantine_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	criteria := &models.HostQuarantineFilterCriteria{}
+	if quarantined := strings.TrimSpace(r.URL.Query().Get("quarantined")); quarantined != "" {
+		quarantinedBool, err := strconv.ParseBool(quarantined)
+		if err != nil {
+			secLog.Errorf("controllers/host_quarantine_controller:Search() %s : Invalid quarantined query parameter", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid search criteria provided"}
+		}
+		criteria.Quarantined = &quarantinedBool
+	}
+
+	quarantines, err := controller.HQStore.Search(criteria)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/host_quarantine_controller:Search() Host quarantine search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search host quarantines"}
+	}
+
+	secLog.Infof("%s: Return host quarantine query result to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.HostQuarantineCollection{HostQuarantines: quarantines}, http.StatusOK, nil
+}
+
+func (controller HostQuarantineController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_quarantine_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/host_quarantine_controller:Retrieve() Leaving")
+
+	hostId := uuid.MustParse(mux.Vars(r)["hostId"])
+	hq, status, err := controller.retrieveQuarantine(hostId)
+	if err != nil {
+		return nil, status, err
+	}
+
+	secLog.Infof("%s: Host quarantine retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hq, http.StatusOK, nil
+}
+
+// Release releases a quarantined host, the request body with the release comment is optional
+func (controller HostQuarantineController) Release(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/host_quarantine_controller:Release() Entering")
+	defer defaultLog.Trace("controllers/host_quarantine_controller:Release() Leaving")
+
+	hostId := uuid.MustParse(mux.Vars(r)["hostId"])
+
+	var reqRelease hvs.HostQuarantineRelease
+	if r.ContentLength != 0 {
+		if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+			return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+		}
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&reqRelease); err != nil {
+			secLog.WithError(err).Errorf("controllers/host_quarantine_controller:Release() %s : Failed to decode"+
+				" request body as HostQuarantineRelease", commLogMsg.InvalidInputBadEncoding)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+		}
+		if reqRelease.Comment != "" {
+			if err := validation.ValidateTextString(reqRelease.Comment); err != nil {
+				secLog.WithError(err).Errorf("controllers/host_quarantine_controller:Release() %s : Invalid release comment", commLogMsg.InvalidInputBadParam)
+				return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid release comment provided"}
+			}
+		}
+	}
+
+	hq, status, err := controller.retrieveQuarantine(hostId)
+	if err != nil {
+		return nil, status, err
+	}
+	if !hq.Quarantined {
+		secLog.WithField("hostId", hostId).Errorf("controllers/host_quarantine_controller:Release() %s : Host is not quarantined", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Host with given ID is not quarantined"}
+	}
+
+	releasedHq, err := controller.RemediationManager.Release(hostId, reqRelease.Comment)
+	if err != nil {
+		defaultLog.WithError(err).WithField("hostId", hostId).Error("controllers/host_quarantine_controller:Release() Failed to release host")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to release host"}
+	}
+
+	secLog.WithField("hostId", hostId).Infof("%s: Host released from quarantine by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return releasedHq, http.StatusOK, nil
+}
+
+func (controller HostQuarantineController) retrieveQuarantine(hostId uuid.UUID) (*hvs.HostQuarantine, int, error) {
+	hq, err := controller.HQStore.Retrieve(hostId)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("hostId", hostId).Info(
+				"controllers/host_quarantine_controller:retrieveQuarantine() Host with given ID has no untrusted reports")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Host with given ID has no untrusted reports or does not exist"}
+		}
+		defaultLog.WithError(err).WithField("hostId", hostId).Error(
+			"controllers/host_quarantine_controller:retrieveQuarantine() Failed to retrieve host quarantine")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve host quarantine"}
+	}
+	return hq, http.StatusOK, nil
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	hvsRoutes "github.com/intel-secl/intel-secl/v5/pkg/hvs/router"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/remediation"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HostQuarantineController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var hostQuarantineStore *mocks.MockHostQuarantineStore
+	var hostQuarantineController *controllers.HostQuarantineController
+
+	// the hosts of the mocked host store, the first one is quarantined
+	const quarantinedHostId = "ee37c360-7eae-4250-a677-6ee12adce8e2"
+	const untrustedHostId = "e57e5ea0-d465-461e-882d-1600090caa0d"
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		hostQuarantineStore = mocks.NewMockHostQuarantineStore()
+		remediationService, err := remediation.NewRemediationService(remediation.RemediationConfig{UntrustedReportsThreshold: 2},
+			hostQuarantineStore, mocks.NewMockHostStore(), nil, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 2; i++ {
+			remediationService.ProcessReport(&models.HVSReport{ID: uuid.New(), HostID: uuid.MustParse(quarantinedHostId)})
+		}
+		remediationService.ProcessReport(&models.HVSReport{ID: uuid.New(), HostID: uuid.MustParse(untrustedHostId)})
+		hostQuarantineController = controllers.NewHostQuarantineController(hostQuarantineStore, remediationService)
+	})
+
+	send := func(method, path, body string) {
+		req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+	}
+
+	// Specs for HTTP Get to "/host-quarantines"
+	Describe("Search host quarantines", func() {
+		BeforeEach(func() {
+			router.Handle("/host-quarantines", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostQuarantineController.Search))).Methods(http.MethodGet)
+		})
+
+		Context("When no filter is provided", func() {
+			It("Should return the hosts with untrusted reports", func() {
+				send(http.MethodGet, "/host-quarantines", "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var quarantines hvs.HostQuarantineCollection
+				Expect(json.Unmarshal(w.Body.Bytes(), &quarantines)).To(Succeed())
+				Expect(quarantines.HostQuarantines).To(HaveLen(2))
+			})
+		})
+		Context("When filtered by quarantined hosts", func() {
+			It("Should return the quarantined host", func() {
+				send(http.MethodGet, "/host-quarantines?quarantined=true", "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var quarantines hvs.HostQuarantineCollection
+				Expect(json.Unmarshal(w.Body.Bytes(), &quarantines)).To(Succeed())
+				Expect(quarantines.HostQuarantines).To(HaveLen(1))
+				Expect(quarantines.HostQuarantines[0].HostId.String()).To(Equal(quarantinedHostId))
+			})
+		})
+		Context("When an invalid quarantined filter is provided", func() {
+			It("Should return bad request", func() {
+				send(http.MethodGet, "/host-quarantines?quarantined=maybe", "")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/host-quarantines/{hostId}"
+	Describe("Retrieve host quarantine", func() {
+		BeforeEach(func() {
+			router.Handle("/host-quarantines/{hostId}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostQuarantineController.Retrieve))).Methods(http.MethodGet)
+		})
+
+		Context("When the host is quarantined", func() {
+			It("Should return the quarantine of the host", func() {
+				send(http.MethodGet, "/host-quarantines/"+quarantinedHostId, "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var hq hvs.HostQuarantine
+				Expect(json.Unmarshal(w.Body.Bytes(), &hq)).To(Succeed())
+				Expect(hq.Quarantined).To(BeTrue())
+				Expect(hq.UntrustedReports).To(Equal(2))
+				Expect(hq.ReportId).NotTo(BeNil())
+			})
+		})
+		Context("When the host has no untrusted reports", func() {
+			It("Should return not found", func() {
+				send(http.MethodGet, "/host-quarantines/73755fda-c910-46be-821f-e8ddeab189e9", "")
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Post to "/host-quarantines/{hostId}/release"
+	Describe("Release host", func() {
+		BeforeEach(func() {
+			router.Handle("/host-quarantines/{hostId}/release", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(hostQuarantineController.Release))).Methods(http.MethodPost)
+		})
+
+		Context("When the host is quarantined", func() {
+			It("Should release the host", func() {
+				send(http.MethodPost, "/host-quarantines/"+quarantinedHostId+"/release", `{"comment":"BIOS reflashed"}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				hq, err := hostQuarantineStore.Retrieve(uuid.MustParse(quarantinedHostId))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hq.Quarantined).To(BeFalse())
+				Expect(hq.UntrustedReports).To(Equal(0))
+				Expect(hq.ReleaseComment).To(Equal("BIOS reflashed"))
+			})
+		})
+		Context("When the request body is not provided", func() {
+			It("Should release the host", func() {
+				send(http.MethodPost, "/host-quarantines/"+quarantinedHostId+"/release", "")
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+		Context("When the host is not quarantined", func() {
+			It("Should return bad request", func() {
+				send(http.MethodPost, "/host-quarantines/"+untrustedHostId+"/release", "")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When the host has no untrusted reports", func() {
+			It("Should return not found", func() {
+				send(http.MethodPost, "/host-quarantines/73755fda-c910-46be-821f-e8ddeab189e9/release", "")
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
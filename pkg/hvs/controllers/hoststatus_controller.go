@@ -30,7 +30,8 @@ type HostStatusController struct {
 }
 
 var hostStatusSearchParams = map[string]bool{"id": true, "hostId": true, "hostHardwareId": true, "hostName": true, "hostStatus": true,
-	"fromDate": true, "toDate": true, "latestPerHost": true, "numberOfDays": true, "limit": true, "afterId": true}
+	"fromDate": true, "toDate": true, "latestPerHost": true, "numberOfDays": true, "limit": true, "afterId": true,
+	"cursor": true, "includeTotal": true, "sortBy": true, "orderBy": true}
 
 // Search returns a collection of HostStatus based on HostStatusFilter criteria
 func (controller HostStatusController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -55,14 +56,23 @@ func (controller HostStatusController) Search(w http.ResponseWriter, r *http.Req
 		return nil, http.StatusInternalServerError, errors.Errorf("Host Status search operation failed")
 	}
 
+	total := 0
+	if filter.IncludeTotal {
+		total, err = controller.Store.Count(filter)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("controllers/hoststatus_controller:Search() Host Status count operation failed")
+			return nil, http.StatusInternalServerError, errors.Errorf("Host Status search operation failed")
+		}
+	}
+
 	var next, prev string
 	if len(hostStatusCollection) > 0 {
 		lastRowId := hostStatusCollection[len(hostStatusCollection)-1].RowId
-		next, prev = GetNextAndPrevValues(filter.Limit, filter.AfterId, lastRowId, len(hostStatusCollection))
+		next, prev = GetPageLinks(filter.PageCriteria, lastRowId, len(hostStatusCollection))
 	}
 
 	hostCollection := hvs.HostStatusCollection{
-		HostStatuses: hostStatusCollection, Next: next, Previous: prev,
+		HostStatuses: hostStatusCollection, Total: total, Next: next, Previous: prev,
 	}
 
 	secLog.Infof("%s: Return Host Status Search query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
@@ -199,12 +209,11 @@ func getHSFilterCriteria(params url.Values) (*models.HostStatusFilterCriteria, e
 		hfc.NumberOfDays = numDays
 	}
 
-	limit, afterId, err := validation.ValidatePaginationValues(params.Get("limit"), params.Get("afterId"))
+	page, err := PopulatePageCriteria(params, models.HostStatusSortFields)
 	if err != nil {
 		return nil, err
 	}
-	hfc.Limit = limit
-	hfc.AfterId = afterId
+	hfc.PageCriteria = *page
 
 	return &hfc, nil
 }
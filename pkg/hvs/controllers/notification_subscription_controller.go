@@ -0,0 +1,237 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const (
+	notificationSecretMinLength = 16
+	notificationSecretMaxLength = 256
+	notificationSecretSize      = 32
+)
+
+type NotificationSubscriptionController struct {
+	NSStore domain.NotificationSubscriptionStore
+}
+
+func NewNotificationSubscriptionController(ns domain.NotificationSubscriptionStore) *NotificationSubscriptionController {
+	return &NotificationSubscriptionController{NSStore: ns}
+}
+
+var notificationSubscriptionSearchParams = map[string]bool{"eventType": true}
+
+// Create registers a webhook subscriber. If the secret used to sign the event payloads is not provided one is
+// generated, the secret is returned only in the response of Create
+func (controller NotificationSubscriptionController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_subscription_controller:Create() Entering")
+	defer defaultLog.Trace("controllers/notification_subscription_controller:Create() Leaving")
+
+	if r.Header.Get("Content-Type") != consts.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if r.ContentLength == 0 {
+		secLog.Error("controllers/notification_subscription_controller:Create() The request body is not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body is not provided"}
+	}
+
+	var reqSubscription hvs.NotificationSubscription
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reqSubscription); err != nil {
+		secLog.WithError(err).Errorf("controllers/notification_subscription_controller:Create() %s : Failed to decode"+
+			" request body as NotificationSubscription", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateNotificationSubscription(&reqSubscription); err != nil {
+		secLog.WithError(err).Errorf("controllers/notification_subscription_controller:Create() %s : Invalid"+
+			" notification subscription", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	if reqSubscription.Secret == "" {
+		secret := make([]byte, notificationSecretSize)
+		if _, err := rand.Read(secret); err != nil {
+			defaultLog.WithError(err).Error("controllers/notification_subscription_controller:Create() Failed to generate subscription secret")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create notification subscription"}
+		}
+		reqSubscription.Secret = hex.EncodeToString(secret)
+	}
+
+	subscription, err := controller.NSStore.Create(&hvs.NotificationSubscription{
+		Url:        reqSubscription.Url,
+		EventTypes: reqSubscription.EventTypes,
+		Secret:     reqSubscription.Secret,
+	})
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/notification_subscription_controller:Create() Notification subscription create failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to create notification subscription"}
+	}
+
+	secLog.WithField("url", subscription.Url).Infof("%s: Notification subscription created by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return subscription, http.StatusCreated, nil
+}
+
+func (controller NotificationSubscriptionController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_subscription_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/notification_subscription_controller:Retrieve() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	subscription, status, err := controller.retrieveSubscription(id)
+	if err != nil {
+		return nil, status, err
+	}
+	subscription.Secret = ""
+
+	secLog.Infof("%s: Notification subscription retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return subscription, http.StatusOK, nil
+}
+
+func (controller NotificationSubscriptionController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_subscription_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/notification_subscription_controller:Search() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), notificationSubscriptionSearchParams); err != nil {
+		secLog.Errorf("controllers/notification_subscription_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	criteria := &models.NotificationSubscriptionFilterCriteria{}
+	if eventType := strings.TrimSpace(r.URL.Query().Get("eventType")); eventType != "" {
+		criteria.EventType = hvs.NotificationEventType(eventType)
+		if !isValidNotificationEventType(criteria.EventType) {
+			secLog.Errorf("controllers/notification_subscription_controller:Search() %s : Invalid event type", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid search criteria provided"}
+		}
+	}
+
+	subscriptions, err := controller.NSStore.Search(criteria)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/notification_subscription_controller:Search() Notification subscription search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search notification subscriptions"}
+	}
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	secLog.Infof("%s: Return notification subscription query result to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.NotificationSubscriptionCollection{NotificationSubscriptions: subscriptions}, http.StatusOK, nil
+}
+
+func (controller NotificationSubscriptionController) Delete(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_subscription_controller:Delete() Entering")
+	defer defaultLog.Trace("controllers/notification_subscription_controller:Delete() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	subscription, status, err := controller.retrieveSubscription(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if err := controller.NSStore.Delete(id); err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/notification_subscription_controller:Delete() Failed to delete notification subscription")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to delete notification subscription"}
+	}
+
+	secLog.WithField("url", subscription.Url).Infof("%s: Notification subscription deleted by: %s", commLogMsg.PrivilegeModified, r.RemoteAddr)
+	return nil, http.StatusNoContent, nil
+}
+
+// SearchDeadLetters returns the events that could not be delivered to the subscriber after all the retries
+func (controller NotificationSubscriptionController) SearchDeadLetters(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/notification_subscription_controller:SearchDeadLetters() Entering")
+	defer defaultLog.Trace("controllers/notification_subscription_controller:SearchDeadLetters() Leaving")
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	if _, status, err := controller.retrieveSubscription(id); err != nil {
+		return nil, status, err
+	}
+
+	deadLetters, err := controller.NSStore.SearchDeadLetters(id)
+	if err != nil {
+		defaultLog.WithError(err).WithField("id", id).Error("controllers/notification_subscription_controller:SearchDeadLetters() Dead letter search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search notification dead letters"}
+	}
+
+	secLog.Infof("%s: Return notification dead letters to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return hvs.NotificationDeadLetterCollection{NotificationDeadLetters: deadLetters}, http.StatusOK, nil
+}
+
+func (controller NotificationSubscriptionController) retrieveSubscription(id uuid.UUID) (*hvs.NotificationSubscription, int, error) {
+	subscription, err := controller.NSStore.Retrieve(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/notification_subscription_controller:retrieveSubscription() Notification subscription with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Notification subscription with given ID does not exist"}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/notification_subscription_controller:retrieveSubscription() Failed to retrieve notification subscription")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve notification subscription"}
+	}
+	return subscription, http.StatusOK, nil
+}
+
+func validateNotificationSubscription(subscription *hvs.NotificationSubscription) error {
+	if subscription.ID != uuid.Nil || !subscription.CreatedAt.IsZero() {
+		return errors.New("id and created must not be provided")
+	}
+
+	subscriberUrl, err := url.ParseRequestURI(subscription.Url)
+	if err != nil || subscriberUrl.Host == "" {
+		return errors.New("Invalid subscriber url")
+	}
+	if subscriberUrl.Scheme != "https" {
+		return errors.New("Subscriber url must use https")
+	}
+
+	if len(subscription.EventTypes) == 0 {
+		return errors.New("At least one event type must be provided")
+	}
+	eventTypes := make(map[hvs.NotificationEventType]bool)
+	for _, eventType := range subscription.EventTypes {
+		if !isValidNotificationEventType(eventType) {
+			return errors.Errorf("Invalid event type %s", eventType)
+		}
+		if eventTypes[eventType] {
+			return errors.Errorf("Duplicate event type %s", eventType)
+		}
+		eventTypes[eventType] = true
+	}
+
+	if subscription.Secret != "" && (len(subscription.Secret) < notificationSecretMinLength || len(subscription.Secret) > notificationSecretMaxLength) {
+		return errors.Errorf("Secret must be between %d and %d characters", notificationSecretMinLength, notificationSecretMaxLength)
+	}
+	return nil
+}
+
+func isValidNotificationEventType(eventType hvs.NotificationEventType) bool {
+	for _, et := range hvs.GetNotificationEventTypes() {
+		if et == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	hvsRoutes "github.com/intel-secl/intel-secl/v5/pkg/hvs/router"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationSubscriptionController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var notificationSubscriptionStore *mocks.MockNotificationSubscriptionStore
+	var notificationSubscriptionController *controllers.NotificationSubscriptionController
+
+	const subscriptionId = "5a2b1c3d-7e8f-4a9b-8c1d-2e3f4a5b6c7d"
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		notificationSubscriptionStore = mocks.NewMockNotificationSubscriptionStore()
+		notificationSubscriptionController = controllers.NewNotificationSubscriptionController(notificationSubscriptionStore)
+	})
+
+	// Specs for HTTP Post to "/notification-subscriptions"
+	Describe("Create notification subscription", func() {
+		BeforeEach(func() {
+			router.Handle("/notification-subscriptions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationSubscriptionController.Create))).Methods(http.MethodPost)
+		})
+		create := func(body string) {
+			req, err := http.NewRequest(http.MethodPost, "/notification-subscriptions", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		Context("When a valid subscription without secret is provided", func() {
+			It("Should create the subscription and return a generated secret", func() {
+				create(`{"url":"https://ihub.example.com:5443/events","event_types":["host.trust_changed","host.state_changed"]}`)
+				Expect(w.Code).To(Equal(http.StatusCreated))
+
+				var subscription hvs.NotificationSubscription
+				Expect(json.Unmarshal(w.Body.Bytes(), &subscription)).To(Succeed())
+				Expect(subscription.EventTypes).To(HaveLen(2))
+				Expect(subscription.Secret).To(HaveLen(64))
+
+				subscriptions, _ := notificationSubscriptionStore.Search(nil)
+				Expect(subscriptions).To(HaveLen(2))
+			})
+		})
+		Context("When the subscriber url is not https", func() {
+			It("Should return bad request", func() {
+				create(`{"url":"http://ihub.example.com:5443/events","event_types":["host.trust_changed"]}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When an invalid event type is provided", func() {
+			It("Should return bad request", func() {
+				create(`{"url":"https://ihub.example.com:5443/events","event_types":["host.deleted"]}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When a too short secret is provided", func() {
+			It("Should return bad request", func() {
+				create(`{"url":"https://ihub.example.com:5443/events","event_types":["host.trust_changed"],"secret":"short"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/notification-subscriptions"
+	Describe("Search notification subscriptions", func() {
+		BeforeEach(func() {
+			router.Handle("/notification-subscriptions", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationSubscriptionController.Search))).Methods(http.MethodGet)
+		})
+		search := func(query string) *hvs.NotificationSubscriptionCollection {
+			req, err := http.NewRequest(http.MethodGet, "/notification-subscriptions"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				return nil
+			}
+			var collection hvs.NotificationSubscriptionCollection
+			Expect(json.Unmarshal(w.Body.Bytes(), &collection)).To(Succeed())
+			return &collection
+		}
+
+		Context("When filtered by a subscribed event type", func() {
+			It("Should return the subscriptions without secrets", func() {
+				collection := search("?eventType=host.trust_changed")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(collection.NotificationSubscriptions).To(HaveLen(1))
+				Expect(collection.NotificationSubscriptions[0].Secret).To(BeEmpty())
+			})
+		})
+		Context("When filtered by an event type without subscribers", func() {
+			It("Should return an empty collection", func() {
+				collection := search("?eventType=host.state_changed")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(collection.NotificationSubscriptions).To(BeEmpty())
+			})
+		})
+		Context("When an invalid event type is provided", func() {
+			It("Should return bad request", func() {
+				search("?eventType=host.deleted")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/notification-subscriptions/{id}" and "/notification-subscriptions/{id}/dead-letters"
+	Describe("Retrieve notification subscription and dead letters", func() {
+		BeforeEach(func() {
+			router.Handle("/notification-subscriptions/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationSubscriptionController.Retrieve))).Methods(http.MethodGet)
+			router.Handle("/notification-subscriptions/{id}/dead-letters", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(notificationSubscriptionController.SearchDeadLetters))).Methods(http.MethodGet)
+		})
+		get := func(path string) {
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		Context("When an existing subscription is retrieved", func() {
+			It("Should return the subscription without secret", func() {
+				get("/notification-subscriptions/" + subscriptionId)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				var subscription hvs.NotificationSubscription
+				Expect(json.Unmarshal(w.Body.Bytes(), &subscription)).To(Succeed())
+				Expect(subscription.Url).To(Equal("https://ihub.example.com:5443/events"))
+				Expect(subscription.Secret).To(BeEmpty())
+			})
+		})
+		Context("When a non-existent subscription is retrieved", func() {
+			It("Should return not found", func() {
+				get("/notification-subscriptions/73755fda-c910-46be-821f-e8ddeab189e9")
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("When the dead letters of a subscription are searched", func() {
+			It("Should return the undelivered events", func() {
+				get("/notification-subscriptions/" + subscriptionId + "/dead-letters")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				var collection hvs.NotificationDeadLetterCollection
+				Expect(json.Unmarshal(w.Body.Bytes(), &collection)).To(Succeed())
+				Expect(collection.NotificationDeadLetters).To(HaveLen(1))
+				Expect(collection.NotificationDeadLetters[0].Attempts).To(Equal(4))
+				Expect(collection.NotificationDeadLetters[0].Event.EventType).To(Equal(hvs.NotificationEventHostTrustChanged))
+			})
+		})
+	})
+
+	// Specs for HTTP Delete to "/notification-subscriptions/{id}"
+	Describe("Delete notification subscription", func() {
+		BeforeEach(func() {
+			router.Handle("/notification-subscriptions/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(notificationSubscriptionController.Delete))).Methods(http.MethodDelete)
+		})
+		del := func(id string) {
+			req, err := http.NewRequest(http.MethodDelete, "/notification-subscriptions/"+id, nil)
+			Expect(err).NotTo(HaveOccurred())
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		Context("When an existing subscription is deleted", func() {
+			It("Should delete the subscription", func() {
+				del(subscriptionId)
+				Expect(w.Code).To(Equal(http.StatusNoContent))
+				subscriptions, _ := notificationSubscriptionStore.Search(nil)
+				Expect(subscriptions).To(BeEmpty())
+			})
+		})
+		Context("When a non-existent subscription is deleted", func() {
+			It("Should return not found", func() {
+				del("73755fda-c910-46be-821f-e8ddeab189e9")
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,82 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/validation"
+	"github.com/pkg/errors"
+)
+
+// PopulatePageCriteria parses the limit, afterId, cursor, sortBy, orderBy and includeTotal query parameters of a Search
+// API, sortBy must be one of the given sort fields. The cursor of a next page link replaces afterId, sortBy and orderBy.
+func PopulatePageCriteria(params url.Values, sortFields []string) (*models.PageCriteria, error) {
+	defaultLog.Trace("controllers/pagination:PopulatePageCriteria() Entering")
+	defer defaultLog.Trace("controllers/pagination:PopulatePageCriteria() Leaving")
+
+	var page models.PageCriteria
+	var err error
+	page.Limit, page.AfterId, err = validation.ValidatePaginationValues(params.Get("limit"), params.Get("afterId"))
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Get("cursor") != "" {
+		if params.Get("afterId") != "" || params.Get("sortBy") != "" || params.Get("orderBy") != "" {
+			return nil, errors.New("cursor cannot be set together with afterId, sortBy or orderBy")
+		}
+		if err = page.ParseCursor(params.Get("cursor")); err != nil {
+			return nil, err
+		}
+	} else {
+		page.SortBy = params.Get("sortBy")
+		if params.Get("orderBy") != "" {
+			page.OrderBy, err = models.GetOrderType(params.Get("orderBy"))
+			if err != nil {
+				return nil, errors.New("Invalid orderBy query param value, must be asc/desc")
+			}
+		}
+	}
+
+	if err = page.ValidateSortBy(sortFields); err != nil {
+		return nil, err
+	}
+
+	if params.Get("includeTotal") != "" {
+		page.IncludeTotal, err = strconv.ParseBool(params.Get("includeTotal"))
+		if err != nil {
+			return nil, errors.New("Invalid includeTotal query param value, must be true/false")
+		}
+	}
+	return &page, nil
+}
+
+// GetPageLinks returns the query parameters of the next and previous pages of a Search API response. The pages in
+// the default order are linked with afterId, the sorted pages are linked with a cursor and have no previous page.
+func GetPageLinks(page models.PageCriteria, lastRowId int, lenOfResponse int) (string, string) {
+	if !page.Sorted() {
+		return GetNextAndPrevValues(page.Limit, page.AfterId, lastRowId, lenOfResponse)
+	}
+	if lenOfResponse == page.Limit {
+		return "limit=" + strconv.Itoa(page.Limit) + "&cursor=" + page.Cursor(lastRowId), ""
+	}
+	return "", ""
+}
+
+func GetNextAndPrevValues(limit int, afterId int, rowId int, lenOfResponse int) (string, string) {
+	var next, prev string
+	if lenOfResponse == limit {
+		next = "limit=" + strconv.Itoa(limit) + "&afterId=" + strconv.Itoa(rowId)
+	}
+	if (afterId - limit) > 0 {
+		prev = "limit=" + strconv.Itoa(limit) + "&afterId=" + strconv.Itoa(afterId-limit)
+	} else if afterId > 0 {
+		prev = "limit=" + strconv.Itoa(limit) + "&afterId=" + strconv.Itoa(0)
+	}
+	return next, prev
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+)
+
+func TestPopulatePageCriteria(t *testing.T) {
+	cursor := models.PageCriteria{SortBy: "name", OrderBy: models.Descending}.Cursor(42)
+	tamperedCursor := models.PageCriteria{SortBy: "description"}.Cursor(42)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    models.PageCriteria
+		wantErr bool
+	}{
+		{
+			name:  "sorted page",
+			query: "limit=10&sortBy=name&orderBy=desc&includeTotal=true",
+			want:  models.PageCriteria{Limit: 10, SortBy: "name", OrderBy: models.Descending, IncludeTotal: true},
+		},
+		{
+			name:  "cursor",
+			query: "limit=10&cursor=" + cursor,
+			want:  models.PageCriteria{Limit: 10, AfterId: 42, SortBy: "name", OrderBy: models.Descending},
+		},
+		{
+			name:    "cursor and afterId",
+			query:   "cursor=" + cursor + "&afterId=1",
+			wantErr: true,
+		},
+		{
+			name:    "cursor and sortBy",
+			query:   "cursor=" + cursor + "&sortBy=name",
+			wantErr: true,
+		},
+		{
+			name:    "cursor and orderBy",
+			query:   "cursor=" + cursor + "&orderBy=asc",
+			wantErr: true,
+		},
+		{
+			name:    "malformed cursor",
+			query:   "cursor=" + cursor[1:],
+			wantErr: true,
+		},
+		{
+			name:    "cursor with a sort field of another search",
+			query:   "cursor=" + tamperedCursor,
+			wantErr: true,
+		},
+		{
+			name:    "unknown sortBy",
+			query:   "sortBy=description",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			page, err := PopulatePageCriteria(params, models.HostSortFields)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PopulatePageCriteria() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && *page != tt.want {
+				t.Errorf("PopulatePageCriteria() page = %+v, want %+v", *page, tt.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,15 @@ import (
 	"strings"
 )
 
+const reportDiffAgainstPrevious = "previous"
+
+var reportDiffParams = map[string]bool{"against": true}
+
+// reportFormatSummary renders the report as a hvs.ReportSummary
+const reportFormatSummary = "summary"
+
+var reportRetrieveParams = map[string]bool{"format": true}
+
 type ReportController struct {
 	ReportStore     domain.ReportStore
 	HostStore       domain.HostStore
@@ -185,24 +194,141 @@ func (controller ReportController) Retrieve(w http.ResponseWriter, r *http.Reque
 	defaultLog.Trace("controllers/report_controller:Retrieve() Entering")
 	defer defaultLog.Trace("controllers/report_controller:Retrieve() Leaving")
 
+	if err := utils.ValidateQueryParams(r.URL.Query(), reportRetrieveParams); err != nil {
+		secLog.Errorf("controllers/report_controller:Retrieve() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != reportFormatSummary {
+		secLog.Errorf("controllers/report_controller:Retrieve() %s : Invalid format query parameter", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid format query parameter, supported format is summary"}
+	}
+
 	id := uuid.MustParse(mux.Vars(r)["id"])
+	hvsReport, status, err := controller.retrieve(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if format == reportFormatSummary {
+		secLog.WithField("id", id).Infof("%s: Report summary retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+		return utils.SummarizeReport(hvsReport), http.StatusOK, nil
+	}
 
+	report := ConvertToReport(hvsReport)
+	secLog.WithField("report", report).Infof("%s: Report retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return report, http.StatusOK, nil
+}
+
+// RetrieveMarkdown renders the summary of the report as a markdown document for operators
+func (controller ReportController) RetrieveMarkdown(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_controller:RetrieveMarkdown() Entering")
+	defer defaultLog.Trace("controllers/report_controller:RetrieveMarkdown() Leaving")
+
+	if r.Header.Get("Accept") != constants.HTTPMediaTypeMarkdown {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Accept type"}
+	}
+	if err := utils.ValidateQueryParams(r.URL.Query(), reportRetrieveParams); err != nil {
+		secLog.Errorf("controllers/report_controller:RetrieveMarkdown() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	if format := r.URL.Query().Get("format"); format != "" && format != reportFormatSummary {
+		secLog.Errorf("controllers/report_controller:RetrieveMarkdown() %s : Invalid format query parameter", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid format query parameter, supported format is summary"}
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	hvsReport, status, err := controller.retrieve(id)
+	if err != nil {
+		return nil, status, err
+	}
+
+	summary := utils.RenderReportSummary(utils.SummarizeReport(hvsReport), &hvsReport.TrustReport.HostManifest.PcrManifest)
+	secLog.WithField("id", id).Infof("%s: Report summary retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	w.Header().Set("Content-Type", constants.HTTPMediaTypeMarkdown)
+	return summary, http.StatusOK, nil
+}
+
+func (controller ReportController) retrieve(id uuid.UUID) (*models.HVSReport, int, error) {
 	hvsReport, err := controller.ReportStore.Retrieve(id)
 	if err != nil {
 		if strings.Contains(err.Error(), commErr.RowsNotFound) {
 			secLog.WithError(err).WithField("id", id).Info(
-				"controllers/report_controller:Retrieve() Report with given ID does not exist")
+				"controllers/report_controller:retrieve() Report with given ID does not exist")
 			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Report with given ID does not exist"}
 		} else {
 			secLog.WithError(err).WithField("id", id).Info(
-				"controllers/report_controller:Retrieve() failed to retrieve Report")
+				"controllers/report_controller:retrieve() failed to retrieve Report")
 			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Report"}
 		}
 	}
+	return hvsReport, http.StatusOK, nil
+}
 
-	report := ConvertToReport(hvsReport)
-	secLog.WithField("report", report).Infof("%s: Report retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
-	return report, http.StatusOK, nil
+// Diff compares a report with the report given by the against query parameter. When against is not provided or is
+// "previous", the report is compared with the previous report of the host.
+func (controller ReportController) Diff(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_controller:Diff() Entering")
+	defer defaultLog.Trace("controllers/report_controller:Diff() Leaving")
+
+	if err := utils.ValidateQueryParams(r.URL.Query(), reportDiffParams); err != nil {
+		secLog.Errorf("controllers/report_controller:Diff() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	id := uuid.MustParse(mux.Vars(r)["id"])
+	hvsReport, status, err := controller.retrieveFromHistory(id, "Report with given ID does not exist")
+	if err != nil {
+		return nil, status, err
+	}
+
+	var against *models.HVSReport
+	againstParam := strings.TrimSpace(r.URL.Query().Get("against"))
+	if againstParam == "" || againstParam == reportDiffAgainstPrevious {
+		against, err = controller.ReportStore.RetrievePrevious(hvsReport)
+		if err != nil {
+			if strings.Contains(err.Error(), commErr.RowsNotFound) {
+				defaultLog.WithError(err).WithField("id", id).Info(
+					"controllers/report_controller:Diff() Previous report of the host does not exist")
+				return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Previous report of the host does not exist"}
+			}
+			defaultLog.WithError(err).WithField("id", id).Error(
+				"controllers/report_controller:Diff() Failed to retrieve previous report")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve previous report"}
+		}
+	} else {
+		againstId, err := uuid.Parse(againstParam)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/report_controller:Diff() %s : Invalid against report ID", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid UUID format of the report to compare against"}
+		}
+		if againstId == id {
+			secLog.Errorf("controllers/report_controller:Diff() %s : Report compared against itself", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The report cannot be compared against itself"}
+		}
+		against, status, err = controller.retrieveFromHistory(againstId, "Report to compare against does not exist")
+		if err != nil {
+			return nil, status, err
+		}
+	}
+
+	secLog.Infof("%s: Report diff retrieved by: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
+	return utils.DiffReports(hvsReport, against), http.StatusOK, nil
+}
+
+func (controller ReportController) retrieveFromHistory(id uuid.UUID, notFoundMessage string) (*models.HVSReport, int, error) {
+	hvsReport, err := controller.ReportStore.RetrieveFromHistory(id)
+	if err != nil {
+		if strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).WithField("id", id).Info(
+				"controllers/report_controller:retrieveFromHistory() Report with given ID does not exist")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: notFoundMessage}
+		}
+		defaultLog.WithError(err).WithField("id", id).Error(
+			"controllers/report_controller:retrieveFromHistory() Failed to retrieve report")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve Report"}
+	}
+	return hvsReport, http.StatusOK, nil
 }
 
 func (controller ReportController) Search(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
@@ -227,14 +353,23 @@ func (controller ReportController) Search(w http.ResponseWriter, r *http.Request
 		return nil, http.StatusInternalServerError, errors.Errorf("HVSReport search operation failed")
 	}
 
+	total := 0
+	if reportFilterCriteria.IncludeTotal {
+		total, err = controller.ReportStore.Count(reportFilterCriteria)
+		if err != nil {
+			defaultLog.WithError(err).Warnf("controllers/report_controller:Search() HVSReport count operation failed")
+			return nil, http.StatusInternalServerError, errors.Errorf("HVSReport search operation failed")
+		}
+	}
+
 	var next, prev string
 	if len(hvsReportCollection) > 0 {
 		lastRowId := hvsReportCollection[len(hvsReportCollection)-1].RowId
-		next, prev = GetNextAndPrevValues(reportFilterCriteria.Limit, reportFilterCriteria.AfterId, lastRowId, len(hvsReportCollection))
+		next, prev = GetPageLinks(reportFilterCriteria.PageCriteria, lastRowId, len(hvsReportCollection))
 	}
 
 	reportCollection := hvs.ReportCollection{
-		Reports: []*hvs.Report{}, Next: next, Previous: prev,
+		Reports: []*hvs.Report{}, Total: total, Next: next, Previous: prev,
 	}
 	for _, hvsReport := range hvsReportCollection {
 		reportCollection.Reports = append(reportCollection.Reports, ConvertToReport(&hvsReport))
@@ -379,12 +514,11 @@ func getReportFilterCriteria(params url.Values) (*models.ReportFilterCriteria, e
 		rfc.NumberOfDays = numDays
 	}
 
-	limit, afterId, err := validation.ValidatePaginationValues(params.Get("limit"), params.Get("afterId"))
+	page, err := PopulatePageCriteria(params, models.ReportSortFields)
 	if err != nil {
 		return nil, err
 	}
-	rfc.Limit = limit
-	rfc.AfterId = afterId
+	rfc.PageCriteria = *page
 
 	return &rfc, nil
 }
@@ -230,6 +230,147 @@ var _ = Describe("ReportController", func() {
 		})
 	})
 
+	// Specs for HTTP Get to "/reports/{id}" rendered as a summary
+	Describe("Retrieve the summary of an existing Report", func() {
+		Context("Retrieve Report by ID with format summary", func() {
+			It("Should retrieve the Report grouped by flavor part", func() {
+				router.Handle("/reports/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Retrieve))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3?format=summary", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var summary hvs.ReportSummary
+				err = json.Unmarshal(w.Body.Bytes(), &summary)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(summary.ReportId.String()).To(Equal("15701f03-7b1d-49f9-ac62-6b9b0728bdb3"))
+				Expect(summary.FlavorParts).NotTo(BeEmpty())
+				for _, flavorPart := range summary.FlavorParts {
+					Expect(flavorPart.Failures).To(BeEmpty())
+				}
+			})
+		})
+
+		Context("Retrieve Report by ID with an invalid format", func() {
+			It("Should fail to retrieve Report", func() {
+				router.Handle("/reports/{id}", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Retrieve))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3?format=pdf", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Retrieve Report by ID as markdown", func() {
+			It("Should retrieve the summary of the Report as a markdown document", func() {
+				router.Handle("/reports/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(reportController.RetrieveMarkdown))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeMarkdown)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Content-Type")).To(Equal(constants.HTTPMediaTypeMarkdown))
+				Expect(w.Body.String()).To(HavePrefix("# Trust report of "))
+				Expect(w.Body.String()).To(ContainSubstring("## PLATFORM: TRUSTED"))
+			})
+		})
+
+		Context("Retrieve Report by non-existent ID as markdown", func() {
+			It("Should fail to retrieve Report", func() {
+				router.Handle("/reports/{id}", hvsRoutes.ErrorHandler(hvsRoutes.ResponseHandler(reportController.RetrieveMarkdown))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/reports/73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeMarkdown)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/reports/{id}/diff"
+	Describe("Diff an existing Report", func() {
+		Context("Diff Report against the previous report of the host", func() {
+			It("Should return the rules whose result changed", func() {
+				router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var reportDiff hvs.ReportDiff
+				err = json.Unmarshal(w.Body.Bytes(), &reportDiff)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reportDiff.AgainstReportId.String()).To(Equal("15701f03-7b1d-49f9-ac62-6b9b0728bdb2"))
+				Expect(len(reportDiff.ChangedRules)).To(Equal(1))
+				Expect(reportDiff.ChangedRules[0].Trusted).To(BeTrue())
+				Expect(reportDiff.ChangedRules[0].AgainstTrusted).To(BeFalse())
+				Expect(len(reportDiff.ChangedRules[0].RemovedFaults)).To(Equal(1))
+			})
+		})
+
+		Context("Diff Report against a given report", func() {
+			It("Should return the diff of the reports", func() {
+				router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb2/diff?against=15701f03-7b1d-49f9-ac62-6b9b0728bdb3", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var reportDiff hvs.ReportDiff
+				err = json.Unmarshal(w.Body.Bytes(), &reportDiff)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(reportDiff.ChangedRules)).To(Equal(1))
+				Expect(len(reportDiff.ChangedRules[0].AddedFaults)).To(Equal(1))
+			})
+		})
+
+		Context("Diff Report of a host without previous report", func() {
+			It("Should return not found", func() {
+				router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb4/diff?against=previous", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("Diff Report against an invalid report ID", func() {
+			It("Should return bad request", func() {
+				router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff?against=abc", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("Diff Report against a non-existent report", func() {
+			It("Should return not found", func() {
+				router.Handle("/reports/{id}/diff", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportController.Diff))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/reports/15701f03-7b1d-49f9-ac62-6b9b0728bdb3/diff?against=73755fda-c910-46be-821f-e8ddeab189e9", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", constants.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
 	// Specs for HTTP Get to "/reports"
 	Describe("Search for all the Reports", func() {
 		Context("Get all the Reports", func() {
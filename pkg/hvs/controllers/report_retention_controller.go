@@ -0,0 +1,28 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+)
+
+type ReportRetentionController struct {
+	RetentionManager domain.ReportRetentionManager
+}
+
+func NewReportRetentionController(rm domain.ReportRetentionManager) *ReportRetentionController {
+	return &ReportRetentionController{RetentionManager: rm}
+}
+
+// Retrieve returns the retention policy of the report history and the statistics of the last purge
+func (controller ReportRetentionController) Retrieve(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/report_retention_controller:Retrieve() Entering")
+	defer defaultLog.Trace("controllers/report_retention_controller:Retrieve() Leaving")
+
+	return controller.RetentionManager.Status(), http.StatusOK, nil
+}
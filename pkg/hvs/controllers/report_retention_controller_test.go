@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	hvsRoutes "github.com/intel-secl/intel-secl/v5/pkg/hvs/router"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/reportretention"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReportRetentionController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var reportRetention *reportretention.ReportRetentionService
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+	})
+
+	// Specs for HTTP Get to "/report-retention"
+	Describe("Retrieve report retention status", func() {
+		retrieve := func() hvs.ReportRetentionStatus {
+			reportRetentionController := controllers.NewReportRetentionController(reportRetention)
+			router.Handle("/report-retention", hvsRoutes.ErrorHandler(hvsRoutes.JsonResponseHandler(reportRetentionController.Retrieve))).Methods(http.MethodGet)
+
+			req, err := http.NewRequest(http.MethodGet, "/report-retention", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var status hvs.ReportRetentionStatus
+			Expect(json.Unmarshal(w.Body.Bytes(), &status)).To(Succeed())
+			return status
+		}
+
+		Context("When the report retention is disabled", func() {
+			It("Should return the disabled policy without runs", func() {
+				var err error
+				reportRetention, err = reportretention.NewReportRetentionService(reportretention.ReportRetentionConfig{
+					RunPeriod: 24 * time.Hour,
+				}, mocks.NewEmptyMockReportStore())
+				Expect(err).NotTo(HaveOccurred())
+
+				status := retrieve()
+				Expect(status.Enabled).To(BeFalse())
+				Expect(status.LastRun).To(BeNil())
+			})
+		})
+		Context("When the report history has been purged", func() {
+			It("Should return the statistics of the last purge", func() {
+				var err error
+				reportRetention, err = reportretention.NewReportRetentionService(reportretention.ReportRetentionConfig{
+					RunPeriod:         24 * time.Hour,
+					RetentionDays:     30,
+					KeepLatestPerHost: 10,
+					BatchSize:         500,
+				}, mocks.NewEmptyMockReportStore())
+				Expect(err).NotTo(HaveOccurred())
+				reportRetention.Purge()
+
+				status := retrieve()
+				Expect(status.Enabled).To(BeTrue())
+				Expect(status.RetentionDays).To(Equal(30))
+				Expect(status.RunPeriod).To(Equal("24h0m0s"))
+				Expect(status.LastRun).NotTo(BeNil())
+				Expect(status.LastRun.Batches).To(Equal(1))
+				Expect(status.LastRun.Error).To(BeEmpty())
+			})
+		})
+	})
+})
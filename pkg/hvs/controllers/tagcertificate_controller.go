@@ -180,7 +180,8 @@ func (controller TagCertificateController) Search(w http.ResponseWriter, r *http
 	defer defaultLog.Trace("controllers/tagcertificate_controller:Search() Leaving")
 
 	var tagCertSearchParams = map[string]bool{"id": true, "hardwareUuid": true, "subjectContains": true, "subjectEqualTo": true,
-		"issuerContains": true, "issuerEqualTo": true, "validOn": true, "validBefore": true, "validAfter": true}
+		"issuerContains": true, "issuerEqualTo": true, "validOn": true, "validBefore": true, "validAfter": true,
+		"limit": true, "afterId": true, "cursor": true, "includeTotal": true, "sortBy": true, "orderBy": true}
 
 	if err := utils.ValidateQueryParams(r.URL.Query(), tagCertSearchParams); err != nil {
 		secLog.Errorf("controllers/tagcertificate_controller:Search() %s", err.Error())
@@ -200,12 +201,27 @@ func (controller TagCertificateController) Search(w http.ResponseWriter, r *http
 		return nil, http.StatusInternalServerError, errors.Errorf("TagCertificate search operation failed")
 	}
 
+	total := 0
+	if filter.IncludeTotal {
+		total, err = controller.Store.Count(filter)
+		if err != nil {
+			defaultLog.WithError(err).Errorf("controllers/tagcertificate_controller:Search() %s : TagCertificate count operation failed", commLogMsg.AppRuntimeErr)
+			return nil, http.StatusInternalServerError, errors.Errorf("TagCertificate search operation failed")
+		}
+	}
+
 	// add the asset tag digest to all the results
 	for _, tc := range tagCertResultSet {
 		tc.SetAssetTagDigest()
 	}
 
-	tagCertCollection := hvs.TagCertificateCollection{TagCertificates: tagCertResultSet}
+	var next, prev string
+	if len(tagCertResultSet) > 0 {
+		lastRowId := tagCertResultSet[len(tagCertResultSet)-1].RowId
+		next, prev = GetPageLinks(filter.PageCriteria, lastRowId, len(tagCertResultSet))
+	}
+
+	tagCertCollection := hvs.TagCertificateCollection{TagCertificates: tagCertResultSet, Total: total, Next: next, Previous: prev}
 
 	secLog.Infof("%s: Return TagCertificate Search query to: %s", commLogMsg.AuthorizedAccess, r.RemoteAddr)
 	return tagCertCollection, http.StatusOK, nil
@@ -357,6 +373,16 @@ func getTCFilterCriteria(params url.Values) (*models.TagCertificateFilterCriteri
 		tagCertFc.HardwareUUID = hwUUID
 	}
 
+	page, err := PopulatePageCriteria(params, models.TagCertificateSortFields)
+	if err != nil {
+		return nil, err
+	}
+	// the certificates are listed by subject unless another sort field is given
+	if page.SortBy == "" {
+		page.SortBy = "subject"
+	}
+	tagCertFc.PageCriteria = *page
+
 	return &tagCertFc, nil
 }
 
@@ -31,7 +31,8 @@ type TpmEndorsementController struct {
 }
 
 var tpmEndorsementSearchParams = map[string]bool{"id": true, "hardwareUuidEqualTo": true, "issuerEqualTo": true, "revokedEqualTo": true,
-	"issuerContains": true, "commentEqualTo": true, "commentContains": true, "certificateDigestEqualTo": true, "afterId": true, "limit": true}
+	"issuerContains": true, "commentEqualTo": true, "commentContains": true, "certificateDigestEqualTo": true, "afterId": true, "limit": true,
+	"cursor": true, "includeTotal": true, "sortBy": true, "orderBy": true}
 
 func (controller TpmEndorsementController) Create(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/tpm_endorsement_controller:Create() Entering")
@@ -201,12 +202,22 @@ func (controller TpmEndorsementController) Search(w http.ResponseWriter, r *http
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search TpmEndorsement"}
 	}
 
+	total := 0
+	if filter.IncludeTotal {
+		total, err = controller.Store.Count(filter)
+		if err != nil {
+			secLog.WithError(err).Error("controllers/tpm_endorsement_controller:Search() TpmEndorsement count failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Unable to search TpmEndorsement"}
+		}
+	}
+
 	var next, prev string
 	if len(tpmEndorsementCollection.TpmEndorsement) > 0 {
 		lastRowId := tpmEndorsementCollection.TpmEndorsement[len(tpmEndorsementCollection.TpmEndorsement)-1].RowId
-		next, prev = GetNextAndPrevValues(filter.Limit, filter.AfterId, lastRowId, len(tpmEndorsementCollection.TpmEndorsement))
+		next, prev = GetPageLinks(filter.PageCriteria, lastRowId, len(tpmEndorsementCollection.TpmEndorsement))
 	}
 
+	tpmEndorsementCollection.Total = total
 	tpmEndorsementCollection.Next = next
 	tpmEndorsementCollection.Previous = prev
 
@@ -368,12 +379,11 @@ func getAndValidateFilterCriteria(params url.Values) (*models.TpmEndorsementFilt
 		criteria.CertificateDigestEqualTo = certificateDigestEqualTo
 	}
 
-	limit, afterId, err := validation.ValidatePaginationValues(params.Get("limit"), params.Get("afterId"))
+	page, err := PopulatePageCriteria(params, models.TpmEndorsementSortFields)
 	if err != nil {
 		return nil, err
 	}
-	criteria.Limit = limit
-	criteria.AfterId = afterId
+	criteria.PageCriteria = *page
 
 	return &criteria, nil
 }
@@ -10,6 +10,9 @@ import (
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/config"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/notification"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/remediation"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/reportretention"
 	commConfig "github.com/intel-secl/intel-secl/v5/pkg/lib/common/config"
 	"github.com/spf13/viper"
 )
@@ -61,6 +64,24 @@ func init() {
 	viper.SetDefault(config.AuditLogNumRotated, constants.DefaultNumRotated)
 	viper.SetDefault(config.AuditLogBufferSize, constants.DefaultChannelBufferSize)
 
+	// set default for notification
+	viper.SetDefault(config.NotificationBufferSize, constants.DefaultNotificationBufferSize)
+	viper.SetDefault(config.NotificationMaxRetries, constants.DefaultNotificationMaxRetries)
+	viper.SetDefault(config.NotificationRetryInterval, constants.DefaultNotificationRetryInterval)
+	viper.SetDefault(config.NotificationRequestTimeout, constants.DefaultNotificationRequestTimeout)
+
+	// set default for report retention
+	viper.SetDefault(config.ReportRetentionRunPeriod, constants.DefaultReportRetentionRunPeriod)
+	viper.SetDefault(config.ReportRetentionDays, constants.DefaultReportRetentionDays)
+	viper.SetDefault(config.ReportRetentionKeepLatestPerHost, constants.DefaultReportRetentionKeepLatestPerHost)
+	viper.SetDefault(config.ReportRetentionBatchSize, constants.DefaultReportRetentionBatchSize)
+	viper.SetDefault(config.ReportRetentionArchive, constants.DefaultReportRetentionArchive)
+
+	// set default for remediation
+	viper.SetDefault(config.RemediationUntrustedReportsThreshold, constants.DefaultRemediationUntrustedReportsThreshold)
+	viper.SetDefault(config.RemediationDeleteTagCertificates, constants.DefaultRemediationDeleteTagCertificates)
+	viper.SetDefault(config.RemediationScriptTimeout, constants.DefaultRemediationScriptTimeout)
+
 	// set default value for aik
 	viper.SetDefault(config.AikCertValidity, constants.DefaultAikCertificateValidity)
 
@@ -70,6 +91,9 @@ func init() {
 	//set default value for Quote Verify For Registration
 	viper.SetDefault(config.VerifyQuoteForHostRegistration, false)
 
+	// set default value for the max age of host bundles
+	viper.SetDefault(config.HostBundleMaxAge, constants.DefaultHostBundleMaxAge)
+
 	// set default values for server
 	viper.SetDefault(commConfig.ServerPort, constants.DefaultHVSListenerPort)
 	viper.SetDefault(commConfig.ServerReadTimeout, constants.DefaultReadTimeout)
@@ -110,6 +134,7 @@ func defaultConfig() *config.Configuration {
 		AikCertValidity:                viper.GetInt(config.AikCertValidity),
 		RequireEKCertForHostProvision:  viper.GetBool(config.RequireEKCertForHostProvision),
 		VerifyQuoteForHostRegistration: viper.GetBool(config.VerifyQuoteForHostRegistration),
+		HostBundleMaxAge:               viper.GetDuration(config.HostBundleMaxAge),
 		AuditLog: config.AuditLogConfig{
 			MaxRowCount: viper.GetInt(config.AuditLogMaxRowCount),
 			NumRotated:  viper.GetInt(config.AuditLogNumRotated),
@@ -178,6 +203,26 @@ func defaultConfig() *config.Configuration {
 			HostTrustCacheThreshold:         viper.GetInt(constants.FvsHostTrustCacheThreshold),
 		},
 		EnableEkCertRevokeChecks: viper.GetBool(constants.EnableEKCertRevokeCheck),
+		Notification: notification.NotificationConfig{
+			BufferSize:     viper.GetInt(config.NotificationBufferSize),
+			MaxRetries:     viper.GetInt(config.NotificationMaxRetries),
+			RetryInterval:  viper.GetDuration(config.NotificationRetryInterval),
+			RequestTimeout: viper.GetDuration(config.NotificationRequestTimeout),
+		},
+		ReportRetention: reportretention.ReportRetentionConfig{
+			RunPeriod:         viper.GetDuration(config.ReportRetentionRunPeriod),
+			RetentionDays:     viper.GetInt(config.ReportRetentionDays),
+			KeepLatestPerHost: viper.GetInt(config.ReportRetentionKeepLatestPerHost),
+			BatchSize:         viper.GetInt(config.ReportRetentionBatchSize),
+			Archive:           viper.GetBool(config.ReportRetentionArchive),
+		},
+		Remediation: remediation.RemediationConfig{
+			UntrustedReportsThreshold: viper.GetInt(config.RemediationUntrustedReportsThreshold),
+			DeleteTagCertificates:     viper.GetBool(config.RemediationDeleteTagCertificates),
+			Actions:                   viper.GetStringSlice(config.RemediationActions),
+			Script:                    viper.GetString(config.RemediationScript),
+			ScriptTimeout:             viper.GetDuration(config.RemediationScriptTimeout),
+		},
 	}
 }
 
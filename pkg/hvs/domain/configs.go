@@ -23,6 +23,7 @@ type HostTrustVerifierConfig struct {
 	SamlIssuerConfig                saml.IssuerConfiguration
 	SkipFlavorSignatureVerification bool
 	HostTrustCache                  *lru.Cache
+	RemediationManager              RemediationManager
 }
 
 type HostTrustMgrConfig struct {
@@ -18,6 +18,7 @@ type (
 		Create(*hvs.FlavorGroup) (*hvs.FlavorGroup, error)
 		Retrieve(uuid.UUID) (*hvs.FlavorGroup, error)
 		Search(*models.FlavorGroupFilterCriteria) ([]hvs.FlavorGroup, error)
+		Count(*models.FlavorGroupFilterCriteria) (int, error)
 		Delete(uuid.UUID) error
 		HasAssociatedHosts(uuid.UUID) (bool, error)
 		AddFlavors(uuid.UUID, []uuid.UUID) ([]uuid.UUID, error)
@@ -25,9 +26,12 @@ type (
 		SearchFlavors(uuid.UUID) ([]uuid.UUID, error)
 		RetrieveFlavor(uuid.UUID, uuid.UUID) (*hvs.FlavorgroupFlavorLink, error)
 		SearchHostsByFlavorGroup(fgID uuid.UUID) ([]uuid.UUID, error)
+		// SearchHostsByFlavorGroups returns the hosts linked to each of the flavorgroups
+		SearchHostsByFlavorGroups(fgIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error)
 		SearchFlavorTemplatesByFlavorGroup(fgID uuid.UUID) ([]uuid.UUID, error)
 		GetFlavorTypesInFlavorGroup(flvGrpId uuid.UUID) (map[hvs.FlavorPartName]bool, error)
 		AddFlavorTemplates(uuid.UUID, []uuid.UUID) error
+		UpdatePolicyRules(uuid.UUID, []hvs.PolicyRule) error
 	}
 
 	HostStore interface {
@@ -37,6 +41,7 @@ type (
 		Delete(uuid.UUID) error
 		DeleteByHostName(string) error
 		Search(*models.HostFilterCriteria, *models.HostInfoFetchCriteria) ([]*hvs.Host, error)
+		Count(*models.HostFilterCriteria) (int, error)
 		AddFlavorgroups(uuid.UUID, []uuid.UUID) error
 		RetrieveFlavorgroup(uuid.UUID, uuid.UUID) (*hvs.HostFlavorgroup, error)
 		RemoveFlavorgroups(uuid.UUID, []uuid.UUID) error
@@ -64,10 +69,19 @@ type (
 		FindByHostName(string) (*models.HostCredential, error)
 	}
 
+	HostRegistrationStore interface {
+		// CreateBatch creates the hosts, the host credentials and the host-flavorgroup links of the registrations
+		// in a single transaction, either all the hosts are created or none of them
+		CreateBatch([]*models.HostRegistration) error
+	}
+
 	FlavorStore interface {
 		Create(*hvs.SignedFlavor) (*hvs.SignedFlavor, error)
 		Retrieve(uuid.UUID) (*hvs.SignedFlavor, error)
 		Search(*models.FlavorVerificationFC) ([]hvs.SignedFlavor, error)
+		Count(*models.FlavorVerificationFC) (int, error)
+		Update(*hvs.SignedFlavor) (*hvs.SignedFlavor, error)
+		SearchRevisions(uuid.UUID) ([]hvs.FlavorRevision, error)
 		Delete(uuid.UUID) error
 	}
 
@@ -76,6 +90,7 @@ type (
 		Update(*hvs.TpmEndorsement) (*hvs.TpmEndorsement, error)
 		Retrieve(uuid.UUID) (*hvs.TpmEndorsement, error)
 		Search(*models.TpmEndorsementFilterCriteria) (*hvs.TpmEndorsementCollection, error)
+		Count(*models.TpmEndorsementFilterCriteria) (int, error)
 		Delete(uuid.UUID) error
 	}
 
@@ -84,6 +99,7 @@ type (
 		Create(*hvs.FlavorTemplate) (*hvs.FlavorTemplate, error)
 		Retrieve(uuid.UUID, bool) (*hvs.FlavorTemplate, error)
 		Search(*models.FlavorTemplateFilterCriteria) ([]hvs.FlavorTemplate, error)
+		Count(*models.FlavorTemplateFilterCriteria) (int, error)
 		Delete(uuid.UUID) error
 		Recover([]string) error
 		AddFlavorgroups(uuid.UUID, []uuid.UUID) error
@@ -92,11 +108,19 @@ type (
 		SearchFlavorgroups(uuid.UUID) ([]uuid.UUID, error)
 	}
 
+	// FlavorBundleStore stores the content of an imported flavor bundle
+	FlavorBundleStore interface {
+		// Import runs the import with flavor template, flavorgroup and flavor stores bound to a single transaction,
+		// nothing is stored when the import returns an error
+		Import(func(FlavorTemplateStore, FlavorGroupStore, FlavorStore) error) error
+	}
+
 	// HostStatusStore specifies the DB operations that must be implemented for the Host Status API
 	HostStatusStore interface {
 		Create(*hvs.HostStatus) (*hvs.HostStatus, error)
 		Retrieve(uuid.UUID) (*hvs.HostStatus, error)
 		Search(*models.HostStatusFilterCriteria) ([]hvs.HostStatus, error)
+		Count(*models.HostStatusFilterCriteria) (int, error)
 		Delete(uuid.UUID) error
 		Persist(*hvs.HostStatus) error
 		FindHostIdsByKeyValue(key, value string) ([]uuid.UUID, error)
@@ -104,6 +128,7 @@ type (
 
 	QueueStore interface {
 		Search(*models.QueueFilterCriteria) ([]*models.Queue, error)
+		CountByState() (map[models.QueueState]int, error)
 		Retrieve(uuid.UUID) (*models.Queue, error)
 		Update(*models.Queue) error
 		Create(*models.Queue) (*models.Queue, error)
@@ -112,17 +137,29 @@ type (
 
 	ReportStore interface {
 		Search(*models.ReportFilterCriteria) ([]models.HVSReport, error)
+		Count(*models.ReportFilterCriteria) (int, error)
 		Retrieve(uuid.UUID) (*models.HVSReport, error)
 		Create(*models.HVSReport) (*models.HVSReport, error)
 		Update(*models.HVSReport) (*models.HVSReport, error)
 		Delete(uuid.UUID) error
 		FindHostIdsFromExpiredReports(fromTime time.Time, toTime time.Time) ([]uuid.UUID, error)
+		// SearchReportTimes returns the creation and expiration times of the current reports of the hosts, the
+		// trust reports and SAML reports are not loaded
+		SearchReportTimes(hostIDs []uuid.UUID) ([]models.HVSReport, error)
+		// RetrieveFromHistory also finds the reports that were replaced by a newer report of the host
+		RetrieveFromHistory(uuid.UUID) (*models.HVSReport, error)
+		// RetrievePrevious finds the report of the host created before the given report
+		RetrievePrevious(*models.HVSReport) (*models.HVSReport, error)
+		// PurgeHistory deletes or archives a batch of the reports replaced by a newer report of the host and
+		// returns the number of purged reports
+		PurgeHistory(*models.ReportPurgeCriteria) (int, error)
 	}
 
 	ESXiClusterStore interface {
 		Create(*hvs.ESXiCluster) (*hvs.ESXiCluster, error)
 		Retrieve(uuid.UUID) (*hvs.ESXiCluster, error)
 		Search(*models.ESXiClusterFilterCriteria) ([]hvs.ESXiCluster, error)
+		Count(*models.ESXiClusterFilterCriteria) (int, error)
 		Delete(uuid.UUID) error
 		AddHosts(uuid.UUID, []string) error
 		SearchHosts(uuid.UUID) ([]string, error)
@@ -134,6 +171,7 @@ type (
 		Retrieve(uuid.UUID) (*hvs.TagCertificate, error)
 		Delete(uuid.UUID) error
 		Search(*models.TagCertificateFilterCriteria) ([]*hvs.TagCertificate, error)
+		Count(*models.TagCertificateFilterCriteria) (int, error)
 	}
 
 	HostTrustManager interface {
@@ -187,7 +225,55 @@ type (
 	AuditLogEntryStore interface {
 		Create(*models.AuditLogEntry) (*models.AuditLogEntry, error)
 		Retrieve(*models.AuditLogEntry) ([]models.AuditLogEntry, error)
+		Search(*models.AuditLogEntryFilterCriteria) ([]models.AuditLogEntry, error)
+		Count(*models.AuditLogEntryFilterCriteria) (int, error)
 		Update(*models.AuditLogEntry) (*models.AuditLogEntry, error)
 		Delete(uuid.UUID) error
 	}
+
+	NotificationSubscriptionStore interface {
+		Create(*hvs.NotificationSubscription) (*hvs.NotificationSubscription, error)
+		Retrieve(uuid.UUID) (*hvs.NotificationSubscription, error)
+		Search(*models.NotificationSubscriptionFilterCriteria) ([]hvs.NotificationSubscription, error)
+		Delete(uuid.UUID) error
+		CreateDeadLetter(*hvs.NotificationDeadLetter) (*hvs.NotificationDeadLetter, error)
+		SearchDeadLetters(uuid.UUID) ([]hvs.NotificationDeadLetter, error)
+	}
+
+	NotificationPublisher interface {
+		// queues the event for delivery to the subscribers of the event type
+		Publish(*hvs.NotificationEvent)
+		Stop()
+	}
+
+	ReportRetentionManager interface {
+		// returns the retention policy and the statistics of the last purge of the report history
+		Status() hvs.ReportRetentionStatus
+	}
+
+	AttestationScheduleStore interface {
+		Create(*hvs.AttestationSchedule) (*hvs.AttestationSchedule, error)
+		Retrieve(uuid.UUID) (*hvs.AttestationSchedule, error)
+		Search(*models.AttestationScheduleFilterCriteria) ([]hvs.AttestationSchedule, error)
+		Update(*hvs.AttestationSchedule) (*hvs.AttestationSchedule, error)
+		Delete(uuid.UUID) error
+	}
+
+	AttestationScheduler interface {
+		// returns the next attestation of each host linked to the flavorgroup of the schedule
+		GetScheduledAttestations(*hvs.AttestationSchedule) ([]hvs.ScheduledAttestation, error)
+	}
+
+	HostQuarantineStore interface {
+		Retrieve(uuid.UUID) (*hvs.HostQuarantine, error)
+		Search(*models.HostQuarantineFilterCriteria) ([]hvs.HostQuarantine, error)
+		Persist(*hvs.HostQuarantine) error
+	}
+
+	RemediationManager interface {
+		// counts the consecutive untrusted reports of the host and quarantines it when the threshold is reached
+		ProcessReport(*models.HVSReport)
+		// releases a quarantined host, the consecutive untrusted reports are counted again from zero
+		Release(hostId uuid.UUID, comment string) (*hvs.HostQuarantine, error)
+	}
 )
@@ -0,0 +1,104 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockAttestationScheduleStore provides a mocked implementation of interface domain.AttestationScheduleStore
+type MockAttestationScheduleStore struct {
+	schedules []hvs.AttestationSchedule
+	lock      sync.Mutex
+}
+
+// Create inserts an AttestationSchedule
+func (store *MockAttestationScheduleStore) Create(as *hvs.AttestationSchedule) (*hvs.AttestationSchedule, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if as.ID == uuid.Nil {
+		as.ID = uuid.New()
+	}
+	as.CreatedAt = time.Now()
+	store.schedules = append(store.schedules, *as)
+	return as, nil
+}
+
+// Retrieve returns an AttestationSchedule
+func (store *MockAttestationScheduleStore) Retrieve(id uuid.UUID) (*hvs.AttestationSchedule, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	for _, as := range store.schedules {
+		if as.ID == id {
+			return &as, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Search returns the AttestationSchedules of the flavorgroup in the filter criteria
+func (store *MockAttestationScheduleStore) Search(criteria *models.AttestationScheduleFilterCriteria) ([]hvs.AttestationSchedule, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	schedules := []hvs.AttestationSchedule{}
+	for _, as := range store.schedules {
+		if criteria == nil || criteria.FlavorgroupId == uuid.Nil || criteria.FlavorgroupId == as.FlavorgroupId {
+			schedules = append(schedules, as)
+		}
+	}
+	return schedules, nil
+}
+
+// Update changes the interval of an AttestationSchedule
+func (store *MockAttestationScheduleStore) Update(as *hvs.AttestationSchedule) (*hvs.AttestationSchedule, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	for i := range store.schedules {
+		if store.schedules[i].ID == as.ID {
+			store.schedules[i].Interval = as.Interval
+			schedule := store.schedules[i]
+			return &schedule, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Delete deletes an AttestationSchedule
+func (store *MockAttestationScheduleStore) Delete(id uuid.UUID) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	for i, as := range store.schedules {
+		if as.ID == id {
+			store.schedules = append(store.schedules[:i], store.schedules[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New(commErr.RowsNotFound)
+}
+
+// NewMockAttestationScheduleStore provides a schedule re-attesting the hosts of the automatic flavorgroup every 5 minutes
+func NewMockAttestationScheduleStore() *MockAttestationScheduleStore {
+	store := &MockAttestationScheduleStore{}
+
+	_, _ = store.Create(&hvs.AttestationSchedule{
+		ID:            uuid.MustParse("3c9f8e2a-1b4d-4e6f-8a7c-5d2e1f0a9b8c"),
+		FlavorgroupId: uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"),
+		Interval:      "5m",
+	})
+	return store
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mocks
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	"github.com/pkg/errors"
+)
+
+// MockAuditLogEntryStore provides a mocked implementation of interface domain.AuditLogEntryStore
+type MockAuditLogEntryStore struct {
+	auditLogEntries []models.AuditLogEntry
+}
+
+// Create inserts an AuditLogEntry
+func (store *MockAuditLogEntryStore) Create(entry *models.AuditLogEntry) (*models.AuditLogEntry, error) {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	entry.RowId = len(store.auditLogEntries) + 1
+	store.auditLogEntries = append(store.auditLogEntries, *entry)
+	return entry, nil
+}
+
+// Retrieve returns the AuditLogEntries matching the non empty fields of the given entry
+func (store *MockAuditLogEntryStore) Retrieve(entry *models.AuditLogEntry) ([]models.AuditLogEntry, error) {
+	var entries []models.AuditLogEntry
+	for _, e := range store.auditLogEntries {
+		if (entry.ID == uuid.Nil || e.ID == entry.ID) &&
+			(entry.EntityID == uuid.Nil || e.EntityID == entry.EntityID) &&
+			(entry.EntityType == "" || e.EntityType == entry.EntityType) &&
+			(entry.Action == "" || e.Action == entry.Action) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// Search returns a collection of AuditLogEntries filtered as per AuditLogEntryFilterCriteria
+func (store *MockAuditLogEntryStore) Search(criteria *models.AuditLogEntryFilterCriteria) ([]models.AuditLogEntry, error) {
+	var entries []models.AuditLogEntry
+	for _, e := range store.auditLogEntries {
+		if criteria == nil {
+			entries = append(entries, e)
+			continue
+		}
+		if criteria.EntityID != uuid.Nil && e.EntityID != criteria.EntityID {
+			continue
+		}
+		if criteria.EntityType != "" && e.EntityType != criteria.EntityType {
+			continue
+		}
+		if criteria.Action != "" && e.Action != criteria.Action {
+			continue
+		}
+		if !criteria.FromDate.IsZero() && e.CreatedAt.Before(criteria.FromDate) {
+			continue
+		}
+		if !criteria.ToDate.IsZero() && !e.CreatedAt.Before(criteria.ToDate) {
+			continue
+		}
+		if e.RowId <= criteria.AfterId {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].RowId < entries[j].RowId
+	})
+	if criteria != nil && criteria.Limit > 0 && len(entries) > criteria.Limit {
+		entries = entries[:criteria.Limit]
+	}
+	return entries, nil
+}
+
+// Count returns the number of audit log entries matching the criteria, the page of the criteria is not applied
+func (store *MockAuditLogEntryStore) Count(criteria *models.AuditLogEntryFilterCriteria) (int, error) {
+	var countCriteria models.AuditLogEntryFilterCriteria
+	if criteria != nil {
+		countCriteria = *criteria
+	}
+	countCriteria.PageCriteria = models.PageCriteria{}
+	entries, err := store.Search(&countCriteria)
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// Update updates an AuditLogEntry
+func (store *MockAuditLogEntryStore) Update(entry *models.AuditLogEntry) (*models.AuditLogEntry, error) {
+	for i, e := range store.auditLogEntries {
+		if e.ID == entry.ID {
+			store.auditLogEntries[i] = *entry
+			return entry, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Delete deletes an AuditLogEntry
+func (store *MockAuditLogEntryStore) Delete(id uuid.UUID) error {
+	for i, e := range store.auditLogEntries {
+		if e.ID == id {
+			store.auditLogEntries = append(store.auditLogEntries[:i], store.auditLogEntries[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New(commErr.RowsNotFound)
+}
+
+// NewMockAuditLogEntryStore provides two dummy report entries and one host status entry
+func NewMockAuditLogEntryStore() *MockAuditLogEntryStore {
+	store := &MockAuditLogEntryStore{}
+
+	created := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	reportId := uuid.MustParse("15701f03-7b1d-461c-8e1d-8f1d8a3b9c20")
+	hostStatusId := uuid.MustParse("afed7a2c-4e8b-4d6b-9d3c-6b8f0d1c2e33")
+
+	_, _ = store.Create(&models.AuditLogEntry{
+		ID:         uuid.MustParse("1bb9c8b6-2a47-4b7a-9a3f-0b3e3a9c1f01"),
+		EntityID:   reportId,
+		EntityType: "report",
+		CreatedAt:  created,
+		Action:     "create",
+		Data: models.AuditTableData{Columns: []models.AuditColumnData{
+			{Name: "id", Value: reportId.String(), IsUpdated: false},
+		}},
+	})
+	_, _ = store.Create(&models.AuditLogEntry{
+		ID:         uuid.MustParse("2cc9c8b6-2a47-4b7a-9a3f-0b3e3a9c1f02"),
+		EntityID:   hostStatusId,
+		EntityType: "host_status",
+		CreatedAt:  created.Add(time.Hour),
+		Action:     "update",
+		Data: models.AuditTableData{Columns: []models.AuditColumnData{
+			{Name: "id", Value: hostStatusId.String(), IsUpdated: false},
+		}},
+	})
+	_, _ = store.Create(&models.AuditLogEntry{
+		ID:         uuid.MustParse("3dd9c8b6-2a47-4b7a-9a3f-0b3e3a9c1f03"),
+		EntityID:   reportId,
+		EntityType: "report",
+		CreatedAt:  created.Add(48 * time.Hour),
+		Action:     "delete",
+		Data: models.AuditTableData{Columns: []models.AuditColumnData{
+			{Name: "id", Value: reportId.String(), IsUpdated: false},
+		}},
+	})
+	return store
+}
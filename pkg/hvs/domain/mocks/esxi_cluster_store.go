@@ -77,6 +77,20 @@ func (store *MockESXiClusterStore) Search(criteria *models.ESXiClusterFilterCrit
 	return ecFiltered, nil
 }
 
+// Count returns the number of ESXi clusters matching the criteria, the page of the criteria is not applied
+func (store *MockESXiClusterStore) Count(criteria *models.ESXiClusterFilterCriteria) (int, error) {
+	var countCriteria models.ESXiClusterFilterCriteria
+	if criteria != nil {
+		countCriteria = *criteria
+	}
+	countCriteria.PageCriteria = models.PageCriteria{}
+	clusters, err := store.Search(&countCriteria)
+	if err != nil {
+		return 0, err
+	}
+	return len(clusters), nil
+}
+
 // Create inserts a ESXi cluster
 func (store *MockESXiClusterStore) Create(ec *hvs.ESXiCluster) (*hvs.ESXiCluster, error) {
 	store.ESXiClusterStore = append(store.ESXiClusterStore, *ec)
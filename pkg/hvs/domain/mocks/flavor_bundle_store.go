@@ -0,0 +1,31 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+)
+
+// MockFlavorBundleStore provides a mocked implementation of interface domain.FlavorBundleStore, the import runs on
+// the given stores without a transaction
+type MockFlavorBundleStore struct {
+	FTStore domain.FlavorTemplateStore
+	FGStore domain.FlavorGroupStore
+	FStore  domain.FlavorStore
+}
+
+// Import runs the import on the stores of the mock
+func (store *MockFlavorBundleStore) Import(importFn func(domain.FlavorTemplateStore, domain.FlavorGroupStore, domain.FlavorStore) error) error {
+	return importFn(store.FTStore, store.FGStore, store.FStore)
+}
+
+// NewMockFlavorBundleStore provides a MockFlavorBundleStore importing in the given stores
+func NewMockFlavorBundleStore(fts domain.FlavorTemplateStore, fgs domain.FlavorGroupStore, fs domain.FlavorStore) *MockFlavorBundleStore {
+	return &MockFlavorBundleStore{
+		FTStore: fts,
+		FGStore: fgs,
+		FStore:  fs,
+	}
+}
@@ -19,6 +19,7 @@ import (
 // MockFlavorStore provides a mocked implementation of interface hvs.FlavorStore
 type MockFlavorStore struct {
 	flavorStore            []hvs.SignedFlavor
+	flavorRevisions        map[uuid.UUID][]hvs.FlavorRevision
 	FlavorFlavorGroupStore map[uuid.UUID][]uuid.UUID
 	FlavorgroupStore       map[uuid.UUID]*hvs.FlavorGroup
 }
@@ -557,6 +558,42 @@ func (store *MockFlavorStore) Retrieve(id uuid.UUID) (*hvs.SignedFlavor, error)
 	return nil, errors.New(commErr.RowsNotFound)
 }
 
+// Update stores a new revision of a Flavor
+func (store *MockFlavorStore) Update(sf *hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
+	for i, f := range store.flavorStore {
+		if f.Flavor.Meta.ID == sf.Flavor.Meta.ID {
+			if store.flavorRevisions == nil {
+				store.flavorRevisions = make(map[uuid.UUID][]hvs.FlavorRevision)
+			}
+			revisions := store.flavorRevisions[f.Flavor.Meta.ID]
+			store.flavorRevisions[f.Flavor.Meta.ID] = append(revisions, hvs.FlavorRevision{
+				Revision:     len(revisions) + 1,
+				SignedFlavor: f,
+			})
+			store.flavorStore[i] = hvs.SignedFlavor{
+				Flavor:    sf.Flavor,
+				Signature: sf.Signature,
+			}
+			return sf, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// SearchRevisions returns all the revisions of a Flavor, latest first
+func (store *MockFlavorStore) SearchRevisions(id uuid.UUID) ([]hvs.FlavorRevision, error) {
+	current, err := store.Retrieve(id)
+	if err != nil {
+		return nil, err
+	}
+	previous := store.flavorRevisions[id]
+	revisions := []hvs.FlavorRevision{{Revision: len(previous) + 1, SignedFlavor: *current}}
+	for i := len(previous) - 1; i >= 0; i-- {
+		revisions = append(revisions, previous[i])
+	}
+	return revisions, nil
+}
+
 // Search returns a filtered list of flavors per the provided FlavorFilterCriteria
 func (store *MockFlavorStore) Search(criteria *models.FlavorVerificationFC) ([]hvs.SignedFlavor, error) {
 	var sfs []hvs.SignedFlavor
@@ -608,6 +645,20 @@ func (store *MockFlavorStore) Search(criteria *models.FlavorVerificationFC) ([]h
 	return sfs, nil
 }
 
+// Count returns the number of flavors matching the criteria, the page of the criteria is not applied
+func (store *MockFlavorStore) Count(criteria *models.FlavorVerificationFC) (int, error) {
+	var countCriteria models.FlavorVerificationFC
+	if criteria != nil {
+		countCriteria = *criteria
+	}
+	countCriteria.FlavorFC.PageCriteria = models.PageCriteria{}
+	flavors, err := store.Search(&countCriteria)
+	if err != nil {
+		return 0, err
+	}
+	return len(flavors), nil
+}
+
 // Create inserts a Flavor
 func (store *MockFlavorStore) Create(sf *hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
 	//It is not right way to directly append the pointer, reference will be copied. Copy only the values.
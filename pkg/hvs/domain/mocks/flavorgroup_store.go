@@ -82,6 +82,20 @@ func (store *MockFlavorgroupStore) Search(criteria *models.FlavorGroupFilterCrit
 	return nil, nil
 }
 
+// Count returns the number of flavorgroups matching the criteria, the page of the criteria is not applied
+func (store *MockFlavorgroupStore) Count(criteria *models.FlavorGroupFilterCriteria) (int, error) {
+	var countCriteria models.FlavorGroupFilterCriteria
+	if criteria != nil {
+		countCriteria = *criteria
+	}
+	countCriteria.PageCriteria = models.PageCriteria{}
+	flavorgroups, err := store.Search(&countCriteria)
+	if err != nil {
+		return 0, err
+	}
+	return len(flavorgroups), nil
+}
+
 // Create inserts a Flavorgroup
 func (store *MockFlavorgroupStore) Create(flavorgroup *hvs.FlavorGroup) (*hvs.FlavorGroup, error) {
 	if flavorgroup.ID == uuid.Nil {
@@ -185,6 +199,18 @@ func (store *MockFlavorgroupStore) SearchHostsByFlavorGroup(fgID uuid.UUID) ([]u
 	return hIds, nil
 }
 
+// SearchHostsByFlavorGroups is used to fetch the hosts linked to each of the provided FlavorGroups
+func (store *MockFlavorgroupStore) SearchHostsByFlavorGroups(fgIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	hostIDs := make(map[uuid.UUID][]uuid.UUID)
+	for _, fgID := range fgIDs {
+		hIds, _ := store.SearchHostsByFlavorGroup(fgID)
+		if len(hIds) > 0 {
+			hostIDs[fgID] = hIds
+		}
+	}
+	return hostIDs, nil
+}
+
 func (store *MockFlavorgroupStore) GetFlavorTypesInFlavorGroup(fgId uuid.UUID) (map[hvs.FlavorPartName]bool, error) {
 	return make(map[hvs.FlavorPartName]bool), nil
 }
@@ -193,6 +219,16 @@ func (store *MockFlavorgroupStore) AddFlavorTemplates(fgId uuid.UUID, ftIds []uu
 	return nil
 }
 
+// UpdatePolicyRules replaces the policy rules of the Flavorgroup
+func (store *MockFlavorgroupStore) UpdatePolicyRules(fgId uuid.UUID, policyRules []hvs.PolicyRule) error {
+	fg, ok := store.FlavorgroupStore[fgId]
+	if !ok {
+		return errors.New(commErr.RowsNotFound)
+	}
+	fg.PolicyRules = policyRules
+	return nil
+}
+
 // NewFakeFlavorgroupStore provides two dummy data for Flavorgroups
 func NewFakeFlavorgroupStore() *MockFlavorgroupStore {
 
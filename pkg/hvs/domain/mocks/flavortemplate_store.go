@@ -148,6 +148,20 @@ func (store *MockFlavorTemplateStore) Search(criteria *models.FlavorTemplateFilt
 	return templates, nil
 }
 
+// Count returns the number of flavor templates matching the criteria, the page of the criteria is not applied
+func (store *MockFlavorTemplateStore) Count(criteria *models.FlavorTemplateFilterCriteria) (int, error) {
+	var countCriteria models.FlavorTemplateFilterCriteria
+	if criteria != nil {
+		countCriteria = *criteria
+	}
+	countCriteria.PageCriteria = models.PageCriteria{}
+	flavorTemplates, err := store.Search(&countCriteria)
+	if err != nil {
+		return 0, err
+	}
+	return len(flavorTemplates), nil
+}
+
 // Detele a Flavortemplate
 func (store *MockFlavorTemplateStore) Delete(templateID uuid.UUID) error {
 	flavorTemplates := store.FlavorTemplates
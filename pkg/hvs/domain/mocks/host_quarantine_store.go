@@ -0,0 +1,65 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockHostQuarantineStore provides a mocked implementation of interface domain.HostQuarantineStore
+type MockHostQuarantineStore struct {
+	quarantines map[uuid.UUID]hvs.HostQuarantine
+	lock        sync.Mutex
+}
+
+// Retrieve returns the HostQuarantine of a host
+func (store *MockHostQuarantineStore) Retrieve(hostId uuid.UUID) (*hvs.HostQuarantine, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if hq, ok := store.quarantines[hostId]; ok {
+		return &hq, nil
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Search returns the HostQuarantines matching the quarantined flag of the filter criteria
+func (store *MockHostQuarantineStore) Search(criteria *models.HostQuarantineFilterCriteria) ([]hvs.HostQuarantine, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	quarantines := []hvs.HostQuarantine{}
+	for _, hq := range store.quarantines {
+		if criteria == nil || criteria.Quarantined == nil || *criteria.Quarantined == hq.Quarantined {
+			quarantines = append(quarantines, hq)
+		}
+	}
+	return quarantines, nil
+}
+
+// Persist creates or updates a HostQuarantine
+func (store *MockHostQuarantineStore) Persist(hq *hvs.HostQuarantine) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	hq.Updated = time.Now()
+	store.quarantines[hq.HostId] = *hq
+	return nil
+}
+
+// NewMockHostQuarantineStore provides an empty HostQuarantine store
+func NewMockHostQuarantineStore() *MockHostQuarantineStore {
+	return &MockHostQuarantineStore{
+		quarantines: make(map[uuid.UUID]hvs.HostQuarantine),
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/pkg/errors"
+)
+
+// MockHostRegistrationStore provides a mocked implementation of interface domain.HostRegistrationStore, the hosts
+// are created in the given host and host credential stores
+type MockHostRegistrationStore struct {
+	HStore  *MockHostStore
+	HCStore *MockHostCredentialStore
+	// FailingHostNames are the names of the hosts that fail the batch they are created in
+	FailingHostNames map[string]bool
+}
+
+// CreateBatch creates the hosts, credentials and flavorgroup links of the registrations, none of them are created
+// when a registration is for one of the FailingHostNames
+func (store *MockHostRegistrationStore) CreateBatch(registrations []*models.HostRegistration) error {
+	for _, registration := range registrations {
+		if store.FailingHostNames[registration.Host.HostName] {
+			return errors.Errorf("failed to create Host %s", registration.Host.HostName)
+		}
+	}
+	for _, registration := range registrations {
+		registration.Host.Id = uuid.New()
+		_, _ = store.HStore.Create(registration.Host)
+		registration.Credential.HostId = registration.Host.Id
+		_, _ = store.HCStore.Create(registration.Credential)
+		if len(registration.FlavorgroupIds) > 0 {
+			_ = store.HStore.AddFlavorgroups(registration.Host.Id, registration.FlavorgroupIds)
+		}
+	}
+	return nil
+}
+
+// NewMockHostRegistrationStore provides a MockHostRegistrationStore creating the hosts in the given stores
+func NewMockHostRegistrationStore(hs *MockHostStore, hcs *MockHostCredentialStore) *MockHostRegistrationStore {
+	return &MockHostRegistrationStore{
+		HStore:  hs,
+		HCStore: hcs,
+	}
+}
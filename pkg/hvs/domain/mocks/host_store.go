@@ -71,7 +71,7 @@ func (store *MockHostStore) DeleteByHostName(hostName string) error {
 
 // Search returns a collection of Hosts filtered as per HostFilterCriteria
 func (store *MockHostStore) Search(criteria *models.HostFilterCriteria, hostInfoFetchCriteria *models.HostInfoFetchCriteria) ([]*hvs.Host, error) {
-	if criteria == nil || reflect.DeepEqual(*criteria, models.HostFilterCriteria{}) || reflect.DeepEqual(*criteria, models.HostFilterCriteria{Limit: 1000, AfterId: 0}) {
+	if criteria == nil || reflect.DeepEqual(*criteria, models.HostFilterCriteria{}) || reflect.DeepEqual(*criteria, models.HostFilterCriteria{PageCriteria: models.PageCriteria{Limit: 1000}}) {
 		return store.hostStore, nil
 	}
 
@@ -96,6 +96,14 @@ func (store *MockHostStore) Search(criteria *models.HostFilterCriteria, hostInfo
 				hosts = append(hosts, h)
 			}
 		}
+	} else if criteria.NameList != nil {
+		for _, h := range store.hostStore {
+			for _, name := range criteria.NameList {
+				if h.HostName == name {
+					hosts = append(hosts, h)
+				}
+			}
+		}
 	} else if criteria.NameContains != "" {
 		for _, h := range store.hostStore {
 			if strings.Contains(h.HostName, criteria.NameContains) {
@@ -108,6 +116,20 @@ func (store *MockHostStore) Search(criteria *models.HostFilterCriteria, hostInfo
 	return hosts, nil
 }
 
+// Count returns the number of hosts matching the criteria, the page of the criteria is not applied
+func (store *MockHostStore) Count(criteria *models.HostFilterCriteria) (int, error) {
+	var countCriteria models.HostFilterCriteria
+	if criteria != nil {
+		countCriteria = *criteria
+	}
+	countCriteria.PageCriteria = models.PageCriteria{}
+	hosts, err := store.Search(&countCriteria, nil)
+	if err != nil {
+		return 0, err
+	}
+	return len(hosts), nil
+}
+
 // AddFlavorgroups associate a Host with specified flavorgroups
 func (store *MockHostStore) AddFlavorgroups(hId uuid.UUID, fgIds []uuid.UUID) error {
 	for _, fgId := range fgIds {
@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// MockNotificationSubscriptionStore provides a mocked implementation of interface domain.NotificationSubscriptionStore
+type MockNotificationSubscriptionStore struct {
+	subscriptions []hvs.NotificationSubscription
+	deadLetters   []hvs.NotificationDeadLetter
+	lock          sync.Mutex
+}
+
+// Create inserts a NotificationSubscription
+func (store *MockNotificationSubscriptionStore) Create(ns *hvs.NotificationSubscription) (*hvs.NotificationSubscription, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if ns.ID == uuid.Nil {
+		ns.ID = uuid.New()
+	}
+	ns.CreatedAt = time.Now()
+	store.subscriptions = append(store.subscriptions, *ns)
+	return ns, nil
+}
+
+// Retrieve returns a NotificationSubscription
+func (store *MockNotificationSubscriptionStore) Retrieve(id uuid.UUID) (*hvs.NotificationSubscription, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	for _, ns := range store.subscriptions {
+		if ns.ID == id {
+			return &ns, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// Search returns the NotificationSubscriptions for the event type in the filter criteria
+func (store *MockNotificationSubscriptionStore) Search(criteria *models.NotificationSubscriptionFilterCriteria) ([]hvs.NotificationSubscription, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	subscriptions := []hvs.NotificationSubscription{}
+	for _, ns := range store.subscriptions {
+		if criteria == nil || criteria.EventType == "" {
+			subscriptions = append(subscriptions, ns)
+			continue
+		}
+		for _, eventType := range ns.EventTypes {
+			if eventType == criteria.EventType {
+				subscriptions = append(subscriptions, ns)
+				break
+			}
+		}
+	}
+	return subscriptions, nil
+}
+
+// Delete deletes a NotificationSubscription along with its dead letters
+func (store *MockNotificationSubscriptionStore) Delete(id uuid.UUID) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	for i, ns := range store.subscriptions {
+		if ns.ID == id {
+			store.subscriptions = append(store.subscriptions[:i], store.subscriptions[i+1:]...)
+			var deadLetters []hvs.NotificationDeadLetter
+			for _, dl := range store.deadLetters {
+				if dl.SubscriptionId != id {
+					deadLetters = append(deadLetters, dl)
+				}
+			}
+			store.deadLetters = deadLetters
+			return nil
+		}
+	}
+	return errors.New(commErr.RowsNotFound)
+}
+
+// CreateDeadLetter inserts a NotificationDeadLetter
+func (store *MockNotificationSubscriptionStore) CreateDeadLetter(dl *hvs.NotificationDeadLetter) (*hvs.NotificationDeadLetter, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	dl.ID = uuid.New()
+	dl.CreatedAt = time.Now()
+	store.deadLetters = append(store.deadLetters, *dl)
+	return dl, nil
+}
+
+// SearchDeadLetters returns the NotificationDeadLetters of a NotificationSubscription
+func (store *MockNotificationSubscriptionStore) SearchDeadLetters(subscriptionId uuid.UUID) ([]hvs.NotificationDeadLetter, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	deadLetters := []hvs.NotificationDeadLetter{}
+	for _, dl := range store.deadLetters {
+		if dl.SubscriptionId == subscriptionId {
+			deadLetters = append(deadLetters, dl)
+		}
+	}
+	return deadLetters, nil
+}
+
+// NewMockNotificationSubscriptionStore provides a subscription to the host trust change events with one dead letter
+func NewMockNotificationSubscriptionStore() *MockNotificationSubscriptionStore {
+	store := &MockNotificationSubscriptionStore{}
+
+	subscription, _ := store.Create(&hvs.NotificationSubscription{
+		ID:         uuid.MustParse("5a2b1c3d-7e8f-4a9b-8c1d-2e3f4a5b6c7d"),
+		Url:        "https://ihub.example.com:5443/events",
+		EventTypes: []hvs.NotificationEventType{hvs.NotificationEventHostTrustChanged},
+		Secret:     "f0e1d2c3b4a5968778695a4b3c2d1e0f",
+	})
+	trusted := false
+	previousTrusted := true
+	_, _ = store.CreateDeadLetter(&hvs.NotificationDeadLetter{
+		SubscriptionId: subscription.ID,
+		Event: hvs.NotificationEvent{
+			ID:              uuid.MustParse("6b3c2d4e-8f9a-4b1c-9d2e-3f4a5b6c7d8e"),
+			EventType:       hvs.NotificationEventHostTrustChanged,
+			HostId:          uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"),
+			CreatedAt:       time.Now(),
+			PreviousTrusted: &previousTrusted,
+			Trusted:         &trusted,
+		},
+		Attempts:  4,
+		LastError: "subscriber responded with status 503",
+	})
+	return store
+}
@@ -41,6 +41,14 @@ func (qs *qStore) Search(criteria *models.QueueFilterCriteria) ([]*models.Queue,
 	return nil, errors.New("No Records fouund")
 }
 
+func (qs *qStore) CountByState() (map[models.QueueState]int, error) {
+	counts := make(map[models.QueueState]int)
+	for _, v := range qs.m {
+		counts[v.State]++
+	}
+	return counts, nil
+}
+
 func (qs *qStore) Retrieve(uuid uuid.UUID) (*models.Queue, error) {
 	if _, ok := qs.m[uuid]; ok {
 		cp := qs.m[uuid]
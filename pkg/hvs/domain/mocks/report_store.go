@@ -8,10 +8,13 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	faultsConst "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
 	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
@@ -22,6 +25,10 @@ import (
 // MockReportStore provides a mocked implementation of interface postgres.ReportStore
 type MockReportStore struct {
 	reportStore map[uuid.UUID]models.HVSReport
+	// reports replaced by a newer report of the host
+	reportHistory []models.HVSReport
+	// ReportArchive holds the reports archived by PurgeHistory
+	ReportArchive []models.HVSReport
 }
 
 // Create inserts a HVSReport
@@ -37,7 +44,14 @@ func (store *MockReportStore) Create(report *models.HVSReport) (*models.HVSRepor
 	return report, nil
 }
 
+// Update replaces the current HVSReport of the host, the replaced report is moved to the report history
 func (store *MockReportStore) Update(report *models.HVSReport) (*models.HVSReport, error) {
+	for id, rs := range store.reportStore {
+		if rs.HostID == report.HostID && id != report.ID {
+			store.reportHistory = append(store.reportHistory, rs)
+			delete(store.reportStore, id)
+		}
+	}
 	store.reportStore[report.ID] = *report
 	return report, nil
 }
@@ -129,6 +143,20 @@ func (store *MockReportStore) Search(criteria *models.ReportFilterCriteria) ([]m
 	return reports, nil
 }
 
+// Count returns the number of reports matching the criteria, the page of the criteria is not applied
+func (store *MockReportStore) Count(criteria *models.ReportFilterCriteria) (int, error) {
+	var countCriteria models.ReportFilterCriteria
+	if criteria != nil {
+		countCriteria = *criteria
+	}
+	countCriteria.PageCriteria = models.PageCriteria{}
+	reports, err := store.Search(&countCriteria)
+	if err != nil {
+		return 0, err
+	}
+	return len(reports), nil
+}
+
 func (store *MockReportStore) FindHostIdsFromExpiredReports(fromTime time.Time, toTime time.Time) ([]uuid.UUID, error) {
 	hostIDs := []uuid.UUID{}
 
@@ -141,6 +169,97 @@ func (store *MockReportStore) FindHostIdsFromExpiredReports(fromTime time.Time,
 	return hostIDs, nil
 }
 
+// SearchReportTimes returns the creation and expiration times of the current HVSReports of the hosts
+func (store *MockReportStore) SearchReportTimes(hostIDs []uuid.UUID) ([]models.HVSReport, error) {
+	var reports []models.HVSReport
+	for _, r := range store.reportStore {
+		for _, hostID := range hostIDs {
+			if r.HostID == hostID {
+				reports = append(reports, models.HVSReport{ID: r.ID, HostID: r.HostID, CreatedAt: r.CreatedAt, Expiration: r.Expiration})
+			}
+		}
+	}
+	return reports, nil
+}
+
+// RetrieveFromHistory returns the current or a replaced HVSReport
+func (store *MockReportStore) RetrieveFromHistory(id uuid.UUID) (*models.HVSReport, error) {
+	if rs, err := store.Retrieve(id); err == nil {
+		return rs, nil
+	}
+	for _, rs := range store.reportHistory {
+		if rs.ID == id {
+			return &rs, nil
+		}
+	}
+	return nil, errors.New(commErr.RowsNotFound)
+}
+
+// RetrievePrevious returns the HVSReport of the host created before the given report
+func (store *MockReportStore) RetrievePrevious(report *models.HVSReport) (*models.HVSReport, error) {
+	var previous *models.HVSReport
+	for i, rs := range store.reportHistory {
+		if rs.HostID == report.HostID && rs.ID != report.ID && rs.CreatedAt.Before(report.CreatedAt) &&
+			(previous == nil || rs.CreatedAt.After(previous.CreatedAt)) {
+			previous = &store.reportHistory[i]
+		}
+	}
+	if previous == nil {
+		return nil, errors.New(commErr.RowsNotFound)
+	}
+	return previous, nil
+}
+
+// PurgeHistory removes the oldest purgeable reports of the report history, the reports of a host are ranked from
+// the newest including the current report
+func (store *MockReportStore) PurgeHistory(criteria *models.ReportPurgeCriteria) (int, error) {
+	if criteria == nil || criteria.BatchSize <= 0 {
+		return 0, errors.New("A positive batch size must be specified")
+	}
+	reports := append([]models.HVSReport{}, store.reportHistory...)
+	for _, rs := range store.reportStore {
+		reports = append(reports, rs)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].CreatedAt.After(reports[j].CreatedAt)
+	})
+
+	hostRanks := map[uuid.UUID]int{}
+	trustRanks := map[uuid.UUID]map[bool]int{}
+	purgeable := map[uuid.UUID]bool{}
+	for _, rs := range reports {
+		hostRanks[rs.HostID]++
+		if trustRanks[rs.HostID] == nil {
+			trustRanks[rs.HostID] = map[bool]int{}
+		}
+		trustRanks[rs.HostID][rs.TrustReport.Trusted]++
+		_, current := store.reportStore[rs.ID]
+		if !current && rs.CreatedAt.Before(criteria.CreatedBefore) && hostRanks[rs.HostID] > criteria.KeepLatestPerHost &&
+			trustRanks[rs.HostID][rs.TrustReport.Trusted] > 1 {
+			purgeable[rs.ID] = true
+		}
+	}
+
+	// the oldest reports are purged first
+	sort.Slice(store.reportHistory, func(i, j int) bool {
+		return store.reportHistory[i].CreatedAt.Before(store.reportHistory[j].CreatedAt)
+	})
+	var history []models.HVSReport
+	purged := 0
+	for _, rs := range store.reportHistory {
+		if purgeable[rs.ID] && purged < criteria.BatchSize {
+			if criteria.Archive {
+				store.ReportArchive = append(store.ReportArchive, rs)
+			}
+			purged++
+			continue
+		}
+		history = append(history, rs)
+	}
+	store.reportHistory = history
+	return purged, nil
+}
+
 // NewMockReportStore provides two dummy data for Reports
 func NewMockReportStore() *MockReportStore {
 	//TODO add more data
@@ -153,6 +272,13 @@ func NewMockReportStore() *MockReportStore {
 	if err != nil {
 		defaultLog.WithError(err).Errorf("Error unmarshalling trust report")
 	}
+	// the host manifest of the resource does not unmarshal, the rule results are read on their own
+	var ruleResults struct {
+		Results []hvs.RuleResult `json:"results"`
+	}
+	if err = json.Unmarshal(trustReportBytes, &ruleResults); err == nil {
+		trustReport.Results = ruleResults.Results
+	}
 	created, _ := time.Parse(constants.ParamDateTimeFormat, "2020-06-21 07:18:00.57")
 	expiration, _ := time.Parse(constants.ParamDateTimeFormat, "2020-06-22 07:18:00.57")
 	_, err = store.Create(&models.HVSReport{
@@ -167,6 +293,29 @@ func NewMockReportStore() *MockReportStore {
 		defaultLog.WithError(err).Errorf("Error creating Trust Report")
 	}
 
+	// the previous report of the first host was untrusted because of the AIK certificate
+	previousTrustReport := trustReport
+	previousTrustReport.Results = make([]hvs.RuleResult, len(trustReport.Results))
+	copy(previousTrustReport.Results, trustReport.Results)
+	for i, result := range previousTrustReport.Results {
+		if strings.HasSuffix(result.Rule.Name, faultsConst.RuleAikCertificateTrusted) {
+			previousTrustReport.Results[i].Trusted = false
+			previousTrustReport.Results[i].Faults = []hvs.Fault{{
+				Name:        faultsConst.FaultAikCertificateNotTrusted,
+				Description: "AIK certificate is not signed by any of the trusted Privacy CAs",
+			}}
+			break
+		}
+	}
+	previousTrustReport.Trusted = false
+	store.reportHistory = append(store.reportHistory, models.HVSReport{
+		ID:          uuid.MustParse("15701f03-7b1d-49f9-ac62-6b9b0728bdb2"),
+		HostID:      uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"),
+		CreatedAt:   created.Add(-24 * time.Hour),
+		Expiration:  created,
+		TrustReport: previousTrustReport,
+	})
+
 	_, err = store.Create(&models.HVSReport{
 		ID:          uuid.MustParse("15701f03-7b1d-49f9-ac62-6b9b0728bdb4"),
 		HostID:      uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d"),
@@ -28,7 +28,7 @@ var tcMap = map[string]string{
 	"00b61da0-5ada-e811-906e-00163566263e": `{"id":"00b61da0-5ada-e811-906e-00163566263e","certificate":"MIIEPzCCAqegAwIBAgIQMvsf7QVxA6d0zhxOSC9kUDANBgkqhkiG9w0BAQwFADAxMS8wLQYDVQQDDCYTJDU2ZGZmZTZmLTU3ZjgtNGY0Yy05Yzk4LTdjNmVmOWNjMGM4YzAeFw0yMDA3MDUwNzI1NTFaFw0yMTA3MDUwNzI1NTFaMDExLzAtBgNVBAMMJhMkNTZkZmZlNmYtNTdmOC00ZjRjLTljOTgtN2M2ZWY5Y2MwYzhjMIIBojANBgkqhkiG9w0BAQEFAAOCAY8AMIIBigKCAYEAp7SFWXQkhnxPOAUoQtPzY2wfgvH8HUnM2A0iN8WtQomnfd+Hzh/qwuWR4dpHMICtV5kMUrXWlJ6haOKa+vBmCqKVTHCxbagZAzjkmGwrCRlVbfwU2I5IvLF7PLSSUsg+PE3RlF0Jh7O2cpfYLAIwnAV26CPqt9rl1wfv/12ezMlqXmBFBo7zP2wqWSujuNZINxqjUVmfrbqFiSaIAHdXytcD87orY2MDpuODsWgAF+HBy2x8gindJQA8D5+YAvD2MCTVf3EAKUwBBmr53CjCnxODR/5yO1DW9yr12L/qNyyCu44pNVtt1lvteD+aZElBRR7TQG1KNpwpghvxKpzdflqCdCGtxCFzVA+OW/w0lgC1ig1fIpsu1H6XESP/bHnprO1/9rn3KXgbztUJ26HBYlvyeBAdWBzzxTLZPJ/nkfGtyP9Jrm/aUvS3FontUgrdF9c36DJEJ9Y0Ww206YgCNWAiJfxiduY0QaGgKS/8F25uAKMKs0mk9WnqueC2TGJfAgMBAAGjUzBRMCQGBVUEhhUBAQH/BBgwFhMITG9jYXRpb24TClNhbnRhQ2xhcmEwKQYFVQSGFQEBAf8EHTAbEwdDb21wYW55ExBJbnRlbENvcnBvcmF0aW9uMA0GCSqGSIb3DQEBDAUAA4IBgQAh6oGgiZ8Pt6A87U5j8v4IO8adNtqy1muouHiCrmnSeICGllM4HK76pla+JPD6hprW8zSyNGzzPR0+zZ9gAqnrNhukUdOsR41i3HpUINIqN21VcTVxoFhOthfVMQBeSjHWBx2Ypi6XJ1vAbbqvVuxntHQ2uUwtTu60quSLO5poomoWjHG1/53/yIIl3TgDnB9qH1uKWYtiDVStAlJT8OjS4fWHaUSarJSSIJFjyQuCFNU9RG61leryX61K9NsNsKySFiwep53g4QYHb7X7DuSJrbHUED9/Xfe8t2lrlOCDPZ+GZh6HfU+ypI6h8pVPDU7pyHrGBOeGdtSSHXE1qgOG4v9KoBTTd1s50kOYXleDd9SSO8JAm7GtUQTy448ciZ2WyahqN8ZpQhwO4ZXRAlacZUxU6y8wmdr/a7CAzQrQUlRBki/Crnm6PM1qXSTEJ1s9OUE5uudmUN4nnWo0ru1UJCbjzcaSKmNSzg4JUZqlIZmY8cViuHAve5P6doU4y6Y=","subject":"00ecd3ab-9af4-e711-906e-001560a04062","issuer":"CN=asset-tag-service","not_before":"` + time.Now().Add(-TimeDuration30Mins).Format(time.RFC3339) + `","not_after":"` + time.Now().AddDate(1, 0, 0).Format(time.RFC3339) + `","hardware_uuid":"00b61da0-5ada-e811-906e-00163566263e"}`,
 }
 
-var tcCols = []string{"id", "hardware_uuid", "certificate", "subject", "issuer", "notbefore", "notafter"}
+var tcCols = []string{"id", "hardware_uuid", "certificate", "subject", "issuer", "notbefore", "notafter", "rowid"}
 
 // MockTagCertificateStore provides a mocked implementation of interface hvs.TagCertificateStore
 type MockTagCertificateStore struct {
@@ -68,7 +68,7 @@ func (store *MockTagCertificateStore) Retrieve(id uuid.UUID) (*hvs.TagCertificat
 		store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE  \("tag_certificate"."id"" = \$1\)`).
 			WithArgs(k).
 			WillReturnRows(sqlmock.NewRows(tcCols).
-				AddRow(tc.ID.String(), tc.HardwareUUID.String(), string(tc.Certificate), tc.Subject, tc.Issuer, tc.NotBefore, tc.NotAfter))
+				AddRow(tc.ID.String(), tc.HardwareUUID.String(), string(tc.Certificate), tc.Subject, tc.Issuer, tc.NotBefore, tc.NotAfter, 1))
 	}
 
 	// Mock error in retrieve
@@ -83,17 +83,17 @@ func (store *MockTagCertificateStore) Retrieve(id uuid.UUID) (*hvs.TagCertificat
 	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \("tag_certificate"."id" = \$1\)`).
 		WithArgs("cf197a51-8362-465f-9ec1-d88ad0023a27").
 		WillReturnRows(sqlmock.NewRows(tcCols).
-			AddRow(rtc.ID.String(), rtc.HardwareUUID.String(), string(rtc.Certificate), rtc.Subject, rtc.Issuer, rtc.NotBefore, rtc.NotAfter))
+			AddRow(rtc.ID.String(), rtc.HardwareUUID.String(), string(rtc.Certificate), rtc.Subject, rtc.Issuer, rtc.NotBefore, rtc.NotAfter, 1))
 
 	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \("tag_certificate"."id" = \$1\)`).
 		WithArgs("fda6105d-a340-42da-bc35-0555e7a5e360").
 		WillReturnRows(sqlmock.NewRows(tcCols).
-			AddRow("fda6105d-a340-42da-bc35-0555e7a5e360", rtc.HardwareUUID.String(), string(rtc.Certificate), rtc.Subject, rtc.Issuer, rtc.NotBefore, rtc.NotAfter))
+			AddRow("fda6105d-a340-42da-bc35-0555e7a5e360", rtc.HardwareUUID.String(), string(rtc.Certificate), rtc.Subject, rtc.Issuer, rtc.NotBefore, rtc.NotAfter, 1))
 
 	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \("tag_certificate"."id" = \$1\)`).
 		WithArgs("7ce60664-faa3-4c2e-8c45-41e209e4f1db").
 		WillReturnRows(sqlmock.NewRows(tcCols).
-			AddRow("7ce60664-faa3-4c2e-8c45-41e209e4f1db", "00e4d709-8d72-44c3-89ae-c5edc395d6fe", string(rtc.Certificate), rtc.Subject, rtc.Issuer, rtc.NotBefore, rtc.NotAfter))
+			AddRow("7ce60664-faa3-4c2e-8c45-41e209e4f1db", "00e4d709-8d72-44c3-89ae-c5edc395d6fe", string(rtc.Certificate), rtc.Subject, rtc.Issuer, rtc.NotBefore, rtc.NotAfter, 1))
 
 	return store.TagCertificateStore.Retrieve(id)
 }
@@ -136,9 +136,9 @@ func (store *MockTagCertificateStore) Search(criteria *models.TagCertificateFilt
 	for _, v := range tcMap {
 		var tc hvs.TagCertificate
 		_ = json.Unmarshal([]byte(v), &tc)
-		allRows.AddRow(tc.ID.String(), tc.HardwareUUID.String(), string(tc.Certificate), tc.Subject, tc.Issuer, tc.NotBefore, tc.NotAfter)
+		allRows.AddRow(tc.ID.String(), tc.HardwareUUID.String(), string(tc.Certificate), tc.Subject, tc.Issuer, tc.NotBefore, tc.NotAfter, 1)
 	}
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"   ORDER BY "subject"`).WillReturnRows(allRows)
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"   ORDER BY tag_certificate.subject`).WillReturnRows(allRows)
 
 	// search by id
 	for k, v := range tcMap {
@@ -147,7 +147,7 @@ func (store *MockTagCertificateStore) Search(criteria *models.TagCertificateFilt
 		store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(id = \$1\)`).
 			WithArgs(k).
 			WillReturnRows(sqlmock.NewRows(tcCols).
-				AddRow(tc.ID.String(), tc.HardwareUUID.String(), string(tc.Certificate), tc.Subject, tc.Issuer, tc.NotBefore, tc.NotAfter))
+				AddRow(tc.ID.String(), tc.HardwareUUID.String(), string(tc.Certificate), tc.Subject, tc.Issuer, tc.NotBefore, tc.NotAfter, 1))
 	}
 
 	// search by non-existent id
@@ -162,12 +162,12 @@ func (store *MockTagCertificateStore) Search(criteria *models.TagCertificateFilt
 	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(hardware_uuid = \$1\)`).
 		WithArgs("80ecce40-04b8-e811-906e-00163566263e").
 		WillReturnRows(sqlmock.NewRows(tcCols).
-			AddRow(tcHWUUID.ID.String(), tcHWUUID.HardwareUUID.String(), string(tcHWUUID.Certificate), tcHWUUID.Subject, tcHWUUID.Issuer, tcHWUUID.NotBefore, tcHWUUID.NotAfter))
+			AddRow(tcHWUUID.ID.String(), tcHWUUID.HardwareUUID.String(), string(tcHWUUID.Certificate), tcHWUUID.Subject, tcHWUUID.Issuer, tcHWUUID.NotBefore, tcHWUUID.NotAfter, 1))
 
 	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(hardware_uuid = \$1\)`).
 		WithArgs("00b61da0-5ada-e811-906e-00163566263e").
 		WillReturnRows(sqlmock.NewRows(tcCols).
-			AddRow(tcHWUUID.ID.String(), tcHWUUID.HardwareUUID.String(), string(tcHWUUID.Certificate), tcHWUUID.Subject, tcHWUUID.Issuer, tcHWUUID.NotBefore, tcHWUUID.NotAfter))
+			AddRow(tcHWUUID.ID.String(), tcHWUUID.HardwareUUID.String(), string(tcHWUUID.Certificate), tcHWUUID.Subject, tcHWUUID.Issuer, tcHWUUID.NotBefore, tcHWUUID.NotAfter, 1))
 
 	// search by non-existent id
 	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(hardware_uuid = \$1\)`).
@@ -183,75 +183,90 @@ func (store *MockTagCertificateStore) Search(criteria *models.TagCertificateFilt
 	for _, v := range tcm {
 		var tc hvs.TagCertificate
 		_ = json.Unmarshal([]byte(v), &tc)
-		subjectEqualToRows.AddRow(tc.ID.String(), tc.HardwareUUID.String(), string(tc.Certificate), tc.Subject, tc.Issuer, tc.NotBefore, tc.NotAfter)
+		subjectEqualToRows.AddRow(tc.ID.String(), tc.HardwareUUID.String(), string(tc.Certificate), tc.Subject, tc.Issuer, tc.NotBefore, tc.NotAfter, 1)
 	}
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) = \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) = \$1\) ORDER BY tag_certificate.subject`).
 		WithArgs("00ecd3ab-9af4-e711-906e-001560a04062").
 		WillReturnRows(subjectEqualToRows)
 
 	// Search by subjectEqualTo which does not exists
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) = \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) = \$1\) ORDER BY tag_certificate.subject`).
 		WithArgs("afc82547-0691-4be1-8b14-bcebfce86fd6").
 		WillReturnRows(sqlmock.NewRows(tcCols))
 
 	// SubjectContains filter - which exists
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) like \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) like \$1\) ORDER BY tag_certificate.subject`).
 		WithArgs("%001560a04062%").
 		WillReturnRows(subjectEqualToRows)
 
 	// SubjectContains filter - which does not exists
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) like \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(subject\) like \$1\) ORDER BY tag_certificate.subject`).
 		WithArgs("%7a466a5beff9%").
 		WillReturnRows(sqlmock.NewRows(tcCols))
 
 	// IssuerEqualTo filter - which exists
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) = \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) = \$1\) ORDER BY tag_certificate.subject`).
 		WithArgs("cn=asset-tag-service").
 		WillReturnRows(allRows)
 
 	// IssuerEqualTo filter - which does not exist
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) = \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) = \$1\) ORDER BY tag_certificate.subject`).
 		WithArgs("cn=nonexistent-tag-service").
 		WillReturnRows(sqlmock.NewRows(tcCols))
 
 	// IssuerContains filter - which exists
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) like \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) like \$1\) ORDER BY tag_certificate.subject`).
 		WithArgs("%asset-tag%").
 		WillReturnRows(allRows)
 
 	// IssuerContains filter - which does not exist
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) like \$1\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(lower\(issuer\) like \$1\) ORDER BY tag_certificate.subject`).
 		WithArgs("%nonexistent-tag-service%").
 		WillReturnRows(sqlmock.NewRows(tcCols))
 
 	// ValidOn - with a valid value
 	var tcValidOn1 hvs.TagCertificate
 	_ = json.Unmarshal([]byte(tcMap["7ce60664-faa3-4c2e-8c45-41e209e4f1db"]), &tcValidOn1)
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(notbefore AS TIMESTAMP\) <= CAST\(\$1 AS TIMESTAMP\) AND CAST\(\$2 AS TIMESTAMP\) <= CAST\(notafter AS TIMESTAMP\)\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(notbefore AS TIMESTAMP\) <= CAST\(\$1 AS TIMESTAMP\) AND CAST\(\$2 AS TIMESTAMP\) <= CAST\(notafter AS TIMESTAMP\)\) ORDER BY tag_certificate.subject`).
 		WithArgs("2016-09-28T09:08:33.913Z", "2016-09-28T09:08:33.913Z").
 		WillReturnRows(sqlmock.NewRows(tcCols).
-			AddRow(tcValidOn1.ID.String(), tcValidOn1.HardwareUUID.String(), string(tcValidOn1.Certificate), tcValidOn1.Subject, tcValidOn1.Issuer, tcValidOn1.NotBefore, tcValidOn1.NotAfter))
+			AddRow(tcValidOn1.ID.String(), tcValidOn1.HardwareUUID.String(), string(tcValidOn1.Certificate), tcValidOn1.Subject, tcValidOn1.Issuer, tcValidOn1.NotBefore, tcValidOn1.NotAfter, 1))
 
 	// ValidBefore - with a valid value
 	var tcValidOn2 hvs.TagCertificate
 	_ = json.Unmarshal([]byte(tcMap["7ce60664-faa3-4c2e-8c45-41e209e4f1db"]), &tcValidOn2)
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(\$1 as timestamp\) >= notbefore\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(\$1 as timestamp\) >= notbefore\) ORDER BY tag_certificate.subject`).
 		WithArgs("2016-09-28T09:08:33.913Z").
 		WillReturnRows(sqlmock.NewRows(tcCols).
-			AddRow(tcValidOn2.ID.String(), tcValidOn2.HardwareUUID.String(), string(tcValidOn2.Certificate), tcValidOn2.Subject, tcValidOn2.Issuer, tcValidOn2.NotBefore, tcValidOn2.NotAfter))
+			AddRow(tcValidOn2.ID.String(), tcValidOn2.HardwareUUID.String(), string(tcValidOn2.Certificate), tcValidOn2.Subject, tcValidOn2.Issuer, tcValidOn2.NotBefore, tcValidOn2.NotAfter, 1))
 
 	// ValidAfter - with a valid value
 	var tcValidOn3 hvs.TagCertificate
 	_ = json.Unmarshal([]byte(tcMap["7ce60664-faa3-4c2e-8c45-41e209e4f1db"]), &tcValidOn3)
-	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(\$1 as timestamp\) <= notafter\) ORDER BY "subject"`).
+	store.Mock.ExpectQuery(`SELECT \* FROM "tag_certificate"  WHERE \(CAST\(\$1 as timestamp\) <= notafter\) ORDER BY tag_certificate.subject`).
 		WithArgs("2040-09-28T09:08:33.913Z").
 		WillReturnRows(sqlmock.NewRows(tcCols).
-			AddRow(tcValidOn3.ID.String(), tcValidOn3.HardwareUUID.String(), string(tcValidOn3.Certificate), tcValidOn3.Subject, tcValidOn3.Issuer, tcValidOn3.NotBefore, tcValidOn3.NotAfter))
+			AddRow(tcValidOn3.ID.String(), tcValidOn3.HardwareUUID.String(), string(tcValidOn3.Certificate), tcValidOn3.Subject, tcValidOn3.Issuer, tcValidOn3.NotBefore, tcValidOn3.NotAfter, 1))
 
 	// call the real store
 	return store.TagCertificateStore.Search(criteria)
 }
 
+// Count returns the number of TagCertificates matching the criteria, the page of the criteria is not applied
+func (store *MockTagCertificateStore) Count(criteria *models.TagCertificateFilterCriteria) (int, error) {
+	var countCriteria models.TagCertificateFilterCriteria
+	if criteria != nil {
+		countCriteria = *criteria
+	}
+	// the mocked queries are paged, only the position and the order of the page are cleared
+	countCriteria.PageCriteria = models.PageCriteria{Limit: countCriteria.Limit}
+	tagCertificates, err := store.Search(&countCriteria)
+	if err != nil {
+		return 0, err
+	}
+	return len(tagCertificates), nil
+}
+
 // NewMockTagCertificateStore initializes the mock datastore
 func NewMockTagCertificateStore() *MockTagCertificateStore {
 	datastore, mock := postgres.NewSQLMockDataStore()
@@ -116,6 +116,20 @@ func (m *MockTpmEndorsementStore) Search(teFilter *models.TpmEndorsementFilterCr
 	return &hvs.TpmEndorsementCollection{TpmEndorsement: tpmEndorsements}, nil
 }
 
+// Count returns the number of TpmEndorsements matching the criteria, the page of the criteria is not applied
+func (m *MockTpmEndorsementStore) Count(teFilter *models.TpmEndorsementFilterCriteria) (int, error) {
+	var countCriteria models.TpmEndorsementFilterCriteria
+	if teFilter != nil {
+		countCriteria = *teFilter
+	}
+	countCriteria.PageCriteria = models.PageCriteria{}
+	tpmEndorsements, err := m.Search(&countCriteria)
+	if err != nil {
+		return 0, err
+	}
+	return len(tpmEndorsements.TpmEndorsement), nil
+}
+
 // Delete mocks base method
 func (m *MockTpmEndorsementStore) Delete(id uuid.UUID) error {
 	for i, te := range m.tpmEndorsementStores {
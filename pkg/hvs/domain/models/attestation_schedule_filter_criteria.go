@@ -0,0 +1,12 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import "github.com/google/uuid"
+
+type AttestationScheduleFilterCriteria struct {
+	FlavorgroupId uuid.UUID
+}
@@ -0,0 +1,21 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLogEntryFilterCriteria struct {
+	EntityID   uuid.UUID
+	EntityType string
+	Action     string
+	FromDate   time.Time
+	ToDate     time.Time
+	PageCriteria
+}
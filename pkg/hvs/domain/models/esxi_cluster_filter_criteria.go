@@ -10,6 +10,5 @@ import "github.com/google/uuid"
 type ESXiClusterFilterCriteria struct {
 	Id          uuid.UUID
 	ClusterName string
-	Limit       int
-	AfterId     int
+	PageCriteria
 }
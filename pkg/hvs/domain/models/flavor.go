@@ -28,8 +28,7 @@ type FlavorFilterCriteria struct {
 	Value         string
 	FlavorgroupID uuid.UUID
 	FlavorParts   []hvs.FlavorPartName
-	Limit         int
-	AfterId       int
+	PageCriteria
 }
 
 type FlavorVerificationFC struct {
@@ -11,6 +11,5 @@ type FlavorGroupFilterCriteria struct {
 	FlavorId     *uuid.UUID
 	NameEqualTo  string
 	NameContains string
-	Limit        int
-	AfterId      int
+	PageCriteria
 }
@@ -12,4 +12,5 @@ type FlavorTemplateFilterCriteria struct {
 	ConditionContains  string
 	FlavorPartContains string
 	IncludeDeleted     bool
+	PageCriteria
 }
@@ -0,0 +1,10 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+type HostQuarantineFilterCriteria struct {
+	Quarantined *bool
+}
@@ -0,0 +1,18 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+)
+
+// HostRegistration has the records created when a host is registered
+type HostRegistration struct {
+	Host           *hvs.Host
+	Credential     *HostCredential
+	FlavorgroupIds []uuid.UUID
+}
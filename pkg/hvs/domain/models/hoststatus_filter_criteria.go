@@ -20,6 +20,5 @@ type HostStatusFilterCriteria struct {
 	ToDate         time.Time
 	LatestPerHost  bool
 	NumberOfDays   int
-	Limit          int
-	AfterId        int
+	PageCriteria
 }
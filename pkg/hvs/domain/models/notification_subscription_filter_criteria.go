@@ -0,0 +1,12 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import "github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+
+type NotificationSubscriptionFilterCriteria struct {
+	EventType hvs.NotificationEventType
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PageCriteria holds the paging parameters common to all the Search criteria. The records are sorted by SortBy,
+// ties are broken by the rowid, and AfterId is the rowid of the last record of the previous page. The records are
+// only counted when IncludeTotal is set.
+type PageCriteria struct {
+	Limit        int       `json:"-"`
+	AfterId      int       `json:"-"`
+	SortBy       string    `json:"-"`
+	OrderBy      OrderType `json:"-"`
+	IncludeTotal bool      `json:"-"`
+}
+
+// Sort fields accepted by the Search APIs, the names are the columns of the respective tables
+var (
+	AuditLogEntrySortFields  = []string{"created", "entity_type", "action"}
+	ESXiClusterSortFields    = []string{"cluster_name"}
+	FlavorSortFields         = []string{"created_at", "label", "flavor_part"}
+	FlavorGroupSortFields    = []string{"name"}
+	HostSortFields           = []string{"name"}
+	HostStatusSortFields     = []string{"created"}
+	ReportSortFields         = []string{"created"}
+	TagCertificateSortFields = []string{"subject", "issuer", "notbefore", "notafter"}
+	TpmEndorsementSortFields = []string{"issuer", "hardware_uuid"}
+)
+
+type OrderType string
+
+const (
+	Ascending  OrderType = "asc"
+	Descending           = "desc"
+)
+
+func (ot OrderType) String() string {
+	orderTypes := [...]string{"asc", "desc"}
+
+	x := string(ot)
+	for _, v := range orderTypes {
+		if v == x {
+			return x
+		}
+	}
+
+	return "asc"
+}
+
+func GetOrderType(oType string) (OrderType, error) {
+	switch oType {
+	case "asc":
+		return Ascending, nil
+	case "desc":
+		return Descending, nil
+	default:
+		return "", errors.New("Invalid order type")
+	}
+}
+
+// pageCursor is the content of the opaque cursor returned in the next page link
+type pageCursor struct {
+	AfterId int       `json:"after_id"`
+	SortBy  string    `json:"sort_by,omitempty"`
+	OrderBy OrderType `json:"order_by,omitempty"`
+}
+
+// Sorted tells if the records are requested in an order other than the default ascending rowid order
+func (pc PageCriteria) Sorted() bool {
+	return pc.SortBy != "" || pc.OrderBy == Descending
+}
+
+// Cursor returns the opaque cursor of the page starting after the record with the given rowid
+func (pc PageCriteria) Cursor(afterId int) string {
+	cursor, _ := json.Marshal(pageCursor{AfterId: afterId, SortBy: pc.SortBy, OrderBy: pc.OrderBy})
+	return base64.RawURLEncoding.EncodeToString(cursor)
+}
+
+// ParseCursor sets AfterId, SortBy and OrderBy from a cursor returned by Cursor
+func (pc *PageCriteria) ParseCursor(cursor string) error {
+	cursorBytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return errors.Wrap(err, "Invalid cursor")
+	}
+	var pageCursor pageCursor
+	if err = json.Unmarshal(cursorBytes, &pageCursor); err != nil {
+		return errors.Wrap(err, "Invalid cursor")
+	}
+	if pageCursor.AfterId < 0 {
+		return errors.New("Invalid cursor")
+	}
+	if pageCursor.OrderBy != "" {
+		if _, err = GetOrderType(string(pageCursor.OrderBy)); err != nil {
+			return errors.New("Invalid cursor")
+		}
+	}
+	pc.AfterId = pageCursor.AfterId
+	pc.SortBy = pageCursor.SortBy
+	pc.OrderBy = pageCursor.OrderBy
+	return nil
+}
+
+// ValidateSortBy checks that SortBy is empty or one of the given sort fields
+func (pc PageCriteria) ValidateSortBy(sortFields []string) error {
+	if pc.SortBy == "" {
+		return nil
+	}
+	for _, sortField := range sortFields {
+		if pc.SortBy == sortField {
+			return nil
+		}
+	}
+	return errors.New("Invalid sortBy value, must be one of " + strings.Join(sortFields, ", "))
+}
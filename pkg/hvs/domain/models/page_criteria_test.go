@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestPageCriteriaCursor(t *testing.T) {
+	encode := func(cursor string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+	}
+
+	tests := []struct {
+		name    string
+		cursor  string
+		want    PageCriteria
+		wantErr bool
+	}{
+		{
+			name:   "round trip",
+			cursor: PageCriteria{SortBy: "name", OrderBy: Descending, Limit: 10}.Cursor(42),
+			want:   PageCriteria{AfterId: 42, SortBy: "name", OrderBy: Descending},
+		},
+		{
+			name:   "round trip without order",
+			cursor: PageCriteria{SortBy: "created"}.Cursor(7),
+			want:   PageCriteria{AfterId: 7, SortBy: "created"},
+		},
+		{
+			name:    "not base64",
+			cursor:  "not a cursor!",
+			wantErr: true,
+		},
+		{
+			name:    "padded base64",
+			cursor:  base64.URLEncoding.EncodeToString([]byte(`{"after_id":1}`)),
+			wantErr: true,
+		},
+		{
+			name:    "not JSON",
+			cursor:  encode("after_id=1"),
+			wantErr: true,
+		},
+		{
+			name:    "after id of the wrong type",
+			cursor:  encode(`{"after_id":"1"}`),
+			wantErr: true,
+		},
+		{
+			name:    "tampered negative after id",
+			cursor:  encode(`{"after_id":-1,"sort_by":"name"}`),
+			wantErr: true,
+		},
+		{
+			name:    "tampered order",
+			cursor:  encode(`{"after_id":1,"sort_by":"name","order_by":"desc; DROP TABLE host"}`),
+			wantErr: true,
+		},
+		{
+			// the sort field is validated against the sort fields of the search, see ValidateSortBy
+			name:   "tampered sort field",
+			cursor: encode(`{"after_id":1,"sort_by":"name; DROP TABLE host"}`),
+			want:   PageCriteria{AfterId: 1, SortBy: "name; DROP TABLE host"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := PageCriteria{Limit: 10, IncludeTotal: true}
+			err := page.ParseCursor(tt.cursor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PageCriteria.ParseCursor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if page != (PageCriteria{Limit: 10, IncludeTotal: true}) {
+					t.Errorf("PageCriteria.ParseCursor() changed the page to %+v on error", page)
+				}
+				return
+			}
+			// the limit and the total are not part of the cursor
+			tt.want.Limit, tt.want.IncludeTotal = 10, true
+			if page != tt.want {
+				t.Errorf("PageCriteria.ParseCursor() page = %+v, want %+v", page, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageCriteriaValidateSortBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		sortBy  string
+		wantErr bool
+	}{
+		{name: "no sort field", sortBy: ""},
+		{name: "accepted sort field", sortBy: "name"},
+		{name: "unknown sort field", sortBy: "description", wantErr: true},
+		{name: "injected sort field", sortBy: "name; DROP TABLE host", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PageCriteria{SortBy: tt.sortBy}.ValidateSortBy(HostSortFields)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PageCriteria.ValidateSortBy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
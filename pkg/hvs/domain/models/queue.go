@@ -68,6 +68,19 @@ func (s QueueState) Valid() bool {
 	return s >= QueueStateNew && s <= QueueStateError
 }
 
+func (s QueueState) String() string {
+	if !s.Valid() {
+		return "Unknown"
+	}
+	return qstatusToString[s]
+}
+
+// GetQueueStates returns all the valid queue states
+func GetQueueStates() []QueueState {
+	return []QueueState{QueueStateNew, QueueStatePending, QueueStateCompleted, QueueStateReturned, QueueStateTimeout,
+		QueueStateConnectionFailure, QueueStateError}
+}
+
 // MarshalJSON marshals the enum as a quoted json string
 func (s QueueState) MarshalJSON() ([]byte, error) {
 	buffer := bytes.NewBufferString(`"`)
@@ -20,8 +20,7 @@ type ReportFilterCriteria struct {
 	FromDate       time.Time
 	ToDate         time.Time
 	LatestPerHost  bool
-	Limit          int
-	AfterId        int
+	PageCriteria
 }
 
 type ReportLocator struct {
@@ -0,0 +1,19 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package models
+
+import "time"
+
+// ReportPurgeCriteria selects the reports of the report history that are purged in a single batch. The current
+// report of a host, its KeepLatestPerHost most recent reports and its most recent trusted and untrusted reports
+// are never purged, the reports of a deleted host are purged once they are older than CreatedBefore.
+type ReportPurgeCriteria struct {
+	CreatedBefore     time.Time
+	KeepLatestPerHost int
+	BatchSize         int
+	// Archive moves the purged reports to the report archive instead of deleting them
+	Archive bool
+}
@@ -23,6 +23,7 @@ type TagCertificateFilterCriteria struct {
 	ValidAfter      time.Time `json:"validAfter"`
 	// swagger:strfmt uuid
 	HardwareUUID uuid.UUID `json:"hardwareUuid"`
+	PageCriteria
 }
 
 // TagCertificateCreateCriteria holds the data used to create a TagCertificate
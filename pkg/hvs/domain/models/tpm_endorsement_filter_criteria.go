@@ -16,6 +16,5 @@ type TpmEndorsementFilterCriteria struct {
 	CommentEqualTo           string
 	CommentContains          string
 	CertificateDigestEqualTo string
-	Limit                    int
-	AfterId                  int
+	PageCriteria
 }
@@ -24,6 +24,15 @@ Available Commands:
 	config-db-rotation     Configure database table rotaition for audit log table, reference db_rotation.sql in documents
 	uninstall [--purge]    Uninstall hvs
 		--purge            all configuration and data files will be removed if this flag is set
+	verify-offline         Verify a saved host manifest against signed flavors without database
+		--manifest <file>                       the host manifest JSON, or a host status with the manifest
+		--flavor <file>                         the signed flavor JSON, a single flavor, an array or a collection, can be repeated
+		--privacy-ca <file>                     the privacy CA certificate, defaults to the HVS privacy CA
+		--tag-ca <file>                         the asset tag CA certificate, defaults to the HVS tag CA
+		--flavor-signing-cert <file>            the flavor signing certificate, defaults to the HVS flavor signing certificate
+		--flavor-ca <file|dir>                  the CA certificates of the flavor signing certificate, defaults to the HVS root CAs
+		--skip-flavor-signature-verification    do not verify the flavor signatures
+		--output json|table                     the format of the trust report, defaults to table
 
 Usage of hvs setup:
 	hvs setup <task> [--help] [--force] [-f <answer-file>]
@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type AttestationScheduleStore struct {
+	Store *DataStore
+}
+
+func NewAttestationScheduleStore(store *DataStore) *AttestationScheduleStore {
+	return &AttestationScheduleStore{
+		Store: store,
+	}
+}
+
+func (ass *AttestationScheduleStore) Create(as *hvs.AttestationSchedule) (*hvs.AttestationSchedule, error) {
+	defaultLog.Trace("postgres/attestation_schedule_store:Create() Entering")
+	defer defaultLog.Trace("postgres/attestation_schedule_store:Create() Leaving")
+
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/attestation_schedule_store:Create() failed to create new UUID")
+	}
+	as.ID = newUuid
+	as.CreatedAt = time.Now()
+	dbSchedule := attestationSchedule{
+		ID:            as.ID,
+		FlavorgroupId: as.FlavorgroupId,
+		Interval:      as.Interval,
+		CreatedAt:     as.CreatedAt,
+	}
+	if err := ass.Store.Db.Create(&dbSchedule).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/attestation_schedule_store:Create() failed to create attestation schedule")
+	}
+	return as, nil
+}
+
+func (ass *AttestationScheduleStore) Retrieve(id uuid.UUID) (*hvs.AttestationSchedule, error) {
+	defaultLog.Trace("postgres/attestation_schedule_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/attestation_schedule_store:Retrieve() Leaving")
+
+	dbSchedule := attestationSchedule{}
+	row := ass.Store.Db.Model(&attestationSchedule{}).Where(&attestationSchedule{ID: id}).Row()
+	if err := row.Scan(&dbSchedule.ID, &dbSchedule.FlavorgroupId, &dbSchedule.Interval, &dbSchedule.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "postgres/attestation_schedule_store:Retrieve() failed to scan record")
+	}
+	return toAttestationSchedule(&dbSchedule), nil
+}
+
+// Search returns the attestation schedules, optionally filtered by flavorgroup
+func (ass *AttestationScheduleStore) Search(criteria *models.AttestationScheduleFilterCriteria) ([]hvs.AttestationSchedule, error) {
+	defaultLog.Trace("postgres/attestation_schedule_store:Search() Entering")
+	defer defaultLog.Trace("postgres/attestation_schedule_store:Search() Leaving")
+
+	tx := ass.Store.Db.Model(&attestationSchedule{}).Order("created")
+	if criteria != nil && criteria.FlavorgroupId != uuid.Nil {
+		tx = tx.Where(&attestationSchedule{FlavorgroupId: criteria.FlavorgroupId})
+	}
+
+	var dbSchedules []attestationSchedule
+	if err := tx.Find(&dbSchedules).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/attestation_schedule_store:Search() failed to retrieve records from db")
+	}
+
+	schedules := []hvs.AttestationSchedule{}
+	for i := range dbSchedules {
+		schedules = append(schedules, *toAttestationSchedule(&dbSchedules[i]))
+	}
+	return schedules, nil
+}
+
+// Update changes the interval of the schedule, the flavorgroup of a schedule cannot be changed
+func (ass *AttestationScheduleStore) Update(as *hvs.AttestationSchedule) (*hvs.AttestationSchedule, error) {
+	defaultLog.Trace("postgres/attestation_schedule_store:Update() Entering")
+	defer defaultLog.Trace("postgres/attestation_schedule_store:Update() Leaving")
+
+	if err := ass.Store.Db.Model(&attestationSchedule{}).Where(&attestationSchedule{ID: as.ID}).
+		Update("interval", as.Interval).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/attestation_schedule_store:Update() failed to update attestation schedule")
+	}
+	return ass.Retrieve(as.ID)
+}
+
+func (ass *AttestationScheduleStore) Delete(id uuid.UUID) error {
+	defaultLog.Trace("postgres/attestation_schedule_store:Delete() Entering")
+	defer defaultLog.Trace("postgres/attestation_schedule_store:Delete() Leaving")
+
+	if err := ass.Store.Db.Delete(&attestationSchedule{ID: id}).Error; err != nil {
+		return errors.Wrap(err, "postgres/attestation_schedule_store:Delete() failed to delete attestation schedule")
+	}
+	return nil
+}
+
+func toAttestationSchedule(dbSchedule *attestationSchedule) *hvs.AttestationSchedule {
+	return &hvs.AttestationSchedule{
+		ID:            dbSchedule.ID,
+		FlavorgroupId: dbSchedule.FlavorgroupId,
+		Interval:      dbSchedule.Interval,
+		CreatedAt:     dbSchedule.CreatedAt,
+	}
+}
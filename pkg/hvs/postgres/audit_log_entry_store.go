@@ -11,6 +11,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 )
 
@@ -90,6 +92,98 @@ func (as *auditLogEntryStore) Retrieve(entry *models.AuditLogEntry) ([]models.Au
 	return ret, nil
 }
 
+// Search retrieves the audit log entries matching the given filter criteria. Entries from all the rotated
+// audit_log_entry partitions are returned, ordered by rowid
+func (as *auditLogEntryStore) Search(criteria *models.AuditLogEntryFilterCriteria) ([]models.AuditLogEntry, error) {
+	defaultLog.Trace("postgres/audit_log_entry_store_store:Search() Entering")
+	defer defaultLog.Trace("postgres/audit_log_entry_store_store:Search() Leaving")
+
+	tx := buildAuditLogEntrySearchQuery(as.store.Db, criteria)
+	if tx == nil {
+		return nil, errors.New("postgres/audit_log_entry_store_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object in AuditLogEntry Search function.")
+	}
+
+	pageCriteria := models.PageCriteria{Limit: constants.Limit}
+	if criteria != nil {
+		pageCriteria = criteria.PageCriteria
+		if pageCriteria.Limit == 0 {
+			pageCriteria.Limit = constants.Limit
+		}
+	}
+	tx, err := buildPageQuery(tx, pageCriteria, "audit_log_entry", "audit_log_entry", models.AuditLogEntrySortFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/audit_log_entry_store_store:Search() failed to build page query")
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/audit_log_entry_store_store:Search() failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	var ret []models.AuditLogEntry
+	for rows.Next() {
+		result := models.AuditLogEntry{}
+		if err := rows.Scan(&result.ID, &result.EntityID, &result.EntityType, &result.CreatedAt, &result.Action, (*PGAuditLogData)(&result.Data), &result.RowId); err != nil {
+			return nil, errors.Wrap(err, "postgres/audit_log_entry_store_store:Search() failed to scan record")
+		}
+		ret = append(ret, result)
+	}
+	return ret, nil
+}
+
+// Count returns the number of audit log entries matching the given filter criteria, the page of the criteria is
+// not applied
+func (as *auditLogEntryStore) Count(criteria *models.AuditLogEntryFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/audit_log_entry_store_store:Count() Entering")
+	defer defaultLog.Trace("postgres/audit_log_entry_store_store:Count() Leaving")
+
+	count, err := countRecords(buildAuditLogEntrySearchQuery(as.store.Db, criteria))
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/audit_log_entry_store_store:Count() failed to count audit log entries")
+	}
+	return count, nil
+}
+
+// buildAuditLogEntrySearchQuery is a helper function to build the query object for an audit log entry search.
+func buildAuditLogEntrySearchQuery(tx *gorm.DB, criteria *models.AuditLogEntryFilterCriteria) *gorm.DB {
+	defaultLog.Trace("postgres/audit_log_entry_store_store:buildAuditLogEntrySearchQuery() Entering")
+	defer defaultLog.Trace("postgres/audit_log_entry_store_store:buildAuditLogEntrySearchQuery() Leaving")
+
+	if tx == nil {
+		return nil
+	}
+	tx = tx.Model(&auditLogEntry{})
+	if criteria == nil {
+		defaultLog.Info("postgres/audit_log_entry_store_store:buildAuditLogEntrySearchQuery() No criteria specified in search query" +
+			". Returning all rows.")
+		return tx
+	}
+
+	if criteria.EntityID != uuid.Nil {
+		tx = tx.Where("entity_id = ?", criteria.EntityID)
+	}
+	if criteria.EntityType != "" {
+		tx = tx.Where("entity_type = ?", criteria.EntityType)
+	}
+	if criteria.Action != "" {
+		tx = tx.Where("action = ?", criteria.Action)
+	}
+	if !criteria.FromDate.IsZero() {
+		tx = tx.Where("CAST(created AS TIMESTAMP) >= CAST(? AS TIMESTAMP)", criteria.FromDate)
+	}
+	if !criteria.ToDate.IsZero() {
+		tx = tx.Where("CAST(created AS TIMESTAMP) < CAST(? AS TIMESTAMP)", criteria.ToDate)
+	}
+	return tx
+}
+
 func (as *auditLogEntryStore) Update(entry *models.AuditLogEntry) (*models.AuditLogEntry, error) {
 	defaultLog.Trace("postgres/audit_log_entry_store_store:Update() Entering")
 	defer defaultLog.Trace("postgres/audit_log_entry_store_store:Update() Leaving")
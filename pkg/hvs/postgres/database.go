@@ -34,7 +34,9 @@ func InitDatabase(cfg *commConfig.DBConfig) (*DataStore, error) {
 		return nil, errors.Wrap(err, "Error instantiating Database")
 	}
 	defaultLog.Info("Migrating Database")
-	dataStore.Migrate()
+	if err := dataStore.Migrate(); err != nil {
+		return nil, errors.Wrap(err, "Error migrating Database")
+	}
 
 	return dataStore, nil
 }
@@ -86,6 +86,15 @@ func (e *ESXiClusterStore) Search(ecFilter *models.ESXiClusterFilterCriteria) ([
 			" a gorm query object.")
 	}
 
+	var pageCriteria models.PageCriteria
+	if ecFilter != nil {
+		pageCriteria = ecFilter.PageCriteria
+	}
+	tx, err := buildPageQuery(tx, pageCriteria, "esxi_cluster", "esxi_cluster", models.ESXiClusterSortFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/esxi_cluster_store:Search() Failed to build page query")
+	}
+
 	rows, err := tx.Rows()
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/esxi_cluster_store:Search() Failed to retrieve records from db")
@@ -114,6 +123,18 @@ func (e *ESXiClusterStore) Search(ecFilter *models.ESXiClusterFilterCriteria) ([
 	return clusters, nil
 }
 
+// Count returns the number of ESXi clusters matching the filter criteria, the page of the criteria is not applied
+func (e *ESXiClusterStore) Count(ecFilter *models.ESXiClusterFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/esxi_cluster_store:Count() Entering")
+	defer defaultLog.Trace("postgres/esxi_cluster_store:Count() Leaving")
+
+	count, err := countRecords(buildESXiClusterSearchQuery(e.Store.Db, ecFilter))
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/esxi_cluster_store:Count() Failed to count ESXi clusters")
+	}
+	return count, nil
+}
+
 func (e *ESXiClusterStore) Delete(id uuid.UUID) error {
 	defaultLog.Trace("postgres/esxi_cluster_store:Delete() Entering")
 	defer defaultLog.Trace("postgres/esxi_cluster_store:Delete() Leaving")
@@ -210,13 +231,6 @@ func buildESXiClusterSearchQuery(tx *gorm.DB, criteria *models.ESXiClusterFilter
 	} else if criteria.ClusterName != "" {
 		tx = tx.Where("cluster_name = ?", criteria.ClusterName)
 	}
-	if criteria.AfterId > 0 {
-		tx = tx.Where("rowid > ?", criteria.AfterId)
-	}
-	tx = tx.Order("rowid asc")
-	if criteria.Limit > 0 {
-		tx = tx.Limit(criteria.Limit)
-	}
 
 	return tx
 }
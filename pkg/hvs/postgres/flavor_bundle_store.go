@@ -0,0 +1,44 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+type FlavorBundleStore struct {
+	Store *DataStore
+	// the flavor types cache of the flavorgroup store is cleared once the flavors are linked to the flavorgroups
+	FGStore domain.FlavorGroupStore
+}
+
+func NewFlavorBundleStore(store *DataStore, fgs domain.FlavorGroupStore) *FlavorBundleStore {
+	return &FlavorBundleStore{
+		Store:   store,
+		FGStore: fgs,
+	}
+}
+
+// Import runs the import of a flavor bundle in a single transaction, the stores passed to the import share the
+// transaction so that a failure partway does not leave a partial import behind
+func (fbs *FlavorBundleStore) Import(importFn func(domain.FlavorTemplateStore, domain.FlavorGroupStore, domain.FlavorStore) error) error {
+	defaultLog.Trace("postgres/flavor_bundle_store:Import() Entering")
+	defer defaultLog.Trace("postgres/flavor_bundle_store:Import() Leaving")
+
+	err := fbs.Store.Db.Transaction(func(tx *gorm.DB) error {
+		txStore := &DataStore{Db: tx}
+		return importFn(NewFlavorTemplateStore(txStore), &FlavorGroupStore{Store: txStore}, NewFlavorStore(txStore))
+	})
+	if err != nil {
+		return errors.Wrap(err, "postgres/flavor_bundle_store:Import()")
+	}
+
+	if fgStore, ok := fbs.FGStore.(*FlavorGroupStore); ok {
+		fgStore.clearFlavorTypesCache()
+	}
+	return nil
+}
@@ -59,42 +59,15 @@ func (f *FlavorStore) Search(flavorFilter *models.FlavorVerificationFC) ([]hvs.S
 	defaultLog.Trace("postgres/flavor_store:Search() Entering")
 	defer defaultLog.Trace("postgres/flavor_store:Search() Leaving")
 
-	var tx *gorm.DB
-	var err error
-
-	tx = f.Store.Db.Table("flavor f").Select("f.id, f.content, f.signature, f.rowid")
-	// build partial query with all the given flavor Id's
-	if len(flavorFilter.FlavorFC.Ids) > 0 {
-		var flavorIds []string
-		for _, fId := range flavorFilter.FlavorFC.Ids {
-			flavorIds = append(flavorIds, fId.String())
-		}
-		tx = tx.Where("f.id IN (?)", flavorFilter.FlavorFC.Ids)
-	}
-	// build partial query with the given key-value pair from flavor description
-	if flavorFilter.FlavorFC.Key != "" && flavorFilter.FlavorFC.Value != "" {
-		tx = tx.Where(convertToPgJsonqueryString("f.content", "meta.description."+flavorFilter.FlavorFC.Key)+" = ?", flavorFilter.FlavorFC.Value)
-	}
-	if flavorFilter.FlavorFC.FlavorgroupID.String() != "" ||
-		len(flavorFilter.FlavorFC.FlavorParts) >= 1 || len(flavorFilter.FlavorPartsWithLatest) >= 1 || flavorFilter.FlavorMeta != nil || len(flavorFilter.FlavorMeta) >= 1 {
-		if len(flavorFilter.FlavorFC.FlavorParts) >= 1 {
-			flavorFilter.FlavorPartsWithLatest = getFlavorPartsWithLatestMap(flavorFilter.FlavorFC.FlavorParts, flavorFilter.FlavorPartsWithLatest)
-		}
-		// add all flavor parts in list of flavor Parts
-		tx = f.buildMultipleFlavorPartQueryString(tx, flavorFilter.FlavorFC.FlavorgroupID, flavorFilter.FlavorMeta, flavorFilter.FlavorPartsWithLatest)
-	}
-
+	tx := f.buildFlavorSearchQuery(flavorFilter)
 	if tx == nil {
 		return nil, errors.New("postgres/flavor_store:Search() Unexpected Error. Could not build gorm query" +
 			" object in flavor Search function")
 	}
 
-	if flavorFilter.FlavorFC.AfterId > 0 {
-		tx = tx.Where("f.rowid > ?", flavorFilter.FlavorFC.AfterId)
-	}
-	tx = tx.Order("f.rowid asc")
-	if flavorFilter.FlavorFC.Limit > 0 {
-		tx = tx.Limit(flavorFilter.FlavorFC.Limit)
+	tx, err := buildPageQuery(tx, flavorFilter.FlavorFC.PageCriteria, "flavor", "f", models.FlavorSortFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:Search() failed to build page query")
 	}
 
 	rows, err := tx.Rows()
@@ -120,6 +93,47 @@ func (f *FlavorStore) Search(flavorFilter *models.FlavorVerificationFC) ([]hvs.S
 	return signedFlavors, nil
 }
 
+// Count returns the number of flavors matching the filter criteria, the page of the criteria is not applied
+func (f *FlavorStore) Count(flavorFilter *models.FlavorVerificationFC) (int, error) {
+	defaultLog.Trace("postgres/flavor_store:Count() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:Count() Leaving")
+
+	count, err := countRecords(f.buildFlavorSearchQuery(flavorFilter))
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/flavor_store:Count() failed to count flavors")
+	}
+	return count, nil
+}
+
+// buildFlavorSearchQuery is a helper function to build the query object for a flavor search
+func (f *FlavorStore) buildFlavorSearchQuery(flavorFilter *models.FlavorVerificationFC) *gorm.DB {
+	defaultLog.Trace("postgres/flavor_store:buildFlavorSearchQuery() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:buildFlavorSearchQuery() Leaving")
+
+	tx := f.Store.Db.Table("flavor f").Select("f.id, f.content, f.signature, f.rowid")
+	// build partial query with all the given flavor Id's
+	if len(flavorFilter.FlavorFC.Ids) > 0 {
+		var flavorIds []string
+		for _, fId := range flavorFilter.FlavorFC.Ids {
+			flavorIds = append(flavorIds, fId.String())
+		}
+		tx = tx.Where("f.id IN (?)", flavorFilter.FlavorFC.Ids)
+	}
+	// build partial query with the given key-value pair from flavor description
+	if flavorFilter.FlavorFC.Key != "" && flavorFilter.FlavorFC.Value != "" {
+		tx = tx.Where(convertToPgJsonqueryString("f.content", "meta.description."+flavorFilter.FlavorFC.Key)+" = ?", flavorFilter.FlavorFC.Value)
+	}
+	if flavorFilter.FlavorFC.FlavorgroupID.String() != "" ||
+		len(flavorFilter.FlavorFC.FlavorParts) >= 1 || len(flavorFilter.FlavorPartsWithLatest) >= 1 || flavorFilter.FlavorMeta != nil || len(flavorFilter.FlavorMeta) >= 1 {
+		if len(flavorFilter.FlavorFC.FlavorParts) >= 1 {
+			flavorFilter.FlavorPartsWithLatest = getFlavorPartsWithLatestMap(flavorFilter.FlavorFC.FlavorParts, flavorFilter.FlavorPartsWithLatest)
+		}
+		// add all flavor parts in list of flavor Parts
+		tx = f.buildMultipleFlavorPartQueryString(tx, flavorFilter.FlavorFC.FlavorgroupID, flavorFilter.FlavorMeta, flavorFilter.FlavorPartsWithLatest)
+	}
+	return tx
+}
+
 func (f *FlavorStore) buildMultipleFlavorPartQueryString(tx *gorm.DB, fgId uuid.UUID, flavorMetaInfo map[hvs.FlavorPartName][]models.FlavorMetaKv, flavorPartsWithLatest map[hvs.FlavorPartName]bool) *gorm.DB {
 	defaultLog.Trace("postgres/flavor_store:buildMultipleFlavorPartQueryString() Entering")
 	defer defaultLog.Trace("postgres/flavor_store:buildMultipleFlavorPartQueryString() Leaving")
@@ -358,6 +372,86 @@ func (f *FlavorStore) Retrieve(flavorId uuid.UUID) (*hvs.SignedFlavor, error) {
 	return &sf, nil
 }
 
+// update flavors, the current revision of the flavor is moved to the revision history
+func (f *FlavorStore) Update(signedFlavor *hvs.SignedFlavor) (*hvs.SignedFlavor, error) {
+	defaultLog.Trace("postgres/flavor_store:Update() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:Update() Leaving")
+	if signedFlavor == nil || signedFlavor.Signature == "" || signedFlavor.Flavor.Meta.ID == uuid.Nil ||
+		signedFlavor.Flavor.Meta.Description[hvs.Label].(string) == "" {
+		return nil, errors.New("postgres/flavor_store:Update()- invalid input : must have ID, content, signature and the label for the flavor")
+	}
+
+	err := f.Store.Db.Transaction(func(tx *gorm.DB) error {
+		current := flavor{}
+		if err := tx.Set("gorm:query_option", "FOR UPDATE").Where(&flavor{ID: signedFlavor.Flavor.Meta.ID}).First(&current).Error; err != nil {
+			return errors.Wrap(err, "failed to retrieve current flavor revision")
+		}
+
+		previous := flavorRevision{
+			FlavorId:  current.ID,
+			Revision:  current.Revision,
+			Content:   current.Content,
+			Signature: current.Signature,
+			CreatedAt: current.CreatedAt,
+		}
+		if err := tx.Create(&previous).Error; err != nil {
+			return errors.Wrap(err, "failed to store previous flavor revision")
+		}
+
+		// created_at is refreshed so that the LATEST match policy picks up the new revision
+		if err := tx.Model(&current).Updates(map[string]interface{}{
+			"content":    PGFlavorContent(signedFlavor.Flavor),
+			"signature":  signedFlavor.Signature,
+			"label":      signedFlavor.Flavor.Meta.Description[hvs.Label].(string),
+			"created_at": time.Now(),
+			"revision":   current.Revision + 1,
+		}).Error; err != nil {
+			return errors.Wrap(err, "failed to update flavor")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:Update()")
+	}
+	return signedFlavor, nil
+}
+
+// search all the revisions of a flavor, latest first
+func (f *FlavorStore) SearchRevisions(flavorId uuid.UUID) ([]hvs.FlavorRevision, error) {
+	defaultLog.Trace("postgres/flavor_store:SearchRevisions() Entering")
+	defer defaultLog.Trace("postgres/flavor_store:SearchRevisions() Leaving")
+
+	current := flavor{}
+	if err := f.Store.Db.Where(&flavor{ID: flavorId}).First(&current).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:SearchRevisions() failed to retrieve flavor")
+	}
+
+	var previous []flavorRevision
+	if err := f.Store.Db.Where(&flavorRevision{FlavorId: flavorId}).Order("revision desc").Find(&previous).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/flavor_store:SearchRevisions() failed to retrieve flavor revisions")
+	}
+
+	revisions := []hvs.FlavorRevision{{
+		Revision:  current.Revision,
+		CreatedAt: current.CreatedAt,
+		SignedFlavor: hvs.SignedFlavor{
+			Flavor:    hvs.Flavor(current.Content),
+			Signature: current.Signature,
+		},
+	}}
+	for _, rev := range previous {
+		revisions = append(revisions, hvs.FlavorRevision{
+			Revision:  rev.Revision,
+			CreatedAt: rev.CreatedAt,
+			SignedFlavor: hvs.SignedFlavor{
+				Flavor:    hvs.Flavor(rev.Content),
+				Signature: rev.Signature,
+			},
+		})
+	}
+	return revisions, nil
+}
+
 // delete flavors
 func (f *FlavorStore) Delete(flavorId uuid.UUID) error {
 	defaultLog.Trace("postgres/flavor_store:Delete() Entering")
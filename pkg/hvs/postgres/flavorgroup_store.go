@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
 	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -42,6 +43,7 @@ func (f *FlavorGroupStore) Create(fg *hvs.FlavorGroup) (*hvs.FlavorGroup, error)
 		ID:                    fg.ID,
 		Name:                  fg.Name,
 		FlavorTypeMatchPolicy: PGFlavorMatchPolicies(fg.MatchPolicies),
+		PolicyRules:           PGPolicyRules(fg.PolicyRules),
 	}
 
 	if err = f.Store.Db.Create(&dbFlavorGroup).Error; err != nil {
@@ -76,27 +78,37 @@ func (f *FlavorGroupStore) Retrieve(flavorGroupId uuid.UUID) (*hvs.FlavorGroup,
 
 	fg := hvs.FlavorGroup{}
 	row := f.Store.Db.Model(&flavorGroup{}).Where(&flavorGroup{ID: flavorGroupId}).Row()
-	if err := row.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies), &fg.RowId); err != nil {
+	if err := row.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies), &fg.RowId, (*PGPolicyRules)(&fg.PolicyRules)); err != nil {
 		return nil, errors.Wrap(err, "postgres/flavorgroup_store:Retrieve() failed to scan record")
 	}
 	return &fg, nil
 }
 
+// UpdatePolicyRules replaces the policy rules of the flavorgroup
+func (f *FlavorGroupStore) UpdatePolicyRules(flavorGroupId uuid.UUID, policyRules []hvs.PolicyRule) error {
+	defaultLog.Trace("postgres/flavorgroup_store:UpdatePolicyRules() Entering")
+	defer defaultLog.Trace("postgres/flavorgroup_store:UpdatePolicyRules() Leaving")
+
+	db := f.Store.Db.Model(&flavorGroup{}).Where(&flavorGroup{ID: flavorGroupId}).Update("policy_rules", PGPolicyRules(policyRules))
+	if db.Error != nil {
+		return errors.Wrap(db.Error, "postgres/flavorgroup_store:UpdatePolicyRules() failed to update policy rules")
+	}
+	if db.RowsAffected == 0 {
+		return errors.New(commErr.RowsNotFound)
+	}
+	return nil
+}
+
 func (f *FlavorGroupStore) Search(fgFilter *models.FlavorGroupFilterCriteria) ([]hvs.FlavorGroup, error) {
 	defaultLog.Trace("postgres/flavorgroup_store:Search() Entering")
 	defer defaultLog.Trace("postgres/flavorgroup_store:Search() Leaving")
 
-	var err error
-	if fgFilter != nil && fgFilter.FlavorId != nil {
-		fgFilter.Ids, err = f.searchFlavorGroups(fgFilter.FlavorId)
-		if err != nil {
-			return nil, errors.New("postgres/flavorgroup_store:Search() Unexpected Error. " +
-				"Error getting associated flavorgroups")
-		}
-		//If filter is only on the basis of flavor Id and no records are there then return
-		if fgFilter.NameEqualTo == "" && fgFilter.NameContains == "" && len(fgFilter.Ids) == 0 {
-			return []hvs.FlavorGroup{}, nil
-		}
+	noFlavorGroups, err := f.resolveFlavorIdFilter(fgFilter)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavorgroup_store:Search() Unexpected Error")
+	}
+	if noFlavorGroups {
+		return []hvs.FlavorGroup{}, nil
 	}
 	tx := buildFlavorGroupSearchQuery(f.Store.Db, fgFilter)
 
@@ -105,6 +117,15 @@ func (f *FlavorGroupStore) Search(fgFilter *models.FlavorGroupFilterCriteria) ([
 			" a gorm query object in FlavorGroups Search function.")
 	}
 
+	var pageCriteria models.PageCriteria
+	if fgFilter != nil {
+		pageCriteria = fgFilter.PageCriteria
+	}
+	tx, err = buildPageQuery(tx, pageCriteria, "flavor_group", "flavor_group", models.FlavorGroupSortFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavorgroup_store:Search() failed to build page query")
+	}
+
 	rows, err := tx.Rows()
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/flavorgroup_store:Search() failed to retrieve records from db")
@@ -119,7 +140,7 @@ func (f *FlavorGroupStore) Search(fgFilter *models.FlavorGroupFilterCriteria) ([
 	flavorgroupList := []hvs.FlavorGroup{}
 	for rows.Next() {
 		fg := hvs.FlavorGroup{}
-		if err := rows.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies), &fg.RowId); err != nil {
+		if err := rows.Scan(&fg.ID, &fg.Name, (*PGFlavorMatchPolicies)(&fg.MatchPolicies), &fg.RowId, (*PGPolicyRules)(&fg.PolicyRules)); err != nil {
 			return nil, errors.Wrap(err, "postgres/flavorgroup_store:Search() failed to scan record")
 		}
 		flavorgroupList = append(flavorgroupList, fg)
@@ -160,6 +181,40 @@ func (f *FlavorGroupStore) HasAssociatedHosts(fgId uuid.UUID) (bool, error) {
 	return false, nil
 }
 
+// Count returns the number of flavorgroups matching the filter criteria, the page of the criteria is not applied
+func (f *FlavorGroupStore) Count(fgFilter *models.FlavorGroupFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/flavorgroup_store:Count() Entering")
+	defer defaultLog.Trace("postgres/flavorgroup_store:Count() Leaving")
+
+	noFlavorGroups, err := f.resolveFlavorIdFilter(fgFilter)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/flavorgroup_store:Count() Unexpected Error")
+	}
+	if noFlavorGroups {
+		return 0, nil
+	}
+	count, err := countRecords(buildFlavorGroupSearchQuery(f.Store.Db, fgFilter))
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/flavorgroup_store:Count() failed to count flavorgroups")
+	}
+	return count, nil
+}
+
+// resolveFlavorIdFilter replaces the flavor Id of the filter criteria with the Ids of the flavorgroups linked to the
+// flavor, it tells if no flavorgroup can match the filter criteria
+func (f *FlavorGroupStore) resolveFlavorIdFilter(fgFilter *models.FlavorGroupFilterCriteria) (bool, error) {
+	if fgFilter == nil || fgFilter.FlavorId == nil {
+		return false, nil
+	}
+	var err error
+	fgFilter.Ids, err = f.searchFlavorGroups(fgFilter.FlavorId)
+	if err != nil {
+		return false, errors.New("Error getting associated flavorgroups")
+	}
+	//If filter is only on the basis of flavor Id and no records are there then return
+	return fgFilter.NameEqualTo == "" && fgFilter.NameContains == "" && len(fgFilter.Ids) == 0, nil
+}
+
 // helper function to build the query object for a FlavorGroup search.
 func buildFlavorGroupSearchQuery(tx *gorm.DB, fgFilter *models.FlavorGroupFilterCriteria) *gorm.DB {
 	defaultLog.Trace("postgres/flavorgroup_store:buildFlavorGroupSearchQuery() Entering")
@@ -182,14 +237,6 @@ func buildFlavorGroupSearchQuery(tx *gorm.DB, fgFilter *models.FlavorGroupFilter
 		tx = tx.Where("name like ? ", "%"+fgFilter.NameContains+"%")
 	}
 
-	if fgFilter.AfterId > 0 {
-		tx = tx.Where("rowid > ?", fgFilter.AfterId)
-	}
-	tx = tx.Order("rowid asc")
-	if fgFilter.Limit > 0 {
-		tx = tx.Limit(fgFilter.Limit)
-	}
-
 	return tx
 }
 
@@ -199,6 +246,13 @@ func (f *FlavorGroupStore) removeFlavorTypesCacheEntry(fgId uuid.UUID) {
 	}
 }
 
+func (f *FlavorGroupStore) clearFlavorTypesCache() {
+	f.flavorPartsCache.Range(func(fgId, _ interface{}) bool {
+		f.flavorPartsCache.Delete(fgId)
+		return true
+	})
+}
+
 // AddFlavors creates a FlavorGroup-Flavor link
 func (f *FlavorGroupStore) AddFlavors(fgId uuid.UUID, fIds []uuid.UUID) ([]uuid.UUID, error) {
 	defaultLog.Trace("postgres/flavorgroup_store:AddFlavors() Entering")
@@ -331,6 +385,37 @@ func (f *FlavorGroupStore) SearchHostsByFlavorGroup(fgID uuid.UUID) ([]uuid.UUID
 	return hIDs, nil
 }
 
+// SearchHostsByFlavorGroups is used to fetch the hosts linked to each of the provided FlavorGroups
+func (f *FlavorGroupStore) SearchHostsByFlavorGroups(fgIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	defaultLog.Trace("postgres/flavorgroup_store:SearchHostsByFlavorGroups() Entering")
+	defer defaultLog.Trace("postgres/flavorgroup_store:SearchHostsByFlavorGroups() Leaving")
+
+	hostIDs := make(map[uuid.UUID][]uuid.UUID)
+	if len(fgIDs) == 0 {
+		return hostIDs, nil
+	}
+	rows, err := f.Store.Db.Model(&hostFlavorgroup{}).Select("flavorgroup_id, host_id").Where("flavorgroup_id IN (?)", fgIDs).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavorgroup_store:SearchHostsByFlavorGroups() failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	for rows.Next() {
+		var fgId, hId uuid.UUID
+		if err := rows.Scan(&fgId, &hId); err != nil {
+			return nil, errors.Wrap(err, "postgres/flavorgroup_store:SearchHostsByFlavorGroups() failed to scan record")
+		}
+		hostIDs[fgId] = append(hostIDs[fgId], hId)
+	}
+
+	return hostIDs, nil
+}
+
 // SearchFlavorTemplatesByFlavorGroup is used to fetch a list of flavor templates which are linked to the provided FlavorGroup
 func (f *FlavorGroupStore) SearchFlavorTemplatesByFlavorGroup(fgID uuid.UUID) ([]uuid.UUID, error) {
 	defaultLog.Trace("postgres/flavorgroup_store:SearchFlavorTemplatesByFlavorGroup() Entering")
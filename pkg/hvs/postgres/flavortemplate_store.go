@@ -58,8 +58,8 @@ func (ft *FlavorTemplateStore) Retrieve(templateID uuid.UUID, includeDeleted boo
 	defer defaultLog.Trace("postgres/flavortemplate_store:Retrieve() Leaving")
 
 	sf := flavorTemplate{}
-	row := ft.Store.Db.Model(flavorTemplate{}).Select("id,content,deleted").Where(&flavorTemplate{ID: templateID}).Row()
-	if err := row.Scan(&sf.ID, &sf.Content, &sf.Deleted); err != nil {
+	row := ft.Store.Db.Model(flavorTemplate{}).Select("id,content,deleted,rowid").Where(&flavorTemplate{ID: templateID}).Row()
+	if err := row.Scan(&sf.ID, &sf.Content, &sf.Deleted, &sf.Rowid); err != nil {
 		if strings.Contains(err.Error(), commErr.RowsNotFound) {
 			defaultLog.Error("postgres/flavortemplate_store:Retrieve() Failed to retrieve record from db", commErr.RowsNotFound)
 			return nil, &commErr.StatusNotFoundError{Message: "Failed to retrieve record from db"}
@@ -74,6 +74,7 @@ func (ft *FlavorTemplateStore) Retrieve(templateID uuid.UUID, includeDeleted boo
 			Label:       sf.Content.Label,
 			Condition:   sf.Content.Condition,
 			FlavorParts: sf.Content.FlavorParts,
+			RowId:       sf.Rowid,
 		}
 		return &flavorTemplate, nil
 	}
@@ -92,6 +93,15 @@ func (ft *FlavorTemplateStore) Search(criteria *models.FlavorTemplateFilterCrite
 			" a gorm query object.")
 	}
 
+	var pageCriteria models.PageCriteria
+	if criteria != nil {
+		pageCriteria = criteria.PageCriteria
+	}
+	tx, err := buildPageQuery(tx, pageCriteria, "flavor_template", "flavor_template", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/flavortemplate_store:Search() failed to build page query")
+	}
+
 	rows, err := tx.Rows()
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/flavortemplate_store:Search() failed to retrieve records from db")
@@ -107,7 +117,7 @@ func (ft *FlavorTemplateStore) Search(criteria *models.FlavorTemplateFilterCrite
 	for rows.Next() {
 		template := flavorTemplate{}
 
-		if err := rows.Scan(&template.ID, &template.Content, &template.Deleted); err != nil {
+		if err := rows.Scan(&template.ID, &template.Content, &template.Deleted, &template.Rowid); err != nil {
 			return nil, errors.Wrap(err, "postgres/flavortemplate_store:Search() - Could not scan record")
 		}
 
@@ -116,6 +126,7 @@ func (ft *FlavorTemplateStore) Search(criteria *models.FlavorTemplateFilterCrite
 			Label:       template.Content.Label,
 			Condition:   template.Content.Condition,
 			FlavorParts: template.Content.FlavorParts,
+			RowId:       template.Rowid,
 		}
 		flavortemplates = append(flavortemplates, flavorTemplate)
 	}
@@ -123,6 +134,18 @@ func (ft *FlavorTemplateStore) Search(criteria *models.FlavorTemplateFilterCrite
 	return flavortemplates, nil
 }
 
+// Count returns the number of flavor templates matching the filter criteria, the page of the criteria is not applied
+func (ft *FlavorTemplateStore) Count(criteria *models.FlavorTemplateFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/flavortemplate_store:Count() Entering")
+	defer defaultLog.Trace("postgres/flavortemplate_store:Count() Leaving")
+
+	count, err := countRecords(ft.buildFlavorTemplateSearchQuery(ft.Store.Db, criteria))
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/flavortemplate_store:Count() failed to count flavor templates")
+	}
+	return count, nil
+}
+
 // Delete flavor template
 func (ft *FlavorTemplateStore) Delete(templateID uuid.UUID) error {
 	defaultLog.Trace("postgres/flavortemplate_store:Delete() Entering")
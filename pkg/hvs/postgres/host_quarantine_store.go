@@ -0,0 +1,116 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type HostQuarantineStore struct {
+	Store          *DataStore
+	AuditLogWriter domain.AuditLogWriter
+}
+
+func NewHostQuarantineStore(store *DataStore) *HostQuarantineStore {
+	return &HostQuarantineStore{Store: store}
+}
+
+func (hqs *HostQuarantineStore) Retrieve(hostId uuid.UUID) (*hvs.HostQuarantine, error) {
+	defaultLog.Trace("postgres/host_quarantine_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/host_quarantine_store:Retrieve() Leaving")
+
+	dbQuarantine := hostQuarantine{}
+	if err := hqs.Store.Db.Where(&hostQuarantine{HostId: hostId}).First(&dbQuarantine).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/host_quarantine_store:Retrieve() failed to retrieve record from db")
+	}
+	return toHostQuarantine(&dbQuarantine), nil
+}
+
+// Search returns the remediation state of the hosts, most recently updated first
+func (hqs *HostQuarantineStore) Search(criteria *models.HostQuarantineFilterCriteria) ([]hvs.HostQuarantine, error) {
+	defaultLog.Trace("postgres/host_quarantine_store:Search() Entering")
+	defer defaultLog.Trace("postgres/host_quarantine_store:Search() Leaving")
+
+	tx := hqs.Store.Db.Model(&hostQuarantine{}).Order("updated desc")
+	if criteria != nil && criteria.Quarantined != nil {
+		tx = tx.Where("quarantined = ?", *criteria.Quarantined)
+	}
+
+	var dbQuarantines []hostQuarantine
+	if err := tx.Find(&dbQuarantines).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/host_quarantine_store:Search() failed to retrieve records from db")
+	}
+
+	quarantines := []hvs.HostQuarantine{}
+	for i := range dbQuarantines {
+		quarantines = append(quarantines, *toHostQuarantine(&dbQuarantines[i]))
+	}
+	return quarantines, nil
+}
+
+// Persist creates or updates the remediation state of the host, the changes are recorded in the audit log
+func (hqs *HostQuarantineStore) Persist(hq *hvs.HostQuarantine) error {
+	defaultLog.Trace("postgres/host_quarantine_store:Persist() Entering")
+	defer defaultLog.Trace("postgres/host_quarantine_store:Persist() Leaving")
+
+	if hq.HostId == uuid.Nil {
+		return errors.New("postgres/host_quarantine_store:Persist() - HostId is missing")
+	}
+
+	oldHq, err := hqs.Retrieve(hq.HostId)
+	if err != nil {
+		oldHq = nil
+	}
+
+	hq.Updated = time.Now()
+	dbQuarantine := hostQuarantine{
+		HostId:                 hq.HostId,
+		Quarantined:            hq.Quarantined,
+		UntrustedReports:       hq.UntrustedReports,
+		ReportId:               hq.ReportId,
+		QuarantinedAt:          hq.QuarantinedAt,
+		DeletedTagCertificates: PGUUIDs(hq.DeletedTagCertificates),
+		ReleasedAt:             hq.ReleasedAt,
+		ReleaseComment:         hq.ReleaseComment,
+		Updated:                hq.Updated,
+	}
+	if err := hqs.Store.Db.Save(&dbQuarantine).Error; err != nil {
+		return errors.Wrap(err, "postgres/host_quarantine_store:Persist() failed to save host quarantine")
+	}
+
+	if hqs.AuditLogWriter != nil {
+		var auditEntry *models.AuditLogEntry
+		if oldHq == nil {
+			auditEntry, err = hqs.AuditLogWriter.CreateEntry("create", hq)
+		} else {
+			auditEntry, err = hqs.AuditLogWriter.CreateEntry("update", oldHq, hq)
+		}
+		if err == nil {
+			hqs.AuditLogWriter.Log(auditEntry)
+		}
+	}
+	return nil
+}
+
+func toHostQuarantine(dbQuarantine *hostQuarantine) *hvs.HostQuarantine {
+	return &hvs.HostQuarantine{
+		HostId:                 dbQuarantine.HostId,
+		Quarantined:            dbQuarantine.Quarantined,
+		UntrustedReports:       dbQuarantine.UntrustedReports,
+		ReportId:               dbQuarantine.ReportId,
+		QuarantinedAt:          dbQuarantine.QuarantinedAt,
+		DeletedTagCertificates: []uuid.UUID(dbQuarantine.DeletedTagCertificates),
+		ReleasedAt:             dbQuarantine.ReleasedAt,
+		ReleaseComment:         dbQuarantine.ReleaseComment,
+		Updated:                dbQuarantine.Updated,
+	}
+}
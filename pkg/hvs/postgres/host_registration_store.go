@@ -0,0 +1,91 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+type HostRegistrationStore struct {
+	Store *DataStore
+	Dek   []byte
+}
+
+func NewHostRegistrationStore(store *DataStore, dek []byte) *HostRegistrationStore {
+	return &HostRegistrationStore{
+		Store: store,
+		Dek:   dek,
+	}
+}
+
+// CreateBatch creates the hosts of the registrations with their credentials and flavorgroup links in a single transaction
+func (hrs *HostRegistrationStore) CreateBatch(registrations []*models.HostRegistration) error {
+	defaultLog.Trace("postgres/host_registration_store:CreateBatch() Entering")
+	defer defaultLog.Trace("postgres/host_registration_store:CreateBatch() Leaving")
+
+	err := hrs.Store.Db.Transaction(func(tx *gorm.DB) error {
+		for _, registration := range registrations {
+			h := registration.Host
+			newUuid, err := uuid.NewRandom()
+			if err != nil {
+				return errors.Wrap(err, "failed to create new UUID")
+			}
+			h.Id = newUuid
+			dbHost := host{
+				Id:               h.Id,
+				Name:             h.HostName,
+				Description:      h.Description,
+				ConnectionString: h.ConnectionString,
+			}
+			if h.HardwareUuid != nil {
+				dbHost.HardwareUuid = models.NewHwUUID(*h.HardwareUuid)
+			}
+			if err := tx.Create(&dbHost).Error; err != nil {
+				return errors.Wrapf(err, "failed to create Host %s", h.HostName)
+			}
+
+			hc := registration.Credential
+			encCred, err := utils.EncryptString(hc.Credential, hrs.Dek)
+			if err != nil {
+				return errors.Wrap(err, "failed to encrypt Host Credential")
+			}
+			newUuid, err = uuid.NewRandom()
+			if err != nil {
+				return errors.Wrap(err, "failed to create new UUID")
+			}
+			hc.Id = newUuid
+			hc.HostId = h.Id
+			hc.HardwareUuid = dbHost.HardwareUuid
+			dbHostCredential := hostCredential{
+				Id:           hc.Id,
+				HostId:       hc.HostId,
+				HostName:     hc.HostName,
+				HardwareUuid: hc.HardwareUuid,
+				Credential:   encCred,
+				CreatedTs:    time.Now(),
+			}
+			if err := tx.Create(&dbHostCredential).Error; err != nil {
+				return errors.Wrapf(err, "failed to create Host Credential of Host %s", h.HostName)
+			}
+
+			for _, fgId := range registration.FlavorgroupIds {
+				if err := tx.Create(&hostFlavorgroup{HostId: h.Id, FlavorgroupId: fgId}).Error; err != nil {
+					return errors.Wrapf(err, "failed to create Host Flavorgroup association of Host %s", h.HostName)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "postgres/host_registration_store:CreateBatch()")
+	}
+	return nil
+}
@@ -154,6 +154,11 @@ func (hs *HostStore) Search(filterCriteria *models.HostFilterCriteria, infoFetch
 		tx = buildInfoFetchQuery(tx, infoFetchCriteria, filterCriteria)
 	}
 
+	tx, err := buildPageQuery(tx, hostPageCriteria(filterCriteria), "host", "host", models.HostSortFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/host_store:Search() failed to build page query")
+	}
+
 	rows, err := tx.Rows()
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/host_store:Search() failed to retrieve records from db")
@@ -180,6 +185,18 @@ func (hs *HostStore) Search(filterCriteria *models.HostFilterCriteria, infoFetch
 	return hosts, nil
 }
 
+// Count returns the number of hosts matching the filter criteria, the page of the criteria is not applied
+func (hs *HostStore) Count(filterCriteria *models.HostFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/host_store:Count() Entering")
+	defer defaultLog.Trace("postgres/host_store:Count() Leaving")
+
+	count, err := countRecords(buildHostSearchQuery(hs.Store.Db, filterCriteria))
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/host_store:Count() failed to count hosts")
+	}
+	return count, nil
+}
+
 // helper function to build the query object for a Host search.
 func buildHostSearchQuery(tx *gorm.DB, criteria *models.HostFilterCriteria) *gorm.DB {
 	defaultLog.Trace("postgres/host_store:buildHostSearchQuery() Entering")
@@ -191,42 +208,41 @@ func buildHostSearchQuery(tx *gorm.DB, criteria *models.HostFilterCriteria) *gor
 
 	tx = tx.Model(&host{})
 
-	if criteria == nil || reflect.DeepEqual(*criteria, models.HostFilterCriteria{}) {
-		tx = tx.Order("name asc")
+	if criteria == nil {
 		return tx
 	}
 
 	if criteria.Id != uuid.Nil {
-		tx = tx.Where("id = ?", criteria.Id)
+		tx = tx.Where("host.id = ?", criteria.Id)
 	} else if criteria.NameEqualTo != "" {
-		tx = tx.Where("name = ?", criteria.NameEqualTo)
+		tx = tx.Where("host.name = ?", criteria.NameEqualTo)
+	} else if criteria.NameList != nil {
+		tx = tx.Where("host.name IN (?)", criteria.NameList)
 	} else if criteria.NameContains != "" {
-		tx = tx.Where("name like ? ", "%"+criteria.NameContains+"%")
+		tx = tx.Where("host.name like ? ", "%"+criteria.NameContains+"%")
 	} else if criteria.HostHardwareId != uuid.Nil {
-		tx = tx.Where("hardware_uuid = ?", criteria.HostHardwareId)
+		tx = tx.Where("host.hardware_uuid = ?", criteria.HostHardwareId)
 	} else if criteria.IdList != nil {
-		tx = tx.Where("id IN (?)", criteria.IdList)
+		tx = tx.Where("host.id IN (?)", criteria.IdList)
 	} else if criteria.Trusted != nil {
 		tx = tx.Joins("join report on report.host_id = host.id AND report.trusted = ?", criteria.Trusted)
 	}
-	if criteria.OrderBy != "" {
-		if criteria.OrderBy == models.Descending {
-			tx = tx.Order("name desc")
-		} else {
-			tx = tx.Order("name asc")
-		}
-	} else {
-		if criteria.AfterId > 0 {
-			tx = tx.Where("rowid > ?", criteria.AfterId)
-		}
-		tx = tx.Order("rowid asc")
-		if criteria.Limit > 0 {
-			tx = tx.Limit(criteria.Limit)
-		}
-	}
 	return tx
 }
 
+// hostPageCriteria returns the page of a host search. All the hosts are returned sorted by name when there is
+// no criteria, and the hosts are sorted by name when only the order is given.
+func hostPageCriteria(criteria *models.HostFilterCriteria) models.PageCriteria {
+	if criteria == nil || reflect.DeepEqual(*criteria, models.HostFilterCriteria{}) {
+		return models.PageCriteria{SortBy: "name"}
+	}
+	page := criteria.PageCriteria
+	if page.SortBy == "" && page.OrderBy != "" {
+		page.SortBy = "name"
+	}
+	return page
+}
+
 func buildInfoFetchQuery(tx *gorm.DB, infoFetchCriteria *models.HostInfoFetchCriteria,
 	filterCriteria *models.HostFilterCriteria) *gorm.DB {
 	defaultLog.Trace("postgres/host_store:buildInfoFetchQuery() Entering")
@@ -21,8 +21,9 @@ import (
 )
 
 type HostStatusStore struct {
-	Store          *DataStore
-	AuditLogWriter domain.AuditLogWriter
+	Store                 *DataStore
+	AuditLogWriter        domain.AuditLogWriter
+	NotificationPublisher domain.NotificationPublisher
 }
 
 func NewHostStatusStore(store *DataStore) *HostStatusStore {
@@ -81,16 +82,20 @@ func (hss *HostStatusStore) Search(hsFilter *models.HostStatusFilterCriteria) ([
 	defaultLog.Trace("postgres/hoststatus_store:Search() Entering")
 	defer defaultLog.Trace("postgres/hoststatus_store:Search() Leaving")
 
-	var tx *gorm.DB
-
 	// setting to empty array
 	hostStatuses := []hvs.HostStatus{}
 
-	if hsFilter.FromDate.IsZero() && hsFilter.ToDate.IsZero() && hsFilter.LatestPerHost {
-		tx = buildLatestHostStatusSearchQuery(hss.Store.Db, hsFilter)
-		if tx == nil {
-			return nil, errors.New("postgres/hoststatus_store:Search() Unexpected Error. Could not build" +
-				" a gorm query object in HostStatus Search function.")
+	tx, fromAuditLog := buildHostStatusFilterQuery(hss.Store.Db, hsFilter)
+	if tx == nil {
+		return nil, errors.New("postgres/hoststatus_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object in HostStatus Search function.")
+	}
+
+	var err error
+	if !fromAuditLog {
+		tx, err = buildPageQuery(tx, hsFilter.PageCriteria, "host_status", "host_status", models.HostStatusSortFields)
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/hoststatus_store:Search() failed to build page query")
 		}
 		rows, err := tx.Rows()
 		if err != nil {
@@ -112,10 +117,9 @@ func (hss *HostStatusStore) Search(hsFilter *models.HostStatusFilterCriteria) ([
 			hostStatuses = append(hostStatuses, result)
 		}
 	} else {
-		tx = buildHostStatusSearchQuery(hss.Store.Db, hsFilter)
-		if tx == nil {
-			return nil, errors.New("postgres/hoststatus_store:Search() Unexpected Error. Could not build" +
-				" a gorm query object in HostStatus Search function.")
+		tx, err = buildPageQuery(tx, hsFilter.PageCriteria, "audit_log_entry", "au", models.HostStatusSortFields)
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/hoststatus_store:Search() failed to build page query")
 		}
 		rows, err := tx.Rows()
 		if err != nil {
@@ -147,6 +151,29 @@ func (hss *HostStatusStore) Search(hsFilter *models.HostStatusFilterCriteria) ([
 	return hostStatuses, nil
 }
 
+// Count returns the number of HostStatus records matching the HostStatusFilterCriteria, the page of the criteria is
+// not applied
+func (hss *HostStatusStore) Count(hsFilter *models.HostStatusFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/hoststatus_store:Count() Entering")
+	defer defaultLog.Trace("postgres/hoststatus_store:Count() Leaving")
+
+	tx, _ := buildHostStatusFilterQuery(hss.Store.Db, hsFilter)
+	count, err := countRecords(tx)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/hoststatus_store:Count() failed to count HostStatus records")
+	}
+	return count, nil
+}
+
+// buildHostStatusFilterQuery is a helper function to build the query object for a hostStatus search without the
+// page, the latest HostStatus records are searched in the host_status table and the other ones in the audit log
+func buildHostStatusFilterQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCriteria) (*gorm.DB, bool) {
+	if hsFilter.FromDate.IsZero() && hsFilter.ToDate.IsZero() && hsFilter.LatestPerHost {
+		return buildLatestHostStatusSearchQuery(tx, hsFilter), false
+	}
+	return buildHostStatusSearchQuery(tx, hsFilter), true
+}
+
 // Persist is used by the HostDataFetcher to update an existing HostStatus record else create one if it does not exist
 func (hss *HostStatusStore) Persist(hs *hvs.HostStatus) error {
 	defaultLog.Trace("postgres/hoststatus_store:Persist() Entering")
@@ -194,6 +221,15 @@ func (hss *HostStatusStore) Persist(hs *hvs.HostStatus) error {
 			hss.AuditLogWriter.Log(auditEntry)
 		}
 	}
+	// notify subscribers of the HostState transition
+	if hss.NotificationPublisher != nil && oldHs.Status.HostState != hs.HostStatusInformation.HostState {
+		hss.NotificationPublisher.Publish(&hvs.NotificationEvent{
+			EventType:         hvs.NotificationEventHostStateChanged,
+			HostId:            hs.HostID,
+			PreviousHostState: oldHs.Status.HostState.String(),
+			HostState:         hs.HostStatusInformation.HostState.String(),
+		})
+	}
 	return nil
 }
 
@@ -351,17 +387,8 @@ func buildHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFilterCr
 		formattedQuery = fmt.Sprintf("%s %s", formattedQuery, additionalOptionsQueryString)
 	}
 
-	// finalize query
-	tx = tx.Raw(formattedQuery)
-	if hsFilter.AfterId > 0 {
-		tx = tx.Where("au.rowid > ?", hsFilter.AfterId)
-	}
-	tx = tx.Order("au.rowid asc")
-	if hsFilter.Limit > 0 {
-		tx = tx.Limit(hsFilter.Limit)
-	}
-
-	return tx
+	// finalize query, the audit log query is used as a derived table so that it can be paged and counted
+	return tx.Table(fmt.Sprintf("(%s) au", formattedQuery)).Select("au.*")
 }
 
 // buildLatestHostStatusSearchQuery is a helper function to build the query object for a hostStatus search
@@ -412,14 +439,6 @@ func buildLatestHostStatusSearchQuery(tx *gorm.DB, hsFilter *models.HostStatusFi
 		tx = tx.Where(`status @> '{"host_state": "` + strings.ToUpper(hsFilter.HostStatus) + `"}'`)
 	}
 
-	if hsFilter.AfterId > 0 {
-		tx = tx.Where("rowid > ?", hsFilter.AfterId)
-	}
-	tx = tx.Order("rowid asc")
-	if hsFilter.Limit > 0 {
-		tx = tx.Limit(hsFilter.Limit)
-	}
-
 	return tx
 }
 
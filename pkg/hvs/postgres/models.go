@@ -21,6 +21,7 @@ import (
 type (
 	PGJsonStrMap            map[string]interface{}
 	PGFlavorMatchPolicies   hvs.FlavorMatchPolicies
+	PGPolicyRules           []hvs.PolicyRule
 	PGHostManifest          hvs.HostManifest
 	PGHostStatusInformation hvs.HostStatusInformation
 	PGFlavorContent         hvs.Flavor
@@ -31,6 +32,7 @@ type (
 		Name                  string                `json:"name" gorm:"type:varchar(255);not null;index:idx_flavorgroup_name"`
 		FlavorTypeMatchPolicy PGFlavorMatchPolicies `json:"flavor_type_match_policy,omitempty" sql:"type:JSONB"`
 		Rowid                 int                   `json:"-" gorm:"auto_increment;not null"`
+		PolicyRules           PGPolicyRules         `json:"policy_rules,omitempty" sql:"type:JSONB"`
 	}
 
 	flavor struct {
@@ -40,9 +42,19 @@ type (
 		Label      string          `gorm:"unique;not null"`
 		FlavorPart string          `json:"flavor_part"`
 		Signature  string          `json:"signature"`
+		Revision   int             `json:"revision" gorm:"not null;default:1"`
 		Rowid      int             `json:"-" gorm:"auto_increment;not null"`
 	}
 
+	// flavorRevision holds the earlier revisions of an updated flavor
+	flavorRevision struct {
+		FlavorId  uuid.UUID       `gorm:"type:uuid REFERENCES flavor(Id) ON UPDATE CASCADE ON DELETE CASCADE;not null;unique_index:idx_flavor_revision"`
+		Revision  int             `gorm:"not null;unique_index:idx_flavor_revision"`
+		Content   PGFlavorContent `sql:"type:JSONB"`
+		Signature string
+		CreatedAt time.Time
+	}
+
 	host struct {
 		Id               uuid.UUID `gorm:"primary_key;type:uuid"`
 		Name             string    `gorm:"unique;type:varchar(255);not null"`
@@ -77,6 +89,7 @@ type (
 		ID      uuid.UUID               `gorm:"column:id;not null;primary_key;type:uuid"`
 		Content PGFlavorTemplateContent `gorm:"column:content" sql:"type:JSONB NOT NULL"`
 		Deleted bool                    `gorm:"column:deleted;not null;type:bool"`
+		Rowid   int                     `gorm:"auto_increment;not null"`
 	}
 
 	flavortemplateFlavorgroup struct {
@@ -137,6 +150,31 @@ type (
 		Rowid       int           `gorm:"auto_increment;not null"`
 	}
 
+	// reportHistory holds every report created for the hosts, the reports replaced by a newer report of the host
+	// are kept until they are purged by the report retention service
+	reportHistory struct {
+		ID          uuid.UUID     `gorm:"primary_key;type:uuid"`
+		HostID      uuid.UUID     `gorm:"column:host_id;type:uuid;not null;index:idx_report_history_host_id"`
+		TrustReport PGTrustReport `gorm:"column:trust_report; not null" sql:"type:JSONB"`
+		Trusted     bool          `gorm:"column:trusted; not null"`
+		CreatedAt   time.Time     `gorm:"column:created;not null"`
+		Expiration  time.Time     `gorm:"column:expiration;not null"`
+		Saml        string        `gorm:"column:saml;not null"`
+		Rowid       int           `gorm:"auto_increment;not null"`
+	}
+
+	// reportArchive holds the reports purged from the report history by the report retention service
+	reportArchive struct {
+		ID          uuid.UUID     `gorm:"primary_key;type:uuid"`
+		HostID      uuid.UUID     `gorm:"column:host_id;type:uuid;not null;index:idx_report_archive_host_id"`
+		TrustReport PGTrustReport `gorm:"column:trust_report; not null" sql:"type:JSONB"`
+		Trusted     bool          `gorm:"column:trusted; not null"`
+		CreatedAt   time.Time     `gorm:"column:created;not null"`
+		Expiration  time.Time     `gorm:"column:expiration;not null"`
+		Saml        string        `gorm:"column:saml;not null"`
+		ArchivedAt  time.Time     `gorm:"column:archived;not null"`
+	}
+
 	tpmEndorsement struct {
 		ID                uuid.UUID `gorm:"primary_key;type:uuid"`
 		HardwareUUID      uuid.UUID `gorm:"column:hardware_uuid;not null;type:uuid"`
@@ -160,6 +198,48 @@ type (
 		Rowid      int            `gorm:"auto_increment;not null"`
 	}
 
+	PGNotificationEventTypes []hvs.NotificationEventType
+	notificationSubscription struct {
+		ID         uuid.UUID                `gorm:"primary_key;type:uuid"`
+		Url        string                   `gorm:"not null"`
+		EventTypes PGNotificationEventTypes `sql:"type:JSONB NOT NULL"`
+		Secret     string                   `gorm:"not null"`
+		CreatedAt  time.Time                `gorm:"column:created;not null"`
+	}
+
+	PGNotificationEvent hvs.NotificationEvent
+	// notificationDeadLetter holds the events that could not be delivered to a notification subscriber
+	notificationDeadLetter struct {
+		ID             uuid.UUID           `gorm:"primary_key;type:uuid"`
+		SubscriptionId uuid.UUID           `gorm:"type:uuid REFERENCES notification_subscription(Id) ON UPDATE CASCADE ON DELETE CASCADE;not null;index:idx_notification_dead_letter_subscription_id"`
+		Event          PGNotificationEvent `sql:"type:JSONB NOT NULL"`
+		Attempts       int                 `gorm:"not null"`
+		LastError      string
+		CreatedAt      time.Time `gorm:"column:created;not null"`
+	}
+
+	// attestationSchedule sets the re-attestation interval of the hosts linked to a flavorgroup
+	attestationSchedule struct {
+		ID            uuid.UUID `gorm:"primary_key;type:uuid"`
+		FlavorgroupId uuid.UUID `gorm:"type:uuid REFERENCES flavor_group(Id) ON UPDATE CASCADE ON DELETE CASCADE;not null;unique_index:idx_attestation_schedule_flavorgroup"`
+		Interval      string    `gorm:"not null"`
+		CreatedAt     time.Time `gorm:"column:created;not null"`
+	}
+
+	PGUUIDs []uuid.UUID
+	// hostQuarantine holds the remediation state of the hosts that had untrusted reports
+	hostQuarantine struct {
+		HostId                 uuid.UUID  `gorm:"primary_key;type:uuid REFERENCES host(Id) ON UPDATE CASCADE ON DELETE CASCADE"`
+		Quarantined            bool       `gorm:"not null;index:idx_host_quarantine_quarantined"`
+		UntrustedReports       int        `gorm:"not null"`
+		ReportId               *uuid.UUID `gorm:"type:uuid"`
+		QuarantinedAt          *time.Time
+		DeletedTagCertificates PGUUIDs `sql:"type:JSONB"`
+		ReleasedAt             *time.Time
+		ReleaseComment         string
+		Updated                time.Time `gorm:"not null"`
+	}
+
 	tagCertificate struct {
 		ID           uuid.UUID `gorm:"primary_key; type:uuid"`
 		HardwareUUID uuid.UUID `gorm:"not null; type:uuid; column:hardware_uuid"`
@@ -168,6 +248,7 @@ type (
 		Issuer       string    `gorm:"not null"`
 		NotBefore    time.Time `gorm:"not null; column:notbefore"`
 		NotAfter     time.Time `gorm:"not null; column:notafter"`
+		Rowid        int       `gorm:"auto_increment;not null"`
 	}
 )
 
@@ -224,6 +305,23 @@ func (fmp *PGFlavorMatchPolicies) Scan(value interface{}) error {
 	return json.Unmarshal(b, &fmp)
 }
 
+func (pr PGPolicyRules) Value() (driver.Value, error) {
+	return json.Marshal(pr)
+}
+
+// Scan accepts NULL since the column is added to the flavorgroups created before the policy rules were supported
+func (pr *PGPolicyRules) Scan(value interface{}) error {
+	if value == nil {
+		*pr = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGPolicyRules_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &pr)
+}
+
 func (trp PGTrustReport) Value() (driver.Value, error) {
 	return json.Marshal(trp)
 }
@@ -271,3 +369,39 @@ func (fl *PGFlavorTemplateContent) Scan(value interface{}) error {
 	}
 	return json.Unmarshal(b, &fl)
 }
+
+func (ne PGNotificationEventTypes) Value() (driver.Value, error) {
+	return json.Marshal(ne)
+}
+
+func (ne *PGNotificationEventTypes) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGNotificationEventTypes_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &ne)
+}
+
+func (ne PGNotificationEvent) Value() (driver.Value, error) {
+	return json.Marshal(ne)
+}
+
+func (ne *PGNotificationEvent) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGNotificationEvent_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &ne)
+}
+
+func (ids PGUUIDs) Value() (driver.Value, error) {
+	return json.Marshal(ids)
+}
+
+func (ids *PGUUIDs) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("postgres/models:PGUUIDs_Scan() - type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &ids)
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type NotificationSubscriptionStore struct {
+	Store *DataStore
+	Dek   []byte
+}
+
+func NewNotificationSubscriptionStore(store *DataStore, dek []byte) *NotificationSubscriptionStore {
+	return &NotificationSubscriptionStore{
+		Store: store,
+		Dek:   dek,
+	}
+}
+
+// Create stores the subscription, the signing secret is encrypted with the data encryption key
+func (nss *NotificationSubscriptionStore) Create(ns *hvs.NotificationSubscription) (*hvs.NotificationSubscription, error) {
+	defaultLog.Trace("postgres/notification_subscription_store:Create() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:Create() Leaving")
+
+	encSecret, err := utils.EncryptString(ns.Secret, nss.Dek)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:Create() failed to encrypt subscription secret")
+	}
+
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:Create() failed to create new UUID")
+	}
+	ns.ID = newUuid
+	ns.CreatedAt = time.Now()
+	dbSubscription := notificationSubscription{
+		ID:         ns.ID,
+		Url:        ns.Url,
+		EventTypes: PGNotificationEventTypes(ns.EventTypes),
+		Secret:     encSecret,
+		CreatedAt:  ns.CreatedAt,
+	}
+	if err := nss.Store.Db.Create(&dbSubscription).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:Create() failed to create notification subscription")
+	}
+	return ns, nil
+}
+
+// Retrieve returns the subscription along with the decrypted signing secret
+func (nss *NotificationSubscriptionStore) Retrieve(id uuid.UUID) (*hvs.NotificationSubscription, error) {
+	defaultLog.Trace("postgres/notification_subscription_store:Retrieve() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:Retrieve() Leaving")
+
+	dbSubscription := notificationSubscription{}
+	row := nss.Store.Db.Model(&notificationSubscription{}).Where(&notificationSubscription{ID: id}).Row()
+	if err := row.Scan(&dbSubscription.ID, &dbSubscription.Url, &dbSubscription.EventTypes, &dbSubscription.Secret, &dbSubscription.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:Retrieve() failed to scan record")
+	}
+	return nss.toNotificationSubscription(&dbSubscription)
+}
+
+// Search returns the subscriptions, along with the decrypted signing secrets, for the given event type
+func (nss *NotificationSubscriptionStore) Search(criteria *models.NotificationSubscriptionFilterCriteria) ([]hvs.NotificationSubscription, error) {
+	defaultLog.Trace("postgres/notification_subscription_store:Search() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:Search() Leaving")
+
+	tx := nss.Store.Db.Model(&notificationSubscription{}).Order("created")
+	if criteria != nil && criteria.EventType != "" {
+		eventTypes, err := json.Marshal([]hvs.NotificationEventType{criteria.EventType})
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/notification_subscription_store:Search() failed to marshal event type")
+		}
+		tx = tx.Where("event_types @> ?", string(eventTypes))
+	}
+
+	var dbSubscriptions []notificationSubscription
+	if err := tx.Find(&dbSubscriptions).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:Search() failed to retrieve records from db")
+	}
+
+	subscriptions := []hvs.NotificationSubscription{}
+	for i := range dbSubscriptions {
+		subscription, err := nss.toNotificationSubscription(&dbSubscriptions[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres/notification_subscription_store:Search()")
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	return subscriptions, nil
+}
+
+func (nss *NotificationSubscriptionStore) Delete(id uuid.UUID) error {
+	defaultLog.Trace("postgres/notification_subscription_store:Delete() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:Delete() Leaving")
+
+	if err := nss.Store.Db.Delete(&notificationSubscription{ID: id}).Error; err != nil {
+		return errors.Wrap(err, "postgres/notification_subscription_store:Delete() failed to delete notification subscription")
+	}
+	return nil
+}
+
+// CreateDeadLetter stores an event that could not be delivered to the subscription
+func (nss *NotificationSubscriptionStore) CreateDeadLetter(dl *hvs.NotificationDeadLetter) (*hvs.NotificationDeadLetter, error) {
+	defaultLog.Trace("postgres/notification_subscription_store:CreateDeadLetter() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:CreateDeadLetter() Leaving")
+
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:CreateDeadLetter() failed to create new UUID")
+	}
+	dl.ID = newUuid
+	dl.CreatedAt = time.Now()
+	dbDeadLetter := notificationDeadLetter{
+		ID:             dl.ID,
+		SubscriptionId: dl.SubscriptionId,
+		Event:          PGNotificationEvent(dl.Event),
+		Attempts:       dl.Attempts,
+		LastError:      dl.LastError,
+		CreatedAt:      dl.CreatedAt,
+	}
+	if err := nss.Store.Db.Create(&dbDeadLetter).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:CreateDeadLetter() failed to create notification dead letter")
+	}
+	return dl, nil
+}
+
+// SearchDeadLetters returns the undelivered events of the subscription, most recent first
+func (nss *NotificationSubscriptionStore) SearchDeadLetters(subscriptionId uuid.UUID) ([]hvs.NotificationDeadLetter, error) {
+	defaultLog.Trace("postgres/notification_subscription_store:SearchDeadLetters() Entering")
+	defer defaultLog.Trace("postgres/notification_subscription_store:SearchDeadLetters() Leaving")
+
+	var dbDeadLetters []notificationDeadLetter
+	if err := nss.Store.Db.Model(&notificationDeadLetter{}).Where(&notificationDeadLetter{SubscriptionId: subscriptionId}).
+		Order("created desc").Find(&dbDeadLetters).Error; err != nil {
+		return nil, errors.Wrap(err, "postgres/notification_subscription_store:SearchDeadLetters() failed to retrieve records from db")
+	}
+
+	deadLetters := []hvs.NotificationDeadLetter{}
+	for _, dbDeadLetter := range dbDeadLetters {
+		deadLetters = append(deadLetters, hvs.NotificationDeadLetter{
+			ID:             dbDeadLetter.ID,
+			SubscriptionId: dbDeadLetter.SubscriptionId,
+			Event:          hvs.NotificationEvent(dbDeadLetter.Event),
+			Attempts:       dbDeadLetter.Attempts,
+			LastError:      dbDeadLetter.LastError,
+			CreatedAt:      dbDeadLetter.CreatedAt,
+		})
+	}
+	return deadLetters, nil
+}
+
+func (nss *NotificationSubscriptionStore) toNotificationSubscription(dbSubscription *notificationSubscription) (*hvs.NotificationSubscription, error) {
+	secret, err := utils.DecryptString(dbSubscription.Secret, nss.Dek)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt subscription secret")
+	}
+	return &hvs.NotificationSubscription{
+		ID:         dbSubscription.ID,
+		Url:        dbSubscription.Url,
+		EventTypes: []hvs.NotificationEventType(dbSubscription.EventTypes),
+		Secret:     secret,
+		CreatedAt:  dbSubscription.CreatedAt,
+	}, nil
+}
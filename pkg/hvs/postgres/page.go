@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// buildPageQuery adds the ordering, the keyset condition and the limit of a page to the search query on table,
+// alias is the name the table is referred to in the query. The records are ordered by the sort field and then by
+// rowid so that the page following the record with rowid AfterId can be fetched without an offset.
+func buildPageQuery(tx *gorm.DB, page models.PageCriteria, table, alias string, sortFields []string) (*gorm.DB, error) {
+	defaultLog.Trace("postgres/page:buildPageQuery() Entering")
+	defer defaultLog.Trace("postgres/page:buildPageQuery() Leaving")
+
+	if tx == nil {
+		return nil, nil
+	}
+	// the sort field is used in the query, only the whitelisted column names are accepted
+	if err := page.ValidateSortBy(sortFields); err != nil {
+		return nil, errors.Wrap(err, "postgres/page:buildPageQuery() Invalid page criteria")
+	}
+
+	order, comparison := "asc", ">"
+	if page.OrderBy == models.Descending {
+		order, comparison = "desc", "<"
+	}
+
+	if page.SortBy != "" {
+		if page.AfterId > 0 {
+			tx = tx.Where(fmt.Sprintf(keysetCondition(page.OrderBy == models.Descending), table, alias, page.SortBy,
+				comparison), page.AfterId)
+		}
+		// the NULL sort values are ordered as the largest values, which is the default of postgres
+		if page.OrderBy == models.Descending {
+			tx = tx.Order(fmt.Sprintf("%s.%s desc nulls first", alias, page.SortBy))
+		} else {
+			tx = tx.Order(fmt.Sprintf("%s.%s asc nulls last", alias, page.SortBy))
+		}
+	} else if page.AfterId > 0 {
+		tx = tx.Where(fmt.Sprintf("%s.rowid %s ?", alias, comparison), page.AfterId)
+	}
+	tx = tx.Order(fmt.Sprintf("%s.rowid %s", alias, order))
+
+	if page.Limit > 0 {
+		tx = tx.Limit(page.Limit)
+	}
+	return tx, nil
+}
+
+// countRecords returns the number of records matched by a search query built without a page
+func countRecords(tx *gorm.DB) (int, error) {
+	if tx == nil {
+		return 0, errors.New("postgres/page:countRecords() Unexpected Error. Could not build a gorm query object")
+	}
+	count := 0
+	if err := tx.Count(&count).Error; err != nil {
+		return 0, errors.Wrap(err, "postgres/page:countRecords() failed to count records")
+	}
+	return count, nil
+}
+
+// keysetCondition returns the condition selecting the records sorted after the cursor record, the record with the
+// rowid given as query argument. A row comparison is NULL when a sort value is NULL, so the NULL sort values are
+// compared explicitly. The format arguments are the table, the alias, the sort field and the rowid comparison.
+func keysetCondition(descending bool) string {
+	if descending {
+		// nulls first: a NULL is followed by the NULLs of lower rowid and by all the values
+		return `EXISTS (SELECT 1 FROM %[1]s page_cursor WHERE page_cursor.rowid = ? AND (
+			(page_cursor.%[3]s IS NULL AND (%[2]s.%[3]s IS NOT NULL OR %[2]s.rowid %[4]s page_cursor.rowid)) OR
+			(%[2]s.%[3]s, %[2]s.rowid) %[4]s (page_cursor.%[3]s, page_cursor.rowid)))`
+	}
+	// nulls last: a value is followed by the greater values and by all the NULLs
+	return `EXISTS (SELECT 1 FROM %[1]s page_cursor WHERE page_cursor.rowid = ? AND (
+		(page_cursor.%[3]s IS NULL AND %[2]s.%[3]s IS NULL AND %[2]s.rowid %[4]s page_cursor.rowid) OR
+		(page_cursor.%[3]s IS NOT NULL AND %[2]s.%[3]s IS NULL) OR
+		(%[2]s.%[3]s, %[2]s.rowid) %[4]s (page_cursor.%[3]s, page_cursor.rowid)))`
+}
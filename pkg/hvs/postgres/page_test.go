@@ -0,0 +1,355 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package postgres
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/jinzhu/gorm"
+)
+
+// pageQuery returns the SQL of a host search with the given page, the query arguments are checked by the mock
+func pageQuery(t *testing.T, page models.PageCriteria, args ...driver.Value) (string, error) {
+	var query string
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherFunc(func(_, actual string) error {
+		query = strings.Join(strings.Fields(actual), " ")
+		return nil
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	gdb, err := gorm.Open("postgres", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gdb.SingularTable(true)
+
+	tx, err := buildPageQuery(gdb.Model(&host{}), page, "host", "host", models.HostSortFields)
+	if err != nil {
+		return "", err
+	}
+	expectation := mock.ExpectQuery("host").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	if len(args) > 0 {
+		expectation.WithArgs(args...)
+	}
+	var hosts []host
+	if err := tx.Find(&hosts).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	return query, nil
+}
+
+func TestBuildPageQuery(t *testing.T) {
+	// the cursor rowid is the first query argument
+	keysetAsc := strings.Replace(strings.Join(strings.Fields(fmt.Sprintf(keysetCondition(false), "host", "host", "name", ">")), " "), "?", "$1", 1)
+	keysetDesc := strings.Replace(strings.Join(strings.Fields(fmt.Sprintf(keysetCondition(true), "host", "host", "name", "<")), " "), "?", "$1", 1)
+
+	tests := []struct {
+		name    string
+		page    models.PageCriteria
+		args    []driver.Value
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default order",
+			page: models.PageCriteria{},
+			want: `SELECT * FROM "host" ORDER BY host.rowid asc`,
+		},
+		{
+			name: "after id with limit",
+			page: models.PageCriteria{AfterId: 5, Limit: 10},
+			args: []driver.Value{int64(5)},
+			want: `SELECT * FROM "host" WHERE (host.rowid > $1) ORDER BY host.rowid asc LIMIT 10`,
+		},
+		{
+			name: "descending after id",
+			page: models.PageCriteria{AfterId: 5, OrderBy: models.Descending},
+			args: []driver.Value{int64(5)},
+			want: `SELECT * FROM "host" WHERE (host.rowid < $1) ORDER BY host.rowid desc`,
+		},
+		{
+			name: "sorted ascending, NULLs last and ties broken by rowid",
+			page: models.PageCriteria{SortBy: "name"},
+			want: `SELECT * FROM "host" ORDER BY host.name asc nulls last,host.rowid asc`,
+		},
+		{
+			name: "sorted ascending after cursor",
+			page: models.PageCriteria{SortBy: "name", AfterId: 5, Limit: 10},
+			args: []driver.Value{int64(5)},
+			want: `SELECT * FROM "host" WHERE (` + keysetAsc + `) ORDER BY host.name asc nulls last,host.rowid asc LIMIT 10`,
+		},
+		{
+			name: "sorted descending after cursor, NULLs first",
+			page: models.PageCriteria{SortBy: "name", AfterId: 5, OrderBy: models.Descending},
+			args: []driver.Value{int64(5)},
+			want: `SELECT * FROM "host" WHERE (` + keysetDesc + `) ORDER BY host.name desc nulls first,host.rowid desc`,
+		},
+		{
+			name:    "sort field not accepted",
+			page:    models.PageCriteria{SortBy: "name; DROP TABLE host"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := pageQuery(t, tt.page, tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildPageQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if query != tt.want {
+				t.Errorf("buildPageQuery() query = %s, want %s", query, tt.want)
+			}
+		})
+	}
+}
+
+// keysetRow is a host with its sort value, a nil name is NULL
+type keysetRow struct {
+	rowid int
+	name  *string
+}
+
+func TestKeysetCondition(t *testing.T) {
+	a, b := "a", "b"
+	// the names have ties and NULLs
+	rows := []keysetRow{{1, &b}, {2, &a}, {3, nil}, {4, &b}, {5, nil}, {6, &a}}
+
+	tests := []struct {
+		name       string
+		descending bool
+		want       []int
+	}{
+		{name: "ascending, NULLs last", descending: false, want: []int{2, 6, 1, 4, 3, 5}},
+		{name: "descending, NULLs first", descending: true, want: []int{5, 3, 4, 1, 6, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparison := ">"
+			if tt.descending {
+				comparison = "<"
+			}
+			condition := fmt.Sprintf(keysetCondition(tt.descending), "host", "host", "name", comparison)
+			// only the comparison of the host with the cursor host is evaluated, the cursor is selected by rowid
+			prefix := "EXISTS (SELECT 1 FROM host page_cursor WHERE page_cursor.rowid = ? AND ("
+			if !strings.HasPrefix(condition, prefix) || !strings.HasSuffix(condition, "))") {
+				t.Fatalf("keysetCondition() = %s, want the EXISTS subquery of the cursor host", condition)
+			}
+			comparisonSql := strings.TrimSuffix(strings.TrimPrefix(condition, prefix), "))")
+
+			// the hosts following each host in the expected order must be selected
+			for i, cursorId := range tt.want {
+				cursor := rows[cursorId-1]
+				var selected []int
+				for _, row := range rows {
+					if evaluateKeysetSql(t, comparisonSql, row, cursor) == sqlTrue {
+						selected = append(selected, row.rowid)
+					}
+				}
+				want := append([]int{}, tt.want[i+1:]...)
+				sort.Ints(selected)
+				sort.Ints(want)
+				if len(want) == 0 {
+					want = nil
+				}
+				if !reflect.DeepEqual(selected, want) {
+					t.Errorf("keysetCondition() after host %d selected %v, want %v", cursorId, selected, want)
+				}
+			}
+		})
+	}
+}
+
+// sqlBool is a boolean of the three-valued logic of SQL
+type sqlBool int
+
+const (
+	sqlFalse sqlBool = iota
+	sqlNull
+	sqlTrue
+)
+
+// evaluateKeysetSql evaluates the comparison of a host with the cursor host of a keyset condition with the NULL
+// semantics of SQL. Only the operators of the keyset conditions are supported: OR, AND, IS [NOT] NULL and the
+// comparisons of columns and of row values.
+func evaluateKeysetSql(t *testing.T, sql string, row, cursor keysetRow) sqlBool {
+	replacer := strings.NewReplacer("(", " ( ", ")", " ) ", ",", " , ")
+	parser := &keysetSqlParser{t: t, tokens: strings.Fields(replacer.Replace(sql)), row: row, cursor: cursor}
+	result := parser.or()
+	if parser.pos != len(parser.tokens) {
+		t.Fatalf("unexpected token %q in %s", parser.tokens[parser.pos], sql)
+	}
+	return result
+}
+
+type keysetSqlParser struct {
+	t           *testing.T
+	tokens      []string
+	pos         int
+	row, cursor keysetRow
+}
+
+func (p *keysetSqlParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *keysetSqlParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *keysetSqlParser) expect(token string) {
+	if actual := p.next(); actual != token {
+		p.t.Fatalf("got token %q, want %q", actual, token)
+	}
+}
+
+func (p *keysetSqlParser) or() sqlBool {
+	result := p.and()
+	for p.peek() == "OR" {
+		p.next()
+		if right := p.and(); right > result {
+			result = right
+		}
+	}
+	return result
+}
+
+func (p *keysetSqlParser) and() sqlBool {
+	result := p.predicate()
+	for p.peek() == "AND" {
+		p.next()
+		if right := p.predicate(); right < result {
+			result = right
+		}
+	}
+	return result
+}
+
+func (p *keysetSqlParser) predicate() sqlBool {
+	if p.peek() == "(" {
+		// a row value has a comma at the top level of the parentheses
+		depth, rowValue := 0, false
+		for _, token := range p.tokens[p.pos:] {
+			if token == "(" {
+				depth++
+			} else if token == ")" {
+				depth--
+				if depth == 0 {
+					break
+				}
+			} else if token == "," && depth == 1 {
+				rowValue = true
+			}
+		}
+		if !rowValue {
+			p.next()
+			result := p.or()
+			p.expect(")")
+			return result
+		}
+		left := p.rowValue()
+		operator := p.next()
+		return compareRowValues(left, p.rowValue(), operator)
+	}
+
+	left := p.value(p.next())
+	if p.peek() == "IS" {
+		p.next()
+		not := p.peek() == "NOT"
+		if not {
+			p.next()
+		}
+		p.expect("NULL")
+		if (left == nil) != not {
+			return sqlTrue
+		}
+		return sqlFalse
+	}
+	operator := p.next()
+	return compareValues(left, p.value(p.next()), operator)
+}
+
+func (p *keysetSqlParser) rowValue() []interface{} {
+	p.expect("(")
+	var values []interface{}
+	for {
+		values = append(values, p.value(p.next()))
+		if p.next() == ")" {
+			return values
+		}
+	}
+}
+
+func (p *keysetSqlParser) value(column string) interface{} {
+	var row keysetRow
+	switch {
+	case strings.HasPrefix(column, "host."):
+		row = p.row
+	case strings.HasPrefix(column, "page_cursor."):
+		row = p.cursor
+	default:
+		p.t.Fatalf("unexpected column %q", column)
+	}
+	if strings.HasSuffix(column, ".rowid") {
+		return strconv.Itoa(100 + row.rowid)
+	}
+	if row.name == nil {
+		return nil
+	}
+	return *row.name
+}
+
+// compareValues compares two values, the comparison of a NULL is NULL
+func compareValues(left, right interface{}, operator string) sqlBool {
+	if left == nil || right == nil {
+		return sqlNull
+	}
+	comparison := strings.Compare(left.(string), right.(string))
+	var result bool
+	switch operator {
+	case "<":
+		result = comparison < 0
+	case ">":
+		result = comparison > 0
+	case "=":
+		result = comparison == 0
+	}
+	if result {
+		return sqlTrue
+	}
+	return sqlFalse
+}
+
+// compareRowValues compares two row values field by field, the comparison is NULL when a NULL is compared before
+// the first field that differs
+func compareRowValues(left, right []interface{}, operator string) sqlBool {
+	for i := range left {
+		if left[i] == nil || right[i] == nil {
+			return sqlNull
+		}
+		if compareValues(left[i], right[i], "=") == sqlFalse {
+			return compareValues(left[i], right[i], operator)
+		}
+	}
+	return sqlFalse
+}
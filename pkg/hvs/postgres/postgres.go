@@ -138,13 +138,31 @@ func (ds *DataStore) ExecuteSqlFile(file string) error {
 	return nil
 }
 
-func (ds *DataStore) Migrate() {
+func (ds *DataStore) Migrate() error {
 	defaultLog.Trace("postgres/postgres:Migrate() Entering")
 	defer defaultLog.Trace("postgres/postgres:Migrate() Leaving")
 
-	ds.Db.AutoMigrate(flavorGroup{}, host{}, flavor{}, trustCache{}, hostuniqueFlavor{}, flavorgroupFlavor{}, hostStatus{}, esxiCluster{},
-		esxiClusterHost{}, tagCertificate{}, tpmEndorsement{}, report{}, hostCredential{}, hostFlavorgroup{}, auditLogEntry{},
-		queue{}, flavorTemplate{}, flavortemplateFlavorgroup{})
+	ds.Db.AutoMigrate(flavorGroup{}, host{}, flavor{}, flavorRevision{}, trustCache{}, hostuniqueFlavor{}, flavorgroupFlavor{}, hostStatus{}, esxiCluster{},
+		esxiClusterHost{}, tagCertificate{}, tpmEndorsement{}, report{}, reportArchive{}, hostCredential{}, hostFlavorgroup{}, auditLogEntry{},
+		queue{}, flavorTemplate{}, flavortemplateFlavorgroup{}, notificationSubscription{}, notificationDeadLetter{},
+		attestationSchedule{}, hostQuarantine{})
+
+	if ds.Db.HasTable(reportHistory{}) {
+		ds.Db.AutoMigrate(reportHistory{})
+		return nil
+	}
+	// the report history was kept in the audit log before it got its own table, the table is created with the copy
+	// of the history so that a failed copy is retried on the next migration
+	err := ds.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(reportHistory{}).Error; err != nil {
+			return err
+		}
+		return tx.Exec(reportHistoryMigrationQuery).Error
+	})
+	if err != nil {
+		return errors.Wrap(err, "postgres/postgres:Migrate() Error copying the report history from the audit log")
+	}
+	return nil
 }
 
 func (ds *DataStore) Close() {
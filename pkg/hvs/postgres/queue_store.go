@@ -97,6 +97,34 @@ func (qr *QueueStore) Search(qf *models.QueueFilterCriteria) ([]*models.Queue, e
 	return result, nil
 }
 
+// CountByState returns the number of queue entries per state, the states without entry are not returned
+func (qr *QueueStore) CountByState() (map[models.QueueState]int, error) {
+	defaultLog.Trace("postgres/queue_store:CountByState() Entering")
+	defer defaultLog.Trace("postgres/queue_store:CountByState() Leaving")
+
+	rows, err := qr.store.Db.Model(&queue{}).Select("state, COUNT(*)").Group("state").Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/queue_store:CountByState() failed to count queues")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	counts := make(map[models.QueueState]int)
+	for rows.Next() {
+		var state models.QueueState
+		var count int
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, errors.Wrap(err, "postgres/queue_store:CountByState() - Could not scan record")
+		}
+		counts[state] = count
+	}
+	return counts, nil
+}
+
 func (qr *QueueStore) Update(q *models.Queue) error {
 	defaultLog.Trace("postgres/queue_store:Update() Entering")
 	defer defaultLog.Trace("postgres/queue_store:Update() Leaving")
@@ -6,10 +6,9 @@
 package postgres
 
 import (
-	"encoding/json"
+	"database/sql"
 	"fmt"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
-	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -17,14 +16,17 @@ import (
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 )
 
 type ReportStore struct {
-	Store          *DataStore
-	AuditLogWriter domain.AuditLogWriter
-	dbLock         sync.Mutex
+	Store                 *DataStore
+	AuditLogWriter        domain.AuditLogWriter
+	NotificationPublisher domain.NotificationPublisher
+	dbLock                sync.Mutex
 }
 
 func NewReportStore(store *DataStore) *ReportStore {
@@ -82,6 +84,19 @@ func (r *ReportStore) Update(re *models.HVSReport) (*models.HVSReport, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/report_store:Update() Error while creating report")
 	}
+
+	// notify subscribers when the overall trust differs from the previous report
+	if r.NotificationPublisher != nil && len(hvsReports) == 1 && hvsReports[0].TrustReport.Trusted != vsReport.TrustReport.Trusted {
+		previousTrusted := hvsReports[0].TrustReport.Trusted
+		trusted := vsReport.TrustReport.Trusted
+		r.NotificationPublisher.Publish(&hvs.NotificationEvent{
+			EventType:       hvs.NotificationEventHostTrustChanged,
+			HostId:          vsReport.HostID,
+			ReportId:        &vsReport.ID,
+			PreviousTrusted: &previousTrusted,
+			Trusted:         &trusted,
+		})
+	}
 	return vsReport, nil
 }
 
@@ -104,7 +119,22 @@ func (r *ReportStore) Create(re *models.HVSReport) (*models.HVSReport, error) {
 		TrustReport: PGTrustReport(re.TrustReport),
 		Trusted:     re.TrustReport.Trusted,
 	}
-	if err := r.Store.Db.Create(&dbReport).Error; err != nil {
+	// the report is added to the report history along with the report table
+	err = r.Store.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&dbReport).Error; err != nil {
+			return err
+		}
+		return tx.Create(&reportHistory{
+			ID:          dbReport.ID,
+			HostID:      dbReport.HostID,
+			TrustReport: dbReport.TrustReport,
+			Trusted:     dbReport.Trusted,
+			CreatedAt:   dbReport.CreatedAt,
+			Expiration:  dbReport.Expiration,
+			Saml:        dbReport.Saml,
+		}).Error
+	})
+	if err != nil {
 		return nil, errors.Wrap(err, "postgres/report_store:Create() failed to create HVSReport")
 	}
 	// log to audit log
@@ -122,6 +152,71 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 	defaultLog.Trace("postgres/report_store:Search() Entering")
 	defer defaultLog.Trace("postgres/report_store:Search() Leaving")
 
+	tx, fromHistory := buildReportFilterQuery(r.Store.Db, criteria)
+	if tx == nil {
+		return nil, errors.New("postgres/report_store:Search() Unexpected Error. Could not build" +
+			" a gorm query object in HVSReport Search function.")
+	}
+
+	pageCriteria := criteria.PageCriteria
+	if pageCriteria.Limit == 0 && criteria.LatestPerHost {
+		pageCriteria.Limit = constants.Limit
+	}
+
+	var err error
+	if !fromHistory {
+		tx, err = buildPageQuery(tx, pageCriteria, "report", "report", models.ReportSortFields)
+	} else {
+		tx, err = buildPageQuery(tx, pageCriteria, "report_history", "rh", models.ReportSortFields)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/report_store:Search() failed to build page query")
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/report_store:Search() failed to retrieve records from db")
+	}
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
+		}
+	}()
+
+	var reports []models.HVSReport
+
+	for rows.Next() {
+		result := models.HVSReport{}
+		ignoreMe := false //The new 'Trusted' field was introduced to v3.5, ignore that field in the query so it returns the correct results
+		if err := rows.Scan(&result.ID, &result.HostID, (*PGTrustReport)(&result.TrustReport), &ignoreMe, &result.CreatedAt, &result.Expiration, &result.Saml, &result.RowId); err != nil {
+			return nil, errors.Wrap(err, "postgres/report_store:Search() failed to scan record")
+		}
+		reports = append(reports, result)
+	}
+
+	return reports, nil
+}
+
+// Count returns the number of reports matching the ReportFilterCriteria, the page of the criteria is not applied
+func (r *ReportStore) Count(criteria *models.ReportFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/report_store:Count() Entering")
+	defer defaultLog.Trace("postgres/report_store:Count() Leaving")
+
+	tx, _ := buildReportFilterQuery(r.Store.Db, criteria)
+	count, err := countRecords(tx)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/report_store:Count() failed to count reports")
+	}
+	return count, nil
+}
+
+// buildReportFilterQuery is a helper function to build the query object for a report search without the page, the
+// latest reports are searched in the report table and the other reports in the report history
+func buildReportFilterQuery(tx *gorm.DB, criteria *models.ReportFilterCriteria) (*gorm.DB, bool) {
+	defaultLog.Trace("postgres/report_store:buildReportFilterQuery() Entering")
+	defer defaultLog.Trace("postgres/report_store:buildReportFilterQuery() Leaving")
+
 	var reportID uuid.UUID
 	var hostID uuid.UUID
 	var hostName string
@@ -152,9 +247,6 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 	if !criteria.FromDate.IsZero() {
 		fromDate = criteria.FromDate
 	}
-	if criteria.Limit == 0 && criteria.LatestPerHost {
-		criteria.Limit = constants.Limit
-	}
 	latestPerHost = criteria.LatestPerHost
 
 	if criteria.NumberOfDays != 0 {
@@ -162,74 +254,10 @@ func (r *ReportStore) Search(criteria *models.ReportFilterCriteria) ([]models.HV
 		fromDate = toDate.AddDate(0, 0, -(criteria.NumberOfDays)).UTC()
 	}
 
-	var tx *gorm.DB
 	if fromDate.IsZero() && toDate.IsZero() && criteria.LatestPerHost {
-		tx = buildLatestReportSearchQuery(r.Store.Db, reportID, hostID, hostHardwareUUID, hostName, hostStatus, criteria.Limit, criteria.AfterId)
-
-		if tx == nil {
-			return nil, errors.New("postgres/report_store:Search() Unexpected Error. Could not build" +
-				" a gorm query object in HVSReport Search function.")
-		}
-
-		rows, err := tx.Rows()
-		if err != nil {
-			return nil, errors.Wrap(err, "postgres/report_store:Search() failed to retrieve records from db")
-		}
-		defer func() {
-			derr := rows.Close()
-			if derr != nil {
-				defaultLog.WithError(derr).Error("Error closing rows")
-			}
-		}()
-
-		var reports []models.HVSReport
-
-		for rows.Next() {
-			result := models.HVSReport{}
-			ignoreMe := false //The new 'Trusted' field was introduced to v3.5, ignore that field in the query so it returns the correct results
-			if err := rows.Scan(&result.ID, &result.HostID, (*PGTrustReport)(&result.TrustReport), &ignoreMe, &result.CreatedAt, &result.Expiration, &result.Saml, &result.RowId); err != nil {
-				return nil, errors.Wrap(err, "postgres/report_store:Search() failed to scan record")
-			}
-			reports = append(reports, result)
-		}
-
-		return reports, nil
-	} else {
-		tx = buildReportSearchQuery(r.Store.Db, hostID, hostHardwareUUID, hostName, hostStatus, fromDate, toDate, latestPerHost, criteria.Limit, criteria.AfterId)
-		if tx == nil {
-			return nil, errors.New("postgres/report_store:Search() Unexpected Error. Could not build" +
-				" a gorm query object in HVSReport Search function.")
-		}
-
-		rows, err := tx.Rows()
-		if err != nil {
-			return nil, errors.Wrap(err, "postgres/report_store:Search() failed to retrieve records from db")
-		}
-		defer func() {
-			derr := rows.Close()
-			if derr != nil {
-				defaultLog.WithError(derr).Error("Error closing rows")
-			}
-		}()
-
-		var reports []models.HVSReport
-		for rows.Next() {
-			result := models.AuditLogEntry{}
-			if err := rows.Scan(&result.ID, &result.EntityID, &result.EntityType, &result.CreatedAt, &result.Action, (*PGAuditLogData)(&result.Data), &result.RowId); err != nil {
-				return nil, errors.Wrap(err, "postgres/report_store:Search() failed to scan record")
-			}
-			if reflect.DeepEqual(models.AuditTableData{}, result.Data) || len(result.Data.Columns) == 0 {
-				continue
-			}
-			hvsReport, err := auditlogEntryToReport(result)
-			if err != nil {
-				return nil, errors.Wrap(err, "postgres/report_store:Search() convert auditloag entry into report")
-			}
-			reports = append(reports, *hvsReport)
-		}
-
-		return reports, nil
+		return buildLatestReportSearchQuery(tx, reportID, hostID, hostHardwareUUID, hostName, hostStatus), false
 	}
+	return buildReportSearchQuery(tx, hostID, hostHardwareUUID, hostName, hostStatus, fromDate, toDate, latestPerHost), true
 }
 
 // FindHostIdsFromExpiredReports searches the report table for reports that have an
@@ -268,57 +296,129 @@ func (r *ReportStore) FindHostIdsFromExpiredReports(fromTime time.Time, toTime t
 	return hostIDs, nil
 }
 
-func auditlogEntryToReport(auRecord models.AuditLogEntry) (*models.HVSReport, error) {
-	defaultLog.Trace("postgres/report_store:auditlogEntryToReport() Entering")
-	defer defaultLog.Trace("postgres/report_store:auditlogEntryToReport() Leaving")
+// SearchReportTimes fetches the creation and expiration times of the current reports of the hosts
+func (r *ReportStore) SearchReportTimes(hostIDs []uuid.UUID) ([]models.HVSReport, error) {
+	defaultLog.Trace("postgres/report_store:SearchReportTimes() Entering")
+	defer defaultLog.Trace("postgres/report_store:SearchReportTimes() Leaving")
 
-	var hvsReport models.HVSReport
-
-	if auRecord.EntityID != uuid.Nil {
-		hvsReport.ID = auRecord.EntityID
+	var reports []models.HVSReport
+	if len(hostIDs) == 0 {
+		return reports, nil
 	}
-	// TODO remove duplicate data: first column and the entityID are both same
-	if !reflect.DeepEqual(models.AuditColumnData{}, auRecord.Data.Columns[1]) && auRecord.Data.Columns[1].Value != nil {
-		hostId, err := uuid.Parse(fmt.Sprintf("%v", auRecord.Data.Columns[1].Value))
-		if err != nil {
-			return nil, errors.Wrap(err, "postgres/report_store:auditlogEntryToReport() - parsing hostid failed")
-		}
-		hvsReport.HostID = hostId
+	rows, err := r.Store.Db.Model(&report{}).Select("id, host_id, created, expiration").Where("host_id IN (?)", hostIDs).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/report_store:SearchReportTimes() failed to retrieve records from db")
 	}
-
-	if !reflect.DeepEqual(models.AuditColumnData{}, auRecord.Data.Columns[2]) && auRecord.Data.Columns[2].Value != nil {
-		c, err := json.Marshal(auRecord.Data.Columns[2].Value)
-		if err != nil {
-			return nil, errors.Wrap(err, "postgres/reports_store:auditlogEntryToReport() - marshalling failed")
-		}
-		err = json.Unmarshal(c, &hvsReport.TrustReport)
-		if err != nil {
-			return nil, errors.Wrap(err, "postgres/reports_store:auditlogEntryToReport() - unmarshalling failed")
+	defer func() {
+		derr := rows.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing rows")
 		}
-	}
+	}()
 
-	var err error
-	if !reflect.DeepEqual(models.AuditColumnData{}, auRecord.Data.Columns[3]) && auRecord.Data.Columns[3].Value != nil {
-		createdString := fmt.Sprintf("%v", auRecord.Data.Columns[3].Value)
-		hvsReport.CreatedAt, err = time.Parse(time.RFC3339Nano, createdString)
-		if err != nil {
-			return nil, errors.Wrap(err, "postgres/reports_store:auditlogEntryToReport() - error parsing time")
+	for rows.Next() {
+		result := models.HVSReport{}
+		if err := rows.Scan(&result.ID, &result.HostID, &result.CreatedAt, &result.Expiration); err != nil {
+			return nil, errors.Wrap(err, "postgres/report_store:SearchReportTimes() failed to scan record")
 		}
+		reports = append(reports, result)
 	}
+	return reports, nil
+}
 
-	if !reflect.DeepEqual(models.AuditColumnData{}, auRecord.Data.Columns[4]) && auRecord.Data.Columns[4].Value != nil {
-		expString := fmt.Sprintf("%v", auRecord.Data.Columns[4].Value)
-		hvsReport.Expiration, err = time.Parse(time.RFC3339Nano, expString)
-		if err != nil {
-			return nil, errors.Wrap(err, "postgres/reports_store:auditlogEntryToReport() - error parsing time")
-		}
-	}
+// RetrieveFromHistory fetches the report for a given Id. The reports replaced by a newer report of the host are
+// retrieved from the report history
+func (r *ReportStore) RetrieveFromHistory(reportId uuid.UUID) (*models.HVSReport, error) {
+	defaultLog.Trace("postgres/report_store:RetrieveFromHistory() Entering")
+	defer defaultLog.Trace("postgres/report_store:RetrieveFromHistory() Leaving")
 
-	if !reflect.DeepEqual(models.AuditColumnData{}, auRecord.Data.Columns[5]) && auRecord.Data.Columns[5].Value != nil {
-		hvsReport.Saml = fmt.Sprintf("%v", auRecord.Data.Columns[5].Value)
+	re, err := r.Retrieve(reportId)
+	if err == nil || !strings.Contains(err.Error(), commErr.RowsNotFound) {
+		return re, err
 	}
 
-	return &hvsReport, nil
+	row := r.Store.Db.Model(&reportHistory{}).Where(&reportHistory{ID: reportId}).Row()
+	return scanReportHistory(row)
+}
+
+// RetrievePrevious fetches the report of the host created before the given report from the report history
+func (r *ReportStore) RetrievePrevious(re *models.HVSReport) (*models.HVSReport, error) {
+	defaultLog.Trace("postgres/report_store:RetrievePrevious() Entering")
+	defer defaultLog.Trace("postgres/report_store:RetrievePrevious() Leaving")
+
+	row := r.Store.Db.Model(&reportHistory{}).
+		Where("id != ? AND host_id = ? AND created < ?", re.ID, re.HostID, re.CreatedAt).
+		Order("created desc").Limit(1).Row()
+	return scanReportHistory(row)
+}
+
+// reportHistoryPurgeQuery deletes the oldest batch of purgeable reports from the report history. The reports of a
+// host are ranked from the newest including the current report, the KeepLatestPerHost newest reports and the newest
+// report of each trust status are kept, unless the host has been deleted. The audit log is left untouched.
+const reportHistoryPurgeQuery = `WITH history AS (
+	SELECT rh.id, rh.host_id, rh.created,
+		row_number() OVER (PARTITION BY rh.host_id ORDER BY rh.created DESC) AS host_rank,
+		row_number() OVER (PARTITION BY rh.host_id, rh.trusted ORDER BY rh.created DESC) AS trust_rank
+	FROM report_history rh
+), purged AS (
+	DELETE FROM report_history WHERE id IN (
+		SELECT h.id FROM history h
+		WHERE h.created < ? AND h.id NOT IN (SELECT r.id FROM report r)
+		AND ((h.host_rank > ? AND h.trust_rank > 1) OR h.host_id NOT IN (SELECT ho.id FROM host ho))
+		ORDER BY h.created LIMIT ?)
+	RETURNING id, host_id, trust_report, trusted, created, expiration, saml
+)%s
+SELECT count(*) FROM purged`
+
+// reportHistoryArchiveQuery copies the reports deleted by reportHistoryPurgeQuery to the report archive
+const reportHistoryArchiveQuery = `, archived AS (
+	INSERT INTO report_archive (id, host_id, trust_report, trusted, created, expiration, saml, archived)
+	SELECT p.id, p.host_id, p.trust_report, p.trusted, p.created, p.expiration, p.saml, now()
+	FROM purged p ON CONFLICT (id) DO NOTHING
+)`
+
+// reportHistoryMigrationQuery copies the report history recorded in the audit log to the report history table
+const reportHistoryMigrationQuery = `INSERT INTO report_history (id, host_id, trust_report, trusted, created, expiration, saml)
+	SELECT au.entity_id, CAST(au.data -> 'Columns' -> 1 ->> 'Value' AS uuid), au.data -> 'Columns' -> 2 -> 'Value',
+		COALESCE(CAST(au.data -> 'Columns' -> 2 -> 'Value' ->> 'trusted' AS boolean), false),
+		CAST(au.data -> 'Columns' -> 3 ->> 'Value' AS TIMESTAMPTZ), CAST(au.data -> 'Columns' -> 4 ->> 'Value' AS TIMESTAMPTZ),
+		COALESCE(au.data -> 'Columns' -> 5 ->> 'Value', '')
+	FROM audit_log_entry au
+	WHERE au.entity_type = 'report' AND au.action = 'create' AND au.data -> 'Columns' -> 4 ->> 'Value' IS NOT NULL
+	ORDER BY au.created
+	ON CONFLICT (id) DO NOTHING`
+
+// PurgeHistory deletes a batch of the reports replaced by a newer report of the host from the report history, the
+// deleted reports are copied to the report archive when requested
+func (r *ReportStore) PurgeHistory(criteria *models.ReportPurgeCriteria) (int, error) {
+	defaultLog.Trace("postgres/report_store:PurgeHistory() Entering")
+	defer defaultLog.Trace("postgres/report_store:PurgeHistory() Leaving")
+
+	if criteria == nil || criteria.BatchSize <= 0 {
+		return 0, errors.New("postgres/report_store:PurgeHistory() A positive batch size must be specified")
+	}
+	archiveQuery := ""
+	if criteria.Archive {
+		archiveQuery = reportHistoryArchiveQuery
+	}
+
+	// the reports are deleted and archived in a single statement so that a failed batch leaves the history unchanged
+	purged := 0
+	row := r.Store.Db.Raw(fmt.Sprintf(reportHistoryPurgeQuery, archiveQuery),
+		criteria.CreatedBefore, criteria.KeepLatestPerHost, criteria.BatchSize).Row()
+	if err := row.Scan(&purged); err != nil {
+		return 0, errors.Wrap(err, "postgres/report_store:PurgeHistory() failed to purge reports")
+	}
+	return purged, nil
+}
+
+func scanReportHistory(row *sql.Row) (*models.HVSReport, error) {
+	re := models.HVSReport{}
+	ignoreMe := false
+	if err := row.Scan(&re.ID, &re.HostID, (*PGTrustReport)(&re.TrustReport), &ignoreMe, &re.CreatedAt, &re.Expiration, &re.Saml, &re.RowId); err != nil {
+		return nil, errors.Wrap(err, "postgres/report_store:scanReportHistory() failed to scan record")
+	}
+	return &re, nil
 }
 
 // Delete method deletes report for a given Id
@@ -342,51 +442,40 @@ func (r *ReportStore) Delete(reportId uuid.UUID) error {
 	return nil
 }
 
-// buildReportSearchQuery is a helper function to build the query object for a report search.
-func buildReportSearchQuery(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, hostName, hostState string, fromDate, toDate time.Time, latestPerHost bool, limit int, afterId int) *gorm.DB {
+// buildReportSearchQuery is a helper function to build the query object for a report history search.
+func buildReportSearchQuery(tx *gorm.DB, hostID, hostHardwareID uuid.UUID, hostName, hostState string, fromDate, toDate time.Time, latestPerHost bool) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildReportSearchQuery() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildReportSearchQuery() Leaving")
 	if tx == nil {
 		return nil
 	}
 	if latestPerHost {
-		entity := "auj"
-		txSubQuery := tx.Table("audit_log_entry auj").Select("data -> 'Columns' -> 1 ->> 'Value' AS host_id, max(auj.created) AS max_date ")
-		txSubQuery = buildReportSearchQueryWithCriteria(txSubQuery, hostHardwareID, hostID, entity, hostName, hostState, fromDate, toDate)
-		txSubQuery = txSubQuery.Group("host_id")
+		entity := "rhj"
+		txSubQuery := tx.Table("report_history rhj").Select("rhj.host_id, max(rhj.created) AS max_date")
+		txSubQuery = buildReportSearchQueryWithCriteria(txSubQuery, hostID, hostHardwareID, entity, hostName, hostState, fromDate, toDate)
+		txSubQuery = txSubQuery.Group("rhj.host_id")
 		subQuery := txSubQuery.SubQuery()
-		tx = tx.Table("audit_log_entry au").Select("au.*").Joins("INNER JOIN ? a ON a.host_id = au.data -> 'Columns' -> 1 ->> 'Value' AND a.max_date = au.created", subQuery)
+		tx = tx.Table("report_history rh").Select("rh.*").Joins("INNER JOIN ? a ON a.host_id = rh.host_id AND a.max_date = rh.created", subQuery)
 	} else {
-		entity := "au"
-		tx = tx.Table("audit_log_entry au").Select("au.*")
-		tx = buildReportSearchQueryWithCriteria(tx, hostHardwareID, hostID, entity, hostName, hostState, fromDate, toDate)
-	}
-
-	if afterId > 0 {
-		tx = tx.Where("rowid > ?", afterId)
-	}
-	tx = tx.Order("rowid asc")
-	if limit > 0 {
-		tx = tx.Limit(limit)
+		entity := "rh"
+		tx = tx.Table("report_history rh").Select("rh.*")
+		tx = buildReportSearchQueryWithCriteria(tx, hostID, hostHardwareID, entity, hostName, hostState, fromDate, toDate)
 	}
 	return tx
 }
 
-func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid.UUID, entity, hostName string, hostState string, fromDate, toDate time.Time) *gorm.DB {
+func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostID, hostHardwareID uuid.UUID, entity, hostName string, hostState string, fromDate, toDate time.Time) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildReportSearchQueryWithCriteria() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildReportSearchQueryWithCriteria() Leaving")
 
 	if hostState != "" {
-		tx = tx.Joins("INNER JOIN host_status hs on CAST(hs.host_id AS VARCHAR) = " + entity + ".data -> 'Columns' -> 1 ->> 'Value'")
+		tx = tx.Joins("INNER JOIN host_status hs on hs.host_id = " + entity + ".host_id")
 	}
 
 	if hostName != "" || hostHardwareID != uuid.Nil {
-		tx = tx.Joins("INNER JOIN host h on CAST(h.id AS VARCHAR) = " + entity + ".data -> 'Columns' -> 1 ->> 'Value'")
+		tx = tx.Joins("INNER JOIN host h on h.id = " + entity + ".host_id")
 	}
 
-	//TODO rename after testing
-	tx = tx.Where(entity + ".entity_type = 'report'")
-
 	if hostName != "" {
 		tx = tx.Where("h.name = ?", hostName)
 	}
@@ -396,7 +485,7 @@ func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid
 	}
 
 	if hostID != uuid.Nil {
-		tx = tx.Where(entity+".data -> 'Columns' -> 1 ->> 'Value' = ?", hostID.String())
+		tx = tx.Where(entity+".host_id = ?", hostID.String())
 	}
 
 	if hostState != "" {
@@ -404,18 +493,18 @@ func buildReportSearchQueryWithCriteria(tx *gorm.DB, hostHardwareID, hostID uuid
 	}
 
 	if !fromDate.IsZero() {
-		tx = tx.Where("CAST("+entity+".created AS TIMESTAMP) >= CAST(? AS TIMESTAMP)", fromDate)
+		tx = tx.Where(entity+".created >= ?", fromDate)
 	}
 
 	if !toDate.IsZero() {
-		tx = tx.Where("CAST("+entity+".created AS TIMESTAMP) < CAST(? AS TIMESTAMP)", toDate)
+		tx = tx.Where(entity+".created < ?", toDate)
 	}
 
 	return tx
 }
 
 // buildLatestReportSearchQuery is a helper function to build the query object for a latest report search.
-func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID uuid.UUID, hostName, hostState string, limit int, afterId int) *gorm.DB {
+func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID uuid.UUID, hostName, hostState string) *gorm.DB {
 	defaultLog.Trace("postgres/report_store:buildLatestReportSearchQuery() Entering")
 	defer defaultLog.Trace("postgres/report_store:buildLatestReportSearchQuery() Leaving")
 
@@ -451,15 +540,5 @@ func buildLatestReportSearchQuery(tx *gorm.DB, reportID, hostID, hostHardwareID
 		tx = tx.Where("host_id = ?", hostID.String())
 	}
 
-	if afterId > 0 {
-		tx = tx.Where("rowid > ?", afterId)
-	}
-
-	tx = tx.Order("rowid asc")
-
-	if limit > 0 {
-		tx = tx.Limit(limit)
-	}
-
 	return tx
 }
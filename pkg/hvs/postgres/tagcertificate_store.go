@@ -66,6 +66,19 @@ func (tcs *TagCertificateStore) Search(tcFilter *models.TagCertificateFilterCrit
 			" a gorm query object in TagCertificate Search function.")
 	}
 
+	// TagCertificates are ordered by subject unless requested otherwise
+	pageCriteria := models.PageCriteria{SortBy: "subject"}
+	if tcFilter != nil {
+		pageCriteria = tcFilter.PageCriteria
+		if pageCriteria.SortBy == "" {
+			pageCriteria.SortBy = "subject"
+		}
+	}
+	tx, err := buildPageQuery(tx, pageCriteria, "tag_certificate", "tag_certificate", models.TagCertificateSortFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/tagcertificate_store:Search() failed to build page query")
+	}
+
 	rows, err := tx.Rows()
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/tagcertificate_store:Search() failed to retrieve records from db")
@@ -79,7 +92,7 @@ func (tcs *TagCertificateStore) Search(tcFilter *models.TagCertificateFilterCrit
 
 	for rows.Next() {
 		hvsTC := hvs.TagCertificate{}
-		if err := rows.Scan(&hvsTC.ID, &hvsTC.HardwareUUID, &hvsTC.Certificate, &hvsTC.Subject, &hvsTC.Issuer, &hvsTC.NotBefore, &hvsTC.NotAfter); err != nil {
+		if err := rows.Scan(&hvsTC.ID, &hvsTC.HardwareUUID, &hvsTC.Certificate, &hvsTC.Subject, &hvsTC.Issuer, &hvsTC.NotBefore, &hvsTC.NotAfter, &hvsTC.RowId); err != nil {
 			return nil, errors.Wrap(err, "postgres/tagcertificate_store:Search() failed to scan record")
 		}
 		tcResultSet = append(tcResultSet, &hvsTC)
@@ -88,6 +101,19 @@ func (tcs *TagCertificateStore) Search(tcFilter *models.TagCertificateFilterCrit
 	return tcResultSet, nil
 }
 
+// Count returns the number of TagCertificates matching the TagCertificateFilterCriteria, the page of the criteria
+// is not applied
+func (tcs *TagCertificateStore) Count(tcFilter *models.TagCertificateFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/tagcertificate_store:Count() Entering")
+	defer defaultLog.Trace("postgres/tagcertificate_store:Count() Leaving")
+
+	count, err := countRecords(buildTagCertificateSearchQuery(tcs.Store.Db, tcFilter))
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/tagcertificate_store:Count() failed to count TagCertificates")
+	}
+	return count, nil
+}
+
 // Retrieve returns a single TagCertificate record by unique ID
 func (tcs *TagCertificateStore) Retrieve(tagCertId uuid.UUID) (*hvs.TagCertificate, error) {
 	defaultLog.Trace("postgres/tagcertificate_store:Retrieve() Entering")
@@ -95,7 +121,7 @@ func (tcs *TagCertificateStore) Retrieve(tagCertId uuid.UUID) (*hvs.TagCertifica
 
 	hvsTC := hvs.TagCertificate{}
 	row := tcs.Store.Db.Model(&tagCertificate{}).Where(&tagCertificate{ID: tagCertId}).Row()
-	if err := row.Scan(&hvsTC.ID, &hvsTC.HardwareUUID, &hvsTC.Certificate, &hvsTC.Subject, &hvsTC.Issuer, &hvsTC.NotBefore, &hvsTC.NotAfter); err != nil {
+	if err := row.Scan(&hvsTC.ID, &hvsTC.HardwareUUID, &hvsTC.Certificate, &hvsTC.Subject, &hvsTC.Issuer, &hvsTC.NotBefore, &hvsTC.NotAfter, &hvsTC.RowId); err != nil {
 		return nil, errors.Wrap(err, "postgres/tagcertificate_store:Retrieve() failed to scan record")
 	}
 	return &hvsTC, nil
@@ -124,7 +150,7 @@ func buildTagCertificateSearchQuery(tx *gorm.DB, tcFilter *models.TagCertificate
 	if tcFilter == nil {
 		defaultLog.Info("postgres/tagcertificate_store:buildTagCertificateSearchQuery() No criteria specified in search query" +
 			". Returning all rows.")
-		return tx.Order("subject")
+		return tx
 	}
 
 	// Tag Certificate ID
@@ -172,8 +198,5 @@ func buildTagCertificateSearchQuery(tx *gorm.DB, tcFilter *models.TagCertificate
 		tx = tx.Where("CAST(? as timestamp) <= notafter", validAfterTs)
 	}
 
-	// ORDER BY
-	tx = tx.Order("subject")
-
 	return tx
 }
@@ -99,6 +99,18 @@ func (t *TpmEndorsementStore) Search(teFilter *models.TpmEndorsementFilterCriter
 			" a gorm query object in Endorsement Search function.")
 	}
 
+	var pageCriteria models.PageCriteria
+	if teFilter != nil {
+		pageCriteria = teFilter.PageCriteria
+		if pageCriteria.Limit == 0 {
+			pageCriteria.Limit = consts.Limit
+		}
+	}
+	tx, err := buildPageQuery(tx, pageCriteria, "tpm_endorsement", "tpm_endorsement", models.TpmEndorsementSortFields)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres/tpm_endorsement_store:Search() failed to build page query")
+	}
+
 	rows, err := tx.Rows()
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres/tpm_endorsement_store:Search() failed to retrieve tpm_endorsements from db")
@@ -123,6 +135,18 @@ func (t *TpmEndorsementStore) Search(teFilter *models.TpmEndorsementFilterCriter
 	return &tpmEndorsementCollection, nil
 }
 
+// Count returns the number of TpmEndorsements matching the filter criteria, the page of the criteria is not applied
+func (t *TpmEndorsementStore) Count(teFilter *models.TpmEndorsementFilterCriteria) (int, error) {
+	defaultLog.Trace("postgres/tpm_endorsement_store:Count() Entering")
+	defer defaultLog.Trace("postgres/tpm_endorsement_store:Count() Leaving")
+
+	count, err := countRecords(buildTpmEndorsementSearchQuery(t.Store.Db, teFilter))
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres/tpm_endorsement_store:Count() failed to count TpmEndorsements")
+	}
+	return count, nil
+}
+
 func buildTpmEndorsementSearchQuery(tx *gorm.DB, teFilter *models.TpmEndorsementFilterCriteria) *gorm.DB {
 	defaultLog.Trace("postgres/tpm_endorsement_store:buildTpmEndorsementSearchQuery() Entering")
 	defer defaultLog.Trace("postgres/tpm_endorsement_store:buildTpmEndorsementSearchQuery() Leaving")
@@ -152,16 +176,5 @@ func buildTpmEndorsementSearchQuery(tx *gorm.DB, teFilter *models.TpmEndorsement
 	} else if teFilter.CertificateDigestEqualTo != "" {
 		tx = tx.Where("certificate_digest = ? ", teFilter.CertificateDigestEqualTo)
 	}
-	if teFilter.Limit == 0 {
-		teFilter.Limit = consts.Limit
-	}
-
-	if teFilter.AfterId > 0 {
-		tx = tx.Where("rowid > ?", teFilter.AfterId)
-	}
-	tx = tx.Order("rowid asc")
-	if teFilter.Limit > 0 {
-		tx = tx.Limit(teFilter.Limit)
-	}
 	return tx
 }
@@ -0,0 +1,54 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/validation"
+)
+
+// SetAttestationScheduleRoutes registers routes for the attestation schedules of flavorgroups
+func SetAttestationScheduleRoutes(router *mux.Router, store *postgres.DataStore, fgs domain.FlavorGroupStore, attestationScheduler domain.AttestationScheduler) *mux.Router {
+	defaultLog.Trace("router/attestation_schedules:SetAttestationScheduleRoutes() Entering")
+	defer defaultLog.Trace("router/attestation_schedules:SetAttestationScheduleRoutes() Leaving")
+
+	attestationScheduleStore := postgres.NewAttestationScheduleStore(store)
+	attestationScheduleController := controllers.NewAttestationScheduleController(attestationScheduleStore, fgs, attestationScheduler)
+
+	scheduleIdExpr := fmt.Sprintf("%s%s", "/attestation-schedules/", validation.IdReg)
+
+	router.Handle("/attestation-schedules",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(attestationScheduleController.Create),
+			[]string{constants.AttestationScheduleCreate}))).Methods(http.MethodPost)
+
+	router.Handle("/attestation-schedules",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(attestationScheduleController.Search),
+			[]string{constants.AttestationScheduleSearch}))).Methods(http.MethodGet)
+
+	router.Handle(scheduleIdExpr,
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(attestationScheduleController.Retrieve),
+			[]string{constants.AttestationScheduleRetrieve}))).Methods(http.MethodGet)
+
+	router.Handle(scheduleIdExpr,
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(attestationScheduleController.Update),
+			[]string{constants.AttestationScheduleUpdate}))).Methods(http.MethodPut)
+
+	router.Handle(scheduleIdExpr,
+		ErrorHandler(PermissionsHandler(ResponseHandler(attestationScheduleController.Delete),
+			[]string{constants.AttestationScheduleDelete}))).Methods(http.MethodDelete)
+
+	router.Handle(scheduleIdExpr+"/hosts",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(attestationScheduleController.SearchScheduledAttestations),
+			[]string{constants.AttestationScheduleRetrieve}))).Methods(http.MethodGet)
+
+	return router
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/postgres"
+)
+
+// SetAuditLogRoutes registers routes for audit logs
+func SetAuditLogRoutes(router *mux.Router, store *postgres.DataStore) *mux.Router {
+	defaultLog.Trace("router/audit_logs:SetAuditLogRoutes() Entering")
+	defer defaultLog.Trace("router/audit_logs:SetAuditLogRoutes() Leaving")
+
+	auditLogEntryStore := postgres.NewAuditLogEntryStore(store)
+	auditLogController := controllers.NewAuditLogController(auditLogEntryStore)
+
+	router.Handle("/audit-logs",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(auditLogController.Search),
+			[]string{constants.AuditLogSearch}))).Methods(http.MethodGet)
+
+	return router
+}
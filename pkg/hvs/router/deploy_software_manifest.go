@@ -26,8 +26,9 @@ func SetDeploySoftwareManifestRoute(router *mux.Router, store *postgres.DataStor
 	hostStatusStore := postgres.NewHostStatusStore(store)
 
 	hostCredentialStore := postgres.NewHostCredentialStore(store, hcConfig.DataEncryptionKey)
+	hostRegistrationStore := postgres.NewHostRegistrationStore(store, hcConfig.DataEncryptionKey)
 	hc := controllers.NewHostController(hostStore, hostStatusStore, flavorStore,
-		flavorGroupStore, hostCredentialStore, htm, hcConfig)
+		flavorGroupStore, hostCredentialStore, hostRegistrationStore, htm, hcConfig)
 	dsmController := controllers.NewDeploySoftwareManifestController(flavorStore, *hc)
 
 	router.Handle("/rpc/deploy-software-manifest",
@@ -30,8 +30,9 @@ func SetESXiClusterRoutes(router *mux.Router, store *postgres.DataStore,
 	flavorStore := postgres.NewFlavorStore(store)
 	flavorGroupStore := postgres.NewFlavorGroupStore(store)
 	hostCredentialStore := postgres.NewHostCredentialStore(store, hostControllerConfig.DataEncryptionKey)
+	hostRegistrationStore := postgres.NewHostRegistrationStore(store, hostControllerConfig.DataEncryptionKey)
 	hc := controllers.NewHostController(hostStore, hostStatusStore, flavorStore,
-		flavorGroupStore, hostCredentialStore, hostTrustManager, hostControllerConfig)
+		flavorGroupStore, hostCredentialStore, hostRegistrationStore, hostTrustManager, hostControllerConfig)
 	esxiClusterController := controllers.NewESXiClusterController(esxiClusterStore, *hc)
 
 	esxiClusterIdExpr := fmt.Sprintf("%s%s", "/esxi-cluster/", validation.IdReg)
@@ -0,0 +1,44 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
+)
+
+// SetFlavorImpactAnalysisRoute registers the route for the API that evaluates candidate flavors against the hosts
+// linked to a flavorgroup
+func SetFlavorImpactAnalysisRoute(router *mux.Router, store *postgres.DataStore, flavorGroupStore domain.FlavorGroupStore,
+	certStore *crypt.CertificatesStore) *mux.Router {
+	defaultLog.Trace("router/flavor_impact_analysis:SetFlavorImpactAnalysisRoute() Entering")
+	defer defaultLog.Trace("router/flavor_impact_analysis:SetFlavorImpactAnalysisRoute() Leaving")
+
+	flavorVerifier, err := utils.NewFlavorVerifier(certStore)
+	if err != nil {
+		defaultLog.WithError(err).Error("router/flavor_impact_analysis:SetFlavorImpactAnalysisRoute() Failed to " +
+			"initialize flavor verifier, flavor impact analysis API is not available")
+		return router
+	}
+
+	hostStore := postgres.NewHostStore(store)
+	hostStatusStore := postgres.NewHostStatusStore(store)
+	reportStore := postgres.NewReportStore(store)
+	flavorImpactAnalysisController := controllers.NewFlavorImpactAnalysisController(flavorGroupStore, hostStore,
+		hostStatusStore, reportStore, certStore, flavorVerifier)
+
+	router.Handle("/rpc/flavor-impact-analysis",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorImpactAnalysisController.Analyze),
+			[]string{constants.FlavorImpactAnalysis}))).Methods(http.MethodPost)
+
+	return router
+}
@@ -69,5 +69,10 @@ func SetFlavorGroupRoutes(router *mux.Router, store *postgres.DataStore, flavorg
 		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorgroupController.SearchFlavors),
 			[]string{constants.FlavorGroupSearch}))).Methods(http.MethodGet)
 
+	fgPolicyRulesExpr := fmt.Sprintf("/flavorgroups/{fgID:%s}/policy-rules", validation.UUIDReg)
+	router.Handle(fgPolicyRulesExpr,
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorgroupController.UpdatePolicyRules),
+			[]string{constants.FlavorGroupCreate}))).Methods(http.MethodPut)
+
 	return router
 }
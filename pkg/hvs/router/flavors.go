@@ -28,6 +28,7 @@ func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupS
 	tagCertStore := postgres.NewTagCertificateStore(store)
 	flavorTemplateStore := postgres.NewFlavorTemplateStore(store)
 	flavorController := controllers.NewFlavorController(flavorStore, flavorGroupStore, hostStore, tagCertStore, hostTrustManager, certStore, flavorControllerConfig, flavorTemplateStore)
+	flavorController.FBStore = postgres.NewFlavorBundleStore(store, flavorGroupStore)
 
 	flavorIdExpr := fmt.Sprintf("%s%s", "/flavors/", validation.IdReg)
 
@@ -40,6 +41,14 @@ func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupS
 		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorController.Search),
 			[]string{constants.FlavorSearch}))).Methods(http.MethodGet)
 
+	router.Handle("/flavors/export",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorController.Export),
+			[]string{constants.FlavorExport}))).Methods(http.MethodGet)
+
+	router.Handle("/flavors/import",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorController.Import),
+			[]string{constants.FlavorImport}))).Methods(http.MethodPost)
+
 	router.Handle(flavorIdExpr,
 		ErrorHandler(PermissionsHandler(ResponseHandler(flavorController.Delete),
 			[]string{constants.FlavorDelete}))).Methods(http.MethodDelete)
@@ -48,5 +57,13 @@ func SetFlavorRoutes(router *mux.Router, store *postgres.DataStore, flavorGroupS
 		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorController.Retrieve),
 			[]string{constants.FlavorRetrieve}))).Methods(http.MethodGet)
 
+	router.Handle(flavorIdExpr,
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorController.Update),
+			[]string{constants.FlavorUpdate}))).Methods(http.MethodPut)
+
+	router.Handle(flavorIdExpr+"/revisions",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorController.SearchRevisions),
+			[]string{constants.FlavorRetrieve}))).Methods(http.MethodGet)
+
 	return router
 }
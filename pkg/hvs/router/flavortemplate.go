@@ -22,8 +22,9 @@ func SetFlavorTemplateRoutes(router *mux.Router, store *postgres.DataStore, flav
 	defer defaultLog.Trace("router/flavortemplate_creation:SetFlavorTemplateRoutes() Leaving")
 
 	flavorTemplateStore := postgres.NewFlavorTemplateStore(store)
+	hostStatusStore := postgres.NewHostStatusStore(store)
 
-	flavorTemplateController := controllers.NewFlavorTemplateController(flavorTemplateStore, flavorGroupStore, constants.CommonDefinitionsSchema, constants.FlavorTemplateSchema)
+	flavorTemplateController := controllers.NewFlavorTemplateController(flavorTemplateStore, flavorGroupStore, hostStatusStore, constants.CommonDefinitionsSchema, constants.FlavorTemplateSchema)
 
 	flavorTemplateIdExpr := fmt.Sprintf("%s/{ftId:%s}", "/flavor-templates", validation.UUIDReg)
 	flavorgroupExpr := fmt.Sprintf("%s/flavorgroups", flavorTemplateIdExpr)
@@ -33,6 +34,10 @@ func SetFlavorTemplateRoutes(router *mux.Router, store *postgres.DataStore, flav
 		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorTemplateController.Create),
 			[]string{constants.FlavorTemplateCreate}))).Methods(http.MethodPost)
 
+	router.Handle("/flavor-templates/validate",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorTemplateController.Validate),
+			[]string{constants.FlavorTemplateValidate}))).Methods(http.MethodPost)
+
 	router.Handle(flavorTemplateIdExpr,
 		ErrorHandler(PermissionsHandler(JsonResponseHandler(flavorTemplateController.Retrieve),
 			[]string{constants.FlavorTemplateRetrieve}))).Methods(http.MethodGet)
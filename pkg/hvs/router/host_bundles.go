@@ -0,0 +1,28 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+)
+
+// SetHostBundleRoutes registers the route used by hosts to push their host bundle
+func SetHostBundleRoutes(router *mux.Router, maxAge time.Duration) *mux.Router {
+	defaultLog.Trace("router/host_bundles:SetHostBundleRoutes() Entering")
+	defer defaultLog.Trace("router/host_bundles:SetHostBundleRoutes() Leaving")
+
+	hostBundleController := controllers.NewHostBundleController(constants.HostBundlesDir, maxAge)
+
+	router.Handle("/host-bundles",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(hostBundleController.Create),
+			[]string{constants.HostBundleCreate}))).Methods(http.MethodPost)
+
+	return router
+}
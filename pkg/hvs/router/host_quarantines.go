@@ -0,0 +1,42 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/validation"
+)
+
+// SetHostQuarantineRoutes registers routes for the remediation state of the hosts with untrusted reports
+func SetHostQuarantineRoutes(router *mux.Router, store *postgres.DataStore, remediationManager domain.RemediationManager) *mux.Router {
+	defaultLog.Trace("router/host_quarantines:SetHostQuarantineRoutes() Entering")
+	defer defaultLog.Trace("router/host_quarantines:SetHostQuarantineRoutes() Leaving")
+
+	hostQuarantineStore := postgres.NewHostQuarantineStore(store)
+	hostQuarantineController := controllers.NewHostQuarantineController(hostQuarantineStore, remediationManager)
+
+	hostIdExpr := fmt.Sprintf("%s{hostId:%s}", "/host-quarantines/", validation.UUIDReg)
+
+	router.Handle("/host-quarantines",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(hostQuarantineController.Search),
+			[]string{constants.HostQuarantineSearch}))).Methods(http.MethodGet)
+
+	router.Handle(hostIdExpr,
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(hostQuarantineController.Retrieve),
+			[]string{constants.HostQuarantineRetrieve}))).Methods(http.MethodGet)
+
+	router.Handle(hostIdExpr+"/release",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(hostQuarantineController.Release),
+			[]string{constants.HostQuarantineRelease}))).Methods(http.MethodPost)
+
+	return router
+}
@@ -26,18 +26,22 @@ func SetHostRoutes(router *mux.Router, store *postgres.DataStore, hostTrustManag
 	flavorStore := postgres.NewFlavorStore(store)
 	flavorGroupStore := postgres.NewFlavorGroupStore(store)
 	hostCredentialStore := postgres.NewHostCredentialStore(store, hostControllerConfig.DataEncryptionKey)
+	hostRegistrationStore := postgres.NewHostRegistrationStore(store, hostControllerConfig.DataEncryptionKey)
 
 	hostController := controllers.NewHostController(hostStore, hostStatusStore,
-		flavorStore, flavorGroupStore, hostCredentialStore,
+		flavorStore, flavorGroupStore, hostCredentialStore, hostRegistrationStore,
 		hostTrustManager, hostControllerConfig)
 
 	hostExpr := "/hosts"
+	hostBulkExpr := fmt.Sprintf("%s/bulk", hostExpr)
 	hostIdExpr := fmt.Sprintf("%s/{hId:%s}", hostExpr, validation.UUIDReg)
 	flavorgroupExpr := fmt.Sprintf("%s/flavorgroups", hostIdExpr)
 	flavorgroupIdExpr := fmt.Sprintf("%s/{fgId:%s}", flavorgroupExpr, validation.UUIDReg)
 
 	router.Handle(hostExpr, ErrorHandler(PermissionsHandler(JsonResponseHandler(hostController.Create),
 		[]string{constants.HostCreate}))).Methods(http.MethodPost)
+	router.Handle(hostBulkExpr, ErrorHandler(PermissionsHandler(JsonResponseHandler(hostController.BulkCreate),
+		[]string{constants.HostCreate}))).Methods(http.MethodPost)
 	router.Handle(hostIdExpr, ErrorHandler(PermissionsHandler(JsonResponseHandler(hostController.Retrieve),
 		[]string{constants.HostRetrieve}))).Methods(http.MethodGet)
 	router.Handle(hostIdExpr, ErrorHandler(PermissionsHandler(JsonResponseHandler(hostController.Update),
@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/postgres"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/validation"
+)
+
+// SetNotificationSubscriptionRoutes registers routes for notification subscriptions
+func SetNotificationSubscriptionRoutes(router *mux.Router, store *postgres.DataStore, dek []byte) *mux.Router {
+	defaultLog.Trace("router/notification_subscriptions:SetNotificationSubscriptionRoutes() Entering")
+	defer defaultLog.Trace("router/notification_subscriptions:SetNotificationSubscriptionRoutes() Leaving")
+
+	notificationSubscriptionStore := postgres.NewNotificationSubscriptionStore(store, dek)
+	notificationSubscriptionController := controllers.NewNotificationSubscriptionController(notificationSubscriptionStore)
+
+	subscriptionIdExpr := fmt.Sprintf("%s%s", "/notification-subscriptions/", validation.IdReg)
+
+	router.Handle("/notification-subscriptions",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(notificationSubscriptionController.Create),
+			[]string{constants.NotificationSubscriptionCreate}))).Methods(http.MethodPost)
+
+	router.Handle("/notification-subscriptions",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(notificationSubscriptionController.Search),
+			[]string{constants.NotificationSubscriptionSearch}))).Methods(http.MethodGet)
+
+	router.Handle(subscriptionIdExpr,
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(notificationSubscriptionController.Retrieve),
+			[]string{constants.NotificationSubscriptionRetrieve}))).Methods(http.MethodGet)
+
+	router.Handle(subscriptionIdExpr,
+		ErrorHandler(PermissionsHandler(ResponseHandler(notificationSubscriptionController.Delete),
+			[]string{constants.NotificationSubscriptionDelete}))).Methods(http.MethodDelete)
+
+	router.Handle(subscriptionIdExpr+"/dead-letters",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(notificationSubscriptionController.SearchDeadLetters),
+			[]string{constants.NotificationSubscriptionRetrieve}))).Methods(http.MethodGet)
+
+	return router
+}
@@ -0,0 +1,28 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+)
+
+// SetReportRetentionRoutes registers the route reporting the status of the report retention
+func SetReportRetentionRoutes(router *mux.Router, reportRetentionManager domain.ReportRetentionManager) *mux.Router {
+	defaultLog.Trace("router/report_retention:SetReportRetentionRoutes() Entering")
+	defer defaultLog.Trace("router/report_retention:SetReportRetentionRoutes() Leaving")
+
+	reportRetentionController := controllers.NewReportRetentionController(reportRetentionManager)
+
+	router.Handle("/report-retention",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(reportRetentionController.Retrieve),
+			[]string{constants.ReportRetentionRetrieve}))).Methods(http.MethodGet)
+
+	return router
+}
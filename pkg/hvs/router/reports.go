@@ -41,10 +41,18 @@ func SetReportRoutes(router *mux.Router, store *postgres.DataStore, hostTrustMan
 		ErrorHandler(PermissionsHandler(ResponseHandler(reportController.SearchSaml),
 			[]string{constants.ReportSearch}))).Methods(http.MethodGet).Headers("Accept", consts.HTTPMediaTypeSaml)
 
+	router.Handle(reportIdExpr,
+		ErrorHandler(PermissionsHandler(ResponseHandler(reportController.RetrieveMarkdown),
+			[]string{constants.ReportRetrieve}))).Methods(http.MethodGet).Headers("Accept", consts.HTTPMediaTypeMarkdown)
+
 	router.Handle(reportIdExpr,
 		ErrorHandler(PermissionsHandler(JsonResponseHandler(reportController.Retrieve),
 			[]string{constants.ReportRetrieve}))).Methods(http.MethodGet)
 
+	router.Handle(reportIdExpr+"/diff",
+		ErrorHandler(PermissionsHandler(JsonResponseHandler(reportController.Diff),
+			[]string{constants.ReportRetrieve}))).Methods(http.MethodGet)
+
 	router.Handle("/reports",
 		ErrorHandler(PermissionsHandler(JsonResponseHandler(reportController.Search),
 			[]string{constants.ReportSearch}))).Methods(http.MethodGet)
@@ -20,6 +20,7 @@ import (
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/postgres"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
 	cmw "github.com/intel-secl/intel-secl/v5/pkg/lib/common/middleware"
 	cos "github.com/intel-secl/intel-secl/v5/pkg/lib/common/os"
 	"github.com/pkg/errors"
@@ -33,7 +34,7 @@ type Router struct {
 }
 
 // InitRoutes registers all routes for the application.
-func InitRoutes(cfg *config.Configuration, dataStore *postgres.DataStore, fgs domain.FlavorGroupStore, certStore *crypt.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig) (*mux.Router, error) {
+func InitRoutes(cfg *config.Configuration, dataStore *postgres.DataStore, fgs domain.FlavorGroupStore, certStore *crypt.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig, reportRetentionManager domain.ReportRetentionManager, attestationScheduler domain.AttestationScheduler, remediationManager domain.RemediationManager) (*mux.Router, error) {
 	defaultLog.Trace("router/router:InitRoutes() Entering")
 	defer defaultLog.Trace("router/router:InitRoutes() Leaving")
 
@@ -42,25 +43,27 @@ func InitRoutes(cfg *config.Configuration, dataStore *postgres.DataStore, fgs do
 
 	// ISECL-8715 - Prevent potential open redirects to external URLs
 	router.SkipClean(true)
+	router.Use(commMetrics.RequestMetrics())
 
-	err := defineSubRoutes(router, constants.OldServiceName, cfg, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
+	err := defineSubRoutes(router, constants.OldServiceName, cfg, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig, reportRetentionManager, attestationScheduler, remediationManager)
 	if err != nil {
 		return nil, errors.Wrap(err, "Could not define sub routes")
 	}
-	err = defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
+	err = defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig, reportRetentionManager, attestationScheduler, remediationManager)
 	if err != nil {
 		return nil, errors.Wrap(err, "Could not define sub routes")
 	}
 	return router, nil
 }
 
-func defineSubRoutes(router *mux.Router, service string, cfg *config.Configuration, dataStore *postgres.DataStore, fgs domain.FlavorGroupStore, certStore *crypt.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig) error {
+func defineSubRoutes(router *mux.Router, service string, cfg *config.Configuration, dataStore *postgres.DataStore, fgs domain.FlavorGroupStore, certStore *crypt.CertificatesStore, hostTrustManager domain.HostTrustManager, hostControllerConfig domain.HostControllerConfig, reportRetentionManager domain.ReportRetentionManager, attestationScheduler domain.AttestationScheduler, remediationManager domain.RemediationManager) error {
 	defaultLog.Trace("router/router:defineSubRoutes() Entering")
 	defer defaultLog.Trace("router/router:defineSubRoutes() Leaving")
 
 	serviceApi := "/" + service + constants.ApiVersion
 	subRouter := router.PathPrefix(serviceApi).Subrouter()
 	subRouter = SetVersionRoutes(subRouter)
+	subRouter = commMetrics.SetMetricsRoutes(subRouter)
 	subRouter = SetCaCertificatesRoutes(subRouter, certStore)
 
 	subRouter = router.PathPrefix(serviceApi).Subrouter()
@@ -80,12 +83,19 @@ func defineSubRoutes(router *mux.Router, service string, cfg *config.Configurati
 	subRouter = SetHostStatusRoutes(subRouter, dataStore)
 	subRouter = SetCertifyHostKeysRoutes(subRouter, certStore)
 	subRouter = SetHostRoutes(subRouter, dataStore, hostTrustManager, hostControllerConfig)
+	subRouter = SetHostBundleRoutes(subRouter, cfg.HostBundleMaxAge)
 	subRouter = SetReportRoutes(subRouter, dataStore, hostTrustManager)
+	subRouter = SetReportRetentionRoutes(subRouter, reportRetentionManager)
 	subRouter = SetCreateCaCertificatesRoutes(subRouter, certStore)
 	subRouter = SetTagCertificateRoutes(subRouter, cfg, fgs, certStore, hostTrustManager, dataStore)
 	subRouter = SetESXiClusterRoutes(subRouter, dataStore, hostTrustManager, hostControllerConfig)
 	subRouter = SetDeploySoftwareManifestRoute(subRouter, dataStore, hostTrustManager, hostControllerConfig)
 	subRouter = SetManifestsRoute(subRouter, dataStore)
+	subRouter = SetAuditLogRoutes(subRouter, dataStore)
+	subRouter = SetFlavorImpactAnalysisRoute(subRouter, dataStore, fgs, certStore)
+	subRouter = SetNotificationSubscriptionRoutes(subRouter, dataStore, hostControllerConfig.DataEncryptionKey)
+	subRouter = SetAttestationScheduleRoutes(subRouter, dataStore, fgs, attestationScheduler)
+	subRouter = SetHostQuarantineRoutes(subRouter, dataStore, remediationManager)
 	return nil
 }
 
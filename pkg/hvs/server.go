@@ -34,10 +34,14 @@ import (
 	hostfetcher "github.com/intel-secl/intel-secl/v5/pkg/hvs/services/host-fetcher"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/hosttrust"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/hrrs"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/notification"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/remediation"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/services/reportretention"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
 	hostconnector "github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/saml"
-	"github.com/intel-secl/intel-secl/v5/pkg/lib/verifier"
+	"github.com/prometheus/client_golang/prometheus"
 
 	commLog "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
 	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
@@ -70,21 +74,37 @@ func (a *App) startServer() error {
 	als := postgres.NewAuditLogEntryStore(dataStore)
 	alw, _ := auditlog.NewAuditLogDBWriter(als, c.AuditLog.BufferSize)
 
+	// Initialize notifications of host trust and state changes
+	nss := postgres.NewNotificationSubscriptionStore(dataStore, getDecodedDek(c))
+	notificationPublisher, err := notification.NewNotificationDispatcher(nss, c.Notification, nil)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing notification dispatcher")
+	}
+
 	// Load Certificates
 	certStore, err := crypt.LoadCertificates(a.loadCertPathStore(), models.GetUniqueCertTypes())
 	if err != nil {
 		return errors.Wrap(err, "Error while loading required certificates")
 	}
 
+	// Initialize remediation of the hosts with consecutive untrusted reports
+	hqs := postgres.NewHostQuarantineStore(dataStore)
+	hqs.AuditLogWriter = alw
+	remediationService, err := remediation.NewRemediationService(c.Remediation, hqs, postgres.NewHostStore(dataStore),
+		postgres.NewTagCertificateStore(dataStore), notificationPublisher, c.NATS.Servers)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing remediation")
+	}
+
 	// Initialize Host trust manager
 	fgs := postgres.NewFlavorGroupStore(dataStore)
-	hostTrustManager := initHostTrustManager(c, dataStore, fgs, certStore, alw)
+	hostTrustManager := initHostTrustManager(c, dataStore, fgs, certStore, alw, notificationPublisher, remediationService)
 	go hostTrustManager.ProcessQueue()
 
 	// create an instance of the HRRS and start it...
 	reportStore := postgres.NewReportStore(dataStore)
 	reportStore.AuditLogWriter = alw
-	reportRefresher, err := hrrs.NewHostReportRefresher(c.HRRS, reportStore, hostTrustManager)
+	reportRefresher, err := hrrs.NewHostReportRefresher(c.HRRS, reportStore, postgres.NewAttestationScheduleStore(dataStore), fgs, hostTrustManager)
 	if err != nil {
 		return errors.Wrap(err, "An error occurred while initializing HRRS")
 	}
@@ -94,6 +114,17 @@ func (a *App) startServer() error {
 		return errors.Wrap(err, "An error occurred while initializing Report Refresher")
 	}
 
+	// create an instance of the report retention service and start it...
+	reportRetention, err := reportretention.NewReportRetentionService(c.ReportRetention, reportStore)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while initializing Report Retention")
+	}
+
+	err = reportRetention.Run()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while starting Report Retention")
+	}
+
 	// Initialize Host controller config
 	hostControllerConfig := initHostControllerConfig(c, certStore)
 
@@ -109,7 +140,7 @@ func (a *App) startServer() error {
 	}
 
 	// Initialize routes
-	routes, err := router.InitRoutes(c, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig)
+	routes, err := router.InitRoutes(c, dataStore, fgs, certStore, hostTrustManager, hostControllerConfig, reportRetention, reportRefresher, remediationService)
 	if err != nil {
 		return errors.Wrap(err, "An error occurred while initializing routes")
 	}
@@ -162,10 +193,16 @@ func (a *App) startServer() error {
 		return errors.Wrap(err, "An error occurred while stopping Report Refresher")
 	}
 
+	err = reportRetention.Stop()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while stopping Report Retention")
+	}
+
 	if err := h.Shutdown(ctx); err != nil {
 		defaultLog.WithError(err).Info("Failed to gracefully shutdown webserver")
 		return err
 	}
+	notificationPublisher.Stop()
 	secLog.Info(commLogMsg.ServiceStop)
 	return nil
 }
@@ -175,7 +212,7 @@ func initHostControllerConfig(cfg *config.Configuration, certStore *crypt.Certif
 	defer defaultLog.Trace("server:initHostControllerConfig() Leaving")
 
 	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
-	hcProvider := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates, cfg.NATS.Servers, cfg.IMAMeasureEnabled)
+	hcProvider := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates, cfg.NATS.Servers, cfg.IMAMeasureEnabled, cfg.HostBundleMaxAge)
 
 	hcc := domain.HostControllerConfig{
 		HostConnectorProvider:          hcProvider,
@@ -199,7 +236,7 @@ func getDecodedDek(cfg *config.Configuration) []byte {
 	return dek
 }
 
-func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataStore, fgs domain.FlavorGroupStore, certStore *crypt.CertificatesStore, alw domain.AuditLogWriter) domain.HostTrustManager {
+func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataStore, fgs domain.FlavorGroupStore, certStore *crypt.CertificatesStore, alw domain.AuditLogWriter, np domain.NotificationPublisher, rm domain.RemediationManager) domain.HostTrustManager {
 	defaultLog.Trace("server:InitHostTrustManager() Entering")
 	defer defaultLog.Trace("server:InitHostTrustManager() Leaving")
 
@@ -208,29 +245,21 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 	hc := postgres.NewHostCredentialStore(dataStore, getDecodedDek(cfg))
 	fs := postgres.NewFlavorStore(dataStore)
 	qs := postgres.NewDBQueueStore(dataStore)
+	if err := prometheus.Register(hosttrust.NewQueueCollector(qs)); err != nil {
+		defaultLog.WithError(err).Warn("Could not register the queue metrics collector")
+	}
 	hss := postgres.NewHostStatusStore(dataStore)
 	hss.AuditLogWriter = alw
+	hss.NotificationPublisher = np
 	rs := postgres.NewReportStore(dataStore)
 	rs.AuditLogWriter = alw
+	rs.NotificationPublisher = np
 
 	//Load certificates
 	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
-	tagCAs := (*certStore)[models.CaCertTypesTagCa.String()]
 	samlCert := (*certStore)[models.CertTypesSaml.String()]
-	privacyCAs := (*certStore)[models.CaCertTypesPrivacyCa.String()]
-	signingCerts := (*certStore)[models.CertTypesFlavorSigning.String()]
-	rootCApool := crypt.GetCertPool(rootCAs.Certificates)
-	for _, val := range signingCerts.Certificates[1:] {
-		rootCApool.AddCert(&val) //Add intermediate CA
-	}
 
-	verifierCerts := verifier.VerifierCertificates{
-		PrivacyCACertificates:    crypt.GetCertPool(privacyCAs.Certificates),
-		AssetTagCACertificates:   crypt.GetCertPool(tagCAs.Certificates),
-		FlavorSigningCertificate: &signingCerts.Certificates[0],
-		FlavorCACertificates:     rootCApool,
-	}
-	libVerifier, _ := verifier.NewVerifier(verifierCerts)
+	libVerifier, _ := utils.NewFlavorVerifier(certStore)
 	samlKey := samlCert.Key.(*rsa.PrivateKey)
 	samlIssuerConfig := saml.IssuerConfiguration{
 		IssuerName:        cfg.SAML.Issuer,
@@ -254,10 +283,11 @@ func initHostTrustManager(cfg *config.Configuration, dataStore *postgres.DataSto
 		SamlIssuerConfig:                samlIssuerConfig,
 		SkipFlavorSignatureVerification: cfg.FVS.SkipFlavorSignatureVerification,
 		HostTrustCache:                  hostQuoteTrustCache,
+		RemediationManager:              rm,
 	}
 
 	// Initialize Host Fetcher service
-	htcFactory := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates, cfg.NATS.Servers, cfg.IMAMeasureEnabled)
+	htcFactory := hostconnector.NewHostConnectorFactory(cfg.AASApiUrl, rootCAs.Certificates, cfg.NATS.Servers, cfg.IMAMeasureEnabled, cfg.HostBundleMaxAge)
 
 	c := domain.HostDataFetcherConfig{
 		HostConnectorProvider: htcFactory,
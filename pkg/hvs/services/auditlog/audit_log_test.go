@@ -66,6 +66,24 @@ func (me *mockEntryStore) Update(e *models.AuditLogEntry) (*models.AuditLogEntry
 	return e, nil
 }
 
+func (me *mockEntryStore) Search(c *models.AuditLogEntryFilterCriteria) ([]models.AuditLogEntry, error) {
+	var ret []models.AuditLogEntry
+	for _, v := range me.data {
+		if (c.EntityID == uuid.Nil || v.EntityID == c.EntityID) &&
+			(c.EntityType == "" || v.EntityType == c.EntityType) &&
+			(c.Action == "" || v.Action == c.Action) {
+			ret = append(ret, *v)
+		}
+	}
+	me.t.Log("Search", c)
+	return ret, nil
+}
+
+func (me *mockEntryStore) Count(c *models.AuditLogEntryFilterCriteria) (int, error) {
+	ret, err := me.Search(c)
+	return len(ret), err
+}
+
 func (me *mockEntryStore) Delete(id uuid.UUID) error {
 	if _, ok := me.data[id.String()]; !ok {
 		return errors.New("record not found")
@@ -53,6 +53,14 @@ func (alp *auditLogDB) CreateEntry(action string, values ...interface{}) (*model
 		}
 		cols = append(cols, report2Cols(base, diff)...)
 		return entryHelper(base.ID, "report", action, cols), nil
+	case *hvs.HostQuarantine:
+		diff := base
+		if action == "update" {
+			if diff, ok = values[1].(*hvs.HostQuarantine); !ok {
+				return nil, errors.New("invalid input for audit log: incoherent input")
+			}
+		}
+		return entryHelper(base.HostId, "host_quarantine", action, hostQuarantine2Cols(base, diff)), nil
 	}
 }
 
@@ -131,3 +139,48 @@ func hostStatus2Cols(old, current *hvs.HostStatus) []models.AuditColumnData {
 		},
 	}
 }
+
+func hostQuarantine2Cols(old, current *hvs.HostQuarantine) []models.AuditColumnData {
+	return []models.AuditColumnData{
+		{
+			Name:      "host_id",
+			Value:     current.HostId,
+			IsUpdated: old.HostId != current.HostId,
+		},
+		{
+			Name:      "quarantined",
+			Value:     current.Quarantined,
+			IsUpdated: old.Quarantined != current.Quarantined,
+		},
+		{
+			Name:      "untrusted_reports",
+			Value:     current.UntrustedReports,
+			IsUpdated: old.UntrustedReports != current.UntrustedReports,
+		},
+		{
+			Name:      "report_id",
+			Value:     current.ReportId,
+			IsUpdated: !reflect.DeepEqual(old.ReportId, current.ReportId),
+		},
+		{
+			Name:      "quarantined_at",
+			Value:     current.QuarantinedAt,
+			IsUpdated: !reflect.DeepEqual(old.QuarantinedAt, current.QuarantinedAt),
+		},
+		{
+			Name:      "deleted_tag_certificates",
+			Value:     current.DeletedTagCertificates,
+			IsUpdated: !reflect.DeepEqual(old.DeletedTagCertificates, current.DeletedTagCertificates),
+		},
+		{
+			Name:      "released_at",
+			Value:     current.ReleasedAt,
+			IsUpdated: !reflect.DeepEqual(old.ReleasedAt, current.ReleasedAt),
+		},
+		{
+			Name:      "release_comment",
+			Value:     current.ReleaseComment,
+			IsUpdated: old.ReleaseComment != current.ReleaseComment,
+		},
+	}
+}
@@ -114,6 +114,7 @@ func NewService(cfg domain.HostDataFetcherConfig, workers int) (*Service, domain
 
 	// start workers.. individual workers are spawned as go routines
 	svc.startWorkers(workers)
+	workersTotal.Set(float64(workers))
 	svc.startRetryChannelProcessor(cfg.RetryTimeMinutes)
 	return svc, svc.Fetcher, nil
 }
@@ -189,6 +190,7 @@ func (svc *Service) addWorkToMap(wrk interface{}) interface{} {
 			svc.workMap.Store(v.host.Id, work)
 		} else {
 			svc.workMap.Store(v.host.Id, []*fetchRequest{v})
+			queueDepth.Inc()
 		}
 		return v.host.Id
 
@@ -201,6 +203,13 @@ func (svc *Service) addWorkToMap(wrk interface{}) interface{} {
 
 }
 
+// removeWork deletes the requests of the host from the map once its data has been fetched
+func (svc *Service) removeWork(hId uuid.UUID) {
+	if _, loaded := svc.workMap.LoadAndDelete(hId); loaded {
+		queueDepth.Dec()
+	}
+}
+
 // function that does the actual work. Receives id of host through work channel
 // then pull records from the map, proceed to work unless requests are not already
 // cancelled
@@ -254,7 +263,9 @@ func (svc *Service) doWork() {
 			}
 
 			if getData {
+				busyWorkers.Inc()
 				svc.FetchDataAndRespond(hId, connUrl, preferHashMatch)
+				busyWorkers.Dec()
 			} else {
 				defaultLog.Info("Fetch data for ", hId, "cancelled")
 			}
@@ -320,7 +331,7 @@ func (svc *Service) FetchDataAndRespond(hId uuid.UUID, connUrl string, preferHas
 		if hosts, err := svc.hs.Search(&models.HostFilterCriteria{Id: hId}, nil); err == nil && len(hosts) == 0 {
 			workEntry, _ := svc.workMap.Load(hId)
 			frs := workEntry.([]*fetchRequest)
-			svc.workMap.Delete(hId)
+			svc.removeWork(hId)
 			for _, fr := range frs {
 				select {
 				case <-fr.ctx.Done():
@@ -364,7 +375,7 @@ func (svc *Service) FetchDataAndRespond(hId uuid.UUID, connUrl string, preferHas
 	if workEntry != nil {
 		frs = workEntry.([]*fetchRequest)
 	}
-	svc.workMap.Delete(hId)
+	svc.removeWork(hId)
 	svc.updateMissingHostDetails(hId, hostData)
 	err = svc.hss.Persist(&hvs.HostStatus{
 		HostID: hId,
@@ -0,0 +1,38 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hostfetcher
+
+import (
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const metricsSubsystem = "hvs_host_fetcher"
+
+var (
+	// queueDepth is the number of hosts waiting for their data to be fetched, including the hosts waiting for a retry
+	queueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: commMetrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "queue_depth",
+		Help:      "Number of hosts waiting for their data to be fetched.",
+	})
+
+	// the worker utilisation is busy_workers / workers
+	workersTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: commMetrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "workers",
+		Help:      "Number of workers fetching the host data.",
+	})
+	busyWorkers = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: commMetrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "busy_workers",
+		Help:      "Number of workers currently fetching the data of a host.",
+	})
+)
@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hosttrust
+
+import (
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var queueBacklogDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(commMetrics.Namespace, "hvs_queue", "backlog"),
+	"Number of entries in the persisted flavor verification queue by state.",
+	[]string{"state"}, nil,
+)
+
+type queueCollector struct {
+	qs domain.QueueStore
+}
+
+// NewQueueCollector returns a collector reporting the backlog of the queue store per state. The entries of the queue
+// store are counted per state when the metrics are collected.
+func NewQueueCollector(qs domain.QueueStore) prometheus.Collector {
+	return &queueCollector{qs: qs}
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueBacklogDesc
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	defaultLog.Trace("hosttrust/metrics:Collect() Entering")
+	defer defaultLog.Trace("hosttrust/metrics:Collect() Leaving")
+
+	backlog, err := c.qs.CountByState()
+	if err != nil {
+		defaultLog.WithError(err).Error("hosttrust/metrics:Collect() Failed to count queue entries")
+		ch <- prometheus.NewInvalidMetric(queueBacklogDesc, err)
+		return
+	}
+
+	for _, state := range models.GetQueueStates() {
+		ch <- prometheus.MustNewConstMetric(queueBacklogDesc, prometheus.GaugeValue, float64(backlog[state]), state.String())
+	}
+}
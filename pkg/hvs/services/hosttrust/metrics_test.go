@@ -0,0 +1,41 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hosttrust
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueCollector(t *testing.T) {
+	store := mocks.NewQueueStore()
+	for _, state := range []models.QueueState{models.QueueStateNew, models.QueueStateNew, models.QueueStateError} {
+		_, err := store.Create(&models.Queue{
+			Action: "flavor-verify",
+			Params: map[string]interface{}{"host_id": "ee37c360-7eae-4250-a677-6ee12adce8e2"},
+			State:  state,
+		})
+		assert.NoError(t, err)
+	}
+
+	expected := `
+# HELP isecl_hvs_queue_backlog Number of entries in the persisted flavor verification queue by state.
+# TYPE isecl_hvs_queue_backlog gauge
+isecl_hvs_queue_backlog{state="Completed"} 0
+isecl_hvs_queue_backlog{state="ConnectionFailure"} 0
+isecl_hvs_queue_backlog{state="Error"} 1
+isecl_hvs_queue_backlog{state="New"} 2
+isecl_hvs_queue_backlog{state="Pending"} 0
+isecl_hvs_queue_backlog{state="Returned"} 0
+isecl_hvs_queue_backlog{state="Timeout"} 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(NewQueueCollector(store), strings.NewReader(expected)))
+}
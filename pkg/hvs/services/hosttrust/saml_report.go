@@ -58,6 +58,9 @@ func getSamlReportMap(t *hvs.TrustReport) map[string]string {
 	for field, value := range getMarkersMap(t) {
 		samlReportMap[field] = value
 	}
+	for field, value := range getPolicyRulesMap(t) {
+		samlReportMap[field] = value
+	}
 	if t.HostManifest.BindingKeyCertificate != "" {
 		samlReportMap["Binding_Key_Certificate"] = t.HostManifest.BindingKeyCertificate
 	}
@@ -94,6 +97,30 @@ func getMarkersMap(t *hvs.TrustReport) map[string]string {
 	return markersMap
 }
 
+// load policy rules map for saml report, a policy rule defined with the same name in several flavorgroups of the
+// host is trusted only if it is trusted in all of them
+func getPolicyRulesMap(t *hvs.TrustReport) map[string]string {
+	defaultLog.Trace("hosttrust/saml_report:getPolicyRulesMap() Entering")
+	defer defaultLog.Trace("hosttrust/saml_report:getPolicyRulesMap() Leaving")
+
+	policyPrefix := "POLICY_"
+	policyTrust := make(map[string]bool)
+	for _, result := range t.Results {
+		if result.Rule.Name != faultsConst.RulePolicyExpressionMatches || result.Rule.PolicyRule == nil {
+			continue
+		}
+		name := policyPrefix + result.Rule.PolicyRule.Name
+		trusted, ok := policyTrust[name]
+		policyTrust[name] = result.IsTrusted() && (!ok || trusted)
+	}
+
+	policyRulesMap := make(map[string]string)
+	for name, trusted := range policyTrust {
+		policyRulesMap[name] = strconv.FormatBool(trusted)
+	}
+	return policyRulesMap
+}
+
 // load host info map for saml report
 func getHostInfoMap(hostInfo model.HostInfo) map[string]string {
 	defaultLog.Trace("hosttrust/saml_report:getHostInfoMap() Entering")
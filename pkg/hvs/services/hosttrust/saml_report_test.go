@@ -11,6 +11,7 @@ import (
 	"io/ioutil"
 
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	faultsConst "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/saml"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/verifier"
@@ -45,6 +46,35 @@ var _ = Describe("SamlReport", func() {
 				log.Info("Generated SAML report : " + assertion.Assertion)
 			})
 		})
+		Context("Given trust report with flavorgroup policy rule results", func() {
+			It("Should add the trust status of each policy rule to the SAML attributes", func() {
+				policyResult := func(name string, trusted bool) hvs.RuleResult {
+					result := hvs.RuleResult{
+						Rule: hvs.RuleInfo{
+							Name:       faultsConst.RulePolicyExpressionMatches,
+							Markers:    []hvs.FlavorPartName{hvs.FlavorPartPlatform},
+							PolicyRule: &hvs.PolicyRule{Name: name},
+						},
+						Trusted: trusted,
+					}
+					if !trusted {
+						result.Faults = []hvs.Fault{{Name: faultsConst.FaultPolicyExpressionMismatch}}
+					}
+					return result
+				}
+				trustReport := hvs.TrustReport{
+					Results: []hvs.RuleResult{
+						policyResult("txt-enabled", true),
+						policyResult("bios-version", true),
+						policyResult("bios-version", false),
+					},
+				}
+				policyRulesMap := getPolicyRulesMap(&trustReport)
+				Expect(policyRulesMap["POLICY_txt-enabled"]).To(Equal("true"))
+				Expect(policyRulesMap["POLICY_bios-version"]).To(Equal("false"))
+				Expect(getMarkersMap(&trustReport)["TRUST_PLATFORM"]).To(Equal("false"))
+			})
+		})
 	})
 })
 
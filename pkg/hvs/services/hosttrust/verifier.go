@@ -8,12 +8,14 @@ package hosttrust
 import (
 	"github.com/google/uuid"
 	lru "github.com/hashicorp/golang-lru"
+	faultsConst "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/saml"
 	flavorVerifier "github.com/intel-secl/intel-secl/v5/pkg/lib/verifier"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/verifier/rules"
 	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
 	taModel "github.com/intel-secl/intel-secl/v5/pkg/model/ta"
 	"github.com/pkg/errors"
@@ -34,6 +36,7 @@ type Verifier struct {
 	SkipFlavorSignatureVerification bool
 	hostQuoteReportCache            map[uuid.UUID]*models.QuoteReportCache
 	HostTrustCache                  *lru.Cache
+	RemediationManager              domain.RemediationManager
 }
 
 func NewVerifier(cfg domain.HostTrustVerifierConfig) domain.HostTrustVerifier {
@@ -47,6 +50,7 @@ func NewVerifier(cfg domain.HostTrustVerifierConfig) domain.HostTrustVerifier {
 		SamlIssuer:                      cfg.SamlIssuerConfig,
 		SkipFlavorSignatureVerification: cfg.SkipFlavorSignatureVerification,
 		HostTrustCache:                  cfg.HostTrustCache,
+		RemediationManager:              cfg.RemediationManager,
 		hostQuoteReportCache:            make(map[uuid.UUID]*models.QuoteReportCache),
 	}
 }
@@ -98,6 +102,7 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *hvs.HostManifest, newData
 			if cachedQuote.QuoteDigest != "" && hostData.QuoteDigest == cachedQuote.QuoteDigest {
 				// retrieve the stored report
 				log.Debugf("hosttrust/verifier:Verify() Quote values matches cached value for host %s - skipping flavor verification", hostId.String())
+				// the flavor results are reused, the policy rules are evaluated again on the cached host manifest
 				if report, err := v.refreshTrustReport(hostId, cachedQuote); err == nil {
 					return report, err
 				} else {
@@ -166,6 +171,18 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *hvs.HostManifest, newData
 		log.Debug("hosttrust/verifier:Verify() Trust status for host id ", hostId, " for flavorgroup ", fg.ID, " is ", fgTrustReport.IsTrusted())
 		// append the results
 		finalTrustReport.AddResults(fgTrustReport.Results)
+
+		// the policy rules are not cached, they are evaluated on every verification
+		policyResults, err := applyPolicyRules(fg, hostData)
+		if err != nil {
+			return nil, errors.Wrap(err, "hosttrust/verifier:Verify() Error while applying flavorgroup policy rules")
+		}
+		for _, policyResult := range policyResults {
+			if !policyResult.Trusted {
+				finalReportValid = false
+			}
+			finalTrustReport.AddResult(policyResult)
+		}
 	}
 	// create a new report if we actually have any results and either the Final Report is untrusted or
 	// we have new Data from the host and therefore need to update based on the new report.
@@ -194,6 +211,27 @@ func (v *Verifier) Verify(hostId uuid.UUID, hostData *hvs.HostManifest, newData
 	return hvsReport, nil
 }
 
+// applyPolicyRules evaluates the policy rules of the flavorgroup on the host manifest
+func applyPolicyRules(fg hvs.FlavorGroup, hostData *hvs.HostManifest) ([]hvs.RuleResult, error) {
+	defaultLog.Trace("hosttrust/verifier:applyPolicyRules() Entering")
+	defer defaultLog.Trace("hosttrust/verifier:applyPolicyRules() Leaving")
+
+	var results []hvs.RuleResult
+	for _, policyRule := range fg.PolicyRules {
+		rule, err := rules.NewPolicyExpressionMatches(policyRule, fg.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error while creating policy rule %s of flavorgroup %s", policyRule.Name, fg.ID)
+		}
+		result, err := rule.Apply(hostData)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error while applying policy rule %s of flavorgroup %s", policyRule.Name, fg.ID)
+		}
+		log.Debugf("hosttrust/verifier:applyPolicyRules() Policy rule %s of flavorgroup %s trusted: %t", policyRule.Name, fg.ID, result.Trusted)
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
 func (v *Verifier) getCachedFlavors(hostId uuid.UUID, flavGrpId uuid.UUID) ([]hvs.SignedFlavor, error) {
 	defaultLog.Trace("hosttrust/verifier:getCachedFlavors() Entering")
 	defer defaultLog.Trace("hosttrust/verifier:getCachedFlavors() Leaving")
@@ -251,9 +289,53 @@ func (v *Verifier) refreshTrustReport(hostID uuid.UUID, cache *models.QuoteRepor
 	defer defaultLog.Trace("hosttrust/verifier:refreshTrustReport() Leaving")
 	log.Debugf("hosttrust/verifier:refreshTrustReport() Generating SAML for host: %s using existing trust report", hostID)
 
+	trustReport, err := v.reapplyPolicyRules(hostID, cache.TrustReport)
+	if err != nil {
+		return nil, errors.Wrap(err, "hosttrust/verifier:refreshTrustReport() Error while applying flavorgroup policy rules")
+	}
+	v.HostTrustCache.Add(hostID, &models.QuoteReportCache{
+		QuoteDigest:  cache.QuoteDigest,
+		TrustPcrList: cache.TrustPcrList,
+		TrustReport:  trustReport,
+	})
+
 	samlReportGen := NewSamlReportGenerator(&v.SamlIssuer)
-	samlReport := samlReportGen.GenerateSamlReport(cache.TrustReport)
-	return v.storeTrustReport(hostID, cache.TrustReport, &samlReport), nil
+	samlReport := samlReportGen.GenerateSamlReport(trustReport)
+	return v.storeTrustReport(hostID, trustReport, &samlReport), nil
+}
+
+// reapplyPolicyRules returns a copy of the cached trust report with the results of the current policy rules of the
+// host flavorgroups, the policy rules may have changed since the report was created. The flavor results are kept.
+func (v *Verifier) reapplyPolicyRules(hostID uuid.UUID, cachedReport *hvs.TrustReport) (*hvs.TrustReport, error) {
+	defaultLog.Trace("hosttrust/verifier:reapplyPolicyRules() Entering")
+	defer defaultLog.Trace("hosttrust/verifier:reapplyPolicyRules() Leaving")
+
+	flvGroupIds, err := v.HostStore.SearchFlavorgroups(hostID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error while retrieving the host flavorgroups")
+	}
+	flvGroups, err := v.FlavorGroupStore.Search(&models.FlavorGroupFilterCriteria{Ids: flvGroupIds})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error while retrieving the flavorgroups")
+	}
+
+	trustReport := hvs.TrustReport{HostManifest: cachedReport.HostManifest}
+	for _, result := range cachedReport.Results {
+		if result.Rule.Name != faultsConst.RulePolicyExpressionMatches {
+			trustReport.Results = append(trustReport.Results, result)
+		}
+	}
+	for _, fg := range flvGroups {
+		policyResults, err := applyPolicyRules(fg, &trustReport.HostManifest)
+		if err != nil {
+			return nil, err
+		}
+		for _, policyResult := range policyResults {
+			trustReport.AddResult(policyResult)
+		}
+	}
+	trustReport.Trusted = trustReport.IsTrusted()
+	return &trustReport, nil
 }
 
 func (v *Verifier) storeTrustReport(hostID uuid.UUID, trustReport *hvs.TrustReport, samlReport *saml.SamlAssertion) *models.HVSReport {
@@ -271,6 +353,8 @@ func (v *Verifier) storeTrustReport(hostID uuid.UUID, trustReport *hvs.TrustRepo
 	report, err := v.ReportStore.Update(&hvsReport)
 	if err != nil {
 		log.WithError(err).Errorf("hosttrust/verifier:storeTrustReport() Failed to store Report")
+	} else if v.RemediationManager != nil {
+		v.RemediationManager.ProcessReport(report)
 	}
 	return report
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	lru "github.com/hashicorp/golang-lru"
+	faultsConst "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
@@ -95,3 +96,121 @@ func TestVerifier_validateCachedFlavors(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyPolicyRules(t *testing.T) {
+	var hostStatus hvs.HostStatus
+	if err := json.Unmarshal([]byte(HostStatus1), &hostStatus); err != nil {
+		t.Fatal(err)
+	}
+	txtEnabled := hostStatus.HostManifest.HostInfo.HardwareFeatures.TXT != nil && hostStatus.HostManifest.HostInfo.HardwareFeatures.TXT.Enabled
+
+	fg := hvs.FlavorGroup{
+		ID: uuid.New(),
+		PolicyRules: []hvs.PolicyRule{
+			{
+				Name: "txt-enabled",
+				Expression: hvs.PolicyExpression{
+					Path:     "host_info.hardware_features.TXT.enabled",
+					Operator: hvs.PolicyOperatorEquals,
+					Value:    true,
+				},
+			},
+			{
+				Name:   "os-name",
+				Marker: hvs.FlavorPartOs,
+				Expression: hvs.PolicyExpression{
+					Path:     "host_info.os_name",
+					Operator: hvs.PolicyOperatorEquals,
+					Value:    hostStatus.HostManifest.HostInfo.OSName + "-other",
+				},
+			},
+		},
+	}
+
+	results, err := applyPolicyRules(fg, &hostStatus.HostManifest)
+	if err != nil {
+		t.Fatalf("applyPolicyRules() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("applyPolicyRules() returned %d results, want 2", len(results))
+	}
+	if results[0].Trusted != txtEnabled {
+		t.Errorf("applyPolicyRules() txt-enabled trusted = %t, want %t", results[0].Trusted, txtEnabled)
+	}
+	if results[1].Trusted || results[1].Rule.Markers[0] != hvs.FlavorPartOs {
+		t.Errorf("applyPolicyRules() os-name should be untrusted with the OS marker")
+	}
+
+	// both results are kept in the report even though they are evaluated by the same rule
+	report := hvs.TrustReport{}
+	results[0].Rule.Markers = results[1].Rule.Markers
+	report.AddResults(results)
+	if len(report.Results) != 2 {
+		t.Errorf("TrustReport.AddResults() kept %d policy rule results, want 2", len(report.Results))
+	}
+}
+
+func TestVerifier_refreshTrustReport(t *testing.T) {
+	var hostStatus hvs.HostStatus
+	if err := json.Unmarshal([]byte(HostStatus1), &hostStatus); err != nil {
+		t.Fatal(err)
+	}
+	hostId := uuid.New()
+
+	// the policy rule of the flavorgroup was added after the cached report was created
+	flavorGroupStore := &mocks.MockFlavorgroupStore{
+		FlavorgroupStore:       make(map[uuid.UUID]*hvs.FlavorGroup),
+		FlavorgroupFlavorStore: make(map[uuid.UUID][]uuid.UUID),
+	}
+	fg, _ := flavorGroupStore.Create(&hvs.FlavorGroup{
+		Name: "policy",
+		PolicyRules: []hvs.PolicyRule{{
+			Name: "os-name",
+			Expression: hvs.PolicyExpression{
+				Path:     "host_info.os_name",
+				Operator: hvs.PolicyOperatorEquals,
+				Value:    hostStatus.HostManifest.HostInfo.OSName + "-other",
+			},
+		}},
+	})
+	hostStore := mocks.NewMockHostStore()
+	_ = hostStore.AddFlavorgroups(hostId, []uuid.UUID{fg.ID})
+
+	cachedReport := &hvs.TrustReport{
+		HostManifest: hostStatus.HostManifest,
+		Results: []hvs.RuleResult{
+			{Rule: hvs.RuleInfo{Name: faultsConst.RulePcrMatchesConstant}, Trusted: true},
+			{Rule: hvs.RuleInfo{Name: faultsConst.RulePolicyExpressionMatches, PolicyRule: &hvs.PolicyRule{Name: "removed"}}, Trusted: true},
+		},
+		Trusted: true,
+	}
+	hostTrustCache, _ := lru.New(10)
+	v := &Verifier{
+		FlavorGroupStore: flavorGroupStore,
+		HostStore:        hostStore,
+		ReportStore:      mocks.NewMockReportStore(),
+		SamlIssuer:       *getIssuer(),
+		HostTrustCache:   hostTrustCache,
+	}
+
+	report, err := v.refreshTrustReport(hostId, &models.QuoteReportCache{QuoteDigest: "digest", TrustReport: cachedReport})
+	if err != nil {
+		t.Fatalf("Verifier.refreshTrustReport() error = %v", err)
+	}
+	if report.TrustReport.Trusted {
+		t.Errorf("Verifier.refreshTrustReport() report should be untrusted by the new policy rule")
+	}
+	if len(report.TrustReport.Results) != 2 {
+		t.Fatalf("Verifier.refreshTrustReport() returned %d results, want 2", len(report.TrustReport.Results))
+	}
+	if report.TrustReport.Results[1].Rule.PolicyRule.Name != "os-name" {
+		t.Errorf("Verifier.refreshTrustReport() policy rule %s, want os-name", report.TrustReport.Results[1].Rule.PolicyRule.Name)
+	}
+	if !cachedReport.Trusted || len(cachedReport.Results) != 2 {
+		t.Errorf("Verifier.refreshTrustReport() modified the cached report")
+	}
+	cacheEntry, ok := hostTrustCache.Get(hostId)
+	if !ok || cacheEntry.(*models.QuoteReportCache).TrustReport.Trusted {
+		t.Errorf("Verifier.refreshTrustReport() cache should hold the refreshed report")
+	}
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hrrs
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type scheduledHost struct {
+	schedule hvs.AttestationSchedule
+	interval time.Duration
+}
+
+// GetScheduledAttestations returns the next attestation of the hosts linked to the flavorgroup of the schedule. A
+// next run in the past means that the host is queued on the next refresh cycle.
+func (refresher *hostReportRefresherImpl) GetScheduledAttestations(schedule *hvs.AttestationSchedule) ([]hvs.ScheduledAttestation, error) {
+	defaultLog.Trace("hrrs/attestation_schedules:GetScheduledAttestations() Entering")
+	defer defaultLog.Trace("hrrs/attestation_schedules:GetScheduledAttestations() Leaving")
+
+	hostIDs, err := refresher.flavorGroupStore.SearchHostsByFlavorGroup(schedule.FlavorgroupId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to search the hosts of flavorgroup %s", schedule.FlavorgroupId)
+	}
+
+	scheduledHosts, err := refresher.getScheduledHosts()
+	if err != nil {
+		return nil, err
+	}
+	reportTimes, err := refresher.getReportTimes(hostIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	scheduledAttestations := []hvs.ScheduledAttestation{}
+	for _, hostID := range hostIDs {
+		sh, ok := scheduledHosts[hostID]
+		if !ok {
+			continue
+		}
+		lastAttestation, nextRun := refresher.getNextRun(hostID, sh.interval, reportTimes, now)
+		scheduledAttestations = append(scheduledAttestations, hvs.ScheduledAttestation{
+			HostId:          hostID,
+			ScheduleId:      sh.schedule.ID,
+			Interval:        sh.schedule.Interval,
+			LastAttestation: lastAttestation,
+			NextRun:         nextRun,
+		})
+	}
+
+	sort.SliceStable(scheduledAttestations, func(i, j int) bool {
+		return scheduledAttestations[i].NextRun.Before(scheduledAttestations[j].NextRun)
+	})
+	return scheduledAttestations, nil
+}
+
+// getScheduledHosts returns the attestation schedule of each host linked to a scheduled flavorgroup, a host linked to
+// several scheduled flavorgroups follows the shortest interval
+func (refresher *hostReportRefresherImpl) getScheduledHosts() (map[uuid.UUID]scheduledHost, error) {
+	schedules, err := refresher.scheduleStore.Search(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to search attestation schedules")
+	}
+
+	var sortedSchedules []scheduledHost
+	var flavorgroupIDs []uuid.UUID
+	for _, schedule := range schedules {
+		interval, err := time.ParseDuration(schedule.Interval)
+		if err != nil || interval <= 0 {
+			defaultLog.Errorf("HRRS ignores attestation schedule %s with invalid interval '%s'", schedule.ID, schedule.Interval)
+			continue
+		}
+		sortedSchedules = append(sortedSchedules, scheduledHost{schedule: schedule, interval: interval})
+		flavorgroupIDs = append(flavorgroupIDs, schedule.FlavorgroupId)
+	}
+	sort.SliceStable(sortedSchedules, func(i, j int) bool {
+		return sortedSchedules[i].interval < sortedSchedules[j].interval
+	})
+
+	scheduledHosts := make(map[uuid.UUID]scheduledHost)
+	if len(sortedSchedules) == 0 {
+		return scheduledHosts, nil
+	}
+	flavorgroupHostIDs, err := refresher.flavorGroupStore.SearchHostsByFlavorGroups(flavorgroupIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to search the hosts of the scheduled flavorgroups")
+	}
+	for _, sh := range sortedSchedules {
+		for _, hostID := range flavorgroupHostIDs[sh.schedule.FlavorgroupId] {
+			if _, ok := scheduledHosts[hostID]; !ok {
+				scheduledHosts[hostID] = sh
+			}
+		}
+	}
+	return scheduledHosts, nil
+}
+
+// getDueHosts returns the scheduled hosts whose interval has elapsed since their latest report or whose report
+// expires within the refresh period. It also shortens the wait between two refresh cycles to the shortest interval
+// of the schedules.
+func (refresher *hostReportRefresherImpl) getDueHosts(scheduledHosts map[uuid.UUID]scheduledHost, now time.Time) ([]uuid.UUID, error) {
+	waitPeriod := refresher.cfg.RefreshPeriod
+	hostIDs := make([]uuid.UUID, 0, len(scheduledHosts))
+	for hostID, sh := range scheduledHosts {
+		if sh.interval < waitPeriod {
+			waitPeriod = sh.interval
+		}
+		hostIDs = append(hostIDs, hostID)
+	}
+	reportTimes, err := refresher.getReportTimes(hostIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var dueHostIDs []uuid.UUID
+	for hostID, sh := range scheduledHosts {
+		_, nextRun := refresher.getNextRun(hostID, sh.interval, reportTimes, now)
+		if !nextRun.After(now) {
+			dueHostIDs = append(dueHostIDs, hostID)
+		}
+	}
+
+	refresher.lock.Lock()
+	defer refresher.lock.Unlock()
+	refresher.waitPeriod = waitPeriod
+	// forget the hosts that are no longer scheduled
+	for hostID := range refresher.queuedTimes {
+		if _, ok := scheduledHosts[hostID]; !ok {
+			delete(refresher.queuedTimes, hostID)
+		}
+	}
+	return dueHostIDs, nil
+}
+
+// getReportTimes returns the creation and expiration times of the current report of each host
+func (refresher *hostReportRefresherImpl) getReportTimes(hostIDs []uuid.UUID) (map[uuid.UUID]models.HVSReport, error) {
+	reports, err := refresher.reportStore.SearchReportTimes(hostIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to search the latest reports of the scheduled hosts")
+	}
+	reportTimes := make(map[uuid.UUID]models.HVSReport, len(reports))
+	for _, report := range reports {
+		if latest, ok := reportTimes[report.HostID]; !ok || report.CreatedAt.After(latest.CreatedAt) {
+			reportTimes[report.HostID] = report
+		}
+	}
+	return reportTimes, nil
+}
+
+// getNextRun returns the creation time of the latest report of the host and the time of its next attestation. The
+// next attestation is the earlier of the end of the interval and the refresh of the report before it expires, so
+// that an interval longer than the validity of the reports does not leave the host with an expired report.
+func (refresher *hostReportRefresherImpl) getNextRun(hostID uuid.UUID, interval time.Duration, reportTimes map[uuid.UUID]models.HVSReport, now time.Time) (*time.Time, time.Time) {
+	var lastAttestation *time.Time
+	var lastRun, refreshTime time.Time
+	if report, ok := reportTimes[hostID]; ok {
+		createdAt := report.CreatedAt
+		lastAttestation = &createdAt
+		lastRun = createdAt
+		refreshTime = report.Expiration.Add(-refresher.cfg.RefreshPeriod)
+	}
+
+	refresher.lock.Lock()
+	queuedTime, queued := refresher.queuedTimes[hostID]
+	refresher.lock.Unlock()
+	if queued && queuedTime.After(lastRun) {
+		lastRun = queuedTime
+	}
+
+	if lastRun.IsZero() {
+		return lastAttestation, now
+	}
+	nextRun := lastRun.Add(interval)
+	// the host is not queued again for the expiration of a report it was already queued for
+	if !refreshTime.IsZero() && refreshTime.Before(nextRun) && !(queued && !queuedTime.Before(refreshTime)) {
+		nextRun = refreshTime
+	}
+	return lastAttestation, nextRun
+}
+func (refresher *hostReportRefresherImpl) setQueuedTimes(hostIDs []uuid.UUID, queuedTime time.Time) {
+	refresher.lock.Lock()
+	defer refresher.lock.Unlock()
+	for _, hostID := range hostIDs {
+		refresher.queuedTimes[hostID] = queuedTime
+	}
+}
+
+func (refresher *hostReportRefresherImpl) getWaitPeriod() time.Duration {
+	refresher.lock.Lock()
+	defer refresher.lock.Unlock()
+	if refresher.waitPeriod > 0 && refresher.waitPeriod < refresher.cfg.RefreshPeriod {
+		return refresher.waitPeriod
+	}
+	return refresher.cfg.RefreshPeriod
+}
@@ -8,8 +8,10 @@ package hrrs
 import (
 	"context"
 	"runtime/debug"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
 	commLog "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
 
@@ -18,10 +20,12 @@ import (
 
 // HostReportRefresher runs in the background and periodically queries HVS'
 // reports to see if they have been expired.  If so, they are passed to
-// the HostTrustManager queue to be updated.
+// the HostTrustManager queue to be updated.  The hosts linked to a flavorgroup
+// with an attestation schedule are instead queued at the interval of the schedule.
 type HostReportRefresher interface {
 	Run() error
 	Stop() error
+	domain.AttestationScheduler
 }
 
 var (
@@ -29,22 +33,32 @@ var (
 	defaultLog       = commLog.GetDefaultLogger()
 )
 
-func NewHostReportRefresher(cfg HRRSConfig, reportStore domain.ReportStore, hostTrustManager domain.HostTrustManager) (HostReportRefresher, error) {
+func NewHostReportRefresher(cfg HRRSConfig, reportStore domain.ReportStore, scheduleStore domain.AttestationScheduleStore, flavorGroupStore domain.FlavorGroupStore, hostTrustManager domain.HostTrustManager) (HostReportRefresher, error) {
 
 	return &hostReportRefresherImpl{
 		reportStore:      reportStore,
+		scheduleStore:    scheduleStore,
+		flavorGroupStore: flavorGroupStore,
 		hostTrustManager: hostTrustManager,
 		cfg:              cfg,
 		fromTime:         firstFromTime,
+		queuedTimes:      make(map[uuid.UUID]time.Time),
 	}, nil
 }
 
 type hostReportRefresherImpl struct {
 	reportStore      domain.ReportStore
+	scheduleStore    domain.AttestationScheduleStore
+	flavorGroupStore domain.FlavorGroupStore
 	hostTrustManager domain.HostTrustManager
 	cfg              HRRSConfig
 	ctx              context.Context
 	fromTime         time.Time
+	// the time each scheduled host was last queued, so that a host is not queued again before its report is updated
+	queuedTimes map[uuid.UUID]time.Time
+	// the shortest interval of the attestation schedules, when shorter than the refresh period
+	waitPeriod time.Duration
+	lock       sync.Mutex
 }
 
 func (refresher *hostReportRefresherImpl) Run() error {
@@ -70,10 +84,13 @@ func (refresher *hostReportRefresherImpl) Run() error {
 			if err != nil {
 				// log any errors, but do not stop trying to refresh reports
 				defaultLog.Errorf("HRRS encountered an error while refreshing reports...\n%+v\n", err)
+				refreshes.WithLabelValues(refreshResultFailure).Inc()
+			} else {
+				refreshes.WithLabelValues(refreshResultSuccess).Inc()
 			}
 
 			select {
-			case <-time.After(refresher.cfg.RefreshPeriod):
+			case <-time.After(refresher.getWaitPeriod()):
 				// continue with the loop and refresh reports again
 			case <-refresher.ctx.Done():
 				defaultLog.Info("The HRRS has been stopped and will now exit")
@@ -103,24 +120,49 @@ func (refresher *hostReportRefresherImpl) Stop() error {
 //
 // The intent of this logic is to avoid adding duplicate hosts to the
 // HostTrustManage queue.
+//
+// The hosts with an attestation schedule are queued once the interval of their schedule
+// has elapsed since their latest report, or earlier when their report expires within the
+// refresh period.
 func (refresher *hostReportRefresherImpl) refreshReports() error {
 
-	toTime := time.Now().UTC().Add(refresher.cfg.RefreshPeriod)
+	now := time.Now().UTC()
+	toTime := now.Add(refresher.cfg.RefreshPeriod)
 	defaultLog.Debugf("HRRS is refreshing hosts that have expired reports between %s and %s", refresher.fromTime, toTime)
 
-	hostIDs, err := refresher.reportStore.FindHostIdsFromExpiredReports(refresher.fromTime, toTime)
+	scheduledHosts, err := refresher.getScheduledHosts()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while HRRS searched for scheduled hosts")
+	}
+
+	expiredHostIDs, err := refresher.reportStore.FindHostIdsFromExpiredReports(refresher.fromTime, toTime)
 
 	if err != nil {
 		return errors.Wrap(err, "An error occurred while HRRS searched for host ids")
 	}
 
-	defaultLog.Debugf("HRRS found %d hosts to refresh", len(hostIDs))
+	var hostIDs []uuid.UUID
+	for _, hostID := range expiredHostIDs {
+		if _, ok := scheduledHosts[hostID]; !ok {
+			hostIDs = append(hostIDs, hostID)
+		}
+	}
+
+	dueHostIDs, err := refresher.getDueHosts(scheduledHosts, now)
+	if err != nil {
+		return errors.Wrap(err, "An error occurred while HRRS searched for the scheduled hosts to refresh")
+	}
+	hostIDs = append(hostIDs, dueHostIDs...)
+
+	defaultLog.Debugf("HRRS found %d hosts to refresh, %d of them from attestation schedules", len(hostIDs), len(dueHostIDs))
 
 	if len(hostIDs) > 0 {
 		err = refresher.hostTrustManager.VerifyHostsAsync(hostIDs, true, true)
 		if err != nil {
 			return errors.Wrap(err, "HRRS encountered an error calling the host trust manager")
 		}
+		refreshedHosts.Add(float64(len(hostIDs)))
+		refresher.setQueuedTimes(dueHostIDs, now)
 	}
 
 	defaultLog.Infof("HRRS queued %d hosts from reports that were expiring between %s and %s", len(hostIDs), refresher.fromTime, toTime)
@@ -16,6 +16,7 @@ import (
 	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
 	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -73,7 +74,7 @@ func TestHostReportRefresher(t *testing.T) {
 	// create a new HostReportRefresher, 'run' the backgound thread and then
 	// sleep for ten seconds.  We expect the expired report to be updated
 	// in the report store.
-	refresher, err := NewHostReportRefresher(cfg, reportStore, hostTrustManager)
+	refresher, err := NewHostReportRefresher(cfg, reportStore, &mocks.MockAttestationScheduleStore{}, mocks.NewFakeFlavorgroupStore(), hostTrustManager)
 	assert.NoError(t, err)
 	err = refresher.Run()
 	assert.NoError(t, err)
@@ -98,6 +99,103 @@ func TestHostReportRefresher(t *testing.T) {
 		assert.Equal(t, len(reports), 1)
 		assert.True(t, reports[0].Expiration.After(time.Now()))
 	}
+
+	// both hosts were queued and no refresh cycle failed
+	assert.GreaterOrEqual(t, testutil.ToFloat64(refreshedHosts), float64(len(hostsToCheck)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(refreshes.WithLabelValues(refreshResultFailure)))
+}
+
+func TestHostReportRefresherAttestationSchedules(t *testing.T) {
+
+	cfg := HRRSConfig{
+		RefreshPeriod: 10 * time.Minute,
+	}
+
+	reportStore := mocks.NewEmptyMockReportStore()
+	flavorGroupStore := mocks.NewFakeFlavorgroupStore()
+	scheduleStore := &mocks.MockAttestationScheduleStore{}
+
+	labFlavorgroupId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	pciFlavorgroupId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e3")
+	labSchedule, _ := scheduleStore.Create(&hvs.AttestationSchedule{FlavorgroupId: labFlavorgroupId, Interval: "24h"})
+	pciSchedule, _ := scheduleStore.Create(&hvs.AttestationSchedule{FlavorgroupId: pciFlavorgroupId, Interval: "5m"})
+
+	// the lab host was attested more than a day ago, the pci host, also linked to the lab flavorgroup, two minutes
+	// ago and the scheduled host attested an hour ago is refreshed before its report expires
+	labHostId := uuid.New()
+	pciHostId := uuid.New()
+	expiredScheduledHostId := uuid.New()
+	expiredHostId := uuid.New()
+	flavorGroupStore.HostFlavorgroupStore = []*hvs.HostFlavorgroup{
+		{HostId: labHostId, FlavorgroupId: labFlavorgroupId},
+		{HostId: pciHostId, FlavorgroupId: labFlavorgroupId},
+		{HostId: pciHostId, FlavorgroupId: pciFlavorgroupId},
+		{HostId: expiredScheduledHostId, FlavorgroupId: labFlavorgroupId},
+	}
+	now := time.Now()
+	pciHostAttestation := now.Add(-2 * time.Minute)
+	for hostId, createdAt := range map[uuid.UUID]time.Time{
+		labHostId:              now.Add(-25 * time.Hour),
+		pciHostId:              pciHostAttestation,
+		expiredScheduledHostId: now.Add(-time.Hour),
+		expiredHostId:          now.Add(-twentyFourHours),
+	} {
+		expiration := now.Add(twentyFourHours)
+		if hostId == expiredScheduledHostId || hostId == expiredHostId {
+			expiration = now.Add(-time.Minute)
+		}
+		_, _ = reportStore.Create(&models.HVSReport{
+			ID:         uuid.New(),
+			HostID:     hostId,
+			CreatedAt:  createdAt,
+			Expiration: expiration,
+		})
+	}
+
+	hostTrustManager := &recordingHostTrustManager{}
+	hostReportRefresher, err := NewHostReportRefresher(cfg, reportStore, scheduleStore, flavorGroupStore, hostTrustManager)
+	assert.NoError(t, err)
+	refresher := hostReportRefresher.(*hostReportRefresherImpl)
+
+	assert.NoError(t, refresher.refreshReports())
+	assert.ElementsMatch(t, []uuid.UUID{labHostId, expiredScheduledHostId, expiredHostId}, hostTrustManager.hostIDs)
+	// the refresh cycle follows the shortest schedule
+	assert.Equal(t, 5*time.Minute, refresher.getWaitPeriod())
+
+	// the lab host and the expired scheduled host are not queued again while their reports are being updated
+	hostTrustManager.hostIDs = nil
+	assert.NoError(t, refresher.refreshReports())
+	assert.Empty(t, hostTrustManager.hostIDs)
+
+	scheduledAttestations, err := refresher.GetScheduledAttestations(labSchedule)
+	assert.NoError(t, err)
+	assert.Len(t, scheduledAttestations, 3)
+	// the pci host follows the shortest interval of its flavorgroups
+	assert.Equal(t, pciHostId, scheduledAttestations[0].HostId)
+	assert.Equal(t, pciSchedule.ID, scheduledAttestations[0].ScheduleId)
+	assert.Equal(t, pciHostAttestation, *scheduledAttestations[0].LastAttestation)
+	assert.Equal(t, pciHostAttestation.Add(5*time.Minute), scheduledAttestations[0].NextRun)
+	for _, scheduledAttestation := range scheduledAttestations[1:] {
+		assert.Equal(t, labSchedule.ID, scheduledAttestation.ScheduleId)
+		assert.True(t, scheduledAttestation.NextRun.After(now.Add(23*time.Hour)))
+	}
+	// the pci host is refreshed before the expiration of its report
+	pciSchedule.Interval = "48h"
+	_, _ = scheduleStore.Update(pciSchedule)
+	scheduledAttestations, err = refresher.GetScheduledAttestations(pciSchedule)
+	assert.NoError(t, err)
+	assert.Len(t, scheduledAttestations, 1)
+	assert.Equal(t, now.Add(twentyFourHours-cfg.RefreshPeriod), scheduledAttestations[0].NextRun)
+}
+
+type recordingHostTrustManager struct {
+	MockHostTrustManager
+	hostIDs []uuid.UUID
+}
+
+func (htm *recordingHostTrustManager) VerifyHostsAsync(hostIDs []uuid.UUID, fetchHostData, preferHashMatch bool) error {
+	htm.hostIDs = append(htm.hostIDs, hostIDs...)
+	return nil
 }
 
 //-------------------------------------------------------------------------------------------------
@@ -0,0 +1,35 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hrrs
+
+import (
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	metricsSubsystem = "hvs_hrrs"
+
+	refreshResultSuccess = "success"
+	refreshResultFailure = "failure"
+)
+
+var (
+	refreshes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: commMetrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "refreshes_total",
+		Help:      "Number of report refresh cycles by result.",
+	}, []string{"result"})
+
+	refreshedHosts = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: commMetrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "refreshed_hosts_total",
+		Help:      "Number of hosts queued for verification because their report expired.",
+	})
+)
@@ -0,0 +1,241 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	commLog "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// Headers set on every event delivered to a subscriber. The signature is the hex encoded HMAC-SHA256 of
+// "<timestamp>.<payload>" computed with the secret of the subscription.
+const (
+	HeaderEventType  = "X-HVS-Event"
+	HeaderDeliveryId = "X-HVS-Delivery"
+	HeaderTimestamp  = "X-HVS-Timestamp"
+	HeaderSignature  = "X-HVS-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+
+type dispatcher struct {
+	store  domain.NotificationSubscriptionStore
+	client *http.Client
+	cfg    NotificationConfig
+
+	eventQueue chan *hvs.NotificationEvent
+	stopChan   chan struct{}
+	doneChan   chan struct{}
+	ctx        context.Context
+	cancel     context.CancelFunc
+	deliveries sync.WaitGroup
+	stopOnce   sync.Once
+}
+
+// NewNotificationDispatcher returns a NotificationPublisher that delivers the published events to the
+// subscribers of the event type. A default client with the configured request timeout is used when client is nil.
+func NewNotificationDispatcher(s domain.NotificationSubscriptionStore, cfg NotificationConfig, client *http.Client) (domain.NotificationPublisher, error) {
+	if s == nil {
+		return nil, errors.New("NewNotificationDispatcher: invalid notification subscription store")
+	}
+	if cfg.BufferSize <= 0 {
+		return nil, errors.New("NewNotificationDispatcher: buffer size must be greater than 0")
+	}
+	if client == nil {
+		client = &http.Client{
+			Timeout: cfg.RequestTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion: tls.VersionTLS12,
+				},
+			},
+		}
+	}
+	d := &dispatcher{
+		store:      s,
+		client:     client,
+		cfg:        cfg,
+		eventQueue: make(chan *hvs.NotificationEvent, cfg.BufferSize),
+		stopChan:   make(chan struct{}),
+		doneChan:   make(chan struct{}),
+	}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	d.startDispatchRoutine()
+	return d, nil
+}
+
+// Publish queues the event without blocking the caller, the event is dropped when the queue is full
+func (d *dispatcher) Publish(e *hvs.NotificationEvent) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	select {
+	case d.eventQueue <- e:
+	default:
+		defaultLog.Errorf("notification/dispatcher:Publish() Event queue is full, dropping %s event %s for host %s", e.EventType, e.ID, e.HostId)
+	}
+}
+
+// Stop dispatches the queued events and waits for the ongoing delivery attempts. Pending retries are cancelled and
+// their events are moved to the dead letters. Only the first call stops the dispatcher, the later calls return.
+func (d *dispatcher) Stop() {
+	d.stopOnce.Do(func() {
+		d.stopChan <- struct{}{}
+		<-d.doneChan
+		d.cancel()
+		d.deliveries.Wait()
+		close(d.stopChan)
+		close(d.doneChan)
+	})
+}
+
+func (d *dispatcher) startDispatchRoutine() {
+	go func() {
+		defer func() {
+			if err := recover(); err != nil {
+				defaultLog.Errorf("Panic occurred: %+v", err)
+				defaultLog.Error(string(debug.Stack()))
+			}
+		}()
+		for {
+			select {
+			case e := <-d.eventQueue:
+				d.dispatch(e)
+			case <-d.stopChan:
+				for len(d.eventQueue) > 0 {
+					d.dispatch(<-d.eventQueue)
+				}
+				d.doneChan <- struct{}{}
+				return
+			}
+		}
+	}()
+}
+
+// dispatch starts a delivery of the event for every subscriber of the event type
+func (d *dispatcher) dispatch(e *hvs.NotificationEvent) {
+	defaultLog.Trace("notification/dispatcher:dispatch() Entering")
+	defer defaultLog.Trace("notification/dispatcher:dispatch() Leaving")
+
+	subscriptions, err := d.store.Search(&models.NotificationSubscriptionFilterCriteria{EventType: e.EventType})
+	if err != nil {
+		defaultLog.WithError(err).Errorf("notification/dispatcher:dispatch() Failed to retrieve subscriptions for %s event %s", e.EventType, e.ID)
+		return
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("notification/dispatcher:dispatch() Failed to marshal %s event %s", e.EventType, e.ID)
+		return
+	}
+	for _, subscription := range subscriptions {
+		d.deliveries.Add(1)
+		go d.deliver(subscription, e, payload)
+	}
+}
+
+// deliver posts the event to the subscriber, retrying with an exponential backoff. The event is moved to the
+// dead letters of the subscription when all the attempts fail.
+func (d *dispatcher) deliver(subscription hvs.NotificationSubscription, e *hvs.NotificationEvent, payload []byte) {
+	defaultLog.Trace("notification/dispatcher:deliver() Entering")
+	defer defaultLog.Trace("notification/dispatcher:deliver() Leaving")
+	defer d.deliveries.Done()
+
+	attempts := 0
+	retryInterval := d.cfg.RetryInterval
+	var err error
+	for {
+		attempts++
+		if err = d.post(subscription, e, payload); err == nil {
+			defaultLog.Debugf("notification/dispatcher:deliver() Delivered %s event %s to subscription %s", e.EventType, e.ID, subscription.ID)
+			return
+		}
+		defaultLog.WithError(err).Warnf("notification/dispatcher:deliver() Attempt %d to deliver %s event %s to subscription %s failed", attempts, e.EventType, e.ID, subscription.ID)
+		if attempts > d.cfg.MaxRetries || !d.waitForRetry(retryInterval) {
+			break
+		}
+		retryInterval *= 2
+	}
+
+	_, dlErr := d.store.CreateDeadLetter(&hvs.NotificationDeadLetter{
+		SubscriptionId: subscription.ID,
+		Event:          *e,
+		Attempts:       attempts,
+		LastError:      err.Error(),
+	})
+	if dlErr != nil {
+		defaultLog.WithError(dlErr).Errorf("notification/dispatcher:deliver() Failed to store dead letter of %s event %s for subscription %s", e.EventType, e.ID, subscription.ID)
+	}
+}
+
+// waitForRetry returns false if the dispatcher is stopped before the retry interval elapses
+func (d *dispatcher) waitForRetry(retryInterval time.Duration) bool {
+	select {
+	case <-time.After(retryInterval):
+		return true
+	case <-d.ctx.Done():
+		return false
+	}
+}
+
+func (d *dispatcher) post(subscription hvs.NotificationSubscription, e *hvs.NotificationEvent, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, subscription.Url, bytes.NewBuffer(payload))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", constants.HTTPMediaTypeJson)
+	req.Header.Set(HeaderEventType, e.EventType.String())
+	req.Header.Set(HeaderDeliveryId, e.ID.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, SignPayload(subscription.Secret, timestamp, payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer func() {
+		derr := resp.Body.Close()
+		if derr != nil {
+			defaultLog.WithError(derr).Error("Error closing response body")
+		}
+	}()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SignPayload returns the value of the signature header for the given payload, subscribers can use it to
+// verify that the payload was sent by HVS
+func SignPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
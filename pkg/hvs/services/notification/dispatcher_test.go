@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package notification
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+type subscriber struct {
+	t          *testing.T
+	secret     string
+	statusCode int
+	events     []hvs.NotificationEvent
+	lock       sync.Mutex
+}
+
+func (s *subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	payload, err := ioutil.ReadAll(r.Body)
+	assert.NoError(s.t, err)
+	assert.Equal(s.t, SignPayload(s.secret, r.Header.Get(HeaderTimestamp), payload), r.Header.Get(HeaderSignature))
+
+	var event hvs.NotificationEvent
+	assert.NoError(s.t, json.Unmarshal(payload, &event))
+	assert.Equal(s.t, event.EventType.String(), r.Header.Get(HeaderEventType))
+	assert.Equal(s.t, event.ID.String(), r.Header.Get(HeaderDeliveryId))
+	s.events = append(s.events, event)
+	w.WriteHeader(s.statusCode)
+}
+
+func (s *subscriber) received() []hvs.NotificationEvent {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.events
+}
+
+func newTestDispatcher(t *testing.T, s *subscriber, eventTypes ...hvs.NotificationEventType) (*dispatcher, *mocks.MockNotificationSubscriptionStore, uuid.UUID) {
+	server := httptest.NewTLSServer(s)
+	t.Cleanup(server.Close)
+
+	store := &mocks.MockNotificationSubscriptionStore{}
+	subscription, err := store.Create(&hvs.NotificationSubscription{
+		Url:        server.URL,
+		EventTypes: eventTypes,
+		Secret:     s.secret,
+	})
+	assert.NoError(t, err)
+
+	publisher, err := NewNotificationDispatcher(store, NotificationConfig{
+		BufferSize:    10,
+		MaxRetries:    2,
+		RetryInterval: time.Millisecond,
+	}, server.Client())
+	assert.NoError(t, err)
+	return publisher.(*dispatcher), store, subscription.ID
+}
+
+func TestDispatcherDeliversSignedEvent(t *testing.T) {
+	s := &subscriber{t: t, secret: "secret", statusCode: http.StatusNoContent}
+	d, store, subscriptionId := newTestDispatcher(t, s, hvs.NotificationEventHostStateChanged)
+
+	hostId := uuid.New()
+	d.Publish(&hvs.NotificationEvent{
+		EventType:         hvs.NotificationEventHostStateChanged,
+		HostId:            hostId,
+		PreviousHostState: hvs.HostStateConnected.String(),
+		HostState:         hvs.HostStateConnectionFailure.String(),
+	})
+	// trust change events are not delivered to the subscriber of host state changes
+	d.Publish(&hvs.NotificationEvent{
+		EventType: hvs.NotificationEventHostTrustChanged,
+		HostId:    hostId,
+	})
+	d.Stop()
+
+	events := s.received()
+	assert.Len(t, events, 1)
+	assert.Equal(t, hostId, events[0].HostId)
+	assert.Equal(t, hvs.HostStateConnectionFailure.String(), events[0].HostState)
+	assert.NotEqual(t, uuid.Nil, events[0].ID)
+
+	deadLetters, err := store.SearchDeadLetters(subscriptionId)
+	assert.NoError(t, err)
+	assert.Empty(t, deadLetters)
+}
+
+func TestDispatcherMovesUndeliveredEventToDeadLetters(t *testing.T) {
+	s := &subscriber{t: t, secret: "secret", statusCode: http.StatusServiceUnavailable}
+	d, store, subscriptionId := newTestDispatcher(t, s, hvs.NotificationEventHostTrustChanged)
+
+	trusted := false
+	d.Publish(&hvs.NotificationEvent{
+		EventType: hvs.NotificationEventHostTrustChanged,
+		HostId:    uuid.New(),
+		Trusted:   &trusted,
+	})
+
+	var deadLetters []hvs.NotificationDeadLetter
+	for i := 0; i < 100 && len(deadLetters) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		deadLetters, _ = store.SearchDeadLetters(subscriptionId)
+	}
+	d.Stop()
+
+	// first attempt and two retries
+	assert.Len(t, s.received(), 3)
+	assert.Len(t, deadLetters, 1)
+	assert.Equal(t, 3, deadLetters[0].Attempts)
+	assert.Equal(t, hvs.NotificationEventHostTrustChanged, deadLetters[0].Event.EventType)
+	assert.Contains(t, deadLetters[0].LastError, "503")
+}
+
+func TestDispatcherStopTwice(t *testing.T) {
+	s := &subscriber{t: t, secret: "secret", statusCode: http.StatusNoContent}
+	d, _, _ := newTestDispatcher(t, s, hvs.NotificationEventHostStateChanged)
+
+	d.Stop()
+	assert.NotPanics(t, d.Stop)
+}
+
+func TestNewNotificationDispatcherInvalidConfig(t *testing.T) {
+	_, err := NewNotificationDispatcher(nil, NotificationConfig{BufferSize: 1}, nil)
+	assert.Error(t, err)
+
+	_, err = NewNotificationDispatcher(&mocks.MockNotificationSubscriptionStore{}, NotificationConfig{}, nil)
+	assert.Error(t, err)
+}
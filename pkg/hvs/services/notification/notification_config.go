@@ -0,0 +1,19 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package notification
+
+import "time"
+
+type NotificationConfig struct {
+	// BufferSize is the number of events that can be queued for delivery
+	BufferSize int `yaml:"buffer-size" mapstructure:"buffer-size"`
+	// MaxRetries is the number of times a failed delivery is retried before the event is moved to the dead letters
+	MaxRetries int `yaml:"max-retries" mapstructure:"max-retries"`
+	// RetryInterval is the wait before the first retry, it is doubled for every subsequent retry
+	RetryInterval time.Duration `yaml:"retry-interval" mapstructure:"retry-interval"`
+	// RequestTimeout is the timeout of a single delivery request
+	RequestTimeout time.Duration `yaml:"request-timeout" mapstructure:"request-timeout"`
+}
@@ -0,0 +1,359 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package remediation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	taClient "github.com/intel-secl/intel-secl/v5/pkg/clients/ta"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	commLog "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
+	cos "github.com/intel-secl/intel-secl/v5/pkg/lib/common/os"
+	hcUtil "github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/util"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v5/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+var secLog = commLog.GetSecurityLogger()
+
+// Environment variables passed to the script of the "script" action
+const (
+	EnvEventType    = "HVS_EVENT_TYPE"
+	EnvHostId       = "HVS_HOST_ID"
+	EnvHostName     = "HVS_HOST_NAME"
+	EnvHardwareUuid = "HVS_HARDWARE_UUID"
+	EnvReportId     = "HVS_REPORT_ID"
+)
+
+// RemediationService counts the consecutive untrusted reports of the hosts and quarantines a host once the
+// threshold is reached. The tag certificates of a quarantined host can be deleted and the configured actions are
+// invoked when a host is quarantined or released. A quarantined host stays quarantined until it is released. The
+// binding key certificate of a quarantined host is not affected.
+type RemediationService struct {
+	cfg                   RemediationConfig
+	quarantineStore       domain.HostQuarantineStore
+	hostStore             domain.HostStore
+	tagCertStore          domain.TagCertificateStore
+	notificationPublisher domain.NotificationPublisher
+	natsServers           []string
+
+	// serialize the updates of the remediation state of a host, the reports of a host can be verified concurrently
+	hostLocksMutex sync.Mutex
+	hostLocks      map[uuid.UUID]*hostLock
+}
+
+// hostLock is the lock of the remediation state of a host, it is removed once it is not referenced
+type hostLock struct {
+	sync.Mutex
+	references int
+}
+
+func NewRemediationService(cfg RemediationConfig, quarantineStore domain.HostQuarantineStore, hostStore domain.HostStore,
+	tagCertStore domain.TagCertificateStore, notificationPublisher domain.NotificationPublisher, natsServers []string) (*RemediationService, error) {
+	if quarantineStore == nil || hostStore == nil {
+		return nil, errors.New("Host quarantine store and host store must be provided")
+	}
+	if cfg.UntrustedReportsThreshold < 0 {
+		return nil, errors.New("Remediation untrusted reports threshold must not be negative")
+	}
+	if cfg.DeleteTagCertificates && tagCertStore == nil {
+		return nil, errors.New("Tag certificate store must be provided to delete the tag certificates")
+	}
+	for _, action := range cfg.Actions {
+		switch action {
+		case ActionWebhook:
+			if notificationPublisher == nil {
+				return nil, errors.New("Notification publisher must be provided for the webhook remediation action")
+			}
+		case ActionScript:
+			if cfg.Script == "" {
+				return nil, errors.New("Script must be provided for the script remediation action")
+			}
+			if cfg.ScriptTimeout <= 0 {
+				return nil, errors.New("Remediation script timeout must be positive")
+			}
+		case ActionNats:
+			if len(natsServers) == 0 {
+				return nil, errors.New("NATS servers must be configured for the nats remediation action")
+			}
+		default:
+			return nil, errors.Errorf("Unsupported remediation action %q", action)
+		}
+	}
+	return &RemediationService{
+		cfg:                   cfg,
+		quarantineStore:       quarantineStore,
+		hostStore:             hostStore,
+		tagCertStore:          tagCertStore,
+		notificationPublisher: notificationPublisher,
+		natsServers:           natsServers,
+		hostLocks:             make(map[uuid.UUID]*hostLock),
+	}, nil
+}
+
+// lockHost locks the remediation state of a host and returns the function unlocking it
+func (svc *RemediationService) lockHost(hostId uuid.UUID) func() {
+	svc.hostLocksMutex.Lock()
+	lock, ok := svc.hostLocks[hostId]
+	if !ok {
+		lock = &hostLock{}
+		svc.hostLocks[hostId] = lock
+	}
+	lock.references++
+	svc.hostLocksMutex.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		svc.hostLocksMutex.Lock()
+		lock.references--
+		if lock.references == 0 {
+			delete(svc.hostLocks, hostId)
+		}
+		svc.hostLocksMutex.Unlock()
+	}
+}
+
+func (svc *RemediationService) enabled() bool {
+	return svc.cfg.UntrustedReportsThreshold > 0
+}
+
+// ProcessReport updates the consecutive untrusted reports of the host of the report and quarantines the host when
+// the threshold is reached. The reports of a quarantined host are ignored.
+func (svc *RemediationService) ProcessReport(report *models.HVSReport) {
+	defaultLog.Trace("remediation/remediation:ProcessReport() Entering")
+	defer defaultLog.Trace("remediation/remediation:ProcessReport() Leaving")
+
+	if !svc.enabled() || report == nil {
+		return
+	}
+
+	defer svc.lockHost(report.HostID)()
+
+	hq, err := svc.quarantineStore.Retrieve(report.HostID)
+	if err != nil {
+		if !strings.Contains(err.Error(), commErr.RowsNotFound) {
+			defaultLog.WithError(err).Errorf("remediation/remediation:ProcessReport() Failed to retrieve the remediation state of host %s", report.HostID)
+			return
+		}
+		hq = &hvs.HostQuarantine{HostId: report.HostID}
+	}
+	if hq.Quarantined {
+		return
+	}
+
+	if report.TrustReport.Trusted {
+		if hq.UntrustedReports == 0 {
+			return
+		}
+		hq.UntrustedReports = 0
+		if err := svc.quarantineStore.Persist(hq); err != nil {
+			defaultLog.WithError(err).Errorf("remediation/remediation:ProcessReport() Failed to reset the untrusted reports of host %s", report.HostID)
+		}
+		return
+	}
+
+	hq.UntrustedReports++
+	if hq.UntrustedReports < svc.cfg.UntrustedReportsThreshold {
+		if err := svc.quarantineStore.Persist(hq); err != nil {
+			defaultLog.WithError(err).Errorf("remediation/remediation:ProcessReport() Failed to update the untrusted reports of host %s", report.HostID)
+		}
+		return
+	}
+
+	host, err := svc.hostStore.Retrieve(report.HostID, nil)
+	if err != nil {
+		defaultLog.WithError(err).Errorf("remediation/remediation:ProcessReport() Failed to retrieve host %s", report.HostID)
+		return
+	}
+
+	now := time.Now()
+	reportId := report.ID
+	hq.Quarantined = true
+	hq.QuarantinedAt = &now
+	hq.ReportId = &reportId
+	hq.DeletedTagCertificates = nil
+	hq.ReleasedAt = nil
+	hq.ReleaseComment = ""
+	if svc.cfg.DeleteTagCertificates {
+		hq.DeletedTagCertificates = svc.deleteTagCertificates(host)
+	}
+	if err := svc.quarantineStore.Persist(hq); err != nil {
+		defaultLog.WithError(err).Errorf("remediation/remediation:ProcessReport() Failed to quarantine host %s", report.HostID)
+		return
+	}
+	secLog.Warnf("remediation/remediation:ProcessReport() Host %s has been quarantined after %d consecutive untrusted reports", host.Id, hq.UntrustedReports)
+
+	go svc.invokeActions(hvs.NotificationEventHostQuarantined, host, hq)
+}
+
+// Release releases a quarantined host. The deleted tag certificates are not restored, new certificates have to be
+// created for the host.
+func (svc *RemediationService) Release(hostId uuid.UUID, comment string) (*hvs.HostQuarantine, error) {
+	defaultLog.Trace("remediation/remediation:Release() Entering")
+	defer defaultLog.Trace("remediation/remediation:Release() Leaving")
+
+	defer svc.lockHost(hostId)()
+
+	hq, err := svc.quarantineStore.Retrieve(hostId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to retrieve the remediation state of host %s", hostId)
+	}
+	if !hq.Quarantined {
+		return nil, errors.Errorf("Host %s is not quarantined", hostId)
+	}
+	host, err := svc.hostStore.Retrieve(hostId, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to retrieve host %s", hostId)
+	}
+
+	now := time.Now()
+	hq.Quarantined = false
+	hq.UntrustedReports = 0
+	hq.ReleasedAt = &now
+	hq.ReleaseComment = comment
+	if err := svc.quarantineStore.Persist(hq); err != nil {
+		return nil, errors.Wrapf(err, "Failed to release host %s", hostId)
+	}
+	secLog.Infof("remediation/remediation:Release() Host %s has been released from quarantine", hostId)
+
+	go svc.invokeActions(hvs.NotificationEventHostReleased, host, hq)
+	return hq, nil
+}
+
+// deleteTagCertificates deletes the tag certificates of the host and returns the IDs of the deleted certificates
+func (svc *RemediationService) deleteTagCertificates(host *hvs.Host) []uuid.UUID {
+	var deleted []uuid.UUID
+	if host.HardwareUuid == nil {
+		return deleted
+	}
+	tagCerts, err := svc.tagCertStore.Search(&models.TagCertificateFilterCriteria{HardwareUUID: *host.HardwareUuid})
+	if err != nil {
+		defaultLog.WithError(err).Errorf("remediation/remediation:deleteTagCertificates() Failed to search the tag certificates of host %s", host.Id)
+		return deleted
+	}
+	for _, tagCert := range tagCerts {
+		if err := svc.tagCertStore.Delete(tagCert.ID); err != nil {
+			defaultLog.WithError(err).Errorf("remediation/remediation:deleteTagCertificates() Failed to delete tag certificate %s of host %s", tagCert.ID, host.Id)
+			continue
+		}
+		deleted = append(deleted, tagCert.ID)
+	}
+	return deleted
+}
+
+// invokeActions runs the configured actions, a failed action is logged and does not prevent the others
+func (svc *RemediationService) invokeActions(eventType hvs.NotificationEventType, host *hvs.Host, hq *hvs.HostQuarantine) {
+	defer func() {
+		if err := recover(); err != nil {
+			defaultLog.Errorf("Panic occurred: %+v", err)
+			defaultLog.Error(string(debug.Stack()))
+		}
+	}()
+
+	for _, action := range svc.cfg.Actions {
+		var err error
+		switch action {
+		case ActionWebhook:
+			svc.notificationPublisher.Publish(&hvs.NotificationEvent{
+				ID:        uuid.New(),
+				EventType: eventType,
+				HostId:    host.Id,
+				CreatedAt: time.Now(),
+				ReportId:  hq.ReportId,
+			})
+		case ActionScript:
+			err = svc.runScript(eventType, host, hq)
+		case ActionNats:
+			err = svc.sendQuarantineNotice(host, hq)
+		}
+		if err != nil {
+			defaultLog.WithError(err).Errorf("remediation/remediation:invokeActions() The %s action failed for %s event of host %s", action, eventType, host.Id)
+		}
+	}
+}
+
+func (svc *RemediationService) runScript(eventType hvs.NotificationEventType, host *hvs.Host, hq *hvs.HostQuarantine) error {
+	ctx, cancel := context.WithTimeout(context.Background(), svc.cfg.ScriptTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, svc.cfg.Script)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("%s=%s", EnvEventType, eventType),
+		fmt.Sprintf("%s=%s", EnvHostId, host.Id),
+		fmt.Sprintf("%s=%s", EnvHostName, host.HostName))
+	if host.HardwareUuid != nil {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", EnvHardwareUuid, host.HardwareUuid))
+	}
+	if hq.ReportId != nil {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", EnvReportId, hq.ReportId))
+	}
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "Remediation script failed with output %q", string(output))
+	}
+	return nil
+}
+
+// sendQuarantineNotice notifies the Trust Agent of the host, hosts that are not connected through NATS are skipped
+func (svc *RemediationService) sendQuarantineNotice(host *hvs.Host, hq *hvs.HostQuarantine) error {
+	vendorConnector, err := hcUtil.GetConnectorDetails(host.ConnectionString)
+	if err != nil {
+		return errors.Wrap(err, "Invalid connection string")
+	}
+	taApiURL, err := url.Parse(vendorConnector.Url)
+	if err != nil || taApiURL.Scheme != "nats" {
+		defaultLog.Debugf("remediation/remediation:sendQuarantineNotice() Host %s is not connected through NATS, skipping", host.Id)
+		return nil
+	}
+
+	rootCAs, _ := x509.SystemCertPool()
+	if rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+	certs, err := cos.GetDirFileContents(constants.TrustedCaCertsDir, "*.pem")
+	if err != nil {
+		defaultLog.WithError(err).Warnf("remediation/remediation:sendQuarantineNotice() Failed to read the certificates in %s", constants.TrustedCaCertsDir)
+	}
+	for _, rootCACert := range certs {
+		rootCAs.AppendCertsFromPEM(rootCACert)
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+	}
+
+	client, err := taClient.NewNatsTAClient(svc.natsServers, taApiURL.Host, tlsConfig, constants.NatsCredentials, false)
+	if err != nil {
+		return errors.Wrap(err, "Could not create nats Trust Agent client")
+	}
+	notifier, ok := client.(taClient.QuarantineNotifier)
+	if !ok {
+		return errors.New("Trust Agent client cannot send quarantine notices")
+	}
+
+	notice := taModel.QuarantineNotice{Quarantined: hq.Quarantined}
+	if hq.Quarantined && hq.ReportId != nil {
+		notice.ReportId = hq.ReportId.String()
+	}
+	return notifier.SendQuarantineNotice(notice)
+}
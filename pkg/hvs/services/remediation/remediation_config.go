@@ -0,0 +1,32 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package remediation
+
+import "time"
+
+// Actions invoked when a host is quarantined or released
+const (
+	// ActionWebhook publishes the event to the notification subscribers of the event type
+	ActionWebhook = "webhook"
+	// ActionScript runs the configured script
+	ActionScript = "script"
+	// ActionNats notifies the Trust Agent of a host connected through NATS
+	ActionNats = "nats"
+)
+
+type RemediationConfig struct {
+	// UntrustedReportsThreshold is the number of consecutive untrusted reports after which a host is quarantined,
+	// zero disables the remediation
+	UntrustedReportsThreshold int `yaml:"untrusted-reports-threshold" mapstructure:"untrusted-reports-threshold"`
+	// DeleteTagCertificates deletes the tag certificates of a host when it is quarantined
+	DeleteTagCertificates bool `yaml:"delete-tag-certificates" mapstructure:"delete-tag-certificates"`
+	// Actions are invoked when a host is quarantined or released, any of "webhook", "script" and "nats"
+	Actions []string `yaml:"actions" mapstructure:"actions"`
+	// Script is the path of the executable run by the "script" action
+	Script string `yaml:"script" mapstructure:"script"`
+	// ScriptTimeout is the time after which the script is killed
+	ScriptTimeout time.Duration `yaml:"script-timeout" mapstructure:"script-timeout"`
+}
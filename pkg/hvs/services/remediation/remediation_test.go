@@ -0,0 +1,226 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package remediation
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+// the intel host of the mocked host store
+var hostId = uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+type publisher struct {
+	lock   sync.Mutex
+	events []hvs.NotificationEvent
+}
+
+func (p *publisher) Publish(e *hvs.NotificationEvent) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.events = append(p.events, *e)
+}
+
+func (p *publisher) Stop() {}
+
+func (p *publisher) eventTypes() []hvs.NotificationEventType {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var eventTypes []hvs.NotificationEventType
+	for _, e := range p.events {
+		eventTypes = append(eventTypes, e.EventType)
+	}
+	return eventTypes
+}
+
+type tagCertificateStore struct {
+	tagCerts []*hvs.TagCertificate
+}
+
+func (s *tagCertificateStore) Create(tc *hvs.TagCertificate) (*hvs.TagCertificate, error) {
+	s.tagCerts = append(s.tagCerts, tc)
+	return tc, nil
+}
+
+func (s *tagCertificateStore) Retrieve(id uuid.UUID) (*hvs.TagCertificate, error) {
+	return nil, nil
+}
+
+func (s *tagCertificateStore) Delete(id uuid.UUID) error {
+	for i, tc := range s.tagCerts {
+		if tc.ID == id {
+			s.tagCerts = append(s.tagCerts[:i], s.tagCerts[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *tagCertificateStore) Search(criteria *models.TagCertificateFilterCriteria) ([]*hvs.TagCertificate, error) {
+	var tagCerts []*hvs.TagCertificate
+	for _, tc := range s.tagCerts {
+		if tc.HardwareUUID == criteria.HardwareUUID {
+			tagCerts = append(tagCerts, tc)
+		}
+	}
+	return tagCerts, nil
+}
+
+func (s *tagCertificateStore) Count(criteria *models.TagCertificateFilterCriteria) (int, error) {
+	tagCerts, err := s.Search(criteria)
+	return len(tagCerts), err
+}
+
+func newReport(trusted bool) *models.HVSReport {
+	return &models.HVSReport{
+		ID:          uuid.New(),
+		HostID:      hostId,
+		TrustReport: hvs.TrustReport{Trusted: trusted},
+	}
+}
+
+func TestRemediationQuarantine(t *testing.T) {
+	quarantineStore := mocks.NewMockHostQuarantineStore()
+	hostStore := mocks.NewMockHostStore()
+	host, _ := hostStore.Retrieve(hostId, nil)
+	tagCertId := uuid.New()
+	tagCertStore := &tagCertificateStore{tagCerts: []*hvs.TagCertificate{
+		{ID: tagCertId, HardwareUUID: *host.HardwareUuid},
+		{ID: uuid.New(), HardwareUUID: uuid.New()},
+	}}
+	np := &publisher{}
+
+	svc, err := NewRemediationService(RemediationConfig{
+		UntrustedReportsThreshold: 2,
+		DeleteTagCertificates:     true,
+		Actions:                   []string{ActionWebhook},
+	}, quarantineStore, hostStore, tagCertStore, np, nil)
+	assert.NoError(t, err)
+
+	// a trusted report resets the consecutive untrusted reports
+	svc.ProcessReport(newReport(false))
+	svc.ProcessReport(newReport(true))
+	hq, err := quarantineStore.Retrieve(hostId)
+	assert.NoError(t, err)
+	assert.False(t, hq.Quarantined)
+	assert.Equal(t, 0, hq.UntrustedReports)
+
+	svc.ProcessReport(newReport(false))
+	report := newReport(false)
+	svc.ProcessReport(report)
+	hq, err = quarantineStore.Retrieve(hostId)
+	assert.NoError(t, err)
+	assert.True(t, hq.Quarantined)
+	assert.Equal(t, 2, hq.UntrustedReports)
+	assert.Equal(t, report.ID, *hq.ReportId)
+	assert.NotNil(t, hq.QuarantinedAt)
+	assert.Equal(t, []uuid.UUID{tagCertId}, hq.DeletedTagCertificates)
+	assert.Len(t, tagCertStore.tagCerts, 1)
+
+	// the host stays quarantined until it is released
+	svc.ProcessReport(newReport(true))
+	hq, _ = quarantineStore.Retrieve(hostId)
+	assert.True(t, hq.Quarantined)
+
+	assert.Eventually(t, func() bool {
+		return len(np.eventTypes()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, hvs.NotificationEventHostQuarantined, np.eventTypes()[0])
+}
+
+func TestRemediationConcurrentReports(t *testing.T) {
+	quarantineStore := mocks.NewMockHostQuarantineStore()
+	svc, err := NewRemediationService(RemediationConfig{UntrustedReportsThreshold: 100}, quarantineStore,
+		mocks.NewMockHostStore(), nil, nil, nil)
+	assert.NoError(t, err)
+
+	otherHostId := uuid.New()
+	const reports = 20
+	var wg sync.WaitGroup
+	for i := 0; i < reports; i++ {
+		for _, id := range []uuid.UUID{hostId, otherHostId} {
+			wg.Add(1)
+			go func(id uuid.UUID) {
+				defer wg.Done()
+				report := newReport(false)
+				report.HostID = id
+				svc.ProcessReport(report)
+			}(id)
+		}
+	}
+	wg.Wait()
+
+	// the untrusted reports of every host are counted and the locks of the hosts are removed
+	for _, id := range []uuid.UUID{hostId, otherHostId} {
+		hq, err := quarantineStore.Retrieve(id)
+		assert.NoError(t, err)
+		assert.Equal(t, reports, hq.UntrustedReports)
+	}
+	assert.Empty(t, svc.hostLocks)
+}
+
+func TestRemediationRelease(t *testing.T) {
+	quarantineStore := mocks.NewMockHostQuarantineStore()
+	np := &publisher{}
+	svc, err := NewRemediationService(RemediationConfig{
+		UntrustedReportsThreshold: 1,
+		Actions:                   []string{ActionWebhook},
+	}, quarantineStore, mocks.NewMockHostStore(), nil, np, nil)
+	assert.NoError(t, err)
+
+	_, err = svc.Release(hostId, "")
+	assert.Error(t, err)
+
+	svc.ProcessReport(newReport(false))
+	hq, err := svc.Release(hostId, "firmware updated")
+	assert.NoError(t, err)
+	assert.False(t, hq.Quarantined)
+	assert.Equal(t, 0, hq.UntrustedReports)
+	assert.Equal(t, "firmware updated", hq.ReleaseComment)
+	assert.NotNil(t, hq.ReleasedAt)
+
+	// releasing a host that is not quarantined fails
+	_, err = svc.Release(hostId, "")
+	assert.Error(t, err)
+
+	assert.Eventually(t, func() bool {
+		return len(np.eventTypes()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, []hvs.NotificationEventType{hvs.NotificationEventHostQuarantined, hvs.NotificationEventHostReleased}, np.eventTypes())
+}
+
+func TestRemediationDisabled(t *testing.T) {
+	quarantineStore := mocks.NewMockHostQuarantineStore()
+	svc, err := NewRemediationService(RemediationConfig{}, quarantineStore, mocks.NewMockHostStore(), nil, nil, nil)
+	assert.NoError(t, err)
+
+	svc.ProcessReport(newReport(false))
+	_, err = quarantineStore.Retrieve(hostId)
+	assert.Error(t, err)
+}
+
+func TestNewRemediationServiceInvalidConfig(t *testing.T) {
+	quarantineStore := mocks.NewMockHostQuarantineStore()
+	hostStore := mocks.NewMockHostStore()
+
+	_, err := NewRemediationService(RemediationConfig{UntrustedReportsThreshold: -1}, quarantineStore, hostStore, nil, nil, nil)
+	assert.Error(t, err)
+	_, err = NewRemediationService(RemediationConfig{Actions: []string{"email"}}, quarantineStore, hostStore, nil, nil, nil)
+	assert.Error(t, err)
+	_, err = NewRemediationService(RemediationConfig{Actions: []string{ActionScript}}, quarantineStore, hostStore, nil, nil, nil)
+	assert.Error(t, err)
+	_, err = NewRemediationService(RemediationConfig{Actions: []string{ActionNats}}, quarantineStore, hostStore, nil, nil, nil)
+	assert.Error(t, err)
+	_, err = NewRemediationService(RemediationConfig{DeleteTagCertificates: true}, quarantineStore, hostStore, nil, nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package reportretention
+
+import (
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	metricsSubsystem = "hvs_report_retention"
+
+	purgeResultSuccess = "success"
+	purgeResultFailure = "failure"
+)
+
+var (
+	purges = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: commMetrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "purges_total",
+		Help:      "Number of report history purges by result.",
+	}, []string{"result"})
+
+	purgedReports = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: commMetrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "purged_reports_total",
+		Help:      "Number of reports removed from the report history.",
+	})
+)
@@ -0,0 +1,185 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package reportretention
+
+import (
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	commLog "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+var defaultLog = commLog.GetDefaultLogger()
+
+// ReportRetentionService runs in the background and periodically purges the reports of the report history
+// that are older than the retention period. The latest reports of every host as well as its last trusted
+// and last untrusted reports are always kept.
+type ReportRetentionService struct {
+	reportStore domain.ReportStore
+	cfg         ReportRetentionConfig
+
+	lock    sync.RWMutex
+	running bool
+	lastRun *hvs.ReportRetentionRun
+	nextRun *time.Time
+	stop    chan struct{}
+}
+
+func NewReportRetentionService(cfg ReportRetentionConfig, reportStore domain.ReportStore) (*ReportRetentionService, error) {
+	if reportStore == nil {
+		return nil, errors.New("Report store must be provided")
+	}
+	if cfg.RetentionDays < 0 || cfg.KeepLatestPerHost < 0 {
+		return nil, errors.New("Report retention days and reports kept per host must not be negative")
+	}
+	if cfg.RetentionDays > 0 && cfg.BatchSize <= 0 {
+		return nil, errors.New("Report retention batch size must be positive")
+	}
+	return &ReportRetentionService{
+		reportStore: reportStore,
+		cfg:         cfg,
+	}, nil
+}
+
+func (svc *ReportRetentionService) enabled() bool {
+	return svc.cfg.RunPeriod > 0 && svc.cfg.RetentionDays > 0
+}
+
+func (svc *ReportRetentionService) Run() error {
+	if !svc.enabled() {
+		defaultLog.Info("The report retention period or run period is zero. Report retention will now exit")
+		return nil
+	}
+	defaultLog.Infof("Report retention is starting with run period '%s' and retention of %d days", svc.cfg.RunPeriod, svc.cfg.RetentionDays)
+
+	svc.lock.Lock()
+	if svc.stop != nil {
+		svc.lock.Unlock()
+		return errors.New("Report retention is already running")
+	}
+	stop := make(chan struct{})
+	svc.stop = stop
+	svc.lock.Unlock()
+
+	go func() {
+		defer func() {
+			if err := recover(); err != nil {
+				defaultLog.Errorf("Panic occurred: %+v", err)
+				defaultLog.Error(string(debug.Stack()))
+			}
+		}()
+		for {
+			run := svc.Purge()
+			if run.Error != "" {
+				// log any errors, but do not stop purging the report history
+				defaultLog.Errorf("Report retention encountered an error while purging reports: %s", run.Error)
+			}
+
+			next := time.Now().UTC().Add(svc.cfg.RunPeriod)
+			svc.lock.Lock()
+			svc.nextRun = &next
+			svc.lock.Unlock()
+
+			select {
+			case <-time.After(svc.cfg.RunPeriod):
+				// continue with the loop and purge the reports again
+			case <-stop:
+				defaultLog.Info("Report retention has been stopped and will now exit")
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (svc *ReportRetentionService) Stop() error {
+	svc.lock.Lock()
+	defer svc.lock.Unlock()
+	if svc.stop == nil {
+		defaultLog.Debug("Report retention is not running")
+		return nil
+	}
+	close(svc.stop)
+	svc.stop = nil
+	svc.nextRun = nil
+	return nil
+}
+
+// Purge removes the reports older than the retention period in batches of the configured size, the statistics
+// of the run are recorded as the last run of the service
+func (svc *ReportRetentionService) Purge() hvs.ReportRetentionRun {
+	run := hvs.ReportRetentionRun{
+		StartedAt:     time.Now().UTC(),
+		CreatedBefore: time.Now().UTC().AddDate(0, 0, -svc.cfg.RetentionDays),
+	}
+	svc.lock.Lock()
+	svc.running = true
+	svc.lock.Unlock()
+
+	criteria := models.ReportPurgeCriteria{
+		CreatedBefore:     run.CreatedBefore,
+		KeepLatestPerHost: svc.cfg.KeepLatestPerHost,
+		BatchSize:         svc.cfg.BatchSize,
+		Archive:           svc.cfg.Archive,
+	}
+	for {
+		purged, err := svc.reportStore.PurgeHistory(&criteria)
+		if err != nil {
+			run.Error = errors.Wrap(err, "Error purging the report history").Error()
+			break
+		}
+		run.Batches++
+		run.Purged += purged
+		purgedReports.Add(float64(purged))
+		if purged < criteria.BatchSize {
+			break
+		}
+	}
+	run.CompletedAt = time.Now().UTC()
+	if run.Error != "" {
+		purges.WithLabelValues(purgeResultFailure).Inc()
+	} else {
+		purges.WithLabelValues(purgeResultSuccess).Inc()
+		defaultLog.Infof("Report retention purged %d reports created before %s in %d batches", run.Purged, run.CreatedBefore, run.Batches)
+	}
+
+	svc.lock.Lock()
+	svc.running = false
+	svc.lastRun = &run
+	svc.lock.Unlock()
+	return run
+}
+
+// Status returns the retention policy and the statistics of the last purge
+func (svc *ReportRetentionService) Status() hvs.ReportRetentionStatus {
+	svc.lock.RLock()
+	defer svc.lock.RUnlock()
+
+	status := hvs.ReportRetentionStatus{
+		Enabled:           svc.enabled(),
+		RunPeriod:         svc.cfg.RunPeriod.String(),
+		RetentionDays:     svc.cfg.RetentionDays,
+		KeepLatestPerHost: svc.cfg.KeepLatestPerHost,
+		BatchSize:         svc.cfg.BatchSize,
+		Archive:           svc.cfg.Archive,
+		Running:           svc.running,
+	}
+	if svc.lastRun != nil {
+		lastRun := *svc.lastRun
+		status.LastRun = &lastRun
+	}
+	if svc.nextRun != nil {
+		nextRun := *svc.nextRun
+		status.NextRun = &nextRun
+	}
+	return status
+}
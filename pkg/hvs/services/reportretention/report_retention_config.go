@@ -0,0 +1,21 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package reportretention
+
+import "time"
+
+type ReportRetentionConfig struct {
+	// RunPeriod determines how frequently the report history is purged
+	RunPeriod time.Duration `yaml:"run-period" mapstructure:"run-period"`
+	// RetentionDays is the number of days the reports are kept in the report history, zero disables the purge
+	RetentionDays int `yaml:"retention-days" mapstructure:"retention-days"`
+	// KeepLatestPerHost is the number of latest reports of a host, including the current one, that are never purged
+	KeepLatestPerHost int `yaml:"keep-latest-per-host" mapstructure:"keep-latest-per-host"`
+	// BatchSize is the maximum number of reports purged in a single transaction
+	BatchSize int `yaml:"batch-size" mapstructure:"batch-size"`
+	// Archive moves the purged reports to the report archive instead of deleting them
+	Archive bool `yaml:"archive" mapstructure:"archive"`
+}
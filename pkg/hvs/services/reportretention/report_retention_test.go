@@ -0,0 +1,107 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package reportretention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+// newReportHistory creates the reports of a host created the given number of days ago, the last one is the
+// current report of the host
+func newReportHistory(t *testing.T, reportStore *mocks.MockReportStore, ageInDays []int, trusted []bool) uuid.UUID {
+	hostID := uuid.New()
+	for i, age := range ageInDays {
+		report := &models.HVSReport{
+			ID:          uuid.New(),
+			HostID:      hostID,
+			CreatedAt:   time.Now().UTC().AddDate(0, 0, -age),
+			TrustReport: hvs.TrustReport{Trusted: trusted[i]},
+		}
+		_, err := reportStore.Update(report)
+		assert.NoError(t, err)
+	}
+	return hostID
+}
+
+func TestReportRetentionPurge(t *testing.T) {
+	reportStore := mocks.NewEmptyMockReportStore().(*mocks.MockReportStore)
+	hostID := newReportHistory(t, reportStore, []int{100, 90, 80, 70, 1}, []bool{true, true, false, true, true})
+
+	svc, err := NewReportRetentionService(ReportRetentionConfig{
+		RunPeriod:         time.Hour,
+		RetentionDays:     30,
+		KeepLatestPerHost: 2,
+		BatchSize:         1,
+		Archive:           true,
+	}, reportStore)
+	assert.NoError(t, err)
+
+	run := svc.Purge()
+	assert.Empty(t, run.Error)
+	// the reports of 100 and 90 days ago are purged, the untrusted report is the last untrusted one
+	assert.Equal(t, 2, run.Purged)
+	assert.Equal(t, 3, run.Batches)
+	assert.Len(t, reportStore.ReportArchive, 2)
+	for _, archived := range reportStore.ReportArchive {
+		assert.Equal(t, hostID, archived.HostID)
+		assert.True(t, archived.TrustReport.Trusted)
+	}
+
+	status := svc.Status()
+	assert.True(t, status.Enabled)
+	assert.False(t, status.Running)
+	assert.Equal(t, "1h0m0s", status.RunPeriod)
+	if assert.NotNil(t, status.LastRun) {
+		assert.Equal(t, 2, status.LastRun.Purged)
+	}
+
+	// nothing is left to purge
+	run = svc.Purge()
+	assert.Empty(t, run.Error)
+	assert.Equal(t, 0, run.Purged)
+	assert.Equal(t, 1, run.Batches)
+}
+
+func TestReportRetentionKeepsRecentReports(t *testing.T) {
+	reportStore := mocks.NewEmptyMockReportStore().(*mocks.MockReportStore)
+	newReportHistory(t, reportStore, []int{20, 10, 1}, []bool{true, true, true})
+
+	svc, err := NewReportRetentionService(ReportRetentionConfig{
+		RunPeriod:     time.Hour,
+		RetentionDays: 30,
+		BatchSize:     10,
+	}, reportStore)
+	assert.NoError(t, err)
+
+	run := svc.Purge()
+	assert.Empty(t, run.Error)
+	assert.Equal(t, 0, run.Purged)
+	assert.Empty(t, reportStore.ReportArchive)
+}
+
+func TestReportRetentionDisabled(t *testing.T) {
+	svc, err := NewReportRetentionService(ReportRetentionConfig{RunPeriod: time.Hour}, mocks.NewEmptyMockReportStore())
+	assert.NoError(t, err)
+	assert.NoError(t, svc.Run())
+	assert.NoError(t, svc.Stop())
+
+	status := svc.Status()
+	assert.False(t, status.Enabled)
+	assert.Nil(t, status.LastRun)
+	assert.Nil(t, status.NextRun)
+
+	_, err = NewReportRetentionService(ReportRetentionConfig{RetentionDays: 30}, mocks.NewEmptyMockReportStore())
+	assert.Error(t, err)
+	_, err = NewReportRetentionService(ReportRetentionConfig{BatchSize: 10}, nil)
+	assert.Error(t, err)
+}
@@ -45,11 +45,12 @@ func NewVCenterClusterSyncer(cfg config.VCSSConfig, hcConfig domain.HostControll
 	flavorStore := postgres.NewFlavorStore(dataStore)
 	flavorGroupStore := postgres.NewFlavorGroupStore(dataStore)
 	hostCredentialStore := postgres.NewHostCredentialStore(dataStore, hcConfig.DataEncryptionKey)
+	hostRegistrationStore := postgres.NewHostRegistrationStore(dataStore, hcConfig.DataEncryptionKey)
 
 	ecStore := postgres.NewESXiCLusterStore(dataStore, hcConfig.DataEncryptionKey)
 
 	hostController := controllers.NewHostController(hostStore, hostStatusStore,
-		flavorStore, flavorGroupStore, hostCredentialStore,
+		flavorStore, flavorGroupStore, hostCredentialStore, hostRegistrationStore,
 		hostTrustManager, hcConfig)
 
 	return &vCenterClusterSyncerImpl{
@@ -124,7 +124,9 @@ func (t *DBSetup) Run() error {
 	if err != nil {
 		return errors.Wrap(err, "Failed to connect database")
 	}
-	dataStore.Migrate()
+	if err := dataStore.Migrate(); err != nil {
+		return errors.Wrap(err, "Failed to migrate database")
+	}
 	return nil
 }
 
@@ -150,7 +150,9 @@ func (a *App) eraseData() error {
 			return errors.Wrap(err, "Failed to execute query")
 		}
 	}
-	dataStore.Migrate()
+	if err := dataStore.Migrate(); err != nil {
+		return errors.Wrap(err, "Failed to migrate database")
+	}
 	// create default flavor group
 	t := tasks.CreateDefaultFlavor{
 		DBConfig: dbConf,
@@ -0,0 +1,206 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// OfflineCertificatePaths holds the locations of the certificates used to verify a host without HVS, each of them
+// can be a PEM file or a directory of PEM files
+type OfflineCertificatePaths struct {
+	PrivacyCA         string
+	AssetTagCA        string
+	FlavorSigningCert string
+	FlavorCA          string
+}
+
+// LoadOfflineCertificates loads the certificates required by the flavor verifier into a certificate store
+func LoadOfflineCertificates(paths OfflineCertificatePaths) (*crypt.CertificatesStore, error) {
+	defaultLog.Trace("utils/offline_verifier:LoadOfflineCertificates() Entering")
+	defer defaultLog.Trace("utils/offline_verifier:LoadOfflineCertificates() Leaving")
+
+	certStore := make(crypt.CertificatesStore)
+	for certType, path := range map[string]string{
+		models.CaCertTypesPrivacyCa.String():   paths.PrivacyCA,
+		models.CaCertTypesTagCa.String():       paths.AssetTagCA,
+		models.CertTypesFlavorSigning.String(): paths.FlavorSigningCert,
+		models.CaCertTypesRootCa.String():      paths.FlavorCA,
+	} {
+		certs, err := loadCertificatesFromPath(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not load %s certificates", certType)
+		}
+		certStore[certType] = &crypt.CertificateStore{CertPath: path, Certificates: certs}
+	}
+	return &certStore, nil
+}
+
+func loadCertificatesFromPath(path string) ([]x509.Certificate, error) {
+	if path == "" {
+		return nil, errors.New("The certificate path is not provided")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not access certificate path "+path)
+	}
+	var certs []x509.Certificate
+	if info.IsDir() {
+		certs, err = crypt.GetCertsFromDir(path)
+	} else {
+		certs, err = crypt.GetSubjectCertsMapFromPemFile(path)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read certificates from "+path)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("No certificates found in " + path)
+	}
+	return certs, nil
+}
+
+// LoadHostManifest reads a host manifest saved as JSON, the manifest can also be wrapped in a host status
+func LoadHostManifest(path string) (*hvs.HostManifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read host manifest file "+path)
+	}
+
+	var hostStatus struct {
+		HostManifest *hvs.HostManifest `json:"host_manifest"`
+	}
+	if err := json.Unmarshal(data, &hostStatus); err == nil && hostStatus.HostManifest != nil {
+		return hostStatus.HostManifest, nil
+	}
+
+	var hostManifest hvs.HostManifest
+	if err := json.Unmarshal(data, &hostManifest); err != nil {
+		return nil, errors.Wrap(err, "Could not decode host manifest file "+path)
+	}
+	return &hostManifest, nil
+}
+
+// LoadSignedFlavors reads the signed flavors saved as JSON, the file can hold a single signed flavor, an array
+// of signed flavors or a signed flavor collection
+func LoadSignedFlavors(path string) ([]hvs.SignedFlavor, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read signed flavor file "+path)
+	}
+
+	var signedFlavors []hvs.SignedFlavor
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err = json.Unmarshal(data, &signedFlavors)
+	} else {
+		var collection hvs.SignedFlavorCollection
+		if err = json.Unmarshal(data, &collection); err == nil && len(collection.SignedFlavors) > 0 {
+			signedFlavors = collection.SignedFlavors
+		} else {
+			var signedFlavor hvs.SignedFlavor
+			if err = json.Unmarshal(data, &signedFlavor); err == nil {
+				signedFlavors = []hvs.SignedFlavor{signedFlavor}
+			}
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not decode signed flavor file "+path)
+	}
+	for _, signedFlavor := range signedFlavors {
+		if signedFlavor.Flavor.Meta.Description == nil {
+			return nil, errors.New("Signed flavor without flavor part found in " + path)
+		}
+	}
+	return signedFlavors, nil
+}
+
+// VerifyOffline verifies the host manifest against each of the signed flavors with the certificates of the
+// certificate store. The results of all the flavors are collected in a single trust report, the report is
+// trusted only when the host matches every flavor.
+func VerifyOffline(hostManifest *hvs.HostManifest, signedFlavors []hvs.SignedFlavor, certStore *crypt.CertificatesStore,
+	skipFlavorSignatureVerification bool) (*hvs.TrustReport, error) {
+	defaultLog.Trace("utils/offline_verifier:VerifyOffline() Entering")
+	defer defaultLog.Trace("utils/offline_verifier:VerifyOffline() Leaving")
+
+	if hostManifest == nil || len(signedFlavors) == 0 {
+		return nil, errors.New("A host manifest and at least one signed flavor must be provided")
+	}
+
+	flavorVerifier, err := NewFlavorVerifier(certStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not create flavor verifier")
+	}
+
+	trustReport := hvs.TrustReport{
+		PolicyName:   "offline verification",
+		HostManifest: *hostManifest,
+		Trusted:      true,
+	}
+	for i := range signedFlavors {
+		flavorReport, err := flavorVerifier.Verify(hostManifest, &signedFlavors[i], skipFlavorSignatureVerification)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error verifying flavor %s", signedFlavors[i].Flavor.Meta.ID)
+		}
+		trustReport.AddResults(flavorReport.Results)
+		trustReport.Trusted = trustReport.Trusted && flavorReport.Trusted
+	}
+	return &trustReport, nil
+}
+
+// WriteTrustReportTable prints the rule results of the trust report as a table ordered by flavor part and rule
+func WriteTrustReportTable(w io.Writer, trustReport *hvs.TrustReport) error {
+	results := make([]hvs.RuleResult, len(trustReport.Results))
+	copy(results, trustReport.Results)
+	sort.SliceStable(results, func(i, j int) bool {
+		mi, mj := ruleMarkers(results[i]), ruleMarkers(results[j])
+		if mi != mj {
+			return mi < mj
+		}
+		return results[i].Rule.Name < results[j].Rule.Name
+	})
+
+	tabW := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tabW, "Overall trust:\t%t\n", trustReport.Trusted)
+	fmt.Fprintln(tabW, "")
+	fmt.Fprintln(tabW, "FLAVOR PART\tRULE\tFLAVOR ID\tTRUSTED\tFAULTS")
+	for _, result := range results {
+		flavorID := "-"
+		if result.FlavorId != nil {
+			flavorID = result.FlavorId.String()
+		}
+		var faults []string
+		for _, fault := range result.Faults {
+			faults = append(faults, fault.Name)
+		}
+		rule := result.Rule.Name
+		if result.Rule.PCR != nil {
+			rule = fmt.Sprintf("%s (pcr %d %s)", rule, result.Rule.PCR.Index, result.Rule.PCR.Bank)
+		}
+		fmt.Fprintf(tabW, "%s\t%s\t%s\t%t\t%s\n", ruleMarkers(result), rule, flavorID, result.Trusted,
+			strings.Join(faults, ", "))
+	}
+	return tabW.Flush()
+}
+
+func ruleMarkers(result hvs.RuleResult) string {
+	markers := make([]string, 0, len(result.Rule.Markers))
+	for _, marker := range result.Rule.Markers {
+		markers = append(markers, marker.String())
+	}
+	return strings.Join(markers, ",")
+}
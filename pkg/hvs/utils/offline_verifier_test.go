@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+const offlineTestData = "../../lib/verifier/test_data/intel20/"
+
+func TestVerifyOffline(t *testing.T) {
+	hostManifest, err := LoadHostManifest(offlineTestData + "host_manifest.json")
+	assert.NoError(t, err)
+	signedFlavors, err := LoadSignedFlavors(offlineTestData + "signed_flavors.json")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, signedFlavors)
+
+	certStore, err := LoadOfflineCertificates(OfflineCertificatePaths{
+		PrivacyCA:         offlineTestData + "PrivacyCA.pem",
+		AssetTagCA:        offlineTestData + "tag-cacerts.pem",
+		FlavorSigningCert: offlineTestData + "flavor-signer.crt.pem",
+		FlavorCA:          offlineTestData + "cms-ca-cert.pem",
+	})
+	assert.NoError(t, err)
+
+	trustReport, err := VerifyOffline(hostManifest, signedFlavors, certStore, true)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, trustReport.Results)
+	assert.Equal(t, hostManifest.HostInfo.HardwareUUID, trustReport.HostManifest.HostInfo.HardwareUUID)
+
+	var table bytes.Buffer
+	assert.NoError(t, WriteTrustReportTable(&table, trustReport))
+	assert.Contains(t, table.String(), "Overall trust:")
+	assert.Contains(t, table.String(), trustReport.Results[0].Rule.Name)
+
+	_, err = VerifyOffline(hostManifest, nil, certStore, true)
+	assert.Error(t, err)
+}
+
+func TestLoadOfflineFiles(t *testing.T) {
+	signedFlavors, err := LoadSignedFlavors(offlineTestData + "signed_flavors.json")
+	assert.NoError(t, err)
+	hostManifest, err := LoadHostManifest(offlineTestData + "host_manifest.json")
+	assert.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "offline-verifier")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeJson := func(name string, v interface{}) string {
+		data, err := json.Marshal(v)
+		assert.NoError(t, err)
+		path := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(path, data, 0600))
+		return path
+	}
+
+	// a single signed flavor
+	flavors, err := LoadSignedFlavors(writeJson("flavor.json", signedFlavors[0]))
+	assert.NoError(t, err)
+	assert.Len(t, flavors, 1)
+
+	// a signed flavor collection as returned by the flavors API
+	flavors, err = LoadSignedFlavors(writeJson("flavors.json", hvs.SignedFlavorCollection{SignedFlavors: signedFlavors}))
+	assert.NoError(t, err)
+	assert.Len(t, flavors, len(signedFlavors))
+
+	// a host manifest wrapped in a host status
+	manifest, err := LoadHostManifest(writeJson("host-status.json", hvs.HostStatus{HostManifest: *hostManifest}))
+	assert.NoError(t, err)
+	assert.Equal(t, hostManifest.HostInfo.HardwareUUID, manifest.HostInfo.HardwareUUID)
+
+	_, err = LoadSignedFlavors(writeJson("invalid.json", map[string]string{"flavor": "invalid"}))
+	assert.Error(t, err)
+
+	// the certificates can be loaded from a directory, a directory without certificates is rejected
+	certPaths := OfflineCertificatePaths{
+		PrivacyCA:         offlineTestData + "PrivacyCA.pem",
+		AssetTagCA:        offlineTestData + "tag-cacerts.pem",
+		FlavorSigningCert: offlineTestData + "flavor-signer.crt.pem",
+		FlavorCA:          dir,
+	}
+	_, err = LoadOfflineCertificates(certPaths)
+	assert.Error(t, err)
+
+	caDir := filepath.Join(dir, "ca")
+	assert.NoError(t, os.Mkdir(caDir, 0700))
+	caCert, err := ioutil.ReadFile(offlineTestData + "cms-ca-cert.pem")
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(caDir, "cms-ca-cert.pem"), caCert, 0600))
+	certPaths.FlavorCA = caDir
+	_, err = LoadOfflineCertificates(certPaths)
+	assert.NoError(t, err)
+}
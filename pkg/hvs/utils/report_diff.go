@@ -0,0 +1,214 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+)
+
+// DiffReports returns the changes of the rule results of report compared to the rule results of against. The rule
+// results are matched by rule name, markers, PCR, flavor and policy rule, the faults and event log entries by value.
+func DiffReports(report, against *models.HVSReport) *hvs.ReportDiff {
+	defaultLog.Trace("utils/report_diff:DiffReports() Entering")
+	defer defaultLog.Trace("utils/report_diff:DiffReports() Leaving")
+
+	diff := &hvs.ReportDiff{
+		ReportId:        report.ID,
+		HostId:          report.HostID,
+		Trusted:         report.TrustReport.Trusted,
+		AgainstReportId: against.ID,
+		AgainstHostId:   against.HostID,
+		AgainstTrusted:  against.TrustReport.Trusted,
+	}
+
+	againstResults := make(map[string]hvs.RuleResult)
+	againstKeys := ruleResultKeys(against.TrustReport.Results)
+	for i, key := range againstKeys {
+		againstResults[key] = against.TrustReport.Results[i]
+	}
+
+	matched := make(map[string]bool)
+	for i, key := range ruleResultKeys(report.TrustReport.Results) {
+		result := report.TrustReport.Results[i]
+		againstResult, ok := againstResults[key]
+		if !ok {
+			diff.AddedRules = append(diff.AddedRules, result)
+			continue
+		}
+		matched[key] = true
+		if ruleDiff := diffRuleResults(result, againstResult); ruleDiff != nil {
+			diff.ChangedRules = append(diff.ChangedRules, *ruleDiff)
+		}
+	}
+	for i, key := range againstKeys {
+		if !matched[key] {
+			diff.RemovedRules = append(diff.RemovedRules, against.TrustReport.Results[i])
+		}
+	}
+	return diff
+}
+
+// diffRuleResults returns nil when the results of the rule did not change
+func diffRuleResults(result, against hvs.RuleResult) *hvs.RuleResultDiff {
+	ruleDiff := &hvs.RuleResultDiff{
+		Rule:           result.Rule,
+		FlavorId:       result.FlavorId,
+		Trusted:        result.Trusted,
+		AgainstTrusted: against.Trusted,
+	}
+	ruleDiff.AddedFaults, ruleDiff.RemovedFaults = diffByKey(result.Faults, against.Faults, func(f hvs.Fault) string {
+		return jsonKey(f)
+	})
+
+	mismatchKey := func(m hvs.MismatchField) string {
+		return fmt.Sprintf("%s|%s|%s", m.Name, pcrIndexKey(m.PcrIndex), shaAlgorithmKey(m.PcrBank))
+	}
+	againstMismatches := make(map[string]hvs.MismatchField)
+	for _, m := range against.MismatchField {
+		againstMismatches[mismatchKey(m)] = m
+	}
+	matched := make(map[string]bool)
+	for _, m := range result.MismatchField {
+		againstMismatch, ok := againstMismatches[mismatchKey(m)]
+		if !ok {
+			ruleDiff.AddedMismatchFields = append(ruleDiff.AddedMismatchFields, m)
+			continue
+		}
+		matched[mismatchKey(m)] = true
+		if mismatchDiff := diffMismatchFields(m, againstMismatch); mismatchDiff != nil {
+			ruleDiff.ChangedMismatchFields = append(ruleDiff.ChangedMismatchFields, *mismatchDiff)
+		}
+	}
+	for _, m := range against.MismatchField {
+		if !matched[mismatchKey(m)] {
+			ruleDiff.RemovedMismatchFields = append(ruleDiff.RemovedMismatchFields, m)
+		}
+	}
+
+	if result.Trusted == against.Trusted && len(ruleDiff.AddedFaults) == 0 && len(ruleDiff.RemovedFaults) == 0 &&
+		len(ruleDiff.AddedMismatchFields) == 0 && len(ruleDiff.RemovedMismatchFields) == 0 && len(ruleDiff.ChangedMismatchFields) == 0 {
+		return nil
+	}
+	return ruleDiff
+}
+
+// diffMismatchFields returns nil when the entries of the mismatch did not change
+func diffMismatchFields(m, against hvs.MismatchField) *hvs.MismatchFieldDiff {
+	eventLogKey := func(e hvs.EventLog) string { return jsonKey(e) }
+	measurementKey := func(e hvs.Measurements) string { return e.File + "|" + e.Measurement }
+
+	mismatchDiff := &hvs.MismatchFieldDiff{
+		Name:     m.Name,
+		PcrIndex: m.PcrIndex,
+		PcrBank:  m.PcrBank,
+	}
+	mismatchDiff.AddedMissingEntries, mismatchDiff.RemovedMissingEntries = diffByKey(m.MissingEntries, against.MissingEntries, eventLogKey)
+	mismatchDiff.AddedUnexpectedEntries, mismatchDiff.RemovedUnexpectedEntries = diffByKey(m.UnexpectedEntries, against.UnexpectedEntries, eventLogKey)
+	mismatchDiff.AddedUnexpectedImaEntries, mismatchDiff.RemovedUnexpectedImaEntries = diffByKey(m.UnexpectedImaEntries, against.UnexpectedImaEntries, measurementKey)
+	mismatchDiff.AddedMismatchedImaEntries, mismatchDiff.RemovedMismatchedImaEntries = diffByKey(m.MismatchedImaEntries, against.MismatchedImaEntries, measurementKey)
+
+	if len(mismatchDiff.AddedMissingEntries) == 0 && len(mismatchDiff.RemovedMissingEntries) == 0 &&
+		len(mismatchDiff.AddedUnexpectedEntries) == 0 && len(mismatchDiff.RemovedUnexpectedEntries) == 0 &&
+		len(mismatchDiff.AddedUnexpectedImaEntries) == 0 && len(mismatchDiff.RemovedUnexpectedImaEntries) == 0 &&
+		len(mismatchDiff.AddedMismatchedImaEntries) == 0 && len(mismatchDiff.RemovedMismatchedImaEntries) == 0 {
+		return nil
+	}
+	return mismatchDiff
+}
+
+// diffByKey returns the items that are not in against and the items of against that are not in items, duplicates
+// are counted so that an item reported twice in items and once in against is added once
+func diffByKey[T any](items, against []T, key func(T) string) (added, removed []T) {
+	counts := make(map[string]int)
+	for _, item := range against {
+		counts[key(item)]++
+	}
+	for _, item := range items {
+		k := key(item)
+		if counts[k] > 0 {
+			counts[k]--
+			continue
+		}
+		added = append(added, item)
+	}
+	for i := len(against) - 1; i >= 0; i-- {
+		k := key(against[i])
+		if counts[k] > 0 {
+			counts[k]--
+			removed = append([]T{against[i]}, removed...)
+		}
+	}
+	return added, removed
+}
+
+// ruleResultKeys returns the identity of each rule result. A rule can be evaluated more than once with the same
+// identity, for example for each PCR of a legacy flavor, so the occurrence is part of the key.
+func ruleResultKeys(results []hvs.RuleResult) []string {
+	occurrences := make(map[string]int)
+	keys := make([]string, len(results))
+	for i, result := range results {
+		markers := make([]string, len(result.Rule.Markers))
+		for j, marker := range result.Rule.Markers {
+			markers[j] = marker.String()
+		}
+
+		var pcr hvs.Pcr
+		switch {
+		case result.Rule.ExpectedPcr != nil:
+			pcr = result.Rule.ExpectedPcr.Pcr
+		case result.Rule.PCR != nil:
+			pcr = *result.Rule.PCR
+		case result.Rule.ExpectedPcrEventLogEntry != nil:
+			pcr = result.Rule.ExpectedPcrEventLogEntry.Pcr
+		}
+
+		flavorId := result.FlavorId
+		if flavorId == nil {
+			flavorId = result.Rule.FlavorID
+		}
+		flavor := ""
+		if flavorId != nil {
+			flavor = flavorId.String()
+		}
+
+		// the policy rules of the flavorgroups are all evaluated by the same rule
+		policy := ""
+		if result.Rule.PolicyRule != nil && result.Rule.FlavorGroupID != nil {
+			policy = result.Rule.FlavorGroupID.String() + "/" + result.Rule.PolicyRule.Name
+		}
+
+		key := fmt.Sprintf("%s|%s|%s|%d|%s|%s", result.Rule.Name, strings.Join(markers, ","), pcr.Bank, pcr.Index, flavor, policy)
+		occurrences[key]++
+		keys[i] = fmt.Sprintf("%s|%d", key, occurrences[key])
+	}
+	return keys
+}
+
+func pcrIndexKey(index *hvs.PcrIndex) string {
+	if index == nil {
+		return ""
+	}
+	return index.String()
+}
+
+func shaAlgorithmKey(bank *hvs.SHAAlgorithm) string {
+	if bank == nil {
+		return ""
+	}
+	return string(*bank)
+}
+
+func jsonKey(v interface{}) string {
+	key, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return string(key)
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	constants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffReports(t *testing.T) {
+	flavorId := uuid.MustParse("1108e0f4-96ee-4839-9bf7-a5a25457797f")
+	pcrIndex := hvs.PcrIndex(17)
+	pcrBank := hvs.SHAAlgorithm(hvs.SHA256)
+	pcr17 := hvs.Pcr{Index: 17, Bank: string(hvs.SHA256)}
+	sinitEvent := hvs.EventLog{TypeName: "EV_SINIT", Measurement: "a1b2"}
+	bootEvent := hvs.EventLog{TypeName: "EV_BOOT", Measurement: "c3d4"}
+
+	aikRule := hvs.RuleResult{
+		Rule:    hvs.RuleInfo{Name: constants.RuleAikCertificateTrusted, Markers: []hvs.FlavorPartName{hvs.FlavorPartPlatform}},
+		Trusted: true,
+	}
+	eventLogRule := func(trusted bool, faults []hvs.Fault, missing ...hvs.EventLog) hvs.RuleResult {
+		return hvs.RuleResult{
+			Rule: hvs.RuleInfo{
+				Name:        constants.RulePcrEventLogEqualsExcluding,
+				Markers:     []hvs.FlavorPartName{hvs.FlavorPartPlatform},
+				ExpectedPcr: &hvs.FlavorPcrs{Pcr: pcr17},
+			},
+			FlavorId: &flavorId,
+			Faults:   faults,
+			MismatchField: []hvs.MismatchField{{
+				Name:           constants.PcrEventLogMissingFields,
+				PcrIndex:       &pcrIndex,
+				PcrBank:        &pcrBank,
+				MissingEntries: missing,
+			}},
+			Trusted: trusted,
+		}
+	}
+	flavorTrustedRule := hvs.RuleResult{
+		Rule:     hvs.RuleInfo{Name: constants.RuleFlavorTrusted, Markers: []hvs.FlavorPartName{hvs.FlavorPartPlatform}},
+		FlavorId: &flavorId,
+		Trusted:  true,
+	}
+	tagRule := hvs.RuleResult{
+		Rule:    hvs.RuleInfo{Name: constants.RuleAssetTagMatches, Markers: []hvs.FlavorPartName{hvs.FlavorPartAssetTag}},
+		Trusted: true,
+	}
+	missingFault := hvs.Fault{Name: constants.FaultPcrEventLogMissingExpectedEntries, PcrIndex: &pcrIndex, PcrBank: &pcrBank}
+
+	against := &models.HVSReport{
+		ID:     uuid.New(),
+		HostID: uuid.New(),
+		TrustReport: hvs.TrustReport{
+			Trusted: true,
+			Results: []hvs.RuleResult{aikRule, eventLogRule(true, nil, sinitEvent), tagRule},
+		},
+	}
+	report := &models.HVSReport{
+		ID:     uuid.New(),
+		HostID: against.HostID,
+		TrustReport: hvs.TrustReport{
+			Trusted: false,
+			Results: []hvs.RuleResult{aikRule, eventLogRule(false, []hvs.Fault{missingFault}, sinitEvent, bootEvent), flavorTrustedRule},
+		},
+	}
+
+	diff := DiffReports(report, against)
+	assert.Equal(t, report.ID, diff.ReportId)
+	assert.Equal(t, against.ID, diff.AgainstReportId)
+	assert.False(t, diff.Trusted)
+	assert.True(t, diff.AgainstTrusted)
+
+	assert.Len(t, diff.AddedRules, 1)
+	assert.Equal(t, constants.RuleFlavorTrusted, diff.AddedRules[0].Rule.Name)
+	assert.Len(t, diff.RemovedRules, 1)
+	assert.Equal(t, constants.RuleAssetTagMatches, diff.RemovedRules[0].Rule.Name)
+
+	// the unchanged AIK rule is not reported
+	assert.Len(t, diff.ChangedRules, 1)
+	changed := diff.ChangedRules[0]
+	assert.Equal(t, constants.RulePcrEventLogEqualsExcluding, changed.Rule.Name)
+	assert.False(t, changed.Trusted)
+	assert.True(t, changed.AgainstTrusted)
+	assert.Equal(t, []hvs.Fault{missingFault}, changed.AddedFaults)
+	assert.Empty(t, changed.RemovedFaults)
+	assert.Empty(t, changed.AddedMismatchFields)
+	assert.Len(t, changed.ChangedMismatchFields, 1)
+	assert.Equal(t, &pcrIndex, changed.ChangedMismatchFields[0].PcrIndex)
+	assert.Equal(t, []hvs.EventLog{bootEvent}, changed.ChangedMismatchFields[0].AddedMissingEntries)
+	assert.Empty(t, changed.ChangedMismatchFields[0].RemovedMissingEntries)
+
+	// comparing a report with itself does not report any change
+	diff = DiffReports(report, report)
+	assert.Empty(t, diff.AddedRules)
+	assert.Empty(t, diff.RemovedRules)
+	assert.Empty(t, diff.ChangedRules)
+}
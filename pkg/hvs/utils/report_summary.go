@@ -0,0 +1,379 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	constants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+)
+
+const (
+	EventLogEntryMissing    = "missing"
+	EventLogEntryUnexpected = "unexpected"
+	EventLogEntryDivergent  = "divergent"
+)
+
+var ruleDescriptions = map[string]string{
+	constants.RuleAikCertificateTrusted:       "AIK certificate is trusted",
+	constants.RuleAssetTagMatches:             "Asset tag matches",
+	constants.RuleFlavorTrusted:               "Flavor signature is trusted",
+	constants.RulePcrEventLogEquals:           "PCR event log equals the flavor",
+	constants.RulePcrEventLogEqualsExcluding:  "PCR event log equals the flavor, excluding the tagged events",
+	constants.RulePcrEventLogIncludes:         "PCR event log includes the events of the flavor",
+	constants.RulePcrEventLogIntegrity:        "PCR event log replays to the PCR value",
+	constants.RulePcrMatchesConstant:          "PCR value matches the flavor",
+	constants.RuleTagCertificateTrusted:       "Asset tag certificate is trusted",
+	constants.RuleXmlMeasurementsDigestEquals: "Measurement log digest matches the flavor",
+	constants.RuleXmlMeasurementLogEquals:     "Measurement log equals the flavor",
+	constants.RuleXmlMeasurementLogIntegrity:  "Measurement log replays to the PCR value",
+	constants.RuleImaMeasurementLogIntegrity:  "IMA log replays to the PCR value",
+	constants.RuleImaEventLogEquals:           "IMA log equals the flavor",
+	constants.RulePolicyExpressionMatches:     "Policy expression matches",
+	constants.RulePcrEventLogReplay:           "PCR event log replays as the flavor",
+}
+
+var faultDescriptions = map[string]string{
+	constants.FaultAikCertificateExpired:     "The AIK certificate of the host has expired",
+	constants.FaultAikCertificateMissing:     "The host report does not include an AIK certificate",
+	constants.FaultAikCertificateNotTrusted:  "The AIK certificate of the host is not signed by a trusted Privacy CA",
+	constants.FaultAikCertificateNotYetValid: "The AIK certificate of the host is not yet valid",
+	constants.FaultAssetTagMismatch:          "The asset tag of the host does not match the tag certificate",
+	constants.FaultAssetTagMissing:           "The flavor does not include an asset tag",
+	constants.FaultAssetTagNotProvisioned:    "The asset tag is not provisioned on the host",
+	constants.FaultTagCertificateExpired:     "The tag certificate has expired",
+	constants.FaultTagCertificateMissing:     "The flavor does not include a tag certificate",
+	constants.FaultTagCertificateNotTrusted:  "The tag certificate is not signed by a trusted CA",
+	constants.FaultTagCertificateNotYetValid: "The tag certificate is not yet valid",
+	constants.FaultPcrManifestMissing:        "The host report does not include PCR values",
+}
+
+// SummarizeReport groups the rule results of the report by flavor part and explains the failing rules in plain
+// sentences. The event log entries of the failures refer to the PCR event logs of the host manifest of the report.
+func SummarizeReport(report *models.HVSReport) *hvs.ReportSummary {
+	defaultLog.Trace("utils/report_summary:SummarizeReport() Entering")
+	defer defaultLog.Trace("utils/report_summary:SummarizeReport() Leaving")
+
+	hostInfo := report.TrustReport.HostManifest.HostInfo
+	summary := &hvs.ReportSummary{
+		ReportId:     report.ID,
+		HostId:       report.HostID,
+		HostName:     hostInfo.HostName,
+		HardwareUUID: hostInfo.HardwareUUID,
+		Created:      report.CreatedAt,
+		Expiration:   report.Expiration,
+		FlavorParts:  []hvs.FlavorPartSummary{},
+	}
+
+	trustReport := hvs.NewTrustReport(report.TrustReport)
+	summary.Trusted = trustReport.IsTrusted()
+	pcrManifest := &report.TrustReport.HostManifest.PcrManifest
+
+	for _, flavorPart := range hvs.GetFlavorTypes() {
+		results := trustReport.GetResultsForMarker(flavorPart.String())
+		if len(results) == 0 {
+			continue
+		}
+
+		partSummary := hvs.FlavorPartSummary{
+			FlavorPart: flavorPart,
+			Trusted:    trustReport.IsTrustedForMarker(flavorPart.String()),
+		}
+
+		flavorIndexes := make(map[uuid.UUID]int)
+		for _, result := range results {
+			if result.FlavorId != nil {
+				index, ok := flavorIndexes[*result.FlavorId]
+				if !ok {
+					index = len(partSummary.Flavors)
+					flavorIndexes[*result.FlavorId] = index
+					partSummary.Flavors = append(partSummary.Flavors, hvs.FlavorMatch{FlavorId: *result.FlavorId, Matched: true})
+				}
+				if result.Rule.FlavorName != nil {
+					partSummary.Flavors[index].Label = *result.Rule.FlavorName
+				}
+				partSummary.Flavors[index].Matched = partSummary.Flavors[index].Matched && result.Trusted
+			}
+
+			if !result.Trusted {
+				partSummary.Failures = append(partSummary.Failures, summarizeRuleFailure(result, pcrManifest))
+			}
+		}
+		summary.FlavorParts = append(summary.FlavorParts, partSummary)
+	}
+	return summary
+}
+
+// summarizeRuleFailure explains each fault of the rule and collects the event log entries the faults refer to
+func summarizeRuleFailure(result hvs.RuleResult, pcrManifest *hvs.PcrManifest) hvs.RuleFailure {
+	failure := hvs.RuleFailure{
+		Rule:     describeRule(result.Rule.Name),
+		FlavorId: result.FlavorId,
+		Reasons:  []string{},
+	}
+
+	for _, fault := range result.Faults {
+		failure.Reasons = append(failure.Reasons, describeFault(fault))
+		if fault.PcrIndex != nil && fault.PcrBank != nil {
+			failure.EventLogEntries = append(failure.EventLogEntries,
+				getEventLogReferences(pcrManifest, *fault.PcrIndex, *fault.PcrBank, fault.MissingEntries, fault.UnexpectedEntries)...)
+		}
+	}
+
+	for _, mismatch := range result.MismatchField {
+		if mismatch.DivergentEvent != nil {
+			failure.Reasons = append(failure.Reasons, describeDivergentEvent(mismatch))
+		}
+		if mismatch.PcrIndex == nil || mismatch.PcrBank == nil {
+			continue
+		}
+		failure.EventLogEntries = append(failure.EventLogEntries,
+			getEventLogReferences(pcrManifest, *mismatch.PcrIndex, *mismatch.PcrBank, mismatch.MissingEntries, mismatch.UnexpectedEntries)...)
+		if mismatch.DivergentEvent != nil {
+			reference := hvs.EventLogReference{
+				PcrIndex:    *mismatch.PcrIndex,
+				PcrBank:     *mismatch.PcrBank,
+				TypeName:    mismatch.DivergentEvent.TypeName,
+				Tags:        mismatch.DivergentEvent.Tags,
+				Measurement: mismatch.DivergentEvent.ExpectedMeasurement,
+				Status:      EventLogEntryMissing,
+			}
+			// the expected event is missing when the event log of the host is shorter than the flavor
+			if mismatch.DivergentEvent.ActualMeasurement != "" {
+				eventIndex := mismatch.DivergentEvent.EventIndex
+				reference.EventIndex = &eventIndex
+				reference.Measurement = mismatch.DivergentEvent.ActualMeasurement
+				reference.Status = EventLogEntryDivergent
+			}
+			failure.EventLogEntries = append(failure.EventLogEntries, reference)
+		}
+	}
+
+	if len(failure.Reasons) == 0 {
+		failure.Reasons = append(failure.Reasons, fmt.Sprintf("%s: the rule is not trusted", failure.Rule))
+	}
+	return failure
+}
+
+// describeRule ignores the package of the rule names of reports created by earlier versions, e.g.
+// com.intel.mtwilson.core.verifier.policy.rule.PcrEventLogIncludes
+func describeRule(ruleName string) string {
+	if index := strings.LastIndex(ruleName, constants.RulePrefix); index >= 0 {
+		ruleName = ruleName[index:]
+	}
+	if description, ok := ruleDescriptions[ruleName]; ok {
+		return description
+	}
+	return strings.TrimPrefix(ruleName, constants.RulePrefix)
+}
+
+// describeFault returns a sentence explaining the fault, the description of the fault is used for the faults that
+// are already described in plain language
+func describeFault(fault hvs.Fault) string {
+	if description, ok := faultDescriptions[fault.Name]; ok {
+		return description
+	}
+
+	pcr := "PCR"
+	if fault.PcrIndex != nil {
+		pcr = fmt.Sprintf("PCR %d", *fault.PcrIndex)
+		if fault.PcrBank != nil {
+			pcr = fmt.Sprintf("%s %s", pcr, *fault.PcrBank)
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(fault.Name, constants.FaultPcrValueMismatch) && fault.ExpectedPcrValue != nil && fault.ActualPcrValue != nil:
+		return fmt.Sprintf("%s mismatch: expected %s, actual %s", pcr, *fault.ExpectedPcrValue, *fault.ActualPcrValue)
+	case fault.Name == constants.FaultPcrValueMissing:
+		return fmt.Sprintf("%s is not in the host report", pcr)
+	case fault.Name == constants.FaultPcrEventLogMissing:
+		return fmt.Sprintf("%s event log is not in the host report", pcr)
+	case fault.Name == constants.FaultPcrEventLogMissingExpectedEntries:
+		return fmt.Sprintf("%s event log is missing %d events of the flavor", pcr, len(fault.MissingEntries))
+	case fault.Name == constants.FaultPcrEventLogContainsUnexpectedEntries:
+		return fmt.Sprintf("%s event log contains %d events that are not in the flavor", pcr, len(fault.UnexpectedEntries))
+	case fault.Name == constants.FaultPcrEventLogInvalid:
+		return fmt.Sprintf("%s event log does not replay to the PCR value", pcr)
+	case fault.Name == constants.FaultFlavorSignatureMissing && fault.FlavorId != nil:
+		return fmt.Sprintf("Flavor %s is not signed", *fault.FlavorId)
+	case fault.Name == constants.FaultFlavorSignatureNotTrusted && fault.FlavorId != nil:
+		return fmt.Sprintf("Flavor %s is not signed by a trusted flavor signing certificate", *fault.FlavorId)
+	case fault.Name == constants.FaultFlavorSignatureVerificationFailed && fault.FlavorId != nil:
+		return fmt.Sprintf("The signature of flavor %s is not valid", *fault.FlavorId)
+	case fault.ExpectedValue != nil && fault.ActualValue != nil:
+		return fmt.Sprintf("%s: expected %s, actual %s", fault.Description, *fault.ExpectedValue, *fault.ActualValue)
+	}
+	return fault.Description
+}
+
+func describeDivergentEvent(mismatch hvs.MismatchField) string {
+	event := mismatch.DivergentEvent
+	pcr := "PCR"
+	if mismatch.PcrIndex != nil && mismatch.PcrBank != nil {
+		pcr = fmt.Sprintf("PCR %d %s", *mismatch.PcrIndex, *mismatch.PcrBank)
+	}
+	switch {
+	case event.ActualMeasurement == "":
+		return fmt.Sprintf("%s event log ends at event %d, expected %s event with measurement %s", pcr, event.EventIndex, event.TypeName, event.ExpectedMeasurement)
+	case event.ExpectedMeasurement == "":
+		return fmt.Sprintf("%s event log has an unexpected %s event %d with measurement %s", pcr, event.TypeName, event.EventIndex, event.ActualMeasurement)
+	}
+	return fmt.Sprintf("%s event log diverges from the flavor at %s event %d: expected %s, actual %s", pcr, event.TypeName, event.EventIndex, event.ExpectedMeasurement, event.ActualMeasurement)
+}
+
+// getEventLogReferences looks for the missing and unexpected events in the event log of the PCR. An unexpected event
+// is in the event log of the host, an event reported more than once refers to its next occurrence.
+func getEventLogReferences(pcrManifest *hvs.PcrManifest, pcrIndex hvs.PcrIndex, pcrBank hvs.SHAAlgorithm, missing, unexpected []hvs.EventLog) []hvs.EventLogReference {
+	var references []hvs.EventLogReference
+
+	for _, event := range missing {
+		references = append(references, hvs.EventLogReference{
+			PcrIndex:    pcrIndex,
+			PcrBank:     pcrBank,
+			TypeName:    event.TypeName,
+			Tags:        event.Tags,
+			Measurement: event.Measurement,
+			Status:      EventLogEntryMissing,
+		})
+	}
+
+	hostEvents, _ := pcrManifest.GetEventLogCriteria(pcrBank, pcrIndex)
+	referenced := make(map[int]bool)
+	for _, event := range unexpected {
+		reference := hvs.EventLogReference{
+			PcrIndex:    pcrIndex,
+			PcrBank:     pcrBank,
+			TypeName:    event.TypeName,
+			Tags:        event.Tags,
+			Measurement: event.Measurement,
+			Status:      EventLogEntryUnexpected,
+		}
+		for i, hostEvent := range hostEvents {
+			if !referenced[i] && hostEvent.TypeID == event.TypeID && hostEvent.Measurement == event.Measurement {
+				eventIndex := i
+				reference.EventIndex = &eventIndex
+				referenced[i] = true
+				break
+			}
+		}
+		references = append(references, reference)
+	}
+	return references
+}
+
+// RenderReportSummary renders the summary as a markdown document. The event log entries of the failures link to an
+// appendix listing the event logs of the PCRs they belong to.
+func RenderReportSummary(summary *hvs.ReportSummary, pcrManifest *hvs.PcrManifest) string {
+	defaultLog.Trace("utils/report_summary:RenderReportSummary() Entering")
+	defer defaultLog.Trace("utils/report_summary:RenderReportSummary() Leaving")
+
+	var md strings.Builder
+
+	fmt.Fprintf(&md, "# Trust report of %s\n\n", summary.HostName)
+	fmt.Fprintf(&md, "- Overall: **%s**\n", trustedString(summary.Trusted))
+	fmt.Fprintf(&md, "- Report: %s\n", summary.ReportId)
+	fmt.Fprintf(&md, "- Host: %s\n", summary.HostId)
+	if summary.HardwareUUID != "" {
+		fmt.Fprintf(&md, "- Hardware UUID: %s\n", summary.HardwareUUID)
+	}
+	fmt.Fprintf(&md, "- Created: %s, expires: %s\n", summary.Created.Format("2006-01-02T15:04:05Z07:00"), summary.Expiration.Format("2006-01-02T15:04:05Z07:00"))
+
+	type pcrEventLog struct {
+		pcrIndex hvs.PcrIndex
+		pcrBank  hvs.SHAAlgorithm
+	}
+	var linkedEventLogs []pcrEventLog
+	linked := make(map[pcrEventLog]bool)
+
+	for _, partSummary := range summary.FlavorParts {
+		fmt.Fprintf(&md, "\n## %s: %s\n", partSummary.FlavorPart, trustedString(partSummary.Trusted))
+
+		if len(partSummary.Flavors) > 0 {
+			md.WriteString("\nFlavors:\n\n")
+			for _, flavor := range partSummary.Flavors {
+				name := flavor.FlavorId.String()
+				if flavor.Label != "" {
+					name = fmt.Sprintf("%s (%s)", flavor.Label, flavor.FlavorId)
+				}
+				matched := "matched"
+				if !flavor.Matched {
+					matched = "not matched"
+				}
+				fmt.Fprintf(&md, "- %s: %s\n", name, matched)
+			}
+		}
+
+		if len(partSummary.Failures) > 0 {
+			md.WriteString("\nFailures:\n")
+		}
+		for _, failure := range partSummary.Failures {
+			fmt.Fprintf(&md, "\n- %s", failure.Rule)
+			if failure.FlavorId != nil {
+				fmt.Fprintf(&md, " (flavor %s)", *failure.FlavorId)
+			}
+			md.WriteString("\n")
+			for _, reason := range failure.Reasons {
+				fmt.Fprintf(&md, "  - %s\n", reason)
+			}
+			for _, entry := range failure.EventLogEntries {
+				eventLog := pcrEventLog{pcrIndex: entry.PcrIndex, pcrBank: entry.PcrBank}
+				if entry.EventIndex == nil {
+					fmt.Fprintf(&md, "  - %s event of PCR %d %s: %s %s%s\n", entry.Status, entry.PcrIndex, entry.PcrBank,
+						entry.TypeName, entry.Measurement, tagsString(entry.Tags))
+					continue
+				}
+				fmt.Fprintf(&md, "  - %s event of PCR %d %s: [event %d](#%s) %s %s%s\n", entry.Status, entry.PcrIndex, entry.PcrBank,
+					*entry.EventIndex, eventAnchor(entry.PcrIndex, entry.PcrBank, *entry.EventIndex), entry.TypeName, entry.Measurement, tagsString(entry.Tags))
+				if !linked[eventLog] {
+					linked[eventLog] = true
+					linkedEventLogs = append(linkedEventLogs, eventLog)
+				}
+			}
+		}
+	}
+
+	if len(linkedEventLogs) == 0 || pcrManifest == nil {
+		return md.String()
+	}
+
+	md.WriteString("\n## Event logs\n")
+	for _, eventLog := range linkedEventLogs {
+		hostEvents, err := pcrManifest.GetEventLogCriteria(eventLog.pcrBank, eventLog.pcrIndex)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&md, "\n### PCR %d %s\n\n", eventLog.pcrIndex, eventLog.pcrBank)
+		md.WriteString("| Event | Type | Tags | Measurement |\n")
+		md.WriteString("|-------|------|------|-------------|\n")
+		for i, event := range hostEvents {
+			fmt.Fprintf(&md, "| <a id=\"%s\"></a>%d | %s | %s | %s |\n", eventAnchor(eventLog.pcrIndex, eventLog.pcrBank, i), i,
+				event.TypeName, strings.Join(event.Tags, ", "), event.Measurement)
+		}
+	}
+	return md.String()
+}
+
+func trustedString(trusted bool) string {
+	if trusted {
+		return "TRUSTED"
+	}
+	return "UNTRUSTED"
+}
+
+func tagsString(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", strings.Join(tags, ", "))
+}
+
+func eventAnchor(pcrIndex hvs.PcrIndex, pcrBank hvs.SHAAlgorithm, eventIndex int) string {
+	return strings.ToLower(fmt.Sprintf("pcr-%d-%s-event-%d", pcrIndex, pcrBank, eventIndex))
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	constants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeReport(t *testing.T) {
+	platformFlavorId := uuid.MustParse("1108e0f4-96ee-4839-9bf7-a5a25457797f")
+	osFlavorId := uuid.MustParse("a9d3f2b6-3c4c-4f7e-9a6b-7a1d0c5e2f11")
+	pcrIndex := hvs.PcrIndex(17)
+	pcrBank := hvs.SHAAlgorithm(hvs.SHA256)
+	expectedValue := "3f2c"
+	actualValue := "9ab1"
+	sinitEvent := hvs.EventLog{TypeID: "0x402", TypeName: "EV_SINIT", Measurement: "a1b2"}
+	bootEvent := hvs.EventLog{TypeID: "0x40c", TypeName: "EV_BOOT", Measurement: "c3d4", Tags: []string{"vmlinuz"}}
+	initrdEvent := hvs.EventLog{TypeID: "0x40c", TypeName: "EV_BOOT", Measurement: "e5f6", Tags: []string{"initrd"}}
+
+	report := &models.HVSReport{
+		ID:     uuid.New(),
+		HostID: uuid.New(),
+		TrustReport: hvs.TrustReport{
+			Results: []hvs.RuleResult{
+				{
+					Rule:    hvs.RuleInfo{Name: constants.RuleAikCertificateTrusted, Markers: []hvs.FlavorPartName{hvs.FlavorPartPlatform}},
+					Trusted: true,
+				},
+				{
+					Rule:     hvs.RuleInfo{Name: constants.RulePcrMatchesConstant, Markers: []hvs.FlavorPartName{hvs.FlavorPartPlatform}},
+					FlavorId: &platformFlavorId,
+					Faults: []hvs.Fault{{
+						Name:             constants.FaultPcrValueMismatchSHA256,
+						PcrIndex:         &pcrIndex,
+						PcrBank:          &pcrBank,
+						ExpectedPcrValue: &expectedValue,
+						ActualPcrValue:   &actualValue,
+					}},
+				},
+				{
+					Rule:     hvs.RuleInfo{Name: constants.RulePcrEventLogEqualsExcluding, Markers: []hvs.FlavorPartName{hvs.FlavorPartPlatform}},
+					FlavorId: &platformFlavorId,
+					Faults: []hvs.Fault{{
+						Name:              constants.FaultPcrEventLogContainsUnexpectedEntries,
+						PcrIndex:          &pcrIndex,
+						PcrBank:           &pcrBank,
+						UnexpectedEntries: []hvs.EventLog{initrdEvent},
+					}},
+				},
+				{
+					Rule:     hvs.RuleInfo{Name: constants.RulePcrEventLogIncludes, Markers: []hvs.FlavorPartName{hvs.FlavorPartOs}},
+					FlavorId: &osFlavorId,
+					Trusted:  true,
+				},
+			},
+			HostManifest: hvs.HostManifest{
+				PcrManifest: hvs.PcrManifest{
+					PcrEventLogMap: hvs.PcrEventLogMap{
+						Sha256EventLogs: []hvs.TpmEventLog{{
+							Pcr:      hvs.Pcr{Index: 17, Bank: string(hvs.SHA256)},
+							TpmEvent: []hvs.EventLog{sinitEvent, bootEvent, initrdEvent},
+						}},
+					},
+				},
+			},
+		},
+	}
+	report.TrustReport.HostManifest.HostInfo.HostName = "host-1"
+
+	summary := SummarizeReport(report)
+	assert.False(t, summary.Trusted)
+	assert.Equal(t, "host-1", summary.HostName)
+	assert.Len(t, summary.FlavorParts, 2)
+
+	platform := summary.FlavorParts[0]
+	assert.Equal(t, hvs.FlavorPartPlatform, platform.FlavorPart)
+	assert.False(t, platform.Trusted)
+	assert.Equal(t, []hvs.FlavorMatch{{FlavorId: platformFlavorId, Matched: false}}, platform.Flavors)
+	assert.Len(t, platform.Failures, 2)
+	assert.Equal(t, "PCR value matches the flavor", platform.Failures[0].Rule)
+	assert.Equal(t, []string{"PCR 17 SHA256 mismatch: expected 3f2c, actual 9ab1"}, platform.Failures[0].Reasons)
+	assert.Equal(t, []string{"PCR 17 SHA256 event log contains 1 events that are not in the flavor"}, platform.Failures[1].Reasons)
+	assert.Len(t, platform.Failures[1].EventLogEntries, 1)
+	assert.Equal(t, 2, *platform.Failures[1].EventLogEntries[0].EventIndex)
+	assert.Equal(t, EventLogEntryUnexpected, platform.Failures[1].EventLogEntries[0].Status)
+
+	osPart := summary.FlavorParts[1]
+	assert.True(t, osPart.Trusted)
+	assert.Equal(t, []hvs.FlavorMatch{{FlavorId: osFlavorId, Matched: true}}, osPart.Flavors)
+	assert.Empty(t, osPart.Failures)
+
+	md := RenderReportSummary(summary, &report.TrustReport.HostManifest.PcrManifest)
+	assert.True(t, strings.HasPrefix(md, "# Trust report of host-1\n"))
+	assert.Contains(t, md, "## PLATFORM: UNTRUSTED")
+	assert.Contains(t, md, "## OS: TRUSTED")
+	assert.Contains(t, md, "  - PCR 17 SHA256 mismatch: expected 3f2c, actual 9ab1\n")
+	assert.Contains(t, md, "[event 2](#pcr-17-sha256-event-2) EV_BOOT e5f6 (initrd)")
+	assert.Contains(t, md, "| <a id=\"pcr-17-sha256-event-2\"></a>2 | EV_BOOT | initrd | e5f6 |")
+}
+
+func TestSummarizeReportDivergentEvent(t *testing.T) {
+	pcrIndex := hvs.PcrIndex(0)
+	pcrBank := hvs.SHAAlgorithm(hvs.SHA384)
+	report := &models.HVSReport{
+		TrustReport: hvs.TrustReport{
+			Results: []hvs.RuleResult{{
+				Rule: hvs.RuleInfo{Name: constants.RulePcrEventLogReplay, Markers: []hvs.FlavorPartName{hvs.FlavorPartPlatform}},
+				MismatchField: []hvs.MismatchField{{
+					Name:     constants.PcrEventLogDivergentEvent,
+					PcrIndex: &pcrIndex,
+					PcrBank:  &pcrBank,
+					DivergentEvent: &hvs.DivergentEvent{
+						EventIndex:          4,
+						TypeName:            "EV_POST_CODE",
+						ExpectedMeasurement: "aa",
+					},
+				}},
+			}},
+		},
+	}
+
+	summary := SummarizeReport(report)
+	failures := summary.FlavorParts[0].Failures
+	assert.Len(t, failures, 1)
+	assert.Equal(t, []string{"PCR 0 SHA384 event log ends at event 4, expected EV_POST_CODE event with measurement aa"}, failures[0].Reasons)
+	// the missing event is not in the event log of the host
+	assert.Nil(t, failures[0].EventLogEntries[0].EventIndex)
+	assert.Equal(t, EventLogEntryMissing, failures[0].EventLogEntries[0].Status)
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/verifier"
+	"github.com/pkg/errors"
+)
+
+// NewFlavorVerifier creates a flavor verifier from the privacy CA, tag CA, root CA and flavor signing
+// certificates loaded in the certificate store
+func NewFlavorVerifier(certStore *crypt.CertificatesStore) (verifier.Verifier, error) {
+	defaultLog.Trace("utils/verifier:NewFlavorVerifier() Entering")
+	defer defaultLog.Trace("utils/verifier:NewFlavorVerifier() Leaving")
+
+	rootCAs := (*certStore)[models.CaCertTypesRootCa.String()]
+	tagCAs := (*certStore)[models.CaCertTypesTagCa.String()]
+	privacyCAs := (*certStore)[models.CaCertTypesPrivacyCa.String()]
+	signingCerts := (*certStore)[models.CertTypesFlavorSigning.String()]
+	if rootCAs == nil || tagCAs == nil || privacyCAs == nil || signingCerts == nil || len(signingCerts.Certificates) == 0 {
+		return nil, errors.New("utils/verifier:NewFlavorVerifier() Required certificates not found in certificate store")
+	}
+
+	rootCApool := crypt.GetCertPool(rootCAs.Certificates)
+	for _, val := range signingCerts.Certificates[1:] {
+		rootCApool.AddCert(&val) //Add intermediate CA
+	}
+
+	verifierCerts := verifier.VerifierCertificates{
+		PrivacyCACertificates:    crypt.GetCertPool(privacyCAs.Certificates),
+		AssetTagCACertificates:   crypt.GetCertPool(tagCAs.Certificates),
+		FlavorSigningCertificate: &signingCerts.Certificates[0],
+		FlavorCACertificates:     rootCApool,
+	}
+	return verifier.NewVerifier(verifierCerts)
+}
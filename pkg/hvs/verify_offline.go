@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"encoding/json"
+	"flag"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/hvs/utils"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const (
+	verifyOfflineOutputJson  = "json"
+	verifyOfflineOutputTable = "table"
+)
+
+// fileListFlag collects the values of a flag that can be repeated
+type fileListFlag []string
+
+func (f *fileListFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileListFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// verifyOffline verifies a saved host manifest against signed flavors using the certificates from disk, no
+// database or running HVS is needed. The trust report is printed to the console.
+func (a *App) verifyOffline(args []string) error {
+	fs := flag.NewFlagSet("verify-offline", flag.ContinueOnError)
+	fs.SetOutput(a.errorWriter())
+
+	var flavorFiles fileListFlag
+	manifestFile := fs.String("manifest", "", "host manifest JSON file")
+	fs.Var(&flavorFiles, "flavor", "signed flavor JSON file, can be repeated")
+	privacyCA := fs.String("privacy-ca", constants.PrivacyCACertFile, "privacy CA certificate file")
+	tagCA := fs.String("tag-ca", constants.TagCACertFile, "asset tag CA certificate file")
+	flavorSigningCert := fs.String("flavor-signing-cert", constants.FlavorSigningCertFile, "flavor signing certificate file")
+	flavorCA := fs.String("flavor-ca", constants.TrustedRootCACertsDir, "flavor signing CA certificate file or directory")
+	skipSignature := fs.Bool("skip-flavor-signature-verification", false, "do not verify the flavor signatures")
+	output := fs.String("output", verifyOfflineOutputTable, "output format, json or table")
+	if err := fs.Parse(args); err != nil {
+		return errors.Wrap(err, "Invalid arguments for verify-offline")
+	}
+	if fs.NArg() != 0 {
+		return errInvalidCmd
+	}
+	if *manifestFile == "" || len(flavorFiles) == 0 {
+		return errors.New("verify-offline requires --manifest and at least one --flavor")
+	}
+	if *output != verifyOfflineOutputJson && *output != verifyOfflineOutputTable {
+		return errors.New("Invalid output format: " + *output)
+	}
+
+	hostManifest, err := utils.LoadHostManifest(*manifestFile)
+	if err != nil {
+		return err
+	}
+	var signedFlavors []hvs.SignedFlavor
+	for _, flavorFile := range flavorFiles {
+		flavors, err := utils.LoadSignedFlavors(flavorFile)
+		if err != nil {
+			return err
+		}
+		signedFlavors = append(signedFlavors, flavors...)
+	}
+	certStore, err := utils.LoadOfflineCertificates(utils.OfflineCertificatePaths{
+		PrivacyCA:         *privacyCA,
+		AssetTagCA:        *tagCA,
+		FlavorSigningCert: *flavorSigningCert,
+		FlavorCA:          *flavorCA,
+	})
+	if err != nil {
+		return err
+	}
+
+	trustReport, err := utils.VerifyOffline(hostManifest, signedFlavors, certStore, *skipSignature)
+	if err != nil {
+		return errors.Wrap(err, "Offline verification failed")
+	}
+
+	if *output == verifyOfflineOutputTable {
+		return utils.WriteTrustReportTable(a.consoleWriter(), trustReport)
+	}
+	enc := json.NewEncoder(a.consoleWriter())
+	enc.SetIndent("", "  ")
+	return enc.Encode(trustReport)
+}
@@ -84,6 +84,14 @@ func (app *App) Run(args []string) error {
 			return errInvalidCmd
 		}
 		return app.status()
+	case "migrate-keys":
+		if len(args) != 2 {
+			return errInvalidCmd
+		}
+		if err := app.migrateKeys(); err != nil {
+			fmt.Fprintln(app.errorWriter(), err.Error())
+			return err
+		}
 	case "uninstall":
 		// the only allowed flag is --purge
 		purge := false
@@ -34,6 +34,12 @@ const (
 	KmipRootCertPath   = "kmip.root-cert-path"
 	KBSServiceUsername = "kbs.service-username"
 	KBSServicePassword = "kbs.service-password"
+
+	DirectoryMasterKeyFile    = "directory.master-key-file"
+	DirectoryMasterKeySealing = "directory.master-key-sealing"
+
+	KeyTransferAuditRetentionDays = "key-transfer-audit.retention-days"
+	KeyTransferAuditMaxRecords    = "key-transfer-audit.max-records"
 )
 
 type Configuration struct {
@@ -49,8 +55,10 @@ type Configuration struct {
 	Log    commConfig.LogConfig     `yaml:"log"`
 	Server commConfig.ServerConfig  `yaml:"server"`
 
-	Kmip KmipConfig `yaml:"kmip" mapstructure:"kmip"`
-	Skc  SKCConfig  `yaml:"skc" mapstructure:"skc"`
+	Kmip             KmipConfig             `yaml:"kmip" mapstructure:"kmip"`
+	Directory        DirectoryConfig        `yaml:"directory" mapstructure:"directory"`
+	Skc              SKCConfig              `yaml:"skc" mapstructure:"skc"`
+	KeyTransferAudit KeyTransferAuditConfig `yaml:"key-transfer-audit" mapstructure:"key-transfer-audit"`
 }
 
 type KBSConfig struct {
@@ -70,6 +78,18 @@ type KmipConfig struct {
 	RootCertificateFilePath   string `yaml:"root-cert-path" mapstructure:"root-cert-path"`
 }
 
+// DirectoryConfig holds the master key settings of the directory key manager
+type DirectoryConfig struct {
+	MasterKeyFile    string `yaml:"master-key-file" mapstructure:"master-key-file"`
+	MasterKeySealing string `yaml:"master-key-sealing" mapstructure:"master-key-sealing"`
+}
+
+// KeyTransferAuditConfig holds the retention policy of the key transfer records, 0 disables a limit
+type KeyTransferAuditConfig struct {
+	RetentionDays int `yaml:"retention-days" mapstructure:"retention-days"`
+	MaxRecords    int `yaml:"max-records" mapstructure:"max-records"`
+}
+
 type SKCConfig struct {
 	StmLabel          string `yaml:"challenge-type" mapstructure:"challenge-type"`
 	SQVSUrl           string `yaml:"sqvs-url" mapstructure:"sqvs-url"`
@@ -23,6 +23,7 @@ const (
 
 	KeysDir               = HomeDir + "keys/"
 	KeysTransferPolicyDir = HomeDir + "keys-transfer-policy/"
+	KeyTransfersDir       = HomeDir + "key-transfers/"
 
 	// certificates' path
 	TrustedJWTSigningCertsDir = ConfigDir + "certs/trustedjwt/"
@@ -36,6 +37,18 @@ const (
 	DefaultTransferPolicy     = "urn:intel:trustedcomputing:key-transfer-policy:require-trust-or-authorization"
 	DefaultConfigFilePath     = ConfigDir + "config.yml"
 	DefaultTransferPolicyFile = ConfigDir + "default_transfer_policy"
+	DefaultMasterKeyFile      = ConfigDir + "master-key"
+	DefaultMasterKeySealing   = FileMasterKeySealing
+
+	// key transfer audit defaults, the records are kept for 90 days and at most 100000 records are kept
+	DefaultKeyTransferRetentionDays = 90
+	DefaultKeyTransferMaxRecords    = 100000
+	KeyTransferPruneInterval        = time.Hour
+
+	// the denied unauthenticated key transfers of a remote host are aggregated in a record per minute, for at most
+	// 1024 remote hosts at a time
+	DeniedKeyTransferWindow   = time.Minute
+	DeniedKeyTransferMaxHosts = 1024
 
 	// default locations for tls certificate and key
 	DefaultTLSCertPath = ConfigDir + "tls-cert.pem"
@@ -69,7 +82,13 @@ const (
 	DefaultSessionExpiryTime = 60 //in minutes
 
 	// keymanager constants
-	KmipKeyManager = "kmip"
+	KmipKeyManager      = "kmip"
+	DirectoryKeyManager = "directory"
+
+	// master key sealing constants of the directory key manager
+	FileMasterKeySealing = "file"
+	TpmMasterKeySealing  = "tpm"
+	MasterKeyLength      = 32
 
 	// algorithm constants
 	CRYPTOALG_AES = "AES"
@@ -84,6 +103,30 @@ const (
 	KMIP_CRYPTOALG_EC  = 0x06
 
 	NonceLength = 32
+
+	// query parameter date formats
+	ParamDateFormat     = "2006-01-02"
+	ParamDateTimeFormat = "2006-01-02 15:04:05"
+)
+
+// key transfer audit constants
+const (
+	EnvelopeKeyTransfer = "envelope"
+	SamlKeyTransfer     = "saml"
+	SKCKeyTransfer      = "skc"
+
+	KeyTransferAllowed = "allowed"
+	KeyTransferDenied  = "denied"
+
+	// TpmAttestationType is recorded for the key transfers with a SAML report of HVS
+	TpmAttestationType = "TPM"
+)
+
+// Results of a key transfer policy attribute evaluation
+const (
+	PolicyAttributeMatched    = "matched"
+	PolicyAttributeMismatched = "mismatched"
+	PolicyAttributeMissing    = "missing"
 )
 
 const (
@@ -39,13 +39,15 @@ import (
 type KeyController struct {
 	remoteManager           *keymanager.RemoteManager
 	policyStore             domain.KeyTransferPolicyStore
+	recordStore             domain.KeyTransferRecordStore
 	defaultTransferPolicyId uuid.UUID
 }
 
-func NewKeyController(rm *keymanager.RemoteManager, ps domain.KeyTransferPolicyStore, dpi uuid.UUID) *KeyController {
+func NewKeyController(rm *keymanager.RemoteManager, ps domain.KeyTransferPolicyStore, rs domain.KeyTransferRecordStore, dpi uuid.UUID) *KeyController {
 	return &KeyController{
 		remoteManager:           rm,
 		policyStore:             ps,
+		recordStore:             rs,
 		defaultTransferPolicyId: dpi,
 	}
 }
@@ -189,6 +191,30 @@ func (kc *KeyController) Delete(responseWriter http.ResponseWriter, request *htt
 	return nil, http.StatusNoContent, nil
 }
 
+//Rotate : Function to create a new version of a key
+func (kc *KeyController) Rotate(responseWriter http.ResponseWriter, request *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/key_controller:Rotate() Entering")
+	defer defaultLog.Trace("controllers/key_controller:Rotate() Leaving")
+
+	id := uuid.MustParse(mux.Vars(request)["id"])
+	key, err := kc.remoteManager.RotateKey(id)
+	if err != nil {
+		if err.Error() == commErr.RecordNotFound {
+			defaultLog.Error("controllers/key_controller:Rotate() Key with specified id could not be located")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Key with specified id does not exist"}
+		} else if err == keymanager.ErrRegisteredKeyRotation {
+			defaultLog.WithField("Id", id).Error("controllers/key_controller:Rotate() Registered key cannot be rotated")
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Registered key cannot be rotated"}
+		} else {
+			defaultLog.WithError(err).Error("controllers/key_controller:Rotate() Key rotate failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to rotate key"}
+		}
+	}
+
+	secLog.WithField("Id", id).Infof("controllers/key_controller:Rotate() %s: Key rotated to version %d by: %s", commLogMsg.PrivilegeModified, key.Version, request.RemoteAddr)
+	return key, http.StatusOK, nil
+}
+
 //Search : Function to search keys
 func (kc *KeyController) Search(responseWriter http.ResponseWriter, request *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/key_controller:Search() Entering")
@@ -222,6 +248,18 @@ func (kc *KeyController) Transfer(responseWriter http.ResponseWriter, request *h
 	defaultLog.Trace("controllers/key_controller:Transfer() Entering")
 	defer defaultLog.Trace("controllers/key_controller:Transfer() Leaving")
 
+	record := newKeyTransferRecord(request, consts.EnvelopeKeyTransfer)
+	record.Requester, _ = comctx.GetTokenSubject(request)
+	record.RequesterVerified = record.Requester != ""
+	response, status, err := kc.transfer(request, record)
+	recordKeyTransfer(kc.recordStore, kc.remoteManager, record, status, err)
+	return response, status, err
+}
+
+func (kc *KeyController) transfer(request *http.Request, record *kbs.KeyTransferRecord) (interface{}, int, error) {
+	defaultLog.Trace("controllers/key_controller:transfer() Entering")
+	defer defaultLog.Trace("controllers/key_controller:transfer() Leaving")
+
 	if request.Header.Get("Content-Type") != constants.HTTPMediaTypePlain {
 		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
 	}
@@ -246,9 +284,16 @@ func (kc *KeyController) Transfer(responseWriter http.ResponseWriter, request *h
 	}
 	envelopeKey := key.(*rsa.PublicKey)
 
+	version, err := getKeyVersion(request)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/key_controller:Transfer() %s : Invalid key version", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	record.KeyVersion = version
+
 	// Wrap key with public key
 	id := uuid.MustParse(mux.Vars(request)["id"])
-	secretKey, status, err := getSecretKey(kc.remoteManager, id)
+	secretKey, status, err := getSecretKey(kc.remoteManager, id, version)
 	if err != nil {
 		return nil, status, err
 	}
@@ -267,15 +312,33 @@ func (kc *KeyController) Transfer(responseWriter http.ResponseWriter, request *h
 	return transferKeyResponse, http.StatusOK, nil
 }
 
-func getSecretKey(remoteManager *keymanager.RemoteManager, id uuid.UUID) (interface{}, int, error) {
+// getKeyVersion returns the key version of the version query parameter, 0 when the current version is requested.
+// The other query parameters are ignored as they were before the key versions.
+func getKeyVersion(request *http.Request) (int, error) {
+	versionParam := request.URL.Query().Get("version")
+	if versionParam == "" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(versionParam)
+	if err != nil || version < 1 {
+		return 0, errors.New("version must be a positive integer")
+	}
+	return version, nil
+}
+
+// getSecretKey returns the key material of a version of the key, the current version when version is 0
+func getSecretKey(remoteManager *keymanager.RemoteManager, id uuid.UUID, version int) (interface{}, int, error) {
 	defaultLog.Trace("controllers/key_controller:getSecretKey() Entering")
 	defer defaultLog.Trace("controllers/key_controller:getSecretKey() Leaving")
 
-	secretKey, err := remoteManager.TransferKey(id)
+	secretKey, err := remoteManager.TransferKeyVersion(id, version)
 	if err != nil {
 		if err.Error() == commErr.RecordNotFound {
 			defaultLog.Error("controllers/key_controller:getSecretKey() Key with specified id could not be located")
 			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Key with specified id does not exist"}
+		} else if err == keymanager.ErrKeyVersionNotFound {
+			defaultLog.WithField("version", version).Error("controllers/key_controller:getSecretKey() Key version could not be located")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Key version does not exist"}
 		} else {
 			defaultLog.WithError(err).Error("controllers/key_controller:getSecretKey() Key transfer failed")
 			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to transfer Key"}
@@ -47,6 +47,7 @@ var _ = Describe("KeyController", func() {
 	var w *httptest.ResponseRecorder
 	var keyStore *mocks.MockKeyStore
 	var policyStore *mocks.MockKeyTransferPolicyStore
+	var recordStore *mocks.MockKeyTransferRecordStore
 	var remoteManager *keymanager.RemoteManager
 	var keyController *controllers.KeyController
 	var keyTransferController *controllers.KeyTransferController
@@ -81,9 +82,10 @@ var _ = Describe("KeyController", func() {
 		router = mux.NewRouter()
 		keyStore = mocks.NewFakeKeyStore()
 		policyStore = mocks.NewFakeKeyTransferPolicyStore()
+		recordStore = mocks.NewFakeKeyTransferRecordStore()
 		remoteManager = keymanager.NewRemoteManager(keyStore, keyManager, endpointUrl)
-		keyController = controllers.NewKeyController(remoteManager, policyStore, newId)
-		keyTransferController = controllers.NewKeyTransferController(remoteManager, policyStore, kcc)
+		keyController = controllers.NewKeyController(remoteManager, policyStore, recordStore, newId)
+		keyTransferController = controllers.NewKeyTransferController(remoteManager, policyStore, recordStore, kcc)
 	})
 
 	// Specs for HTTP Post to "/keys"
@@ -749,6 +751,159 @@ var _ = Describe("KeyController", func() {
 		})
 	})
 
+	// Specs for HTTP Post to "/keys/{id}/rotate"
+	Describe("Rotate an existing Key", func() {
+		rotate := func(id string) {
+			router.Handle("/keys/{id}/rotate", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyController.Rotate))).Methods(http.MethodPost)
+			req, err := http.NewRequest(http.MethodPost, "/keys/"+id+"/rotate", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+		transfer := func(id, query string) {
+			router.Handle("/keys/{id}/transfer", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyController.Transfer))).Methods(http.MethodPost)
+			req, err := http.NewRequest(http.MethodPost, "/keys/"+id+"/transfer"+query, strings.NewReader(string(validEnvelopeKey)))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+			req.Header.Set("Content-Type", consts.HTTPMediaTypePlain)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		Context("Rotate Key by ID", func() {
+			It("Should create a new version of the Key", func() {
+				rotate("ee37c360-7eae-4250-a677-6ee12adce8e2")
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var keyResponse kbs.KeyResponse
+				Expect(json.Unmarshal(w.Body.Bytes(), &keyResponse)).To(Succeed())
+				Expect(keyResponse.KeyInformation.ID.String()).To(Equal("ee37c360-7eae-4250-a677-6ee12adce8e2"))
+				Expect(keyResponse.Version).To(Equal(2))
+				Expect(keyResponse.Versions).To(HaveLen(2))
+				Expect(keyResponse.Versions[0].RotatedAt).NotTo(BeNil())
+				Expect(keyResponse.Versions[1].RotatedAt).To(BeNil())
+			})
+		})
+		Context("Rotate Key by non-existent ID", func() {
+			It("Should fail to rotate Key with not found error", func() {
+				rotate("73755fda-c910-46be-821f-e8ddeab189e9")
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("Transfer a previous version of a rotated Key", func() {
+			It("Should transfer the previous version", func() {
+				rotate("ee37c360-7eae-4250-a677-6ee12adce8e2")
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				transfer("ee37c360-7eae-4250-a677-6ee12adce8e2", "?version=1")
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+		Context("Transfer a non-existent version of a Key", func() {
+			It("Should fail to transfer Key with not found error", func() {
+				transfer("ee37c360-7eae-4250-a677-6ee12adce8e2", "?version=2")
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+		Context("Rotate a registered Key", func() {
+			It("Should fail to rotate Key with bad request error", func() {
+				key, err := keyStore.Retrieve(uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"))
+				Expect(err).NotTo(HaveOccurred())
+				key.Registered = true
+				_, err = keyStore.Create(key)
+				Expect(err).NotTo(HaveOccurred())
+
+				rotate("ee37c360-7eae-4250-a677-6ee12adce8e2")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Transfer a Key with an unknown query parameter", func() {
+			It("Should ignore the query parameter and transfer the Key", func() {
+				transfer("ee37c360-7eae-4250-a677-6ee12adce8e2", "?context=unknown")
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+		Context("Transfer an invalid version of a Key", func() {
+			It("Should fail to transfer Key with bad request error", func() {
+				transfer("ee37c360-7eae-4250-a677-6ee12adce8e2", "?version=latest")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("Audit key transfers", func() {
+		records := func() []*kbs.KeyTransferRecord {
+			var transferRecords []*kbs.KeyTransferRecord
+			for _, record := range recordStore.KeyTransferRecordStore {
+				transferRecords = append(transferRecords, record)
+			}
+			return transferRecords
+		}
+
+		Context("Transfer a Key using public key", func() {
+			It("Should record the allowed key transfer", func() {
+				router.Handle("/keys/{id}/transfer", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyController.Transfer))).Methods(http.MethodPost)
+				req, err := http.NewRequest(http.MethodPost, "/keys/ee37c360-7eae-4250-a677-6ee12adce8e2/transfer", strings.NewReader(string(validEnvelopeKey)))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypePlain)
+				req = context.SetTokenSubject(req, "admin@kbs")
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				Expect(records()).To(HaveLen(1))
+				record := records()[0]
+				Expect(record.KeyId.String()).To(Equal("ee37c360-7eae-4250-a677-6ee12adce8e2"))
+				Expect(record.TransferType).To(Equal(constants.EnvelopeKeyTransfer))
+				Expect(record.Requester).To(Equal("admin@kbs"))
+				Expect(record.RequesterVerified).To(BeTrue())
+				Expect(record.TransferPolicyId.String()).To(Equal("ee37c360-7eae-4250-a677-6ee12adce8e2"))
+				Expect(record.Decision).To(Equal(constants.KeyTransferAllowed))
+				Expect(record.FaultReason).To(BeEmpty())
+			})
+		})
+		Context("Transfer a non-existent Key using public key", func() {
+			It("Should record the denied key transfer with the fault reason", func() {
+				router.Handle("/keys/{id}/transfer", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyController.Transfer))).Methods(http.MethodPost)
+				req, err := http.NewRequest(http.MethodPost, "/keys/73755fda-c910-46be-821f-e8ddeab189e9/transfer", strings.NewReader(string(validEnvelopeKey)))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypePlain)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+
+				Expect(records()).To(HaveLen(1))
+				Expect(records()[0].Decision).To(Equal(constants.KeyTransferDenied))
+				Expect(records()[0].FaultReason).To(Equal("Key with specified id does not exist"))
+			})
+		})
+		Context("Transfer a Key using saml report with unknown signer", func() {
+			It("Should record the denied key transfer with the saml report hash", func() {
+				router.Handle("/keys/{id}/transfer", kbsRoutes.ErrorHandler(kbsRoutes.ResponseHandler(keyTransferController.TransferWithSaml))).Methods(http.MethodPost)
+				req, err := http.NewRequest(http.MethodPost, "/keys/ee37c360-7eae-4250-a677-6ee12adce8e2/transfer", strings.NewReader(string(invalidSamlReport)))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeOctetStream)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeSaml)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+
+				Expect(records()).To(HaveLen(1))
+				record := records()[0]
+				Expect(record.TransferType).To(Equal(constants.SamlKeyTransfer))
+				Expect(record.Requester).To(Equal("127.0.0.1"))
+				Expect(record.RequesterVerified).To(BeFalse())
+				Expect(record.AttestationType).To(Equal(constants.TpmAttestationType))
+				Expect(record.EvidenceHash).To(HaveLen(96))
+				Expect(record.Decision).To(Equal(constants.KeyTransferDenied))
+				Expect(record.FaultReason).To(Equal("Client not trusted by HVS"))
+			})
+		})
+	})
+
 	// Specs for HTTP Get to "/keys"
 	Describe("Search for all the Keys", func() {
 		Context("Get all the Keys", func() {
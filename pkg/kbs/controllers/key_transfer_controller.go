@@ -13,6 +13,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/keymanager"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/keytransfer"
@@ -20,18 +21,24 @@ import (
 	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/saml"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
 )
 
+// samlHostNameAttribute is the SAML report attribute recorded as the requester of a key transfer
+const samlHostNameAttribute = "HostName"
+
 type KeyTransferController struct {
 	remoteManager *keymanager.RemoteManager
 	policyStore   domain.KeyTransferPolicyStore
+	recordStore   domain.KeyTransferRecordStore
 	keyConfig     domain.KeyTransferControllerConfig
 }
 
-func NewKeyTransferController(rm *keymanager.RemoteManager, ps domain.KeyTransferPolicyStore, kc domain.KeyTransferControllerConfig) *KeyTransferController {
+func NewKeyTransferController(rm *keymanager.RemoteManager, ps domain.KeyTransferPolicyStore, rs domain.KeyTransferRecordStore, kc domain.KeyTransferControllerConfig) *KeyTransferController {
 	return &KeyTransferController{
 		remoteManager: rm,
 		policyStore:   ps,
+		recordStore:   rs,
 		keyConfig:     kc,
 	}
 }
@@ -41,6 +48,17 @@ func (kc *KeyTransferController) TransferWithSaml(responseWriter http.ResponseWr
 	defaultLog.Trace("controllers/key_transfer_controller:TransferWithSaml() Entering")
 	defer defaultLog.Trace("controllers/key_transfer_controller:TransferWithSaml() Leaving")
 
+	record := newKeyTransferRecord(request, consts.SamlKeyTransfer)
+	record.AttestationType = consts.TpmAttestationType
+	response, status, err := kc.transferWithSaml(request, record)
+	recordKeyTransfer(kc.recordStore, kc.remoteManager, record, status, err)
+	return response, status, err
+}
+
+func (kc *KeyTransferController) transferWithSaml(request *http.Request, record *kbs.KeyTransferRecord) (interface{}, int, error) {
+	defaultLog.Trace("controllers/key_transfer_controller:transferWithSaml() Entering")
+	defer defaultLog.Trace("controllers/key_transfer_controller:transferWithSaml() Leaving")
+
 	if request.Header.Get("Content-Type") != constants.HTTPMediaTypeSaml {
 		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
 	}
@@ -55,6 +73,7 @@ func (kc *KeyTransferController) TransferWithSaml(responseWriter http.ResponseWr
 		secLog.WithError(err).Errorf("controllers/key_transfer_controller:TransferWithSaml() %s : Unable to read request body", commLogMsg.InvalidInputBadEncoding)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to read request body"}
 	}
+	record.EvidenceHash = evidenceHash(bytes)
 
 	// Unmarshal saml report in request
 	var samlReport *saml.Saml
@@ -63,17 +82,30 @@ func (kc *KeyTransferController) TransferWithSaml(responseWriter http.ResponseWr
 		secLog.WithError(err).Errorf("controllers/key_transfer_controller:TransferWithSaml() %s : SAML report unmarshal failed", commLogMsg.InvalidInputBadParam)
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Failed to unmarshal SAML report"}
 	}
+	version, err := getKeyVersion(request)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/key_transfer_controller:TransferWithSaml() %s : Invalid key version", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+	record.KeyVersion = version
 
 	// Validate saml report in request
 	keyId := uuid.MustParse(mux.Vars(request)["id"])
 	trusted, bindingCert := keytransfer.IsTrustedByHvs(string(bytes), samlReport, keyId, kc.keyConfig, kc.remoteManager)
+	// the host name is claimed by the report until its signature is verified
+	for _, attribute := range samlReport.Attribute {
+		if attribute.Name == samlHostNameAttribute {
+			record.Requester = attribute.AttributeValue
+			record.RequesterVerified = trusted
+		}
+	}
 	if !trusted {
 		secLog.Error("controllers/key_transfer_controller:TransferWithSaml() Client not trusted by HVS")
 		return nil, http.StatusUnauthorized, &commErr.ResourceError{Message: "Client not trusted by HVS"}
 	}
 	envelopeKey := bindingCert.PublicKey.(*rsa.PublicKey)
 
-	secretKey, status, err := getSecretKey(kc.remoteManager, keyId)
+	secretKey, status, err := getSecretKey(kc.remoteManager, keyId, version)
 	if err != nil {
 		return nil, status, err
 	}
@@ -7,11 +7,14 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/utils"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
 	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
 	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
@@ -22,15 +25,19 @@ import (
 	"github.com/pkg/errors"
 )
 
+var keyTransferPolicyDeleteParams = map[string]bool{"force": true}
+
 type KeyTransferPolicyController struct {
-	policyStore domain.KeyTransferPolicyStore
-	keyStore    domain.KeyStore
+	policyStore             domain.KeyTransferPolicyStore
+	keyStore                domain.KeyStore
+	defaultTransferPolicyId uuid.UUID
 }
 
-func NewKeyTransferPolicyController(ps domain.KeyTransferPolicyStore, ks domain.KeyStore) *KeyTransferPolicyController {
+func NewKeyTransferPolicyController(ps domain.KeyTransferPolicyStore, ks domain.KeyStore, dtp uuid.UUID) *KeyTransferPolicyController {
 	return &KeyTransferPolicyController{
-		policyStore: ps,
-		keyStore:    ks,
+		policyStore:             ps,
+		keyStore:                ks,
+		defaultTransferPolicyId: dtp,
 	}
 }
 
@@ -140,7 +147,7 @@ func (ktpc *KeyTransferPolicyController) Update(responseWriter http.ResponseWrit
 
 	if err := CompareTransferPolicy(transferPolicy, &requestPolicy); err != nil {
 		secLog.WithError(err).Error("controllers/key_transfer_policy_controller:Update() transfer policy don't match")
-		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Can't update the record."}
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Can't update the record."}
 	}
 
 	updatedPolicy, err := ktpc.policyStore.Update(transferPolicy)
@@ -157,10 +164,37 @@ func CompareTransferPolicy(retrievedPolicy *kbs.KeyTransferPolicy, inputPolicy *
 	defaultLog.Trace("controllers/key_transfer_policy_controller:CompareTransferPolicy() Entering")
 	defer defaultLog.Trace("controllers/key_transfer_policy_controller:CompareTransferPolicy() Leaving")
 
-	if slice.Contains(inputPolicy.AttestationType, aps.SGX) && inputPolicy.SGX.Attributes != nil {
+	retrievedPolicy.AttestationType = inputPolicy.AttestationType
+	retrievedPolicy.IssuerName = inputPolicy.IssuerName
+
+	if slice.Contains(inputPolicy.AttestationType, aps.SGX) && inputPolicy.SGX != nil {
+		if retrievedPolicy.SGX == nil {
+			retrievedPolicy.SGX = &kbs.SgxPolicy{}
+		}
+		retrievedPolicy.TDX = nil
+		retrievedPolicy.SGX.PolicyIds = inputPolicy.SGX.PolicyIds
+		if inputPolicy.SGX.Attributes == nil {
+			retrievedPolicy.SGX.Attributes = nil
+			return nil
+		}
+		if retrievedPolicy.SGX.Attributes == nil {
+			retrievedPolicy.SGX.Attributes = &kbs.SgxAttributes{}
+		}
 		return UpdateSGXAttributes(retrievedPolicy.SGX.Attributes, inputPolicy.SGX.Attributes)
 	}
-	if slice.Contains(inputPolicy.AttestationType, aps.TDX) && inputPolicy.TDX.Attributes != nil {
+	if slice.Contains(inputPolicy.AttestationType, aps.TDX) && inputPolicy.TDX != nil {
+		if retrievedPolicy.TDX == nil {
+			retrievedPolicy.TDX = &kbs.TdxPolicy{}
+		}
+		retrievedPolicy.SGX = nil
+		retrievedPolicy.TDX.PolicyIds = inputPolicy.TDX.PolicyIds
+		if inputPolicy.TDX.Attributes == nil {
+			retrievedPolicy.TDX.Attributes = nil
+			return nil
+		}
+		if retrievedPolicy.TDX.Attributes == nil {
+			retrievedPolicy.TDX.Attributes = &kbs.TdxAttributes{}
+		}
 		return UpdateTDXAttributes(retrievedPolicy.TDX.Attributes, inputPolicy.TDX.Attributes)
 	} else {
 		return errors.New("controllers/key_transfer_policy_controller:CompareTransferPolicy() Incorrect attestation type in key transfer policy")
@@ -202,19 +236,41 @@ func (ktpc *KeyTransferPolicyController) Delete(responseWriter http.ResponseWrit
 	defer defaultLog.Trace("controllers/key_transfer_policy_controller:Delete() Leaving")
 
 	id := uuid.MustParse(mux.Vars(request)["id"])
-	criteria := &models.KeyFilterCriteria{
-		TransferPolicyId: id,
+
+	// check for query parameters
+	if err := utils.ValidateQueryParams(request.URL.Query(), keyTransferPolicyDeleteParams); err != nil {
+		secLog.Errorf("controllers/key_transfer_policy_controller:Delete() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
 	}
 
-	keys, err := ktpc.keyStore.Search(criteria)
+	force := false
+	if param := strings.TrimSpace(request.URL.Query().Get("force")); param != "" {
+		var err error
+		if force, err = strconv.ParseBool(param); err != nil {
+			secLog.WithError(err).Errorf("controllers/key_transfer_policy_controller:Delete() %s : Invalid force query param value", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Invalid force query param value, must be true or false"}
+		}
+	}
+
+	// the default policy is assigned to the keys created without a policy, it cannot be deleted even if forced
+	if id == ktpc.defaultTransferPolicyId {
+		defaultLog.Error("controllers/key_transfer_policy_controller:Delete() Default key transfer policy cannot be deleted")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Default key transfer policy cannot be deleted"}
+	}
+
+	keyIds, err := ktpc.getKeysUsingPolicy(id)
 	if err != nil {
 		defaultLog.WithError(err).Error("controllers/key_transfer_policy_controller:Delete() Key search failed")
 		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to search keys"}
 	}
 
-	if len(keys) > 0 {
-		defaultLog.Error("controllers/key_transfer_policy_controller:Delete() Key transfer policy is associated with existing keys")
-		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Key transfer policy is associated with keys"}
+	if len(keyIds) > 0 {
+		if !force {
+			defaultLog.Error("controllers/key_transfer_policy_controller:Delete() Key transfer policy is associated with existing keys")
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Key transfer policy is associated with keys: " + joinKeyIds(keyIds)}
+		}
+		// the keys keep referring to the deleted policy, their transfer is denied as long as they exist
+		secLog.WithField("Id", id).Warnf("controllers/key_transfer_policy_controller:Delete() Forced delete of key transfer policy associated with keys: %s", joinKeyIds(keyIds))
 	}
 
 	err = ktpc.policyStore.Delete(id)
@@ -232,6 +288,62 @@ func (ktpc *KeyTransferPolicyController) Delete(responseWriter http.ResponseWrit
 	return nil, http.StatusNoContent, nil
 }
 
+// Usage : Function to list the keys associated with a key transfer policy
+func (ktpc *KeyTransferPolicyController) Usage(responseWriter http.ResponseWriter, request *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/key_transfer_policy_controller:Usage() Entering")
+	defer defaultLog.Trace("controllers/key_transfer_policy_controller:Usage() Leaving")
+
+	id := uuid.MustParse(mux.Vars(request)["id"])
+	_, err := ktpc.policyStore.Retrieve(id)
+	if err != nil {
+		if err.Error() == commErr.RecordNotFound {
+			defaultLog.Errorf("controllers/key_transfer_policy_controller:Usage() Key transfer policy with specified id could not be located")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Key transfer policy with specified id does not exist"}
+		} else {
+			defaultLog.WithError(err).Error("controllers/key_transfer_policy_controller:Usage() Key transfer policy retrieve failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve key transfer policy"}
+		}
+	}
+
+	keyIds, err := ktpc.getKeysUsingPolicy(id)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/key_transfer_policy_controller:Usage() Key search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to search keys"}
+	}
+
+	secLog.WithField("Id", id).Infof("controllers/key_transfer_policy_controller:Usage() %s: Key Transfer Policy usage retrieved by: %s", commLogMsg.AuthorizedAccess, request.RemoteAddr)
+	return kbs.KeyTransferPolicyUsage{PolicyId: id, KeyIds: keyIds}, http.StatusOK, nil
+}
+
+// getKeysUsingPolicy returns the ids of the keys whose transfer policy is the given policy
+func (ktpc *KeyTransferPolicyController) getKeysUsingPolicy(id uuid.UUID) ([]uuid.UUID, error) {
+	defaultLog.Trace("controllers/key_transfer_policy_controller:getKeysUsingPolicy() Entering")
+	defer defaultLog.Trace("controllers/key_transfer_policy_controller:getKeysUsingPolicy() Leaving")
+
+	criteria := &models.KeyFilterCriteria{
+		TransferPolicyId: id,
+	}
+
+	keys, err := ktpc.keyStore.Search(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	keyIds := []uuid.UUID{}
+	for _, key := range keys {
+		keyIds = append(keyIds, key.ID)
+	}
+	return keyIds, nil
+}
+
+func joinKeyIds(keyIds []uuid.UUID) string {
+	ids := make([]string, len(keyIds))
+	for i, keyId := range keyIds {
+		ids[i] = keyId.String()
+	}
+	return strings.Join(ids, ", ")
+}
+
 //Search : Function to retrieve all the key transfer policies
 func (ktpc *KeyTransferPolicyController) Search(responseWriter http.ResponseWriter, request *http.Request) (interface{}, int, error) {
 	defaultLog.Trace("controllers/key_transfer_policy_controller:Search() Entering")
@@ -265,6 +377,14 @@ func ValidateKeyTransferPolicy(requestPolicy kbs.KeyTransferPolicy) error {
 		return errors.New("controllers/key_transfer_policy_controller:ValidateKeyTransferPolicy() Invalid attestation type")
 	}
 
+	if slice.Contains(requestPolicy.AttestationType, aps.SGX) && (requestPolicy.SGX == nil || (requestPolicy.SGX.Attributes == nil && len(requestPolicy.SGX.PolicyIds) == 0)) {
+		return errors.New("controllers/key_transfer_policy_controller:ValidateKeyTransferPolicy() Either policy_ids or attributes must be specified for SGX policy")
+	}
+
+	if slice.Contains(requestPolicy.AttestationType, aps.TDX) && (requestPolicy.TDX == nil || (requestPolicy.TDX.Attributes == nil && len(requestPolicy.TDX.PolicyIds) == 0)) {
+		return errors.New("controllers/key_transfer_policy_controller:ValidateKeyTransferPolicy() Either policy_ids or attributes must be specified for TDX policy")
+	}
+
 	if slice.Contains(requestPolicy.AttestationType, aps.SGX) && requestPolicy.SGX.Attributes != nil {
 		if requestPolicy.SGX.Attributes.MrSigner == nil || requestPolicy.SGX.Attributes.IsvProductId == nil {
 			return errors.New("controllers/key_transfer_policy_controller:ValidateKeyTransferPolicy() MrSigner and IsvProductId must be specified for SGX policy")
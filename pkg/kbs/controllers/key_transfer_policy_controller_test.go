@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/controllers"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/mocks"
@@ -31,7 +32,7 @@ var _ = Describe("KeyTransferPolicyController", func() {
 		keyStore = mocks.NewFakeKeyStore()
 		policyStore = mocks.NewFakeKeyTransferPolicyStore()
 
-		keyTransferPolicyController = controllers.NewKeyTransferPolicyController(policyStore, keyStore)
+		keyTransferPolicyController = controllers.NewKeyTransferPolicyController(policyStore, keyStore, uuid.MustParse("ed37c360-7eae-4250-a677-6ee12adce8e3"))
 	})
 
 	// Specs for HTTP Post to "/key-transfer-policies"
@@ -620,6 +621,93 @@ var _ = Describe("KeyTransferPolicyController", func() {
 				Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
 			})
 		})
+		Context("Provide a valid Update request with cert issuer and policy ids", func() {
+			It("Should replace the attributes of an existing Key Transfer Policy", func() {
+				router.Handle("/key-transfer-policies/{id}", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyTransferPolicyController.Update))).Methods(http.MethodPut)
+				policyJson := `{
+					"attestation_type": ["SGX"],
+					"sgx": {
+						"attributes": {
+							"mrsigner": ["dd171c56941c6ce49690b455f691d9c8a04c2e43e0a4d30f752fa5285c7ee57f"],
+							"isvprodid": [2]
+						},
+						"policy_ids": ["37965f5f-ccaf-4cdc-a356-a8ed5268a5bf"]
+					},
+					"cert_issuer": ["CMSCA"]
+				}`
+
+				req, err := http.NewRequest(
+					http.MethodPut,
+					"/key-transfer-policies/ee37c360-7eae-4250-a677-6ee12adce8e2",
+					strings.NewReader(policyJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var policy kbs.KeyTransferPolicy
+				err = json.Unmarshal(w.Body.Bytes(), &policy)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policy.ID.String()).To(Equal("ee37c360-7eae-4250-a677-6ee12adce8e2"))
+				Expect(policy.IssuerName).To(Equal([]string{"CMSCA"}))
+				Expect(policy.SGX.PolicyIds).To(HaveLen(1))
+				Expect(policy.SGX.Attributes.IsvProductId).To(Equal([]uint16{2}))
+				Expect(policy.SGX.Attributes.MrEnclave).To(BeEmpty())
+			})
+		})
+		Context("Provide an Update request changing the attestation type", func() {
+			It("Should update an existing  Key Transfer Policy", func() {
+				router.Handle("/key-transfer-policies/{id}", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyTransferPolicyController.Update))).Methods(http.MethodPut)
+				policyJson := `{
+					"attestation_type": ["TDX"],
+					"tdx": {
+						"policy_ids": ["9846bf40-e380-4842-ae15-1b60996d1190"]
+					}
+				}`
+
+				req, err := http.NewRequest(
+					http.MethodPut,
+					"/key-transfer-policies/ee37c360-7eae-4250-a677-6ee12adce8e2",
+					strings.NewReader(policyJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var policy kbs.KeyTransferPolicy
+				err = json.Unmarshal(w.Body.Bytes(), &policy)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policy.SGX).To(BeNil())
+				Expect(policy.TDX.Attributes).To(BeNil())
+				Expect(policy.TDX.PolicyIds).To(HaveLen(1))
+			})
+		})
+		Context("Provide an invalid Update request without sgx policy", func() {
+			It("Should fail to update an existing  Key Transfer Policy", func() {
+				router.Handle("/key-transfer-policies/{id}", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyTransferPolicyController.Update))).Methods(http.MethodPut)
+				policyJson := `{
+					"attestation_type": ["SGX"]
+				}`
+
+				req, err := http.NewRequest(
+					http.MethodPut,
+					"/key-transfer-policies/ee37c360-7eae-4250-a677-6ee12adce8e2",
+					strings.NewReader(policyJson),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 	})
 
 	// Specs for HTTP Delete to "/key-transfer-policies/{id}"
@@ -652,6 +740,87 @@ var _ = Describe("KeyTransferPolicyController", func() {
 				w = httptest.NewRecorder()
 				router.ServeHTTP(w, req)
 				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(ContainSubstring("e57e5ea0-d465-461e-882d-1600090caa0d"))
+			})
+		})
+		Context("Force delete Key Transfer Policy associated with Key", func() {
+			It("Should delete a Key Transfer Policy", func() {
+				router.Handle("/key-transfer-policies/{id}", kbsRoutes.ErrorHandler(kbsRoutes.ResponseHandler(keyTransferPolicyController.Delete))).Methods(http.MethodDelete)
+				req, err := http.NewRequest(http.MethodDelete, "/key-transfer-policies/ee37c360-7eae-4250-a677-6ee12adce8e2?force=true", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+		Context("Force delete the default Key Transfer Policy", func() {
+			It("Should fail to delete the default Key Transfer Policy with bad request error", func() {
+				router.Handle("/key-transfer-policies/{id}", kbsRoutes.ErrorHandler(kbsRoutes.ResponseHandler(keyTransferPolicyController.Delete))).Methods(http.MethodDelete)
+				req, err := http.NewRequest(http.MethodDelete, "/key-transfer-policies/ed37c360-7eae-4250-a677-6ee12adce8e3?force=true", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				_, err = policyStore.Retrieve(uuid.MustParse("ed37c360-7eae-4250-a677-6ee12adce8e3"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+		Context("Delete Key Transfer Policy with invalid force value", func() {
+			It("Should fail to delete Key Transfer Policy with bad request error", func() {
+				router.Handle("/key-transfer-policies/{id}", kbsRoutes.ErrorHandler(kbsRoutes.ResponseHandler(keyTransferPolicyController.Delete))).Methods(http.MethodDelete)
+				req, err := http.NewRequest(http.MethodDelete, "/key-transfer-policies/ee37c360-7eae-4250-a677-6ee12adce8e2?force=yes", nil)
+				Expect(err).NotTo(HaveOccurred())
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	// Specs for HTTP Get to "/key-transfer-policies/{id}/usage"
+	Describe("Retrieve the usage of a Key Transfer Policy", func() {
+		Context("Retrieve usage of Key Transfer Policy associated with Keys", func() {
+			It("Should list the associated Keys", func() {
+				router.Handle("/key-transfer-policies/{id}/usage", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyTransferPolicyController.Usage))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/key-transfer-policies/ee37c360-7eae-4250-a677-6ee12adce8e2/usage", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var usage kbs.KeyTransferPolicyUsage
+				err = json.Unmarshal(w.Body.Bytes(), &usage)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usage.PolicyId.String()).To(Equal("ee37c360-7eae-4250-a677-6ee12adce8e2"))
+				Expect(usage.KeyIds).To(ContainElements(uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2"), uuid.MustParse("e57e5ea0-d465-461e-882d-1600090caa0d")))
+			})
+		})
+		Context("Retrieve usage of Key Transfer Policy not associated with Keys", func() {
+			It("Should return an empty list of Keys", func() {
+				router.Handle("/key-transfer-policies/{id}/usage", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyTransferPolicyController.Usage))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/key-transfer-policies/73755fda-c910-46be-821f-e8ddeab189e9/usage", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var usage kbs.KeyTransferPolicyUsage
+				err = json.Unmarshal(w.Body.Bytes(), &usage)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usage.KeyIds).To(BeEmpty())
+			})
+		})
+		Context("Retrieve usage of non-existent Key Transfer Policy", func() {
+			It("Should fail with not found error", func() {
+				router.Handle("/key-transfer-policies/{id}/usage", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(keyTransferPolicyController.Usage))).Methods(http.MethodGet)
+				req, err := http.NewRequest(http.MethodGet, "/key-transfer-policies/e57e5ea0-d465-461e-882d-1600090caa0d/usage", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
 			})
 		})
 	})
@@ -0,0 +1,142 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/keytransfer"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/saml"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+	"github.com/pkg/errors"
+)
+
+type KeyTransferPolicyEvaluationController struct {
+	policyStore domain.KeyTransferPolicyStore
+	keyStore    domain.KeyStore
+	keyConfig   domain.KeyTransferControllerConfig
+}
+
+func NewKeyTransferPolicyEvaluationController(ps domain.KeyTransferPolicyStore, ks domain.KeyStore, kc domain.KeyTransferControllerConfig) *KeyTransferPolicyEvaluationController {
+	return &KeyTransferPolicyEvaluationController{
+		policyStore: ps,
+		keyStore:    ks,
+		keyConfig:   kc,
+	}
+}
+
+// Evaluate : Function to evaluate sample attestation claims against a key transfer policy without releasing any key
+func (ktpec *KeyTransferPolicyEvaluationController) Evaluate(responseWriter http.ResponseWriter, request *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/key_transfer_policy_evaluation_controller:Evaluate() Entering")
+	defer defaultLog.Trace("controllers/key_transfer_policy_evaluation_controller:Evaluate() Leaving")
+
+	id := uuid.MustParse(mux.Vars(request)["id"])
+
+	if request.Header.Get("Content-Type") != constants.HTTPMediaTypeJson {
+		return nil, http.StatusUnsupportedMediaType, &commErr.ResourceError{Message: "Invalid Content-Type"}
+	}
+
+	if request.ContentLength == 0 {
+		secLog.Error("controllers/key_transfer_policy_evaluation_controller:Evaluate() The request body was not provided")
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "The request body was not provided"}
+	}
+
+	var evaluationRequest kbs.KeyTransferPolicyEvaluationRequest
+	// Decode the incoming json data to note struct
+	dec := json.NewDecoder(request.Body)
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(&evaluationRequest)
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/key_transfer_policy_evaluation_controller:Evaluate() %s : Unable to decode JSON request body", commLogMsg.InvalidInputBadEncoding)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Unable to decode JSON request body"}
+	}
+
+	if err := validateKeyTransferPolicyEvaluationRequest(evaluationRequest); err != nil {
+		secLog.WithError(err).Errorf("controllers/key_transfer_policy_evaluation_controller:Evaluate() %s : Input validation failed", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	transferPolicy, err := ktpec.policyStore.Retrieve(id)
+	if err != nil {
+		if err.Error() == commErr.RecordNotFound {
+			defaultLog.Error("controllers/key_transfer_policy_evaluation_controller:Evaluate() Key transfer policy with specified id could not be located")
+			return nil, http.StatusNotFound, &commErr.ResourceError{Message: "Key transfer policy with specified id does not exist"}
+		} else {
+			defaultLog.WithError(err).Error("controllers/key_transfer_policy_evaluation_controller:Evaluate() Key transfer policy retrieve failed")
+			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve key transfer policy"}
+		}
+	}
+
+	var evaluations []kbs.PolicyAttributeEvaluation
+	if evaluationRequest.Saml != "" {
+		// Unmarshal saml report in request
+		var samlReport *saml.Saml
+		err = xml.Unmarshal([]byte(evaluationRequest.Saml), &samlReport)
+		if err != nil {
+			secLog.WithError(err).Errorf("controllers/key_transfer_policy_evaluation_controller:Evaluate() %s : SAML report unmarshal failed", commLogMsg.InvalidInputBadParam)
+			return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Failed to unmarshal SAML report"}
+		}
+
+		// the usage policy of the key is enforced along with the SAML report
+		var usage string
+		if evaluationRequest.KeyId != uuid.Nil {
+			key, err := ktpec.keyStore.Retrieve(evaluationRequest.KeyId)
+			if err != nil {
+				if err.Error() == commErr.RecordNotFound {
+					defaultLog.Error("controllers/key_transfer_policy_evaluation_controller:Evaluate() Key with specified id could not be located")
+					return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Key with specified key_id does not exist"}
+				}
+				defaultLog.WithError(err).Error("controllers/key_transfer_policy_evaluation_controller:Evaluate() Key retrieve failed")
+				return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve key"}
+			}
+			if key.TransferPolicyId != id {
+				defaultLog.Error("controllers/key_transfer_policy_evaluation_controller:Evaluate() Key is not associated with the key transfer policy")
+				return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Key with specified key_id is not associated with the key transfer policy"}
+			}
+			usage = key.Usage
+		}
+		evaluations, _ = keytransfer.EvaluateSamlReport(evaluationRequest.Saml, samlReport, usage, ktpec.keyConfig)
+	}
+
+	if evaluationRequest.Saml == "" || evaluationRequest.AttestationType != "" || evaluationRequest.SGX != nil || evaluationRequest.TDX != nil || evaluationRequest.CertIssuer != "" {
+		evaluations = append(evaluations, keytransfer.EvaluateKeyTransferPolicy(transferPolicy, &evaluationRequest)...)
+	}
+
+	evaluation := kbs.KeyTransferPolicyEvaluation{
+		PolicyId:   id,
+		Decision:   consts.KeyTransferDenied,
+		Attributes: evaluations,
+	}
+	if keytransfer.IsPolicySatisfied(evaluations) {
+		evaluation.Decision = consts.KeyTransferAllowed
+	}
+
+	secLog.WithField("Id", id).Infof("controllers/key_transfer_policy_evaluation_controller:Evaluate() %s: Key Transfer Policy evaluated by: %s", commLogMsg.AuthorizedAccess, request.RemoteAddr)
+	return evaluation, http.StatusOK, nil
+}
+
+func validateKeyTransferPolicyEvaluationRequest(evaluationRequest kbs.KeyTransferPolicyEvaluationRequest) error {
+	defaultLog.Trace("controllers/key_transfer_policy_evaluation_controller:validateKeyTransferPolicyEvaluationRequest() Entering")
+	defer defaultLog.Trace("controllers/key_transfer_policy_evaluation_controller:validateKeyTransferPolicyEvaluationRequest() Leaving")
+
+	if evaluationRequest.AttestationType != "" && !evaluationRequest.AttestationType.Valid() {
+		return errors.New("Invalid attestation_type")
+	}
+
+	if evaluationRequest.KeyId != uuid.Nil && evaluationRequest.Saml == "" {
+		return errors.New("key_id is only applicable with a saml report")
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/mocks"
+	kbsRoutes "github.com/intel-secl/intel-secl/v5/pkg/kbs/router"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyTransferPolicyEvaluationController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var policyEvaluationController *controllers.KeyTransferPolicyEvaluationController
+
+	kcc := domain.KeyTransferControllerConfig{
+		SamlCertsDir:        samlCertsDir,
+		TrustedCaCertsDir:   trustedCaCertsDir,
+		TpmIdentityCertsDir: tpmIdentityCertsDir,
+	}
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		policyEvaluationController = controllers.NewKeyTransferPolicyEvaluationController(mocks.NewFakeKeyTransferPolicyStore(), mocks.NewFakeKeyStore(), kcc)
+		router.Handle("/key-transfer-policies/{id}/evaluate", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(policyEvaluationController.Evaluate))).Methods(http.MethodPost)
+	})
+
+	evaluate := func(policyId, evaluationJson string) {
+		req, err := http.NewRequest(
+			http.MethodPost,
+			"/key-transfer-policies/"+policyId+"/evaluate",
+			strings.NewReader(evaluationJson),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		req.Header.Set("Content-Type", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+	}
+
+	results := func(evaluation kbs.KeyTransferPolicyEvaluation) map[string]string {
+		attributeResults := make(map[string]string)
+		for _, attribute := range evaluation.Attributes {
+			attributeResults[attribute.Name] = attribute.Result
+		}
+		return attributeResults
+	}
+
+	// Specs for HTTP Post to "/key-transfer-policies/{id}/evaluate"
+	Describe("Evaluate a Key Transfer Policy", func() {
+		Context("Provide SGX claims matching the policy", func() {
+			It("Should return an allowed decision", func() {
+				evaluate("ee37c360-7eae-4250-a677-6ee12adce8e2", `{
+					"sgx": {
+						"mrsigner": "cd171c56941c6ce49690b455f691d9c8a04c2e43e0a4d30f752fa5285c7ee57f",
+						"isvprodid": 1,
+						"mrenclave": "01c60b9617b2f96e53cb75ef01e0dccea3afc7b7992697eabb8f714b2ccd1953",
+						"isvsvn": 0
+					}
+				}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var evaluation kbs.KeyTransferPolicyEvaluation
+				err := json.Unmarshal(w.Body.Bytes(), &evaluation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(evaluation.Decision).To(Equal(constants.KeyTransferAllowed))
+				Expect(results(evaluation)).To(Equal(map[string]string{
+					"attestation_type": constants.PolicyAttributeMatched,
+					"mrsigner":         constants.PolicyAttributeMatched,
+					"isvprodid":        constants.PolicyAttributeMatched,
+					"mrenclave":        constants.PolicyAttributeMatched,
+					"isvsvn":           constants.PolicyAttributeMatched,
+				}))
+			})
+		})
+		Context("Provide SGX claims with a different signer and no isvsvn", func() {
+			It("Should return a denied decision with mismatched and missing attributes", func() {
+				evaluate("ee37c360-7eae-4250-a677-6ee12adce8e2", `{
+					"attestation_type": "SGX",
+					"sgx": {
+						"mrsigner": "ab171c56941c6ce49690b455f691d9c8a04c2e43e0a4d30f752fa5285c7ee57f",
+						"isvprodid": 1,
+						"mrenclave": "01c60b9617b2f96e53cb75ef01e0dccea3afc7b7992697eabb8f714b2ccd1953"
+					}
+				}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var evaluation kbs.KeyTransferPolicyEvaluation
+				err := json.Unmarshal(w.Body.Bytes(), &evaluation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(evaluation.Decision).To(Equal(constants.KeyTransferDenied))
+				Expect(results(evaluation)["mrsigner"]).To(Equal(constants.PolicyAttributeMismatched))
+				Expect(results(evaluation)["isvprodid"]).To(Equal(constants.PolicyAttributeMatched))
+				Expect(results(evaluation)["isvsvn"]).To(Equal(constants.PolicyAttributeMissing))
+			})
+		})
+		Context("Provide TDX claims for a SGX policy", func() {
+			It("Should return a denied decision with mismatched attestation type", func() {
+				evaluate("ee37c360-7eae-4250-a677-6ee12adce8e2", `{
+					"tdx": {
+						"mrseam": "0f3b72d0f9606086d6a7800e7d50b82fa6cb5ec64c7210353a0696c1eef343679bf5b9e8ec0bf58ab3fce10f2c166ebe"
+					}
+				}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var evaluation kbs.KeyTransferPolicyEvaluation
+				err := json.Unmarshal(w.Body.Bytes(), &evaluation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(evaluation.Decision).To(Equal(constants.KeyTransferDenied))
+				Expect(results(evaluation)["attestation_type"]).To(Equal(constants.PolicyAttributeMismatched))
+			})
+		})
+		Context("Provide TDX claims with a different RTMR", func() {
+			It("Should return a denied decision with mismatched RTMR", func() {
+				evaluate("ed37c360-7eae-4250-a677-6ee12adce8e3", `{
+					"tdx": {
+						"mrsignerseam": "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+						"mrseam": "0f3b72d0f9606086d6a7800e7d50b82fa6cb5ec64c7210353a0696c1eef343679bf5b9e8ec0bf58ab3fce10f2c166ebe",
+						"seamsvn": 0,
+						"mrtd": "cf656414fc0f49b23e2ae64b6f23b82901e2206aab36b671e360ebd414899dab51bbb60134bbe6ad8dcc70b995d9dc50",
+						"rtmr0": "b90abd43736381b12fc9b038924c73e31c8371674905e7fcb7941d69fe59d30eda3adb9e41b878151e756fb05ad13d14",
+						"rtmr1": "a53c98b16f0de470338e7f072d9c5fcef6171327ec6c78b842e637251b1de6e37354c47fb68de27ef14bb67caf288d9b",
+						"rtmr2": "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+						"rtmr3": "111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111"
+					}
+				}`)
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var evaluation kbs.KeyTransferPolicyEvaluation
+				err := json.Unmarshal(w.Body.Bytes(), &evaluation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(evaluation.Decision).To(Equal(constants.KeyTransferDenied))
+				Expect(results(evaluation)["mrseam"]).To(Equal(constants.PolicyAttributeMatched))
+				Expect(results(evaluation)["rtmr2"]).To(Equal(constants.PolicyAttributeMatched))
+				Expect(results(evaluation)["rtmr3"]).To(Equal(constants.PolicyAttributeMismatched))
+			})
+		})
+		Context("Provide a SAML report signed by an unknown signer", func() {
+			It("Should return a denied decision with mismatched signature", func() {
+				samlReport, err := ioutil.ReadFile(invalidSamlReportPath)
+				Expect(err).NotTo(HaveOccurred())
+				evaluationJson, err := json.Marshal(kbs.KeyTransferPolicyEvaluationRequest{Saml: string(samlReport)})
+				Expect(err).NotTo(HaveOccurred())
+
+				evaluate("ee37c360-7eae-4250-a677-6ee12adce8e2", string(evaluationJson))
+				Expect(w.Code).To(Equal(http.StatusOK))
+
+				var evaluation kbs.KeyTransferPolicyEvaluation
+				err = json.Unmarshal(w.Body.Bytes(), &evaluation)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(evaluation.Decision).To(Equal(constants.KeyTransferDenied))
+				Expect(results(evaluation)["saml_signature"]).To(Equal(constants.PolicyAttributeMismatched))
+				Expect(results(evaluation)).NotTo(HaveKey("attestation_type"))
+			})
+		})
+		Context("Provide a key which is not associated with the policy", func() {
+			It("Should fail to evaluate the Key Transfer Policy", func() {
+				evaluate("ee37c360-7eae-4250-a677-6ee12adce8e2", `{
+					"saml": "<Assertion></Assertion>",
+					"key_id": "ed37c360-7eae-4250-a677-6ee12adce8e3"
+				}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide an invalid attestation type", func() {
+			It("Should fail to evaluate the Key Transfer Policy", func() {
+				evaluate("ee37c360-7eae-4250-a677-6ee12adce8e2", `{"attestation_type": "XYZ"}`)
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Provide a non-existent Key Transfer Policy id", func() {
+			It("Should fail to evaluate the Key Transfer Policy", func() {
+				evaluate("73755fda-c910-46be-821f-e8ddeab189e8", `{"attestation_type": "SGX"}`)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
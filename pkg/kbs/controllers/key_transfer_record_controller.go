@@ -0,0 +1,197 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/keymanager"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/utils"
+	commErr "github.com/intel-secl/intel-secl/v5/pkg/lib/common/err"
+	commLogMsg "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log/message"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+	"github.com/pkg/errors"
+)
+
+type KeyTransferRecordController struct {
+	recordStore domain.KeyTransferRecordStore
+}
+
+func NewKeyTransferRecordController(rs domain.KeyTransferRecordStore) *KeyTransferRecordController {
+	return &KeyTransferRecordController{
+		recordStore: rs,
+	}
+}
+
+var keyTransferRecordSearchParams = map[string]bool{"keyId": true, "transferType": true, "requester": true, "transferPolicyId": true,
+	"decision": true, "fromDate": true, "toDate": true, "limit": true}
+
+var allowedTransferTypes = map[string]bool{consts.EnvelopeKeyTransfer: true, consts.SamlKeyTransfer: true, consts.SKCKeyTransfer: true}
+var allowedDecisions = map[string]bool{consts.KeyTransferAllowed: true, consts.KeyTransferDenied: true}
+
+// Search : Function to search the audit records of the key transfers
+func (ktrc *KeyTransferRecordController) Search(responseWriter http.ResponseWriter, request *http.Request) (interface{}, int, error) {
+	defaultLog.Trace("controllers/key_transfer_record_controller:Search() Entering")
+	defer defaultLog.Trace("controllers/key_transfer_record_controller:Search() Leaving")
+
+	// check for query parameters
+	if err := utils.ValidateQueryParams(request.URL.Query(), keyTransferRecordSearchParams); err != nil {
+		secLog.Errorf("controllers/key_transfer_record_controller:Search() %s", err.Error())
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	criteria, err := getKeyTransferRecordFilterCriteria(request.URL.Query())
+	if err != nil {
+		secLog.WithError(err).Errorf("controllers/key_transfer_record_controller:Search() %s Invalid filter criteria", commLogMsg.InvalidInputBadParam)
+		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: err.Error()}
+	}
+
+	records, err := ktrc.recordStore.Search(criteria)
+	if err != nil {
+		defaultLog.WithError(err).Error("controllers/key_transfer_record_controller:Search() Key transfer record search failed")
+		return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to search key transfer records"}
+	}
+
+	secLog.Infof("controllers/key_transfer_record_controller:Search() %s: Key transfer records searched by: %s", commLogMsg.AuthorizedAccess, request.RemoteAddr)
+	return records, http.StatusOK, nil
+}
+
+func getKeyTransferRecordFilterCriteria(params url.Values) (*models.KeyTransferRecordFilterCriteria, error) {
+	defaultLog.Trace("controllers/key_transfer_record_controller:getKeyTransferRecordFilterCriteria() Entering")
+	defer defaultLog.Trace("controllers/key_transfer_record_controller:getKeyTransferRecordFilterCriteria() Leaving")
+
+	criteria := models.KeyTransferRecordFilterCriteria{}
+
+	// keyId
+	if param := strings.TrimSpace(params.Get("keyId")); param != "" {
+		id, err := uuid.Parse(param)
+		if err != nil {
+			return nil, errors.New("Invalid keyId query param value, must be UUID")
+		}
+		criteria.KeyId = id
+	}
+
+	// transferType
+	if param := strings.TrimSpace(params.Get("transferType")); param != "" {
+		if !allowedTransferTypes[param] {
+			return nil, errors.New("Valid transferType must be specified")
+		}
+		criteria.TransferType = param
+	}
+
+	// requester
+	if param := strings.TrimSpace(params.Get("requester")); param != "" {
+		criteria.Requester = param
+	}
+
+	// transferPolicyId
+	if param := strings.TrimSpace(params.Get("transferPolicyId")); param != "" {
+		id, err := uuid.Parse(param)
+		if err != nil {
+			return nil, errors.New("Invalid transferPolicyId query param value, must be UUID")
+		}
+		criteria.TransferPolicyId = id
+	}
+
+	// decision
+	if param := strings.TrimSpace(params.Get("decision")); param != "" {
+		if !allowedDecisions[param] {
+			return nil, errors.New("Valid decision must be specified")
+		}
+		criteria.Decision = param
+	}
+
+	// fromDate
+	if param := strings.TrimSpace(params.Get("fromDate")); param != "" {
+		fromDate, err := utils.ParseDateQueryParam(param)
+		if err != nil {
+			return nil, errors.New("Invalid fromDate specified")
+		}
+		criteria.FromDate = fromDate
+	}
+
+	// toDate
+	if param := strings.TrimSpace(params.Get("toDate")); param != "" {
+		toDate, err := utils.ParseDateQueryParam(param)
+		if err != nil {
+			return nil, errors.New("Invalid toDate specified")
+		}
+		criteria.ToDate = toDate
+	}
+
+	// limit
+	if param := strings.TrimSpace(params.Get("limit")); param != "" {
+		limit, err := strconv.Atoi(param)
+		if err != nil || limit < 1 {
+			return nil, errors.New("limit must be a positive integer")
+		}
+		criteria.Limit = limit
+	}
+	return &criteria, nil
+}
+
+// newKeyTransferRecord starts the audit record of a key transfer request, the decision is set by recordKeyTransfer
+func newKeyTransferRecord(request *http.Request, transferType string) *kbs.KeyTransferRecord {
+	record := &kbs.KeyTransferRecord{
+		TransferType:  transferType,
+		RemoteAddress: request.RemoteAddr,
+		CreatedAt:     time.Now().UTC(),
+	}
+	if id, err := uuid.Parse(mux.Vars(request)["id"]); err == nil {
+		record.KeyId = id
+	}
+	return record
+}
+
+// recordKeyTransfer completes the audit record with the outcome of the key transfer and saves it. A failure to save
+// the record is logged, it does not fail the key transfer.
+func recordKeyTransfer(recordStore domain.KeyTransferRecordStore, remoteManager *keymanager.RemoteManager, record *kbs.KeyTransferRecord, status int, err error) {
+	defaultLog.Trace("controllers/key_transfer_record_controller:recordKeyTransfer() Entering")
+	defer defaultLog.Trace("controllers/key_transfer_record_controller:recordKeyTransfer() Leaving")
+
+	if recordStore == nil {
+		return
+	}
+
+	if err == nil && status >= http.StatusOK && status < http.StatusMultipleChoices {
+		record.Decision = consts.KeyTransferAllowed
+	} else {
+		record.Decision = consts.KeyTransferDenied
+		if record.FaultReason == "" {
+			if err != nil {
+				record.FaultReason = err.Error()
+			} else {
+				record.FaultReason = http.StatusText(status)
+			}
+		}
+	}
+
+	if record.TransferPolicyId == uuid.Nil && record.KeyId != uuid.Nil {
+		if key, err := remoteManager.RetrieveKey(record.KeyId); err == nil {
+			record.TransferPolicyId = key.TransferPolicyID
+		}
+	}
+
+	if _, err := recordStore.Create(record); err != nil {
+		secLog.WithError(err).WithField("Id", record.KeyId).Errorf("controllers/key_transfer_record_controller:recordKeyTransfer() Failed to save key transfer record, decision: %s", record.Decision)
+	}
+}
+
+// evidenceHash returns the hex encoded SHA-384 digest of the attestation evidence of a key transfer
+func evidenceHash(evidence []byte) string {
+	digest := sha512.Sum384(evidence)
+	return hex.EncodeToString(digest[:])
+}
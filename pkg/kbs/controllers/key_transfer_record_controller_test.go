@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/mocks"
+	kbsRoutes "github.com/intel-secl/intel-secl/v5/pkg/kbs/router"
+	consts "github.com/intel-secl/intel-secl/v5/pkg/lib/common/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyTransferRecordController", func() {
+	var router *mux.Router
+	var w *httptest.ResponseRecorder
+	var recordStore *mocks.MockKeyTransferRecordStore
+	var recordController *controllers.KeyTransferRecordController
+
+	keyId := uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		recordStore = mocks.NewFakeKeyTransferRecordStore()
+		recordController = controllers.NewKeyTransferRecordController(recordStore)
+
+		recordStore.Create(&kbs.KeyTransferRecord{
+			KeyId:        keyId,
+			TransferType: constants.EnvelopeKeyTransfer,
+			Decision:     constants.KeyTransferAllowed,
+			CreatedAt:    time.Now().UTC().AddDate(0, 0, -2),
+		})
+		recordStore.Create(&kbs.KeyTransferRecord{
+			KeyId:        keyId,
+			TransferType: constants.SamlKeyTransfer,
+			Decision:     constants.KeyTransferDenied,
+			FaultReason:  "Client not trusted by HVS",
+			CreatedAt:    time.Now().UTC(),
+		})
+		recordStore.Create(&kbs.KeyTransferRecord{
+			KeyId:        uuid.MustParse("87d59b82-33b7-47e7-8fcb-6f7f12c82719"),
+			TransferType: constants.SKCKeyTransfer,
+			Decision:     constants.KeyTransferAllowed,
+			CreatedAt:    time.Now().UTC().AddDate(0, 0, -1),
+		})
+	})
+
+	search := func(query string) []kbs.KeyTransferRecord {
+		router.Handle("/key-transfers", kbsRoutes.ErrorHandler(kbsRoutes.JsonResponseHandler(recordController.Search))).Methods(http.MethodGet)
+		req, err := http.NewRequest(http.MethodGet, "/key-transfers"+query, nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Accept", consts.HTTPMediaTypeJson)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var records []kbs.KeyTransferRecord
+		_ = json.Unmarshal(w.Body.Bytes(), &records)
+		return records
+	}
+
+	// Specs for HTTP Get to "/key-transfers"
+	Describe("Search for the key transfer records", func() {
+		Context("Get all the key transfer records", func() {
+			It("Should get all the records, the most recent first", func() {
+				records := search("")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(records).To(HaveLen(3))
+				Expect(records[0].TransferType).To(Equal(constants.SamlKeyTransfer))
+			})
+		})
+		Context("Get the key transfer records of a Key", func() {
+			It("Should get the records of the Key", func() {
+				records := search("?keyId=ee37c360-7eae-4250-a677-6ee12adce8e2")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(records).To(HaveLen(2))
+			})
+		})
+		Context("Get the denied key transfer records", func() {
+			It("Should get the denied records", func() {
+				records := search("?decision=denied")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].FaultReason).To(Equal("Client not trusted by HVS"))
+			})
+		})
+		Context("Get the key transfer records from a date with a limit", func() {
+			It("Should get the most recent record", func() {
+				records := search("?fromDate=" + time.Now().UTC().AddDate(0, 0, -1).Add(-time.Hour).Format(time.RFC3339Nano) + "&limit=1")
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].TransferType).To(Equal(constants.SamlKeyTransfer))
+			})
+		})
+		Context("Get the key transfer records with an invalid transferType", func() {
+			It("Should fail to get the records with bad request error", func() {
+				search("?transferType=pkcs11")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Get the key transfer records with an invalid fromDate", func() {
+			It("Should fail to get the records with bad request error", func() {
+				search("?fromDate=yesterday")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("Get the key transfer records with an unknown query parameter", func() {
+			It("Should fail to get the records with bad request error", func() {
+				search("?keyID=ee37c360-7eae-4250-a677-6ee12adce8e2")
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -159,6 +159,9 @@ func (sc *SessionController) Create(responseWriter http.ResponseWriter, request
 	}
 
 	sessionObj.SWK = swkKey
+	// the digest of the quote is recorded in the audit records of the key transfers in this session
+	quote, _ := base64.StdEncoding.DecodeString(sessionRequest.Quote)
+	sessionObj.QuoteHash = evidenceHash(quote)
 	keyInfo.SessionMap[sessionRequest.Challenge] = sessionObj
 
 	var respAttr kbs.SessionResponseAttributes
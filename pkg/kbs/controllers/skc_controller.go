@@ -28,14 +28,16 @@ import (
 type SKCController struct {
 	remoteManager    *keymanager.RemoteManager
 	policyStore      domain.KeyTransferPolicyStore
+	recordStore      domain.KeyTransferRecordStore
 	config           *config.Configuration
 	trustedCaCertDir string
 }
 
-func NewSKCController(rm *keymanager.RemoteManager, ps domain.KeyTransferPolicyStore, kc *config.Configuration, caCertDir string) *SKCController {
+func NewSKCController(rm *keymanager.RemoteManager, ps domain.KeyTransferPolicyStore, rs domain.KeyTransferRecordStore, kc *config.Configuration, caCertDir string) *SKCController {
 	return &SKCController{
 		remoteManager:    rm,
 		policyStore:      ps,
+		recordStore:      rs,
 		config:           kc,
 		trustedCaCertDir: caCertDir,
 	}
@@ -45,6 +47,16 @@ func (kc *SKCController) TransferApplicationKey(responseWriter http.ResponseWrit
 	defaultLog.Trace("controllers/skc_controller:TransferApplicationKey() Entering")
 	defer defaultLog.Trace("controllers/skc_controller:TransferApplicationKey() Leaving")
 
+	record := newKeyTransferRecord(request, constants.SKCKeyTransfer)
+	response, status, err := kc.transferApplicationKey(responseWriter, request, record)
+	recordKeyTransfer(kc.recordStore, kc.remoteManager, record, status, err)
+	return response, status, err
+}
+
+func (kc *SKCController) transferApplicationKey(responseWriter http.ResponseWriter, request *http.Request, record *kbs.KeyTransferRecord) (interface{}, int, error) {
+	defaultLog.Trace("controllers/skc_controller:transferApplicationKey() Entering")
+	defer defaultLog.Trace("controllers/skc_controller:transferApplicationKey() Leaving")
+
 	stmChallenge, sessionId, err := validateKeyTransferRequest(request.Header)
 	if err != nil {
 		secLog.WithError(err).Error("controllers/skc_controller:TransferApplicationKey() Invalid transfer request")
@@ -68,12 +80,15 @@ func (kc *SKCController) TransferApplicationKey(responseWriter http.ResponseWrit
 
 	keyInfo.IssuerCommonName = request.TLS.PeerCertificates[0].Issuer.CommonName
 	userCommonName := request.TLS.PeerCertificates[0].Subject.CommonName
+	record.Requester = userCommonName
+	record.RequesterVerified = true
 
 	err = keyInfo.SetUserContext(userCommonName, kc.config, kc.trustedCaCertDir)
 	if err != nil {
 		secLog.WithError(err).Error("controllers/skc_controller:TransferApplicationKey() error while getting common name")
 		return nil, http.StatusBadRequest, &commErr.ResourceError{Message: "Couldn't fetch common name for specified user"}
 	}
+	record.AttestationType = keyInfo.ActiveStmLabel
 
 	key, err := kc.remoteManager.RetrieveKey(keyID)
 	if err != nil {
@@ -85,6 +100,7 @@ func (kc *SKCController) TransferApplicationKey(responseWriter http.ResponseWrit
 			return nil, http.StatusInternalServerError, &commErr.ResourceError{Message: "Failed to retrieve key"}
 		}
 	}
+	record.TransferPolicyId = key.TransferPolicyID
 	transferPolicy, err := kc.policyStore.Retrieve(key.TransferPolicyID)
 	if err != nil {
 		if err.Error() == commErr.RecordNotFound {
@@ -116,6 +132,7 @@ func (kc *SKCController) TransferApplicationKey(responseWriter http.ResponseWrit
 			challenge.Status = constants.FailureStatus
 
 			secLog.Info("controllers/skc_controller:TransferApplicationKey() Unauthorized: Generated Challenge")
+			record.FaultReason = "Session is required, challenge generated"
 			return challenge, http.StatusUnauthorized, nil
 		}
 	}
@@ -137,10 +154,12 @@ func (kc *SKCController) TransferApplicationKey(responseWriter http.ResponseWrit
 			challenge.Status = constants.FailureStatus
 
 			secLog.Info("controllers/skc_controller:TransferApplicationKey() NotFound: sgx attributes verification failed")
+			record.FaultReason = t.Message
 			return challenge, http.StatusNotFound, nil
 		}
 
 		defaultLog.Debug("Session is valid. Hence directly transfer the key")
+		record.EvidenceHash = keyInfo.GetSessionObj(keyInfo.ActiveSessionID).QuoteHash
 		keyData, err := kc.remoteManager.TransferKey(keyID)
 		if err != nil {
 			defaultLog.WithError(err).Error("controllers/skc_controller:TransferApplicationKey() Key retrieve failed")
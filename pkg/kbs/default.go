@@ -32,6 +32,14 @@ func init() {
 	// Set default value for kmip version
 	viper.SetDefault(config.KmipVersion, constants.KMIP_2_0)
 
+	// Set default values for directory key manager
+	viper.SetDefault(config.DirectoryMasterKeyFile, constants.DefaultMasterKeyFile)
+	viper.SetDefault(config.DirectoryMasterKeySealing, constants.DefaultMasterKeySealing)
+
+	// Set default values for key transfer audit
+	viper.SetDefault(config.KeyTransferAuditRetentionDays, constants.DefaultKeyTransferRetentionDays)
+	viper.SetDefault(config.KeyTransferAuditMaxRecords, constants.DefaultKeyTransferMaxRecords)
+
 	// Set default values for server
 	viper.SetDefault(commConfig.ServerPort, constants.DefaultKBSListenerPort)
 	viper.SetDefault(commConfig.ServerReadTimeout, constants.DefaultReadTimeout)
@@ -85,11 +93,19 @@ func defaultConfig() *config.Configuration {
 			ClientCertificateFilePath: viper.GetString("kmip-client-cert-path"),
 			RootCertificateFilePath:   viper.GetString("kmip-root-cert-path"),
 		},
+		Directory: config.DirectoryConfig{
+			MasterKeyFile:    viper.GetString(config.DirectoryMasterKeyFile),
+			MasterKeySealing: viper.GetString(config.DirectoryMasterKeySealing),
+		},
 		Skc: config.SKCConfig{
 			StmLabel:          viper.GetString("skc-challenge-type"),
 			SQVSUrl:           viper.GetString("sqvs-url"),
 			SessionExpiryTime: viper.GetInt("session-expiry-time"),
 		},
+		KeyTransferAudit: config.KeyTransferAuditConfig{
+			RetentionDays: viper.GetInt(config.KeyTransferAuditRetentionDays),
+			MaxRecords:    viper.GetInt(config.KeyTransferAuditMaxRecords),
+		},
 	}
 }
 
@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package directory
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+)
+
+// DeniedKeyTransferAggregator saves the key transfer records to a record store, except the denied transfers of an
+// unverified requester which are aggregated per remote host. The first denied transfer of a remote host is saved,
+// the following denied transfers within the window are counted and saved as a single record at the end of the
+// window. The remote hosts in excess of the maximum are aggregated together.
+type DeniedKeyTransferAggregator struct {
+	domain.KeyTransferRecordStore
+	window   time.Duration
+	maxHosts int
+
+	lock  sync.Mutex
+	hosts map[string]*deniedKeyTransfers
+}
+
+// deniedKeyTransfers are the denied transfers of a remote host following the first transfer of the window
+type deniedKeyTransfers struct {
+	last     *kbs.KeyTransferRecord
+	attempts int
+}
+
+func NewDeniedKeyTransferAggregator(store domain.KeyTransferRecordStore, window time.Duration, maxHosts int) *DeniedKeyTransferAggregator {
+	return &DeniedKeyTransferAggregator{
+		KeyTransferRecordStore: store,
+		window:                 window,
+		maxHosts:               maxHosts,
+		hosts:                  make(map[string]*deniedKeyTransfers),
+	}
+}
+
+func (dkta *DeniedKeyTransferAggregator) Create(record *kbs.KeyTransferRecord) (*kbs.KeyTransferRecord, error) {
+	defaultLog.Trace("directory/denied_key_transfer_aggregator:Create() Entering")
+	defer defaultLog.Trace("directory/denied_key_transfer_aggregator:Create() Leaving")
+
+	if record.Decision != constants.KeyTransferDenied || record.RequesterVerified {
+		return dkta.KeyTransferRecordStore.Create(record)
+	}
+
+	host := record.RemoteAddress
+	if h, _, err := net.SplitHostPort(record.RemoteAddress); err == nil {
+		host = h
+	}
+
+	dkta.lock.Lock()
+	if _, ok := dkta.hosts[host]; !ok && len(dkta.hosts) >= dkta.maxHosts {
+		host = ""
+	}
+	if transfers, ok := dkta.hosts[host]; ok {
+		last := *record
+		transfers.last = &last
+		transfers.attempts++
+		dkta.lock.Unlock()
+		return record, nil
+	}
+	dkta.hosts[host] = &deniedKeyTransfers{}
+	time.AfterFunc(dkta.window, func() {
+		dkta.flush(host)
+	})
+	dkta.lock.Unlock()
+
+	return dkta.KeyTransferRecordStore.Create(record)
+}
+
+// flush saves the denied transfers of a remote host at the end of the window
+func (dkta *DeniedKeyTransferAggregator) flush(host string) {
+	defaultLog.Trace("directory/denied_key_transfer_aggregator:flush() Entering")
+	defer defaultLog.Trace("directory/denied_key_transfer_aggregator:flush() Leaving")
+
+	dkta.lock.Lock()
+	transfers := dkta.hosts[host]
+	delete(dkta.hosts, host)
+	dkta.lock.Unlock()
+
+	if transfers == nil || transfers.attempts == 0 {
+		return
+	}
+	transfers.last.Attempts = transfers.attempts
+	if _, err := dkta.KeyTransferRecordStore.Create(transfers.last); err != nil {
+		defaultLog.WithError(err).Errorf("directory/denied_key_transfer_aggregator:flush() Failed to save %d denied key transfer records of %s", transfers.attempts, host)
+	}
+}
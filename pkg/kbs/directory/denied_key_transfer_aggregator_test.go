@@ -0,0 +1,81 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package directory
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+)
+
+func TestDeniedKeyTransferAggregator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kbs-key-transfers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewKeyTransferRecordStore(dir)
+	aggregator := NewDeniedKeyTransferAggregator(store, 100*time.Millisecond, 2)
+	records := []kbs.KeyTransferRecord{
+		{Decision: constants.KeyTransferAllowed, RemoteAddress: "10.0.0.1:1000", RequesterVerified: true},
+		{Decision: constants.KeyTransferDenied, RemoteAddress: "10.0.0.1:1000", RequesterVerified: true},
+		{Decision: constants.KeyTransferDenied, RemoteAddress: "10.0.0.1:1001"},
+		{Decision: constants.KeyTransferDenied, RemoteAddress: "10.0.0.1:1002"},
+		{Decision: constants.KeyTransferDenied, RemoteAddress: "10.0.0.1:1003", FaultReason: "last"},
+		{Decision: constants.KeyTransferDenied, RemoteAddress: "10.0.0.2:1000"},
+		// the remote hosts in excess of the maximum are aggregated together
+		{Decision: constants.KeyTransferDenied, RemoteAddress: "10.0.0.3:1000"},
+		{Decision: constants.KeyTransferDenied, RemoteAddress: "10.0.0.4:1000"},
+	}
+	for i := range records {
+		if _, err := aggregator.Create(&records[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saved, err := store.Search(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 5 {
+		t.Fatalf("DeniedKeyTransferAggregator saved %d records within the window, want 5", len(saved))
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	saved, err = store.Search(&models.KeyTransferRecordFilterCriteria{Decision: constants.KeyTransferDenied})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attempts := map[string]int{}
+	for _, record := range saved {
+		if record.Attempts > 0 {
+			attempts[record.RemoteAddress] = record.Attempts
+			if record.RemoteAddress == "10.0.0.1:1003" && record.FaultReason != "last" {
+				t.Errorf("DeniedKeyTransferAggregator saved fault reason %q, want the last one", record.FaultReason)
+			}
+		}
+	}
+	if len(saved) != 6 || len(attempts) != 2 || attempts["10.0.0.1:1003"] != 2 || attempts["10.0.0.4:1000"] != 1 {
+		t.Errorf("DeniedKeyTransferAggregator saved %d denied records with attempts %v, want 6 with 2 attempts of 10.0.0.1 and 1 of 10.0.0.4", len(saved), attempts)
+	}
+
+	// a new window starts once the denied transfers are saved
+	if _, err := aggregator.Create(&kbs.KeyTransferRecord{Decision: constants.KeyTransferDenied, RemoteAddress: "10.0.0.1:1004"}); err != nil {
+		t.Fatal(err)
+	}
+	saved, err = store.Search(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 8 || saved[0].RemoteAddress != "10.0.0.1:1004" {
+		t.Errorf("DeniedKeyTransferAggregator saved %d records, want 8 with the new window", len(saved))
+	}
+}
@@ -0,0 +1,297 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package directory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+	"github.com/pkg/errors"
+)
+
+const (
+	// the key transfer records are appended to a file per day, one JSON record per line
+	recordFileDateLayout = "2006-01-02"
+	recordFileExtension  = ".jsonl"
+)
+
+// recordFilesLock serializes the writes to the key transfer record files, the record stores of all routes share the
+// same directory
+var recordFilesLock sync.Mutex
+
+// KeyTransferRecordStore appends the key transfer records to a file per day of creation. The records are only
+// rewritten by Prune, a search reads the files of the days matching its filter criteria.
+type KeyTransferRecordStore struct {
+	dir string
+}
+
+func NewKeyTransferRecordStore(dir string) *KeyTransferRecordStore {
+	return &KeyTransferRecordStore{dir}
+}
+
+// recordFile is a file of the key transfer records created on a day
+type recordFile struct {
+	path string
+	day  time.Time
+}
+
+func (ktrs *KeyTransferRecordStore) Create(record *kbs.KeyTransferRecord) (*kbs.KeyTransferRecord, error) {
+	defaultLog.Trace("directory/key_transfer_record_store:Create() Entering")
+	defer defaultLog.Trace("directory/key_transfer_record_store:Create() Leaving")
+
+	newUuid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "directory/key_transfer_record_store:Create() failed to create new UUID")
+	}
+	record.ID = newUuid
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now().UTC()
+	}
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return nil, errors.Wrap(err, "directory/key_transfer_record_store:Create() Failed to marshal key transfer record")
+	}
+
+	recordFilesLock.Lock()
+	defer recordFilesLock.Unlock()
+	path := filepath.Join(ktrs.dir, record.CreatedAt.UTC().Format(recordFileDateLayout)+recordFileExtension)
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "directory/key_transfer_record_store:Create() Error in opening key transfer record file")
+	}
+	_, err = file.Write(append(bytes, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "directory/key_transfer_record_store:Create() Error in saving key transfer record")
+	}
+
+	return record, nil
+}
+
+// Search returns the key transfer records matching the filter criteria, the most recent record first. Only the files
+// of the days between the from and to dates are read, from the most recent day until the limit is reached.
+func (ktrs *KeyTransferRecordStore) Search(criteria *models.KeyTransferRecordFilterCriteria) ([]kbs.KeyTransferRecord, error) {
+	defaultLog.Trace("directory/key_transfer_record_store:Search() Entering")
+	defer defaultLog.Trace("directory/key_transfer_record_store:Search() Leaving")
+
+	if criteria == nil {
+		criteria = &models.KeyTransferRecordFilterCriteria{}
+	}
+	recordFiles, err := ktrs.recordFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var records = []kbs.KeyTransferRecord{}
+	for _, recordFile := range recordFiles {
+		if !criteria.ToDate.IsZero() && recordFile.day.After(criteria.ToDate) {
+			continue
+		}
+		// the remaining files are of the days before the from date
+		if !criteria.FromDate.IsZero() && !recordFile.day.AddDate(0, 0, 1).After(criteria.FromDate) {
+			break
+		}
+
+		dayRecords, _, err := readRecordFile(recordFile.path)
+		if err != nil {
+			return nil, err
+		}
+		for i := range dayRecords {
+			if matchesKeyTransferRecord(&dayRecords[i], criteria) {
+				records = append(records, dayRecords[i])
+			}
+		}
+		// the records of the remaining files are older than the records found
+		if criteria.Limit > 0 && len(records) >= criteria.Limit {
+			break
+		}
+	}
+
+	sortKeyTransferRecords(records)
+	if criteria.Limit > 0 && len(records) > criteria.Limit {
+		records = records[:criteria.Limit]
+	}
+	return records, nil
+}
+
+// Prune deletes the key transfer records created before the given time and the oldest records in excess of the
+// maximum number of records, a zero time or maximum disables the limit. The files of the days that are entirely
+// pruned are removed without being read, only the files at the limits are rewritten. It returns the number of
+// deleted records.
+func (ktrs *KeyTransferRecordStore) Prune(createdBefore time.Time, maxRecords int) (int, error) {
+	defaultLog.Trace("directory/key_transfer_record_store:Prune() Entering")
+	defer defaultLog.Trace("directory/key_transfer_record_store:Prune() Leaving")
+
+	recordFilesLock.Lock()
+	defer recordFilesLock.Unlock()
+	recordFiles, err := ktrs.recordFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, kept := 0, 0
+	for _, recordFile := range recordFiles {
+		expired := !createdBefore.IsZero() && !recordFile.day.AddDate(0, 0, 1).After(createdBefore)
+		if expired || (maxRecords > 0 && kept >= maxRecords) {
+			content, err := ioutil.ReadFile(recordFile.path)
+			if err != nil {
+				return deleted, errors.Wrapf(err, "directory/key_transfer_record_store:Prune() Error in reading key transfer record file : %s", recordFile.path)
+			}
+			if err := os.Remove(recordFile.path); err != nil {
+				return deleted, errors.Wrapf(err, "directory/key_transfer_record_store:Prune() Error in removing key transfer record file : %s", recordFile.path)
+			}
+			deleted += bytes.Count(content, []byte{'\n'})
+			continue
+		}
+
+		records, invalid, err := readRecordFile(recordFile.path)
+		if err != nil {
+			return deleted, err
+		}
+		sortKeyTransferRecords(records)
+		var keptRecords []kbs.KeyTransferRecord
+		for _, record := range records {
+			if (!createdBefore.IsZero() && record.CreatedAt.Before(createdBefore)) || (maxRecords > 0 && kept >= maxRecords) {
+				deleted++
+				continue
+			}
+			keptRecords = append(keptRecords, record)
+			kept++
+		}
+		if len(keptRecords) < len(records) || invalid > 0 {
+			if err := writeRecordFile(recordFile.path, keptRecords); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	return deleted, nil
+}
+
+// recordFiles lists the key transfer record files, the most recent day first. The files that are not named after a
+// day are skipped.
+func (ktrs *KeyTransferRecordStore) recordFiles() ([]recordFile, error) {
+	fileInfos, err := ioutil.ReadDir(ktrs.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "directory/key_transfer_record_store:recordFiles() Error in reading the key transfer records directory : %s", ktrs.dir)
+	}
+
+	var recordFiles []recordFile
+	for _, fileInfo := range fileInfos {
+		day, err := time.Parse(recordFileDateLayout, strings.TrimSuffix(fileInfo.Name(), recordFileExtension))
+		if fileInfo.IsDir() || !strings.HasSuffix(fileInfo.Name(), recordFileExtension) || err != nil {
+			defaultLog.Warnf("directory/key_transfer_record_store:recordFiles() Skipping invalid key transfer record file : %s", fileInfo.Name())
+			continue
+		}
+		recordFiles = append(recordFiles, recordFile{path: filepath.Join(ktrs.dir, fileInfo.Name()), day: day})
+	}
+	sort.Slice(recordFiles, func(i, j int) bool {
+		return recordFiles[i].day.After(recordFiles[j].day)
+	})
+	return recordFiles, nil
+}
+
+// readRecordFile returns the key transfer records of a file along with the number of invalid records, which are
+// skipped. A file removed by a concurrent prune has no records.
+func readRecordFile(path string) ([]kbs.KeyTransferRecord, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, errors.Wrapf(err, "directory/key_transfer_record_store:readRecordFile() Unable to read key transfer record file : %s", path)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			defaultLog.WithError(err).Errorf("directory/key_transfer_record_store:readRecordFile() Error closing key transfer record file : %s", path)
+		}
+	}()
+
+	var records []kbs.KeyTransferRecord
+	invalid := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record kbs.KeyTransferRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			defaultLog.WithError(err).Warnf("directory/key_transfer_record_store:readRecordFile() Skipping invalid key transfer record in file : %s", path)
+			invalid++
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, errors.Wrapf(err, "directory/key_transfer_record_store:readRecordFile() Unable to read key transfer record file : %s", path)
+	}
+	return records, invalid, nil
+}
+
+// writeRecordFile replaces the key transfer records of a file, the file is removed when no record is left
+func writeRecordFile(path string, records []kbs.KeyTransferRecord) error {
+	if len(records) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "directory/key_transfer_record_store:writeRecordFile() Error in removing key transfer record file : %s", path)
+		}
+		return nil
+	}
+
+	var content bytes.Buffer
+	for i := len(records) - 1; i >= 0; i-- {
+		line, err := json.Marshal(records[i])
+		if err != nil {
+			return errors.Wrap(err, "directory/key_transfer_record_store:writeRecordFile() Failed to marshal key transfer record")
+		}
+		content.Write(append(line, '\n'))
+	}
+	if err := ioutil.WriteFile(path+".tmp", content.Bytes(), 0600); err != nil {
+		return errors.Wrapf(err, "directory/key_transfer_record_store:writeRecordFile() Error in writing key transfer record file : %s", path)
+	}
+	return errors.Wrapf(os.Rename(path+".tmp", path), "directory/key_transfer_record_store:writeRecordFile() Error in replacing key transfer record file : %s", path)
+}
+
+// helper function to sort the key transfer records by creation time with the most recent record first
+func sortKeyTransferRecords(records []kbs.KeyTransferRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+}
+
+// helper function to match a key transfer record against the given filter criteria
+func matchesKeyTransferRecord(record *kbs.KeyTransferRecord, criteria *models.KeyTransferRecordFilterCriteria) bool {
+	if criteria.KeyId != uuid.Nil && record.KeyId != criteria.KeyId {
+		return false
+	}
+	if criteria.TransferType != "" && record.TransferType != criteria.TransferType {
+		return false
+	}
+	if criteria.Requester != "" && record.Requester != criteria.Requester {
+		return false
+	}
+	if criteria.TransferPolicyId != uuid.Nil && record.TransferPolicyId != criteria.TransferPolicyId {
+		return false
+	}
+	if criteria.Decision != "" && record.Decision != criteria.Decision {
+		return false
+	}
+	if !criteria.FromDate.IsZero() && record.CreatedAt.Before(criteria.FromDate) {
+		return false
+	}
+	if !criteria.ToDate.IsZero() && record.CreatedAt.After(criteria.ToDate) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package directory
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+)
+
+func TestKeyTransferRecordStoreSearch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kbs-key-transfers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewKeyTransferRecordStore(dir)
+	now := time.Now().UTC()
+	ages := map[uuid.UUID]int{}
+	for _, age := range []int{40, 0, 90, 10} {
+		createdAt := now.AddDate(0, 0, -age)
+		record, err := store.Create(&kbs.KeyTransferRecord{Decision: "allowed", CreatedAt: createdAt})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ages[record.ID] = age
+	}
+	// the invalid files and records are skipped
+	if err := ioutil.WriteFile(filepath.Join(dir, "not-a-record"), []byte("{}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	appendLine(t, filepath.Join(dir, now.Format(recordFileDateLayout)+recordFileExtension), "{")
+
+	tests := []struct {
+		name     string
+		criteria *models.KeyTransferRecordFilterCriteria
+		want     []int
+	}{
+		{
+			name:     "all records",
+			criteria: nil,
+			want:     []int{0, 10, 40, 90},
+		},
+		{
+			name:     "limit",
+			criteria: &models.KeyTransferRecordFilterCriteria{Limit: 2},
+			want:     []int{0, 10},
+		},
+		{
+			name:     "from date",
+			criteria: &models.KeyTransferRecordFilterCriteria{FromDate: now.AddDate(0, 0, -50)},
+			want:     []int{0, 10, 40},
+		},
+		{
+			name:     "to date and limit",
+			criteria: &models.KeyTransferRecordFilterCriteria{ToDate: now.AddDate(0, 0, -5), Limit: 1},
+			want:     []int{10},
+		},
+		{
+			name:     "no match",
+			criteria: &models.KeyTransferRecordFilterCriteria{Decision: "denied"},
+			want:     []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := store.Search(tt.criteria)
+			if err != nil {
+				t.Fatalf("KeyTransferRecordStore.Search() error = %v", err)
+			}
+			if len(records) != len(tt.want) {
+				t.Fatalf("KeyTransferRecordStore.Search() returned %d records, want %d", len(records), len(tt.want))
+			}
+			for i, record := range records {
+				if ages[record.ID] != tt.want[i] {
+					t.Errorf("KeyTransferRecordStore.Search() returned record of age %d, want %d", ages[record.ID], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestKeyTransferRecordStorePrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kbs-key-transfers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewKeyTransferRecordStore(dir)
+	now := time.Now().UTC()
+	for _, age := range []int{0, 0, 10, 10, 40, 90} {
+		if _, err := store.Create(&kbs.KeyTransferRecord{Decision: "allowed", CreatedAt: now.AddDate(0, 0, -age)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	appendLine(t, filepath.Join(dir, now.AddDate(0, 0, -90).Format(recordFileDateLayout)+recordFileExtension), "{")
+
+	// the file of the 90 days old records is removed along with its invalid record
+	deleted, err := store.Prune(now.AddDate(0, 0, -60), 0)
+	if err != nil {
+		t.Fatalf("KeyTransferRecordStore.Prune() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("KeyTransferRecordStore.Prune() deleted %d records, want 2", deleted)
+	}
+
+	// the file of the 10 days old records is rewritten with one record
+	deleted, err = store.Prune(time.Time{}, 3)
+	if err != nil {
+		t.Fatalf("KeyTransferRecordStore.Prune() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("KeyTransferRecordStore.Prune() deleted %d records, want 2", deleted)
+	}
+
+	records, err := store.Search(nil)
+	if err != nil {
+		t.Fatalf("KeyTransferRecordStore.Search() error = %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("KeyTransferRecordStore.Search() returned %d records, want 3", len(records))
+	}
+	if records[2].CreatedAt.After(now.AddDate(0, 0, -5)) {
+		t.Errorf("KeyTransferRecordStore.Search() returned %v as oldest record, want a 10 days old record", records[2].CreatedAt)
+	}
+	recordFiles, err := store.recordFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordFiles) != 2 {
+		t.Errorf("KeyTransferRecordStore kept %d record files, want 2", len(recordFiles))
+	}
+}
+
+func appendLine(t *testing.T, path, line string) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(line + "\n"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -5,6 +5,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
 	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
@@ -26,6 +28,12 @@ type (
 		Search(criteria *models.KeyTransferPolicyFilterCriteria) ([]kbs.KeyTransferPolicy, error)
 	}
 
+	KeyTransferRecordStore interface {
+		Create(record *kbs.KeyTransferRecord) (*kbs.KeyTransferRecord, error)
+		Prune(createdBefore time.Time, maxRecords int) (int, error)
+		Search(criteria *models.KeyTransferRecordFilterCriteria) ([]kbs.KeyTransferRecord, error)
+	}
+
 	CertificateStore interface {
 		Create(certificate *kbs.Certificate) (*kbs.Certificate, error)
 		Retrieve(uuid.UUID) (*kbs.Certificate, error)
@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+)
+
+// MockKeyTransferRecordStore provides a mocked implementation of interface domain.KeyTransferRecordStore
+type MockKeyTransferRecordStore struct {
+	KeyTransferRecordStore map[uuid.UUID]*kbs.KeyTransferRecord
+}
+
+// Create inserts a KeyTransferRecord into the store
+func (store *MockKeyTransferRecordStore) Create(r *kbs.KeyTransferRecord) (*kbs.KeyTransferRecord, error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now().UTC()
+	}
+	store.KeyTransferRecordStore[r.ID] = r
+	return r, nil
+}
+
+// Prune deletes the KeyTransferRecords created before the given time and the oldest records in excess of the maximum
+func (store *MockKeyTransferRecordStore) Prune(createdBefore time.Time, maxRecords int) (int, error) {
+	records, _ := store.Search(nil)
+	deleted := 0
+	for i, r := range records {
+		if (!createdBefore.IsZero() && r.CreatedAt.Before(createdBefore)) || (maxRecords > 0 && i >= maxRecords) {
+			delete(store.KeyTransferRecordStore, r.ID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// Search returns a filtered list of KeyTransferRecords per the provided KeyTransferRecordFilterCriteria
+func (store *MockKeyTransferRecordStore) Search(criteria *models.KeyTransferRecordFilterCriteria) ([]kbs.KeyTransferRecord, error) {
+
+	var records []kbs.KeyTransferRecord
+	// start with all records
+	for _, r := range store.KeyTransferRecordStore {
+		records = append(records, *r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+
+	// KeyTransferRecord filter is false
+	if criteria == nil || reflect.DeepEqual(*criteria, models.KeyTransferRecordFilterCriteria{}) {
+		return records, nil
+	}
+
+	var rFiltered []kbs.KeyTransferRecord
+	for _, r := range records {
+		if (criteria.KeyId == uuid.Nil || r.KeyId == criteria.KeyId) &&
+			(criteria.TransferType == "" || r.TransferType == criteria.TransferType) &&
+			(criteria.Requester == "" || r.Requester == criteria.Requester) &&
+			(criteria.TransferPolicyId == uuid.Nil || r.TransferPolicyId == criteria.TransferPolicyId) &&
+			(criteria.Decision == "" || r.Decision == criteria.Decision) &&
+			(criteria.FromDate.IsZero() || !r.CreatedAt.Before(criteria.FromDate)) &&
+			(criteria.ToDate.IsZero() || !r.CreatedAt.After(criteria.ToDate)) {
+			rFiltered = append(rFiltered, r)
+		}
+	}
+	if criteria.Limit > 0 && len(rFiltered) > criteria.Limit {
+		rFiltered = rFiltered[:criteria.Limit]
+	}
+	return rFiltered, nil
+}
+
+// NewFakeKeyTransferRecordStore provides an empty MockKeyTransferRecordStore
+func NewFakeKeyTransferRecordStore() *MockKeyTransferRecordStore {
+	return &MockKeyTransferRecordStore{KeyTransferRecordStore: make(map[uuid.UUID]*kbs.KeyTransferRecord)}
+}
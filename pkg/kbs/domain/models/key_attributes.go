@@ -26,6 +26,38 @@ type KeyAttributes struct {
 	CreatedAt        time.Time `json:"created_at,omitempty"`
 	Label            string    `json:"label,omitempty"`
 	Usage            string    `json:"usage,omitempty"`
+	// Registered is set for the keys registered with their key material, these keys are not rotated
+	Registered bool `json:"registered,omitempty"`
+	// Version is the current version of a rotated key, the versions before the rotations are kept in
+	// PreviousVersions to transfer the keys of existing content
+	Version          int          `json:"version,omitempty"`
+	PreviousVersions []KeyVersion `json:"previous_versions,omitempty"`
+}
+
+// KeyVersion - Key material of a version replaced by a key rotation.
+type KeyVersion struct {
+	Version   int       `json:"version"`
+	KeyData   string    `json:"key,omitempty"`
+	PublicKey string    `json:"public_key,omitempty"`
+	KmipKeyID string    `json:"kmip_key_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	RotatedAt time.Time `json:"rotated_at"`
+}
+
+// CurrentVersion returns the version of the current key material, keys that were never rotated are at version 1
+func (ka *KeyAttributes) CurrentVersion() int {
+	if ka.Version < 1 {
+		return 1
+	}
+	return ka.Version
+}
+
+// CurrentVersionCreatedAt returns the time the current key material was created at
+func (ka *KeyAttributes) CurrentVersionCreatedAt() time.Time {
+	if len(ka.PreviousVersions) == 0 {
+		return ka.CreatedAt
+	}
+	return ka.PreviousVersions[len(ka.PreviousVersions)-1].RotatedAt
 }
 
 func (ka *KeyAttributes) ToKeyResponse() *kbs.KeyResponse {
@@ -45,6 +77,24 @@ func (ka *KeyAttributes) ToKeyResponse() *kbs.KeyResponse {
 		CreatedAt:        ka.CreatedAt,
 		Label:            ka.Label,
 		Usage:            ka.Usage,
+		Version:          ka.CurrentVersion(),
+	}
+
+	if len(ka.PreviousVersions) > 0 {
+		for _, version := range ka.PreviousVersions {
+			rotatedAt := version.RotatedAt
+			keyResponse.Versions = append(keyResponse.Versions, kbs.KeyVersion{
+				Version:   version.Version,
+				KmipKeyID: version.KmipKeyID,
+				CreatedAt: version.CreatedAt,
+				RotatedAt: &rotatedAt,
+			})
+		}
+		keyResponse.Versions = append(keyResponse.Versions, kbs.KeyVersion{
+			Version:   ka.CurrentVersion(),
+			KmipKeyID: ka.KmipKeyID,
+			CreatedAt: ka.CurrentVersionCreatedAt(),
+		})
 	}
 
 	return &keyResponse
@@ -0,0 +1,23 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KeyTransferRecordFilterCriteria stores the parameters for filtering the key transfer records
+type KeyTransferRecordFilterCriteria struct {
+	KeyId            uuid.UUID
+	TransferType     string
+	Requester        string
+	TransferPolicyId uuid.UUID
+	Decision         string
+	FromDate         time.Time
+	ToDate           time.Time
+	Limit            int
+}
@@ -57,6 +57,19 @@ type CreateKeyPairResponsePayload struct {
 	PublicKeyUniqueIdentifier  string
 }
 
+// RegisterRequestPayload used to construct REGISTER request operation
+type RegisterRequestPayload struct {
+	ObjectType   kmip20.ObjectType
+	Attributes   Attributes
+	SymmetricKey *kmip.SymmetricKey
+	PrivateKey   *kmip.PrivateKey
+}
+
+// RegisterResponsePayload to receive response of REGISTER operation
+type RegisterResponsePayload struct {
+	UniqueIdentifier string
+}
+
 // GetRequestPayload used to construct GET request operation
 type GetRequestPayload struct {
 	UniqueIdentifier kmip20.UniqueIdentifierValue
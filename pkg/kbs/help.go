@@ -16,6 +16,7 @@ const helpStr = `Usage:
 Available Commands:
 	help|-h|--help         Show this help message
 	version|-v|--version   Show the version of current kbs build
+	migrate-keys           Migrate the keys of directory key manager to the configured KMIP server
 	setup <task>           Run setup task
 	start                  Start kbs
 	status                 Show the status of kbs
@@ -0,0 +1,239 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package keymanager
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+	"github.com/pkg/errors"
+)
+
+var curves = map[string]elliptic.Curve{
+	"secp256r1":  elliptic.P256(),
+	"prime256v1": elliptic.P256(),
+	"secp384r1":  elliptic.P384(),
+	"secp521r1":  elliptic.P521(),
+}
+
+// DirectoryManager generates and keeps the key material locally. The key material is stored in the key
+// attributes encrypted with AES-GCM under the master key, the ID of the key is used as additional data so
+// that the encrypted key material cannot be moved to another key.
+type DirectoryManager struct {
+	aead cipher.AEAD
+}
+
+func NewDirectoryManager(masterKey []byte) (*DirectoryManager, error) {
+	if len(masterKey) != constants.MasterKeyLength {
+		return nil, errors.Errorf("master key must be %d bytes long", constants.MasterKeyLength)
+	}
+	block, err := aes.NewCipher(masterKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize master key cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize master key cipher")
+	}
+	return &DirectoryManager{aead}, nil
+}
+
+func (dm *DirectoryManager) CreateKey(request *kbs.KeyRequest) (*models.KeyAttributes, error) {
+	defaultLog.Trace("keymanager/directory_key_manager:CreateKey() Entering")
+	defer defaultLog.Trace("keymanager/directory_key_manager:CreateKey() Leaving")
+
+	keyAttributes, err := newKeyAttributes(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyMaterial []byte
+	var publicKey crypto.PublicKey
+	switch request.KeyInformation.Algorithm {
+	case constants.CRYPTOALG_AES:
+		keyMaterial = make([]byte, request.KeyInformation.KeyLength/8)
+		if _, err := rand.Read(keyMaterial); err != nil {
+			return nil, errors.Wrap(err, "failed to create AES key")
+		}
+		keyAttributes.KeyLength = request.KeyInformation.KeyLength
+	case constants.CRYPTOALG_RSA:
+		privateKey, err := rsa.GenerateKey(rand.Reader, request.KeyInformation.KeyLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create RSA key pair")
+		}
+		keyMaterial = x509.MarshalPKCS1PrivateKey(privateKey)
+		publicKey = &privateKey.PublicKey
+		keyAttributes.KeyLength = request.KeyInformation.KeyLength
+	case constants.CRYPTOALG_EC:
+		curve, ok := curves[request.KeyInformation.CurveType]
+		if !ok {
+			return nil, errors.Errorf("%s curve is not supported", request.KeyInformation.CurveType)
+		}
+		privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create EC key pair")
+		}
+		keyMaterial, err = x509.MarshalECPrivateKey(privateKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal EC private key")
+		}
+		publicKey = &privateKey.PublicKey
+		keyAttributes.CurveType = request.KeyInformation.CurveType
+	default:
+		return nil, errors.Errorf("%s algorithm is not supported", request.KeyInformation.Algorithm)
+	}
+
+	return keyAttributes, dm.setKeyMaterial(keyAttributes, keyMaterial, publicKey)
+}
+
+func (dm *DirectoryManager) DeleteKey(attributes *models.KeyAttributes) error {
+	defaultLog.Trace("keymanager/directory_key_manager:DeleteKey() Entering")
+	defer defaultLog.Trace("keymanager/directory_key_manager:DeleteKey() Leaving")
+
+	// the key material is removed along with the key attributes
+	if attributes.KeyData == "" {
+		return errors.New("key is not created with directory key manager")
+	}
+	return nil
+}
+
+func (dm *DirectoryManager) RegisterKey(request *kbs.KeyRequest) (*models.KeyAttributes, error) {
+	defaultLog.Trace("keymanager/directory_key_manager:RegisterKey() Entering")
+	defer defaultLog.Trace("keymanager/directory_key_manager:RegisterKey() Leaving")
+
+	if request.KeyInformation.KeyString == "" {
+		return nil, errors.New("key_string cannot be empty for register operation in directory mode")
+	}
+	block, _ := pem.Decode([]byte(request.KeyInformation.KeyString))
+	if block == nil {
+		return nil, errors.New("key_string must be PEM formatted")
+	}
+
+	keyAttributes, err := newKeyAttributes(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyMaterial []byte
+	var publicKey crypto.PublicKey
+	switch request.KeyInformation.Algorithm {
+	case constants.CRYPTOALG_AES:
+		if len(block.Bytes)*8 != request.KeyInformation.KeyLength {
+			return nil, errors.New("key_string does not match key_length")
+		}
+		keyMaterial = block.Bytes
+		keyAttributes.KeyLength = request.KeyInformation.KeyLength
+	case constants.CRYPTOALG_RSA:
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			key, pkcs8Err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if privateKey, _ = key.(*rsa.PrivateKey); pkcs8Err != nil || privateKey == nil {
+				return nil, errors.New("key_string is not a RSA private key")
+			}
+		}
+		if privateKey.N.BitLen() != request.KeyInformation.KeyLength {
+			return nil, errors.New("key_string does not match key_length")
+		}
+		keyMaterial = x509.MarshalPKCS1PrivateKey(privateKey)
+		publicKey = &privateKey.PublicKey
+		keyAttributes.KeyLength = request.KeyInformation.KeyLength
+	case constants.CRYPTOALG_EC:
+		privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			key, pkcs8Err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if privateKey, _ = key.(*ecdsa.PrivateKey); pkcs8Err != nil || privateKey == nil {
+				return nil, errors.New("key_string is not an EC private key")
+			}
+		}
+		if curve, ok := curves[request.KeyInformation.CurveType]; !ok || curve != privateKey.Curve {
+			return nil, errors.New("key_string does not match curve_type")
+		}
+		keyMaterial, err = x509.MarshalECPrivateKey(privateKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal EC private key")
+		}
+		publicKey = &privateKey.PublicKey
+		keyAttributes.CurveType = request.KeyInformation.CurveType
+	default:
+		return nil, errors.Errorf("%s algorithm is not supported", request.KeyInformation.Algorithm)
+	}
+
+	return keyAttributes, dm.setKeyMaterial(keyAttributes, keyMaterial, publicKey)
+}
+
+// TransferKey returns the raw AES key, the PKCS #1 DER RSA private key or the SEC 1 DER EC private key
+func (dm *DirectoryManager) TransferKey(attributes *models.KeyAttributes) ([]byte, error) {
+	defaultLog.Trace("keymanager/directory_key_manager:TransferKey() Entering")
+	defer defaultLog.Trace("keymanager/directory_key_manager:TransferKey() Leaving")
+
+	if attributes.KeyData == "" {
+		return nil, errors.New("key is not created with directory key manager")
+	}
+
+	sealedKey, err := base64.StdEncoding.DecodeString(attributes.KeyData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode key material")
+	}
+	nonceSize := dm.aead.NonceSize()
+	if len(sealedKey) < nonceSize {
+		return nil, errors.New("invalid key material")
+	}
+	keyMaterial, err := dm.aead.Open(nil, sealedKey[:nonceSize], sealedKey[nonceSize:], attributes.ID[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt key material")
+	}
+	return keyMaterial, nil
+}
+
+func (dm *DirectoryManager) setKeyMaterial(attributes *models.KeyAttributes, keyMaterial []byte, publicKey crypto.PublicKey) error {
+	nonce := make([]byte, dm.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return errors.Wrap(err, "failed to generate nonce")
+	}
+	sealedKey := dm.aead.Seal(nonce, nonce, keyMaterial, attributes.ID[:])
+	attributes.KeyData = base64.StdEncoding.EncodeToString(sealedKey)
+
+	if publicKey != nil {
+		publicKeyDer, err := x509.MarshalPKIXPublicKey(publicKey)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal public key")
+		}
+		attributes.PublicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDer}))
+	}
+	return nil
+}
+
+// newKeyAttributes keeps the ID of the request, it is provided for a new version of a rotated key
+func newKeyAttributes(request *kbs.KeyRequest) (*models.KeyAttributes, error) {
+	id := request.KeyInformation.ID
+	if id == uuid.Nil {
+		newUuid, err := uuid.NewRandom()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create new UUID")
+		}
+		id = newUuid
+	}
+	return &models.KeyAttributes{
+		ID:               id,
+		Algorithm:        request.KeyInformation.Algorithm,
+		TransferPolicyId: request.TransferPolicyID,
+		CreatedAt:        time.Now().UTC(),
+		Label:            request.Label,
+		Usage:            request.Usage,
+	}, nil
+}
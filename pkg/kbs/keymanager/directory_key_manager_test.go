@@ -0,0 +1,236 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package keymanager
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+)
+
+func newTestDirectoryManager(t *testing.T) *DirectoryManager {
+	masterKey := make([]byte, constants.MasterKeyLength)
+	if _, err := rand.Read(masterKey); err != nil {
+		t.Fatal(err)
+	}
+	dm, err := NewDirectoryManager(masterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dm
+}
+
+func TestDirectoryManagerCreateAndTransferKey(t *testing.T) {
+	dm := newTestDirectoryManager(t)
+
+	tests := []struct {
+		name    string
+		keyInfo *kbs.KeyInformation
+		wantErr bool
+	}{
+		{
+			name:    "create AES key",
+			keyInfo: &kbs.KeyInformation{Algorithm: "AES", KeyLength: 256},
+		},
+		{
+			name:    "create RSA key pair",
+			keyInfo: &kbs.KeyInformation{Algorithm: "RSA", KeyLength: 2048},
+		},
+		{
+			name:    "create EC key pair",
+			keyInfo: &kbs.KeyInformation{Algorithm: "EC", CurveType: "secp384r1"},
+		},
+		{
+			name:    "negative test - curve not supported",
+			keyInfo: &kbs.KeyInformation{Algorithm: "EC", CurveType: "secp192r1"},
+			wantErr: true,
+		},
+		{
+			name:    "negative test - algorithm not supported",
+			keyInfo: &kbs.KeyInformation{Algorithm: "ECB", KeyLength: 256},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes, err := dm.CreateKey(&kbs.KeyRequest{KeyInformation: tt.keyInfo})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DirectoryManager.CreateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if attributes.KeyData == "" || attributes.KmipKeyID != "" {
+				t.Fatalf("DirectoryManager.CreateKey() key material is not stored locally")
+			}
+
+			keyMaterial, err := dm.TransferKey(attributes)
+			if err != nil {
+				t.Fatalf("DirectoryManager.TransferKey() error = %v", err)
+			}
+			switch tt.keyInfo.Algorithm {
+			case "AES":
+				if len(keyMaterial) != tt.keyInfo.KeyLength/8 {
+					t.Errorf("DirectoryManager.TransferKey() AES key length = %d", len(keyMaterial)*8)
+				}
+			case "RSA":
+				privateKey, err := x509.ParsePKCS1PrivateKey(keyMaterial)
+				if err != nil || privateKey.N.BitLen() != tt.keyInfo.KeyLength {
+					t.Errorf("DirectoryManager.TransferKey() invalid RSA private key, error = %v", err)
+				}
+			case "EC":
+				privateKey, err := x509.ParseECPrivateKey(keyMaterial)
+				if err != nil || privateKey.Curve != elliptic.P384() {
+					t.Errorf("DirectoryManager.TransferKey() invalid EC private key, error = %v", err)
+				}
+				if attributes.PublicKey == "" {
+					t.Errorf("DirectoryManager.CreateKey() public key is not set")
+				}
+			}
+		})
+	}
+}
+
+func TestDirectoryManagerRegisterKey(t *testing.T) {
+	dm := newTestDirectoryManager(t)
+
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ecPkcs8, _ := x509.MarshalPKCS8PrivateKey(ecKey)
+	aesKey := bytes.Repeat([]byte{0x2a}, 32)
+
+	tests := []struct {
+		name    string
+		keyInfo *kbs.KeyInformation
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "register AES key",
+			keyInfo: &kbs.KeyInformation{Algorithm: "AES", KeyLength: 256,
+				KeyString: string(pem.EncodeToMemory(&pem.Block{Type: "AES KEY", Bytes: aesKey}))},
+			want: aesKey,
+		},
+		{
+			name:    "register PKCS #1 RSA private key",
+			keyInfo: &kbs.KeyInformation{Algorithm: "RSA", KeyLength: 2048, KeyString: privateKey},
+		},
+		{
+			name: "register PKCS #8 EC private key",
+			keyInfo: &kbs.KeyInformation{Algorithm: "EC", CurveType: "prime256v1",
+				KeyString: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPkcs8}))},
+		},
+		{
+			name:    "negative test - key length mismatch",
+			keyInfo: &kbs.KeyInformation{Algorithm: "RSA", KeyLength: 3072, KeyString: privateKey},
+			wantErr: true,
+		},
+		{
+			name: "negative test - curve mismatch",
+			keyInfo: &kbs.KeyInformation{Algorithm: "EC", CurveType: "secp521r1",
+				KeyString: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPkcs8}))},
+			wantErr: true,
+		},
+		{
+			name:    "negative test - kmip key id",
+			keyInfo: &kbs.KeyInformation{Algorithm: "AES", KeyLength: 256, KmipKeyID: "1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes, err := dm.RegisterKey(&kbs.KeyRequest{KeyInformation: tt.keyInfo})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DirectoryManager.RegisterKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			keyMaterial, err := dm.TransferKey(attributes)
+			if err != nil {
+				t.Fatalf("DirectoryManager.TransferKey() error = %v", err)
+			}
+			if tt.want != nil && !bytes.Equal(keyMaterial, tt.want) {
+				t.Errorf("DirectoryManager.TransferKey() returned a different key")
+			}
+		})
+	}
+}
+
+func TestDirectoryManagerTransferKeyWithOtherMasterKey(t *testing.T) {
+	attributes, err := newTestDirectoryManager(t).CreateKey(&kbs.KeyRequest{
+		KeyInformation: &kbs.KeyInformation{Algorithm: "AES", KeyLength: 128},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newTestDirectoryManager(t).TransferKey(attributes); err == nil {
+		t.Errorf("DirectoryManager.TransferKey() key is decrypted with another master key")
+	}
+}
+
+func TestLoadMasterKeyFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kbs-master-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	sealer, err := NewMasterKeySealer("file", filepath.Join(dir, "master-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyStore := &mocks.MockKeyStore{KeyStore: map[uuid.UUID]*models.KeyAttributes{}}
+	masterKey, err := LoadMasterKey(sealer, keyStore)
+	if err != nil || len(masterKey) != constants.MasterKeyLength {
+		t.Fatalf("LoadMasterKey() error = %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "master-key"))
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("LoadMasterKey() master key file is not created with 0600 permissions")
+	}
+
+	loadedKey, err := LoadMasterKey(sealer, keyStore)
+	if err != nil || !bytes.Equal(masterKey, loadedKey) {
+		t.Errorf("LoadMasterKey() master key is not loaded from file, error = %v", err)
+	}
+
+	// a new master key would leave the keys sealed under the missing one unreadable
+	dm, err := NewDirectoryManager(masterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := dm.CreateKey(&kbs.KeyRequest{KeyInformation: &kbs.KeyInformation{Algorithm: "AES", KeyLength: 256}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyStore.Create(key)
+	if err := os.Remove(filepath.Join(dir, "master-key")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadMasterKey(sealer, keyStore); err == nil {
+		t.Errorf("LoadMasterKey() new master key is generated while keys are sealed under the missing one")
+	}
+	if sealer.Exists() {
+		t.Errorf("LoadMasterKey() new master key is sealed while keys are sealed under the missing one")
+	}
+
+	if _, err := NewMasterKeySealer("hsm", filepath.Join(dir, "master-key")); err == nil {
+		t.Errorf("NewMasterKeySealer() unsupported sealing is accepted")
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/config"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/kmipclient"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
@@ -18,7 +19,7 @@ import (
 
 var defaultLog = log.GetDefaultLogger()
 
-func NewKeyManager(cfg *config.Configuration) (KeyManager, error) {
+func NewKeyManager(cfg *config.Configuration, keyStore domain.KeyStore) (KeyManager, error) {
 	defaultLog.Trace("keymanager/key_manager:NewKeyManager() Entering")
 	defer defaultLog.Trace("keymanager/key_manager:NewKeyManager() Leaving")
 
@@ -30,6 +31,18 @@ func NewKeyManager(cfg *config.Configuration) (KeyManager, error) {
 			return nil, errors.New("Failed to initialize KeyManager")
 		}
 		return NewKmipManager(kmipClient), nil
+	} else if strings.ToLower(cfg.KeyManager) == constants.DirectoryKeyManager {
+		sealer, err := NewMasterKeySealer(cfg.Directory.MasterKeySealing, cfg.Directory.MasterKeyFile)
+		if err != nil {
+			defaultLog.WithError(err).Error("keymanager/key_manager:NewKeyManager() Failed to initialize master key sealer")
+			return nil, errors.New("Failed to initialize KeyManager")
+		}
+		masterKey, err := LoadMasterKey(sealer, keyStore)
+		if err != nil {
+			defaultLog.WithError(err).Error("keymanager/key_manager:NewKeyManager() Failed to load master key")
+			return nil, errors.New("Failed to initialize KeyManager")
+		}
+		return NewDirectoryManager(masterKey)
 	} else {
 		defaultLog.Errorf("keymanager/key_manager:NewKeyManager() No Key Manager supported for provider: %s", cfg.KeyManager)
 		return nil, errors.Errorf("No Key Manager supported for provider: %s", cfg.KeyManager)
@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package keymanager
+
+import (
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/kmipclient"
+	"github.com/pkg/errors"
+)
+
+// KeyMigrationResult lists the keys moved from the directory key manager to the KMIP server
+type KeyMigrationResult struct {
+	Migrated []uuid.UUID
+	// Skipped are the keys of an algorithm that cannot be transferred by the KMIP key manager
+	Skipped []uuid.UUID
+}
+
+// MigrateKeys registers the key material of the keys created by the directory key manager on the KMIP server.
+// The key attributes are updated with the KMIP key ID and the locally stored key material is removed, the keys
+// keep their IDs and can be transferred once the KMIP key manager is configured. The migration can be resumed
+// after a failure, the keys that are already on the KMIP server are not registered again.
+func MigrateKeys(store domain.KeyStore, dm *DirectoryManager, client kmipclient.KmipClient) (*KeyMigrationResult, error) {
+	defaultLog.Trace("keymanager/key_migration:MigrateKeys() Entering")
+	defer defaultLog.Trace("keymanager/key_migration:MigrateKeys() Leaving")
+
+	keys, err := store.Search(&models.KeyFilterCriteria{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to search keys")
+	}
+
+	result := &KeyMigrationResult{}
+	for i := range keys {
+		key := &keys[i]
+		if !hasLocalKeyMaterial(key) {
+			continue
+		}
+		if key.Algorithm != constants.CRYPTOALG_AES && key.Algorithm != constants.CRYPTOALG_RSA {
+			defaultLog.Warnf("keymanager/key_migration:MigrateKeys() %s key %s cannot be transferred by KMIP key manager, skipping", key.Algorithm, key.ID)
+			result.Skipped = append(result.Skipped, key.ID)
+			continue
+		}
+
+		// the previous versions of a rotated key are migrated along with the current version
+		for j := range key.PreviousVersions {
+			version := &key.PreviousVersions[j]
+			if version.KeyData == "" || version.KmipKeyID != "" {
+				continue
+			}
+			versionKey, err := atVersion(key, version.Version)
+			if err != nil {
+				return result, err
+			}
+			if version.KmipKeyID, err = registerKeyMaterial(versionKey, dm, client); err != nil {
+				return result, err
+			}
+			version.KeyData = ""
+		}
+		if key.KeyData != "" && key.KmipKeyID == "" {
+			kmipKeyID, err := registerKeyMaterial(key, dm, client)
+			if err != nil {
+				return result, err
+			}
+			key.KmipKeyID = kmipKeyID
+			key.KeyData = ""
+		}
+
+		if _, err := store.Create(key); err != nil {
+			return result, errors.Wrapf(err, "failed to update key %s with KMIP key ID", key.ID)
+		}
+		defaultLog.Infof("keymanager/key_migration:MigrateKeys() Key %s migrated to KMIP key %s", key.ID, key.KmipKeyID)
+		result.Migrated = append(result.Migrated, key.ID)
+	}
+	return result, nil
+}
+
+func hasLocalKeyMaterial(key *models.KeyAttributes) bool {
+	if key.KeyData != "" && key.KmipKeyID == "" {
+		return true
+	}
+	for _, version := range key.PreviousVersions {
+		if version.KeyData != "" && version.KmipKeyID == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func registerKeyMaterial(key *models.KeyAttributes, dm *DirectoryManager, client kmipclient.KmipClient) (string, error) {
+	keyMaterial, err := dm.TransferKey(key)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve key material of version %d of key %s", key.CurrentVersion(), key.ID)
+	}
+	kmipKeyID, err := client.RegisterKey(key.Algorithm, key.KeyLength, keyMaterial)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to register version %d of key %s on KMIP server", key.CurrentVersion(), key.ID)
+	}
+	return kmipKeyID, nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package keymanager
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/kmipclient"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMigrateKeys(t *testing.T) {
+	dm := newTestDirectoryManager(t)
+	keyStore := &mocks.MockKeyStore{KeyStore: map[uuid.UUID]*models.KeyAttributes{}}
+	for _, keyInfo := range []*kbs.KeyInformation{
+		{Algorithm: "AES", KeyLength: 256},
+		{Algorithm: "EC", CurveType: "prime256v1"},
+	} {
+		attributes, err := dm.CreateKey(&kbs.KeyRequest{KeyInformation: keyInfo})
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyStore.Create(attributes)
+	}
+	kmipKey := &models.KeyAttributes{ID: uuid.New(), Algorithm: "AES", KeyLength: 256, KmipKeyID: "1"}
+	keyStore.Create(kmipKey)
+
+	mockClient := kmipclient.NewMockKmipClient()
+	mockClient.On("RegisterKey", "AES", 256, mock.Anything).Return("10", nil)
+
+	result, err := MigrateKeys(keyStore, dm, mockClient)
+	if err != nil {
+		t.Fatalf("MigrateKeys() error = %v", err)
+	}
+	if len(result.Migrated) != 1 || len(result.Skipped) != 1 {
+		t.Fatalf("MigrateKeys() migrated = %v, skipped = %v", result.Migrated, result.Skipped)
+	}
+	migratedKey, _ := keyStore.Retrieve(result.Migrated[0])
+	if migratedKey.KmipKeyID != "10" || migratedKey.KeyData != "" {
+		t.Errorf("MigrateKeys() key attributes are not updated")
+	}
+	skippedKey, _ := keyStore.Retrieve(result.Skipped[0])
+	if skippedKey.KeyData == "" {
+		t.Errorf("MigrateKeys() key material of skipped key is removed")
+	}
+	mockClient.AssertNumberOfCalls(t, "RegisterKey", 1)
+
+	// the migrated keys are not registered again
+	if _, err := MigrateKeys(keyStore, dm, mockClient); err != nil {
+		t.Fatalf("MigrateKeys() error = %v", err)
+	}
+	mockClient.AssertNumberOfCalls(t, "RegisterKey", 1)
+}
+
+func TestMigrateKeysRegisterFailure(t *testing.T) {
+	dm := newTestDirectoryManager(t)
+	keyStore := &mocks.MockKeyStore{KeyStore: map[uuid.UUID]*models.KeyAttributes{}}
+	attributes, err := dm.CreateKey(&kbs.KeyRequest{KeyInformation: &kbs.KeyInformation{Algorithm: "AES", KeyLength: 128}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyStore.Create(attributes)
+
+	mockClient := kmipclient.NewMockKmipClient()
+	mockClient.On("RegisterKey", "AES", 128, mock.Anything).Return("", errors.New("connection refused"))
+
+	if _, err := MigrateKeys(keyStore, dm, mockClient); err == nil {
+		t.Fatalf("MigrateKeys() error is not returned")
+	}
+	storedKey, _ := keyStore.Retrieve(attributes.ID)
+	if storedKey.KeyData == "" || storedKey.KmipKeyID != "" {
+		t.Errorf("MigrateKeys() key attributes are updated after failure")
+	}
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package keymanager
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
+	"github.com/pkg/errors"
+)
+
+// MasterKeySealer protects the master key of the directory key manager at rest
+type MasterKeySealer interface {
+	// Exists returns true when a sealed master key is present
+	Exists() bool
+	Seal(masterKey []byte) error
+	Unseal() ([]byte, error)
+}
+
+func NewMasterKeySealer(sealing, masterKeyFile string) (MasterKeySealer, error) {
+	if masterKeyFile == "" {
+		return nil, errors.New("master key file is not provided")
+	}
+	switch strings.ToLower(sealing) {
+	case constants.FileMasterKeySealing:
+		return &FileSealer{path: masterKeyFile}, nil
+	case constants.TpmMasterKeySealing:
+		return &TpmSealer{path: masterKeyFile}, nil
+	default:
+		return nil, errors.Errorf("master key sealing %s is not supported", sealing)
+	}
+}
+
+// LoadMasterKey unseals the master key, a new master key is generated and sealed when none exists. A new
+// master key is not generated while the key store still has key material sealed under the missing one.
+func LoadMasterKey(sealer MasterKeySealer, keyStore domain.KeyStore) ([]byte, error) {
+	defaultLog.Trace("keymanager/master_key:LoadMasterKey() Entering")
+	defer defaultLog.Trace("keymanager/master_key:LoadMasterKey() Leaving")
+
+	if sealer.Exists() {
+		masterKey, err := sealer.Unseal()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unseal master key")
+		}
+		if len(masterKey) != constants.MasterKeyLength {
+			return nil, errors.Errorf("master key must be %d bytes long", constants.MasterKeyLength)
+		}
+		return masterKey, nil
+	}
+
+	keys, err := keyStore.Search(&models.KeyFilterCriteria{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to search keys sealed under master key")
+	}
+	for i := range keys {
+		if hasLocalKeyMaterial(&keys[i]) {
+			return nil, errors.Errorf("master key does not exist but key %s is sealed under it", keys[i].ID)
+		}
+	}
+
+	masterKey := make([]byte, constants.MasterKeyLength)
+	if _, err := rand.Read(masterKey); err != nil {
+		return nil, errors.Wrap(err, "failed to generate master key")
+	}
+	if err := sealer.Seal(masterKey); err != nil {
+		return nil, errors.Wrap(err, "failed to seal master key")
+	}
+	defaultLog.Info("keymanager/master_key:LoadMasterKey() New master key generated")
+	return masterKey, nil
+}
+
+// FileSealer keeps the master key in a file readable by the KBS user only
+type FileSealer struct {
+	path string
+}
+
+func (fs *FileSealer) Exists() bool {
+	_, err := os.Stat(fs.path)
+	return err == nil
+}
+
+func (fs *FileSealer) Seal(masterKey []byte) error {
+	if err := os.MkdirAll(filepath.Dir(fs.path), 0700); err != nil {
+		return errors.Wrap(err, "failed to create master key directory")
+	}
+	return errors.Wrap(ioutil.WriteFile(fs.path, masterKey, 0600), "failed to write master key file")
+}
+
+func (fs *FileSealer) Unseal() ([]byte, error) {
+	masterKey, err := ioutil.ReadFile(fs.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read master key file")
+	}
+	return masterKey, nil
+}
+
+// TpmSealer seals the master key to the storage primary key of the TPM owner hierarchy with tpm2-tools.
+// The public and private parts of the sealed object are kept in <path>.pub and <path>.priv, the master key
+// can only be unsealed on the platform that sealed it.
+type TpmSealer struct {
+	path string
+}
+
+func (ts *TpmSealer) Exists() bool {
+	_, err := os.Stat(ts.path + ".priv")
+	return err == nil
+}
+
+func (ts *TpmSealer) Seal(masterKey []byte) error {
+	if err := os.MkdirAll(filepath.Dir(ts.path), 0700); err != nil {
+		return errors.Wrap(err, "failed to create master key directory")
+	}
+	return ts.withPrimary(func(primaryCtx string) error {
+		_, err := runTpm2Tool(masterKey, "tpm2_create", "-Q", "-C", primaryCtx, "-i", "-",
+			"-u", ts.path+".pub", "-r", ts.path+".priv")
+		if err != nil {
+			return err
+		}
+		return errors.Wrap(os.Chmod(ts.path+".priv", 0600), "failed to set sealed master key permissions")
+	})
+}
+
+func (ts *TpmSealer) Unseal() ([]byte, error) {
+	var masterKey []byte
+	err := ts.withPrimary(func(primaryCtx string) error {
+		objectCtx := filepath.Join(filepath.Dir(primaryCtx), "master-key.ctx")
+		if _, err := runTpm2Tool(nil, "tpm2_load", "-Q", "-C", primaryCtx, "-u", ts.path+".pub",
+			"-r", ts.path+".priv", "-c", objectCtx); err != nil {
+			return err
+		}
+		var err error
+		masterKey, err = runTpm2Tool(nil, "tpm2_unseal", "-c", objectCtx)
+		return err
+	})
+	return masterKey, err
+}
+
+// withPrimary recreates the storage primary key, it is derived from the owner seed and is the same on every call
+func (ts *TpmSealer) withPrimary(f func(primaryCtx string) error) error {
+	dir, err := ioutil.TempDir("", "kbs-tpm")
+	if err != nil {
+		return errors.Wrap(err, "failed to create TPM context directory")
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			defaultLog.WithError(err).Warn("keymanager/master_key:withPrimary() Failed to remove TPM context directory")
+		}
+	}()
+
+	primaryCtx := filepath.Join(dir, "primary.ctx")
+	if _, err := runTpm2Tool(nil, "tpm2_createprimary", "-Q", "-C", "o", "-c", primaryCtx); err != nil {
+		return err
+	}
+	return f(primaryCtx)
+}
+
+func runTpm2Tool(stdin []byte, name string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "%s failed: %s", name, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
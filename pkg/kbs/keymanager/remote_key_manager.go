@@ -7,13 +7,26 @@ package keymanager
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/models"
 	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+	"github.com/pkg/errors"
 )
 
+// ErrKeyVersionNotFound is returned when the requested version of a key does not exist
+var ErrKeyVersionNotFound = errors.New("key version does not exist")
+
+// ErrRegisteredKeyRotation is returned when a registered key is rotated, a new version would not be the key
+// material of the owner of the key
+var ErrRegisteredKeyRotation = errors.New("registered key cannot be rotated")
+
+// rotationLocks serializes the rotations of a key, the remote managers of all routes share the key store
+var rotationLocks sync.Map
+
 type RemoteManager struct {
 	store       domain.KeyStore
 	manager     KeyManager
@@ -32,6 +45,8 @@ func (rm *RemoteManager) CreateKey(request *kbs.KeyRequest) (*kbs.KeyResponse, e
 	defaultLog.Trace("keymanager/remote_key_manager:CreateKey() Entering")
 	defer defaultLog.Trace("keymanager/remote_key_manager:CreateKey() Leaving")
 
+	// the key ID is assigned by the key manager, it is only provided to create a new version of a key
+	request.KeyInformation.ID = uuid.Nil
 	keyAttributes, err := rm.manager.CreateKey(request)
 	if err != nil {
 		return nil, err
@@ -62,11 +77,26 @@ func (rm *RemoteManager) DeleteKey(keyId uuid.UUID) error {
 	defaultLog.Trace("keymanager/remote_key_manager:DeleteKey() Entering")
 	defer defaultLog.Trace("keymanager/remote_key_manager:DeleteKey() Leaving")
 
+	// the rotation lock of the key is removed along with the key
+	lock, _ := rotationLocks.LoadOrStore(keyId, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+	defer rotationLocks.Delete(keyId)
+
 	keyAttributes, err := rm.store.Retrieve(keyId)
 	if err != nil {
 		return err
 	}
 
+	for _, version := range keyAttributes.PreviousVersions {
+		versionAttributes, err := atVersion(keyAttributes, version.Version)
+		if err != nil {
+			return err
+		}
+		if err := rm.manager.DeleteKey(versionAttributes); err != nil {
+			return err
+		}
+	}
 	if err := rm.manager.DeleteKey(keyAttributes); err != nil {
 		return err
 	}
@@ -95,12 +125,14 @@ func (rm *RemoteManager) RegisterKey(request *kbs.KeyRequest) (*kbs.KeyResponse,
 	defaultLog.Trace("keymanager/remote_key_manager:RegisterKey() Entering")
 	defer defaultLog.Trace("keymanager/remote_key_manager:RegisterKey() Leaving")
 
+	request.KeyInformation.ID = uuid.Nil
 	keyAttributes, err := rm.manager.RegisterKey(request)
 	if err != nil {
 		return nil, err
 	}
 
 	keyAttributes.TransferLink = rm.getTransferLink(keyAttributes.ID)
+	keyAttributes.Registered = true
 	storedKey, err := rm.store.Create(keyAttributes)
 	if err != nil {
 		return nil, err
@@ -113,12 +145,101 @@ func (rm *RemoteManager) TransferKey(keyId uuid.UUID) ([]byte, error) {
 	defaultLog.Trace("keymanager/remote_key_manager:TransferKey() Entering")
 	defer defaultLog.Trace("keymanager/remote_key_manager:TransferKey() Leaving")
 
+	return rm.TransferKeyVersion(keyId, 0)
+}
+
+// TransferKeyVersion returns the key material of a version of the key, the current version is returned when version is 0
+func (rm *RemoteManager) TransferKeyVersion(keyId uuid.UUID, version int) ([]byte, error) {
+	defaultLog.Trace("keymanager/remote_key_manager:TransferKeyVersion() Entering")
+	defer defaultLog.Trace("keymanager/remote_key_manager:TransferKeyVersion() Leaving")
+
 	keyAttributes, err := rm.store.Retrieve(keyId)
 	if err != nil {
 		return nil, err
 	}
 
-	return rm.manager.TransferKey(keyAttributes)
+	versionAttributes, err := atVersion(keyAttributes, version)
+	if err != nil {
+		return nil, err
+	}
+	return rm.manager.TransferKey(versionAttributes)
+}
+
+// RotateKey creates a new version of the key with the key manager. The key keeps its ID, transfer policy and
+// transfer link, the key material of the previous versions is kept to transfer the keys of existing content.
+// Registered keys are not rotated, ErrRegisteredKeyRotation is returned.
+func (rm *RemoteManager) RotateKey(keyId uuid.UUID) (*kbs.KeyResponse, error) {
+	defaultLog.Trace("keymanager/remote_key_manager:RotateKey() Entering")
+	defer defaultLog.Trace("keymanager/remote_key_manager:RotateKey() Leaving")
+
+	lock, _ := rotationLocks.LoadOrStore(keyId, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	keyAttributes, err := rm.store.Retrieve(keyId)
+	if err != nil {
+		return nil, err
+	}
+	if keyAttributes.Registered {
+		return nil, ErrRegisteredKeyRotation
+	}
+
+	newKey, err := rm.manager.CreateKey(&kbs.KeyRequest{
+		KeyInformation: &kbs.KeyInformation{
+			ID:        keyAttributes.ID,
+			Algorithm: keyAttributes.Algorithm,
+			KeyLength: keyAttributes.KeyLength,
+			CurveType: keyAttributes.CurveType,
+		},
+		TransferPolicyID: keyAttributes.TransferPolicyId,
+		Label:            keyAttributes.Label,
+		Usage:            keyAttributes.Usage,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create new key version")
+	}
+
+	keyAttributes.PreviousVersions = append(keyAttributes.PreviousVersions, models.KeyVersion{
+		Version:   keyAttributes.CurrentVersion(),
+		KeyData:   keyAttributes.KeyData,
+		PublicKey: keyAttributes.PublicKey,
+		KmipKeyID: keyAttributes.KmipKeyID,
+		CreatedAt: keyAttributes.CurrentVersionCreatedAt(),
+		RotatedAt: time.Now().UTC(),
+	})
+	keyAttributes.Version = keyAttributes.CurrentVersion() + 1
+	keyAttributes.KeyData = newKey.KeyData
+	keyAttributes.PublicKey = newKey.PublicKey
+	keyAttributes.KmipKeyID = newKey.KmipKeyID
+
+	storedKey, err := rm.store.Create(keyAttributes)
+	if err != nil {
+		if deleteErr := rm.manager.DeleteKey(newKey); deleteErr != nil {
+			defaultLog.WithError(deleteErr).Errorf("keymanager/remote_key_manager:RotateKey() Failed to delete new version of key %s", keyId)
+		}
+		return nil, err
+	}
+
+	return storedKey.ToKeyResponse(), nil
+}
+
+// atVersion returns the key attributes with the key material of a version, the current version when version is 0
+func atVersion(keyAttributes *models.KeyAttributes, version int) (*models.KeyAttributes, error) {
+	if version == 0 || version == keyAttributes.CurrentVersion() {
+		return keyAttributes, nil
+	}
+	for _, previousVersion := range keyAttributes.PreviousVersions {
+		if previousVersion.Version == version {
+			versionAttributes := *keyAttributes
+			versionAttributes.Version = previousVersion.Version
+			versionAttributes.KeyData = previousVersion.KeyData
+			versionAttributes.PublicKey = previousVersion.PublicKey
+			versionAttributes.KmipKeyID = previousVersion.KmipKeyID
+			versionAttributes.PreviousVersions = nil
+			return &versionAttributes, nil
+		}
+	}
+	return nil, ErrKeyVersionNotFound
 }
 
 func (rm *RemoteManager) getTransferLink(keyId uuid.UUID) string {
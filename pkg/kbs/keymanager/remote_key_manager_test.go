@@ -5,6 +5,9 @@
 package keymanager
 
 import (
+	"bytes"
+	"encoding/pem"
+	"sync"
 	"testing"
 
 	"github.com/google/uuid"
@@ -475,6 +478,125 @@ func TestRemoteManagerTransferKey(t *testing.T) {
 	}
 }
 
+func TestRemoteManagerRotateKey(t *testing.T) {
+	keyStore := &mocks.MockKeyStore{KeyStore: map[uuid.UUID]*models.KeyAttributes{}}
+	rm := NewRemoteManager(keyStore, newTestDirectoryManager(t), "https://localhost:9443/kbs/v1")
+
+	createdKey, err := rm.CreateKey(&kbs.KeyRequest{KeyInformation: &kbs.KeyInformation{Algorithm: "AES", KeyLength: 256}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyId := createdKey.KeyInformation.ID
+	firstVersion, err := rm.TransferKey(keyId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotatedKey, err := rm.RotateKey(keyId)
+	if err != nil {
+		t.Fatalf("RemoteManager.RotateKey() error = %v", err)
+	}
+	if rotatedKey.KeyInformation.ID != keyId || rotatedKey.TransferLink != createdKey.TransferLink {
+		t.Errorf("RemoteManager.RotateKey() key ID or transfer link changed")
+	}
+	if rotatedKey.Version != 2 || len(rotatedKey.Versions) != 2 {
+		t.Errorf("RemoteManager.RotateKey() version = %d, versions = %v", rotatedKey.Version, rotatedKey.Versions)
+	}
+
+	currentVersion, err := rm.TransferKey(keyId)
+	if err != nil || bytes.Equal(currentVersion, firstVersion) {
+		t.Errorf("RemoteManager.TransferKey() current version is not transferred, error = %v", err)
+	}
+	previousVersion, err := rm.TransferKeyVersion(keyId, 1)
+	if err != nil || !bytes.Equal(previousVersion, firstVersion) {
+		t.Errorf("RemoteManager.TransferKeyVersion() previous version is not transferred, error = %v", err)
+	}
+	if _, err := rm.TransferKeyVersion(keyId, 3); err != ErrKeyVersionNotFound {
+		t.Errorf("RemoteManager.TransferKeyVersion() error = %v, want %v", err, ErrKeyVersionNotFound)
+	}
+
+	if _, err := rm.RotateKey(uuid.New()); err == nil {
+		t.Errorf("RemoteManager.RotateKey() non-existent key is rotated")
+	}
+}
+
+func TestRemoteManagerRotateRegisteredKey(t *testing.T) {
+	keyStore := &mocks.MockKeyStore{KeyStore: map[uuid.UUID]*models.KeyAttributes{}}
+	rm := NewRemoteManager(keyStore, newTestDirectoryManager(t), "https://localhost:9443/kbs/v1")
+
+	aesKey := bytes.Repeat([]byte{0x2a}, 32)
+	registeredKey, err := rm.RegisterKey(&kbs.KeyRequest{KeyInformation: &kbs.KeyInformation{Algorithm: "AES", KeyLength: 256,
+		KeyString: string(pem.EncodeToMemory(&pem.Block{Type: "AES KEY", Bytes: aesKey}))}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyId := registeredKey.KeyInformation.ID
+
+	if _, err := rm.RotateKey(keyId); err != ErrRegisteredKeyRotation {
+		t.Fatalf("RemoteManager.RotateKey() error = %v, want %v", err, ErrRegisteredKeyRotation)
+	}
+	key, err := rm.TransferKey(keyId)
+	if err != nil || !bytes.Equal(key, aesKey) {
+		t.Errorf("RemoteManager.TransferKey() registered key material is replaced, error = %v", err)
+	}
+}
+
+func TestRemoteManagerDeleteKeyRotationLock(t *testing.T) {
+	keyStore := &mocks.MockKeyStore{KeyStore: map[uuid.UUID]*models.KeyAttributes{}}
+	rm := NewRemoteManager(keyStore, newTestDirectoryManager(t), "https://localhost:9443/kbs/v1")
+
+	createdKey, err := rm.CreateKey(&kbs.KeyRequest{KeyInformation: &kbs.KeyInformation{Algorithm: "AES", KeyLength: 256}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyId := createdKey.KeyInformation.ID
+	if _, err := rm.RotateKey(keyId); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rotationLocks.Load(keyId); !ok {
+		t.Fatal("RemoteManager.RotateKey() rotation lock is not stored")
+	}
+
+	if err := rm.DeleteKey(keyId); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rotationLocks.Load(keyId); ok {
+		t.Errorf("RemoteManager.DeleteKey() rotation lock of the deleted key is kept")
+	}
+}
+
+func TestRemoteManagerRotateKeyConcurrently(t *testing.T) {
+	keyStore := &mocks.MockKeyStore{KeyStore: map[uuid.UUID]*models.KeyAttributes{}}
+	rm := NewRemoteManager(keyStore, newTestDirectoryManager(t), "https://localhost:9443/kbs/v1")
+
+	createdKey, err := rm.CreateKey(&kbs.KeyRequest{KeyInformation: &kbs.KeyInformation{Algorithm: "AES", KeyLength: 256}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyId := createdKey.KeyInformation.ID
+
+	const rotations = 10
+	var wg sync.WaitGroup
+	for i := 0; i < rotations; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := rm.RotateKey(keyId); err != nil {
+				t.Errorf("RemoteManager.RotateKey() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	rotatedKey, err := rm.RetrieveKey(keyId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotatedKey.Version != rotations+1 || len(rotatedKey.Versions) != rotations+1 {
+		t.Errorf("RemoteManager.RotateKey() version = %d, versions = %v", rotatedKey.Version, rotatedKey.Versions)
+	}
+}
+
 func TestNewRemoteManager(t *testing.T) {
 	var keyStore *mocks.MockKeyStore
 
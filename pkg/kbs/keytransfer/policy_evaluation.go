@@ -0,0 +1,209 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package keytransfer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/aps"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+)
+
+// EvaluateKeyTransferPolicy - Function to evaluate the sample attestation claims against the key transfer policy,
+// it uses the same checks as the SKC key transfer and does not release any key
+func EvaluateKeyTransferPolicy(policy *kbs.KeyTransferPolicy, claims *kbs.KeyTransferPolicyEvaluationRequest) []kbs.PolicyAttributeEvaluation {
+	defaultLog.Trace("keytransfer/policy_evaluation:EvaluateKeyTransferPolicy() Entering")
+	defer defaultLog.Trace("keytransfer/policy_evaluation:EvaluateKeyTransferPolicy() Leaving")
+
+	keyInfo := KeyDetails{
+		IssuerCommonName:         claims.CertIssuer,
+		TransferPolicyAttributes: policy,
+	}
+
+	var evaluations []kbs.PolicyAttributeEvaluation
+	if len(policy.IssuerName) != 0 {
+		evaluations = append(evaluations, evaluateClaim("cert_issuer", strings.Join(policy.IssuerName, ","), claims.CertIssuer,
+			keyInfo.doesCertIssuerCNMatchKeyTransferPolicy()))
+	}
+
+	attestationType := claims.AttestationType
+	if attestationType == "" {
+		if claims.SGX != nil {
+			attestationType = aps.SGX
+		} else if claims.TDX != nil {
+			attestationType = aps.TDX
+		}
+	}
+
+	var policyAttestationTypes []string
+	for _, attType := range policy.AttestationType {
+		policyAttestationTypes = append(policyAttestationTypes, attType.String())
+	}
+
+	matched := false
+	if attestationType != "" {
+		keyInfo.FinalStmLabels = []string{attestationType.String()}
+		matched = keyInfo.doesAttestTypeMatchKeyTransferPolicy()
+	}
+	evaluations = append(evaluations, evaluateClaim("attestation_type", strings.Join(policyAttestationTypes, ","), attestationType.String(), matched))
+
+	switch attestationType {
+	case aps.SGX:
+		if policy.SGX != nil && policy.SGX.Attributes != nil {
+			sgxClaims := claims.SGX
+			if sgxClaims == nil {
+				sgxClaims = &kbs.SgxClaims{}
+			}
+			evaluations = append(evaluations, keyInfo.evaluateSgxAttributes(sgxClaims)...)
+		}
+	case aps.TDX:
+		if policy.TDX != nil && policy.TDX.Attributes != nil {
+			tdxClaims := claims.TDX
+			if tdxClaims == nil {
+				tdxClaims = &kbs.TdxClaims{}
+			}
+			evaluations = append(evaluations, evaluateTdxAttributes(policy.TDX.Attributes, tdxClaims)...)
+		}
+	}
+	return evaluations
+}
+
+// IsPolicySatisfied - Function to check that every evaluated attribute is matched
+func IsPolicySatisfied(evaluations []kbs.PolicyAttributeEvaluation) bool {
+	if len(evaluations) == 0 {
+		return false
+	}
+	for _, evaluation := range evaluations {
+		if evaluation.Result != constants.PolicyAttributeMatched {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluateSgxAttributes - Function to evaluate the sgx claims with the validations of the SKC key transfer
+func (keyInfo KeyDetails) evaluateSgxAttributes(claims *kbs.SgxClaims) []kbs.PolicyAttributeEvaluation {
+	defaultLog.Trace("keytransfer/policy_evaluation:evaluateSgxAttributes() Entering")
+	defer defaultLog.Trace("keytransfer/policy_evaluation:evaluateSgxAttributes() Leaving")
+
+	attributes := keyInfo.TransferPolicyAttributes.SGX.Attributes
+	var evaluations []kbs.PolicyAttributeEvaluation
+
+	evaluations = append(evaluations, evaluateClaim("mrsigner", strings.Join(attributes.MrSigner, ","), claims.MrSigner,
+		keyInfo.validateSgxEnclaveIssuer(claims.MrSigner)))
+
+	var productIds []string
+	for _, productId := range attributes.IsvProductId {
+		productIds = append(productIds, strconv.FormatUint(uint64(productId), 10))
+	}
+	isvProductId := ""
+	if claims.IsvProductId != nil {
+		isvProductId = strconv.FormatUint(uint64(*claims.IsvProductId), 10)
+	}
+	evaluations = append(evaluations, evaluateClaim("isvprodid", strings.Join(productIds, ","), isvProductId,
+		keyInfo.validateSgxEnclaveIssuerProdId(isvProductId)))
+
+	if len(attributes.MrEnclave) != 0 {
+		evaluations = append(evaluations, evaluateClaim("mrenclave", strings.Join(attributes.MrEnclave, ","), claims.MrEnclave,
+			keyInfo.validateSgxEnclaveMeasurement(claims.MrEnclave)))
+	}
+
+	if attributes.IsvSvn != nil {
+		isvSvn := ""
+		if claims.IsvSvn != nil {
+			isvSvn = strconv.FormatUint(uint64(*claims.IsvSvn), 10)
+		}
+		evaluations = append(evaluations, evaluateClaim("isvsvn", strconv.FormatUint(uint64(*attributes.IsvSvn), 10), isvSvn,
+			isvSvn != "" && keyInfo.validateSgxIsvSvn(isvSvn)))
+	}
+
+	if attributes.EnforceTCBUptoDate != nil && *attributes.EnforceTCBUptoDate {
+		evaluations = append(evaluations, evaluateTcbLevel(claims.TCBLevel))
+	}
+	return evaluations
+}
+
+// evaluateTdxAttributes - Function to evaluate the tdx claims against the tdx attributes of the key transfer policy
+func evaluateTdxAttributes(attributes *kbs.TdxAttributes, claims *kbs.TdxClaims) []kbs.PolicyAttributeEvaluation {
+	defaultLog.Trace("keytransfer/policy_evaluation:evaluateTdxAttributes() Entering")
+	defer defaultLog.Trace("keytransfer/policy_evaluation:evaluateTdxAttributes() Leaving")
+
+	var evaluations []kbs.PolicyAttributeEvaluation
+	evaluations = append(evaluations, evaluateAnyOf("mrsignerseam", attributes.MrSignerSeam, claims.MrSignerSeam))
+	evaluations = append(evaluations, evaluateAnyOf("mrseam", attributes.MrSeam, claims.MrSeam))
+
+	if attributes.SeamSvn != nil {
+		seamSvn := ""
+		if claims.SeamSvn != nil {
+			seamSvn = strconv.FormatUint(uint64(*claims.SeamSvn), 10)
+		}
+		expected := strconv.FormatUint(uint64(*attributes.SeamSvn), 10)
+		evaluations = append(evaluations, evaluateClaim("seamsvn", expected, seamSvn, seamSvn == expected))
+	}
+
+	if len(attributes.MRTD) != 0 {
+		evaluations = append(evaluations, evaluateAnyOf("mrtd", attributes.MRTD, claims.MRTD))
+	}
+
+	rtmrs := []struct {
+		name, expected, actual string
+	}{
+		{"rtmr0", attributes.RTMR0, claims.RTMR0},
+		{"rtmr1", attributes.RTMR1, claims.RTMR1},
+		{"rtmr2", attributes.RTMR2, claims.RTMR2},
+		{"rtmr3", attributes.RTMR3, claims.RTMR3},
+	}
+	for _, rtmr := range rtmrs {
+		if rtmr.expected != "" {
+			evaluations = append(evaluations, evaluateClaim(rtmr.name, rtmr.expected, rtmr.actual, strings.EqualFold(rtmr.expected, rtmr.actual)))
+		}
+	}
+
+	if attributes.EnforceTCBUptoDate != nil && *attributes.EnforceTCBUptoDate {
+		evaluations = append(evaluations, evaluateTcbLevel(claims.TCBLevel))
+	}
+	return evaluations
+}
+
+// evaluateAnyOf - Function to evaluate a claim that must match any of the values in the key transfer policy
+func evaluateAnyOf(name string, expected []string, actual string) kbs.PolicyAttributeEvaluation {
+	matched := false
+	for _, value := range expected {
+		if strings.EqualFold(value, actual) {
+			matched = true
+			break
+		}
+	}
+	return evaluateClaim(name, strings.Join(expected, ","), actual, matched)
+}
+
+// evaluateTcbLevel - Function to evaluate the platform TCB level when the key transfer policy enforces an up to date TCB
+func evaluateTcbLevel(tcbLevel string) kbs.PolicyAttributeEvaluation {
+	return evaluateClaim("enforce_tcb_upto_date", "true", tcbLevel, tcbLevel != constants.TCBLevelOutOfDate)
+}
+
+// evaluateClaim - Function to build the evaluation of a claim, an empty claim is reported as missing
+func evaluateClaim(name, expected, actual string, matched bool) kbs.PolicyAttributeEvaluation {
+	evaluation := evaluateCheck(name, actual != "", matched)
+	evaluation.Expected = expected
+	evaluation.Actual = actual
+	return evaluation
+}
+
+// evaluateCheck - Function to build the evaluation of a check that has no comparable value
+func evaluateCheck(name string, present, matched bool) kbs.PolicyAttributeEvaluation {
+	evaluation := kbs.PolicyAttributeEvaluation{Name: name}
+	switch {
+	case !present:
+		evaluation.Result = constants.PolicyAttributeMissing
+	case matched:
+		evaluation.Result = constants.PolicyAttributeMatched
+	default:
+		evaluation.Result = constants.PolicyAttributeMismatched
+	}
+	return evaluation
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/privacyca"
 	samlLib "github.com/intel-secl/intel-secl/v5/pkg/lib/saml"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
 	model "github.com/intel-secl/intel-secl/v5/pkg/model/wlagent"
 )
 
@@ -33,10 +34,30 @@ func IsTrustedByHvs(saml string, samlReport *samlLib.Saml, keyId uuid.UUID, conf
 	defaultLog.Trace("keytransfer/transfer_with_saml:IsTrustedByHvs() Entering")
 	defer defaultLog.Trace("keytransfer/transfer_with_saml:IsTrustedByHvs() Leaving")
 
-	usagePolicyTags := make(map[string]string, 0)
+	var usage string
 	key, _ := remoteManager.RetrieveKey(keyId)
-	if key != nil && key.Usage != "" {
-		usagePolicies := strings.Split(key.Usage, ",")
+	if key != nil {
+		usage = key.Usage
+	}
+
+	evaluations, bindingKeyCert := EvaluateSamlReport(saml, samlReport, usage, config)
+	if !IsPolicySatisfied(evaluations) {
+		return false, nil
+	}
+	return true, bindingKeyCert
+}
+
+// EvaluateSamlReport checks the signature and the attributes of the saml report along with the usage policy of the key,
+// the binding key certificate is returned only when every check is matched. The TRUST_OVERALL and tpmVersion
+// attributes are required, a report missing either of them is denied.
+func EvaluateSamlReport(saml string, samlReport *samlLib.Saml, usage string, config domain.KeyTransferControllerConfig) ([]kbs.PolicyAttributeEvaluation, *x509.Certificate) {
+	defaultLog.Trace("keytransfer/transfer_with_saml:EvaluateSamlReport() Entering")
+	defer defaultLog.Trace("keytransfer/transfer_with_saml:EvaluateSamlReport() Leaving")
+
+	var evaluations []kbs.PolicyAttributeEvaluation
+	usagePolicyTags := make(map[string]string, 0)
+	if usage != "" {
+		usagePolicies := strings.Split(usage, ",")
 
 		// create a map for the usage policies
 		for _, usagePolicy := range usagePolicies {
@@ -49,40 +70,27 @@ func IsTrustedByHvs(saml string, samlReport *samlLib.Saml, keyId uuid.UUID, conf
 	saml = pattern.ReplaceAllString(saml, "<")
 	verified := verifySamlSignature(saml, config.SamlCertsDir, config.TrustedCaCertsDir)
 	if !verified {
-		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Invalid signature on trust report")
-		return false, nil
+		defaultLog.Error("keytransfer/transfer_with_saml:EvaluateSamlReport() Invalid signature on trust report")
 	}
+	evaluations = append(evaluations, evaluateCheck("saml_signature", true, verified))
 
-	var err error
 	var assetTagDeployed bool
+	trustOverall, tpmVersion, assetTag := "", "", ""
 	tagsDeployedOnHost := make(map[string]string, 0)
-	var bindingKeyCertBytes, aikCertBytes []byte
+	var bindingKeyCertValue, aikCertValue string
 	for _, as := range samlReport.Attribute {
 
 		switch as.Name {
 		case "TRUST_OVERALL":
-			if as.AttributeValue != "true" {
-				defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Host is not trusted")
-				return false, nil
-			}
+			trustOverall = as.AttributeValue
 		case "tpmVersion":
-			if as.AttributeValue != "2.0" {
-				defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() TPM version not supported")
-				return false, nil
-			}
+			tpmVersion = as.AttributeValue
 		case "Binding_Key_Certificate":
-			bindingKeyCertBytes, err = base64.StdEncoding.DecodeString(as.AttributeValue)
-			if err != nil {
-				defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Unable to decode Binding Key Certificate")
-				return false, nil
-			}
+			bindingKeyCertValue = as.AttributeValue
 		case "AIK_Certificate":
-			aikCertBytes, err = base64.StdEncoding.DecodeString(as.AttributeValue)
-			if err != nil {
-				defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Unable to decode AIK certificate")
-				return false, nil
-			}
+			aikCertValue = as.AttributeValue
 		case "TRUST_ASSET_TAG":
+			assetTag = as.AttributeValue
 			// check if asset tag is deployed on the host
 			if as.AttributeValue == "true" {
 				assetTagDeployed = true
@@ -95,62 +103,80 @@ func IsTrustedByHvs(saml string, samlReport *samlLib.Saml, keyId uuid.UUID, conf
 		}
 	}
 
-	if len(aikCertBytes) == 0 {
-		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Assertion does not include AIK Certificate")
-		return false, nil
+	if trustOverall != "true" {
+		defaultLog.Error("keytransfer/transfer_with_saml:EvaluateSamlReport() Host is not trusted")
 	}
+	evaluations = append(evaluations, evaluateClaim("TRUST_OVERALL", "true", trustOverall, trustOverall == "true"))
 
-	aikCert, err := x509.ParseCertificate(aikCertBytes)
-	if err != nil {
-		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Unable to parse AIK certificate")
-		return false, nil
+	if tpmVersion != "2.0" {
+		defaultLog.Error("keytransfer/transfer_with_saml:EvaluateSamlReport() TPM version not supported")
 	}
+	evaluations = append(evaluations, evaluateClaim("tpmVersion", "2.0", tpmVersion, tpmVersion == "2.0"))
 
-	verified = verifySignature(aikCert, config.TpmIdentityCertsDir)
-	if !verified {
-		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() AIK certificate not verified by any trusted authority")
-		return false, nil
+	aikCert := parseSamlCertificate("AIK_Certificate", aikCertValue)
+	verified = aikCert != nil && verifySignature(aikCert, config.TpmIdentityCertsDir)
+	if aikCert != nil && !verified {
+		defaultLog.Error("keytransfer/transfer_with_saml:EvaluateSamlReport() AIK certificate not verified by any trusted authority")
 	}
+	evaluations = append(evaluations, evaluateCheck("AIK_Certificate", aikCertValue != "", verified))
 
-	if len(bindingKeyCertBytes) == 0 {
-		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() No binding key certificate in trust report")
-		return false, nil
+	bindingKeyCert := parseSamlCertificate("Binding_Key_Certificate", bindingKeyCertValue)
+	verified = bindingKeyCert != nil && verifySignature(bindingKeyCert, config.TpmIdentityCertsDir)
+	if bindingKeyCert != nil && !verified {
+		defaultLog.Error("keytransfer/transfer_with_saml:EvaluateSamlReport() Binding key certificate not verified by any trusted authority")
 	}
-
-	bindingKeyCert, err := x509.ParseCertificate(bindingKeyCertBytes)
-	if err != nil {
-		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Unable to parse Binding Key certificate")
-		return false, nil
-	}
-
-	verified = verifySignature(bindingKeyCert, config.TpmIdentityCertsDir)
-	if !verified {
-		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Binding key certificate not verified by any trusted authority")
-		return false, nil
-	}
-
-	verified = verifyTpmBindingKeyCertificate(bindingKeyCert, aikCert)
-	if !verified {
-		defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Binding key certificate has invalid attributes or cannot be verified with the AIK")
-		return false, nil
+	if verified && aikCert != nil {
+		verified = verifyTpmBindingKeyCertificate(bindingKeyCert, aikCert)
+		if !verified {
+			defaultLog.Error("keytransfer/transfer_with_saml:EvaluateSamlReport() Binding key certificate has invalid attributes or cannot be verified with the AIK")
+		}
 	}
+	evaluations = append(evaluations, evaluateCheck("Binding_Key_Certificate", bindingKeyCertValue != "", verified))
 
 	if len(usagePolicyTags) != 0 {
 		if !assetTagDeployed {
-			defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Asset tags are not deployed on the host, but a usage policy is defined for the requested key")
-			return false, nil
+			defaultLog.Error("keytransfer/transfer_with_saml:EvaluateSamlReport() Asset tags are not deployed on the host, but a usage policy is defined for the requested key")
 		}
+		evaluations = append(evaluations, evaluateClaim("TRUST_ASSET_TAG", "true", assetTag, assetTagDeployed))
 
 		// check if all the keys in tagsDeployedOnHost exist in usagePolicyTags and their values match
 		for key, value := range usagePolicyTags {
-			if v, ok := tagsDeployedOnHost[key]; !ok || strings.ToLower(v) != strings.ToLower(value) {
-				defaultLog.Error("keytransfer/transfer_with_saml:IsTrustedByHvs() Usage policy requirements of the key does not match with tags deployed on the host")
-				return false, nil
+			v, ok := tagsDeployedOnHost[key]
+			if !ok || strings.ToLower(v) != strings.ToLower(value) {
+				defaultLog.Error("keytransfer/transfer_with_saml:EvaluateSamlReport() Usage policy requirements of the key does not match with tags deployed on the host")
 			}
+			evaluations = append(evaluations, evaluateClaim("TAG_"+key, value, v, ok && strings.ToLower(v) == strings.ToLower(value)))
 		}
 	}
 
-	return true, bindingKeyCert
+	if !IsPolicySatisfied(evaluations) {
+		return evaluations, nil
+	}
+	return evaluations, bindingKeyCert
+}
+
+// parseSamlCertificate decodes and parses a certificate attribute of the saml report, nil is returned when it is absent or invalid
+func parseSamlCertificate(name, value string) *x509.Certificate {
+	defaultLog.Trace("keytransfer/transfer_with_saml:parseSamlCertificate() Entering")
+	defer defaultLog.Trace("keytransfer/transfer_with_saml:parseSamlCertificate() Leaving")
+
+	if value == "" {
+		defaultLog.Errorf("keytransfer/transfer_with_saml:parseSamlCertificate() Assertion does not include %s", name)
+		return nil
+	}
+
+	certBytes, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		defaultLog.Errorf("keytransfer/transfer_with_saml:parseSamlCertificate() Unable to decode %s", name)
+		return nil
+	}
+
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		defaultLog.Errorf("keytransfer/transfer_with_saml:parseSamlCertificate() Unable to parse %s", name)
+		return nil
+	}
+	return cert
 }
 
 //verifySamlSignature verifies signature of the saml report
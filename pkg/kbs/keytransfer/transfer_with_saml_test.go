@@ -0,0 +1,354 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package keytransfer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"encoding/xml"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/privacyca/constants"
+	samlLib "github.com/intel-secl/intel-secl/v5/pkg/lib/saml"
+)
+
+// testCertificateAuthority issues the certificates of the saml reports
+type testCertificateAuthority struct {
+	key  *rsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newTestCertificateAuthority(t *testing.T, cn string) *testCertificateAuthority {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCertificateAuthority{key: key, cert: cert}
+}
+
+func (ca *testCertificateAuthority) issue(t *testing.T, cn string, publicKey crypto.PublicKey, extensions []pkix.Extension) *x509.Certificate {
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:    serialNumber,
+		Subject:         pkix.Name{CommonName: cn},
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(time.Hour),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtraExtensions: extensions,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, ca.cert, publicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func writeCertificate(t *testing.T, dir string, cert *x509.Certificate) {
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	if err := ioutil.WriteFile(filepath.Join(dir, cert.Subject.CommonName+".pem"), certPem, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newBindingKeyCertificate issues a binding key certificate with the TPM2B_ATTEST of the binding key signed by the AIK
+func newBindingKeyCertificate(t *testing.T, privacyCa *testCertificateAuthority, aikKey *rsa.PrivateKey) *x509.Certificate {
+	bindingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var certifyInfo bytes.Buffer
+	certifyInfo.Write(constants.Tpm2CertifiedKeyMagic[:])
+	certifyInfo.Write(constants.Tpm2CertifiedKeyType[:])
+	// qualified signer and extra data
+	binary.Write(&certifyInfo, binary.BigEndian, uint16(0))
+	binary.Write(&certifyInfo, binary.BigEndian, uint16(0))
+	// clock info and firmware version
+	certifyInfo.Write(make([]byte, 8+4+4+1+8))
+	// name of the binding key
+	name := sha256.Sum256(x509.MarshalPKCS1PublicKey(&bindingKey.PublicKey))
+	binary.Write(&certifyInfo, binary.BigEndian, uint16(2+len(name)))
+	binary.Write(&certifyInfo, binary.BigEndian, uint16(constants.TPM_ALG_ID_SHA256))
+	certifyInfo.Write(name[:])
+
+	digest := sha256.Sum256(certifyInfo.Bytes())
+	signature, err := rsa.SignPKCS1v15(rand.Reader, aikKey, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// TPMT_SIGNATURE with the RSASSA scheme and the SHA256 hash ahead of the signature
+	certifySignature := append([]byte{0x00, 0x14, 0x00, 0x0b, 0x01, 0x00}, signature...)
+
+	return privacyCa.issue(t, "Binding Key Certificate", &bindingKey.PublicKey, []pkix.Extension{
+		{Id: asn1.ObjectIdentifier{2, 5, 4, 133, 3, 2, 41}, Value: certifyInfo.Bytes()},
+		{Id: asn1.ObjectIdentifier{2, 5, 4, 133, 3, 2, 41, 1}, Value: certifySignature},
+	})
+}
+
+// isTrustedByHvsBeforeEvaluations is the decision of IsTrustedByHvs before the saml report checks were collected
+// into policy attribute evaluations, a report is denied at the first check that fails
+func isTrustedByHvsBeforeEvaluations(saml string, samlReport *samlLib.Saml, usage string, config domain.KeyTransferControllerConfig) bool {
+	usagePolicyTags := make(map[string]string, 0)
+	if usage != "" {
+		for _, usagePolicy := range strings.Split(usage, ",") {
+			tagKeyValuePair := strings.Split(usagePolicy, ":")
+			usagePolicyTags[strings.ToLower(tagKeyValuePair[0])] = tagKeyValuePair[1]
+		}
+	}
+
+	saml = pattern.ReplaceAllString(saml, "<")
+	if !verifySamlSignature(saml, config.SamlCertsDir, config.TrustedCaCertsDir) {
+		return false
+	}
+
+	var err error
+	var assetTagDeployed bool
+	tagsDeployedOnHost := make(map[string]string, 0)
+	var bindingKeyCertBytes, aikCertBytes []byte
+	for _, as := range samlReport.Attribute {
+		switch as.Name {
+		case "TRUST_OVERALL":
+			if as.AttributeValue != "true" {
+				return false
+			}
+		case "tpmVersion":
+			if as.AttributeValue != "2.0" {
+				return false
+			}
+		case "Binding_Key_Certificate":
+			if bindingKeyCertBytes, err = base64.StdEncoding.DecodeString(as.AttributeValue); err != nil {
+				return false
+			}
+		case "AIK_Certificate":
+			if aikCertBytes, err = base64.StdEncoding.DecodeString(as.AttributeValue); err != nil {
+				return false
+			}
+		case "TRUST_ASSET_TAG":
+			if as.AttributeValue == "true" {
+				assetTagDeployed = true
+			}
+		default:
+			if strings.HasPrefix(as.Name, "TAG_") {
+				tagsDeployedOnHost[strings.ToLower(strings.TrimPrefix(as.Name, "TAG_"))] = as.AttributeValue
+			}
+		}
+	}
+
+	if len(aikCertBytes) == 0 {
+		return false
+	}
+	aikCert, err := x509.ParseCertificate(aikCertBytes)
+	if err != nil || !verifySignature(aikCert, config.TpmIdentityCertsDir) {
+		return false
+	}
+	if len(bindingKeyCertBytes) == 0 {
+		return false
+	}
+	bindingKeyCert, err := x509.ParseCertificate(bindingKeyCertBytes)
+	if err != nil || !verifySignature(bindingKeyCert, config.TpmIdentityCertsDir) ||
+		!verifyTpmBindingKeyCertificate(bindingKeyCert, aikCert) {
+		return false
+	}
+
+	if len(usagePolicyTags) != 0 {
+		if !assetTagDeployed {
+			return false
+		}
+		for key, value := range usagePolicyTags {
+			if v, ok := tagsDeployedOnHost[key]; !ok || strings.ToLower(v) != strings.ToLower(value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestEvaluateSamlReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kbs-saml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := domain.KeyTransferControllerConfig{
+		SamlCertsDir:        filepath.Join(dir, "saml"),
+		TrustedCaCertsDir:   filepath.Join(dir, "trustedca"),
+		TpmIdentityCertsDir: filepath.Join(dir, "tpm-identity"),
+	}
+	for _, certsDir := range []string{config.SamlCertsDir, config.TrustedCaCertsDir, config.TpmIdentityCertsDir} {
+		if err := os.Mkdir(certsDir, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rootCa := newTestCertificateAuthority(t, "Root CA")
+	writeCertificate(t, config.TrustedCaCertsDir, rootCa.cert)
+	samlKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	samlCert := rootCa.issue(t, "SAML Certificate", &samlKey.PublicKey, nil)
+	writeCertificate(t, config.SamlCertsDir, samlCert)
+
+	privacyCa := newTestCertificateAuthority(t, "Privacy CA")
+	writeCertificate(t, config.TpmIdentityCertsDir, privacyCa.cert)
+	aikKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aikCert := privacyCa.issue(t, "AIK Certificate", &aikKey.PublicKey, nil)
+	bindingKeyCert := newBindingKeyCertificate(t, privacyCa, aikKey)
+	// an AIK certificate issued by a privacy CA which is not trusted
+	untrustedAikCert := newTestCertificateAuthority(t, "Untrusted Privacy CA").issue(t, "AIK Certificate", &aikKey.PublicKey, nil)
+
+	signer, err := samlLib.NewLegacySAML(samlLib.IssuerConfiguration{
+		IssuerName:        "https://hvs.com:8443/hvs/v2",
+		IssuerServiceName: "HVS",
+		ValiditySeconds:   3600,
+		PrivateKey:        samlKey,
+		Certificate:       samlCert,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	trustedReport := func() map[string]string {
+		return map[string]string{
+			"TRUST_OVERALL":           "true",
+			"tpmVersion":              "2.0",
+			"AIK_Certificate":         base64.StdEncoding.EncodeToString(aikCert.Raw),
+			"Binding_Key_Certificate": base64.StdEncoding.EncodeToString(bindingKeyCert.Raw),
+			"TRUST_ASSET_TAG":         "true",
+			"TAG_Location":            "Folsom",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		report func(map[string]string)
+		usage  string
+		// the decisions before and after the saml report checks were collected into evaluations
+		wantBefore bool
+		want       bool
+	}{
+		{
+			name:       "trusted report",
+			report:     func(map[string]string) {},
+			wantBefore: true,
+			want:       true,
+		},
+		{
+			name:       "trusted report with a matching usage tag",
+			report:     func(map[string]string) {},
+			usage:      "location:folsom",
+			wantBefore: true,
+			want:       true,
+		},
+		{
+			name:   "untrusted report",
+			report: func(attributes map[string]string) { attributes["TRUST_OVERALL"] = "false" },
+		},
+		{
+			// the report is denied since the checks are collected into evaluations
+			name:       "report missing TRUST_OVERALL",
+			report:     func(attributes map[string]string) { delete(attributes, "TRUST_OVERALL") },
+			wantBefore: true,
+		},
+		{
+			// the report is denied since the checks are collected into evaluations
+			name:       "report missing tpmVersion",
+			report:     func(attributes map[string]string) { delete(attributes, "tpmVersion") },
+			wantBefore: true,
+		},
+		{
+			name:   "report missing AIK certificate",
+			report: func(attributes map[string]string) { delete(attributes, "AIK_Certificate") },
+		},
+		{
+			name: "report with an AIK certificate of an untrusted privacy CA",
+			report: func(attributes map[string]string) {
+				attributes["AIK_Certificate"] = base64.StdEncoding.EncodeToString(untrustedAikCert.Raw)
+			},
+		},
+		{
+			name:   "report with an invalid AIK certificate",
+			report: func(attributes map[string]string) { attributes["AIK_Certificate"] = "not a certificate" },
+		},
+		{
+			name:   "report with a mismatched usage tag",
+			report: func(map[string]string) {},
+			usage:  "location:hillsboro",
+		},
+		{
+			name:   "report without asset tag with a usage tag",
+			report: func(attributes map[string]string) { attributes["TRUST_ASSET_TAG"] = "false" },
+			usage:  "location:folsom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := trustedReport()
+			tt.report(attributes)
+			assertion, err := signer.GenerateSamlAssertion(samlLib.NewLegacyMapFormatter(attributes))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var samlReport samlLib.Saml
+			if err := xml.Unmarshal([]byte(assertion.Assertion), &samlReport); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := isTrustedByHvsBeforeEvaluations(assertion.Assertion, &samlReport, tt.usage, config); got != tt.wantBefore {
+				t.Errorf("decision before the evaluations = %v, want %v", got, tt.wantBefore)
+			}
+			evaluations, cert := EvaluateSamlReport(assertion.Assertion, &samlReport, tt.usage, config)
+			if got := IsPolicySatisfied(evaluations); got != tt.want {
+				t.Errorf("EvaluateSamlReport() satisfied = %v, want %v, evaluations = %+v", got, tt.want, evaluations)
+			}
+			if (cert != nil) != tt.want {
+				t.Errorf("EvaluateSamlReport() binding key certificate returned = %v, want %v", cert != nil, tt.want)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ type KmipClient interface {
 	InitializeClient(string, string, string, string, string, string, string, string, string) error
 	CreateSymmetricKey(int) (string, error)
 	CreateAsymmetricKeyPair(string, string, int) (string, error)
+	RegisterKey(string, int, []byte) (string, error)
 	DeleteKey(string) error
 	GetKey(string, string) ([]byte, error)
 	SendRequest(interface{}, kmip14.Operation) (*kmip.ResponseBatchItem, *ttlv.Decoder, error)
@@ -245,6 +245,87 @@ func (kc *kmipClient) CreateAsymmetricKeyPair(algorithm, curveType string, lengt
 	return respPayload.PrivateKeyUniqueIdentifier, nil
 }
 
+// RegisterKey registers an AES key or a PKCS #1 encoded RSA private key on kmip server
+func (kc *kmipClient) RegisterKey(algorithm string, length int, keyMaterial []byte) (string, error) {
+	defaultLog.Trace("kmipclient/kmipclient:RegisterKey() Entering")
+	defer defaultLog.Trace("kmipclient/kmipclient:RegisterKey() Leaving")
+
+	var objectType kmip14.ObjectType
+	var symmetricKey *kmip.SymmetricKey
+	var privateKey *kmip.PrivateKey
+	var cryptoAlgorithm kmip14.CryptographicAlgorithm
+	var usageMask kmip14.CryptographicUsageMask
+	switch algorithm {
+	case constants.CRYPTOALG_AES:
+		objectType = kmip14.ObjectTypeSymmetricKey
+		cryptoAlgorithm = kmip14.CryptographicAlgorithmAES
+		usageMask = kmip14.CryptographicUsageMaskEncrypt | kmip14.CryptographicUsageMaskDecrypt
+		symmetricKey = &kmip.SymmetricKey{
+			KeyBlock: kmip.KeyBlock{
+				KeyFormatType:          kmip14.KeyFormatTypeRaw,
+				KeyValue:               models.KeyValue{KeyMaterial: keyMaterial},
+				CryptographicAlgorithm: cryptoAlgorithm,
+				CryptographicLength:    length,
+			},
+		}
+	case constants.CRYPTOALG_RSA:
+		objectType = kmip14.ObjectTypePrivateKey
+		cryptoAlgorithm = kmip14.CryptographicAlgorithmRSA
+		usageMask = kmip14.CryptographicUsageMaskDecrypt
+		privateKey = &kmip.PrivateKey{
+			KeyBlock: kmip.KeyBlock{
+				KeyFormatType:          kmip14.KeyFormatTypePKCS_1,
+				KeyValue:               models.KeyValue{KeyMaterial: keyMaterial},
+				CryptographicAlgorithm: cryptoAlgorithm,
+				CryptographicLength:    length,
+			},
+		}
+	default:
+		return "", errors.Errorf("unsupported %s algorithm provided", algorithm)
+	}
+
+	var registerRequestPayLoad interface{}
+	if kc.KMIPVersion == constants.KMIP_2_0 {
+		registerRequestPayLoad = models.RegisterRequestPayload{
+			ObjectType: kmip20.ObjectType(objectType),
+			Attributes: models.Attributes{
+				CryptographicAlgorithm: cryptoAlgorithm,
+				CryptographicLength:    int32(length),
+				CryptographicUsageMask: usageMask,
+			},
+			SymmetricKey: symmetricKey,
+			PrivateKey:   privateKey,
+		}
+	} else {
+		registerRequestPayLoad = kmip.RegisterRequestPayload{
+			ObjectType: objectType,
+			TemplateAttribute: kmip.TemplateAttribute{
+				Attribute: []kmip.Attribute{
+					{
+						AttributeName:  "Cryptographic Usage Mask",
+						AttributeValue: usageMask,
+					},
+				},
+			},
+			SymmetricKey: symmetricKey,
+			PrivateKey:   privateKey,
+		}
+	}
+
+	batchItem, decoder, err := kc.SendRequest(registerRequestPayLoad, kmip14.OperationRegister)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to perform register key operation")
+	}
+
+	var respPayload models.RegisterResponsePayload
+	err = decoder.DecodeValue(&respPayload, batchItem.ResponsePayload.(ttlv.TTLV))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode register key response payload")
+	}
+
+	return respPayload.UniqueIdentifier, nil
+}
+
 // GetKey retrieves a key from kmip server
 func (kc *kmipClient) GetKey(keyID, algorithm string) ([]byte, error) {
 	defaultLog.Trace("kmipclient/kmipclient:GetKey() Entering")
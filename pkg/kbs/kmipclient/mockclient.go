@@ -39,6 +39,12 @@ func (m *MockKmipClient) CreateAsymmetricKeyPair(algorithm, curveType string, le
 	return args.Get(0).(string), args.Error(1)
 }
 
+// RegisterKey mocks base method
+func (m *MockKmipClient) RegisterKey(algorithm string, length int, keyMaterial []byte) (string, error) {
+	args := m.Called(algorithm, length, keyMaterial)
+	return args.Get(0).(string), args.Error(1)
+}
+
 // DeleteSymmetricKey mocks base method
 func (m *MockKmipClient) DeleteKey(id string) error {
 	args := m.Called(id)
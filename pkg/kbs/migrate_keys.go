@@ -0,0 +1,64 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package kbs
+
+import (
+	"fmt"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/directory"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/keymanager"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/kmipclient"
+	"github.com/pkg/errors"
+)
+
+// migrateKeys moves the keys of the directory key manager to the KMIP server configured in the kmip section
+func (app *App) migrateKeys() error {
+	configuration := app.configuration()
+	if configuration == nil {
+		return errors.New("Failed to load configuration")
+	}
+	if err := app.configureLogs(false, true); err != nil {
+		return err
+	}
+
+	sealer, err := keymanager.NewMasterKeySealer(configuration.Directory.MasterKeySealing, configuration.Directory.MasterKeyFile)
+	if err != nil {
+		return errors.Wrap(err, "Failed to initialize master key sealer")
+	}
+	if !sealer.Exists() {
+		return errors.New("Master key of directory key manager does not exist")
+	}
+	keyStore := directory.NewKeyStore(constants.KeysDir)
+	masterKey, err := keymanager.LoadMasterKey(sealer, keyStore)
+	if err != nil {
+		return errors.Wrap(err, "Failed to load master key")
+	}
+	directoryManager, err := keymanager.NewDirectoryManager(masterKey)
+	if err != nil {
+		return err
+	}
+
+	kmipClient := kmipclient.NewKmipClient()
+	err = kmipClient.InitializeClient(configuration.Kmip.Version, configuration.Kmip.ServerIP, configuration.Kmip.ServerPort,
+		configuration.Kmip.Hostname, configuration.Kmip.Username, configuration.Kmip.Password, configuration.Kmip.ClientKeyFilePath,
+		configuration.Kmip.ClientCertificateFilePath, configuration.Kmip.RootCertificateFilePath)
+	if err != nil {
+		return errors.Wrap(err, "Failed to initialize KMIP client")
+	}
+
+	result, err := keymanager.MigrateKeys(keyStore, directoryManager, kmipClient)
+	if result != nil {
+		fmt.Fprintf(app.consoleWriter(), "%d key(s) migrated to KMIP server\n", len(result.Migrated))
+		for _, id := range result.Skipped {
+			fmt.Fprintf(app.consoleWriter(), "Key %s skipped: algorithm is not supported by KMIP key manager\n", id)
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "Failed to migrate keys")
+	}
+	fmt.Fprintln(app.consoleWriter(), "Set key-manager to kmip in "+constants.DefaultConfigFilePath+" and restart KBS to serve the migrated keys")
+	return nil
+}
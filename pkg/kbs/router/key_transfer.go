@@ -22,12 +22,16 @@ func setKeyTransferRoutes(router *mux.Router, endpointUrl string, config domain.
 
 	keyStore := directory.NewKeyStore(constants.KeysDir)
 	policyStore := directory.NewKeyTransferPolicyStore(constants.KeysTransferPolicyDir)
+	// the SAML key transfers are not authenticated, the denied transfers are aggregated so that a client cannot flood
+	// the key transfer records
+	recordStore := directory.NewDeniedKeyTransferAggregator(directory.NewKeyTransferRecordStore(constants.KeyTransfersDir),
+		constants.DeniedKeyTransferWindow, constants.DeniedKeyTransferMaxHosts)
 	remoteManager := keymanager.NewRemoteManager(keyStore, keyManager, endpointUrl)
-	keyTransferController := controllers.NewKeyTransferController(remoteManager, policyStore, config)
+	keyTransferController := controllers.NewKeyTransferController(remoteManager, policyStore, recordStore, config)
 	keyIdExpr := "/keys/" + validation.IdReg
 
 	router.Handle(keyIdExpr+"/transfer",
-		ErrorHandler(ResponseHandler(keyTransferController.TransferWithSaml))).Methods(http.MethodPost)
+		ErrorHandler(ResponseHandler(keyTransferMetricsHandler(keyTransferSaml, keyTransferController.TransferWithSaml)))).Methods(http.MethodPost)
 
 	return router
 }
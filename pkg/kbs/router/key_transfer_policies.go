@@ -9,18 +9,20 @@ import (
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/controllers"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/directory"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/validation"
 	"net/http"
 )
 
 //setKeyTransferPolicyRoutes registers routes to perform KeyTransferPolicy CRUD operations
-func setKeyTransferPolicyRoutes(router *mux.Router) *mux.Router {
+func setKeyTransferPolicyRoutes(router *mux.Router, keyTransferConfig domain.KeyTransferControllerConfig) *mux.Router {
 	defaultLog.Trace("router/key_transfer_policy:setKeyTransferPolicyRoutes() Entering")
 	defer defaultLog.Trace("router/key_transfer_policy:setKeyTransferPolicyRoutes() Leaving")
 
 	keyStore := directory.NewKeyStore(constants.KeysDir)
 	policyStore := directory.NewKeyTransferPolicyStore(constants.KeysTransferPolicyDir)
-	transferPolicyController := controllers.NewKeyTransferPolicyController(policyStore, keyStore)
+	transferPolicyController := controllers.NewKeyTransferPolicyController(policyStore, keyStore, keyTransferConfig.DefaultTransferPolicyId)
+	policyEvaluationController := controllers.NewKeyTransferPolicyEvaluationController(policyStore, keyStore, keyTransferConfig)
 	keyTransferPolicyIdExpr := "/key-transfer-policies/" + validation.IdReg
 
 	router.Handle("/key-transfer-policies",
@@ -39,6 +41,14 @@ func setKeyTransferPolicyRoutes(router *mux.Router) *mux.Router {
 		ErrorHandler(permissionsHandler(ResponseHandler(transferPolicyController.Delete),
 			[]string{constants.KeyTransferPolicyDelete}))).Methods(http.MethodDelete)
 
+	router.Handle(keyTransferPolicyIdExpr+"/usage",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(transferPolicyController.Usage),
+			[]string{constants.KeyTransferPolicyRetrieve}))).Methods(http.MethodGet)
+
+	router.Handle(keyTransferPolicyIdExpr+"/evaluate",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(policyEvaluationController.Evaluate),
+			[]string{constants.KeyTransferPolicyEvaluate}))).Methods(http.MethodPost)
+
 	router.Handle("/key-transfer-policies",
 		ErrorHandler(permissionsHandler(JsonResponseHandler(transferPolicyController.Search),
 			[]string{constants.KeyTransferPolicySearch}))).Methods(http.MethodGet)
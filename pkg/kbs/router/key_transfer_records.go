@@ -0,0 +1,29 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/controllers"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/directory"
+)
+
+// setKeyTransferRecordRoutes registers routes to search the audit records of the key transfers
+func setKeyTransferRecordRoutes(router *mux.Router) *mux.Router {
+	defaultLog.Trace("router/key_transfer_records:setKeyTransferRecordRoutes() Entering")
+	defer defaultLog.Trace("router/key_transfer_records:setKeyTransferRecordRoutes() Leaving")
+
+	recordStore := directory.NewKeyTransferRecordStore(constants.KeyTransfersDir)
+	recordController := controllers.NewKeyTransferRecordController(recordStore)
+
+	router.Handle("/key-transfers",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(recordController.Search),
+			[]string{constants.KeyTransferRecordSearch}))).Methods(http.MethodGet)
+
+	return router
+}
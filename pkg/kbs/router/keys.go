@@ -23,8 +23,9 @@ func setKeyRoutes(router *mux.Router, endpointUrl string, defaultPolicyId uuid.U
 
 	keyStore := directory.NewKeyStore(constants.KeysDir)
 	policyStore := directory.NewKeyTransferPolicyStore(constants.KeysTransferPolicyDir)
+	recordStore := directory.NewKeyTransferRecordStore(constants.KeyTransfersDir)
 	remoteManager := keymanager.NewRemoteManager(keyStore, keyManager, endpointUrl)
-	keyController := controllers.NewKeyController(remoteManager, policyStore, defaultPolicyId)
+	keyController := controllers.NewKeyController(remoteManager, policyStore, recordStore, defaultPolicyId)
 	keyIdExpr := "/keys/" + validation.IdReg
 
 	router.Handle("/keys",
@@ -39,12 +40,16 @@ func setKeyRoutes(router *mux.Router, endpointUrl string, defaultPolicyId uuid.U
 		ErrorHandler(permissionsHandler(ResponseHandler(keyController.Delete),
 			[]string{constants.KeyDelete}))).Methods(http.MethodDelete)
 
+	router.Handle(keyIdExpr+"/rotate",
+		ErrorHandler(permissionsHandler(JsonResponseHandler(keyController.Rotate),
+			[]string{constants.KeyRotate}))).Methods(http.MethodPost)
+
 	router.Handle("/keys",
 		ErrorHandler(permissionsHandler(JsonResponseHandler(keyController.Search),
 			[]string{constants.KeySearch}))).Methods(http.MethodGet)
 
 	router.Handle(keyIdExpr,
-		ErrorHandler(permissionsHandler(JsonResponseHandler(keyController.Transfer),
+		ErrorHandler(permissionsHandler(JsonResponseHandler(keyTransferMetricsHandler(keyTransferEnvelope, keyController.Transfer)),
 			[]string{constants.KeyTransfer}))).Methods(http.MethodPost)
 
 	return router
@@ -56,12 +61,13 @@ func setSKCKeyTransferRoutes(router *mux.Router, kbsConfig *config.Configuration
 
 	keyStore := directory.NewKeyStore(constants.KeysDir)
 	policyStore := directory.NewKeyTransferPolicyStore(constants.KeysTransferPolicyDir)
+	recordStore := directory.NewKeyTransferRecordStore(constants.KeyTransfersDir)
 	remoteManager := keymanager.NewRemoteManager(keyStore, keyManager, kbsConfig.EndpointURL)
-	skcController := controllers.NewSKCController(remoteManager, policyStore, kbsConfig, constants.TrustedCaCertsDir)
+	skcController := controllers.NewSKCController(remoteManager, policyStore, recordStore, kbsConfig, constants.TrustedCaCertsDir)
 	keyIdExpr := "/keys/" + validation.IdReg
 
 	router.Handle(keyIdExpr+"/dhsm2-transfer",
-		ErrorHandler(permissionsHandlerUsingTLSMAuth(JsonResponseHandler(skcController.TransferApplicationKey),
+		ErrorHandler(permissionsHandlerUsingTLSMAuth(JsonResponseHandler(keyTransferMetricsHandler(keyTransferSKC, skcController.TransferApplicationKey)),
 			kbsConfig.AASBaseUrl, kbsConfig.KBS))).Methods("GET")
 
 	return router
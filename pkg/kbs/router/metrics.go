@@ -0,0 +1,41 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package router
+
+import (
+	"net/http"
+
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Kinds of key transfer reported by the key transfer metrics
+const (
+	keyTransferEnvelope = "envelope"
+	keyTransferSaml     = "saml"
+	keyTransferSKC      = "skc"
+)
+
+var keyTransfers = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: commMetrics.Namespace,
+	Subsystem: "kbs",
+	Name:      "key_transfers_total",
+	Help:      "Number of key transfer requests by kind and result.",
+}, []string{"kind", "result"})
+
+// keyTransferMetricsHandler counts the key transfers handled by h, the transfer succeeded when h does not return
+// an error along with a 2xx status
+func keyTransferMetricsHandler(kind string, h func(http.ResponseWriter, *http.Request) (interface{}, int, error)) func(http.ResponseWriter, *http.Request) (interface{}, int, error) {
+	return func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		data, status, err := h(w, r)
+		result := "success"
+		if err != nil || status < http.StatusOK || status >= http.StatusMultipleChoices {
+			result = "failure"
+		}
+		keyTransfers.WithLabelValues(kind, result).Inc()
+		return data, status, err
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/keymanager"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
 	cmw "github.com/intel-secl/intel-secl/v5/pkg/lib/common/middleware"
 	"github.com/pkg/errors"
 )
@@ -37,6 +38,7 @@ func InitRoutes(cfg *config.Configuration, keyTransferConfig domain.KeyTransferC
 
 	// ISECL-8715 - Prevent potential open redirects to external URLs
 	router.SkipClean(true)
+	router.Use(commMetrics.RequestMetrics())
 
 	// Define sub routes for path /kbs/v1
 	defineSubRoutes(router, "/"+strings.ToLower(constants.ServiceName)+constants.ApiVersion, cfg, keyTransferConfig, keyManager, aasClient)
@@ -50,6 +52,7 @@ func defineSubRoutes(router *mux.Router, serviceApi string, cfg *config.Configur
 
 	subRouter := router.PathPrefix(serviceApi).Subrouter()
 	subRouter = setVersionRoutes(subRouter)
+	subRouter = commMetrics.SetMetricsRoutes(subRouter)
 	subRouter = setKeyTransferRoutes(subRouter, cfg.EndpointURL, keyTransferConfig, keyManager)
 	subRouter = setSKCKeyTransferRoutes(subRouter, cfg, keyManager)
 	subRouter = setSessionRoutes(subRouter, cfg)
@@ -61,7 +64,8 @@ func defineSubRoutes(router *mux.Router, serviceApi string, cfg *config.Configur
 		constants.TrustedCaCertsDir, cfgRouter.fnGetJwtCerts,
 		cacheTime))
 	subRouter = setKeyRoutes(subRouter, cfg.EndpointURL, keyTransferConfig.DefaultTransferPolicyId, keyManager)
-	subRouter = setKeyTransferPolicyRoutes(subRouter)
+	subRouter = setKeyTransferPolicyRoutes(subRouter, keyTransferConfig)
+	subRouter = setKeyTransferRecordRoutes(subRouter)
 	subRouter = setSamlCertRoutes(subRouter)
 	subRouter = setTpmIdentityCertRoutes(subRouter)
 }
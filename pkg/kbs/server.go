@@ -18,7 +18,9 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/intel-secl/intel-secl/v5/pkg/clients"
 	"github.com/intel-secl/intel-secl/v5/pkg/clients/aas"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/config"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/directory"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/keymanager"
 	"github.com/intel-secl/intel-secl/v5/pkg/kbs/router"
@@ -53,7 +55,7 @@ func (app *App) startServer() error {
 	}
 
 	// Initialize KeyManager
-	km, err := keymanager.NewKeyManager(configuration)
+	km, err := keymanager.NewKeyManager(configuration, directory.NewKeyStore(constants.KeysDir))
 	if err != nil {
 		return err
 	}
@@ -77,6 +79,12 @@ func (app *App) startServer() error {
 		HTTPClient: client,
 	}
 
+	// Create the key transfer records directory, it does not exist on an upgraded installation
+	if err := os.MkdirAll(constants.KeyTransfersDir, 0700); err != nil {
+		defaultLog.WithError(err).Error("kbs/server:startServer() Error creating key transfer records directory")
+		return err
+	}
+
 	// Initialize routes
 	routes := router.InitRoutes(configuration, kcc, km, aasClient)
 	loggerMiddleware := middleware.LogWriterMiddleware{app.logWriter()}
@@ -121,8 +129,13 @@ func (app *App) startServer() error {
 		}
 	}()
 
+	// Dispatch key transfer records retention go routine
+	stopRetention := make(chan struct{})
+	go pruneKeyTransferRecords(configuration.KeyTransferAudit, stopRetention)
+
 	secLog.Info(commLogMsg.ServiceStart)
 	<-stop
+	close(stopRetention)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -153,3 +166,28 @@ func initKeyTransferControllerConfig() (domain.KeyTransferControllerConfig, erro
 	}
 	return kcc, nil
 }
+
+// pruneKeyTransferRecords applies the retention policy to the key transfer records at start up and then
+// periodically until stop is closed
+func pruneKeyTransferRecords(auditConfig config.KeyTransferAuditConfig, stop <-chan struct{}) {
+	defaultLog.Trace("kbs/server:pruneKeyTransferRecords() Entering")
+	defer defaultLog.Trace("kbs/server:pruneKeyTransferRecords() Leaving")
+
+	recordStore := directory.NewKeyTransferRecordStore(constants.KeyTransfersDir)
+	ticker := time.NewTicker(constants.KeyTransferPruneInterval)
+	defer ticker.Stop()
+	for {
+		deleted, err := utils.PruneKeyTransferRecords(recordStore, auditConfig, time.Now())
+		if err != nil {
+			defaultLog.WithError(err).Error("kbs/server:pruneKeyTransferRecords() Error pruning key transfer records")
+		} else if deleted > 0 {
+			secLog.Infof("kbs/server:pruneKeyTransferRecords() %d key transfer records deleted per retention policy", deleted)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -32,32 +32,38 @@ type UpdateServiceConfig struct {
 const envHelpPrompt = "Following environment variables are required for update-service-config setup:"
 
 var allowedSKCChallengeTypes = map[string]bool{"sgx": true}
-var allowedKeyManagers = map[string]bool{"kmip": true}
+var allowedKeyManagers = map[string]bool{"kmip": true, "directory": true}
+var allowedMasterKeySealings = map[string]bool{"file": true, "tpm": true}
 
 var envHelp = map[string]string{
-	"SERVICE_USERNAME":           "The service username as configured in AAS",
-	"SERVICE_PASSWORD":           "The service password as configured in AAS",
-	"LOG_LEVEL":                  "Log level",
-	"LOG_MAX_LENGTH":             "Max length of log statement",
-	"LOG_ENABLE_STDOUT":          "Enable console log",
-	"AAS_BASE_URL":               "AAS Base URL",
-	"KMIP_SERVER_IP":             "IP of KMIP server",
-	"KMIP_SERVER_PORT":           "PORT of KMIP server",
-	"KMIP_HOSTNAME":              "HOSTNAME of KMIP server",
-	"KMIP_USERNAME":              "USERNAME of KMIP server",
-	"KMIP_PASSWORD":              "PASSWORD of KMIP server",
-	"KMIP_CLIENT_CERT_PATH":      "KMIP Client certificate path",
-	"KMIP_CLIENT_KEY_PATH":       "KMIP Client key path",
-	"KMIP_ROOT_CERT_PATH":        "KMIP Root Certificate path",
-	"SKC_CHALLENGE_TYPE":         "SKC challenge type",
-	"SQVS_URL":                   "SQVS URL",
-	"SESSION_EXPIRY_TIME":        "Session Expiry Time",
-	"SERVER_PORT":                "The Port on which Server Listens to",
-	"SERVER_READ_TIMEOUT":        "Request Read Timeout Duration in Seconds",
-	"SERVER_READ_HEADER_TIMEOUT": "Request Read Header Timeout Duration in Seconds",
-	"SERVER_WRITE_TIMEOUT":       "Request Write Timeout Duration in Seconds",
-	"SERVER_IDLE_TIMEOUT":        "Request Idle Timeout in Seconds",
-	"SERVER_MAX_HEADER_BYTES":    "Max Length Of Request Header in Bytes ",
+	"SERVICE_USERNAME":                  "The service username as configured in AAS",
+	"SERVICE_PASSWORD":                  "The service password as configured in AAS",
+	"LOG_LEVEL":                         "Log level",
+	"LOG_MAX_LENGTH":                    "Max length of log statement",
+	"LOG_ENABLE_STDOUT":                 "Enable console log",
+	"AAS_BASE_URL":                      "AAS Base URL",
+	"KEY_MANAGER":                       "Key manager, kmip or directory",
+	"DIRECTORY_MASTER_KEY_FILE":         "Master key file of directory key manager",
+	"DIRECTORY_MASTER_KEY_SEALING":      "Master key sealing of directory key manager, file or tpm",
+	"KMIP_SERVER_IP":                    "IP of KMIP server",
+	"KMIP_SERVER_PORT":                  "PORT of KMIP server",
+	"KMIP_HOSTNAME":                     "HOSTNAME of KMIP server",
+	"KMIP_USERNAME":                     "USERNAME of KMIP server",
+	"KMIP_PASSWORD":                     "PASSWORD of KMIP server",
+	"KMIP_CLIENT_CERT_PATH":             "KMIP Client certificate path",
+	"KMIP_CLIENT_KEY_PATH":              "KMIP Client key path",
+	"KMIP_ROOT_CERT_PATH":               "KMIP Root Certificate path",
+	"SKC_CHALLENGE_TYPE":                "SKC challenge type",
+	"SQVS_URL":                          "SQVS URL",
+	"SESSION_EXPIRY_TIME":               "Session Expiry Time",
+	"KEY_TRANSFER_AUDIT_RETENTION_DAYS": "Number of days the key transfer records are kept, 0 keeps them indefinitely",
+	"KEY_TRANSFER_AUDIT_MAX_RECORDS":    "Maximum number of key transfer records kept, 0 for no limit",
+	"SERVER_PORT":                       "The Port on which Server Listens to",
+	"SERVER_READ_TIMEOUT":               "Request Read Timeout Duration in Seconds",
+	"SERVER_READ_HEADER_TIMEOUT":        "Request Read Header Timeout Duration in Seconds",
+	"SERVER_WRITE_TIMEOUT":              "Request Write Timeout Duration in Seconds",
+	"SERVER_IDLE_TIMEOUT":               "Request Idle Timeout in Seconds",
+	"SERVER_MAX_HEADER_BYTES":           "Max Length Of Request Header in Bytes ",
 }
 
 func (uc UpdateServiceConfig) Run() error {
@@ -95,12 +101,20 @@ func (uc UpdateServiceConfig) Run() error {
 		RootCertificateFilePath:   viper.GetString(config.KmipRootCertPath),
 	}
 	(*uc.AppConfig).KeyManager = viper.GetString(config.KeyManager)
+	(*uc.AppConfig).Directory = config.DirectoryConfig{
+		MasterKeyFile:    viper.GetString(config.DirectoryMasterKeyFile),
+		MasterKeySealing: viper.GetString(config.DirectoryMasterKeySealing),
+	}
 
 	(*uc.AppConfig).Skc = config.SKCConfig{
 		StmLabel:          viper.GetString("skc-challenge-type"),
 		SQVSUrl:           viper.GetString("sqvs-url"),
 		SessionExpiryTime: viper.GetInt("session-expiry-time"),
 	}
+	(*uc.AppConfig).KeyTransferAudit = config.KeyTransferAuditConfig{
+		RetentionDays: viper.GetInt(config.KeyTransferAuditRetentionDays),
+		MaxRecords:    viper.GetInt(config.KeyTransferAuditMaxRecords),
+	}
 	return nil
 }
 
@@ -122,7 +136,15 @@ func (uc UpdateServiceConfig) Validate() error {
 		return errors.New("tasks/update_service_config:Validate() Configured Log Length not valid. Please specify value within " + strconv.Itoa(constants.MinLogLengthLimit) + " and " + strconv.Itoa(constants.MaxLogLengthLimit))
 	}
 	if _, validInput := allowedKeyManagers[strings.ToLower((*uc.AppConfig).KeyManager)]; !validInput {
-		return errors.New("Invalid value provided for KEY_MANAGER. Value should be kmip")
+		return errors.New("Invalid value provided for KEY_MANAGER. Value should be kmip or directory")
+	}
+	if strings.ToLower((*uc.AppConfig).KeyManager) == constants.DirectoryKeyManager {
+		if _, validInput := allowedMasterKeySealings[strings.ToLower((*uc.AppConfig).Directory.MasterKeySealing)]; !validInput {
+			return errors.New("Invalid value provided for DIRECTORY_MASTER_KEY_SEALING. Value should be file or tpm")
+		}
+		if (*uc.AppConfig).Directory.MasterKeyFile == "" {
+			return errors.New("DIRECTORY_MASTER_KEY_FILE is not set")
+		}
 	}
 	if (*uc.AppConfig).Skc.StmLabel != "" {
 		if _, validInput := allowedSKCChallengeTypes[strings.ToLower((*uc.AppConfig).Skc.StmLabel)]; !validInput {
@@ -132,6 +154,12 @@ func (uc UpdateServiceConfig) Validate() error {
 	if (*uc.AppConfig).Skc.SessionExpiryTime <= 0 {
 		return errors.New("Invalid value provided for SESSION_EXPIRY_TIME. Value should be greater than 0")
 	}
+	if (*uc.AppConfig).KeyTransferAudit.RetentionDays < 0 {
+		return errors.New("Invalid value provided for KEY_TRANSFER_AUDIT_RETENTION_DAYS. Value should not be negative")
+	}
+	if (*uc.AppConfig).KeyTransferAudit.MaxRecords < 0 {
+		return errors.New("Invalid value provided for KEY_TRANSFER_AUDIT_MAX_RECORDS. Value should not be negative")
+	}
 	return nil
 }
 func (uc UpdateServiceConfig) PrintHelp(w io.Writer) {
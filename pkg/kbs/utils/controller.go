@@ -6,7 +6,9 @@ package utils
 
 import (
 	"net/url"
+	"time"
 
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/constants"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
 	"github.com/pkg/errors"
 )
@@ -29,3 +31,20 @@ func ValidateQueryParams(params url.Values, validQueries map[string]bool) error
 	}
 	return nil
 }
+
+func ParseDateQueryParam(dt string) (time.Time, error) {
+	defaultLog.Trace("utils/controller:ParseDateQueryParam() Entering")
+	defer defaultLog.Trace("utils/controller:ParseDateQueryParam() Leaving")
+
+	pTime, err := time.Parse(constants.ParamDateFormat, dt)
+	if err != nil {
+		pTime, err = time.Parse(constants.ParamDateTimeFormat, dt)
+		if err != nil {
+			pTime, err = time.Parse(time.RFC3339Nano, dt)
+			if err != nil {
+				return time.Time{}, errors.Wrap(err, "One of Valid date formats (YYYY-MM-DD)|(YYYY-MM-DD hh:mm:ss)|(YYYY-MM-DDThh:mm:ss.000Z)|(YYYY-MM-DDThh:mm:ss.000000Z) must be specified")
+			}
+		}
+	}
+	return pTime, nil
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"time"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/config"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain"
+	"github.com/pkg/errors"
+)
+
+// PruneKeyTransferRecords deletes the key transfer records older than the retention days and the oldest records
+// in excess of the maximum number of records. It returns the number of deleted records.
+func PruneKeyTransferRecords(store domain.KeyTransferRecordStore, auditConfig config.KeyTransferAuditConfig, now time.Time) (int, error) {
+	defaultLog.Trace("utils/key_transfer_record:PruneKeyTransferRecords() Entering")
+	defer defaultLog.Trace("utils/key_transfer_record:PruneKeyTransferRecords() Leaving")
+
+	if auditConfig.RetentionDays <= 0 && auditConfig.MaxRecords <= 0 {
+		return 0, nil
+	}
+
+	var createdBefore time.Time
+	if auditConfig.RetentionDays > 0 {
+		createdBefore = now.AddDate(0, 0, -auditConfig.RetentionDays)
+	}
+	deleted, err := store.Prune(createdBefore, auditConfig.MaxRecords)
+	if err != nil {
+		return deleted, errors.Wrap(err, "Failed to prune key transfer records")
+	}
+	return deleted, nil
+}
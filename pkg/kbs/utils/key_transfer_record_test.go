@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/config"
+	"github.com/intel-secl/intel-secl/v5/pkg/kbs/domain/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/kbs"
+)
+
+func TestPruneKeyTransferRecords(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		auditConfig config.KeyTransferAuditConfig
+		wantDeleted int
+		wantKept    []int
+	}{
+		{
+			name:        "retention days",
+			auditConfig: config.KeyTransferAuditConfig{RetentionDays: 30},
+			wantDeleted: 2,
+			wantKept:    []int{0, 10},
+		},
+		{
+			name:        "max records",
+			auditConfig: config.KeyTransferAuditConfig{MaxRecords: 3},
+			wantDeleted: 1,
+			wantKept:    []int{0, 10, 40},
+		},
+		{
+			name:        "retention days and max records",
+			auditConfig: config.KeyTransferAuditConfig{RetentionDays: 30, MaxRecords: 1},
+			wantDeleted: 3,
+			wantKept:    []int{0},
+		},
+		{
+			name:        "no retention",
+			auditConfig: config.KeyTransferAuditConfig{},
+			wantDeleted: 0,
+			wantKept:    []int{0, 10, 40, 90},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := mocks.NewFakeKeyTransferRecordStore()
+			ages := map[uuid.UUID]int{}
+			for _, age := range []int{40, 0, 90, 10} {
+				record, _ := store.Create(&kbs.KeyTransferRecord{CreatedAt: now.AddDate(0, 0, -age)})
+				ages[record.ID] = age
+			}
+
+			deleted, err := PruneKeyTransferRecords(store, tt.auditConfig, now)
+			if err != nil {
+				t.Fatalf("PruneKeyTransferRecords() error = %v", err)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("PruneKeyTransferRecords() deleted = %d, want %d", deleted, tt.wantDeleted)
+			}
+			records, _ := store.Search(nil)
+			if len(records) != len(tt.wantKept) {
+				t.Fatalf("PruneKeyTransferRecords() kept %d records, want %d", len(records), len(tt.wantKept))
+			}
+			for i, record := range records {
+				if ages[record.ID] != tt.wantKept[i] {
+					t.Errorf("PruneKeyTransferRecords() kept record of age %d, want %d", ages[record.ID], tt.wantKept[i])
+				}
+			}
+		})
+	}
+}
@@ -14,4 +14,6 @@ const (
 	HTTPMediaTypePemFile     = "application/x-pem-file"
 	HTTPMediaTypePkixCrl     = "application/pkix-crl"
 	HTTPMediaTypeOctetStream = "application/octet-stream"
+	HTTPMediaTypeCsv         = "text/csv"
+	HTTPMediaTypeMarkdown    = "text/markdown"
 )
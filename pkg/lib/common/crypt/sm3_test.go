@@ -0,0 +1,51 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package crypt
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestNewSM3(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "Validate SM3 of empty input",
+			input: "",
+			want:  "1ab21d8355cfa17f8e61194831e81a8f22bec8c728fefb747ed035eb5082aa2b",
+		},
+		{
+			name:  "Validate SM3 of GB/T 32905 example 1",
+			input: "abc",
+			want:  "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0",
+		},
+		{
+			name:  "Validate SM3 of GB/T 32905 example 2",
+			input: strings.Repeat("abcd", 16),
+			want:  "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewSM3()
+			// write in two parts to go through the partial block handling
+			h.Write([]byte(tt.input[:len(tt.input)/3]))
+			h.Write([]byte(tt.input[len(tt.input)/3:]))
+			if got := hex.EncodeToString(h.Sum(nil)); got != tt.want {
+				t.Errorf("NewSM3() = %v, want %v", got, tt.want)
+			}
+			// Sum does not change the state of the hash
+			if got := hex.EncodeToString(h.Sum(nil)); got != tt.want {
+				t.Errorf("NewSM3() second Sum = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
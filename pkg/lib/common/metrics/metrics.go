@@ -0,0 +1,130 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+// Package metrics exposes the operational metrics of the services in the Prometheus text format. The request
+// metrics are recorded by RequestMetrics for every route of the router, the services register their own collectors
+// with the default Prometheus registry using the Namespace of this package.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// Namespace is the prefix of all the metrics exposed by the services
+	Namespace = "isecl"
+
+	// MetricsPath is the path of the metrics endpoint relative to the service API path
+	MetricsPath = "/metrics"
+
+	unmatchedRoute = "unmatched"
+)
+
+var (
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the HTTP requests by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	responses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "responses_total",
+		Help:      "Number of HTTP responses by route and status code.",
+	}, []string{"method", "route", "code"})
+)
+
+// SetMetricsRoutes registers the endpoint serving the metrics. The endpoint does not require authentication so
+// that it can be scraped, only aggregated values and route templates are exposed.
+func SetMetricsRoutes(router *mux.Router) *mux.Router {
+	router.Handle(MetricsPath, promhttp.Handler()).Methods(http.MethodGet)
+	return router
+}
+
+// RequestMetrics returns a middleware recording the latency and the status code of every request matched by the
+// router. The requests are labelled with the path template of the route instead of the path, so that the IDs in
+// the path do not create a time series per resource.
+func RequestMetrics() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			startTime := time.Now()
+			sw := &statusResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r)
+
+			route := unmatchedRoute
+			if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+				if tpl, err := currentRoute.GetPathTemplate(); err == nil {
+					route = RouteName(tpl)
+				}
+			}
+			if sw.statusCode == 0 {
+				sw.statusCode = http.StatusOK
+			}
+			requestDuration.WithLabelValues(r.Method, route).Observe(time.Since(startTime).Seconds())
+			responses.WithLabelValues(r.Method, route, strconv.Itoa(sw.statusCode)).Inc()
+		})
+	}
+}
+
+// RouteName strips the regular expressions from the variables of a path template, for example
+// "/hvs/v2/hosts/{hId:[0-9a-f-]{36}}" becomes "/hvs/v2/hosts/{hId}"
+func RouteName(tpl string) string {
+	var name strings.Builder
+	for i := 0; i < len(tpl); i++ {
+		if tpl[i] != '{' {
+			name.WriteByte(tpl[i])
+			continue
+		}
+		// copy the variable name and skip its pattern, which can contain nested braces
+		depth := 1
+		inPattern := false
+		name.WriteByte('{')
+		for i++; i < len(tpl) && depth > 0; i++ {
+			switch {
+			case tpl[i] == '{':
+				depth++
+			case tpl[i] == '}':
+				depth--
+			case tpl[i] == ':' && depth == 1:
+				inPattern = true
+			case !inPattern:
+				name.WriteByte(tpl[i])
+			}
+		}
+		name.WriteByte('}')
+		i--
+	}
+	return name.String()
+}
+
+type statusResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusResponseWriter) WriteHeader(code int) {
+	if w.statusCode == 0 {
+		w.statusCode = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusResponseWriter) Write(body []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.ResponseWriter.Write(body)
+}
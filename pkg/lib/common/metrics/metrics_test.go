@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/validation"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteName(t *testing.T) {
+	assert.Equal(t, "/hvs/v2/version", RouteName("/hvs/v2/version"))
+	assert.Equal(t, "/hvs/v2/hosts/{id}", RouteName("/hvs/v2/hosts/"+validation.IdReg))
+	assert.Equal(t, "/kbs/v1/keys/{id}/transfer", RouteName("/kbs/v1/keys/"+validation.IdReg+"/transfer"))
+	assert.Equal(t, "/reports/{id}/{name}", RouteName("/reports/{id}/{name:[a-z]{2,4}}"))
+}
+
+func TestRequestMetrics(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(RequestMetrics())
+	subRouter := router.PathPrefix("/test/v1").Subrouter()
+	SetMetricsRoutes(subRouter)
+	subRouter.HandleFunc("/items/"+validation.IdReg, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods(http.MethodGet)
+	subRouter.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("[]"))
+	}).Methods(http.MethodGet)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	serve("/test/v1/items/4ec8a8b6-4f2c-4b3e-8a2e-3d2c0f9e1a7b")
+	serve("/test/v1/items/0b3f2c9d-6a1e-4d8f-9c2b-7e5a4d3c2b1a")
+	serve("/test/v1/items")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(responses.WithLabelValues(http.MethodGet, "/test/v1/items/{id}", "404")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(responses.WithLabelValues(http.MethodGet, "/test/v1/items", "200")))
+
+	w := serve("/test/v1" + MetricsPath)
+	assert.Equal(t, http.StatusOK, w.Code)
+	body, err := ioutil.ReadAll(w.Body)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(body), `isecl_http_request_duration_seconds_count{method="GET",route="/test/v1/items/{id}"} 2`))
+}
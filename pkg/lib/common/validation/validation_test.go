@@ -956,6 +956,20 @@ func TestValidateConnectionString(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Validate connection string with host bundle file",
+			args: args{
+				cs: "bundle:file:///opt/hvs/host-bundles/host1.json;u=admin;p=password",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Validate connection string with host bundle over https",
+			args: args{
+				cs: "bundle:https://127.0.0.1:1443/host1.json",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
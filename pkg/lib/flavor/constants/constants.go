@@ -34,10 +34,12 @@ const (
 	FlavorWoTimestampFormat = "2006-01-02T15:04:05.999999-07:00"
 
 	//PCR Info
-	PCR22  = 22
-	SHA256 = "SHA256"
-	SHA384 = "SHA384"
-	SHA1   = "SHA1"
+	PCR22   = 22
+	SHA256  = "SHA256"
+	SHA384  = "SHA384"
+	SHA512  = "SHA512"
+	SM3_256 = "SM3_256"
+	SHA1    = "SHA1"
 
 	//IMA label
 	IMA = "Isecl_IMA"
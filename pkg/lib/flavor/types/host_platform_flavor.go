@@ -443,9 +443,12 @@ func UpdateMetaSectionDetails(flavorPart hvs.FlavorPartName, newMeta *hvs.Meta,
 //getVendorName This method is used to get the vendor name
 func (pf HostPlatformFlavor) getVendorName() hcConstants.Vendor {
 	var vendorName hcConstants.Vendor
-	if strings.ToLower(pf.HostManifest.HostInfo.OSType) == taModel.OsTypeLinux {
+	switch strings.ToLower(pf.HostManifest.HostInfo.OSType) {
+	case taModel.OsTypeLinux:
 		vendorName = hcConstants.VendorIntel
-	} else {
+	case taModel.OsTypeWindows:
+		vendorName = hcConstants.VendorMicrosoft
+	default:
 		vendorName = hcConstants.VendorVMware
 	}
 	return vendorName
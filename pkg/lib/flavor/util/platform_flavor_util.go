@@ -223,6 +223,12 @@ func (pfutil PlatformFlavorUtil) GetHardwareSectionDetails(hostManifest *hvs.Hos
 	if hostManifest.PcrManifest.Sha384Pcrs != nil && len(hostManifest.PcrManifest.Sha384Pcrs) > 0 {
 		tpm.Meta.PCRBanks = append(tpm.Meta.PCRBanks, string(hvs.SHA384))
 	}
+	if hostManifest.PcrManifest.Sha512Pcrs != nil && len(hostManifest.PcrManifest.Sha512Pcrs) > 0 {
+		tpm.Meta.PCRBanks = append(tpm.Meta.PCRBanks, string(hvs.SHA512))
+	}
+	if hostManifest.PcrManifest.Sm3Pcrs != nil && len(hostManifest.PcrManifest.Sm3Pcrs) > 0 {
+		tpm.Meta.PCRBanks = append(tpm.Meta.PCRBanks, string(hvs.SM3_256))
+	}
 	feature.TPM = tpm
 
 	if hostInfo.HardwareFeatures.TXT != nil {
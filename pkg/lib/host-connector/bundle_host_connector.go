@@ -0,0 +1,214 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"io/ioutil"
+	"time"
+
+	hvsConstants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/util"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v5/pkg/model/ta"
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+// BundleConnector reads the attestation data of a host from the host bundle the host pushed to the HVS, the host
+// is never contacted so asset tags and software manifests cannot be deployed
+type BundleConnector struct {
+	bundlePath string
+	maxAge     time.Duration
+}
+
+func (bc *BundleConnector) readHostBundle() (*hvs.HostBundle, error) {
+	bundleBytes, err := ioutil.ReadFile(bc.bundlePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "bundle_host_connector:readHostBundle() Error reading host bundle %s", bc.bundlePath)
+	}
+	var hostBundle hvs.HostBundle
+	err = json.Unmarshal(bundleBytes, &hostBundle)
+	if err != nil {
+		return nil, errors.Wrapf(err, "bundle_host_connector:readHostBundle() Error unmarshalling host bundle %s", bc.bundlePath)
+	}
+	return &hostBundle, nil
+}
+
+func (bc *BundleConnector) GetHostDetails() (taModel.HostInfo, error) {
+	log.Trace("bundle_host_connector:GetHostDetails() Entering")
+	defer log.Trace("bundle_host_connector:GetHostDetails() Leaving")
+
+	hostBundle, err := bc.readHostBundle()
+	if err != nil {
+		return taModel.HostInfo{}, err
+	}
+	var hostInfo taModel.HostInfo
+	err = json.Unmarshal(hostBundle.HostInfo, &hostInfo)
+	if err != nil {
+		return taModel.HostInfo{}, errors.Wrap(err, "bundle_host_connector:GetHostDetails() Error unmarshalling host info")
+	}
+	return hostInfo, nil
+}
+
+// GetHostManifest returns the host manifest of the bundle, the PCRs are the ones quoted by the host when the bundle
+// was created. Once the bundle is older than the max age the manifest only contains the host info, so that the
+// host is reported as untrusted until it pushes a new bundle
+func (bc *BundleConnector) GetHostManifest(pcrList []int) (hvs.HostManifest, error) {
+	log.Trace("bundle_host_connector:GetHostManifest() Entering")
+	defer log.Trace("bundle_host_connector:GetHostManifest() Leaving")
+
+	hostBundle, err := bc.readHostBundle()
+	if err != nil {
+		return hvs.HostManifest{}, err
+	}
+	hostManifest, err := VerifyHostBundle(hostBundle)
+	if err != nil {
+		return hvs.HostManifest{}, errors.Wrap(err, "bundle_host_connector:GetHostManifest() Error creating host manifest")
+	}
+	if err = CheckHostBundleAge(hostBundle, bc.maxAge); err != nil {
+		secLog.WithError(err).Warnf("bundle_host_connector:GetHostManifest() The quote of host bundle %s is not used", bc.bundlePath)
+		return hvs.HostManifest{HostInfo: hostManifest.HostInfo}, nil
+	}
+	return *hostManifest, nil
+}
+
+// GetTPMQuoteResponse returns the TPM quote of the bundle, the quote is for the nonce of the bundle and not for the
+// provided nonce since the host cannot be challenged
+func (bc *BundleConnector) GetTPMQuoteResponse(nonce string, pcrList []int) ([]byte, []byte, *x509.Certificate, *pem.Block, taModel.TpmQuoteResponse, error) {
+	log.Trace("bundle_host_connector:GetTPMQuoteResponse() Entering")
+	defer log.Trace("bundle_host_connector:GetTPMQuoteResponse() Leaving")
+
+	hostBundle, err := bc.readHostBundle()
+	if err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, err
+	}
+	if err = CheckHostBundleAge(hostBundle, bc.maxAge); err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, err
+	}
+	return getHostBundleTpmQuote(hostBundle)
+}
+
+func (bc *BundleConnector) DeployAssetTag(hardwareUUID, tag string) error {
+	return errors.New("bundle_host_connector:DeployAssetTag() Operation not supported")
+}
+
+func (bc *BundleConnector) DeploySoftwareManifest(manifest taModel.Manifest) error {
+	return errors.New("bundle_host_connector:DeploySoftwareManifest() Operation not supported")
+}
+
+func (bc *BundleConnector) GetMeasurementFromManifest(manifest taModel.Manifest) (taModel.Measurement, error) {
+	return taModel.Measurement{}, errors.New("bundle_host_connector:GetMeasurementFromManifest() Operation not supported")
+}
+
+func (bc *BundleConnector) GetClusterReference(clusterName string) ([]mo.HostSystem, error) {
+	return nil, errors.New("bundle_host_connector:GetClusterReference() Operation not supported")
+}
+
+// CheckHostBundleAge returns an error when the host bundle is older than the max age or was created in the future,
+// the creation time is covered by the quote of the bundle so it cannot be changed by replaying an old bundle
+func CheckHostBundleAge(hostBundle *hvs.HostBundle, maxAge time.Duration) error {
+	now := time.Now()
+	if hostBundle.Created.After(now.Add(hvsConstants.HostBundleMaxClockSkew)) {
+		return errors.Errorf("bundle_host_connector:CheckHostBundleAge() The host bundle was created in the future at %s",
+			hostBundle.Created.Format(time.RFC3339))
+	}
+	if hostBundle.Created.Before(now.Add(-maxAge)) {
+		return errors.Errorf("bundle_host_connector:CheckHostBundleAge() The host bundle created at %s is older than %s",
+			hostBundle.Created.Format(time.RFC3339), maxAge)
+	}
+	return nil
+}
+
+// getHostBundleTpmQuote checks that the nonce of the bundle matches its host info and creation time and returns the
+// TPM quote details in the same form as IntelConnector.GetTPMQuoteResponse
+func getHostBundleTpmQuote(hostBundle *hvs.HostBundle) ([]byte, []byte, *x509.Certificate, *pem.Block, taModel.TpmQuoteResponse, error) {
+	if hostBundle.Nonce != hvs.GetHostBundleNonce(hostBundle.HostInfo, hostBundle.Created) {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, errors.New("bundle_host_connector:getHostBundleTpmQuote() " +
+			"The nonce of the host bundle does not match its host info and creation time")
+	}
+
+	var tpmQuoteResponse taModel.TpmQuoteResponse
+	err := xml.Unmarshal([]byte(hostBundle.TpmQuoteResponse), &tpmQuoteResponse)
+	if err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, errors.Wrap(err, "bundle_host_connector:getHostBundleTpmQuote() "+
+			"Error unmarshalling TPM quote response")
+	}
+
+	nonceInBytes, err := base64.StdEncoding.DecodeString(hostBundle.Nonce)
+	if err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, errors.Wrap(err, "bundle_host_connector:getHostBundleTpmQuote() "+
+			"Base64 decode of TPM nonce failed")
+	}
+	verificationNonce, err := util.GetVerificationNonce(nonceInBytes, tpmQuoteResponse)
+	if err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, err
+	}
+	verificationNonceInBytes, err := base64.StdEncoding.DecodeString(verificationNonce)
+	if err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, errors.Wrap(err, "bundle_host_connector:getHostBundleTpmQuote() "+
+			"Error converting nonce to bytes")
+	}
+
+	aikCertInBytes, err := base64.StdEncoding.DecodeString(tpmQuoteResponse.Aik)
+	if err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, errors.Wrap(err, "bundle_host_connector:getHostBundleTpmQuote() "+
+			"Error decoding AIK certificate to bytes")
+	}
+	aikPem, _ := pem.Decode(aikCertInBytes)
+	if aikPem == nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, errors.New("bundle_host_connector:getHostBundleTpmQuote() " +
+			"Error decoding AIK certificate PEM")
+	}
+	aikCertificate, err := x509.ParseCertificate(aikPem.Bytes)
+	if err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, errors.Wrap(err, "bundle_host_connector:getHostBundleTpmQuote() "+
+			"Error parsing AIK certificate")
+	}
+
+	tpmQuoteInBytes, err := base64.StdEncoding.DecodeString(tpmQuoteResponse.Quote)
+	if err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, errors.Wrap(err, "bundle_host_connector:getHostBundleTpmQuote() "+
+			"Error converting tpm quote to bytes")
+	}
+	return verificationNonceInBytes, tpmQuoteInBytes, aikCertificate, aikPem, tpmQuoteResponse, nil
+}
+
+// VerifyHostBundle verifies the TPM quote of a host bundle, which covers the host info through the nonce, and returns
+// the host manifest of the host
+func VerifyHostBundle(hostBundle *hvs.HostBundle) (*hvs.HostManifest, error) {
+	log.Trace("bundle_host_connector:VerifyHostBundle() Entering")
+	defer log.Trace("bundle_host_connector:VerifyHostBundle() Leaving")
+
+	verificationNonceInBytes, tpmQuoteInBytes, aikCertificate, aikPem, tpmQuoteResponse, err := getHostBundleTpmQuote(hostBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	var hostManifest hvs.HostManifest
+	err = json.Unmarshal(hostBundle.HostInfo, &hostManifest.HostInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "bundle_host_connector:VerifyHostBundle() Error unmarshalling host info")
+	}
+
+	err = setHostManifestFromTpmQuote(&hostManifest, verificationNonceInBytes, tpmQuoteInBytes, aikCertificate, aikPem, tpmQuoteResponse)
+	if err != nil {
+		return nil, errors.Wrap(err, "bundle_host_connector:VerifyHostBundle() Error creating host manifest from TPM Quote")
+	}
+
+	if hostBundle.BindingKeyCertificate != "" {
+		bindingKeyCertificate, _ := pem.Decode([]byte(hostBundle.BindingKeyCertificate))
+		if bindingKeyCertificate == nil {
+			return nil, errors.New("bundle_host_connector:VerifyHostBundle() Could not decode Binding key certificate")
+		}
+		hostManifest.BindingKeyCertificate = base64.StdEncoding.EncodeToString(bindingKeyCertificate.Bytes)
+	}
+	log.Info("bundle_host_connector:VerifyHostBundle() Host manifest created successfully from host bundle")
+	return &hostManifest, nil
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"crypto/x509"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	hvsConstants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+type BundleConnectorFactory struct {
+	bundleDir string
+	maxAge    time.Duration
+}
+
+// NewBundleConnectorFactory returns a factory for the bundles in bundleDir, the quote of a bundle is not used once
+// the bundle is older than maxAge and the default max age is used when maxAge is not set
+func NewBundleConnectorFactory(bundleDir string, maxAge time.Duration) *BundleConnectorFactory {
+	if maxAge <= 0 {
+		maxAge = hvsConstants.DefaultHostBundleMaxAge
+	}
+	return &BundleConnectorFactory{bundleDir, maxAge}
+}
+
+// GetHostConnector returns a connector for connection strings in the form bundle:file:///<bundle path>, the bundle
+// must be in the host bundle directory of the HVS
+func (bcf *BundleConnectorFactory) GetHostConnector(vendorConnector types.VendorConnector, aasApiUrl string,
+	trustedCaCerts []x509.Certificate, imaMeasureEnabled bool) (HostConnector, error) {
+
+	log.Trace("bundle_host_connector_factory:GetHostConnector() Entering")
+	defer log.Trace("bundle_host_connector_factory:GetHostConnector() Leaving")
+
+	bundleURL, err := url.Parse(vendorConnector.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "bundle_host_connector_factory:GetHostConnector() Error parsing host bundle URL")
+	}
+	if bundleURL.Scheme != "file" {
+		return nil, errors.Errorf("bundle_host_connector_factory:GetHostConnector() Unsupported host bundle URL scheme '%s'", bundleURL.Scheme)
+	}
+
+	bundlePath := filepath.Clean(bundleURL.Path)
+	if !strings.HasPrefix(bundlePath, filepath.Clean(bcf.bundleDir)+string(filepath.Separator)) {
+		return nil, errors.Errorf("bundle_host_connector_factory:GetHostConnector() Host bundle must be in %s", bcf.bundleDir)
+	}
+	return &BundleConnector{bundlePath: bundlePath, maxAge: bcf.maxAge}, nil
+}
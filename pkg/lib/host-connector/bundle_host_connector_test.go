@@ -0,0 +1,135 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package host_connector_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	host_connector "github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/mocks"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/types"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v5/pkg/model/ta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyHostBundle(t *testing.T) {
+	hostInfo, err := ioutil.ReadFile("./test/sample_platform_info.json")
+	assert.NoError(t, err)
+	hostBundle, err := mocks.NewMockHostBundle(hostInfo)
+	assert.NoError(t, err)
+
+	hostManifest, err := host_connector.VerifyHostBundle(hostBundle)
+	assert.NoError(t, err)
+	assert.Equal(t, "RedHatEnterprise", hostManifest.HostInfo.OSName)
+	assert.NotEmpty(t, hostManifest.AIKCertificate)
+	pcr, err := hostManifest.PcrManifest.GetPcrValue(hvs.SHA256, hvs.PCR0)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pcr.Value)
+
+	// the nonce covers the creation time and the host info of the bundle
+	hostBundle.Created = hostBundle.Created.Add(time.Hour)
+	_, err = host_connector.VerifyHostBundle(hostBundle)
+	assert.Error(t, err)
+
+	hostBundle.Created = hostBundle.Created.Add(-time.Hour)
+	hostBundle.HostInfo = []byte(`{"os_name":"RedHatEnterprise"}`)
+	_, err = host_connector.VerifyHostBundle(hostBundle)
+	assert.Error(t, err)
+
+	// a quote that is not for the nonce of the bundle is rejected
+	hostBundle.Nonce = hvs.GetHostBundleNonce(hostBundle.HostInfo, hostBundle.Created)
+	_, err = host_connector.VerifyHostBundle(hostBundle)
+	assert.Error(t, err)
+}
+
+func TestBundleConnector(t *testing.T) {
+	hostInfo, err := ioutil.ReadFile("./test/sample_platform_info.json")
+	assert.NoError(t, err)
+	hostBundle, err := mocks.NewMockHostBundle(hostInfo)
+	assert.NoError(t, err)
+
+	bundleDir, err := ioutil.TempDir("", "host-bundles")
+	assert.NoError(t, err)
+	defer os.RemoveAll(bundleDir)
+	bundlePath := filepath.Join(bundleDir, "host.json")
+	bundleBytes, err := json.Marshal(hostBundle)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(bundlePath, bundleBytes, 0600))
+
+	factory := host_connector.NewBundleConnectorFactory(bundleDir, time.Hour)
+	connector, err := factory.GetHostConnector(vendorConnector("file://"+bundlePath), "", nil, false)
+	assert.NoError(t, err)
+
+	hostDetails, err := connector.GetHostDetails()
+	assert.NoError(t, err)
+	assert.Equal(t, "Intel Corporation", hostDetails.BiosName)
+
+	hostManifest, err := connector.GetHostManifest(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "RedHatEnterprise", hostManifest.HostInfo.OSName)
+
+	_, _, _, _, _, err = connector.GetTPMQuoteResponse("", nil)
+	assert.NoError(t, err)
+
+	assert.Error(t, connector.DeployAssetTag("", ""))
+	assert.Error(t, connector.DeploySoftwareManifest(taModel.Manifest{}))
+
+	// bundles outside of the bundle directory are rejected
+	_, err = factory.GetHostConnector(vendorConnector("file://"+bundleDir+"/../host.json"), "", nil, false)
+	assert.Error(t, err)
+	_, err = factory.GetHostConnector(vendorConnector("https://"+bundlePath), "", nil, false)
+	assert.Error(t, err)
+}
+
+func TestBundleConnectorStaleBundle(t *testing.T) {
+	hostInfo, err := ioutil.ReadFile("./test/sample_platform_info.json")
+	assert.NoError(t, err)
+	hostBundle, err := mocks.NewMockHostBundleCreatedAt(hostInfo, time.Now().Add(-2*time.Hour))
+	assert.NoError(t, err)
+
+	bundleDir, err := ioutil.TempDir("", "host-bundles")
+	assert.NoError(t, err)
+	defer os.RemoveAll(bundleDir)
+	bundlePath := filepath.Join(bundleDir, "host.json")
+	bundleBytes, err := json.Marshal(hostBundle)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(bundlePath, bundleBytes, 0600))
+
+	factory := host_connector.NewBundleConnectorFactory(bundleDir, time.Hour)
+	connector, err := factory.GetHostConnector(vendorConnector("file://"+bundlePath), "", nil, false)
+	assert.NoError(t, err)
+
+	// the quote of a stale bundle is not used so that the host is untrusted
+	hostManifest, err := connector.GetHostManifest(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "RedHatEnterprise", hostManifest.HostInfo.OSName)
+	assert.Empty(t, hostManifest.AIKCertificate)
+	assert.Empty(t, hostManifest.QuoteDigest)
+	assert.Empty(t, hostManifest.PcrManifest.Sha256Pcrs)
+
+	_, _, _, _, _, err = connector.GetTPMQuoteResponse("", nil)
+	assert.Error(t, err)
+}
+
+func TestCheckHostBundleAge(t *testing.T) {
+	assert.NoError(t, host_connector.CheckHostBundleAge(&hvs.HostBundle{Created: time.Now().Add(-time.Minute)}, time.Hour))
+	assert.Error(t, host_connector.CheckHostBundleAge(&hvs.HostBundle{Created: time.Now().Add(-2 * time.Hour)}, time.Hour))
+	assert.Error(t, host_connector.CheckHostBundleAge(&hvs.HostBundle{Created: time.Now().Add(time.Hour)}, time.Hour))
+}
+
+func vendorConnector(url string) types.VendorConnector {
+	return types.VendorConnector{
+		Vendor: constants.VendorBundle,
+		Url:    url,
+	}
+}
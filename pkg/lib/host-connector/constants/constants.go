@@ -19,10 +19,12 @@ const (
 	VendorIntel
 	VendorVMware
 	VendorMicrosoft
+	// VendorBundle is used for hosts that push their attestation data to the HVS in a bundle
+	VendorBundle
 )
 
 func (vendor Vendor) String() string {
-	return [...]string{"UNKNOWN", "INTEL", "VMWARE", "MICROSOFT"}[vendor]
+	return [...]string{"UNKNOWN", "INTEL", "VMWARE", "MICROSOFT", "BUNDLE"}[vendor]
 }
 
 func (vendor *Vendor) GetVendorFromOSType(osType string) error {
@@ -57,6 +59,8 @@ func (vendor *Vendor) UnmarshalJSON(b []byte) error {
 		*vendor = VendorVMware
 	case "INTEL":
 		*vendor = VendorIntel
+	case "BUNDLE":
+		*vendor = VendorBundle
 	default:
 		*vendor = VendorUnknown
 		err = errors.Errorf("Provided vendor is not supported. Vendor : '%s'", jsonValue)
@@ -6,7 +6,9 @@ package host_connector
 
 import (
 	"crypto/x509"
+	"time"
 
+	hvsConstants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants"
 	commLog "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/constants"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/util"
@@ -26,10 +28,11 @@ type HostConnectorFactory struct {
 	trustedCaCerts    []x509.Certificate
 	natsServers       []string
 	imaMeasureEnabled bool
+	hostBundleMaxAge  time.Duration
 }
 
-func NewHostConnectorFactory(aasApiUrl string, trustedCaCerts []x509.Certificate, natsServers []string, imaMeasureEnabled bool) *HostConnectorFactory {
-	return &HostConnectorFactory{aasApiUrl, trustedCaCerts, natsServers, imaMeasureEnabled}
+func NewHostConnectorFactory(aasApiUrl string, trustedCaCerts []x509.Certificate, natsServers []string, imaMeasureEnabled bool, hostBundleMaxAge time.Duration) *HostConnectorFactory {
+	return &HostConnectorFactory{aasApiUrl, trustedCaCerts, natsServers, imaMeasureEnabled, hostBundleMaxAge}
 }
 
 func (htcFactory *HostConnectorFactory) NewHostConnector(connectionString string) (HostConnector, error) {
@@ -43,12 +46,18 @@ func (htcFactory *HostConnectorFactory) NewHostConnector(connectionString string
 	}
 
 	switch vendorConnector.Vendor {
-	case constants.VendorIntel, constants.VendorMicrosoft:
+	case constants.VendorIntel:
 		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is INTEL")
 		connectorFactory = &IntelConnectorFactory{htcFactory.natsServers}
+	case constants.VendorMicrosoft:
+		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is MICROSOFT")
+		connectorFactory = &MicrosoftConnectorFactory{htcFactory.natsServers}
 	case constants.VendorVMware:
 		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is VMWARE")
 		connectorFactory = &VmwareConnectorFactory{}
+	case constants.VendorBundle:
+		log.Debug("host_connector/host_connector_factory:NewHostConnector() Connector type for provided connection string is BUNDLE")
+		connectorFactory = NewBundleConnectorFactory(hvsConstants.HostBundlesDir, htcFactory.hostBundleMaxAge)
 	default:
 		return nil, errors.New("host_connector_factory:NewHostConnector() Vendor not supported yet: " + vendorConnector.Vendor.String())
 	}
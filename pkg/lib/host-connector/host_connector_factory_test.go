@@ -17,7 +17,7 @@ func TestNewHostConnector(t *testing.T) {
 	sampleUrl1 := "intel:https://ta.ip.com:1443;u=admin;p=password"
 	aasurl := "https://aas.url.com:8444/aas"
 	var caCertMap []x509.Certificate
-	htcFactory := NewHostConnectorFactory(aasurl, caCertMap, nil, true)
+	htcFactory := NewHostConnectorFactory(aasurl, caCertMap, nil, true, 0)
 
 	hostConnector, err := htcFactory.NewHostConnector(sampleUrl1)
 	assert.NoError(t, err, nil)
@@ -32,4 +32,20 @@ func TestNewHostConnector(t *testing.T) {
 	hostConnector, err = htcFactory.NewHostConnector(unknownVendorURL)
 	assert.Error(t, err)
 	assert.Equal(t, hostConnector, nil)
+
+	microsoftURL := "microsoft:https://ta.ip.com:1443;u=admin;p=password"
+	hostConnector, err = htcFactory.NewHostConnector(microsoftURL)
+	assert.NoError(t, err)
+	_, ok := hostConnector.(*MicrosoftConnector)
+	assert.True(t, ok)
+
+	bundleURL := "bundle:file:///opt/hvs/host-bundles/00ecd3ab-9af4-e711-906e-001560a04062.json"
+	hostConnector, err = htcFactory.NewHostConnector(bundleURL)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hostConnector, nil)
+
+	outsideBundleDirURL := "bundle:file:///opt/hvs/host-bundles/../configuration/config.yml"
+	hostConnector, err = htcFactory.NewHostConnector(outsideBundleDirURL)
+	assert.Error(t, err)
+	assert.Equal(t, hostConnector, nil)
 }
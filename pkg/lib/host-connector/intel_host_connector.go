@@ -141,34 +141,10 @@ func (ic *IntelConnector) GetHostManifestAcceptNonce(nonce string, pcrList []int
 		return hvs.HostManifest{}, errors.Wrap(err, "intel_host_connector:GetHostManifestAcceptNonce() Error in getting TPM Quote response")
 	}
 
-	log.Info("intel_host_connector:GetHostManifestAcceptNonce() Verifying quote and retrieving PCR manifest from TPM quote " +
-		"response ...")
-	pcrsDigest, buffer, err := util.VerifyQuoteAndGetPCRDetails(verificationNonceInBytes,
-		tpmQuoteInBytes, aikCertificate)
+	err = setHostManifestFromTpmQuote(&hostManifest, verificationNonceInBytes, tpmQuoteInBytes, aikCertificate, aikPem, tpmQuoteResponse)
 	if err != nil {
-		return hvs.HostManifest{}, errors.Wrap(err, "intel_host_connector:GetHostManifestAcceptNonce() Error verifying "+
-			"TPM Quote")
-	}
-
-	pcrManifest, err := util.GetPCRManifest(tpmQuoteResponse.EventLog, buffer)
-	if err != nil {
-		return hvs.HostManifest{}, errors.Wrap(err, "util/aik_quote_verifier:GetHostManifestAcceptNonce() Error "+
-			"retrieving PCR manifest from quote")
-	}
-	log.Info("intel_host_connector:GetHostManifestAcceptNonce() Successfully retrieved PCR manifest from quote")
-
-	var imaLogs hvs.ImaLogs
-	if tpmQuoteResponse.ImaLogs != "" {
-		var imaLog hvs.ImaLog
-		err = json.Unmarshal([]byte(tpmQuoteResponse.ImaLogs), &imaLog)
-		if err != nil {
-			return hvs.HostManifest{}, errors.Wrap(err, "intel_host_connector:GetHostManifestAcceptNonce() Error unmarshaling the imalogbytes")
-		}
-		log.Info("intel_host_connector:GetHostManifestAcceptNonce() Successfully unmarshalled IMAlog from tpmQuoteResponse")
-		imaLogs.Pcr = imaLog.Pcr
-		imaLogs.Measurements = imaLog.ImaMeasurements
-		imaLogs.ImaTemplate = imaLog.ImaTemplate
-		hostManifest.ImaLogs = &imaLogs
+		return hvs.HostManifest{}, errors.Wrap(err, "intel_host_connector:GetHostManifestAcceptNonce() Error creating "+
+			"host manifest from TPM Quote")
 	}
 
 	isWlaInstalled := false
@@ -213,14 +189,7 @@ func (ic *IntelConnector) GetHostManifestAcceptNonce(nonce string, pcrList []int
 		}
 		bindingKeyCertificateBase64 = base64.StdEncoding.EncodeToString(bindingKeyCertificate.Bytes)
 	}
-	aikCertificateBase64 := base64.StdEncoding.EncodeToString(aikPem.Bytes)
-
-	hostManifest.PcrManifest = pcrManifest
-	hostManifest.AIKCertificate = aikCertificateBase64
-	hostManifest.AssetTagDigest = tpmQuoteResponse.AssetTag
 	hostManifest.BindingKeyCertificate = bindingKeyCertificateBase64
-	hostManifest.MeasurementXmls = tpmQuoteResponse.TcbMeasurements.TcbMeasurements
-	hostManifest.QuoteDigest = hex.EncodeToString(pcrsDigest) + hostManifest.AssetTagDigest
 
 	hostManifestJson, err := json.Marshal(hostManifest)
 	if err != nil {
@@ -232,6 +201,46 @@ func (ic *IntelConnector) GetHostManifestAcceptNonce(nonce string, pcrList []int
 	return hostManifest, err
 }
 
+// setHostManifestFromTpmQuote verifies the TPM quote and fills the host manifest with the PCR manifest, IMA logs,
+// AIK certificate and measurements of the quote
+func setHostManifestFromTpmQuote(hostManifest *hvs.HostManifest, verificationNonceInBytes []byte, tpmQuoteInBytes []byte,
+	aikCertificate *x509.Certificate, aikPem *pem.Block, tpmQuoteResponse taModel.TpmQuoteResponse) error {
+	log.Info("host_connector:setHostManifestFromTpmQuote() Verifying quote and retrieving PCR manifest from TPM quote " +
+		"response ...")
+	pcrsDigest, buffer, err := util.VerifyQuoteAndGetPCRDetails(verificationNonceInBytes,
+		tpmQuoteInBytes, aikCertificate)
+	if err != nil {
+		return errors.Wrap(err, "host_connector:setHostManifestFromTpmQuote() Error verifying TPM Quote")
+	}
+
+	pcrManifest, err := util.GetPCRManifest(tpmQuoteResponse.EventLog, buffer)
+	if err != nil {
+		return errors.Wrap(err, "host_connector:setHostManifestFromTpmQuote() Error retrieving PCR manifest from quote")
+	}
+	log.Info("host_connector:setHostManifestFromTpmQuote() Successfully retrieved PCR manifest from quote")
+
+	if tpmQuoteResponse.ImaLogs != "" {
+		var imaLog hvs.ImaLog
+		err = json.Unmarshal([]byte(tpmQuoteResponse.ImaLogs), &imaLog)
+		if err != nil {
+			return errors.Wrap(err, "host_connector:setHostManifestFromTpmQuote() Error unmarshaling the imalogbytes")
+		}
+		log.Info("host_connector:setHostManifestFromTpmQuote() Successfully unmarshalled IMAlog from tpmQuoteResponse")
+		hostManifest.ImaLogs = &hvs.ImaLogs{
+			Pcr:          imaLog.Pcr,
+			Measurements: imaLog.ImaMeasurements,
+			ImaTemplate:  imaLog.ImaTemplate,
+		}
+	}
+
+	hostManifest.PcrManifest = pcrManifest
+	hostManifest.AIKCertificate = base64.StdEncoding.EncodeToString(aikPem.Bytes)
+	hostManifest.AssetTagDigest = tpmQuoteResponse.AssetTag
+	hostManifest.MeasurementXmls = tpmQuoteResponse.TcbMeasurements.TcbMeasurements
+	hostManifest.QuoteDigest = hex.EncodeToString(pcrsDigest) + hostManifest.AssetTagDigest
+	return nil
+}
+
 func (ic *IntelConnector) DeployAssetTag(hardwareUUID, tag string) error {
 
 	log.Trace("intel_host_connector:DeployAssetTag() Entering")
@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+
+	client "github.com/intel-secl/intel-secl/v5/pkg/clients/ta"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/util"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v5/pkg/model/ta"
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+// MicrosoftConnector collects the host manifest of Windows hosts from the Trust Agent, the event log of the TPM quote
+// is the Windows Boot Configuration Log (WBCL) of the last boot
+type MicrosoftConnector struct {
+	client client.TAClient
+}
+
+func (mc *MicrosoftConnector) GetHostDetails() (taModel.HostInfo, error) {
+
+	log.Trace("microsoft_host_connector:GetHostDetails() Entering")
+	defer log.Trace("microsoft_host_connector:GetHostDetails() Leaving")
+	hostInfo, err := mc.client.GetHostInfo()
+	return hostInfo, err
+}
+
+func (mc *MicrosoftConnector) GetHostManifest(pcrList []int) (hvs.HostManifest, error) {
+	log.Trace("microsoft_host_connector:GetHostManifest() Entering")
+	defer log.Trace("microsoft_host_connector:GetHostManifest() Leaving")
+
+	nonce, err := util.GenerateNonce(32)
+	if err != nil {
+		return hvs.HostManifest{}, errors.Wrap(err, "microsoft_host_connector:GetHostManifest() Error generating "+
+			"nonce for TPM quote request")
+	}
+
+	hostManifest, err := mc.GetHostManifestAcceptNonce(nonce, pcrList)
+	if err != nil {
+		return hvs.HostManifest{}, errors.Wrap(err, "microsoft_host_connector:GetHostManifest() Error creating "+
+			"host manifest")
+	}
+	return hostManifest, nil
+}
+
+// GetTPMQuoteResponse gets and decodes the TPM quote in the same way as for Intel hosts, the WBCL of the quote
+// response is then converted to the event log format of the HVS
+func (mc *MicrosoftConnector) GetTPMQuoteResponse(nonce string, pcrList []int) ([]byte, []byte, *x509.Certificate, *pem.Block, taModel.TpmQuoteResponse, error) {
+	log.Trace("microsoft_host_connector:GetTPMQuoteResponse() Entering")
+	defer log.Trace("microsoft_host_connector:GetTPMQuoteResponse() Leaving")
+
+	intelConnector := IntelConnector{client: mc.client}
+	verificationNonceInBytes, tpmQuoteInBytes, aikCertificate, aikPem, tpmQuoteResponse, err := intelConnector.GetTPMQuoteResponse(nonce, pcrList)
+	if err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, err
+	}
+
+	eventLog, err := getEventLogFromWbcl(tpmQuoteResponse.EventLog)
+	if err != nil {
+		return nil, nil, nil, nil, taModel.TpmQuoteResponse{}, errors.Wrap(err, "microsoft_host_connector:GetTPMQuoteResponse() "+
+			"Error parsing WBCL of TPM quote response")
+	}
+	tpmQuoteResponse.EventLog = eventLog
+	return verificationNonceInBytes, tpmQuoteInBytes, aikCertificate, aikPem, tpmQuoteResponse, nil
+}
+
+// GetHostManifestAcceptNonce accepts the nonce of the TPM quote to support unit tests
+func (mc *MicrosoftConnector) GetHostManifestAcceptNonce(nonce string, pcrList []int) (hvs.HostManifest, error) {
+	log.Trace("microsoft_host_connector:GetHostManifestAcceptNonce() Entering")
+	defer log.Trace("microsoft_host_connector:GetHostManifestAcceptNonce() Leaving")
+
+	var hostManifest hvs.HostManifest
+	var err error
+
+	hostManifest.HostInfo, err = mc.client.GetHostInfo()
+	if err != nil {
+		return hvs.HostManifest{}, errors.Wrap(err, "microsoft_host_connector:GetHostManifestAcceptNonce() Error getting "+
+			"host details from TA")
+	}
+
+	verificationNonceInBytes, tpmQuoteInBytes, aikCertificate, aikPem, tpmQuoteResponse, err := mc.GetTPMQuoteResponse(nonce, pcrList)
+	if err != nil {
+		return hvs.HostManifest{}, errors.Wrap(err, "microsoft_host_connector:GetHostManifestAcceptNonce() Error in getting TPM Quote response")
+	}
+
+	err = setHostManifestFromTpmQuote(&hostManifest, verificationNonceInBytes, tpmQuoteInBytes, aikCertificate, aikPem, tpmQuoteResponse)
+	if err != nil {
+		return hvs.HostManifest{}, errors.Wrap(err, "microsoft_host_connector:GetHostManifestAcceptNonce() Error creating "+
+			"host manifest from TPM Quote")
+	}
+
+	log.Info("microsoft_host_connector:GetHostManifestAcceptNonce() Host manifest created successfully")
+	return hostManifest, nil
+}
+
+// getEventLogFromWbcl converts the base64 encoded WBCL sent by the Trust Agent to the json event log expected by
+// util.GetPCRManifest
+func getEventLogFromWbcl(wbclBase64 string) (string, error) {
+	if wbclBase64 == "" {
+		return "", nil
+	}
+
+	wbcl, err := base64.StdEncoding.DecodeString(wbclBase64)
+	if err != nil {
+		return "", errors.Wrap(err, "Error decoding WBCL")
+	}
+
+	eventLogs, err := util.ParseWbcl(wbcl)
+	if err != nil {
+		return "", err
+	}
+
+	eventLogJson, err := json.Marshal(eventLogs)
+	if err != nil {
+		return "", errors.Wrap(err, "Error marshalling event log")
+	}
+	return string(eventLogJson), nil
+}
+
+func (mc *MicrosoftConnector) DeployAssetTag(hardwareUUID, tag string) error {
+
+	log.Trace("microsoft_host_connector:DeployAssetTag() Entering")
+	defer log.Trace("microsoft_host_connector:DeployAssetTag() Leaving")
+	err := mc.client.DeployAssetTag(hardwareUUID, tag)
+	return err
+}
+
+func (mc *MicrosoftConnector) DeploySoftwareManifest(manifest taModel.Manifest) error {
+	return errors.New("microsoft_host_connector :DeploySoftwareManifest() Operation not supported")
+}
+
+func (mc *MicrosoftConnector) GetMeasurementFromManifest(manifest taModel.Manifest) (taModel.Measurement, error) {
+	return taModel.Measurement{}, errors.New("microsoft_host_connector :GetMeasurementFromManifest() Operation not supported")
+}
+
+func (mc *MicrosoftConnector) GetClusterReference(clusterName string) ([]mo.HostSystem, error) {
+	return nil, errors.New("microsoft_host_connector :GetClusterReference() Operation not supported")
+}
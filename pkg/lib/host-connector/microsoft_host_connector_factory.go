@@ -0,0 +1,36 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package host_connector
+
+import (
+	"crypto/x509"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/types"
+	"github.com/pkg/errors"
+)
+
+type MicrosoftConnectorFactory struct {
+	natsServers []string
+}
+
+// GetHostConnector returns a MicrosoftConnector, Windows hosts run the same Trust Agent API as Intel hosts
+func (mcf *MicrosoftConnectorFactory) GetHostConnector(vendorConnector types.VendorConnector, aasApiUrl string,
+	trustedCaCerts []x509.Certificate, imaMeasureEnabled bool) (HostConnector, error) {
+
+	log.Trace("microsoft_host_connector_factory:GetHostConnector() Entering")
+	defer log.Trace("microsoft_host_connector_factory:GetHostConnector() Leaving")
+
+	intelConnectorFactory := IntelConnectorFactory{mcf.natsServers}
+	connector, err := intelConnectorFactory.GetHostConnector(vendorConnector, aasApiUrl, trustedCaCerts, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "microsoft_host_connector_factory:GetHostConnector() Could not create Trust Agent client")
+	}
+
+	intelConnector, ok := connector.(*IntelConnector)
+	if !ok {
+		return nil, errors.New("microsoft_host_connector_factory:GetHostConnector() Unexpected host connector type")
+	}
+	return &MicrosoftConnector{intelConnector.client}, nil
+}
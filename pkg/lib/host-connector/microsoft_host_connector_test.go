@@ -0,0 +1,80 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package host_connector
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/clients/ta"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v5/pkg/model/ta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newMockWindowsTAClient(t *testing.T) *ta.MockTAClient {
+	mockTAClient, err := ta.NewMockTAClient()
+	assert.NoError(t, err)
+
+	// the sample quote contains the WBCL of ./test/sample_wbcl.bin
+	var tpmQuoteResponse taModel.TpmQuoteResponse
+	b, err := ioutil.ReadFile("./test/sample_windows_tpm_quote.xml")
+	assert.NoError(t, err)
+	err = xml.Unmarshal(b, &tpmQuoteResponse)
+	assert.NoError(t, err)
+	mockTAClient.On("GetTPMQuote", mock.Anything, mock.Anything, mock.Anything).Return(tpmQuoteResponse, nil)
+
+	var hostInfo taModel.HostInfo
+	b, err = ioutil.ReadFile("./test/sample_windows_platform_info.json")
+	assert.NoError(t, err)
+	err = json.Unmarshal(b, &hostInfo)
+	assert.NoError(t, err)
+	mockTAClient.On("GetHostInfo").Return(hostInfo, nil)
+
+	aikBytes, err := ioutil.ReadFile("./test/windows_aik.pem")
+	assert.NoError(t, err)
+	aikDer, _ := pem.Decode(aikBytes)
+	mockTAClient.On("GetAIK").Return(aikDer.Bytes, nil)
+	return mockTAClient
+}
+
+func TestMicrosoftCreateHostManifestFromSampleData(t *testing.T) {
+	microsoftConnector := MicrosoftConnector{
+		client: newMockWindowsTAClient(t),
+	}
+
+	hostManifest, err := microsoftConnector.GetHostManifestAcceptNonce("tHgfRQED1+pYgEZpq3dZC9ONmBCZKdx10LErTZs1k/k=", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "windows", hostManifest.HostInfo.OSType)
+	assert.Len(t, hostManifest.PcrManifest.Sha1Pcrs, 6)
+	assert.Len(t, hostManifest.PcrManifest.Sha256Pcrs, 6)
+
+	// the event logs of the WBCL replay to the PCR values of the quote
+	for _, eventLog := range hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs {
+		pcr, err := hostManifest.PcrManifest.GetPcrValue(hvs.SHA256, hvs.PcrIndex(eventLog.Pcr.Index))
+		assert.NoError(t, err)
+		pcrValue, err := eventLog.Replay()
+		assert.NoError(t, err)
+		assert.Equal(t, pcr.Value, pcrValue, "PCR %d", eventLog.Pcr.Index)
+	}
+
+	pcr13Events, err := hostManifest.PcrManifest.GetEventLogCriteria(hvs.SHA256, hvs.PCR13)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"winload"}, pcr13Events[0].Tags)
+}
+
+func TestMicrosoftCreateHostManifestInvalidNonce(t *testing.T) {
+	microsoftConnector := MicrosoftConnector{
+		client: newMockWindowsTAClient(t),
+	}
+
+	_, err := microsoftConnector.GetHostManifestAcceptNonce("EsJ0GRgwSvwn9u3ir9NhidLSKVX5oVn2UJKsH4heHuQ=", nil)
+	assert.Error(t, err)
+}
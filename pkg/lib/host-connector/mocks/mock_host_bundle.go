@@ -0,0 +1,87 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+package mocks
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"time"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v5/pkg/model/ta"
+	"github.com/pkg/errors"
+)
+
+// NewMockHostBundle returns a host bundle for the provided host info with a TPM quote of the SHA256 PCR 0 signed
+// by a generated AIK, the layout of the quote is the one produced by the Trust Agent
+func NewMockHostBundle(hostInfo []byte) (*hvs.HostBundle, error) {
+	return NewMockHostBundleCreatedAt(hostInfo, time.Now())
+}
+
+// NewMockHostBundleCreatedAt returns a mock host bundle created at the provided time
+func NewMockHostBundleCreatedAt(hostInfo []byte, created time.Time) (*hvs.HostBundle, error) {
+	aikKey, aikPem, err := crypt.CreateSelfSignedCertAndRSAPrivKeys(2048)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating AIK")
+	}
+
+	created = created.UTC().Truncate(time.Second)
+	nonce := hvs.GetHostBundleNonce(hostInfo, created)
+	nonceInBytes, _ := base64.StdEncoding.DecodeString(nonce)
+	taNonce := sha256.Sum256(nonceInBytes)
+
+	pcrValue := sha256.Sum256([]byte("pcr0"))
+	pcrDigest := sha256.Sum256(pcrValue[:])
+
+	// TPMS_ATTEST
+	var quoteInfo bytes.Buffer
+	quoteInfo.Write([]byte{0xff, 0x54, 0x43, 0x47, 0x80, 0x18})
+	binary.Write(&quoteInfo, binary.BigEndian, uint16(0))
+	binary.Write(&quoteInfo, binary.BigEndian, uint16(len(taNonce)))
+	quoteInfo.Write(taNonce[:])
+	quoteInfo.Write(make([]byte, 17+8))
+	binary.Write(&quoteInfo, binary.BigEndian, uint32(1))
+	quoteInfo.Write([]byte{0x00, 0x0b, 0x03, 0x01, 0x00, 0x00})
+	binary.Write(&quoteInfo, binary.BigEndian, uint16(len(pcrDigest)))
+	quoteInfo.Write(pcrDigest[:])
+
+	quoteInfoDigest := sha256.Sum256(quoteInfo.Bytes())
+	signature, err := rsa.SignPKCS1v15(rand.Reader, aikKey, crypto.SHA256, quoteInfoDigest[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "Error signing quote")
+	}
+
+	var quote bytes.Buffer
+	binary.Write(&quote, binary.BigEndian, uint16(quoteInfo.Len()))
+	quote.Write(quoteInfo.Bytes())
+	// TPMT_SIGNATURE
+	quote.Write([]byte{0x00, 0x14, 0x00, 0x0b})
+	binary.Write(&quote, binary.BigEndian, uint16(len(signature)))
+	quote.Write(signature)
+	quote.Write(pcrValue[:])
+
+	tpmQuoteResponse, err := xml.Marshal(taModel.TpmQuoteResponse{
+		TimeStamp: created.Unix(),
+		Aik:       base64.StdEncoding.EncodeToString([]byte(aikPem)),
+		Quote:     base64.StdEncoding.EncodeToString(quote.Bytes()),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling TPM quote response")
+	}
+
+	return &hvs.HostBundle{
+		Created:          created,
+		HostInfo:         hostInfo,
+		Nonce:            nonce,
+		TpmQuoteResponse: string(tpmQuoteResponse),
+	}, nil
+}
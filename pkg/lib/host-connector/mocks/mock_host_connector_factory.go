@@ -27,13 +27,16 @@ import (
 // using MockedHostConnector in its place
 type MockHostConnectorFactory struct{}
 
-// NewHostConnector returns a mocked instance of VendorConnector passing in a MockedTAClient or a MockVMwareClient as required
+// NewHostConnector returns a mocked instance of VendorConnector passing in a MockedTAClient or a MockVMwareClient as required,
+// the Microsoft hosts get a MockMicrosoftConnector
 func (htcFactory MockHostConnectorFactory) NewHostConnector(connectionString string) (host_connector.HostConnector, error) {
 	vendorConnector, _ := util.GetConnectorDetails(connectionString)
 	var connectorFactory host_connector.VendorHostConnectorFactory
 	switch vendorConnector.Vendor {
-	case constants.VendorIntel, constants.VendorMicrosoft:
+	case constants.VendorIntel:
 		connectorFactory = &MockIntelConnectorFactory{}
+	case constants.VendorMicrosoft:
+		connectorFactory = &MockMicrosoftConnectorFactory{}
 	case constants.VendorVMware:
 		connectorFactory = &MockVmwareConnectorFactory{}
 	default:
@@ -81,6 +84,28 @@ func (micf MockIntelConnectorFactory) GetHostConnector(vendorConnector types.Ven
 	return &mhc, nil
 }
 
+// MockMicrosoftConnectorFactory implements the VendorConnectorFactory interface
+type MockMicrosoftConnectorFactory struct{}
+
+// GetHostConnector returns an instance of MockMicrosoftConnector with the platform info of a Windows host, the
+// software manifests are not supported on Windows hosts
+func (mmcf MockMicrosoftConnectorFactory) GetHostConnector(vendorConnector types.VendorConnector, aasApiUrl string, trustedCaCerts []x509.Certificate, imaMeasureEnabled bool) (host_connector.HostConnector, error) {
+	mhc := MockMicrosoftConnector{}
+
+	mhc.On("DeployAssetTag", "4c4c4544-0043-4210-8053-b4c04f395032", mock.AnythingOfType("string")).Return(nil)
+
+	var hostInfo taModel.HostInfo
+	hostInfoBytes, _ := ioutil.ReadFile("../../lib/host-connector/test/sample_windows_platform_info.json")
+	_ = json.Unmarshal(hostInfoBytes, &hostInfo)
+	mhc.On("GetHostDetails").Return(hostInfo, nil)
+	mhc.On("GetHostManifest").Return(hvs.HostManifest{HostInfo: hostInfo}, nil)
+
+	mhc.On("DeploySoftwareManifest", mock.Anything).Return(errors.New("Operation not supported"))
+	mhc.On("GetMeasurementFromManifest", mock.Anything).Return(taModel.Measurement{}, errors.New("Operation not supported"))
+
+	return &mhc, nil
+}
+
 // MockVmwareConnectorFactory implements the VendorConnectorFactory interface
 type MockVmwareConnectorFactory struct{}
 
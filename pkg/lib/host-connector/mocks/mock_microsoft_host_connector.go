@@ -0,0 +1,57 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mocks
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/clients/ta"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	taModel "github.com/intel-secl/intel-secl/v5/pkg/model/ta"
+	"github.com/stretchr/testify/mock"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+type MockMicrosoftConnector struct {
+	client *ta.MockTAClient
+	mock.Mock
+}
+
+func (mhc *MockMicrosoftConnector) GetTPMQuoteResponse(nonce string, pcrList []int) ([]byte, []byte, *x509.Certificate, *pem.Block, taModel.TpmQuoteResponse, error) {
+	args := mhc.Called(nonce, pcrList)
+	return args.Get(0).([]byte), args.Get(1).([]byte), args.Get(2).(*x509.Certificate), args.Get(3).(*pem.Block), args.Get(4).(taModel.TpmQuoteResponse), args.Error(5)
+}
+
+func (mhc *MockMicrosoftConnector) GetHostDetails() (taModel.HostInfo, error) {
+	args := mhc.Called()
+	return args.Get(0).(taModel.HostInfo), args.Error(1)
+}
+
+func (mhc *MockMicrosoftConnector) GetHostManifest([]int) (hvs.HostManifest, error) {
+	args := mhc.Called()
+	return args.Get(0).(hvs.HostManifest), args.Error(1)
+}
+
+func (mhc *MockMicrosoftConnector) DeployAssetTag(hardwareUUID, tag string) error {
+	args := mhc.Called(hardwareUUID, tag)
+	return args.Error(0)
+}
+
+func (mhc *MockMicrosoftConnector) DeploySoftwareManifest(manifest taModel.Manifest) error {
+	args := mhc.Called(manifest)
+	return args.Error(0)
+}
+
+func (mhc *MockMicrosoftConnector) GetMeasurementFromManifest(manifest taModel.Manifest) (taModel.Measurement, error) {
+	args := mhc.Called(manifest)
+	return args.Get(0).(taModel.Measurement), args.Error(1)
+}
+
+func (mhc *MockMicrosoftConnector) GetClusterReference(clusterName string) ([]mo.HostSystem, error) {
+	args := mhc.Called(clusterName)
+	return args.Get(0).([]mo.HostSystem), args.Error(1)
+}
@@ -44,6 +44,8 @@ const (
 	SHA1                      = "SHA1"
 	SHA256                    = "SHA256"
 	SHA384                    = "SHA384"
+	SHA512                    = "SHA512"
+	SM3_256                   = "SM3_256"
 	EVENT_LOG_DIGEST_SHA1     = "com.intel.mtwilson.core.common.model.MeasurementSha1"
 	EVENT_LOG_DIGEST_SHA256   = "com.intel.mtwilson.core.common.model.MeasurementSha256"
 	EVENT_LOG_DIGEST_SHA384   = "com.intel.mtwilson.core.common.model.MeasurementSha384"
@@ -189,7 +191,7 @@ func VerifyQuoteAndGetPCRDetails(verificationNonce []byte, tpmQuoteInBytes []byt
 			"AIK Quote verification failed, No PCR values included in quote")
 	}
 	pcrs := tpmtSig[pos : pos+pcrLen]
-	pcrConcatLen := SHA256_SIZE * 24 * 3
+	pcrConcatLen := SHA512_SIZE * 24 * MAX_PCR_BANKS
 	pcrPos := 0
 	count := 0
 	var pcrConcat []byte
@@ -223,12 +225,13 @@ func VerifyQuoteAndGetPCRDetails(verificationNonce []byte, tpmQuoteInBytes []byt
 					pcrBuffer.WriteString(fmt.Sprintf("%2d_SHA256 ", pcr))
 				} else if hashAlg == TPM_API_ALG_ID_SHA384 {
 					pcrBuffer.WriteString(fmt.Sprintf("%2d_SHA384 ", pcr))
+				} else if hashAlg == TPM_API_ALG_ID_SHA512 {
+					pcrBuffer.WriteString(fmt.Sprintf("%2d_SHA512 ", pcr))
+				} else if hashAlg == TPM_API_ALG_ID_SM3_SHA256 {
+					pcrBuffer.WriteString(fmt.Sprintf("%2d_SM3_256 ", pcr))
 				}
-				//Ignore the pcr banks other than SHA1 SHA256 and SHA384
-				if hashAlg == TPM_API_ALG_ID_SHA1 || hashAlg == TPM_API_ALG_ID_SHA256 || hashAlg == TPM_API_ALG_ID_SHA384 {
-					for i := 0; i < pcrSize; i++ {
-						pcrBuffer.WriteString(fmt.Sprintf("%02x", pcrs[pcrPos+i]))
-					}
+				for i := 0; i < pcrSize; i++ {
+					pcrBuffer.WriteString(fmt.Sprintf("%02x", pcrs[pcrPos+i]))
 				}
 				pcrBuffer.WriteString("\n")
 				count++
@@ -297,6 +300,8 @@ func createPCRManifest(pcrList []string, eventLog string) (hvs.PcrManifest, erro
 	pcrManifest.Sha256Pcrs = []hvs.HostManifestPcrs{}
 	pcrManifest.Sha1Pcrs = []hvs.HostManifestPcrs{}
 	pcrManifest.Sha384Pcrs = []hvs.HostManifestPcrs{}
+	pcrManifest.Sha512Pcrs = []hvs.HostManifestPcrs{}
+	pcrManifest.Sm3Pcrs = []hvs.HostManifestPcrs{}
 
 	for _, pcrString := range pcrList {
 		parts := strings.Split(strings.TrimSpace(pcrString), " ")
@@ -305,11 +310,12 @@ func createPCRManifest(pcrList []string, eventLog string) (hvs.PcrManifest, erro
 			 * in case of SHA1, the bank algorithm is not attached. so the format is just the pcr number same as before
 			 * in case of SHA256 or other algorithms, the format is "pcrNumber_SHA256"
 			 */
-			pcrIndexParts := strings.Split(strings.TrimSpace(parts[0]), "_")
+			pcrIndexParts := strings.SplitN(strings.TrimSpace(parts[0]), "_", 2)
 			pcrNumber := strings.ReplaceAll(strings.ReplaceAll(strings.TrimSpace(pcrIndexParts[0]),
 				PCR_NUMBER_UNTAINT, ""), "\n", "")
 			var pcrBank string
 			if len(pcrIndexParts) == 2 {
+				// SM3_256 contains the separator, so the bank is everything after the first one
 				pcrBank = strings.TrimSpace(pcrIndexParts[1])
 			} else {
 				pcrBank = SHA1
@@ -347,6 +353,18 @@ func createPCRManifest(pcrList []string, eventLog string) (hvs.PcrManifest, erro
 						Value:   pcrValue,
 						PcrBank: shaAlgorithm,
 					})
+				} else if strings.EqualFold(pcrBank, "SHA512") {
+					pcrManifest.Sha512Pcrs = append(pcrManifest.Sha512Pcrs, hvs.HostManifestPcrs{
+						Index:   pcrIndex,
+						Value:   pcrValue,
+						PcrBank: shaAlgorithm,
+					})
+				} else if strings.EqualFold(pcrBank, "SM3_256") {
+					pcrManifest.Sm3Pcrs = append(pcrManifest.Sm3Pcrs, hvs.HostManifestPcrs{
+						Index:   pcrIndex,
+						Value:   pcrValue,
+						PcrBank: shaAlgorithm,
+					})
 				}
 			} else {
 				log.Warn("util/aik_quote_verifier:createPCRManifest() Result PCR invalid")
@@ -450,6 +468,44 @@ func addPcrEntry(module hvs.TpmEventLog, eventLogMap *hvs.PcrEventLogMap) {
 			}
 		}
 
+	case SHA512:
+		for _, entry := range eventLogMap.Sha512EventLogs {
+			if entry.Pcr.Index == module.Pcr.Index {
+				pcrFound = true
+				break
+			}
+			index++
+		}
+
+		if !pcrFound {
+			eventLogMap.Sha512EventLogs = append(eventLogMap.Sha512EventLogs, hvs.TpmEventLog{Pcr: hvs.Pcr{Index: module.Pcr.Index, Bank: SHA512}, TpmEvent: module.TpmEvent})
+		} else {
+			for _, events := range module.TpmEvent {
+				eventLog := hvs.EventLog{Measurement: events.Measurement,
+					Tags: events.Tags, TypeID: events.TypeID, TypeName: events.TypeName}
+				eventLogMap.Sha512EventLogs[index].TpmEvent = append(eventLogMap.Sha512EventLogs[index].TpmEvent, eventLog)
+			}
+		}
+
+	case SM3_256:
+		for _, entry := range eventLogMap.Sm3EventLogs {
+			if entry.Pcr.Index == module.Pcr.Index {
+				pcrFound = true
+				break
+			}
+			index++
+		}
+
+		if !pcrFound {
+			eventLogMap.Sm3EventLogs = append(eventLogMap.Sm3EventLogs, hvs.TpmEventLog{Pcr: hvs.Pcr{Index: module.Pcr.Index, Bank: SM3_256}, TpmEvent: module.TpmEvent})
+		} else {
+			for _, events := range module.TpmEvent {
+				eventLog := hvs.EventLog{Measurement: events.Measurement,
+					Tags: events.Tags, TypeID: events.TypeID, TypeName: events.TypeName}
+				eventLogMap.Sm3EventLogs[index].TpmEvent = append(eventLogMap.Sm3EventLogs[index].TpmEvent, eventLog)
+			}
+		}
+
 	}
 	log.Debugf("util/aik_quote_verifier:addPcrEntry() Successfully added PCR log entries")
 }
@@ -6,13 +6,16 @@
 package util
 
 import (
+	"bytes"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
 	"encoding/xml"
 	"io/ioutil"
+	"strings"
 	"testing"
 
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
 	taModel "github.com/intel-secl/intel-secl/v5/pkg/model/ta"
 	"github.com/stretchr/testify/assert"
 )
@@ -94,3 +97,26 @@ func TestGetVerificationNonceAssetTagProvisioned(t *testing.T) {
 	_, err = GetVerificationNonce(nonceInBytes, tpmQuoteResponse)
 	assert.NoError(t, err)
 }
+
+func TestGetPCRManifestSha512AndSm3Banks(t *testing.T) {
+	var buffer bytes.Buffer
+	buffer.WriteString(" 0_SHA512 " + strings.Repeat("ab", SHA512_SIZE) + "\n")
+	buffer.WriteString(" 0_SM3_256 " + strings.Repeat("cd", SHA256_SIZE) + "\n")
+	eventLog := `[{"pcr":{"index":0,"bank":"SHA512"},"tpm_events":[{"type_name":"EV_POST_CODE","measurement":"` + strings.Repeat("01", SHA512_SIZE) + `"}]},
+		{"pcr":{"index":0,"bank":"SM3_256"},"tpm_events":[{"type_name":"EV_POST_CODE","measurement":"` + strings.Repeat("02", SHA256_SIZE) + `"}]}]`
+
+	pcrManifest, err := GetPCRManifest(eventLog, buffer)
+	assert.NoError(t, err)
+	assert.Len(t, pcrManifest.Sha512Pcrs, 1)
+	assert.Equal(t, hvs.SHA512, pcrManifest.Sha512Pcrs[0].PcrBank)
+	assert.Equal(t, strings.Repeat("ab", SHA512_SIZE), pcrManifest.Sha512Pcrs[0].Value)
+	assert.Len(t, pcrManifest.Sm3Pcrs, 1)
+	assert.Equal(t, hvs.SM3_256, pcrManifest.Sm3Pcrs[0].PcrBank)
+	assert.Equal(t, []hvs.SHAAlgorithm{hvs.SHA512, hvs.SM3_256}, pcrManifest.GetPcrBanks())
+
+	assert.Len(t, pcrManifest.PcrEventLogMap.Sha512EventLogs, 1)
+	assert.Len(t, pcrManifest.PcrEventLogMap.Sm3EventLogs, 1)
+	events, err := pcrManifest.GetEventLogCriteria(hvs.SM3_256, hvs.PCR0)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("02", SHA256_SIZE), events[0].Measurement)
+}
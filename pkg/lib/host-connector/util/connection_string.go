@@ -70,6 +70,8 @@ func GetVendorPrefix(connectionString string) constants.Vendor {
 		return constants.VendorVMware
 	} else if strings.HasPrefix(strings.ToLower(connectionString), strings.ToLower(constants.VendorMicrosoft.String()+":")) {
 		return constants.VendorMicrosoft
+	} else if strings.HasPrefix(strings.ToLower(connectionString), strings.ToLower(constants.VendorBundle.String()+":")) {
+		return constants.VendorBundle
 	}
 	return constants.VendorUnknown
 }
@@ -0,0 +1,467 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// Windows Boot Configuration Log (WBCL) parsing. The WBCL is the TCG PC Client event log of the last boot as
+// recorded by Windows, either in the crypto agile format (TCG_PCR_EVENT2 events after a "Spec ID Event03" header)
+// or in the legacy SHA1 format. Windows records its own boot components in EV_EVENT_TAG events containing SIPA
+// (System Integrity Platform Attestation) events, these are mapped to event tags so that flavor templates can
+// include them by name.
+
+const (
+	wbclSpecIdEventSignature = "Spec ID Event03"
+	wbclMaxEventSize         = 1 << 24
+	wbclMaxSipaDepth         = 8
+
+	evNoAction                     = 0x00000003
+	evEventTag                     = 0x00000006
+	evEfiVariableDriverConfig      = 0x80000001
+	evEfiVariableBoot              = 0x80000002
+	evEfiBootServicesApplication   = 0x80000003
+	evEfiBootServicesDriver        = 0x80000004
+	evEfiVariableBoot2             = 0x8000000C
+	evEfiVariableAuthority         = 0x800000E0
+	sipaEventTypeAggregation       = 0x40000000
+	sipaEventFilePath              = 0x00070001
+	uefiDevicePathTypeMedia        = 0x04
+	uefiDevicePathSubTypeFilePath  = 0x04
+	uefiDevicePathTypeEnd          = 0x7F
+	startupLocalitySignature       = "StartupLocality"
+	uefiImageLoadEventHeaderLength = 32
+)
+
+var wbclEventTypeNames = map[uint32]string{
+	0x00000000: "EV_PREBOOT_CERT",
+	0x00000001: "EV_POST_CODE",
+	0x00000002: "EV_UNUSED",
+	0x00000003: "EV_NO_ACTION",
+	0x00000004: "EV_SEPARATOR",
+	0x00000005: "EV_ACTION",
+	0x00000006: "EV_EVENT_TAG",
+	0x00000007: "EV_S_CRTM_CONTENTS",
+	0x00000008: "EV_S_CRTM_VERSION",
+	0x00000009: "EV_CPU_MICROCODE",
+	0x0000000A: "EV_PLATFORM_CONFIG_FLAGS",
+	0x0000000B: "EV_TABLE_OF_DEVICES",
+	0x0000000C: "EV_COMPACT_HASH",
+	0x0000000D: "EV_IPL",
+	0x0000000E: "EV_IPL_PARTITION_DATA",
+	0x0000000F: "EV_NONHOST_CODE",
+	0x00000010: "EV_NONHOST_CONFIG",
+	0x00000011: "EV_NONHOST_INFO",
+	0x00000012: "EV_OMIT_BOOT_DEVICE_EVENTS",
+	0x80000001: "EV_EFI_VARIABLE_DRIVER_CONFIG",
+	0x80000002: "EV_EFI_VARIABLE_BOOT",
+	0x80000003: "EV_EFI_BOOT_SERVICES_APPLICATION",
+	0x80000004: "EV_EFI_BOOT_SERVICES_DRIVER",
+	0x80000005: "EV_EFI_RUNTIME_SERVICES_DRIVER",
+	0x80000006: "EV_EFI_GPT_EVENT",
+	0x80000007: "EV_EFI_ACTION",
+	0x80000008: "EV_EFI_PLATFORM_FIRMWARE_BLOB",
+	0x80000009: "EV_EFI_HANDOFF_TABLES",
+	0x8000000A: "EV_EFI_PLATFORM_FIRMWARE_BLOB2",
+	0x8000000B: "EV_EFI_HANDOFF_TABLES2",
+	0x8000000C: "EV_EFI_VARIABLE_BOOT2",
+	0x80000010: "EV_EFI_HCRTM_EVENT",
+	0x800000E0: "EV_EFI_VARIABLE_AUTHORITY",
+}
+
+// events whose data is a descriptive string
+var wbclStringEvents = map[uint32]bool{
+	0x00000001: true, // EV_POST_CODE
+	0x00000005: true, // EV_ACTION
+	0x0000000D: true, // EV_IPL
+	0x80000007: true, // EV_EFI_ACTION
+}
+
+// SIPA events defined in wbcl.h of the Windows SDK that are reported as event tags
+var sipaEventNames = map[uint32]string{
+	0x00020002: "SIPAEVENT_BOOTCOUNTER",
+	0x00020003: "SIPAEVENT_TRANSFER_CONTROL",
+	0x00020005: "SIPAEVENT_BITLOCKER_UNLOCK",
+	0x00020006: "SIPAEVENT_EVENTCOUNTER",
+	0x00020007: "SIPAEVENT_COUNTERID",
+	0x00020009: "SIPAEVENT_APPLICATION_SVN",
+	0x00040001: "SIPAEVENT_BOOTDEBUGGING",
+	0x00040002: "SIPAEVENT_BOOT_REVOCATION_LIST",
+	0x00050001: "SIPAEVENT_OSKERNELDEBUG",
+	0x00050002: "SIPAEVENT_CODEINTEGRITY",
+	0x00050003: "SIPAEVENT_TESTSIGNING",
+	0x00050004: "SIPAEVENT_DATAEXECUTIONPREVENTION",
+	0x00050005: "SIPAEVENT_SAFEMODE",
+	0x00050006: "SIPAEVENT_WINPE",
+	0x00050007: "SIPAEVENT_PHYSICALADDRESSEXTENSION",
+	0x00050008: "SIPAEVENT_OSDEVICE",
+	0x00050009: "SIPAEVENT_SYSTEMROOT",
+	0x0005000A: "SIPAEVENT_HYPERVISOR_LAUNCH_TYPE",
+	0x0005000B: "SIPAEVENT_HYPERVISOR_PATH",
+	0x0005000C: "SIPAEVENT_HYPERVISOR_IOMMU_POLICY",
+	0x0005000D: "SIPAEVENT_HYPERVISOR_DEBUG",
+	0x0005000E: "SIPAEVENT_DRIVER_LOAD_POLICY",
+	0x0005000F: "SIPAEVENT_SI_POLICY",
+	0x00050010: "SIPAEVENT_HYPERVISOR_MMIO_NX_POLICY",
+	0x00050011: "SIPAEVENT_HYPERVISOR_MSR_FILTER_POLICY",
+	0x00050012: "SIPAEVENT_VSM_LAUNCH_TYPE",
+	0x00050013: "SIPAEVENT_OS_REVOCATION_LIST",
+	0x00050014: "SIPAEVENT_SMT_STATUS",
+	0x00050020: "SIPAEVENT_VSM_IDK_INFO",
+	0x00050021: "SIPAEVENT_FLIGHTSIGNING",
+	0x00050022: "SIPAEVENT_PAGEFILE_ENCRYPTION_ENABLED",
+	0x00050024: "SIPAEVENT_HIBERNATION_DISABLED",
+	0x00050025: "SIPAEVENT_DUMPS_DISABLED",
+	0x00050026: "SIPAEVENT_DUMP_ENCRYPTION_ENABLED",
+	0x00050028: "SIPAEVENT_LSAISO_CONFIG",
+	0x00050030: "SIPAEVENT_HYPERVISOR_BOOT_DMA_PROTECTION",
+	0x00060001: "SIPAEVENT_NOAUTHORITY",
+	0x00060002: "SIPAEVENT_AUTHORITYPUBKEY",
+	0x00090001: "SIPAEVENT_ELAM_KEYNAME",
+	0x00090002: "SIPAEVENT_ELAM_CONFIGURATION",
+	0x00090003: "SIPAEVENT_ELAM_POLICY",
+	0x00090004: "SIPAEVENT_ELAM_MEASURED",
+	0x000A0001: "SIPAEVENT_VBS_VSM_REQUIRED",
+	0x000A0002: "SIPAEVENT_VBS_SECUREBOOT_REQUIRED",
+	0x000A0003: "SIPAEVENT_VBS_IOMMU_REQUIRED",
+	0x000A0004: "SIPAEVENT_VBS_MMIO_NX_REQUIRED",
+	0x000A0005: "SIPAEVENT_VBS_MSR_FILTERING_REQUIRED",
+	0x000A0006: "SIPAEVENT_VBS_MANDATORY_ENFORCEMENT",
+	0x000A0007: "SIPAEVENT_VBS_HVCI_POLICY",
+	0x000A0008: "SIPAEVENT_VBS_MICROSOFT_BOOT_CHAIN_REQUIRED",
+}
+
+// WindowsBootComponents maps the file names of the Windows boot components to the event tag used in flavors,
+// the other loaded modules are tagged with their lower case file name
+var WindowsBootComponents = map[string]string{
+	"bootmgfw.efi":     "bootmgr",
+	"bootmgr.efi":      "bootmgr",
+	"winload.efi":      "winload",
+	"winresume.efi":    "winresume",
+	"hvloader.efi":     "hvloader",
+	"hvix64.exe":       "hypervisor",
+	"hvax64.exe":       "hypervisor",
+	"securekernel.exe": "securekernel",
+	"ntoskrnl.exe":     "ntoskrnl",
+	"hal.dll":          "hal",
+	"ci.dll":           "ci",
+	"tcbloader.dll":    "tcbloader",
+}
+
+var wbclBanks = map[uint16]string{
+	TPM_API_ALG_ID_SHA1:       SHA1,
+	TPM_API_ALG_ID_SHA256:     SHA256,
+	TPM_API_ALG_ID_SHA384:     SHA384,
+	TPM_API_ALG_ID_SHA512:     SHA512,
+	TPM_API_ALG_ID_SM3_SHA256: SM3_256,
+}
+
+type wbclDigest struct {
+	bank   string
+	digest []byte
+}
+
+// ParseWbcl parses a Windows Boot Configuration Log and returns its events by PCR index and bank, in the order of
+// the log
+func ParseWbcl(wbcl []byte) ([]hvs.TpmEventLog, error) {
+	log.Trace("util/wbcl_parser:ParseWbcl() Entering")
+	defer log.Trace("util/wbcl_parser:ParseWbcl() Leaving")
+
+	buf := bytes.NewReader(wbcl)
+
+	// the first event is always in the SHA1 format, it describes the digests of the log when crypto agile
+	pcrIndex, eventType, digest, eventData, err := readWbclEventV1(buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "util/wbcl_parser:ParseWbcl() Error reading first event of the WBCL")
+	}
+
+	var eventLogs []hvs.TpmEventLog
+	digestSizes, cryptoAgile, err := parseSpecIdEvent(eventType, eventData)
+	if err != nil {
+		return nil, errors.Wrap(err, "util/wbcl_parser:ParseWbcl() Error parsing Spec ID event")
+	}
+	if !cryptoAgile {
+		eventLogs = addWbclEvent(eventLogs, pcrIndex, eventType, []wbclDigest{{SHA1, digest}}, eventData)
+	}
+
+	for buf.Len() > 0 {
+		var digests []wbclDigest
+		if cryptoAgile {
+			pcrIndex, eventType, digests, eventData, err = readWbclEventV2(buf, digestSizes)
+		} else {
+			pcrIndex, eventType, digest, eventData, err = readWbclEventV1(buf)
+			digests = []wbclDigest{{SHA1, digest}}
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "util/wbcl_parser:ParseWbcl() Error reading event at offset %d of the WBCL", len(wbcl)-buf.Len())
+		}
+		eventLogs = addWbclEvent(eventLogs, pcrIndex, eventType, digests, eventData)
+	}
+	return eventLogs, nil
+}
+
+func readWbclEventV1(buf *bytes.Reader) (uint32, uint32, []byte, []byte, error) {
+	var header struct {
+		PcrIndex  uint32
+		EventType uint32
+		Digest    [SHA1_SIZE]byte
+		EventSize uint32
+	}
+	if err := binary.Read(buf, binary.LittleEndian, &header); err != nil {
+		return 0, 0, nil, nil, errors.Wrap(err, "Error reading TCG_PCR_EVENT header")
+	}
+	eventData, err := readWbclBytes(buf, header.EventSize)
+	if err != nil {
+		return 0, 0, nil, nil, errors.Wrap(err, "Error reading TCG_PCR_EVENT data")
+	}
+	return header.PcrIndex, header.EventType, header.Digest[:], eventData, nil
+}
+
+func readWbclEventV2(buf *bytes.Reader, digestSizes map[uint16]uint16) (uint32, uint32, []wbclDigest, []byte, error) {
+	var header struct {
+		PcrIndex    uint32
+		EventType   uint32
+		DigestCount uint32
+	}
+	if err := binary.Read(buf, binary.LittleEndian, &header); err != nil {
+		return 0, 0, nil, nil, errors.Wrap(err, "Error reading TCG_PCR_EVENT2 header")
+	}
+	if header.DigestCount > uint32(len(digestSizes)) {
+		return 0, 0, nil, nil, errors.Errorf("Invalid TCG_PCR_EVENT2 digest count %d", header.DigestCount)
+	}
+
+	var digests []wbclDigest
+	for i := uint32(0); i < header.DigestCount; i++ {
+		var algID uint16
+		if err := binary.Read(buf, binary.LittleEndian, &algID); err != nil {
+			return 0, 0, nil, nil, errors.Wrap(err, "Error reading TCG_PCR_EVENT2 digest algorithm")
+		}
+		digestSize, ok := digestSizes[algID]
+		if !ok {
+			return 0, 0, nil, nil, errors.Errorf("TCG_PCR_EVENT2 digest algorithm 0x%x is not in the Spec ID event", algID)
+		}
+		digest, err := readWbclBytes(buf, uint32(digestSize))
+		if err != nil {
+			return 0, 0, nil, nil, errors.Wrap(err, "Error reading TCG_PCR_EVENT2 digest")
+		}
+		// digests of banks the HVS does not support are skipped
+		if bank, ok := wbclBanks[algID]; ok {
+			digests = append(digests, wbclDigest{bank, digest})
+		}
+	}
+
+	var eventSize uint32
+	if err := binary.Read(buf, binary.LittleEndian, &eventSize); err != nil {
+		return 0, 0, nil, nil, errors.Wrap(err, "Error reading TCG_PCR_EVENT2 event size")
+	}
+	eventData, err := readWbclBytes(buf, eventSize)
+	if err != nil {
+		return 0, 0, nil, nil, errors.Wrap(err, "Error reading TCG_PCR_EVENT2 data")
+	}
+	return header.PcrIndex, header.EventType, digests, eventData, nil
+}
+
+func readWbclBytes(buf *bytes.Reader, size uint32) ([]byte, error) {
+	if size > wbclMaxEventSize || int64(size) > int64(buf.Len()) {
+		return nil, errors.Errorf("Invalid size %d, %d bytes left in the log", size, buf.Len())
+	}
+	data := make([]byte, size)
+	_, err := buf.Read(data)
+	return data, err
+}
+
+// parseSpecIdEvent returns the digest sizes of the TCG_EfiSpecIDEvent of a crypto agile log, the log is in the
+// legacy SHA1 format when the first event is not a Spec ID event
+func parseSpecIdEvent(eventType uint32, eventData []byte) (map[uint16]uint16, bool, error) {
+	if eventType != evNoAction || len(eventData) < 16 ||
+		string(bytes.TrimRight(eventData[:16], "\x00")) != wbclSpecIdEventSignature {
+		return nil, false, nil
+	}
+
+	buf := bytes.NewReader(eventData[16:])
+	var header struct {
+		PlatformClass      uint32
+		SpecVersionMinor   uint8
+		SpecVersionMajor   uint8
+		SpecErrata         uint8
+		UintnSize          uint8
+		NumberOfAlgorithms uint32
+	}
+	if err := binary.Read(buf, binary.LittleEndian, &header); err != nil {
+		return nil, false, errors.Wrap(err, "Error reading TCG_EfiSpecIDEvent header")
+	}
+	if header.NumberOfAlgorithms == 0 || header.NumberOfAlgorithms > MAX_PCR_BANKS*2 {
+		return nil, false, errors.Errorf("Invalid number of algorithms %d in TCG_EfiSpecIDEvent", header.NumberOfAlgorithms)
+	}
+
+	digestSizes := make(map[uint16]uint16)
+	for i := uint32(0); i < header.NumberOfAlgorithms; i++ {
+		var algorithm struct {
+			AlgorithmID uint16
+			DigestSize  uint16
+		}
+		if err := binary.Read(buf, binary.LittleEndian, &algorithm); err != nil {
+			return nil, false, errors.Wrap(err, "Error reading TCG_EfiSpecIDEvent digest sizes")
+		}
+		digestSizes[algorithm.AlgorithmID] = algorithm.DigestSize
+	}
+	return digestSizes, true, nil
+}
+
+// addWbclEvent appends the event to the event log of its PCR for every bank of the event
+func addWbclEvent(eventLogs []hvs.TpmEventLog, pcrIndex, eventType uint32, digests []wbclDigest, eventData []byte) []hvs.TpmEventLog {
+	event := hvs.EventLog{
+		TypeID:   fmt.Sprintf("0x%x", eventType),
+		TypeName: wbclEventTypeNames[eventType],
+		Tags:     getWbclEventTags(eventType, eventData),
+	}
+
+	for _, digest := range digests {
+		event.Measurement = hex.EncodeToString(digest.digest)
+		found := false
+		for i := range eventLogs {
+			if eventLogs[i].Pcr.Index == int(pcrIndex) && eventLogs[i].Pcr.Bank == digest.bank {
+				eventLogs[i].TpmEvent = append(eventLogs[i].TpmEvent, event)
+				found = true
+				break
+			}
+		}
+		if !found {
+			eventLogs = append(eventLogs, hvs.TpmEventLog{
+				Pcr:      hvs.Pcr{Index: int(pcrIndex), Bank: digest.bank},
+				TpmEvent: []hvs.EventLog{event},
+			})
+		}
+	}
+	return eventLogs
+}
+
+// getWbclEventTags returns the tags of an event, the event data is never trusted since it is not covered by the
+// measurement of most events, the tags only help matching the events in flavors
+func getWbclEventTags(eventType uint32, eventData []byte) []string {
+	var tags []string
+	switch {
+	case eventType == evNoAction:
+		// the locality of the startup is used when replaying PCR 0
+		if len(eventData) == len(startupLocalitySignature)+2 &&
+			string(eventData[:len(startupLocalitySignature)]) == startupLocalitySignature {
+			tags = append(tags, startupLocalitySignature+strconv.Itoa(int(eventData[len(eventData)-1])))
+		}
+	case eventType == evEventTag:
+		tags = getSipaEventTags(eventData, tags, 0)
+	case eventType == evEfiVariableDriverConfig || eventType == evEfiVariableBoot ||
+		eventType == evEfiVariableBoot2 || eventType == evEfiVariableAuthority:
+		// UEFI_VARIABLE_DATA: VariableName GUID, UnicodeNameLength, VariableDataLength, UnicodeName
+		if len(eventData) >= 32 {
+			nameLength := binary.LittleEndian.Uint64(eventData[16:24])
+			if nameLength <= uint64(len(eventData)-32)/2 {
+				tags = append(tags, decodeUtf16(eventData[32:32+nameLength*2]))
+			}
+		}
+	case eventType == evEfiBootServicesApplication || eventType == evEfiBootServicesDriver:
+		// UEFI_IMAGE_LOAD_EVENT: the device path follows the image location, length, link time address and
+		// device path length
+		if len(eventData) >= uefiImageLoadEventHeaderLength {
+			devicePathLength := binary.LittleEndian.Uint64(eventData[24:32])
+			if devicePathLength <= uint64(len(eventData)-uefiImageLoadEventHeaderLength) {
+				filePath := getDevicePathFilePath(eventData[uefiImageLoadEventHeaderLength : uefiImageLoadEventHeaderLength+devicePathLength])
+				if filePath != "" {
+					tags = append(tags, getWindowsBootComponentTag(filePath))
+				}
+			}
+		}
+	case wbclStringEvents[eventType]:
+		tag := strings.TrimRight(string(eventData), "\x00")
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// getSipaEventTags walks the SIPA events of an EV_EVENT_TAG event, the aggregation events contain other SIPA events
+func getSipaEventTags(eventData []byte, tags []string, depth int) []string {
+	if depth > wbclMaxSipaDepth {
+		return tags
+	}
+	for len(eventData) >= 8 {
+		sipaEventType := binary.LittleEndian.Uint32(eventData[0:4])
+		sipaEventSize := binary.LittleEndian.Uint32(eventData[4:8])
+		if uint64(sipaEventSize) > uint64(len(eventData)-8) {
+			break
+		}
+		sipaEventData := eventData[8 : 8+sipaEventSize]
+		eventData = eventData[8+sipaEventSize:]
+
+		if sipaEventType&sipaEventTypeAggregation != 0 {
+			tags = getSipaEventTags(sipaEventData, tags, depth+1)
+			continue
+		}
+		var tag string
+		if sipaEventType == sipaEventFilePath {
+			tag = getWindowsBootComponentTag(decodeUtf16(sipaEventData))
+		} else {
+			tag = sipaEventNames[sipaEventType]
+		}
+		if tag != "" && !containsTag(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// getDevicePathFilePath returns the last file path node of an EFI device path
+func getDevicePathFilePath(devicePath []byte) string {
+	var filePath string
+	for len(devicePath) >= 4 {
+		nodeType := devicePath[0]
+		nodeSubType := devicePath[1]
+		nodeLength := int(binary.LittleEndian.Uint16(devicePath[2:4]))
+		if nodeType == uefiDevicePathTypeEnd || nodeLength < 4 || nodeLength > len(devicePath) {
+			break
+		}
+		if nodeType == uefiDevicePathTypeMedia && nodeSubType == uefiDevicePathSubTypeFilePath {
+			filePath = decodeUtf16(devicePath[4:nodeLength])
+		}
+		devicePath = devicePath[nodeLength:]
+	}
+	return filePath
+}
+
+func getWindowsBootComponentTag(filePath string) string {
+	fileName := strings.ToLower(path.Base(strings.ReplaceAll(filePath, "\\", "/")))
+	if component, ok := WindowsBootComponents[fileName]; ok {
+		return component
+	}
+	return fileName
+}
+
+func decodeUtf16(data []byte) string {
+	utf16Data := make([]uint16, len(data)/2)
+	for i := range utf16Data {
+		utf16Data[i] = binary.LittleEndian.Uint16(data[i*2:])
+	}
+	return strings.TrimRight(string(utf16.Decode(utf16Data)), "\x00")
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
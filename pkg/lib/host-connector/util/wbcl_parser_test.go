@@ -0,0 +1,93 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package util
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func getWbclEventLog(t *testing.T, eventLogs []hvs.TpmEventLog, pcrIndex int, bank string) *hvs.TpmEventLog {
+	for i := range eventLogs {
+		if eventLogs[i].Pcr.Index == pcrIndex && eventLogs[i].Pcr.Bank == bank {
+			return &eventLogs[i]
+		}
+	}
+	t.Fatalf("No event log for PCR %d of bank %s", pcrIndex, bank)
+	return nil
+}
+
+func getTpmEventTags(eventLog *hvs.TpmEventLog) []string {
+	var tags []string
+	for _, event := range eventLog.TpmEvent {
+		tags = append(tags, event.Tags...)
+	}
+	return tags
+}
+
+func TestParseWbcl(t *testing.T) {
+	wbcl, err := ioutil.ReadFile("../test/sample_wbcl.bin")
+	assert.NoError(t, err)
+
+	eventLogs, err := ParseWbcl(wbcl)
+	assert.NoError(t, err)
+	// PCR 0, 4, 7, 11, 12 and 13 in the SHA1 and SHA256 banks
+	assert.Len(t, eventLogs, 12)
+
+	// the Spec ID event is not part of the event log, the startup locality is
+	pcr0 := getWbclEventLog(t, eventLogs, 0, SHA256)
+	assert.Equal(t, "EV_NO_ACTION", pcr0.TpmEvent[0].TypeName)
+	assert.Equal(t, []string{hvs.StartupLocalityTag}, pcr0.TpmEvent[0].Tags)
+	assert.Equal(t, "EV_S_CRTM_VERSION", pcr0.TpmEvent[1].TypeName)
+	assert.Equal(t, "0x8", pcr0.TpmEvent[1].TypeID)
+
+	pcr4 := getWbclEventLog(t, eventLogs, 4, SHA256)
+	assert.Equal(t, []string{"Calling EFI Application from Boot Option", "bootmgr"}, getTpmEventTags(pcr4))
+
+	pcr7 := getWbclEventLog(t, eventLogs, 7, SHA1)
+	assert.Equal(t, []string{"SecureBoot", "PK", "KEK", "db", "dbx", "db"}, getTpmEventTags(pcr7))
+	assert.Len(t, pcr7.TpmEvent[0].Measurement, 40)
+
+	pcr12 := getWbclEventLog(t, eventLogs, 12, SHA256)
+	assert.Contains(t, getTpmEventTags(pcr12), "SIPAEVENT_BOOTDEBUGGING")
+	assert.Contains(t, getTpmEventTags(pcr12), "SIPAEVENT_TESTSIGNING")
+
+	// the loaded modules are tagged with their component name or their file name
+	pcr13 := getWbclEventLog(t, eventLogs, 13, SHA256)
+	assert.Equal(t, "EV_EVENT_TAG", pcr13.TpmEvent[0].TypeName)
+	assert.Equal(t, []string{"winload", "ntoskrnl", "hal", "tpm.sys", "ci"}, getTpmEventTags(pcr13))
+}
+
+func TestParseWbclReplay(t *testing.T) {
+	wbcl, err := ioutil.ReadFile("../test/sample_wbcl.bin")
+	assert.NoError(t, err)
+
+	eventLogs, err := ParseWbcl(wbcl)
+	assert.NoError(t, err)
+
+	// PCR 0 starts from locality 3
+	pcr0, err := getWbclEventLog(t, eventLogs, 0, SHA256).Replay()
+	assert.NoError(t, err)
+	assert.Equal(t, "fc0fbd3088ddef6db78132af673e4b6eba5bde9e7169a1c79db907cd515cdfaa", pcr0)
+
+	pcr13, err := getWbclEventLog(t, eventLogs, 13, SHA256).Replay()
+	assert.NoError(t, err)
+	assert.Equal(t, "0841ac4465ec756a0226588ec07e212ad9149424211b572fe7dfed13c094ecab", pcr13)
+}
+
+func TestParseWbclTruncated(t *testing.T) {
+	wbcl, err := ioutil.ReadFile("../test/sample_wbcl.bin")
+	assert.NoError(t, err)
+
+	_, err = ParseWbcl(wbcl[:len(wbcl)-10])
+	assert.Error(t, err)
+
+	_, err = ParseWbcl(wbcl[:20])
+	assert.Error(t, err)
+}
@@ -169,6 +169,9 @@ static int CreateQuoteBuffer(TPM2B_ATTEST *quote,
         case TPM2_ALG_SHA512:
             pcrSize = 64;
             break;
+        case TPM2_ALG_SM3_256:
+            pcrSize = 32;
+            break;
         default:
             ERROR("Unknown pcr selection hash: 0x%x", pcrSelection->pcrSelections[i].hash);
             return -1;
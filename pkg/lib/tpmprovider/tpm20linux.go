@@ -207,6 +207,8 @@ func (t *tpm20Linux) CreateAik(ownerSecretKey, endorsementSecretKey string) erro
 // #define TPM2_ALG_SHA1                0x0004											[["SHA1"]]
 // #define TPM2_ALG_SHA256              0x000B											[["SHA256"]]
 // #define TPM2_ALG_SHA384              0x000C											[["SHA384"]]
+// #define TPM2_ALG_SHA512              0x000D											[["SHA512"]]
+// #define TPM2_ALG_SM3_256             0x0012											[["SM3_256"]]
 //
 // Design goals were to keep the go code 'application specific' (i.e. fx that
 // were needed by GTA -- no a general use TPM library).  So, we're keeping this function's
@@ -234,6 +236,10 @@ func getPcrSelectionBytes(pcrBanks []string, pcrs []int) ([]byte, error) {
 			hash = 0x0B
 		case "SHA384":
 			hash = 0x0C
+		case "SHA512":
+			hash = 0x0D
+		case "SM3_256":
+			hash = 0x12
 		default:
 			return nil, fmt.Errorf("Invalid pcr bank type: %s", pcrBanks[i])
 		}
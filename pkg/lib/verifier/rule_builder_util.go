@@ -78,6 +78,27 @@ func getPcrEventLogEqualsExcludingRules(pcrLogData *hvs.FlavorPcrs, marker hvs.F
 	return pcrRules, nil
 }
 
+//getPcrEventLogReplayRules method will create PcrEventLogReplayRule and return the rule
+//return nil if error occurs
+func getPcrEventLogReplayRules(pcrLogData *hvs.FlavorPcrs, marker hvs.FlavorPartName) ([]rules.Rule, error) {
+	var pcrRules []rules.Rule
+
+	expectedPcrEventLogEntry := hvs.TpmEventLog{
+		Pcr: hvs.Pcr{
+			Index: pcrLogData.Pcr.Index,
+			Bank:  pcrLogData.Pcr.Bank,
+		},
+		TpmEvent: pcrLogData.EventlogEqual.Events,
+	}
+	rule, err := rules.NewPcrEventLogReplay(&expectedPcrEventLogEntry, pcrLogData.EventlogEqual.ExcludeTags, marker)
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred creating a PcrEventLogReplay rule for bank '%s', index '%d'", pcrLogData.Pcr.Bank, pcrLogData.Pcr.Index)
+	}
+	pcrRules = append(pcrRules, rule)
+
+	return pcrRules, nil
+}
+
 //getPcrEventLogIntegrityRules method will create PcrEventLogIntegrityRule and return the rule
 //return nil if error occurs
 func getPcrEventLogIntegrityRules(pcrLogData *hvs.FlavorPcrs, marker hvs.FlavorPartName) ([]rules.Rule, error) {
@@ -104,6 +104,11 @@ func (factory *ruleFactory) GetVerificationRules() ([]rules.Rule, string, error)
 					pcrRules, err = getPcrEventLogEqualsExcludingRules(&rule, flavorPartName)
 				}
 				requiredRules = append(requiredRules, pcrRules...)
+				if err == nil {
+					//call method to create pcr event log replay rule, it reports the first event diverging from the flavor
+					pcrRules, err = getPcrEventLogReplayRules(&rule, flavorPartName)
+					requiredRules = append(requiredRules, pcrRules...)
+				}
 			} else if value.Type().Field(i).Name == hvsconstants.EventlogIncludesRule && len(rule.EventlogIncludes) > 0 {
 				eventsPresent = true
 				//call method to create pcr event log includes rule
@@ -172,6 +177,11 @@ func (factory *ruleFactory) getRuleBuilder() (ruleBuilder, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "There was an error creating the Intel rule builder")
 		}
+	case constants.VendorMicrosoft:
+		builder, err = newRuleBuilderMicrosoftTpm20(factory.verifierCertificates, factory.hostManifest, factory.signedFlavor)
+		if err != nil {
+			return nil, errors.Wrap(err, "There was an error creating the Microsoft rule builder")
+		}
 	case constants.VendorVMware:
 		tpmVersionString := factory.signedFlavor.Flavor.Meta.Description[flavormodel.TpmVersion].(string)
 		if len(tpmVersionString) == 0 {
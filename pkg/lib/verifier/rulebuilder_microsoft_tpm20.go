@@ -0,0 +1,99 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package verifier
+
+//
+// Builds rules for "microsoft" vendor and TPM 2.0.
+//
+
+import (
+	hvsconstants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/verifier/rules"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+type ruleBuilderMicrosoftTpm20 struct {
+	verifierCertificates VerifierCertificates
+	hostManifest         *hvs.HostManifest
+	signedFlavor         *hvs.SignedFlavor
+	rules                []rules.Rule
+}
+
+func newRuleBuilderMicrosoftTpm20(verifierCertificates VerifierCertificates, hostManifest *hvs.HostManifest, signedFlavor *hvs.SignedFlavor) (ruleBuilder, error) {
+	builder := ruleBuilderMicrosoftTpm20{
+		verifierCertificates: verifierCertificates,
+		hostManifest:         hostManifest,
+		signedFlavor:         signedFlavor,
+	}
+
+	return &builder, nil
+}
+
+func (builder *ruleBuilderMicrosoftTpm20) GetName() string {
+	return hvsconstants.MicrosoftBuilder
+}
+
+// AikCertificateTrusted
+// FlavorTrusted (added in verifierimpl)
+//
+// The PCR rules are added by the rule factory from the flavor, the events of the WBCL are tagged with the
+// Windows boot components they measure (see host-connector/util/wbcl_parser.go).
+func (builder *ruleBuilderMicrosoftTpm20) GetAikCertificateTrustedRule(flavorPart hvs.FlavorPartName) ([]rules.Rule, error) {
+
+	var results []rules.Rule
+
+	//
+	// Add 'AikCertificateTrusted' rule...
+	//
+	aikCertificateTrusted, err := rules.NewAikCertificateTrusted(builder.verifierCertificates.PrivacyCACertificates, flavorPart)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in getting AikCertificateTrusted rule")
+	}
+
+	results = append(results, aikCertificateTrusted)
+
+	return results, nil
+}
+
+// TagCertificateTrusted
+// AssetTagMatches
+// FlavorTrusted (added in verifierimpl)
+func (builder *ruleBuilderMicrosoftTpm20) GetAssetTagRules() ([]rules.Rule, error) {
+
+	var results []rules.Rule
+
+	//
+	// TagCertificateTrusted
+	//
+	tagCertificateTrusted, err := getTagCertificateTrustedRule(builder.verifierCertificates.AssetTagCACertificates, &builder.signedFlavor.Flavor)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in getting TagCertificateTrusted rule")
+	}
+
+	results = append(results, tagCertificateTrusted)
+
+	//
+	// AssetTagMatches
+	//
+	assetTagMatches, err := getAssetTagMatchesRule(&builder.signedFlavor.Flavor)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in getting AssetTagMatches rule")
+	}
+
+	results = append(results, assetTagMatches)
+
+	return results, nil
+}
+
+// Software flavors are measured by tboot/the workload agent on linux hosts only
+func (builder *ruleBuilderMicrosoftTpm20) GetSoftwareRules() ([]rules.Rule, error) {
+	return nil, errors.New("Software rules are not supported for Microsoft hosts")
+}
+
+// IMA is not available on Windows hosts
+func (builder *ruleBuilderMicrosoftTpm20) GetImaRules(rule *hvs.FlavorPcrs, flavor hvs.Flavor, flavorPartName hvs.FlavorPartName) ([]rules.Rule, error) {
+	return nil, errors.New("IMA rules are not supported for Microsoft hosts")
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package verifier
+
+import (
+	"crypto/x509"
+	"io/ioutil"
+	"testing"
+
+	hvsconstants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/constants"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/util"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+// newWindowsHostManifest returns a host manifest with the event logs of the sample WBCL of the host connector and
+// the PCR values they replay to
+func newWindowsHostManifest(t *testing.T) *hvs.HostManifest {
+	wbcl, err := ioutil.ReadFile("../host-connector/test/sample_wbcl.bin")
+	assert.NoError(t, err)
+	eventLogs, err := util.ParseWbcl(wbcl)
+	assert.NoError(t, err)
+
+	hostManifest := hvs.HostManifest{}
+	for _, eventLog := range eventLogs {
+		if eventLog.Pcr.Bank != string(hvs.SHA256) {
+			continue
+		}
+		pcrValue, err := eventLog.Replay()
+		assert.NoError(t, err)
+		hostManifest.PcrManifest.Sha256Pcrs = append(hostManifest.PcrManifest.Sha256Pcrs, hvs.HostManifestPcrs{
+			Index:   hvs.PcrIndex(eventLog.Pcr.Index),
+			Value:   pcrValue,
+			PcrBank: hvs.SHA256,
+		})
+		hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs = append(hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs, eventLog)
+	}
+	return &hostManifest
+}
+
+// newWindowsOsFlavor returns an OS flavor including the events of PCR 13 tagged with the provided components
+func newWindowsOsFlavor(hostManifest *hvs.HostManifest, components ...string) *hvs.SignedFlavor {
+	pcr13Events, _ := hostManifest.PcrManifest.GetEventLogCriteria(hvs.SHA256, hvs.PCR13)
+	pcr13, _ := hostManifest.PcrManifest.GetPcrValue(hvs.SHA256, hvs.PCR13)
+
+	var includes []hvs.EventLog
+	for _, event := range pcr13Events {
+		for _, component := range components {
+			if len(event.Tags) > 0 && event.Tags[0] == component {
+				includes = append(includes, event)
+			}
+		}
+	}
+
+	return &hvs.SignedFlavor{
+		Flavor: hvs.Flavor{
+			Meta: hvs.Meta{
+				Description: map[string]interface{}{
+					hvs.FlavorPartDescription: hvs.FlavorPartOs.String(),
+				},
+				Vendor: constants.VendorMicrosoft,
+			},
+			Pcrs: []hvs.FlavorPcrs{
+				{
+					Pcr: hvs.Pcr{
+						Index: 13,
+						Bank:  string(hvs.SHA256),
+					},
+					Measurement:      pcr13.Value,
+					EventlogIncludes: includes,
+				},
+			},
+		},
+	}
+}
+
+func TestRuleFactoryMicrosoftTpm20(t *testing.T) {
+	hostManifest := newWindowsHostManifest(t)
+	signedFlavor := newWindowsOsFlavor(hostManifest, "winload", "ntoskrnl", "hal", "ci")
+	assert.Len(t, signedFlavor.Flavor.Pcrs[0].EventlogIncludes, 4)
+
+	factory := NewRuleFactory(VerifierCertificates{PrivacyCACertificates: x509.NewCertPool()}, hostManifest, signedFlavor, true)
+	requiredRules, builderName, err := factory.GetVerificationRules()
+	assert.NoError(t, err)
+	assert.Equal(t, hvsconstants.MicrosoftBuilder, builderName)
+	// AikCertificateTrusted, PcrEventLogIncludes and PcrEventLogIntegrity
+	assert.Len(t, requiredRules, 3)
+
+	// the boot components of the WBCL match the flavor
+	for _, rule := range requiredRules[1:] {
+		result, err := rule.Apply(hostManifest)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Faults, result.Rule.Name)
+	}
+
+	// a different ntoskrnl is reported as a missing event
+	for i, eventLog := range hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs {
+		if eventLog.Pcr.Index == 13 {
+			hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs[i].TpmEvent[1].Measurement = "0000000000000000000000000000000000000000000000000000000000000000"
+		}
+	}
+	result, err := requiredRules[1].Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.Faults)
+}
+
+func Test_ruleBuilderMicrosoftTpm20_GetSoftwareRules(t *testing.T) {
+	builder, err := newRuleBuilderMicrosoftTpm20(VerifierCertificates{}, &hvs.HostManifest{}, &hvs.SignedFlavor{})
+	assert.NoError(t, err)
+
+	_, err = builder.GetSoftwareRules()
+	assert.Error(t, err)
+
+	_, err = builder.GetImaRules(nil, hvs.Flavor{}, hvs.FlavorPartIma)
+	assert.Error(t, err)
+}
@@ -79,7 +79,7 @@ func TestPcrEventLogIncludesUnsupportedSHAFault(t *testing.T) {
 	flavorEventsLog := hvs.TpmEventLog{
 		Pcr: hvs.Pcr{
 			Index: 0,
-			Bank:  "SHA512",
+			Bank:  "SHA3_256",
 		},
 		TpmEvent: []hvs.EventLog{
 			{
@@ -84,7 +84,7 @@ func TestPcrEventLogIntegrityUnsupportedSHAFault(t *testing.T) {
 	expectedPcrLog := hvs.FlavorPcrs{
 		Pcr: hvs.Pcr{
 			Index: 0,
-			Bank:  "SHA512",
+			Bank:  "SHA3_256",
 		},
 		Measurement: expectedCumulativeHash,
 	}
@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	constants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+// NewPcrEventLogReplay creates a diagnostic rule that replays the event log of a PCR (in the host-manifest)
+// event by event and reports the first event that differs from the events expected by the flavor. Events
+// with one of the exclude tags are not compared but are still replayed.
+func NewPcrEventLogReplay(expectedPcrEventLogEntry *hvs.TpmEventLog, excludeTags []string, marker hvs.FlavorPartName) (Rule, error) {
+	if expectedPcrEventLogEntry == nil {
+		return nil, errors.New("The expected pcr event log cannot be nil")
+	}
+
+	rule := pcrEventLogReplay{
+		expectedPcrEventLogEntry: expectedPcrEventLogEntry,
+		excludeTags:              excludeTags,
+		marker:                   marker,
+	}
+
+	return &rule, nil
+}
+
+type pcrEventLogReplay struct {
+	expectedPcrEventLogEntry *hvs.TpmEventLog
+	excludeTags              []string
+	marker                   hvs.FlavorPartName
+}
+
+// indexedEvent is an event of the host event log along with its position in the log
+type indexedEvent struct {
+	index int
+	event hvs.EventLog
+}
+
+// The rule never raises a fault, a missing PCR manifest or event log is reported by the PcrEventLogIntegrity
+// and PcrEventLogEquals rules.
+// The hostmanifest's event log at 'expected' bank/index is replayed keeping the PCR value after each event,
+// then the events that are not excluded are compared with the 'expected' events in order. The first event whose
+// type or measurement differs, or that is unexpected or missing, is added to the result as a
+// PcrEventLogDivergentEvent mismatch along with the PCR value replayed up to that event.
+func (rule *pcrEventLogReplay) Apply(hostManifest *hvs.HostManifest) (*hvs.RuleResult, error) {
+	result := hvs.RuleResult{}
+	result.Trusted = true
+	result.Rule.Name = constants.RulePcrEventLogReplay
+	result.Rule.ExpectedPcrEventLogEntry = rule.expectedPcrEventLogEntry
+	result.Rule.Exclude_Tags = rule.excludeTags
+	result.Rule.Markers = append(result.Rule.Markers, rule.marker)
+
+	if hostManifest.PcrManifest.IsEmpty() {
+		return &result, nil
+	}
+
+	actualEventLogCriteria, pIndex, bank, err := hostManifest.PcrManifest.PcrEventLogMap.GetEventLogNew(rule.expectedPcrEventLogEntry.Pcr.Bank, rule.expectedPcrEventLogEntry.Pcr.Index)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in getting actual eventlogs in Pcr Eventlog Replay rule")
+	}
+	if actualEventLogCriteria == nil {
+		return &result, nil
+	}
+
+	actualEventLog := &hvs.TpmEventLog{
+		Pcr: hvs.Pcr{
+			Index: pIndex,
+			Bank:  bank,
+		},
+		TpmEvent: actualEventLogCriteria,
+	}
+	replayedPcrValues, err := actualEventLog.ReplayEvents()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in replaying the event log in Pcr Eventlog Replay rule")
+	}
+
+	var actualEvents []indexedEvent
+	for i, event := range actualEventLog.TpmEvent {
+		if !rule.isExcluded(event) {
+			actualEvents = append(actualEvents, indexedEvent{index: i, event: event})
+		}
+	}
+	var expectedEvents []hvs.EventLog
+	for _, event := range rule.expectedPcrEventLogEntry.TpmEvent {
+		if !rule.isExcluded(event) {
+			expectedEvents = append(expectedEvents, event)
+		}
+	}
+
+	divergentEvent := findDivergentEvent(actualEvents, expectedEvents, len(actualEventLog.TpmEvent))
+	if divergentEvent == nil {
+		return &result, nil
+	}
+	if divergentEvent.EventIndex < len(replayedPcrValues) {
+		divergentEvent.ReplayedPcrValue = replayedPcrValues[divergentEvent.EventIndex]
+	} else if len(replayedPcrValues) > 0 {
+		divergentEvent.ReplayedPcrValue = replayedPcrValues[len(replayedPcrValues)-1]
+	}
+
+	pcrIndex := hvs.PcrIndex(actualEventLog.Pcr.Index)
+	pcrBank := hvs.SHAAlgorithm(actualEventLog.Pcr.Bank)
+	result.MismatchField = append(result.MismatchField, hvs.MismatchField{
+		Name: constants.PcrEventLogDivergentEvent,
+		Description: fmt.Sprintf("Event log for PCR %d of %s diverges from the flavor at event %d (%s): expected measurement '%s', actual measurement '%s'",
+			actualEventLog.Pcr.Index, actualEventLog.Pcr.Bank, divergentEvent.EventIndex, divergentEvent.TypeName,
+			divergentEvent.ExpectedMeasurement, divergentEvent.ActualMeasurement),
+		PcrIndex:       &pcrIndex,
+		PcrBank:        &pcrBank,
+		DivergentEvent: divergentEvent,
+	})
+	return &result, nil
+}
+
+func (rule *pcrEventLogReplay) isExcluded(event hvs.EventLog) bool {
+	for _, excludeTag := range rule.excludeTags {
+		for _, tag := range event.Tags {
+			if excludeTag == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findDivergentEvent compares the actual and expected events in order and returns the first difference, nil is
+// returned when the events are the same
+func findDivergentEvent(actualEvents []indexedEvent, expectedEvents []hvs.EventLog, eventLogLength int) *hvs.DivergentEvent {
+	for i := 0; i < len(actualEvents) || i < len(expectedEvents); i++ {
+		if i >= len(expectedEvents) {
+			// the host has more events than expected
+			actual := actualEvents[i]
+			return &hvs.DivergentEvent{
+				EventIndex:        actual.index,
+				TypeID:            actual.event.TypeID,
+				TypeName:          actual.event.TypeName,
+				Tags:              actual.event.Tags,
+				ActualMeasurement: actual.event.Measurement,
+			}
+		}
+		expected := expectedEvents[i]
+		if i >= len(actualEvents) {
+			// the expected event is missing from the host event log
+			return &hvs.DivergentEvent{
+				EventIndex:          eventLogLength,
+				TypeID:              expected.TypeID,
+				TypeName:            expected.TypeName,
+				Tags:                expected.Tags,
+				ExpectedMeasurement: expected.Measurement,
+			}
+		}
+		actual := actualEvents[i]
+		if !sameEvent(actual.event, expected) {
+			return &hvs.DivergentEvent{
+				EventIndex:          actual.index,
+				TypeID:              actual.event.TypeID,
+				TypeName:            actual.event.TypeName,
+				Tags:                actual.event.Tags,
+				ExpectedMeasurement: expected.Measurement,
+				ActualMeasurement:   actual.event.Measurement,
+			}
+		}
+	}
+	return nil
+}
+
+// sameEvent compares the measurements of the events, the types are only compared when both events have them
+func sameEvent(actual, expected hvs.EventLog) bool {
+	if !strings.EqualFold(actual.Measurement, expected.Measurement) {
+		return false
+	}
+	if actual.TypeID != "" && expected.TypeID != "" && actual.TypeID != expected.TypeID {
+		return false
+	}
+	if actual.TypeName != "" && expected.TypeName != "" && actual.TypeName != expected.TypeName {
+		return false
+	}
+	return true
+}
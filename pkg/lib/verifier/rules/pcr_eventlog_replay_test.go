@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	constants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/host-connector/util"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReplayTestHostManifest(events []hvs.EventLog) *hvs.HostManifest {
+	hostManifest := hvs.HostManifest{}
+	hostManifest.PcrManifest.Sha256Pcrs = append(hostManifest.PcrManifest.Sha256Pcrs, hvs.HostManifestPcrs{
+		Index:   0,
+		PcrBank: "SHA256",
+		Value:   PCR_VALID_256,
+	})
+	hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs = append(hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs, hvs.TpmEventLog{
+		Pcr: hvs.Pcr{
+			Index: 0,
+			Bank:  "SHA256",
+		},
+		TpmEvent: events,
+	})
+	return &hostManifest
+}
+
+func TestPcrEventLogReplayNoMismatch(t *testing.T) {
+	hostManifest := newReplayTestHostManifest(testExpectedPcrEventLogEntry.TpmEvent)
+
+	rule, err := NewPcrEventLogReplay(&testExpectedPcrEventLogEntry, nil, hvs.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.True(t, result.Trusted)
+	assert.Equal(t, constants.RulePcrEventLogReplay, result.Rule.Name)
+	assert.Empty(t, result.Faults)
+	assert.Empty(t, result.MismatchField)
+}
+
+func TestPcrEventLogReplayDivergentMeasurement(t *testing.T) {
+	tamperedMeasurement := "22222222222222222222222222222222"
+	hostManifest := newReplayTestHostManifest([]hvs.EventLog{
+		testExpectedPcrEventLogEntry.TpmEvent[0],
+		{
+			TypeName:    util.EVENT_LOG_DIGEST_SHA256,
+			Tags:        []string{"shim"},
+			Measurement: tamperedMeasurement,
+		},
+	})
+
+	rule, err := NewPcrEventLogReplay(&testExpectedPcrEventLogEntry, nil, hvs.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	// the rule is a diagnostic, it does not change the trust status
+	assert.True(t, result.Trusted)
+	assert.Empty(t, result.Faults)
+	assert.Len(t, result.MismatchField, 1)
+
+	mismatch := result.MismatchField[0]
+	assert.Equal(t, constants.PcrEventLogDivergentEvent, mismatch.Name)
+	assert.Equal(t, hvs.PcrIndex(0), *mismatch.PcrIndex)
+	assert.Equal(t, hvs.SHA256, *mismatch.PcrBank)
+	assert.Equal(t, 1, mismatch.DivergentEvent.EventIndex)
+	assert.Equal(t, []string{"shim"}, mismatch.DivergentEvent.Tags)
+	assert.Equal(t, ones, mismatch.DivergentEvent.ExpectedMeasurement)
+	assert.Equal(t, tamperedMeasurement, mismatch.DivergentEvent.ActualMeasurement)
+
+	replayed, err := (&hvs.TpmEventLog{
+		Pcr:      testExpectedPcrEventLogEntry.Pcr,
+		TpmEvent: hostManifest.PcrManifest.PcrEventLogMap.Sha256EventLogs[0].TpmEvent,
+	}).Replay()
+	assert.NoError(t, err)
+	assert.Equal(t, replayed, mismatch.DivergentEvent.ReplayedPcrValue)
+}
+
+func TestPcrEventLogReplayMissingEvent(t *testing.T) {
+	hostManifest := newReplayTestHostManifest(testExpectedPcrEventLogEntry.TpmEvent[:1])
+
+	rule, err := NewPcrEventLogReplay(&testExpectedPcrEventLogEntry, nil, hvs.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Len(t, result.MismatchField, 1)
+	assert.Equal(t, 1, result.MismatchField[0].DivergentEvent.EventIndex)
+	assert.Equal(t, ones, result.MismatchField[0].DivergentEvent.ExpectedMeasurement)
+	assert.Empty(t, result.MismatchField[0].DivergentEvent.ActualMeasurement)
+}
+
+func TestPcrEventLogReplayExcludedEvent(t *testing.T) {
+	hostManifest := newReplayTestHostManifest([]hvs.EventLog{
+		testExpectedPcrEventLogEntry.TpmEvent[0],
+		{
+			TypeName:    util.EVENT_LOG_DIGEST_SHA256,
+			Tags:        []string{"vmlinuz"},
+			Measurement: "33333333333333333333333333333333",
+		},
+		testExpectedPcrEventLogEntry.TpmEvent[1],
+	})
+
+	rule, err := NewPcrEventLogReplay(&testExpectedPcrEventLogEntry, []string{"vmlinuz"}, hvs.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(hostManifest)
+	assert.NoError(t, err)
+	assert.Empty(t, result.MismatchField)
+}
+
+func TestPcrEventLogReplayPcrManifestMissing(t *testing.T) {
+	rule, err := NewPcrEventLogReplay(&testExpectedPcrEventLogEntry, nil, hvs.FlavorPartPlatform)
+	assert.NoError(t, err)
+	result, err := rule.Apply(&hvs.HostManifest{})
+	assert.NoError(t, err)
+	assert.True(t, result.Trusted)
+	assert.Empty(t, result.Faults)
+
+	_, err = NewPcrEventLogReplay(nil, nil, hvs.FlavorPartPlatform)
+	assert.Error(t, err)
+}
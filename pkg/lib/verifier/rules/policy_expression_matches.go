@@ -0,0 +1,430 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+//
+// Rule that evaluates the policy expression of a flavorgroup policy rule on the host manifest.
+//
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	constants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	"github.com/pkg/errors"
+)
+
+const maxPolicyExpressionDepth = 16
+
+var policyRuleNameRegex = regexp.MustCompile(`^[A-Za-z0-9_.\-]{1,64}$`)
+
+func NewPolicyExpressionMatches(policyRule hvs.PolicyRule, flavorGroupId uuid.UUID) (Rule, error) {
+
+	if policyRule.Name == "" {
+		return nil, errors.New("The policy rule name cannot be empty")
+	}
+
+	if policyRule.Marker == "" {
+		policyRule.Marker = hvs.FlavorPartPlatform
+	}
+
+	rule := policyExpressionMatches{
+		policyRule:    policyRule,
+		flavorGroupId: flavorGroupId,
+	}
+	return &rule, nil
+}
+
+type policyExpressionMatches struct {
+	policyRule    hvs.PolicyRule
+	flavorGroupId uuid.UUID
+}
+
+//   - if the policy rule is not valid, raise 'policy expression invalid' fault
+//   - evaluate the expression on the JSON representation of the host manifest and raise a
+//     'policy expression mismatch' fault for each condition that is not satisfied
+func (rule *policyExpressionMatches) Apply(hostManifest *hvs.HostManifest) (*hvs.RuleResult, error) {
+
+	result := hvs.RuleResult{}
+	result.Trusted = true // default to true, set to false when fault encountered
+	result.Rule.Name = constants.RulePolicyExpressionMatches
+	result.Rule.Markers = append(result.Rule.Markers, rule.policyRule.Marker)
+	result.Rule.FlavorGroupID = &rule.flavorGroupId
+	policyRule := rule.policyRule
+	result.Rule.PolicyRule = &policyRule
+
+	if err := ValidatePolicyRule(rule.policyRule); err != nil {
+		result.Faults = append(result.Faults, hvs.Fault{
+			Name:        constants.FaultPolicyExpressionInvalid,
+			Description: fmt.Sprintf("Policy rule '%s' is not valid: %s", rule.policyRule.Name, err.Error()),
+		})
+		result.Trusted = false
+		return &result, nil
+	}
+
+	manifestBytes, err := json.Marshal(hostManifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not marshal the HostManifest to validate rule PolicyExpressionMatches")
+	}
+	var document interface{}
+	if err := json.Unmarshal(manifestBytes, &document); err != nil {
+		return nil, errors.Wrap(err, "Could not unmarshal the HostManifest to validate rule PolicyExpressionMatches")
+	}
+
+	_, faults := evaluatePolicyExpression(rule.policyRule.Name, rule.policyRule.Expression, document)
+	if len(faults) > 0 {
+		result.Faults = append(result.Faults, faults...)
+		result.Trusted = false
+	}
+
+	return &result, nil
+}
+
+// ValidatePolicyRule checks that the name and the marker of the policy rule are valid and that every condition of
+// its expression has a path, a supported operator and a value of the type expected by the operator
+func ValidatePolicyRule(policyRule hvs.PolicyRule) error {
+	if !policyRuleNameRegex.MatchString(policyRule.Name) {
+		return errors.New("Policy rule name must be 1 to 64 characters of letters, digits, '_', '.' or '-'")
+	}
+	if policyRule.Marker != "" {
+		var marker hvs.FlavorPartName
+		if err := marker.Parse(policyRule.Marker.String()); err != nil {
+			return errors.Wrapf(err, "Invalid marker for policy rule '%s'", policyRule.Name)
+		}
+	}
+	if err := validatePolicyExpression(policyRule.Expression, 1); err != nil {
+		return errors.Wrapf(err, "Invalid expression for policy rule '%s'", policyRule.Name)
+	}
+	return nil
+}
+
+func validatePolicyExpression(expression hvs.PolicyExpression, depth int) error {
+	if depth > maxPolicyExpressionDepth {
+		return errors.Errorf("Expression cannot be nested more than %d levels", maxPolicyExpressionDepth)
+	}
+
+	kinds := 0
+	if expression.AllOf != nil {
+		kinds++
+	}
+	if expression.AnyOf != nil {
+		kinds++
+	}
+	if expression.Not != nil {
+		kinds++
+	}
+	if expression.Path != "" || expression.Operator != "" {
+		kinds++
+	}
+	if kinds != 1 {
+		return errors.New("Expression must have exactly one of all_of, any_of, not or a path condition")
+	}
+
+	switch {
+	case expression.AllOf != nil || expression.AnyOf != nil:
+		expressions := append(expression.AllOf, expression.AnyOf...)
+		if len(expressions) == 0 {
+			return errors.New("all_of and any_of must contain at least one expression")
+		}
+		for _, e := range expressions {
+			if err := validatePolicyExpression(e, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case expression.Not != nil:
+		return validatePolicyExpression(*expression.Not, depth+1)
+	}
+
+	for _, segment := range strings.Split(expression.Path, ".") {
+		if segment == "" {
+			return errors.Errorf("Invalid path '%s'", expression.Path)
+		}
+	}
+
+	switch expression.Operator {
+	case hvs.PolicyOperatorExists:
+		if expression.Value != nil {
+			return errors.Errorf("Operator '%s' does not take a value", expression.Operator)
+		}
+	case hvs.PolicyOperatorEquals, hvs.PolicyOperatorNotEquals, hvs.PolicyOperatorContains:
+		if expression.Value == nil {
+			return errors.Errorf("Operator '%s' requires a value", expression.Operator)
+		}
+	case hvs.PolicyOperatorIn:
+		if _, ok := expression.Value.([]interface{}); !ok {
+			return errors.Errorf("Operator '%s' requires a list of values", expression.Operator)
+		}
+	case hvs.PolicyOperatorMatches:
+		pattern, ok := expression.Value.(string)
+		if !ok {
+			return errors.Errorf("Operator '%s' requires a regular expression", expression.Operator)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return errors.Wrapf(err, "Invalid regular expression '%s'", pattern)
+		}
+	case hvs.PolicyOperatorGt, hvs.PolicyOperatorGte, hvs.PolicyOperatorLt, hvs.PolicyOperatorLte:
+		switch expression.Value.(type) {
+		case float64, int, string:
+		default:
+			return errors.Errorf("Operator '%s' requires a number or a version string", expression.Operator)
+		}
+	default:
+		return errors.Errorf("Unsupported operator '%s'", expression.Operator)
+	}
+	return nil
+}
+
+// evaluatePolicyExpression returns whether the expression is satisfied by the document and, when it is not, the
+// faults of the conditions that caused it
+func evaluatePolicyExpression(ruleName string, expression hvs.PolicyExpression, document interface{}) (bool, []hvs.Fault) {
+	switch {
+	case expression.AllOf != nil:
+		var faults []hvs.Fault
+		for _, e := range expression.AllOf {
+			if ok, f := evaluatePolicyExpression(ruleName, e, document); !ok {
+				faults = append(faults, f...)
+			}
+		}
+		return len(faults) == 0, faults
+	case expression.AnyOf != nil:
+		var faults []hvs.Fault
+		for _, e := range expression.AnyOf {
+			ok, f := evaluatePolicyExpression(ruleName, e, document)
+			if ok {
+				return true, nil
+			}
+			faults = append(faults, f...)
+		}
+		return false, faults
+	case expression.Not != nil:
+		if ok, _ := evaluatePolicyExpression(ruleName, *expression.Not, document); ok {
+			return false, []hvs.Fault{{
+				Name:        constants.FaultPolicyExpressionMismatch,
+				Description: fmt.Sprintf("Policy rule '%s' requires the expression %s not to be satisfied", ruleName, describePolicyExpression(*expression.Not)),
+			}}
+		}
+		return true, nil
+	}
+
+	actual, found := lookupPolicyPath(document, expression.Path)
+	if evaluatePolicyCondition(expression, actual, found) {
+		return true, nil
+	}
+
+	fault := hvs.Fault{
+		Name:        constants.FaultPolicyExpressionMismatch,
+		Description: fmt.Sprintf("Policy rule '%s' condition %s is not satisfied", ruleName, describePolicyExpression(expression)),
+	}
+	if expression.Value != nil {
+		expectedValue := policyValueString(expression.Value)
+		fault.ExpectedValue = &expectedValue
+	}
+	if found {
+		actualValue := policyValueString(actual)
+		fault.ActualValue = &actualValue
+	} else {
+		fault.Description += ", the host manifest does not include " + expression.Path
+	}
+	return false, []hvs.Fault{fault}
+}
+
+func evaluatePolicyCondition(expression hvs.PolicyExpression, actual interface{}, found bool) bool {
+	if !found || actual == nil {
+		return false
+	}
+
+	switch expression.Operator {
+	case hvs.PolicyOperatorExists:
+		return true
+	case hvs.PolicyOperatorEquals:
+		return policyValuesEqual(actual, expression.Value)
+	case hvs.PolicyOperatorNotEquals:
+		return !policyValuesEqual(actual, expression.Value)
+	case hvs.PolicyOperatorContains:
+		switch a := actual.(type) {
+		case []interface{}:
+			for _, item := range a {
+				if policyValuesEqual(item, expression.Value) {
+					return true
+				}
+			}
+			return false
+		case string:
+			return strings.Contains(a, policyValueString(expression.Value))
+		}
+		return false
+	case hvs.PolicyOperatorIn:
+		values, _ := expression.Value.([]interface{})
+		for _, value := range values {
+			if policyValuesEqual(actual, value) {
+				return true
+			}
+		}
+		return false
+	case hvs.PolicyOperatorMatches:
+		pattern, _ := expression.Value.(string)
+		matched, err := regexp.MatchString(pattern, policyValueString(actual))
+		return err == nil && matched
+	case hvs.PolicyOperatorGt:
+		return comparePolicyValues(actual, expression.Value) > 0
+	case hvs.PolicyOperatorGte:
+		return comparePolicyValues(actual, expression.Value) >= 0
+	case hvs.PolicyOperatorLt:
+		return comparePolicyValues(actual, expression.Value) < 0
+	case hvs.PolicyOperatorLte:
+		return comparePolicyValues(actual, expression.Value) <= 0
+	}
+	return false
+}
+
+// lookupPolicyPath returns the value found at the dot separated path of the document, numeric segments index arrays
+func lookupPolicyPath(document interface{}, path string) (interface{}, bool) {
+	current := document
+	for _, segment := range strings.Split(path, ".") {
+		switch c := current.(type) {
+		case map[string]interface{}:
+			value, ok := c[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(c) {
+				return nil, false
+			}
+			current = c[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// policyValuesEqual compares the values as numbers or booleans when both can be parsed as such, since the host
+// manifest reports some numbers and booleans as strings, and as strings otherwise
+func policyValuesEqual(actual, expected interface{}) bool {
+	a := policyValueString(actual)
+	e := policyValueString(expected)
+	if af, err := strconv.ParseFloat(a, 64); err == nil {
+		if ef, err := strconv.ParseFloat(e, 64); err == nil {
+			return af == ef
+		}
+	}
+	if ab, err := strconv.ParseBool(a); err == nil {
+		if eb, err := strconv.ParseBool(e); err == nil {
+			return ab == eb
+		}
+	}
+	return a == e
+}
+
+// comparePolicyValues compares the values as numbers when both can be parsed as such and as versions otherwise,
+// where runs of digits are compared numerically so that "2.10" is greater than "2.9"
+func comparePolicyValues(actual, expected interface{}) int {
+	a := policyValueString(actual)
+	e := policyValueString(expected)
+	if af, err := strconv.ParseFloat(a, 64); err == nil {
+		if ef, err := strconv.ParseFloat(e, 64); err == nil {
+			switch {
+			case af < ef:
+				return -1
+			case af > ef:
+				return 1
+			}
+			return 0
+		}
+	}
+	return compareVersions(a, e)
+}
+
+func compareVersions(a, b string) int {
+	aTokens := versionTokens(a)
+	bTokens := versionTokens(b)
+	for i := 0; i < len(aTokens) && i < len(bTokens); i++ {
+		aToken, bToken := aTokens[i], bTokens[i]
+		aNumber, aErr := strconv.ParseUint(aToken, 10, 64)
+		bNumber, bErr := strconv.ParseUint(bToken, 10, 64)
+		if aErr == nil && bErr == nil {
+			if aNumber != bNumber {
+				if aNumber < bNumber {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+		if c := strings.Compare(aToken, bToken); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(aTokens) < len(bTokens):
+		return -1
+	case len(aTokens) > len(bTokens):
+		return 1
+	}
+	return 0
+}
+
+// versionTokens splits the version in runs of digits and runs of other characters
+func versionTokens(version string) []string {
+	var tokens []string
+	var token strings.Builder
+	digits := false
+	for i, r := range version {
+		if i > 0 && unicode.IsDigit(r) != digits {
+			tokens = append(tokens, token.String())
+			token.Reset()
+		}
+		digits = unicode.IsDigit(r)
+		token.WriteRune(r)
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens
+}
+
+func policyValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(valueBytes)
+}
+
+func describePolicyExpression(expression hvs.PolicyExpression) string {
+	switch {
+	case expression.AllOf != nil:
+		return fmt.Sprintf("all_of(%d expressions)", len(expression.AllOf))
+	case expression.AnyOf != nil:
+		return fmt.Sprintf("any_of(%d expressions)", len(expression.AnyOf))
+	case expression.Not != nil:
+		return "not(" + describePolicyExpression(*expression.Not) + ")"
+	case expression.Value == nil:
+		return fmt.Sprintf("'%s %s'", expression.Path, expression.Operator)
+	}
+	return fmt.Sprintf("'%s %s %s'", expression.Path, expression.Operator, policyValueString(expression.Value))
+}
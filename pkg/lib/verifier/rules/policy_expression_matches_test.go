@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package rules
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	constants "github.com/intel-secl/intel-secl/v5/pkg/hvs/constants/verifier-rules-and-faults"
+	"github.com/intel-secl/intel-secl/v5/pkg/model/hvs"
+	ta "github.com/intel-secl/intel-secl/v5/pkg/model/ta"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testPolicyFlavorGroupId = uuid.MustParse("ee37c360-7eae-4250-a677-6ee12adce8e2")
+	testPolicyHostManifest  = hvs.HostManifest{
+		HostInfo: ta.HostInfo{
+			OSName:      "RedHatEnterprise",
+			BiosVersion: "SE5C620.86B.02.01.0010.010620200716",
+			HardwareFeatures: ta.HardwareFeatures{
+				TXT: &ta.HardwareFeature{Enabled: true},
+			},
+			NumberOfSockets:     2,
+			InstalledComponents: []string{"tagent", "wlagent"},
+		},
+	}
+)
+
+func applyPolicyRule(t *testing.T, expression hvs.PolicyExpression) *hvs.RuleResult {
+	rule, err := NewPolicyExpressionMatches(hvs.PolicyRule{Name: "policy", Expression: expression}, testPolicyFlavorGroupId)
+	assert.NoError(t, err)
+
+	result, err := rule.Apply(&testPolicyHostManifest)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, constants.RulePolicyExpressionMatches, result.Rule.Name)
+	assert.Equal(t, []hvs.FlavorPartName{hvs.FlavorPartPlatform}, result.Rule.Markers)
+	assert.Equal(t, testPolicyFlavorGroupId, *result.Rule.FlavorGroupID)
+	assert.Equal(t, "policy", result.Rule.PolicyRule.Name)
+	return result
+}
+
+func TestPolicyExpressionMatchesNoFault(t *testing.T) {
+	result := applyPolicyRule(t, hvs.PolicyExpression{
+		AllOf: []hvs.PolicyExpression{
+			{Path: "host_info.hardware_features.TXT.enabled", Operator: hvs.PolicyOperatorEquals, Value: true},
+			{Path: "host_info.bios_version", Operator: hvs.PolicyOperatorGte, Value: "SE5C620.86B.02.01.0009"},
+			{Path: "host_info.no_of_sockets", Operator: hvs.PolicyOperatorLt, Value: float64(4)},
+			{Path: "host_info.installed_components", Operator: hvs.PolicyOperatorContains, Value: "wlagent"},
+			{Path: "host_info.installed_components.0", Operator: hvs.PolicyOperatorIn, Value: []interface{}{"tagent"}},
+			{Path: "host_info.os_name", Operator: hvs.PolicyOperatorMatches, Value: "^RedHat"},
+			{Not: &hvs.PolicyExpression{Path: "host_info.hardware_features.CBNT", Operator: hvs.PolicyOperatorExists}},
+			{AnyOf: []hvs.PolicyExpression{
+				{Path: "host_info.os_name", Operator: hvs.PolicyOperatorEquals, Value: "Ubuntu"},
+				{Path: "host_info.os_name", Operator: hvs.PolicyOperatorNotEquals, Value: "Ubuntu"},
+			}},
+		},
+	})
+	assert.Empty(t, result.Faults)
+	assert.True(t, result.Trusted)
+}
+
+func TestPolicyExpressionMatchesMismatchFault(t *testing.T) {
+	result := applyPolicyRule(t, hvs.PolicyExpression{
+		AllOf: []hvs.PolicyExpression{
+			{Path: "host_info.hardware_features.TXT.enabled", Operator: hvs.PolicyOperatorEquals, Value: true},
+			// digit runs are compared numerically, 0010 < 0100
+			{Path: "host_info.bios_version", Operator: hvs.PolicyOperatorGte, Value: "SE5C620.86B.02.01.0100"},
+			{Path: "host_info.hardware_features.TPM.enabled", Operator: hvs.PolicyOperatorEquals, Value: true},
+		},
+	})
+	assert.False(t, result.Trusted)
+	assert.Len(t, result.Faults, 2)
+	assert.Equal(t, constants.FaultPolicyExpressionMismatch, result.Faults[0].Name)
+	assert.Equal(t, "SE5C620.86B.02.01.0100", *result.Faults[0].ExpectedValue)
+	assert.Equal(t, testPolicyHostManifest.HostInfo.BiosVersion, *result.Faults[0].ActualValue)
+	assert.Equal(t, constants.FaultPolicyExpressionMismatch, result.Faults[1].Name)
+	assert.Nil(t, result.Faults[1].ActualValue)
+}
+
+func TestPolicyExpressionMatchesInvalidFault(t *testing.T) {
+	result := applyPolicyRule(t, hvs.PolicyExpression{Path: "host_info.os_name", Operator: "startswith", Value: "Red"})
+	assert.False(t, result.Trusted)
+	assert.Len(t, result.Faults, 1)
+	assert.Equal(t, constants.FaultPolicyExpressionInvalid, result.Faults[0].Name)
+}
+
+func TestValidatePolicyRule(t *testing.T) {
+	valid := hvs.PolicyRule{
+		Name:       "txt-enabled",
+		Marker:     hvs.FlavorPartOs,
+		Expression: hvs.PolicyExpression{Path: "host_info.hardware_features.TXT.enabled", Operator: hvs.PolicyOperatorEquals, Value: true},
+	}
+	assert.NoError(t, ValidatePolicyRule(valid))
+
+	invalid := valid
+	invalid.Name = "txt enabled"
+	assert.Error(t, ValidatePolicyRule(invalid))
+
+	invalid = valid
+	invalid.Marker = "BIOS"
+	assert.Error(t, ValidatePolicyRule(invalid))
+
+	invalid = valid
+	invalid.Expression.AllOf = []hvs.PolicyExpression{valid.Expression}
+	assert.Error(t, ValidatePolicyRule(invalid))
+
+	invalid = valid
+	invalid.Expression = hvs.PolicyExpression{AnyOf: []hvs.PolicyExpression{}}
+	assert.Error(t, ValidatePolicyRule(invalid))
+
+	invalid = valid
+	invalid.Expression = hvs.PolicyExpression{Path: "host_info..os_name", Operator: hvs.PolicyOperatorExists}
+	assert.Error(t, ValidatePolicyRule(invalid))
+
+	invalid = valid
+	invalid.Expression = hvs.PolicyExpression{Path: "host_info.os_name", Operator: hvs.PolicyOperatorMatches, Value: "("}
+	assert.Error(t, ValidatePolicyRule(invalid))
+
+	invalid = valid
+	invalid.Expression = hvs.PolicyExpression{Path: "host_info.os_name", Operator: hvs.PolicyOperatorIn, Value: "RedHat"}
+	assert.Error(t, ValidatePolicyRule(invalid))
+}
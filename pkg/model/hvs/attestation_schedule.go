@@ -0,0 +1,45 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttestationSchedule sets how often the hosts linked to a flavorgroup are re-attested by the report refresher, in
+// addition to the refresh of their reports before they expire
+type AttestationSchedule struct {
+	// swagger:strfmt uuid
+	ID uuid.UUID `json:"id,omitempty"`
+	// swagger:strfmt uuid
+	FlavorgroupId uuid.UUID `json:"flavorgroup_id"`
+	// Interval between two attestations of a host, e.g. "5m" or "24h"
+	Interval  string    `json:"interval"`
+	CreatedAt time.Time `json:"created,omitempty"`
+}
+
+type AttestationScheduleCollection struct {
+	AttestationSchedules []AttestationSchedule `json:"attestation_schedules"`
+}
+
+// ScheduledAttestation is the next attestation of a host by the report refresher
+type ScheduledAttestation struct {
+	// swagger:strfmt uuid
+	HostId uuid.UUID `json:"host_id"`
+	// The schedule applied to the host, a host linked to several scheduled flavorgroups follows the shortest interval
+	// swagger:strfmt uuid
+	ScheduleId uuid.UUID `json:"schedule_id"`
+	Interval   string    `json:"interval"`
+	// Creation time of the latest report of the host
+	LastAttestation *time.Time `json:"last_attestation,omitempty"`
+	NextRun         time.Time  `json:"next_run"`
+}
+
+type ScheduledAttestationCollection struct {
+	ScheduledAttestations []ScheduledAttestation `json:"scheduled_attestations"`
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLogEntry is a single audit record of a change made to an HVS entity
+type AuditLogEntry struct {
+	RowId int `json:"-"`
+	// swagger:strfmt uuid
+	ID uuid.UUID `json:"id"`
+	// swagger:strfmt uuid
+	EntityID   uuid.UUID    `json:"entity_id"`
+	EntityType string       `json:"entity_type"`
+	CreatedAt  time.Time    `json:"created"`
+	Action     string       `json:"action"`
+	Data       AuditLogData `json:"data"`
+}
+
+// AuditLogData holds the columns of the audited entity at the time of the change
+type AuditLogData struct {
+	Columns []AuditLogColumn `json:"columns"`
+}
+
+type AuditLogColumn struct {
+	Name      string      `json:"name"`
+	Value     interface{} `json:"value"`
+	IsUpdated bool        `json:"is_updated"`
+}
+
+type AuditLogEntryCollection struct {
+	AuditLogEntries []AuditLogEntry `json:"audit_logs"`
+	Total           int             `json:"total,omitempty"`
+	Next            string          `json:"next,omitempty"`
+	Previous        string          `json:"prev,omitempty"`
+}
@@ -9,6 +9,7 @@ import "github.com/google/uuid"
 
 type ESXiClusterCollection struct {
 	ESXiCluster []ESXiCluster `json:"esxi_clusters"`
+	Total       int           `json:"total,omitempty"`
 	Next        string        `json:"next,omitempty"`
 	Previous    string        `json:"prev,omitempty"`
 }
@@ -82,6 +82,7 @@ type Flavors struct {
 // SignedFlavorCollection is a list of SignedFlavor objects
 type SignedFlavorCollection struct {
 	SignedFlavors []SignedFlavor `json:"signed_flavors"`
+	Total         int            `json:"total,omitempty"`
 	Next          string         `json:"next,omitempty"`
 	Previous      string         `json:"prev,omitempty"`
 }
@@ -0,0 +1,116 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// FlavorBundle holds the flavors exported from an HVS together with the flavorgroups and flavor templates they
+// depend on, so that they can be imported into another HVS
+type FlavorBundle struct {
+	Created         time.Time        `json:"created"`
+	SignedFlavors   []SignedFlavor   `json:"signed_flavors"`
+	FlavorGroups    []FlavorGroup    `json:"flavorgroups,omitempty"`
+	FlavorTemplates []FlavorTemplate `json:"flavor_templates,omitempty"`
+}
+
+// SignedFlavorBundle is the exported archive, the bundle is signed with the flavor signing key of the source HVS
+// and carries the PEM encoded signing certificate chain
+type SignedFlavorBundle struct {
+	Bundle             FlavorBundle `json:"bundle"`
+	Signature          string       `json:"signature"`
+	SigningCertificate string       `json:"signing_certificate"`
+}
+
+// FlavorBundleConflict describes an item of the bundle that cannot be imported without overwriting existing data
+type FlavorBundleConflict struct {
+	// Type is one of flavor, flavorgroup or flavor_template
+	Type string `json:"type"`
+	// swagger:strfmt uuid
+	ID     uuid.UUID `json:"id,omitempty"`
+	Name   string    `json:"name,omitempty"`
+	Reason string    `json:"reason"`
+}
+
+// FlavorBundleImportReport is the result of a flavor bundle import, existing items are identical to the items of
+// the bundle and are left untouched
+type FlavorBundleImportReport struct {
+	DryRun bool `json:"dry_run"`
+	// swagger:strfmt uuid
+	ImportedFlavors []uuid.UUID `json:"imported_flavors,omitempty"`
+	// swagger:strfmt uuid
+	ExistingFlavors      []uuid.UUID `json:"existing_flavors,omitempty"`
+	CreatedFlavorGroups  []string    `json:"created_flavorgroups,omitempty"`
+	ExistingFlavorGroups []string    `json:"existing_flavorgroups,omitempty"`
+	// swagger:strfmt uuid
+	CreatedFlavorTemplates []uuid.UUID `json:"created_flavor_templates,omitempty"`
+	// swagger:strfmt uuid
+	ExistingFlavorTemplates []uuid.UUID            `json:"existing_flavor_templates,omitempty"`
+	Conflicts               []FlavorBundleConflict `json:"conflicts,omitempty"`
+}
+
+func (bundle *FlavorBundle) getBundleDigest() ([]byte, error) {
+	bundleJson, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred attempting to convert the flavor bundle to json")
+	}
+	hash := sha512.Sum384(bundleJson)
+	return hash[:], nil
+}
+
+// NewSignedFlavorBundle signs the flavor bundle with the private key, the certificate chain of the key is added to
+// the signed bundle
+func NewSignedFlavorBundle(bundle *FlavorBundle, privateKey *rsa.PrivateKey, signingCertificate string) (*SignedFlavorBundle, error) {
+	if bundle == nil {
+		return nil, errors.New("The flavor bundle must be provided and cannot be nil")
+	}
+	if privateKey == nil || privateKey.Validate() != nil {
+		return nil, errors.New("Valid private key must be provided and cannot be nil")
+	}
+
+	bundleDigest, err := bundle.getBundleDigest()
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred while creating the signed flavor bundle")
+	}
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA384, bundleDigest)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred while signing the flavor bundle")
+	}
+	return &SignedFlavorBundle{
+		Bundle:             *bundle,
+		Signature:          base64.StdEncoding.EncodeToString(signature),
+		SigningCertificate: signingCertificate,
+	}, nil
+}
+
+// Verify checks the signature of the bundle with the public key of the signing certificate
+func (signedBundle *SignedFlavorBundle) Verify(publicKey *rsa.PublicKey) error {
+	if len(signedBundle.Signature) == 0 {
+		return errors.New("Could not verify the flavor bundle: The flavor bundle does not have a signature")
+	}
+	signatureBytes, err := base64.StdEncoding.DecodeString(signedBundle.Signature)
+	if err != nil {
+		return errors.Wrap(err, "Could not verify the flavor bundle: An error occurred attempting to decode the signature")
+	}
+	bundleDigest, err := signedBundle.Bundle.getBundleDigest()
+	if err != nil {
+		return errors.Wrap(err, "Could not verify the flavor bundle: An error occurred collecting the bundle digest")
+	}
+	if err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA384, bundleDigest, signatureBytes); err != nil {
+		return errors.Wrap(err, "Could not verify the flavor bundle: PKCS1 verification failed")
+	}
+	return nil
+}
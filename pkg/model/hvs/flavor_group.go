@@ -13,6 +13,7 @@ import (
 
 type FlavorgroupCollection struct {
 	Flavorgroups []FlavorGroup `json:"flavorgroups" xml:"flavorgroup"`
+	Total        int           `json:"total,omitempty" xml:"total"`
 	Next         string        `json:"next,omitempty" xml:"next"`
 	Previous     string        `json:"prev,omitempty" xml:"prev"`
 }
@@ -34,6 +35,7 @@ type FlavorGroup struct {
 	FlavorTemplateIds []uuid.UUID         `json:"flavorTemplateIds,omitempty"`
 	Flavors           []Flavor            `json:"flavors,omitempty"`
 	MatchPolicies     FlavorMatchPolicies `json:"flavor_match_policies,omitempty"`
+	PolicyRules       []PolicyRule        `json:"policy_rules,omitempty"`
 }
 
 type FlavorMatchPolicy struct {
@@ -91,6 +93,7 @@ func (r FlavorGroup) MarshalJSON() ([]byte, error) {
 		Flavors                     []Flavor                    `json:"flavors,omitempty"`
 		FlavorTemplateIds           []uuid.UUID                 `json:"flavorTemplateIds,omitempty"`
 		FlavorMatchPolicyCollection FlavorMatchPolicyCollection `json:"flavor_match_policy_collection,omitempty"`
+		PolicyRules                 []PolicyRule                `json:"policy_rules,omitempty"`
 	}{
 		ID:                          r.ID,
 		Name:                        r.Name,
@@ -98,6 +101,7 @@ func (r FlavorGroup) MarshalJSON() ([]byte, error) {
 		Flavors:                     r.Flavors,
 		FlavorTemplateIds:           r.FlavorTemplateIds,
 		FlavorMatchPolicyCollection: FlavorMatchPolicyCollection{r.MatchPolicies},
+		PolicyRules:                 r.PolicyRules,
 	})
 }
 
@@ -109,6 +113,7 @@ func (r *FlavorGroup) UnmarshalJSON(b []byte) error {
 		FlavorTemplateIds           []uuid.UUID                 `json:"flavorTemplateIds,omitempty"`
 		Flavors                     []Flavor                    `json:"flavors,omitempty"`
 		FlavorMatchPolicyCollection FlavorMatchPolicyCollection `json:"flavor_match_policy_collection,omitempty"`
+		PolicyRules                 []PolicyRule                `json:"policy_rules,omitempty"`
 	})
 	err := json.Unmarshal(b, decoded)
 	if err == nil {
@@ -118,6 +123,7 @@ func (r *FlavorGroup) UnmarshalJSON(b []byte) error {
 		r.FlavorTemplateIds = decoded.FlavorTemplateIds
 		r.Flavors = decoded.Flavors
 		r.MatchPolicies = decoded.FlavorMatchPolicyCollection.FlavorMatchPolicies
+		r.PolicyRules = decoded.PolicyRules
 	}
 	return err
 }
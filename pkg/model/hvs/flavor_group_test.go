@@ -61,6 +61,32 @@ var _ = Describe("FlavorGroup", func() {
 		})
 	})
 
+	Describe("Marshal flavorgroup with policy rules", func() {
+		Context("Provided a Flavorgroup with a policy rule", func() {
+			It("Should keep the policy rule expression", func() {
+				var fg hvs.FlavorGroup
+				err := json.Unmarshal([]byte(flavorgroupJson), &fg)
+				Expect(err).NotTo(HaveOccurred())
+				fg.PolicyRules = []hvs.PolicyRule{{
+					Name: "txt-enabled",
+					Expression: hvs.PolicyExpression{
+						Path:     "host_info.hardware_features.TXT.enabled",
+						Operator: hvs.PolicyOperatorEquals,
+						Value:    true,
+					},
+				}}
+				fgJson, err := json.Marshal(fg)
+				Expect(err).NotTo(HaveOccurred())
+
+				var decoded hvs.FlavorGroup
+				err = json.Unmarshal(fgJson, &decoded)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded.PolicyRules).To(Equal(fg.PolicyRules))
+				Expect(decoded.MatchPolicies).To(Equal(fg.MatchPolicies))
+			})
+		})
+	})
+
 	Describe("get match policy types map for each flavorgroup", func() {
 		Context("Provided a valid Flavorgroup and get match policy details", func() {
 			It("Should generate valid maps", func() {
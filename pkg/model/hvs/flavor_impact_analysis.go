@@ -0,0 +1,47 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import "github.com/google/uuid"
+
+// FlavorImpactAnalysis is the result of a dry run trust evaluation of candidate flavors against the hosts
+// linked to a flavorgroup. Nothing is persisted while it is computed.
+type FlavorImpactAnalysis struct {
+	// swagger:strfmt uuid
+	FlavorgroupId   uuid.UUID `json:"flavorgroup_id"`
+	FlavorgroupName string    `json:"flavorgroup_name"`
+	// Number of hosts linked to the flavorgroup
+	HostCount int `json:"host_count"`
+	// Number of hosts whose trust status would change
+	TrustChangedCount int `json:"trust_changed_count"`
+	// Number of hosts that could not be evaluated, for example hosts without a cached host manifest
+	NotEvaluatedCount int                `json:"not_evaluated_count"`
+	Hosts             []HostFlavorImpact `json:"hosts"`
+}
+
+// HostFlavorImpact describes how the candidate flavors would change the trust status of a single host
+type HostFlavorImpact struct {
+	// swagger:strfmt uuid
+	HostId   uuid.UUID `json:"host_id"`
+	HostName string    `json:"host_name"`
+	// Trust status from the latest report of the host, not set if the host has no report
+	CurrentTrusted *bool `json:"current_trusted,omitempty"`
+	// Trust status the host would have once the candidate flavors are added to the flavorgroup
+	PredictedTrusted *bool                `json:"predicted_trusted,omitempty"`
+	TrustChanged     bool                 `json:"trust_changed"`
+	FlavorResults    []FlavorImpactResult `json:"flavor_results,omitempty"`
+	// Reason the host could not be evaluated
+	Error string `json:"error,omitempty"`
+}
+
+// FlavorImpactResult is the result of verifying one candidate flavor against the cached host manifest
+type FlavorImpactResult struct {
+	// swagger:strfmt uuid
+	FlavorId   uuid.UUID      `json:"flavor_id,omitempty"`
+	Label      string         `json:"label"`
+	FlavorPart FlavorPartName `json:"flavor_part"`
+	Trusted    bool           `json:"trusted"`
+	Faults     []Fault        `json:"faults,omitempty"`
+}
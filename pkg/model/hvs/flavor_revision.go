@@ -0,0 +1,19 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import "time"
+
+// FlavorRevision is a signed revision of a flavor. Updating a flavor keeps its ID and creates a new revision,
+// the earlier revisions are retained for reference.
+type FlavorRevision struct {
+	Revision  int       `json:"revision"`
+	CreatedAt time.Time `json:"created"`
+	SignedFlavor
+}
+
+type FlavorRevisionCollection struct {
+	FlavorRevisions []FlavorRevision `json:"revisions"`
+}
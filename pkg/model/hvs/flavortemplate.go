@@ -12,7 +12,7 @@ import (
 type PCR struct {
 	// Valid PCR index is from 0 to 23.
 	Index int `json:"index"`
-	// Valid PCR banks are SHA1, SHA256, SHA384 and SHA512.
+	// Valid PCR banks are SHA1, SHA256, SHA384, SHA512 and SM3_256, in the order of priority.
 	Bank []string `json:"bank"`
 }
 
@@ -57,6 +57,7 @@ type FlavorTemplate struct {
 	// An array of 'jsonquery' statements that are used to determine if the template should be executed. Sample value: ["//host_info/os_name//*[text()='RedHatEnterprise']","//host_info/hardware_features/TPM/meta/tpm_version//*[text()='2.0']"].
 	Condition   []string     `json:"condition" sql:"type:text[]"`
 	FlavorParts *FlavorParts `json:"flavor_parts,omitempty" sql:"type:JSONB"`
+	RowId       int          `json:"-"`
 }
 
 type FlavorTemplateFlavorgroupCollection struct {
@@ -0,0 +1,47 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package hvs
+
+import "github.com/google/uuid"
+
+// FlavorTemplateValidationRequest contains a flavor template to check and, optionally, the host manifest or the ID
+// of a registered host used to preview the flavors the template would generate
+type FlavorTemplateValidationRequest struct {
+	FlavorTemplate FlavorTemplate `json:"flavor_template"`
+	HostManifest   *HostManifest  `json:"host_manifest,omitempty"`
+	// swagger:strfmt uuid
+	HostId *uuid.UUID `json:"host_id,omitempty"`
+}
+
+// FlavorTemplateValidation is the result of the validation of a flavor template. The template is not stored.
+type FlavorTemplateValidation struct {
+	// The template can be created when there are no errors
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors,omitempty"`
+	// Rules that are accepted but are likely not what the author intended
+	Warnings []string               `json:"warnings,omitempty"`
+	Preview  *FlavorTemplatePreview `json:"preview,omitempty"`
+}
+
+// FlavorTemplatePreview shows how the template applies to the host manifest of the request
+type FlavorTemplatePreview struct {
+	// swagger:strfmt uuid
+	HostId       uuid.UUID                       `json:"host_id,omitempty"`
+	HardwareUUID string                          `json:"hardware_uuid,omitempty"`
+	Conditions   []FlavorTemplateConditionResult `json:"conditions"`
+	// The template is applied to the host only when all the conditions match
+	Matched bool `json:"matched"`
+	// Flavors generated by the template for the host, they are neither signed nor stored
+	Flavors []Flavor `json:"flavors,omitempty"`
+	// Errors raised while generating the flavors
+	Errors []string `json:"errors,omitempty"`
+}
+
+// FlavorTemplateConditionResult is the result of one condition of the template on the host manifest
+type FlavorTemplateConditionResult struct {
+	Condition string `json:"condition"`
+	Matched   bool   `json:"matched"`
+	Error     string `json:"error,omitempty"`
+}
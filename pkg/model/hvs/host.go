@@ -11,6 +11,7 @@ import (
 )
 
 type HostCollection struct {
+	Total    int     `json:"total,omitempty" xml:"total"`
 	Next     string  `json:"next,omitempty" xml:"next"`
 	Previous string  `json:"prev,omitempty" xml:"prev"`
 	Hosts    []*Host `json:"hosts" xml:"host"`
@@ -38,6 +39,26 @@ type HostCreateRequest struct {
 	FlavorgroupNames []string `json:"flavorgroup_names,omitempty"`
 }
 
+// HostBulkCreateRequest registers several hosts in a single request
+type HostBulkCreateRequest struct {
+	HostCreateRequests []HostCreateRequest `json:"hosts"`
+}
+
+// HostBulkCreateResponse has the result of each host of a HostBulkCreateRequest, in the order of the request
+type HostBulkCreateResponse struct {
+	Created int                    `json:"created"`
+	Failed  int                    `json:"failed"`
+	Results []HostBulkCreateResult `json:"results"`
+}
+
+type HostBulkCreateResult struct {
+	// Row is the position of the host in the request, starting at 1
+	Row      int    `json:"row"`
+	HostName string `json:"host_name"`
+	Host     *Host  `json:"host,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
 type HostFlavorgroupCollection struct {
 	HostFlavorgroups []HostFlavorgroup `json:"flavorgroup_host_links" xml:"flavorgroup_host_link"`
 }
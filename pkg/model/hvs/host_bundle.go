@@ -0,0 +1,52 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// HostBundle is the attestation data collected on a host that cannot accept connections from the HVS. The host
+// reads its host info from the Trust Agent and requests a TPM quote with the nonce returned by GetHostBundleNonce,
+// so the AIK signature of the quote also covers the host info and the creation time of the bundle.
+type HostBundle struct {
+	Created time.Time `json:"created"`
+	// HostInfo is the json document returned by the Trust Agent host info API, it is kept as is since the nonce
+	// is computed from it and a re-encoded document could differ from the one the host used
+	HostInfo json.RawMessage `json:"host_info"`
+	// Nonce is the base64 encoded nonce sent in the TPM quote request
+	Nonce string `json:"nonce"`
+	// TpmQuoteResponse is the xml document returned by the Trust Agent TPM quote API
+	TpmQuoteResponse string `json:"tpm_quote_response"`
+	// BindingKeyCertificate is the PEM encoded binding key certificate of the host, if any
+	BindingKeyCertificate string `json:"binding_key_certificate,omitempty"`
+}
+
+// HostBundleUploadResponse is returned once a host bundle has been verified and stored by the HVS
+type HostBundleUploadResponse struct {
+	HardwareUUID     string    `json:"hardware_uuid"`
+	Created          time.Time `json:"created"`
+	ConnectionString string    `json:"connection_string"`
+}
+
+// GetHostBundleNonce returns the base64 encoded nonce a host must use in the TPM quote of a bundle, the nonce is
+// the SHA256 digest of the compacted host info document followed by the creation time in RFC3339 format
+func GetHostBundleNonce(hostInfo []byte, created time.Time) string {
+	// the host info is compacted so that the nonce does not depend on how the bundle was serialized
+	var compactHostInfo bytes.Buffer
+	if err := json.Compact(&compactHostInfo, hostInfo); err != nil {
+		compactHostInfo.Reset()
+		compactHostInfo.Write(hostInfo)
+	}
+	hash := sha256.New()
+	hash.Write(compactHostInfo.Bytes())
+	hash.Write([]byte(created.UTC().Format(time.RFC3339)))
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+}
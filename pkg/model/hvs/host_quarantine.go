@@ -0,0 +1,40 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HostQuarantine is the remediation state of a host, a host is quarantined after a configured number of consecutive
+// untrusted reports and stays quarantined until it is released through the API
+type HostQuarantine struct {
+	// swagger:strfmt uuid
+	HostId      uuid.UUID `json:"host_id"`
+	Quarantined bool      `json:"quarantined"`
+	// Number of consecutive untrusted reports of the host
+	UntrustedReports int `json:"untrusted_reports"`
+	// The report that caused the quarantine
+	// swagger:strfmt uuid
+	ReportId      *uuid.UUID `json:"report_id,omitempty"`
+	QuarantinedAt *time.Time `json:"quarantined_at,omitempty"`
+	// Tag certificates of the host deleted when it was quarantined
+	DeletedTagCertificates []uuid.UUID `json:"deleted_tag_certificates,omitempty"`
+	ReleasedAt             *time.Time  `json:"released_at,omitempty"`
+	ReleaseComment         string      `json:"release_comment,omitempty"`
+	Updated                time.Time   `json:"updated"`
+}
+
+type HostQuarantineCollection struct {
+	HostQuarantines []HostQuarantine `json:"host_quarantines"`
+}
+
+// HostQuarantineRelease is the request to release a quarantined host
+type HostQuarantineRelease struct {
+	Comment string `json:"comment,omitempty"`
+}
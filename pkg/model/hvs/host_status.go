@@ -32,6 +32,7 @@ type HostStatus struct {
 // HostStatusCollection holds a collection of HostStatus in response to an API query
 type HostStatusCollection struct {
 	HostStatuses []HostStatus `json:"host_status" xml:"host_status"`
+	Total        int          `json:"total,omitempty" xml:"total"`
 	Next         string       `json:"next,omitempty" xml:"next"`
 	Previous     string       `json:"prev,omitempty" xml:"prev"`
 }
@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationEventType represents the type of event delivered to notification subscribers
+type NotificationEventType string
+
+const (
+	// NotificationEventHostTrustChanged is emitted when the overall trust of a new report differs from the previous report of the host
+	NotificationEventHostTrustChanged NotificationEventType = "host.trust_changed"
+	// NotificationEventHostStateChanged is emitted when the HostState of a host transitions, e.g. CONNECTED -> CONNECTION_FAILURE
+	NotificationEventHostStateChanged NotificationEventType = "host.state_changed"
+	// NotificationEventHostQuarantined is emitted when the remediation quarantines a host after consecutive untrusted reports
+	NotificationEventHostQuarantined NotificationEventType = "host.quarantined"
+	// NotificationEventHostReleased is emitted when a quarantined host is released
+	NotificationEventHostReleased NotificationEventType = "host.released"
+)
+
+func (net NotificationEventType) String() string {
+	return string(net)
+}
+
+// GetNotificationEventTypes returns all the supported notification event types
+func GetNotificationEventTypes() []NotificationEventType {
+	return []NotificationEventType{NotificationEventHostTrustChanged, NotificationEventHostStateChanged,
+		NotificationEventHostQuarantined, NotificationEventHostReleased}
+}
+
+// NotificationSubscription registers an HTTP webhook that receives the events of the given types
+type NotificationSubscription struct {
+	// swagger: strfmt uuid
+	ID         uuid.UUID               `json:"id,omitempty"`
+	Url        string                  `json:"url"`
+	EventTypes []NotificationEventType `json:"event_types"`
+	// Secret used to sign the event payloads, returned only when the subscription is created
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created,omitempty"`
+}
+
+type NotificationSubscriptionCollection struct {
+	NotificationSubscriptions []NotificationSubscription `json:"notification_subscriptions"`
+}
+
+// NotificationEvent is the payload delivered to the notification subscribers
+type NotificationEvent struct {
+	// swagger: strfmt uuid
+	ID        uuid.UUID             `json:"id"`
+	EventType NotificationEventType `json:"event_type"`
+	// swagger: strfmt uuid
+	HostId    uuid.UUID `json:"host_id"`
+	CreatedAt time.Time `json:"created"`
+	// swagger: strfmt uuid
+	ReportId          *uuid.UUID `json:"report_id,omitempty"`
+	PreviousTrusted   *bool      `json:"previous_trusted,omitempty"`
+	Trusted           *bool      `json:"trusted,omitempty"`
+	PreviousHostState string     `json:"previous_host_state,omitempty"`
+	HostState         string     `json:"host_state,omitempty"`
+}
+
+// NotificationDeadLetter holds an event that could not be delivered to a subscriber after all the retries
+type NotificationDeadLetter struct {
+	// swagger: strfmt uuid
+	ID uuid.UUID `json:"id"`
+	// swagger: strfmt uuid
+	SubscriptionId uuid.UUID         `json:"subscription_id"`
+	Event          NotificationEvent `json:"event"`
+	Attempts       int               `json:"attempts"`
+	LastError      string            `json:"last_error"`
+	CreatedAt      time.Time         `json:"created"`
+}
+
+type NotificationDeadLetterCollection struct {
+	NotificationDeadLetters []NotificationDeadLetter `json:"dead_letters"`
+}
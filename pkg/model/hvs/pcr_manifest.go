@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
 	"github.com/pkg/errors"
 )
 
@@ -74,7 +75,7 @@ type TpmEventLog struct {
 type Pcr struct {
 	// Valid PCR index is from 0 to 23.
 	Index int `json:"index"`
-	// Valid PCR banks are SHA1, SHA256, SHA384 and SHA512.
+	// Valid PCR banks are SHA1, SHA256, SHA384, SHA512 and SM3_256.
 	Bank string `json:"bank"`
 }
 type FlavorPcrs struct {
@@ -94,11 +95,15 @@ type PcrEventLogMap struct {
 	Sha1EventLogs   []TpmEventLog `json:"SHA1,omitempty"`
 	Sha256EventLogs []TpmEventLog `json:"SHA256,omitempty"`
 	Sha384EventLogs []TpmEventLog `json:"SHA384,omitempty"`
+	Sha512EventLogs []TpmEventLog `json:"SHA512,omitempty"`
+	Sm3EventLogs    []TpmEventLog `json:"SM3_256,omitempty"`
 }
 type PcrManifest struct {
 	Sha1Pcrs       []HostManifestPcrs `json:"sha1pcrs,omitempty"`
 	Sha256Pcrs     []HostManifestPcrs `json:"sha2pcrs,omitempty"`
 	Sha384Pcrs     []HostManifestPcrs `json:"sha3pcrs,omitempty"`
+	Sha512Pcrs     []HostManifestPcrs `json:"sha5pcrs,omitempty"`
+	Sm3Pcrs        []HostManifestPcrs `json:"sm3pcrs,omitempty"`
 	PcrEventLogMap PcrEventLogMap     `json:"pcr_event_log_map"`
 }
 
@@ -201,6 +206,7 @@ const (
 	SHA256  SHAAlgorithm = "SHA256"
 	SHA384  SHAAlgorithm = "SHA384"
 	SHA512  SHAAlgorithm = "SHA512"
+	SM3_256 SHAAlgorithm = "SM3_256"
 	UNKNOWN SHAAlgorithm = "unknown"
 )
 
@@ -214,6 +220,8 @@ func GetSHAAlgorithm(algorithm string) (SHAAlgorithm, error) {
 		return SHA384, nil
 	case string(SHA512):
 		return SHA512, nil
+	case string(SM3_256):
+		return SM3_256, nil
 	}
 
 	return UNKNOWN, errors.Errorf("Could not retrieve SHA from value '%s'", algorithm)
@@ -244,7 +252,7 @@ func GetPcrIndexFromString(stringValue string) (PcrIndex, error) {
 
 // Finds the Pcr in a PcrManifest provided the pcrBank and index.  Returns
 // null if not found.  Returns an error if the pcrBank is not supported
-// by intel-secl (currently supports SHA1, SHA256 and SHA384).
+// by intel-secl (currently supports SHA1, SHA256, SHA384, SHA512 and SM3_256).
 func (pcrManifest *PcrManifest) GetPcrValue(pcrBank SHAAlgorithm, pcrIndex PcrIndex) (*HostManifestPcrs, error) {
 	// TODO: Is this the right data model for the PcrManifest?  Two things...
 	// - Flavor API returns a map[bank]map[pcrindex]
@@ -273,6 +281,20 @@ func (pcrManifest *PcrManifest) GetPcrValue(pcrBank SHAAlgorithm, pcrIndex PcrIn
 				break
 			}
 		}
+	case SHA512:
+		for _, pcr := range pcrManifest.Sha512Pcrs {
+			if pcr.Index == pcrIndex {
+				pcrValue = &pcr
+				break
+			}
+		}
+	case SM3_256:
+		for _, pcr := range pcrManifest.Sm3Pcrs {
+			if pcr.Index == pcrIndex {
+				pcrValue = &pcr
+				break
+			}
+		}
 	default:
 		return nil, errors.Errorf("Unsupported sha algorithm %s", pcrBank)
 	}
@@ -280,15 +302,16 @@ func (pcrManifest *PcrManifest) GetPcrValue(pcrBank SHAAlgorithm, pcrIndex PcrIn
 	return pcrValue, nil
 }
 
-// IsEmpty returns true if both the Sha1Pcrs, Sha256Pcrs and Sha384Pcrs
-// are empty.
+// IsEmpty returns true if all of the Sha1Pcrs, Sha256Pcrs, Sha384Pcrs, Sha512Pcrs
+// and Sm3Pcrs are empty.
 func (pcrManifest *PcrManifest) IsEmpty() bool {
-	return len(pcrManifest.Sha1Pcrs) == 0 && len(pcrManifest.Sha256Pcrs) == 0 && len(pcrManifest.Sha384Pcrs) == 0
+	return len(pcrManifest.Sha1Pcrs) == 0 && len(pcrManifest.Sha256Pcrs) == 0 && len(pcrManifest.Sha384Pcrs) == 0 &&
+		len(pcrManifest.Sha512Pcrs) == 0 && len(pcrManifest.Sm3Pcrs) == 0
 }
 
 // Finds the EventLogEntry in a PcrEventLogMap provided the pcrBank and index.  Returns
 // null if not found.  Returns an error if the pcrBank is not supported
-// by intel-secl (currently supports SHA1, SHA256 and SHA384).
+// by intel-secl (currently supports SHA1, SHA256, SHA384, SHA512 and SM3_256).
 func (pcrEventLogMap *PcrEventLogMap) GetEventLogNew(pcrBank string, pcrIndex int) ([]EventLog, int, string, error) {
 	var eventLog []EventLog
 	var pIndex int
@@ -322,6 +345,24 @@ func (pcrEventLogMap *PcrEventLogMap) GetEventLogNew(pcrBank string, pcrIndex in
 				break
 			}
 		}
+	case SHA512:
+		for _, entry := range pcrEventLogMap.Sha512EventLogs {
+			if entry.Pcr.Index == pcrIndex {
+				eventLog = entry.TpmEvent
+				pIndex = entry.Pcr.Index
+				bank = entry.Pcr.Bank
+				break
+			}
+		}
+	case SM3_256:
+		for _, entry := range pcrEventLogMap.Sm3EventLogs {
+			if entry.Pcr.Index == pcrIndex {
+				eventLog = entry.TpmEvent
+				pIndex = entry.Pcr.Index
+				bank = entry.Pcr.Bank
+				break
+			}
+		}
 	default:
 		return nil, 0, "", errors.Errorf("Unsupported sha algorithm %s", pcrBank)
 	}
@@ -411,38 +452,64 @@ func (eventLogEntry *TpmEventLog) Subtract(eventsToSubtract *TpmEventLog) (*TpmE
 // Returns the string value of the "cumulative" hash of the
 // an event log.
 func (eventLogEntry *TpmEventLog) Replay() (string, error) {
+	cumulativeHash, err := eventLogEntry.replay(nil)
+	if err != nil {
+		return "", err
+	}
+
+	cumulativeHashString := hex.EncodeToString(cumulativeHash)
+	return cumulativeHashString, nil
+}
+
+// ReplayEvents returns the value of the PCR after each event of the event log has been extended, the value
+// is unchanged by the events that are not extended
+func (eventLogEntry *TpmEventLog) ReplayEvents() ([]string, error) {
+	pcrValues := make([]string, 0, len(eventLogEntry.TpmEvent))
+	_, err := eventLogEntry.replay(func(cumulativeHash []byte) {
+		pcrValues = append(pcrValues, hex.EncodeToString(cumulativeHash))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pcrValues, nil
+}
+
+// replay extends the events of the event log and returns the final cumulative hash, extended is called with
+// the cumulative hash after each event
+func (eventLogEntry *TpmEventLog) replay(extended func([]byte)) ([]byte, error) {
 	//get the cumulative hash based on the pcr bank
 	cumulativeHash, err := getCumulativeHash(SHAAlgorithm(eventLogEntry.Pcr.Bank))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// use the first EV_NO_ACTION/"StartupLocality" event to send the cumualtive hash
-	if eventLogEntry.Pcr.Index == 0 && eventLogEntry.TpmEvent[0].TypeName == StartupLocalityEvent &&
-		eventLogEntry.TpmEvent[0].Tags[0] == StartupLocalityTag {
+	if eventLogEntry.Pcr.Index == 0 && len(eventLogEntry.TpmEvent) > 0 && eventLogEntry.TpmEvent[0].TypeName == StartupLocalityEvent &&
+		len(eventLogEntry.TpmEvent[0].Tags) > 0 && eventLogEntry.TpmEvent[0].Tags[0] == StartupLocalityTag {
 		cumulativeHash[len(cumulativeHash)-1] = 0x3
 	}
 
 	for i, eventLog := range eventLogEntry.TpmEvent {
 		//if the event is EV_NO_ACTION, skip from summing the hash
-		if eventLog.TypeName == StartupLocalityEvent {
-			continue
-		}
-		//get the respective hash based on the pcr bank
-		hash := getHash(SHAAlgorithm(eventLogEntry.Pcr.Bank))
+		if eventLog.TypeName != StartupLocalityEvent {
+			//get the respective hash based on the pcr bank
+			hash := getHash(SHAAlgorithm(eventLogEntry.Pcr.Bank))
 
-		eventHash, err := hex.DecodeString(eventLog.Measurement)
-		if err != nil {
-			return "", errors.Wrapf(err, "Failed to decode event log %d using hex string '%s'", i, eventLog.Measurement)
-		}
+			eventHash, err := hex.DecodeString(eventLog.Measurement)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Failed to decode event log %d using hex string '%s'", i, eventLog.Measurement)
+			}
 
-		hash.Write(cumulativeHash)
-		hash.Write(eventHash)
-		cumulativeHash = hash.Sum(nil)
+			hash.Write(cumulativeHash)
+			hash.Write(eventHash)
+			cumulativeHash = hash.Sum(nil)
+		}
+		if extended != nil {
+			extended(cumulativeHash)
+		}
 	}
 
-	cumulativeHashString := hex.EncodeToString(cumulativeHash)
-	return cumulativeHashString, nil
+	return cumulativeHash, nil
 }
 
 // Returns the string value of the "cumulative" hash of the ima log.
@@ -505,6 +572,18 @@ func (pcrManifest *PcrManifest) GetEventLogCriteria(pcrBank SHAAlgorithm, pcrInd
 				return eventLogEntry.TpmEvent, nil
 			}
 		}
+	case "SHA512":
+		for _, eventLogEntry := range pcrManifest.PcrEventLogMap.Sha512EventLogs {
+			if eventLogEntry.Pcr.Index == pI {
+				return eventLogEntry.TpmEvent, nil
+			}
+		}
+	case "SM3_256":
+		for _, eventLogEntry := range pcrManifest.PcrEventLogMap.Sm3EventLogs {
+			if eventLogEntry.Pcr.Index == pI {
+				return eventLogEntry.TpmEvent, nil
+			}
+		}
 	default:
 		return nil, fmt.Errorf("Unsupported sha algorithm %s", pcrBank)
 	}
@@ -527,6 +606,14 @@ func (pcrManifest *PcrManifest) GetPcrBanks() []SHAAlgorithm {
 	if len(pcrManifest.Sha384Pcrs) > 0 {
 		bankList = append(bankList, SHA384)
 	}
+	// check if each known digest algorithm is present and return
+	if len(pcrManifest.Sha512Pcrs) > 0 {
+		bankList = append(bankList, SHA512)
+	}
+	// check if each known digest algorithm is present and return
+	if len(pcrManifest.Sm3Pcrs) > 0 {
+		bankList = append(bankList, SM3_256)
+	}
 
 	return bankList
 }
@@ -548,6 +635,8 @@ func getHash(pcrBank SHAAlgorithm) hash.Hash {
 		hash = sha512.New384()
 	case SHA512:
 		hash = sha512.New()
+	case SM3_256:
+		hash = crypt.NewSM3()
 	}
 
 	return hash
@@ -566,6 +655,8 @@ func getCumulativeHash(pcrBank SHAAlgorithm) ([]byte, error) {
 		cumulativeHash = make([]byte, sha512.Size384)
 	case SHA512:
 		cumulativeHash = make([]byte, sha512.Size)
+	case SM3_256:
+		cumulativeHash = make([]byte, crypt.SM3Size)
 	default:
 		return nil, errors.Errorf("Invalid sha algorithm '%s'", pcrBank)
 	}
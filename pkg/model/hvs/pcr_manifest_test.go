@@ -0,0 +1,68 @@
+/*
+ *  Copyright (C) 2022 Intel Corporation
+ *  SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"strings"
+	"testing"
+
+	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTpmEventLogReplaySha512AndSm3(t *testing.T) {
+	tests := []struct {
+		bank    SHAAlgorithm
+		newHash func() hash.Hash
+		size    int
+	}{
+		{bank: SHA512, newHash: sha512.New, size: sha512.Size},
+		{bank: SM3_256, newHash: crypt.NewSM3, size: crypt.SM3Size},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.bank), func(t *testing.T) {
+			measurement := strings.Repeat("a5", tt.size)
+			eventLog := TpmEventLog{
+				Pcr: Pcr{
+					Index: 17,
+					Bank:  string(tt.bank),
+				},
+				TpmEvent: []EventLog{{TypeName: "EV_POST_CODE", Measurement: measurement}},
+			}
+
+			// PCR_new = H(PCR_old || measurement), starting from zeros
+			h := tt.newHash()
+			h.Write(make([]byte, tt.size))
+			measurementBytes, _ := hex.DecodeString(measurement)
+			h.Write(measurementBytes)
+
+			replayed, err := eventLog.Replay()
+			assert.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(h.Sum(nil)), replayed)
+
+			pcrManifest := PcrManifest{}
+			assert.True(t, pcrManifest.IsEmpty())
+			if tt.bank == SHA512 {
+				pcrManifest.Sha512Pcrs = []HostManifestPcrs{{Index: PCR17, Value: replayed, PcrBank: tt.bank}}
+				pcrManifest.PcrEventLogMap.Sha512EventLogs = []TpmEventLog{eventLog}
+			} else {
+				pcrManifest.Sm3Pcrs = []HostManifestPcrs{{Index: PCR17, Value: replayed, PcrBank: tt.bank}}
+				pcrManifest.PcrEventLogMap.Sm3EventLogs = []TpmEventLog{eventLog}
+			}
+			assert.False(t, pcrManifest.IsEmpty())
+			pcr, err := pcrManifest.GetPcrValue(tt.bank, PCR17)
+			assert.NoError(t, err)
+			assert.Equal(t, replayed, pcr.Value)
+			events, _, bank, err := pcrManifest.PcrEventLogMap.GetEventLogNew(string(tt.bank), 17)
+			assert.NoError(t, err)
+			assert.Equal(t, string(tt.bank), bank)
+			assert.Len(t, events, 1)
+		})
+	}
+}
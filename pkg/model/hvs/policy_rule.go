@@ -0,0 +1,51 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+// PolicyRule is a user defined rule of a flavorgroup that is evaluated on the host manifest of the hosts linked
+// to the flavorgroup, in addition to the rules of the flavors. The result of the rule is reported under Marker.
+type PolicyRule struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Marker      FlavorPartName   `json:"marker,omitempty"`
+	Expression  PolicyExpression `json:"expression"`
+}
+
+// PolicyRuleCollection is the request and response body of the replacement of the policy rules of a flavorgroup
+type PolicyRuleCollection struct {
+	PolicyRules []PolicyRule `json:"policy_rules"`
+}
+
+// PolicyExpression is either a condition on the value found at Path in the host manifest or a combination of
+// expressions with all_of, any_of or not. Path is a dot separated list of the JSON field names of the host
+// manifest, numeric segments index arrays, for example "host_info.hardware_features.TXT.enabled".
+type PolicyExpression struct {
+	AllOf    []PolicyExpression `json:"all_of,omitempty"`
+	AnyOf    []PolicyExpression `json:"any_of,omitempty"`
+	Not      *PolicyExpression  `json:"not,omitempty"`
+	Path     string             `json:"path,omitempty"`
+	Operator PolicyOperator     `json:"operator,omitempty"`
+	Value    interface{}        `json:"value,omitempty"`
+}
+
+type PolicyOperator string
+
+const (
+	PolicyOperatorEquals    PolicyOperator = "equals"
+	PolicyOperatorNotEquals PolicyOperator = "not_equals"
+	PolicyOperatorExists    PolicyOperator = "exists"
+	PolicyOperatorContains  PolicyOperator = "contains"
+	PolicyOperatorIn        PolicyOperator = "in"
+	PolicyOperatorMatches   PolicyOperator = "matches"
+	PolicyOperatorGt        PolicyOperator = "gt"
+	PolicyOperatorGte       PolicyOperator = "gte"
+	PolicyOperatorLt        PolicyOperator = "lt"
+	PolicyOperatorLte       PolicyOperator = "lte"
+)
+
+func (po PolicyOperator) String() string {
+	return string(po)
+}
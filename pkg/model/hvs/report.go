@@ -14,6 +14,7 @@ import (
 
 type ReportCollection struct {
 	Reports  []*Report `json:"reports" xml:"reports"`
+	Total    int       `json:"total,omitempty" xml:"total"`
 	Next     string    `json:"next,omitempty" xml:"next"`
 	Previous string    `json:"prev,omitempty" xml:"prev"`
 }
@@ -0,0 +1,50 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "github.com/google/uuid"
+
+// ReportDiff describes what changed in a report compared to the report it is compared against. The added rules are
+// only evaluated in the report, the removed rules only in the report it is compared against.
+type ReportDiff struct {
+	ReportId        uuid.UUID        `json:"report_id"`
+	HostId          uuid.UUID        `json:"host_id"`
+	Trusted         bool             `json:"trusted"`
+	AgainstReportId uuid.UUID        `json:"against_report_id"`
+	AgainstHostId   uuid.UUID        `json:"against_host_id"`
+	AgainstTrusted  bool             `json:"against_trusted"`
+	AddedRules      []RuleResult     `json:"added_rules,omitempty"`
+	RemovedRules    []RuleResult     `json:"removed_rules,omitempty"`
+	ChangedRules    []RuleResultDiff `json:"changed_rules,omitempty"`
+}
+
+// RuleResultDiff describes the changes of the result of a rule evaluated in both reports
+type RuleResultDiff struct {
+	Rule                  RuleInfo            `json:"rule"`
+	FlavorId              *uuid.UUID          `json:"flavor_id,omitempty"`
+	Trusted               bool                `json:"trusted"`
+	AgainstTrusted        bool                `json:"against_trusted"`
+	AddedFaults           []Fault             `json:"added_faults,omitempty"`
+	RemovedFaults         []Fault             `json:"removed_faults,omitempty"`
+	AddedMismatchFields   []MismatchField     `json:"added_mismatch_fields,omitempty"`
+	RemovedMismatchFields []MismatchField     `json:"removed_mismatch_fields,omitempty"`
+	ChangedMismatchFields []MismatchFieldDiff `json:"changed_mismatch_fields,omitempty"`
+}
+
+// MismatchFieldDiff describes the changes of the PCR or event log mismatch reported in both reports
+type MismatchFieldDiff struct {
+	Name                        string         `json:"name"`
+	PcrIndex                    *PcrIndex      `json:"pcr_index,omitempty"`
+	PcrBank                     *SHAAlgorithm  `json:"pcr_bank,omitempty"`
+	AddedMissingEntries         []EventLog     `json:"added_missing_entries,omitempty"`
+	RemovedMissingEntries       []EventLog     `json:"removed_missing_entries,omitempty"`
+	AddedUnexpectedEntries      []EventLog     `json:"added_unexpected_entries,omitempty"`
+	RemovedUnexpectedEntries    []EventLog     `json:"removed_unexpected_entries,omitempty"`
+	AddedUnexpectedImaEntries   []Measurements `json:"added_unexpected_ima_entries,omitempty"`
+	RemovedUnexpectedImaEntries []Measurements `json:"removed_unexpected_ima_entries,omitempty"`
+	AddedMismatchedImaEntries   []Measurements `json:"added_mismatched_ima_entries,omitempty"`
+	RemovedMismatchedImaEntries []Measurements `json:"removed_mismatched_ima_entries,omitempty"`
+}
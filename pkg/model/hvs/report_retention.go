@@ -0,0 +1,34 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import "time"
+
+// ReportRetentionStatus is the response of the report retention API, it describes the retention policy applied
+// to the report history and the result of the last purge
+type ReportRetentionStatus struct {
+	Enabled           bool   `json:"enabled"`
+	RunPeriod         string `json:"run_period"`
+	RetentionDays     int    `json:"retention_days"`
+	KeepLatestPerHost int    `json:"keep_latest_per_host"`
+	BatchSize         int    `json:"batch_size"`
+	Archive           bool   `json:"archive"`
+	// Running is set while a purge is in progress
+	Running bool                `json:"running"`
+	LastRun *ReportRetentionRun `json:"last_run,omitempty"`
+	NextRun *time.Time          `json:"next_run,omitempty"`
+}
+
+// ReportRetentionRun holds the statistics of a purge of the report history
+type ReportRetentionRun struct {
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	// CreatedBefore is the creation time before which the reports were eligible for the purge
+	CreatedBefore time.Time `json:"created_before"`
+	Batches       int       `json:"batches"`
+	Purged        int       `json:"purged"`
+	Error         string    `json:"error,omitempty"`
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package hvs
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportSummary describes a trust report for operators: the rule results are grouped by flavor part and the failing
+// rules are explained in plain sentences
+type ReportSummary struct {
+	// swagger:strfmt uuid
+	ReportId uuid.UUID `json:"report_id"`
+	// swagger:strfmt uuid
+	HostId       uuid.UUID           `json:"host_id"`
+	HostName     string              `json:"host_name"`
+	HardwareUUID string              `json:"hardware_uuid,omitempty"`
+	Created      time.Time           `json:"created"`
+	Expiration   time.Time           `json:"expiration"`
+	Trusted      bool                `json:"trusted"`
+	FlavorParts  []FlavorPartSummary `json:"flavor_parts"`
+}
+
+// FlavorPartSummary is the trust status of a flavor part and the reasons why it is not trusted
+type FlavorPartSummary struct {
+	FlavorPart FlavorPartName `json:"flavor_part"`
+	Trusted    bool           `json:"trusted"`
+	// Flavors the host was verified against, the flavor matches the host when all its rules are trusted
+	Flavors  []FlavorMatch `json:"flavors,omitempty"`
+	Failures []RuleFailure `json:"failures,omitempty"`
+}
+
+// FlavorMatch tells whether the host matches a flavor of the flavor part
+type FlavorMatch struct {
+	// swagger:strfmt uuid
+	FlavorId uuid.UUID `json:"flavor_id"`
+	Label    string    `json:"label,omitempty"`
+	Matched  bool      `json:"matched"`
+}
+
+// RuleFailure explains why a rule is not trusted
+type RuleFailure struct {
+	Rule string `json:"rule"`
+	// swagger:strfmt uuid
+	FlavorId *uuid.UUID `json:"flavor_id,omitempty"`
+	// One sentence per fault of the rule
+	Reasons         []string            `json:"reasons"`
+	EventLogEntries []EventLogReference `json:"event_log_entries,omitempty"`
+}
+
+// EventLogReference points to an event of the PCR event logs of the host manifest the report was created from
+type EventLogReference struct {
+	PcrIndex PcrIndex     `json:"pcr_index"`
+	PcrBank  SHAAlgorithm `json:"pcr_bank"`
+	// Position of the event in the event log of the PCR, not set when the event is expected but missing from the
+	// event log of the host
+	EventIndex *int     `json:"event_index,omitempty"`
+	TypeName   string   `json:"type_name,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	// Measurement of the event in the event log of the host or in the flavor when the event is missing
+	Measurement string `json:"measurement"`
+	// missing, unexpected or divergent
+	Status string `json:"status"`
+}
@@ -25,11 +25,15 @@ type TagCertificate struct {
 	// swagger:strfmt uuid
 	HardwareUUID  uuid.UUID `json:"hardware_uuid"`
 	TagCertDigest string    `json:"asset_tag_digest"`
+	RowId         int       `json:"-"`
 }
 
 // TagCertificateCollection is the response sent by the tag-certificate API
 type TagCertificateCollection struct {
 	TagCertificates []*TagCertificate `json:"certificates" xml:"certificates"`
+	Total           int               `json:"total,omitempty" xml:"total"`
+	Next            string            `json:"next,omitempty" xml:"next"`
+	Previous        string            `json:"prev,omitempty" xml:"prev"`
 }
 
 // SetAssetTagDigest computes the hash of the Asset Tag certificate
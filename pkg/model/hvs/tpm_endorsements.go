@@ -23,6 +23,7 @@ type TpmEndorsement struct {
 
 type TpmEndorsementCollection struct {
 	TpmEndorsement []*TpmEndorsement `json:"tpmendorsements"`
+	Total          int               `json:"total,omitempty"`
 	Next           string            `json:"next,omitempty"`
 	Previous       string            `json:"prev,omitempty"`
 }
@@ -36,14 +36,30 @@ type AdditionalInfo struct {
 }
 
 type MismatchField struct {
-	Name                 string         `json:"name"`
-	Description          string         `json:"description"`
-	PcrIndex             *PcrIndex      `json:"pcr_index,omitempty"`
-	PcrBank              *SHAAlgorithm  `json:"pcr_bank,omitempty"`
-	MissingEntries       []EventLog     `json:"missing_entries,omitempty"`
-	UnexpectedEntries    []EventLog     `json:"unexpected_entries,omitempty"`
-	UnexpectedImaEntries []Measurements `json:"unexpected_ima_entries,omitempty"`
-	MismatchedImaEntries []Measurements `json:"mismatched_ima_entries,omitempty"`
+	Name                 string          `json:"name"`
+	Description          string          `json:"description"`
+	PcrIndex             *PcrIndex       `json:"pcr_index,omitempty"`
+	PcrBank              *SHAAlgorithm   `json:"pcr_bank,omitempty"`
+	MissingEntries       []EventLog      `json:"missing_entries,omitempty"`
+	UnexpectedEntries    []EventLog      `json:"unexpected_entries,omitempty"`
+	UnexpectedImaEntries []Measurements  `json:"unexpected_ima_entries,omitempty"`
+	MismatchedImaEntries []Measurements  `json:"mismatched_ima_entries,omitempty"`
+	DivergentEvent       *DivergentEvent `json:"divergent_event,omitempty"`
+}
+
+// DivergentEvent is the first event of a PCR event log that differs from the events expected by the flavor
+type DivergentEvent struct {
+	// EventIndex is the position of the event in the event log of the host, when the expected event is missing
+	// it is the number of events in the event log
+	EventIndex          int      `json:"event_index"`
+	TypeID              string   `json:"type_id,omitempty"`
+	TypeName            string   `json:"type_name,omitempty"`
+	Tags                []string `json:"tags,omitempty"`
+	ExpectedMeasurement string   `json:"expected_measurement,omitempty"`
+	ActualMeasurement   string   `json:"actual_measurement,omitempty"`
+	// ReplayedPcrValue is the value of the PCR after the event log has been replayed up to and including the
+	// divergent event
+	ReplayedPcrValue string `json:"replayed_pcr_value,omitempty"`
 }
 
 type RuleInfo struct {
@@ -62,6 +78,8 @@ type RuleInfo struct {
 	ExpectedTag              []byte                 `json:"expected_tag,omitempty"`
 	Tags                     map[string]string      `json:"tags,omitempty"`
 	ExpectedImaLogEntry      *Ima                   `json:"expected_imavalues,omitempty"`
+	FlavorGroupID            *uuid.UUID             `json:"flavorgroup_id,omitempty"`
+	PolicyRule               *PolicyRule            `json:"policy_rule,omitempty"`
 }
 
 type Fault struct {
@@ -150,6 +168,25 @@ func (t *TrustReport) CheckResultExists(targetRuleResult RuleResult) bool {
 				} else {
 					continue
 				}
+			case constants.RulePcrEventLogReplay:
+				// The replay diagnostic is reported once per PCR bank and index
+				if targetRuleResult.Rule.ExpectedPcrEventLogEntry == nil || ruleResult.Rule.ExpectedPcrEventLogEntry == nil {
+					return false
+				} else if targetRuleResult.Rule.ExpectedPcrEventLogEntry.Pcr == ruleResult.Rule.ExpectedPcrEventLogEntry.Pcr {
+					return true
+				} else {
+					continue
+				}
+			case constants.RulePolicyExpressionMatches:
+				// A flavorgroup can define several policy rules, they are identified by the flavorgroup and their name
+				if targetRuleResult.Rule.PolicyRule == nil || ruleResult.Rule.PolicyRule == nil {
+					return false
+				} else if targetRuleResult.Rule.PolicyRule.Name == ruleResult.Rule.PolicyRule.Name &&
+					uuidPtrEquals(targetRuleResult.Rule.FlavorGroupID, ruleResult.Rule.FlavorGroupID) {
+					return true
+				} else {
+					continue
+				}
 			default:
 				if len(targetRuleResult.Faults) > 0 {
 					return false
@@ -182,6 +219,13 @@ func find(slice []FlavorPartName, val string) bool {
 	return false
 }
 
+func uuidPtrEquals(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func (r *RuleResult) equals(target RuleResult) bool {
 	if target.Rule.Name == r.Rule.Name {
 		return true
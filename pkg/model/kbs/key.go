@@ -39,6 +39,18 @@ type KeyResponse struct {
 	CreatedAt        time.Time `json:"created_at"`
 	Label            string    `json:"label,omitempty"`
 	Usage            string    `json:"usage,omitempty"`
+	// Version is the version of the key material returned by a transfer without version
+	Version int `json:"version,omitempty"`
+	// Versions is the rotation history of the key, it is only set once the key is rotated
+	Versions []KeyVersion `json:"versions,omitempty"`
+}
+
+// KeyVersion - Version of a rotated key, the current version has no rotated_at.
+type KeyVersion struct {
+	Version   int        `json:"version"`
+	KmipKeyID string     `json:"kmip_key_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
 }
 
 // KeyTransferAttributes - Contains all possible key transfer attributes.
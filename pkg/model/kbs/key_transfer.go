@@ -5,6 +5,12 @@
 
 package kbs
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type KeyTransferResponse struct {
 	WrappedKey string `json:"wrapped_key"`
 	WrappedSWK string `json:"wrapped_swk,omitempty"`
@@ -15,3 +21,32 @@ type SKCKeyTransferResponse struct {
 	Operation string                `json:"operation"`
 	Status    string                `json:"status"`
 }
+
+// KeyTransferRecord is the audit record of a key transfer attempt
+type KeyTransferRecord struct {
+	// swagger:strfmt uuid
+	ID uuid.UUID `json:"id"`
+	// swagger:strfmt uuid
+	KeyId      uuid.UUID `json:"key_id"`
+	KeyVersion int       `json:"key_version,omitempty"`
+	// TransferType is envelope, saml or skc
+	TransferType string `json:"transfer_type"`
+	// Requester is the subject of the bearer token, the host name of the SAML report or the common name of the client certificate
+	Requester string `json:"requester,omitempty"`
+	// RequesterVerified is false when the requester is not authenticated, e.g. the host name of a SAML report that is
+	// not verified
+	RequesterVerified bool   `json:"requester_verified"`
+	RemoteAddress     string `json:"remote_address,omitempty"`
+	AttestationType   string `json:"attestation_type,omitempty"`
+	// EvidenceHash is the hex encoded SHA-384 digest of the SAML report or of the SGX quote of the session
+	EvidenceHash string `json:"evidence_hash,omitempty"`
+	// swagger:strfmt uuid
+	TransferPolicyId uuid.UUID `json:"transfer_policy_id,omitempty"`
+	// Decision is allowed or denied
+	Decision    string `json:"decision"`
+	FaultReason string `json:"fault_reason,omitempty"`
+	// Attempts is the number of denied unverified transfers from the remote address aggregated in the record, the
+	// record is the last of these transfers
+	Attempts  int       `json:"attempts,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -70,3 +70,58 @@ type KeyTransferPolicyAttributes struct {
 	AttestationTypeAnyof              []string  `json:"attestation_type_anyof,omitempty"`
 	SGXEnforceTCBUptoDate             bool      `json:"sgx_enforce_tcb_up_to_date,omitempty"`
 }
+
+// KeyTransferPolicyEvaluationRequest - sample attestation claims evaluated against a key transfer policy, no key is released.
+type KeyTransferPolicyEvaluationRequest struct {
+	AttestationType aps.AttestationType `json:"attestation_type,omitempty"`
+	CertIssuer      string              `json:"cert_issuer,omitempty"`
+	SGX             *SgxClaims          `json:"sgx,omitempty"`
+	TDX             *TdxClaims          `json:"tdx,omitempty"`
+	Saml            string              `json:"saml,omitempty"`
+	// swagger:strfmt uuid
+	KeyId uuid.UUID `json:"key_id,omitempty"`
+}
+
+type SgxClaims struct {
+	MrSigner     string  `json:"mrsigner,omitempty"`
+	IsvProductId *uint16 `json:"isvprodid,omitempty"`
+	MrEnclave    string  `json:"mrenclave,omitempty"`
+	IsvSvn       *uint16 `json:"isvsvn,omitempty"`
+	TCBLevel     string  `json:"tcb_level,omitempty"`
+}
+
+type TdxClaims struct {
+	MrSignerSeam string `json:"mrsignerseam,omitempty"`
+	MrSeam       string `json:"mrseam,omitempty"`
+	SeamSvn      *uint8 `json:"seamsvn,omitempty"`
+	MRTD         string `json:"mrtd,omitempty"`
+	RTMR0        string `json:"rtmr0,omitempty"`
+	RTMR1        string `json:"rtmr1,omitempty"`
+	RTMR2        string `json:"rtmr2,omitempty"`
+	RTMR3        string `json:"rtmr3,omitempty"`
+	TCBLevel     string `json:"tcb_level,omitempty"`
+}
+
+// KeyTransferPolicyEvaluation - result of evaluating sample attestation claims against a key transfer policy.
+type KeyTransferPolicyEvaluation struct {
+	// swagger:strfmt uuid
+	PolicyId   uuid.UUID                   `json:"policy_id"`
+	Decision   string                      `json:"decision"`
+	Attributes []PolicyAttributeEvaluation `json:"attributes"`
+}
+
+// PolicyAttributeEvaluation - outcome of a single attribute check, result is one of matched, mismatched or missing.
+type PolicyAttributeEvaluation struct {
+	Name     string `json:"name"`
+	Result   string `json:"result"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// KeyTransferPolicyUsage - keys associated with a key transfer policy.
+type KeyTransferPolicyUsage struct {
+	// swagger:strfmt uuid
+	PolicyId uuid.UUID `json:"policy_id"`
+	// swagger:strfmt uuid
+	KeyIds []uuid.UUID `json:"key_ids"`
+}
@@ -13,6 +13,7 @@ type KeyTransferSession struct {
 	SessionId         string `json:"sessionid"`
 	ClientCertHash    string `json:"clientcerthash"`
 	Stmlabel          string `json:"stmlabel"`
+	QuoteHash         string `json:"quotehash,omitempty"`
 	SessionExpiryTime time.Time
 }
 
@@ -16,6 +16,7 @@ const (
 	NatsApplicationMeasurementRequest = "application-measurement-request"
 	NatsVersionRequest                = "version-request"
 	NatsSendImaFileList               = "send-ima-filelist-request"
+	NatsQuarantineNotice              = "quarantine-notice"
 )
 
 func CreateSubject(id, request string) string {
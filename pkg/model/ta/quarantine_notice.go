@@ -0,0 +1,16 @@
+/*
+ * Copyright (C) 2022 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+package model
+
+// QuarantineNotice is published by VS to the Trust Agent when its host is quarantined after consecutive untrusted
+// reports, or released
+// {
+//     "quarantined" : true,
+//     "report_id"   : "0a6b0a1a-d1ee-4c46-8a83-5b1c3e1c9e2d"
+// }
+type QuarantineNotice struct {
+	Quarantined bool   `json:"quarantined"`
+	ReportId    string `json:"report_id,omitempty"`
+}
@@ -59,20 +59,24 @@ func CreateTpmQuoteResponse(cfg *config.TrustAgentConfiguration, tpm tpmprovider
 
 	// ISECL-12121: strip inactive PCR Banks from the request
 	if len(tpmQuoteRequest.PcrBanks) == 0 {
-		tpmQuoteRequest.PcrBanks = []string{string(constants.SHA384), string(constants.SHA256), string(constants.SHA1)}
+		tpmQuoteRequest.PcrBanks = []string{string(constants.SHA384), string(constants.SHA256), string(constants.SHA1),
+			string(constants.SHA512), string(constants.SM3_256)}
 	}
 
-	for i, pcrBank := range tpmQuoteRequest.PcrBanks {
+	var activePcrBanks []string
+	for _, pcrBank := range tpmQuoteRequest.PcrBanks {
 		isActive, err := tpm.IsPcrBankActive(pcrBank)
 		if !isActive {
 			log.WithError(err).Debugf("common/quote:CreateTpmQuoteResponse() %s PCR bank is inactive. Dropping from quote request",
 				pcrBank)
-			tpmQuoteRequest.PcrBanks = append(tpmQuoteRequest.PcrBanks[:i], tpmQuoteRequest.PcrBanks[i+1:]...)
+			continue
 		} else if err != nil {
 			log.WithError(err).Warnf("common/quote:CreateTpmQuoteResponse() Error while determining PCR bank "+
 				"%s state: %s", pcrBank, err.Error())
 		}
+		activePcrBanks = append(activePcrBanks, pcrBank)
 	}
+	tpmQuoteRequest.PcrBanks = activePcrBanks
 
 	tpmQuoteResponse, err := createTpmQuote(cfg.ImaMeasureEnabled, cfg.Tpm.TagSecretKey, tpm, tpmQuoteRequest, aikCertPath, measureLogFilePath, ramfsDir)
 	if err != nil {
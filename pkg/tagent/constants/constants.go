@@ -113,6 +113,7 @@ const (
 	SHA256  SHAAlgorithm = "SHA256"
 	SHA384  SHAAlgorithm = "SHA384"
 	SHA512  SHAAlgorithm = "SHA512"
+	SM3_256 SHAAlgorithm = "SM3_256"
 	UNKNOWN SHAAlgorithm = "unknown"
 )
 
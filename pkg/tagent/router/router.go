@@ -15,6 +15,7 @@ import (
 
 	"github.com/gorilla/mux"
 	commLog "github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/middleware"
 )
 
@@ -43,6 +44,7 @@ func InitRoutes(trustedJWTSigningCertsDir, trustedCaCertsDir string, requestHand
 	router := mux.NewRouter()
 	// ISECL-8715 - Prevent potential open redirects to external URLs
 	router.SkipClean(true)
+	router.Use(commMetrics.RequestMetrics())
 	defineSubRoutes(router, trustedJWTSigningCertsDir, trustedCaCertsDir, requestHandler)
 	return router
 }
@@ -54,6 +56,7 @@ func defineSubRoutes(router *mux.Router, trustedJWTSigningCertsDir, trustedCaCer
 	serviceApi := "/" + constants.ApiVersion
 	subRouter := router.PathPrefix(serviceApi).Subrouter()
 	subRouter = setVersionRoutes(subRouter)
+	subRouter = commMetrics.SetMetricsRoutes(subRouter)
 
 	subRouter = router.PathPrefix(serviceApi).Subrouter()
 	subRouter.Use(middleware.NewTokenAuth(trustedJWTSigningCertsDir, trustedCaCertsDir, fnGetJwtCerts, cacheTime))
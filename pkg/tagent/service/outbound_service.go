@@ -234,6 +234,21 @@ func (subscriber *trustAgentOutboundService) Start() error {
 		return errors.Wrapf(err, "NATs client failed to create subscription to version messages")
 	}
 
+	// subscribe to quarantine notices published by VS when the host is quarantined or released
+	quarantineSubject := taModel.CreateSubject(subscriber.natsParameters.HostID, taModel.NatsQuarantineNotice)
+	_, err = subscriber.natsConnection.Subscribe(quarantineSubject, func(notice *taModel.QuarantineNotice) {
+		defer recoverFunc()
+
+		if notice.Quarantined {
+			secLog.Warnf("Host %q has been quarantined by VS after untrusted report %q", subscriber.natsParameters.HostID, notice.ReportId)
+		} else {
+			secLog.Infof("Host %q has been released from quarantine by VS", subscriber.natsParameters.HostID)
+		}
+	})
+	if err != nil {
+		return errors.Wrapf(err, "NATs client failed to create subscription to quarantine-notice messages")
+	}
+
 	if conn.IsConnected() {
 		log.Infof("Outbound Trust-Agent %q connected to %q", subscriber.natsParameters.HostID, subscriber.natsConnection.Conn.ConnectedAddr())
 	}
@@ -15,6 +15,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/crypt"
 	"github.com/intel-secl/intel-secl/v5/pkg/lib/common/log"
+	commMetrics "github.com/intel-secl/intel-secl/v5/pkg/lib/common/metrics"
 	cmw "github.com/intel-secl/intel-secl/v5/pkg/lib/common/middleware"
 	cos "github.com/intel-secl/intel-secl/v5/pkg/lib/common/os"
 	"github.com/intel-secl/intel-secl/v5/pkg/wls/config"
@@ -38,6 +39,7 @@ func InitRoutes(cfg *config.Configuration, certStore *crypt.CertificatesStore) (
 	router := mux.NewRouter()
 
 	router.SkipClean(true)
+	router.Use(commMetrics.RequestMetrics())
 	err := defineSubRoutes(router, strings.ToLower(constants.ServiceName), cfg, certStore)
 	if err != nil {
 		return nil, errors.Wrap(err, "Could not define sub routes")
@@ -53,6 +55,7 @@ func defineSubRoutes(router *mux.Router, service string, cfg *config.Configurati
 	serviceApi := "/" + service + "/v{version:[1-2]}/"
 	subRouter := router.PathPrefix(serviceApi).Subrouter()
 	subRouter = SetVersionRoutes(subRouter)
+	subRouter = commMetrics.SetMetricsRoutes(subRouter)
 
 	cfgRouter := Router{cfg: cfg}
 	var cacheTime, err = time.ParseDuration(constants.JWTCertsCacheTime)